@@ -280,6 +280,7 @@ func TestMrSystemDatabase(t *testing.T) {
 				{"TABLE system.public.locations"},
 				{"TABLE system.public.migrations"},
 				{"TABLE system.public.mvcc_statistics"},
+				{"TABLE system.public.plan_baselines"},
 				{"TABLE system.public.protected_ts_meta"},
 				{"TABLE system.public.protected_ts_records"},
 				{"TABLE system.public.rangelog"},
@@ -321,6 +322,7 @@ func TestMrSystemDatabase(t *testing.T) {
 				{"TABLE system.public.locations"},
 				{"TABLE system.public.migrations"},
 				{"TABLE system.public.mvcc_statistics"},
+				{"TABLE system.public.plan_baselines"},
 				{"TABLE system.public.protected_ts_meta"},
 				{"TABLE system.public.protected_ts_records"},
 				{"TABLE system.public.rangelog"},
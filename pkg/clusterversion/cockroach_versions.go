@@ -251,6 +251,14 @@ const (
 	// system.statement_diagnostics_requests table.
 	V24_2_StmtDiagRedacted
 
+	// V24_2_PlanBaselinesTable is the migration which adds the
+	// system.plan_baselines table.
+	V24_2_PlanBaselinesTable
+
+	// V24_2_TableStatisticsExpression is the migration which adds the
+	// expression column to the system.table_statistics table.
+	V24_2_TableStatisticsExpression
+
 	// *************************************************
 	// Step (1) Add new versions above this comment.
 	// Do not add new versions to a patch release.
@@ -310,7 +318,9 @@ var versionTable = [numKeys]roachpb.Version{
 	// v24.2 versions. Internal versions must be even.
 	V24_2Start: {Major: 24, Minor: 1, Internal: 2},
 
-	V24_2_StmtDiagRedacted: {Major: 24, Minor: 1, Internal: 4},
+	V24_2_StmtDiagRedacted:          {Major: 24, Minor: 1, Internal: 4},
+	V24_2_PlanBaselinesTable:        {Major: 24, Minor: 1, Internal: 6},
+	V24_2_TableStatisticsExpression: {Major: 24, Minor: 1, Internal: 8},
 
 	// *************************************************
 	// Step (2): Add new versions above this comment.
@@ -258,6 +258,26 @@ func ParseGeometryFromGeoJSON(json []byte) (Geometry, error) {
 	return MakeGeometry(g)
 }
 
+// ParseGeometryFromGML parses the GML into a Geometry, taking the SRID from
+// the srsName of the GML.
+func ParseGeometryFromGML(gml []byte) (Geometry, error) {
+	g, err := parseGML(geopb.SpatialObjectType_GeometryType, gml, geopb.DefaultGeometrySRID, DefaultSRIDIsHint)
+	if err != nil {
+		return Geometry{}, err
+	}
+	return MakeGeometry(g)
+}
+
+// ParseGeometryFromGMLAndSRID parses the GML into a Geometry with the given
+// SRID set, ignoring the srsName of the GML.
+func ParseGeometryFromGMLAndSRID(gml []byte, srid geopb.SRID) (Geometry, error) {
+	g, err := parseGML(geopb.SpatialObjectType_GeometryType, gml, srid, DefaultSRIDShouldOverwrite)
+	if err != nil {
+		return Geometry{}, err
+	}
+	return MakeGeometry(g)
+}
+
 // ParseGeometryFromEWKBUnsafe returns a new Geometry from an EWKB, without any SRID checks.
 // You should only do this if you trust the EWKB is setup correctly.
 // You most likely want geo.ParseGeometryFromEWKB instead.
@@ -0,0 +1,150 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+	"github.com/twpayne/go-geom"
+)
+
+// maxChaikinIterations is the maximum number of smoothing iterations allowed,
+// as each iteration doubles the number of points.
+const maxChaikinIterations = 5
+
+// ChaikinSmoothing smooths the given Geometry using Chaikin's algorithm,
+// which replaces each segment with points a quarter and three quarters
+// along it. Each iteration doubles the number of points. If
+// preserveEndPoints is set, the end points of LineStrings are kept in place.
+// Polygon rings are always kept closed. Points are returned as is.
+func ChaikinSmoothing(
+	g geo.Geometry, numIterations int, preserveEndPoints bool,
+) (geo.Geometry, error) {
+	if numIterations < 1 || numIterations > maxChaikinIterations {
+		return geo.Geometry{}, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"number of iterations must be between 1 and %d",
+			maxChaikinIterations,
+		)
+	}
+	if g.Empty() {
+		return g, nil
+	}
+	t, err := g.AsGeomT()
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	for i := 0; i < numIterations; i++ {
+		if t, err = chaikinSmoothing(t, preserveEndPoints); err != nil {
+			return geo.Geometry{}, err
+		}
+	}
+	return geo.MakeGeometryFromGeomT(t)
+}
+
+func chaikinSmoothing(t geom.T, preserveEndPoints bool) (geom.T, error) {
+	if t.Empty() {
+		return t, nil
+	}
+	switch t := t.(type) {
+	case *geom.Point, *geom.MultiPoint:
+		return t, nil
+	case *geom.LineString:
+		return geom.NewLineStringFlat(
+			t.Layout(),
+			chaikinSmoothFlatCoords(t.FlatCoords(), t.Layout(), preserveEndPoints, false /* closed */),
+		).SetSRID(t.SRID()), nil
+	case *geom.Polygon:
+		ret := geom.NewPolygon(t.Layout()).SetSRID(t.SRID())
+		for i := 0; i < t.NumLinearRings(); i++ {
+			ring := t.LinearRing(i)
+			if err := ret.Push(geom.NewLinearRingFlat(
+				t.Layout(),
+				chaikinSmoothFlatCoords(ring.FlatCoords(), t.Layout(), preserveEndPoints, true /* closed */),
+			)); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	case *geom.MultiLineString:
+		ret := geom.NewMultiLineString(t.Layout()).SetSRID(t.SRID())
+		for i := 0; i < t.NumLineStrings(); i++ {
+			ls, err := chaikinSmoothing(t.LineString(i), preserveEndPoints)
+			if err != nil {
+				return nil, err
+			}
+			if err := ret.Push(ls.(*geom.LineString)); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	case *geom.MultiPolygon:
+		ret := geom.NewMultiPolygon(t.Layout()).SetSRID(t.SRID())
+		for i := 0; i < t.NumPolygons(); i++ {
+			p, err := chaikinSmoothing(t.Polygon(i), preserveEndPoints)
+			if err != nil {
+				return nil, err
+			}
+			if err := ret.Push(p.(*geom.Polygon)); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	case *geom.GeometryCollection:
+		ret := geom.NewGeometryCollection().SetSRID(t.SRID())
+		for _, subT := range t.Geoms() {
+			smoothed, err := chaikinSmoothing(subT, preserveEndPoints)
+			if err != nil {
+				return nil, err
+			}
+			if err := ret.Push(smoothed); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	default:
+		return nil, errors.AssertionFailedf("unknown geometry type: %T", t)
+	}
+}
+
+// chaikinSmoothFlatCoords applies one iteration of Chaikin's algorithm to the
+// given coordinates, interpolating every ordinate.
+func chaikinSmoothFlatCoords(
+	flatCoords []float64, layout geom.Layout, preserveEndPoints bool, closed bool,
+) []float64 {
+	stride := layout.Stride()
+	numCoords := len(flatCoords) / stride
+	if numCoords < 3 {
+		return flatCoords
+	}
+	ret := make([]float64, 0, (numCoords*2+1)*stride)
+	if preserveEndPoints {
+		ret = append(ret, flatCoords[:stride]...)
+	}
+	for i := 1; i < numCoords; i++ {
+		prev := flatCoords[(i-1)*stride : i*stride]
+		curr := flatCoords[i*stride : (i+1)*stride]
+		for j := 0; j < stride; j++ {
+			ret = append(ret, prev[j]*0.75+curr[j]*0.25)
+		}
+		for j := 0; j < stride; j++ {
+			ret = append(ret, prev[j]*0.25+curr[j]*0.75)
+		}
+	}
+	if preserveEndPoints {
+		ret = append(ret, flatCoords[len(flatCoords)-stride:]...)
+	} else if closed {
+		ret = append(ret, ret[:stride]...)
+	}
+	return ret
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaikinSmoothing(t *testing.T) {
+	testCases := []struct {
+		wkt               string
+		numIterations     int
+		preserveEndPoints bool
+		expected          string
+	}{
+		{"POINT(1 2)", 1, false, "POINT(1 2)"},
+		{"LINESTRING(0 0, 8 8, 0 16)", 1, false, "LINESTRING(2 2, 6 6, 6 10, 2 14)"},
+		{"LINESTRING(0 0, 8 8, 0 16)", 1, true, "LINESTRING(0 0, 2 2, 6 6, 6 10, 2 14, 0 16)"},
+		{
+			"LINESTRING(0 0, 8 8, 0 16)",
+			2,
+			true,
+			"LINESTRING(0 0, 0.5 0.5, 1.5 1.5, 3 3, 5 5, 6 7, 6 9, 5 11, 3 13, 1.5 14.5, 0.5 15.5, 0 16)",
+		},
+		{"LINESTRING(0 0, 8 8)", 1, false, "LINESTRING(0 0, 8 8)"},
+		{"LINESTRING M (0 0 0, 8 8 8, 0 16 16)", 1, false, "LINESTRING M (2 2 2, 6 6 6, 6 10 10, 2 14 14)"},
+		{
+			"POLYGON((0 0, 8 0, 8 8, 0 8, 0 0))",
+			1,
+			false,
+			"POLYGON((2 0, 6 0, 8 2, 8 6, 6 8, 2 8, 0 6, 0 2, 2 0))",
+		},
+		{
+			"MULTILINESTRING((0 0, 8 8, 0 16), EMPTY)",
+			1,
+			false,
+			"MULTILINESTRING((2 2, 6 6, 6 10, 2 14), EMPTY)",
+		},
+		{
+			"GEOMETRYCOLLECTION(POINT(1 2), LINESTRING(0 0, 8 8, 0 16))",
+			1,
+			false,
+			"GEOMETRYCOLLECTION(POINT(1 2), LINESTRING(2 2, 6 6, 6 10, 2 14))",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s/%d/%t", tc.wkt, tc.numIterations, tc.preserveEndPoints), func(t *testing.T) {
+			g, err := geo.ParseGeometry(tc.wkt)
+			require.NoError(t, err)
+			ret, err := ChaikinSmoothing(g, tc.numIterations, tc.preserveEndPoints)
+			require.NoError(t, err)
+			expected, err := geo.ParseGeometry(tc.expected)
+			require.NoError(t, err)
+			require.Equal(t, expected, ret)
+		})
+	}
+
+	t.Run("invalid number of iterations", func(t *testing.T) {
+		for _, numIterations := range []int{0, 6} {
+			_, err := ChaikinSmoothing(geo.MustParseGeometry("LINESTRING(0 0, 8 8, 0 16)"), numIterations, false)
+			require.EqualError(t, err, "number of iterations must be between 1 and 5")
+		}
+	})
+}
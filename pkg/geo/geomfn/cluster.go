@@ -0,0 +1,415 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"context"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+)
+
+// NoCluster is the cluster id assigned to geometries that do not belong to
+// any cluster, e.g. noise points in DBSCAN or EMPTY geometries in k-means.
+const NoCluster = -1
+
+// kMeansMaxIterations bounds the number of iterations of Lloyd's algorithm
+// performed by ClusterKMeans. It matches the limit used by PostGIS.
+const kMeansMaxIterations = 1000
+
+// ClusterDBSCAN assigns cluster ids to the given geometries using the 2D
+// DBSCAN algorithm. Two geometries are neighbors if the Cartesian distance
+// between them is at most eps; a geometry with at least minPoints neighbors
+// (including itself) is a core geometry. Geometries that are neither core
+// geometries nor within eps of one are assigned NoCluster. Cluster ids are
+// numbered from 0 in the order in which their first geometry appears.
+func ClusterDBSCAN(
+	ctx context.Context, gs []geo.Geometry, eps float64, minPoints int,
+) ([]int, error) {
+	if eps < 0 || math.IsNaN(eps) {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "eps must be a non-negative number")
+	}
+	if err := checkClusterSRIDs(gs); err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(gs))
+	for i := range ids {
+		ids[i] = NoCluster
+	}
+	visited := make([]bool, len(gs))
+	// queued records the geometries that were added to the queue of a cluster,
+	// so that each geometry is queued at most once and the queue never holds
+	// more than len(gs) geometries.
+	queued := make([]bool, len(gs))
+	var queue []int
+	neighbors := func(i int) ([]int, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var ret []int
+		for j := range gs {
+			within, err := DWithin(gs[i], gs[j], eps, geo.FnInclusive)
+			if err != nil {
+				return nil, err
+			}
+			if within {
+				ret = append(ret, j)
+			}
+		}
+		return ret, nil
+	}
+
+	nextID := 0
+	// expand adds the neighbors of a core geometry to the current cluster, and
+	// queues those that haven't been visited yet.
+	expand := func(nb []int) {
+		for _, j := range nb {
+			if ids[j] == NoCluster {
+				ids[j] = nextID
+			}
+			if !visited[j] && !queued[j] {
+				queued[j] = true
+				queue = append(queue, j)
+			}
+		}
+	}
+	for i := range gs {
+		if visited[i] {
+			continue
+		}
+		visited[i] = true
+		nb, err := neighbors(i)
+		if err != nil {
+			return nil, err
+		}
+		if len(nb) < minPoints || len(nb) == 0 {
+			// Noise for now; the geometry may still be claimed as a border
+			// geometry of a cluster discovered later.
+			continue
+		}
+		ids[i] = nextID
+		queue = queue[:0]
+		expand(nb)
+		for k := 0; k < len(queue); k++ {
+			j := queue[k]
+			visited[j] = true
+			nb, err := neighbors(j)
+			if err != nil {
+				return nil, err
+			}
+			if len(nb) >= minPoints {
+				expand(nb)
+			}
+		}
+		nextID++
+	}
+	return ids, nil
+}
+
+// ClusterKMeans assigns each of the given geometries to one of k clusters
+// using k-means on the geometries' centroids. If maxRadius is finite, the
+// number of clusters is increased until no geometry is farther than maxRadius
+// from the center of its cluster. That number is found with a binary search,
+// which assumes that clusters only get smaller as their number grows, so that
+// k-means runs a logarithmic number of times. EMPTY geometries are assigned
+// NoCluster. If there are fewer non-empty geometries than k, every geometry is
+// placed in its own cluster. Cluster ids are numbered from 0 in the order in
+// which their first geometry appears.
+func ClusterKMeans(
+	ctx context.Context, gs []geo.Geometry, k int, maxRadius float64,
+) ([]int, error) {
+	if k <= 0 {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "number of clusters must be greater than zero")
+	}
+	if maxRadius < 0 || math.IsNaN(maxRadius) {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "max_radius must be a non-negative number")
+	}
+	if err := checkClusterSRIDs(gs); err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(gs))
+	// idxs maps each entry of points back to the geometry it came from.
+	var points []geom.Coord
+	var idxs []int
+	for i, g := range gs {
+		ids[i] = NoCluster
+		if g.Empty() {
+			continue
+		}
+		c, err := clusterCentroid(g)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, c)
+		idxs = append(idxs, i)
+	}
+	if len(points) == 0 {
+		return ids, nil
+	}
+	if k > len(points) {
+		k = len(points)
+	}
+
+	assignment, centers, err := kMeans(ctx, points, k)
+	if err != nil {
+		return nil, err
+	}
+	if !withinRadius(points, assignment, centers, maxRadius) {
+		// With as many clusters as points, every point is the center of its
+		// own cluster, so the smallest sufficient number of clusters is between
+		// k+1 and len(points).
+		lo, hi := k+1, len(points)
+		for lo < hi {
+			mid := lo + (hi-lo)/2
+			a, c, err := kMeans(ctx, points, mid)
+			if err != nil {
+				return nil, err
+			}
+			if withinRadius(points, a, c, maxRadius) {
+				hi = mid
+			} else {
+				lo = mid + 1
+			}
+		}
+		k = lo
+		if assignment, _, err = kMeans(ctx, points, k); err != nil {
+			return nil, err
+		}
+	}
+
+	// Renumber the clusters so that ids are stable for a given input order.
+	renumber := make(map[int]int, k)
+	for i, c := range assignment {
+		id, ok := renumber[c]
+		if !ok {
+			id = len(renumber)
+			renumber[c] = id
+		}
+		ids[idxs[i]] = id
+	}
+	return ids, nil
+}
+
+// ClusterWithinIDs assigns cluster ids to the given geometries such that two
+// geometries are in the same cluster if they are connected by a chain of
+// geometries, each within distance d of the next. Cluster ids are numbered
+// from 0 in the order in which their first geometry appears.
+func ClusterWithinIDs(gs []geo.Geometry, d float64) ([]int, error) {
+	if d < 0 || math.IsNaN(d) {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "distance must be a non-negative number")
+	}
+	if err := checkClusterSRIDs(gs); err != nil {
+		return nil, err
+	}
+	parent := make([]int, len(gs))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range gs {
+		for j := i + 1; j < len(gs); j++ {
+			if find(i) == find(j) {
+				continue
+			}
+			within, err := DWithin(gs[i], gs[j], d, geo.FnInclusive)
+			if err != nil {
+				return nil, err
+			}
+			if within {
+				// Always keep the smaller index as the root so that the root of
+				// each set is its first geometry.
+				ri, rj := find(i), find(j)
+				if ri < rj {
+					parent[rj] = ri
+				} else {
+					parent[ri] = rj
+				}
+			}
+		}
+	}
+	ids := make([]int, len(gs))
+	renumber := make(map[int]int)
+	for i := range gs {
+		root := find(i)
+		id, ok := renumber[root]
+		if !ok {
+			id = len(renumber)
+			renumber[root] = id
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// ClusterWithin groups the given geometries into GeometryCollections as
+// described by ClusterWithinIDs. The collections are returned in cluster id
+// order, and the geometries within each collection retain their input order.
+func ClusterWithin(gs []geo.Geometry, d float64) ([]geo.Geometry, error) {
+	ids, err := ClusterWithinIDs(gs, d)
+	if err != nil {
+		return nil, err
+	}
+	var colls []*geom.GeometryCollection
+	for i, g := range gs {
+		t, err := g.AsGeomT()
+		if err != nil {
+			return nil, err
+		}
+		if ids[i] == len(colls) {
+			colls = append(colls, geom.NewGeometryCollection().SetSRID(t.SRID()))
+		}
+		if err := colls[ids[i]].Push(t); err != nil {
+			return nil, err
+		}
+	}
+	ret := make([]geo.Geometry, len(colls))
+	for i, c := range colls {
+		if ret[i], err = geo.MakeGeometryFromGeomT(c); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// checkClusterSRIDs returns an error if the given geometries do not all share
+// the same SRID.
+func checkClusterSRIDs(gs []geo.Geometry) error {
+	for i := 1; i < len(gs); i++ {
+		if gs[i].SRID() != gs[0].SRID() {
+			return geo.NewMismatchingSRIDsError(gs[0].SpatialObject(), gs[i].SpatialObject())
+		}
+	}
+	return nil
+}
+
+// clusterCentroid returns the 2D coordinates of the centroid of a non-empty
+// geometry.
+func clusterCentroid(g geo.Geometry) (geom.Coord, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := t.(*geom.Point); ok {
+		return geom.Coord{p.X(), p.Y()}, nil
+	}
+	c, err := Centroid(g)
+	if err != nil {
+		return nil, err
+	}
+	if t, err = c.AsGeomT(); err != nil {
+		return nil, err
+	}
+	p := t.(*geom.Point)
+	return geom.Coord{p.X(), p.Y()}, nil
+}
+
+// kMeans runs Lloyd's algorithm over the given points, returning the cluster
+// each point is assigned to along with the center of each cluster. The initial
+// centers are picked deterministically: the first point, followed by the point
+// farthest from all centers picked so far.
+func kMeans(
+	ctx context.Context, points []geom.Coord, k int,
+) (assignment []int, centers []geom.Coord, _ error) {
+	centers = make([]geom.Coord, 0, k)
+	centers = append(centers, geom.Coord{points[0][0], points[0][1]})
+	minDist := make([]float64, len(points))
+	for i := range minDist {
+		minDist[i] = math.Inf(1)
+	}
+	for len(centers) < k {
+		last := centers[len(centers)-1]
+		farthest := 0
+		for i, p := range points {
+			minDist[i] = math.Min(minDist[i], squaredDistance(p, last))
+			if minDist[i] > minDist[farthest] {
+				farthest = i
+			}
+		}
+		centers = append(centers, geom.Coord{points[farthest][0], points[farthest][1]})
+	}
+
+	assignment = make([]int, len(points))
+	for i := range assignment {
+		assignment[i] = -1
+	}
+	for iter := 0; iter < kMeansMaxIterations; iter++ {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		changed := false
+		for i, p := range points {
+			best := 0
+			bestDist := squaredDistance(p, centers[0])
+			for c := 1; c < len(centers); c++ {
+				if d := squaredDistance(p, centers[c]); d < bestDist {
+					best, bestDist = c, d
+				}
+			}
+			if assignment[i] != best {
+				assignment[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		// Move each center to the mean of its points. Centers without any
+		// points keep their previous position.
+		sums := make([]geom.Coord, k)
+		counts := make([]int, k)
+		for i := range sums {
+			sums[i] = geom.Coord{0, 0}
+		}
+		for i, p := range points {
+			c := assignment[i]
+			sums[c][0] += p[0]
+			sums[c][1] += p[1]
+			counts[c]++
+		}
+		for c := range centers {
+			if counts[c] > 0 {
+				centers[c] = geom.Coord{sums[c][0] / float64(counts[c]), sums[c][1] / float64(counts[c])}
+			}
+		}
+	}
+	return assignment, centers, nil
+}
+
+// withinRadius returns whether every point is within maxRadius of the center
+// of the cluster it is assigned to.
+func withinRadius(
+	points []geom.Coord, assignment []int, centers []geom.Coord, maxRadius float64,
+) bool {
+	if math.IsInf(maxRadius, 1) {
+		return true
+	}
+	for i, p := range points {
+		if math.Sqrt(squaredDistance(p, centers[assignment[i]])) > maxRadius {
+			return false
+		}
+	}
+	return true
+}
+
+// squaredDistance returns the squared 2D Cartesian distance between a and b.
+func squaredDistance(a, b geom.Coord) float64 {
+	dx, dy := a[0]-b[0], a[1]-b[1]
+	return dx*dx + dy*dy
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+func parseGeometries(t *testing.T, wkts ...string) []geo.Geometry {
+	ret := make([]geo.Geometry, len(wkts))
+	for i, wkt := range wkts {
+		g, err := geo.ParseGeometry(wkt)
+		require.NoError(t, err)
+		ret[i] = g
+	}
+	return ret
+}
+
+func TestClusterDBSCAN(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		wkts      []string
+		eps       float64
+		minPoints int
+		expected  []int
+	}{
+		{
+			desc:      "two clusters and noise",
+			wkts:      []string{"POINT(0 0)", "POINT(0 1)", "POINT(10 10)", "POINT(1 1)", "POINT(10 11)", "POINT(50 50)"},
+			eps:       1.5,
+			minPoints: 2,
+			expected:  []int{0, 0, 1, 0, 1, NoCluster},
+		},
+		{
+			desc:      "border point joins cluster",
+			wkts:      []string{"POINT(3 0)", "POINT(0 0)", "POINT(1 0)", "POINT(2 0)"},
+			eps:       1,
+			minPoints: 3,
+			expected:  []int{0, 0, 0, 0},
+		},
+		{
+			desc:      "minpoints too high",
+			wkts:      []string{"POINT(0 0)", "POINT(0 1)"},
+			eps:       1,
+			minPoints: 3,
+			expected:  []int{NoCluster, NoCluster},
+		},
+		{
+			desc:      "empty geometry is noise",
+			wkts:      []string{"POINT(0 0)", "POINT EMPTY"},
+			eps:       1,
+			minPoints: 1,
+			expected:  []int{0, NoCluster},
+		},
+		{
+			desc:      "polygons",
+			wkts:      []string{"POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))", "POINT(2 0.5)", "POINT(5 5)"},
+			eps:       1,
+			minPoints: 1,
+			expected:  []int{0, 0, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ids, err := ClusterDBSCAN(context.Background(), parseGeometries(t, tc.wkts...), tc.eps, tc.minPoints)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, ids)
+		})
+	}
+
+	t.Run("negative eps", func(t *testing.T) {
+		_, err := ClusterDBSCAN(context.Background(), parseGeometries(t, "POINT(0 0)"), -1, 1)
+		require.EqualError(t, err, "eps must be a non-negative number")
+	})
+
+	t.Run("mismatching SRIDs", func(t *testing.T) {
+		_, err := ClusterDBSCAN(context.Background(), parseGeometries(t, "POINT(0 0)", "SRID=4326;POINT(0 0)"), 1, 1)
+		require.Error(t, err)
+	})
+}
+
+func TestClusterKMeans(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		wkts      []string
+		k         int
+		maxRadius float64
+		expected  []int
+	}{
+		{
+			desc:      "two clusters",
+			wkts:      []string{"POINT(0 0)", "POINT(10 10)", "POINT(0 1)", "POINT(11 10)", "POINT(1 0)"},
+			k:         2,
+			maxRadius: math.Inf(1),
+			expected:  []int{0, 1, 0, 1, 0},
+		},
+		{
+			desc:      "k larger than number of geometries",
+			wkts:      []string{"POINT(0 0)", "POINT(5 5)"},
+			k:         5,
+			maxRadius: math.Inf(1),
+			expected:  []int{0, 1},
+		},
+		{
+			desc:      "empty geometries are not clustered",
+			wkts:      []string{"POINT(0 0)", "POINT EMPTY", "POINT(10 10)"},
+			k:         2,
+			maxRadius: math.Inf(1),
+			expected:  []int{0, NoCluster, 1},
+		},
+		{
+			desc:      "max radius splits clusters",
+			wkts:      []string{"POINT(0 0)", "POINT(0 1)", "POINT(10 0)", "POINT(10 1)"},
+			k:         1,
+			maxRadius: 1,
+			expected:  []int{0, 0, 1, 1},
+		},
+		{
+			desc: "max radius needs many clusters",
+			wkts: []string{
+				"POINT(0 0)", "POINT(0 1)", "POINT(10 0)", "POINT(10 1)",
+				"POINT(20 0)", "POINT(20 1)", "POINT(30 0)", "POINT(30 1)",
+			},
+			k:         1,
+			maxRadius: 1,
+			expected:  []int{0, 0, 1, 1, 2, 2, 3, 3},
+		},
+		{
+			desc:      "polygons use their centroid",
+			wkts:      []string{"POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))", "POINT(1 1)", "POINT(20 20)"},
+			k:         2,
+			maxRadius: math.Inf(1),
+			expected:  []int{0, 0, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ids, err := ClusterKMeans(context.Background(), parseGeometries(t, tc.wkts...), tc.k, tc.maxRadius)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, ids)
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := ClusterKMeans(ctx, parseGeometries(t, "POINT(0 0)", "POINT(1 1)"), 1, math.Inf(1))
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("k must be positive", func(t *testing.T) {
+		_, err := ClusterKMeans(context.Background(), parseGeometries(t, "POINT(0 0)"), 0, math.Inf(1))
+		require.EqualError(t, err, "number of clusters must be greater than zero")
+	})
+}
+
+func TestClusterWithin(t *testing.T) {
+	gs := parseGeometries(t,
+		"POINT(0 0)", "POINT(5 5)", "LINESTRING(1 0, 2 0)", "POINT(3 0)", "POINT(5 6)", "POINT(100 100)",
+	)
+
+	ids, err := ClusterWithinIDs(gs, 1)
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 0, 0, 1, 2}, ids)
+
+	colls, err := ClusterWithin(gs, 1)
+	require.NoError(t, err)
+	var ewkts []string
+	for _, c := range colls {
+		ewkt, err := geo.SpatialObjectToEWKT(c.SpatialObject(), -1)
+		require.NoError(t, err)
+		ewkts = append(ewkts, string(ewkt))
+	}
+	require.Equal(t, []string{
+		"GEOMETRYCOLLECTION (POINT (0 0), LINESTRING (1 0, 2 0), POINT (3 0))",
+		"GEOMETRYCOLLECTION (POINT (5 5), POINT (5 6))",
+		"GEOMETRYCOLLECTION (POINT (100 100))",
+	}, ewkts)
+
+	_, err = ClusterWithinIDs(gs, -1)
+	require.EqualError(t, err, "distance must be a non-negative number")
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+	"github.com/twpayne/go-geom"
+)
+
+// GeometryDump is a component of a Geometry along with the 1-indexed path to
+// it, mirroring the geometry_dump type of PostGIS.
+type GeometryDump struct {
+	Path []int
+	Geom geo.Geometry
+}
+
+// Dump returns the non-collection components of the given Geometry. The path
+// of each component holds its index within each enclosing collection. If the
+// Geometry is not a collection, it is returned with an empty path.
+func Dump(g geo.Geometry) ([]GeometryDump, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return nil, err
+	}
+	var ret []GeometryDump
+	err = forEachDumpComponent(t, nil /* path */, func(t geom.T, path []int) error {
+		return appendGeometryDump(&ret, t, g.SRID(), path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// DumpPoints returns every vertex of the given Geometry as a Point. The path
+// of each Point holds its index within each enclosing collection, followed by
+// the index of its ring for polygons, followed by its index within its point,
+// line or ring.
+func DumpPoints(g geo.Geometry) ([]GeometryDump, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return nil, err
+	}
+	var ret []GeometryDump
+	appendCoords := func(flatCoords []float64, layout geom.Layout, path []int) error {
+		stride := layout.Stride()
+		for i := 0; i < len(flatCoords); i += stride {
+			p := geom.NewPointFlat(layout, flatCoords[i:i+stride])
+			if err := appendGeometryDump(&ret, p, g.SRID(), appendDumpPath(path, i/stride+1)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	err = forEachDumpComponent(t, nil /* path */, func(t geom.T, path []int) error {
+		switch t := t.(type) {
+		case *geom.Point, *geom.LineString:
+			return appendCoords(t.FlatCoords(), t.Layout(), path)
+		case *geom.Polygon:
+			for i := 0; i < t.NumLinearRings(); i++ {
+				ring := t.LinearRing(i)
+				if err := appendCoords(ring.FlatCoords(), ring.Layout(), appendDumpPath(path, i+1)); err != nil {
+					return err
+				}
+			}
+			return nil
+		default:
+			return errors.AssertionFailedf("unexpected geometry type: %T", t)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// DumpRings returns each ring of the given Polygon as a Polygon. The path of
+// the exterior ring is {0}, and the path of the n-th interior ring is {n}.
+func DumpRings(g geo.Geometry) ([]GeometryDump, error) {
+	if g.ShapeType2D() != geopb.ShapeType_Polygon {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "input is not a polygon")
+	}
+	t, err := g.AsGeomT()
+	if err != nil {
+		return nil, err
+	}
+	poly := t.(*geom.Polygon)
+	var ret []GeometryDump
+	for i := 0; i < poly.NumLinearRings(); i++ {
+		ring := poly.LinearRing(i)
+		p := geom.NewPolygonFlat(ring.Layout(), ring.FlatCoords(), []int{len(ring.FlatCoords())})
+		if err := appendGeometryDump(&ret, p, g.SRID(), []int{i}); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// forEachDumpComponent calls fn on every non-collection component of t along
+// with its path. Empty collections have no components.
+func forEachDumpComponent(t geom.T, path []int, fn func(geom.T, []int) error) error {
+	switch t := t.(type) {
+	case *geom.MultiPoint:
+		for i := 0; i < t.NumPoints(); i++ {
+			if err := fn(t.Point(i), appendDumpPath(path, i+1)); err != nil {
+				return err
+			}
+		}
+	case *geom.MultiLineString:
+		for i := 0; i < t.NumLineStrings(); i++ {
+			if err := fn(t.LineString(i), appendDumpPath(path, i+1)); err != nil {
+				return err
+			}
+		}
+	case *geom.MultiPolygon:
+		for i := 0; i < t.NumPolygons(); i++ {
+			if err := fn(t.Polygon(i), appendDumpPath(path, i+1)); err != nil {
+				return err
+			}
+		}
+	case *geom.GeometryCollection:
+		for i := 0; i < t.NumGeoms(); i++ {
+			if err := forEachDumpComponent(t.Geom(i), appendDumpPath(path, i+1), fn); err != nil {
+				return err
+			}
+		}
+	default:
+		return fn(t, path)
+	}
+	return nil
+}
+
+// appendDumpPath returns a copy of path with idx appended, so that paths
+// handed out to callers never share a backing array.
+func appendDumpPath(path []int, idx int) []int {
+	ret := make([]int, len(path), len(path)+1)
+	copy(ret, path)
+	return append(ret, idx)
+}
+
+// appendGeometryDump converts t to a Geometry with the given SRID and appends
+// it to dumps along with its path.
+func appendGeometryDump(dumps *[]GeometryDump, t geom.T, srid geopb.SRID, path []int) error {
+	geo.AdjustGeomTSRID(t, srid)
+	g, err := geo.MakeGeometryFromGeomT(t)
+	if err != nil {
+		return err
+	}
+	if path == nil {
+		path = []int{}
+	}
+	*dumps = append(*dumps, GeometryDump{Path: path, Geom: g})
+	return nil
+}
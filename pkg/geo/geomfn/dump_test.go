@@ -0,0 +1,150 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+type expectedDump struct {
+	path []int
+	ewkt string
+}
+
+func requireDumps(t *testing.T, expected []expectedDump, dumps []GeometryDump) {
+	require.Len(t, dumps, len(expected))
+	for i, d := range dumps {
+		require.Equal(t, expected[i].path, d.Path)
+		ewkt, err := geo.SpatialObjectToEWKT(d.Geom.SpatialObject(), -1)
+		require.NoError(t, err)
+		require.Equal(t, expected[i].ewkt, string(ewkt))
+	}
+}
+
+func TestDump(t *testing.T) {
+	testCases := []struct {
+		wkt      string
+		expected []expectedDump
+	}{
+		{
+			wkt:      "POINT (1 2)",
+			expected: []expectedDump{{[]int{}, "POINT (1 2)"}},
+		},
+		{
+			wkt: "SRID=4326;MULTIPOINT (1 2, 3 4)",
+			expected: []expectedDump{
+				{[]int{1}, "SRID=4326;POINT (1 2)"},
+				{[]int{2}, "SRID=4326;POINT (3 4)"},
+			},
+		},
+		{
+			wkt: "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((5 5, 6 5, 6 6, 5 5)))",
+			expected: []expectedDump{
+				{[]int{1}, "POLYGON ((0 0, 1 0, 1 1, 0 0))"},
+				{[]int{2}, "POLYGON ((5 5, 6 5, 6 6, 5 5))"},
+			},
+		},
+		{
+			wkt: "GEOMETRYCOLLECTION (POINT (1 1), GEOMETRYCOLLECTION (LINESTRING (0 0, 1 1), MULTIPOINT (2 2)))",
+			expected: []expectedDump{
+				{[]int{1}, "POINT (1 1)"},
+				{[]int{2, 1}, "LINESTRING (0 0, 1 1)"},
+				{[]int{2, 2, 1}, "POINT (2 2)"},
+			},
+		},
+		{
+			wkt:      "GEOMETRYCOLLECTION EMPTY",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			dumps, err := Dump(geo.MustParseGeometry(tc.wkt))
+			require.NoError(t, err)
+			requireDumps(t, tc.expected, dumps)
+		})
+	}
+}
+
+func TestDumpPoints(t *testing.T) {
+	testCases := []struct {
+		wkt      string
+		expected []expectedDump
+	}{
+		{
+			wkt:      "POINT (1 2)",
+			expected: []expectedDump{{[]int{1}, "POINT (1 2)"}},
+		},
+		{
+			wkt:      "POINT EMPTY",
+			expected: nil,
+		},
+		{
+			wkt: "SRID=4326;LINESTRING (0 0, 1 1)",
+			expected: []expectedDump{
+				{[]int{1}, "SRID=4326;POINT (0 0)"},
+				{[]int{2}, "SRID=4326;POINT (1 1)"},
+			},
+		},
+		{
+			wkt: "POLYGON ((0 0, 4 0, 4 4, 0 0), (1 1, 2 1, 2 2, 1 1))",
+			expected: []expectedDump{
+				{[]int{1, 1}, "POINT (0 0)"},
+				{[]int{1, 2}, "POINT (4 0)"},
+				{[]int{1, 3}, "POINT (4 4)"},
+				{[]int{1, 4}, "POINT (0 0)"},
+				{[]int{2, 1}, "POINT (1 1)"},
+				{[]int{2, 2}, "POINT (2 1)"},
+				{[]int{2, 3}, "POINT (2 2)"},
+				{[]int{2, 4}, "POINT (1 1)"},
+			},
+		},
+		{
+			wkt: "GEOMETRYCOLLECTION (POINT Z (0 1 2), MULTILINESTRING Z ((0 3 1, 3 4 1)))",
+			expected: []expectedDump{
+				{[]int{1, 1}, "POINT Z (0 1 2)"},
+				{[]int{2, 1, 1}, "POINT Z (0 3 1)"},
+				{[]int{2, 1, 2}, "POINT Z (3 4 1)"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			dumps, err := DumpPoints(geo.MustParseGeometry(tc.wkt))
+			require.NoError(t, err)
+			requireDumps(t, tc.expected, dumps)
+		})
+	}
+}
+
+func TestDumpRings(t *testing.T) {
+	dumps, err := DumpRings(geo.MustParseGeometry(
+		"SRID=3857;POLYGON ((0 0, 4 0, 4 4, 0 0), (1 1, 2 1, 2 2, 1 1), (3 1, 3.5 1, 3.5 2, 3 1))",
+	))
+	require.NoError(t, err)
+	requireDumps(t, []expectedDump{
+		{[]int{0}, "SRID=3857;POLYGON ((0 0, 4 0, 4 4, 0 0))"},
+		{[]int{1}, "SRID=3857;POLYGON ((1 1, 2 1, 2 2, 1 1))"},
+		{[]int{2}, "SRID=3857;POLYGON ((3 1, 3.5 1, 3.5 2, 3 1))"},
+	}, dumps)
+
+	dumps, err = DumpRings(geo.MustParseGeometry("POLYGON EMPTY"))
+	require.NoError(t, err)
+	require.Empty(t, dumps)
+
+	_, err = DumpRings(geo.MustParseGeometry("MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)))"))
+	require.EqualError(t, err, "input is not a polygon")
+}
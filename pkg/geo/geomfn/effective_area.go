@@ -0,0 +1,317 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/errors"
+	"github.com/twpayne/go-geom"
+)
+
+// maxEffectiveArea is the effective area given to points that are never
+// removed, such as the end points of a LineString. This matches PostGIS,
+// which uses FLT_MAX.
+const maxEffectiveArea = math.MaxFloat32
+
+// SimplifyVW simplifies the given Geometry using the Visvalingam-Whyatt
+// algorithm, removing every vertex whose effective area is less than the given
+// tolerance. LineStrings keep at least 2 points and Polygon rings keep at
+// least 4. Points are returned as is.
+func SimplifyVW(g geo.Geometry, tolerance float64) (geo.Geometry, error) {
+	return effectiveArea(g, tolerance, false /* setArea */)
+}
+
+// SetEffectiveArea sets the M coordinate of every vertex of the given Geometry
+// to its effective area as computed by the Visvalingam-Whyatt algorithm.
+// Vertices with an effective area less than the given threshold are removed.
+// End points, which are never removed, have an effective area of the largest
+// float4.
+func SetEffectiveArea(g geo.Geometry, threshold float64) (geo.Geometry, error) {
+	return effectiveArea(g, threshold, true /* setArea */)
+}
+
+func effectiveArea(g geo.Geometry, threshold float64, setArea bool) (geo.Geometry, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	ret, err := effectiveAreaFromGeomT(t, threshold, setArea)
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.MakeGeometryFromGeomT(ret)
+}
+
+// effectiveAreaLayout returns the layout of geometries returned by
+// effectiveAreaFromGeomT. If setArea is set, an M dimension is added.
+func effectiveAreaLayout(layout geom.Layout, setArea bool) geom.Layout {
+	if !setArea {
+		return layout
+	}
+	switch layout {
+	case geom.XY, geom.XYM:
+		return geom.XYM
+	default:
+		return geom.XYZM
+	}
+}
+
+func effectiveAreaFromGeomT(t geom.T, threshold float64, setArea bool) (geom.T, error) {
+	layout := effectiveAreaLayout(t.Layout(), setArea)
+	switch t := t.(type) {
+	case *geom.Point:
+		return geom.NewPointFlat(
+			layout,
+			effectiveAreaFlatCoords(t.FlatCoords(), t.Layout(), threshold, 1, setArea),
+		).SetSRID(t.SRID()), nil
+	case *geom.MultiPoint:
+		// Ends index into the flat coordinates, so they are rescaled to the
+		// output stride.
+		ends := make([]int, len(t.Ends()))
+		for i, end := range t.Ends() {
+			ends[i] = end / t.Stride() * layout.Stride()
+		}
+		return geom.NewMultiPointFlat(
+			layout,
+			effectiveAreaFlatCoords(t.FlatCoords(), t.Layout(), threshold, t.NumCoords(), setArea),
+			geom.NewMultiPointFlatOptionWithEnds(ends),
+		).SetSRID(t.SRID()), nil
+	case *geom.LineString:
+		return geom.NewLineStringFlat(
+			layout,
+			effectiveAreaFlatCoords(t.FlatCoords(), t.Layout(), threshold, 2, setArea),
+		).SetSRID(t.SRID()), nil
+	case *geom.Polygon:
+		ret := geom.NewPolygon(layout).SetSRID(t.SRID())
+		for i := 0; i < t.NumLinearRings(); i++ {
+			ring := t.LinearRing(i)
+			// Exterior rings are kept from collapsing, whereas holes may collapse
+			// and are then dropped.
+			minPoints := 0
+			if i == 0 {
+				minPoints = 4
+			}
+			flatCoords := effectiveAreaFlatCoords(ring.FlatCoords(), t.Layout(), threshold, minPoints, setArea)
+			if len(flatCoords) < 4*layout.Stride() {
+				if i == 0 {
+					return ret, nil
+				}
+				continue
+			}
+			if err := ret.Push(geom.NewLinearRingFlat(layout, flatCoords)); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	case *geom.MultiLineString:
+		ret := geom.NewMultiLineString(layout).SetSRID(t.SRID())
+		for i := 0; i < t.NumLineStrings(); i++ {
+			ls, err := effectiveAreaFromGeomT(t.LineString(i), threshold, setArea)
+			if err != nil {
+				return nil, err
+			}
+			if ls.Empty() {
+				continue
+			}
+			if err := ret.Push(ls.(*geom.LineString)); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	case *geom.MultiPolygon:
+		ret := geom.NewMultiPolygon(layout).SetSRID(t.SRID())
+		for i := 0; i < t.NumPolygons(); i++ {
+			p, err := effectiveAreaFromGeomT(t.Polygon(i), threshold, setArea)
+			if err != nil {
+				return nil, err
+			}
+			if p.Empty() {
+				continue
+			}
+			if err := ret.Push(p.(*geom.Polygon)); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	case *geom.GeometryCollection:
+		ret := geom.NewGeometryCollection().SetSRID(t.SRID())
+		for _, subT := range t.Geoms() {
+			processed, err := effectiveAreaFromGeomT(subT, threshold, setArea)
+			if err != nil {
+				return nil, err
+			}
+			if processed.Empty() {
+				continue
+			}
+			if err := ret.Push(processed); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	default:
+		return nil, errors.AssertionFailedf("unknown geometry type: %T", t)
+	}
+}
+
+// effectiveAreaFlatCoords returns the coordinates whose effective area is at
+// least the given threshold. If setArea is set, the M coordinate of each
+// returned coordinate is set to its effective area. No fewer than minPoints
+// coordinates are returned.
+func effectiveAreaFlatCoords(
+	flatCoords []float64, inLayout geom.Layout, threshold float64, minPoints int, setArea bool,
+) []float64 {
+	outLayout := effectiveAreaLayout(inLayout, setArea)
+	inStride := inLayout.Stride()
+	outStride := outLayout.Stride()
+	numCoords := len(flatCoords) / inStride
+	areas := effectiveAreas(flatCoords, inLayout, minPoints)
+	ret := make([]float64, 0, numCoords*outStride)
+	for i := 0; i < numCoords; i++ {
+		if areas[i] < threshold {
+			continue
+		}
+		coord := flatCoords[i*inStride : (i+1)*inStride]
+		ret = append(ret, coord[0], coord[1])
+		if outLayout.ZIndex() != -1 {
+			ret = append(ret, coord[inLayout.ZIndex()])
+		}
+		if outLayout.MIndex() != -1 {
+			if setArea {
+				ret = append(ret, areas[i])
+			} else {
+				ret = append(ret, coord[inLayout.MIndex()])
+			}
+		}
+	}
+	return ret
+}
+
+// effectiveAreas returns the effective area of each of the given coordinates
+// as computed by the Visvalingam-Whyatt algorithm. The effective area of a
+// point is the area of the triangle it forms with its neighbors at the time
+// it is removed, which is never less than that of a previously removed point.
+// Once only minPoints points remain, the rest are given maxEffectiveArea.
+func effectiveAreas(flatCoords []float64, layout geom.Layout, minPoints int) []float64 {
+	stride := layout.Stride()
+	numCoords := len(flatCoords) / stride
+	areas := make([]float64, numCoords)
+	for i := range areas {
+		areas[i] = maxEffectiveArea
+	}
+	if numCoords <= minPoints || numCoords < 3 {
+		return areas
+	}
+
+	coord := func(i int) []float64 {
+		return flatCoords[i*stride : (i+1)*stride]
+	}
+	h := effectiveAreaHeap{
+		prev:    make([]int, numCoords),
+		next:    make([]int, numCoords),
+		heapIdx: make([]int, numCoords),
+		areas:   areas,
+	}
+	for i := 1; i < numCoords-1; i++ {
+		h.prev[i] = i - 1
+		h.next[i] = i + 1
+		areas[i] = triangleArea(coord(i-1), coord(i), coord(i+1), layout)
+		h.items = append(h.items, i)
+		h.heapIdx[i] = len(h.items) - 1
+	}
+	heap.Init(&h)
+
+	remaining := numCoords
+	minArea := 0.0
+	for h.Len() > 0 && remaining > minPoints {
+		i := heap.Pop(&h).(int)
+		remaining--
+		// Effective areas are non-decreasing in the order points are removed.
+		if areas[i] < minArea {
+			areas[i] = minArea
+		}
+		minArea = areas[i]
+		prev, next := h.prev[i], h.next[i]
+		h.next[prev] = next
+		h.prev[next] = prev
+		for _, j := range []int{prev, next} {
+			if j == 0 || j == numCoords-1 {
+				continue
+			}
+			areas[j] = triangleArea(coord(h.prev[j]), coord(j), coord(h.next[j]), layout)
+			heap.Fix(&h, h.heapIdx[j])
+		}
+	}
+	// The points which were not removed are kept regardless of threshold.
+	for _, i := range h.items {
+		areas[i] = maxEffectiveArea
+	}
+	return areas
+}
+
+// triangleArea returns the area of the triangle formed by the given
+// coordinates, taking Z into account if the layout has it.
+func triangleArea(a, b, c []float64, layout geom.Layout) float64 {
+	abX, abY := b[0]-a[0], b[1]-a[1]
+	acX, acY := c[0]-a[0], c[1]-a[1]
+	if zIdx := layout.ZIndex(); zIdx != -1 {
+		abZ, acZ := b[zIdx]-a[zIdx], c[zIdx]-a[zIdx]
+		crossX := abY*acZ - abZ*acY
+		crossY := abZ*acX - abX*acZ
+		crossZ := abX*acY - abY*acX
+		return math.Sqrt(crossX*crossX+crossY*crossY+crossZ*crossZ) / 2
+	}
+	return math.Abs(abX*acY-abY*acX) / 2
+}
+
+// effectiveAreaHeap is a min-heap of coordinate indexes ordered by their
+// current triangle area, with prev and next linking the coordinates which
+// have not yet been removed.
+type effectiveAreaHeap struct {
+	items   []int
+	prev    []int
+	next    []int
+	heapIdx []int
+	areas   []float64
+}
+
+var _ heap.Interface = (*effectiveAreaHeap)(nil)
+
+// Len implements the heap.Interface interface.
+func (h *effectiveAreaHeap) Len() int { return len(h.items) }
+
+// Less implements the heap.Interface interface.
+func (h *effectiveAreaHeap) Less(i, j int) bool {
+	return h.areas[h.items[i]] < h.areas[h.items[j]]
+}
+
+// Swap implements the heap.Interface interface.
+func (h *effectiveAreaHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.heapIdx[h.items[i]] = i
+	h.heapIdx[h.items[j]] = j
+}
+
+// Push implements the heap.Interface interface.
+func (h *effectiveAreaHeap) Push(x interface{}) {
+	h.heapIdx[x.(int)] = len(h.items)
+	h.items = append(h.items, x.(int))
+}
+
+// Pop implements the heap.Interface interface.
+func (h *effectiveAreaHeap) Pop() interface{} {
+	n := len(h.items)
+	ret := h.items[n-1]
+	h.items = h.items[:n-1]
+	return ret
+}
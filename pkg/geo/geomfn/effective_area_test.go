@@ -0,0 +1,120 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimplifyVW(t *testing.T) {
+	testCases := []struct {
+		wkt       string
+		tolerance float64
+		expected  string
+	}{
+		{"POINT(1 2)", 30, "POINT(1 2)"},
+		{"MULTIPOINT(1 1, 2 2)", 30, "MULTIPOINT(1 1, 2 2)"},
+		{"LINESTRING(5 2, 3 8, 6 20, 7 25, 10 10)", 30, "LINESTRING(5 2, 7 25, 10 10)"},
+		{"LINESTRING(5 2, 3 8, 6 20, 7 25, 10 10)", 0, "LINESTRING(5 2, 3 8, 6 20, 7 25, 10 10)"},
+		{"LINESTRING(5 2, 3 8, 6 20, 7 25, 10 10)", 1000, "LINESTRING(5 2, 10 10)"},
+		{"LINESTRING Z (0 0 0, 1 1 1, 2 0 0)", 1, "LINESTRING Z (0 0 0, 1 1 1, 2 0 0)"},
+		{"LINESTRING Z (0 0 0, 1 1 1, 2 0 0)", 2, "LINESTRING Z (0 0 0, 2 0 0)"},
+		{
+			"POLYGON((0 0, 0 10, 5 11, 10 10, 10 0, 5 1, 0 0), (2 2, 2 3, 3 3, 2 2))",
+			30,
+			"POLYGON((0 0, 0 10, 10 10, 10 0, 0 0))",
+		},
+		{
+			"POLYGON((0 0, 0 10, 5 11, 10 10, 10 0, 5 1, 0 0), (2 2, 2 3, 3 3, 2 2))",
+			0.1,
+			"POLYGON((0 0, 0 10, 5 11, 10 10, 10 0, 5 1, 0 0), (2 2, 2 3, 3 3, 2 2))",
+		},
+		{
+			"MULTILINESTRING((5 2, 3 8, 6 20, 7 25, 10 10), EMPTY)",
+			30,
+			"MULTILINESTRING((5 2, 7 25, 10 10))",
+		},
+		{
+			"GEOMETRYCOLLECTION(POINT(1 2), LINESTRING(5 2, 3 8, 6 20, 7 25, 10 10))",
+			30,
+			"GEOMETRYCOLLECTION(POINT(1 2), LINESTRING(5 2, 7 25, 10 10))",
+		},
+		{"LINESTRING EMPTY", 30, "LINESTRING EMPTY"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s/%f", tc.wkt, tc.tolerance), func(t *testing.T) {
+			g, err := geo.ParseGeometry(tc.wkt)
+			require.NoError(t, err)
+			ret, err := SimplifyVW(g, tc.tolerance)
+			require.NoError(t, err)
+			expected, err := geo.ParseGeometry(tc.expected)
+			require.NoError(t, err)
+			require.Equal(t, expected, ret)
+		})
+	}
+}
+
+func TestSetEffectiveArea(t *testing.T) {
+	testCases := []struct {
+		wkt       string
+		threshold float64
+		expected  string
+	}{
+		{"POINT(1 2)", 0, "POINT M (1 2 3.4028234663852886e+38)"},
+		{
+			"LINESTRING(5 2, 3 8, 6 20, 7 25, 10 10)",
+			0,
+			"LINESTRING M (5 2 3.4028234663852886e+38, 3 8 29, 6 20 1.5, 7 25 49.5, 10 10 3.4028234663852886e+38)",
+		},
+		{
+			"LINESTRING(5 2, 3 8, 6 20, 7 25, 10 10)",
+			2,
+			"LINESTRING M (5 2 3.4028234663852886e+38, 3 8 29, 7 25 49.5, 10 10 3.4028234663852886e+38)",
+		},
+		{
+			"LINESTRING M (5 2 0, 3 8 0, 6 20 0, 7 25 0, 10 10 0)",
+			30,
+			"LINESTRING M (5 2 3.4028234663852886e+38, 7 25 49.5, 10 10 3.4028234663852886e+38)",
+		},
+		{
+			"LINESTRING Z (0 0 0, 1 1 1, 2 0 0)",
+			0,
+			"LINESTRING ZM (0 0 0 3.4028234663852886e+38, 1 1 1 1.4142135623730951, 2 0 0 3.4028234663852886e+38)",
+		},
+		{
+			"POLYGON((0 0, 0 10, 10 10, 10 0, 5 1, 0 0), (2 2, 2 3, 3 3, 2 2))",
+			1,
+			"POLYGON M ((0 0 3.4028234663852886e+38, 0 10 3.4028234663852886e+38, 10 10 3.4028234663852886e+38, 10 0 50, 5 1 5, 0 0 3.4028234663852886e+38))",
+		},
+		{
+			"MULTIPOINT(1 1, EMPTY, 2 2)",
+			0,
+			"MULTIPOINT M (1 1 3.4028234663852886e+38, EMPTY, 2 2 3.4028234663852886e+38)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s/%f", tc.wkt, tc.threshold), func(t *testing.T) {
+			g, err := geo.ParseGeometry(tc.wkt)
+			require.NoError(t, err)
+			ret, err := SetEffectiveArea(g, tc.threshold)
+			require.NoError(t, err)
+			expected, err := geo.ParseGeometry(tc.expected)
+			require.NoError(t, err)
+			require.Equal(t, expected, ret)
+		})
+	}
+}
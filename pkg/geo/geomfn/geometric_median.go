@@ -0,0 +1,229 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+)
+
+// DefaultGeometricMedianMaxIterations is the default maximum number of
+// iterations used by GeometricMedian.
+const DefaultGeometricMedianMaxIterations = 10000
+
+// geometricMedianEpsilon is the distance under which a candidate median is
+// considered to coincide with an input point.
+const geometricMedianEpsilon = 1e-12
+
+// DefaultGeometricMedianTolerance returns the default tolerance used by
+// GeometricMedian for the given Geometry, which is scaled by the magnitude of
+// its coordinates.
+func DefaultGeometricMedianTolerance(g geo.Geometry) float64 {
+	const relativeTolerance = 1e-10
+	bbox := g.CartesianBoundingBox()
+	if bbox == nil {
+		return relativeTolerance
+	}
+	maxOrdinate := math.Max(
+		math.Max(math.Abs(bbox.LoX), math.Abs(bbox.HiX)),
+		math.Max(math.Abs(bbox.LoY), math.Abs(bbox.HiY)),
+	)
+	return relativeTolerance * math.Max(maxOrdinate, 1)
+}
+
+// GeometricMedian returns the point minimizing the sum of distances to the
+// points of the given Point or MultiPoint, using Weiszfeld's algorithm with
+// the Vardi-Zhang modification for candidates coinciding with an input point.
+// If the input has an M dimension, the M values are used as weights. The
+// median is computed in 3D if the input has a Z dimension.
+//
+// Iteration stops once the median moves less than tolerance, or after
+// maxIterations. In the latter case, an error is returned if
+// failIfNotConverged is set.
+func GeometricMedian(
+	g geo.Geometry, tolerance float64, maxIterations int, failIfNotConverged bool,
+) (geo.Geometry, error) {
+	if tolerance < 0 {
+		return geo.Geometry{}, pgerror.Newf(pgcode.InvalidParameterValue, "tolerance must be positive")
+	}
+	if maxIterations < 0 {
+		return geo.Geometry{}, pgerror.Newf(pgcode.InvalidParameterValue, "maximum iterations must be positive")
+	}
+	t, err := g.AsGeomT()
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	switch t.(type) {
+	case *geom.Point, *geom.MultiPoint:
+	default:
+		return geo.Geometry{}, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"expected Point or MultiPoint, got %s",
+			g.ShapeType2D(),
+		)
+	}
+
+	layout := t.Layout()
+	outLayout := geom.XY
+	dims := 2
+	if layout.ZIndex() != -1 {
+		outLayout = geom.XYZ
+		dims = 3
+	}
+	// Gather the non-empty points and their weights.
+	var points [][3]float64
+	var weights []float64
+	stride := layout.Stride()
+	flatCoords := t.FlatCoords()
+	for i := 0; i < len(flatCoords); i += stride {
+		p := [3]float64{flatCoords[i], flatCoords[i+1]}
+		if dims == 3 {
+			p[2] = flatCoords[i+layout.ZIndex()]
+		}
+		w := 1.0
+		if layout.MIndex() != -1 {
+			w = flatCoords[i+layout.MIndex()]
+			if w < 0 {
+				return geo.Geometry{}, pgerror.Newf(
+					pgcode.InvalidParameterValue,
+					"geometric median input contains points with negative weights",
+				)
+			}
+		}
+		points = append(points, p)
+		weights = append(weights, w)
+	}
+	if len(points) == 0 {
+		return geo.MakeGeometryFromGeomT(geom.NewPointEmpty(geom.XY).SetSRID(int(g.SRID())))
+	}
+
+	median, converged := weiszfeld(points, weights, dims, tolerance, maxIterations)
+	if !converged && failIfNotConverged {
+		return geo.Geometry{}, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"median failed to converge within %g after %d iterations",
+			tolerance,
+			maxIterations,
+		)
+	}
+	return geo.MakeGeometryFromGeomT(
+		geom.NewPointFlat(outLayout, median[:dims]).SetSRID(int(g.SRID())),
+	)
+}
+
+// weiszfeld iterates towards the weighted geometric median of the given
+// points, starting from their weighted centroid. It returns the median and
+// whether it converged within maxIterations.
+func weiszfeld(
+	points [][3]float64, weights []float64, dims int, tolerance float64, maxIterations int,
+) ([3]float64, bool) {
+	var median [3]float64
+	totalWeight := 0.0
+	for i, p := range points {
+		for d := 0; d < dims; d++ {
+			median[d] += p[d] * weights[i]
+		}
+		totalWeight += weights[i]
+	}
+	if totalWeight == 0 {
+		return points[0], true
+	}
+	for d := 0; d < dims; d++ {
+		median[d] /= totalWeight
+	}
+
+	distance := func(a, b [3]float64) float64 {
+		sum := 0.0
+		for d := 0; d < dims; d++ {
+			sum += (a[d] - b[d]) * (a[d] - b[d])
+		}
+		return math.Sqrt(sum)
+	}
+	// pull returns the weight of the input points coinciding with the given
+	// candidate, and the magnitude of the sum of the weighted unit vectors
+	// pointing from the candidate towards the other input points.
+	pull := func(candidate [3]float64) (hitWeight float64, magnitude float64) {
+		var direction [3]float64
+		for i, p := range points {
+			dist := distance(p, candidate)
+			if dist < geometricMedianEpsilon {
+				hitWeight += weights[i]
+				continue
+			}
+			for d := 0; d < dims; d++ {
+				direction[d] += weights[i] * (p[d] - candidate[d]) / dist
+			}
+		}
+		return hitWeight, distance(direction, [3]float64{})
+	}
+	// Weiszfeld's algorithm converges slowly when the median is an input
+	// point, so input points closest to the candidate are checked directly.
+	// An input point is the median if the pull of the other points does not
+	// overcome its weight.
+	checked := make([]bool, len(points))
+	for iter := 0; iter < maxIterations; iter++ {
+		var weighted [3]float64
+		denominator := 0.0
+		// hitWeight is the weight of the input points which coincide with the
+		// current candidate.
+		hitWeight := 0.0
+		nearest, nearestDist := 0, math.Inf(1)
+		for i, p := range points {
+			dist := distance(p, median)
+			if dist < nearestDist {
+				nearest, nearestDist = i, dist
+			}
+			if dist < geometricMedianEpsilon {
+				hitWeight += weights[i]
+				continue
+			}
+			for d := 0; d < dims; d++ {
+				weighted[d] += weights[i] * p[d] / dist
+			}
+			denominator += weights[i] / dist
+		}
+		if denominator == 0 {
+			// Every point coincides with the candidate.
+			return median, true
+		}
+		if !checked[nearest] {
+			checked[nearest] = true
+			if w, p := pull(points[nearest]); p <= w {
+				return points[nearest], true
+			}
+		}
+
+		var next [3]float64
+		for d := 0; d < dims; d++ {
+			next[d] = weighted[d] / denominator
+		}
+		if hitWeight > 0 {
+			// The candidate coincides with input points which are not the median,
+			// so step towards the Weiszfeld point in proportion to their pull.
+			_, p := pull(median)
+			gamma := hitWeight / p
+			for d := 0; d < dims; d++ {
+				next[d] = (1-gamma)*next[d] + gamma*median[d]
+			}
+		}
+
+		delta := distance(next, median)
+		median = next
+		if delta < tolerance {
+			return median, true
+		}
+	}
+	return median, false
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeometricMedian(t *testing.T) {
+	testCases := []struct {
+		wkt      string
+		expected string
+	}{
+		{"POINT(1 2)", "POINT(1 2)"},
+		{"MULTIPOINT((0 0), (1 1), (0 1), (2 2))", "POINT(1 1)"},
+		{"MULTIPOINT((0 0), (0 0), (0 0), (1 0))", "POINT(0 0)"},
+		{"MULTIPOINT((0 0), (10 0), (0 10))", "POINT(2.113248654051871 2.113248654051871)"},
+		{"MULTIPOINT Z ((0 0 0), (6 0 0), (0 6 0), (0 0 6))", "POINT Z (1 1 1)"},
+		// M coordinates are used as weights.
+		{"MULTIPOINT M ((0 0 1), (10 0 1), (0 10 5))", "POINT(0 10)"},
+		{"SRID=4326;MULTIPOINT EMPTY", "SRID=4326;POINT EMPTY"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			g, err := geo.ParseGeometry(tc.wkt)
+			require.NoError(t, err)
+			ret, err := GeometricMedian(
+				g,
+				DefaultGeometricMedianTolerance(g),
+				DefaultGeometricMedianMaxIterations,
+				true, /* failIfNotConverged */
+			)
+			require.NoError(t, err)
+			retT, err := ret.AsGeomT()
+			require.NoError(t, err)
+			expected, err := geo.ParseGeometry(tc.expected)
+			require.NoError(t, err)
+			expectedT, err := expected.AsGeomT()
+			require.NoError(t, err)
+			require.Equal(t, expectedT.Layout(), retT.Layout())
+			require.Equal(t, expectedT.SRID(), retT.SRID())
+			require.InDeltaSlice(t, expectedT.FlatCoords(), retT.FlatCoords(), 1e-8)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		g := geo.MustParseGeometry("MULTIPOINT((0 0), (10 0), (0 10))")
+		_, err := GeometricMedian(g, -1, DefaultGeometricMedianMaxIterations, false)
+		require.EqualError(t, err, "tolerance must be positive")
+		_, err = GeometricMedian(g, 0, -1, false)
+		require.EqualError(t, err, "maximum iterations must be positive")
+		_, err = GeometricMedian(g, 0, 1, true)
+		require.EqualError(t, err, "median failed to converge within 0 after 1 iterations")
+		_, err = GeometricMedian(geo.MustParseGeometry("LINESTRING(0 0, 1 1)"), 0, 1, false)
+		require.EqualError(t, err, "expected Point or MultiPoint, got LineString")
+		_, err = GeometricMedian(geo.MustParseGeometry("MULTIPOINT M ((0 0 1), (1 1 -1))"), 0, 1, false)
+		require.EqualError(t, err, "geometric median input contains points with negative weights")
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geoprojbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+	"github.com/golang/geo/s2"
+	"github.com/twpayne/go-geom"
+)
+
+var spheroidRegexp = regexp.MustCompile(
+	`(?i)^\s*SPHEROID\s*\[\s*"([^"]*)"\s*,\s*([^,\s]+)\s*,\s*([^\]\s]+)\s*\]\s*$`,
+)
+
+// ParseSpheroid parses a spheroid of the form
+// SPHEROID["name",semi-major axis,inverse flattening], as used by PostGIS,
+// e.g. SPHEROID["WGS 84",6378137,298.257223563]. An inverse flattening of 0
+// denotes a sphere.
+func ParseSpheroid(s string) (geoprojbase.Spheroid, error) {
+	invalidSpheroidErr := pgerror.Newf(
+		pgcode.InvalidParameterValue,
+		`invalid spheroid %q: expected SPHEROID["name",semi-major axis,inverse flattening]`,
+		s,
+	)
+	matches := spheroidRegexp.FindStringSubmatch(s)
+	if matches == nil {
+		return nil, invalidSpheroidErr
+	}
+	radius, err := strconv.ParseFloat(matches[2], 64)
+	if err != nil || radius <= 0 || math.IsInf(radius, 0) {
+		return nil, invalidSpheroidErr
+	}
+	invFlattening, err := strconv.ParseFloat(matches[3], 64)
+	if err != nil || invFlattening < 0 || math.IsInf(invFlattening, 0) {
+		return nil, invalidSpheroidErr
+	}
+	flattening := 0.0
+	if invFlattening != 0 {
+		flattening = 1 / invFlattening
+	}
+	return geoprojbase.MakeSpheroid(radius, flattening)
+}
+
+// LengthSpheroid returns the length of the given Geometry on the given
+// spheroid in meters, treating coordinates as longitude and latitude in
+// degrees. Polygons contribute their perimeter. If use3D is set, Z
+// coordinates are taken into account as heights in meters.
+func LengthSpheroid(g geo.Geometry, spheroid geoprojbase.Spheroid, use3D bool) (float64, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return 0, err
+	}
+	return lengthSpheroidFromGeomT(t, spheroid, use3D)
+}
+
+func lengthSpheroidFromGeomT(t geom.T, spheroid geoprojbase.Spheroid, use3D bool) (float64, error) {
+	switch t := t.(type) {
+	case *geom.Point, *geom.MultiPoint:
+		return 0, nil
+	case *geom.LineString:
+		return lengthSpheroidFlatCoords(t.FlatCoords(), t.Layout(), spheroid, use3D), nil
+	case *geom.MultiLineString:
+		total := 0.0
+		for i := 0; i < t.NumLineStrings(); i++ {
+			ls := t.LineString(i)
+			total += lengthSpheroidFlatCoords(ls.FlatCoords(), ls.Layout(), spheroid, use3D)
+		}
+		return total, nil
+	case *geom.Polygon:
+		total := 0.0
+		for i := 0; i < t.NumLinearRings(); i++ {
+			ring := t.LinearRing(i)
+			total += lengthSpheroidFlatCoords(ring.FlatCoords(), ring.Layout(), spheroid, use3D)
+		}
+		return total, nil
+	case *geom.MultiPolygon:
+		total := 0.0
+		for i := 0; i < t.NumPolygons(); i++ {
+			length, err := lengthSpheroidFromGeomT(t.Polygon(i), spheroid, use3D)
+			if err != nil {
+				return 0, err
+			}
+			total += length
+		}
+		return total, nil
+	case *geom.GeometryCollection:
+		total := 0.0
+		for _, subT := range t.Geoms() {
+			length, err := lengthSpheroidFromGeomT(subT, spheroid, use3D)
+			if err != nil {
+				return 0, err
+			}
+			total += length
+		}
+		return total, nil
+	default:
+		return 0, errors.AssertionFailedf("unknown geometry type: %T", t)
+	}
+}
+
+// lengthSpheroidFlatCoords sums the geodesic lengths of the segments formed
+// by the given coordinates.
+func lengthSpheroidFlatCoords(
+	flatCoords []float64, layout geom.Layout, spheroid geoprojbase.Spheroid, use3D bool,
+) float64 {
+	stride := layout.Stride()
+	zIdx := layout.ZIndex()
+	total := 0.0
+	for i := stride; i < len(flatCoords); i += stride {
+		prev := flatCoords[i-stride : i]
+		curr := flatCoords[i : i+stride]
+		dist, _, _ := spheroid.Inverse(
+			s2.LatLngFromDegrees(prev[1], prev[0]),
+			s2.LatLngFromDegrees(curr[1], curr[0]),
+		)
+		if use3D && zIdx != -1 {
+			dz := curr[zIdx] - prev[zIdx]
+			dist = math.Sqrt(dist*dist + dz*dz)
+		}
+		total += dist
+	}
+	return total
+}
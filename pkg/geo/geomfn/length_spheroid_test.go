@@ -0,0 +1,84 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpheroid(t *testing.T) {
+	for _, s := range []string{
+		`SPHEROID["GRS_1980",6378137,298.257222101]`,
+		` spheroid [ "GRS_1980" , 6378137 , 298.257222101 ] `,
+	} {
+		spheroid, err := ParseSpheroid(s)
+		require.NoError(t, err)
+		require.Equal(t, 6378137.0, spheroid.Radius())
+		require.Equal(t, 1/298.257222101, spheroid.Flattening())
+	}
+
+	sphere, err := ParseSpheroid(`SPHEROID["sphere",6371000,0]`)
+	require.NoError(t, err)
+	require.Equal(t, 0.0, sphere.Flattening())
+
+	for _, s := range []string{
+		``,
+		`SPHEROID["GRS_1980",6378137]`,
+		`SPHEROID["GRS_1980",-6378137,298.257222101]`,
+		`SPHEROID["GRS_1980",6378137,abc]`,
+		`ELLIPSOID["GRS_1980",6378137,298.257222101]`,
+	} {
+		_, err := ParseSpheroid(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestLengthSpheroid(t *testing.T) {
+	spheroid, err := ParseSpheroid(`SPHEROID["GRS_1980",6378137,298.257222101]`)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		wkt        string
+		expected2D float64
+		expected3D float64
+	}{
+		{"POINT(1 2)", 0, 0},
+		{"LINESTRING EMPTY", 0, 0},
+		{"LINESTRING(-118.584 38.374, -118.583 38.5)", 13986.8725, 13986.8725},
+		{
+			"MULTILINESTRING((-118.584 38.374 20, -118.583 38.5 30), (-71.05957 42.3589 75, -71.061 43 90))",
+			85204.5208,
+			85204.5259,
+		},
+		{"POLYGON((0 0, 1 0, 1 1, 0 0))", 378793.4476, 378793.4476},
+		{
+			"GEOMETRYCOLLECTION(POINT(1 2), LINESTRING(-118.584 38.374, -118.583 38.5))",
+			13986.8725,
+			13986.8725,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			g, err := geo.ParseGeometry(tc.wkt)
+			require.NoError(t, err)
+			length2D, err := LengthSpheroid(g, spheroid, false /* use3D */)
+			require.NoError(t, err)
+			require.InDelta(t, tc.expected2D, length2D, 1e-4)
+			length3D, err := LengthSpheroid(g, spheroid, true /* use3D */)
+			require.NoError(t, err)
+			require.InDelta(t, tc.expected3D, length3D, 1e-4)
+		})
+	}
+}
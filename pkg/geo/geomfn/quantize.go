@@ -0,0 +1,66 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/twpayne/go-geom"
+)
+
+// QuantizeCoordinates zeroes out the low order bits of the mantissa of every
+// coordinate that are not needed to represent the given number of digits after
+// the decimal point. Precisions are given in X, Y, Z, M order. The resulting
+// geometry compresses better, at the cost of precision.
+func QuantizeCoordinates(g geo.Geometry, precision [4]int) (geo.Geometry, error) {
+	if g.Empty() {
+		return g, nil
+	}
+	t, err := g.AsGeomT()
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	t, err = applyOnCoordsForGeomT(t, func(l geom.Layout, dst []float64, src []float64) error {
+		dst[0] = quantizeOrdinate(src[0], precision[0])
+		dst[1] = quantizeOrdinate(src[1], precision[1])
+		if l.ZIndex() != -1 {
+			dst[l.ZIndex()] = quantizeOrdinate(src[l.ZIndex()], precision[2])
+		}
+		if l.MIndex() != -1 {
+			dst[l.MIndex()] = quantizeOrdinate(src[l.MIndex()], precision[3])
+		}
+		return nil
+	})
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.MakeGeometryFromGeomT(t)
+}
+
+// quantizeOrdinate masks out the mantissa bits of f that are not needed to
+// preserve the given number of decimal digits.
+func quantizeOrdinate(f float64, decimalDigits int) float64 {
+	if f == 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+		return f
+	}
+	digitsLeftOfDecimal := int(1 + math.Log10(math.Abs(f)))
+	// Each decimal digit needs log2(10) bits of mantissa.
+	bitsNeeded := int(math.Ceil(float64(decimalDigits+digitsLeftOfDecimal) / math.Log10(2)))
+	if bitsNeeded > 52 {
+		return f
+	}
+	if bitsNeeded < 1 {
+		bitsNeeded = 1
+	}
+	mask := ^uint64(0) << uint(52-bitsNeeded)
+	return math.Float64frombits(math.Float64bits(f) & mask)
+}
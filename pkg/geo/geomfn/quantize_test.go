@@ -0,0 +1,54 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuantizeCoordinates(t *testing.T) {
+	testCases := []struct {
+		wkt       string
+		precision [4]int
+		expected  string
+	}{
+		{"POINT(1.23456789 2.3456789)", [4]int{1, 2, 0, 0}, "POINT(1.234375 2.34375)"},
+		{"POINT(1.23456789 2.3456789)", [4]int{20, 20, 0, 0}, "POINT(1.23456789 2.3456789)"},
+		{"POINT(0 -0.5)", [4]int{1, 1, 1, 1}, "POINT(0 -0.5)"},
+		{
+			"POINT ZM (100.123456 0.5 3.1415926 3.1415926)",
+			[4]int{1, 2, 3, 20},
+			"POINT ZM (100.12109375 0.5 3.1414794921875 3.1415926)",
+		},
+		{
+			"LINESTRING(1.23456789 2.3456789, 3.456789 4.56789)",
+			[4]int{2, 2, 2, 2},
+			"LINESTRING(1.234375 2.34375, 3.455078125 4.56640625)",
+		},
+		{"POLYGON EMPTY", [4]int{1, 1, 1, 1}, "POLYGON EMPTY"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s/%v", tc.wkt, tc.precision), func(t *testing.T) {
+			g, err := geo.ParseGeometry(tc.wkt)
+			require.NoError(t, err)
+			ret, err := QuantizeCoordinates(g, tc.precision)
+			require.NoError(t, err)
+			expected, err := geo.ParseGeometry(tc.expected)
+			require.NoError(t, err)
+			require.Equal(t, expected, ret)
+		})
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+)
+
+// Split splits the given Geometry by the blade Geometry, returning a
+// GeometryCollection of the resulting parts. (Multi)LineStrings can be split
+// by (multi)points, (multi)linestrings or (multi)polygons, and (Multi)Polygons
+// can be split by (multi)linestrings.
+func Split(g geo.Geometry, blade geo.Geometry) (geo.Geometry, error) {
+	if g.SRID() != blade.SRID() {
+		return geo.Geometry{}, geo.NewMismatchingSRIDsError(g.SpatialObject(), blade.SpatialObject())
+	}
+	gc := geom.NewGeometryCollection().SetSRID(int(g.SRID()))
+	if g.Empty() {
+		return geo.MakeGeometryFromGeomT(gc)
+	}
+	var parts []geom.T
+	var err error
+	switch g.ShapeType2D() {
+	case geopb.ShapeType_LineString, geopb.ShapeType_MultiLineString:
+		parts, err = splitLines(g, blade)
+	case geopb.ShapeType_Polygon, geopb.ShapeType_MultiPolygon:
+		parts, err = splitPolygons(g, blade)
+	default:
+		return geo.Geometry{}, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"splitting a %s is not supported",
+			g.ShapeType2D(),
+		)
+	}
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	if err := gc.Push(parts...); err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.MakeGeometryFromGeomT(gc)
+}
+
+// splitLines splits a (Multi)LineString by the given blade.
+func splitLines(g geo.Geometry, blade geo.Geometry) ([]geom.T, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return nil, err
+	}
+	if blade.Empty() {
+		return []geom.T{t}, nil
+	}
+	switch blade.ShapeType2D() {
+	case geopb.ShapeType_Point, geopb.ShapeType_MultiPoint:
+		bladeT, err := blade.AsGeomT()
+		if err != nil {
+			return nil, err
+		}
+		var points []geom.Coord
+		if err := forEachDumpComponent(bladeT, nil /* path */, func(t geom.T, _ []int) error {
+			if !t.Empty() {
+				points = append(points, t.(*geom.Point).Coords())
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		var parts []geom.T
+		if err := forEachDumpComponent(t, nil /* path */, func(t geom.T, _ []int) error {
+			parts = append(parts, splitLineStringByPoints(t.(*geom.LineString), points)...)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		return parts, nil
+
+	case geopb.ShapeType_Polygon, geopb.ShapeType_MultiPolygon:
+		if blade, err = Boundary(blade); err != nil {
+			return nil, err
+		}
+		fallthrough
+	case geopb.ShapeType_LineString, geopb.ShapeType_MultiLineString:
+		overlaps, err := RelatePattern(g, blade, "1********")
+		if err != nil {
+			return nil, err
+		}
+		if overlaps {
+			return nil, pgerror.Newf(
+				pgcode.InvalidParameterValue,
+				"splitter line has linear intersection with input",
+			)
+		}
+		// The difference is noded wherever the blade crosses the input, so each
+		// of its components is one of the split parts.
+		diff, err := Difference(g, blade)
+		if err != nil {
+			return nil, err
+		}
+		return dumpGeomTs(diff)
+
+	default:
+		return nil, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"splitting a %s by a %s is not supported",
+			g.ShapeType2D(),
+			blade.ShapeType2D(),
+		)
+	}
+}
+
+// splitPolygons splits a (Multi)Polygon by the given blade. The boundary of
+// the input is noded against the blade and polygonized, and the polygons that
+// fall inside the input are kept.
+func splitPolygons(g geo.Geometry, blade geo.Geometry) ([]geom.T, error) {
+	switch blade.ShapeType2D() {
+	case geopb.ShapeType_LineString, geopb.ShapeType_MultiLineString:
+	default:
+		return nil, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"splitting a %s by a %s is not supported",
+			g.ShapeType2D(),
+			blade.ShapeType2D(),
+		)
+	}
+	if blade.Empty() {
+		t, err := g.AsGeomT()
+		if err != nil {
+			return nil, err
+		}
+		return []geom.T{t}, nil
+	}
+	boundary, err := Boundary(g)
+	if err != nil {
+		return nil, err
+	}
+	noded, err := Union(boundary, blade)
+	if err != nil {
+		return nil, err
+	}
+	polygons, err := Polygonize([]geo.Geometry{noded})
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := dumpGeomTs(polygons)
+	if err != nil {
+		return nil, err
+	}
+	var parts []geom.T
+	for _, c := range candidates {
+		candidate, err := geo.MakeGeometryFromGeomT(c)
+		if err != nil {
+			return nil, err
+		}
+		p, err := PointOnSurface(candidate)
+		if err != nil {
+			return nil, err
+		}
+		inside, err := Intersects(g, p)
+		if err != nil {
+			return nil, err
+		}
+		if inside {
+			parts = append(parts, c)
+		}
+	}
+	return parts, nil
+}
+
+// dumpGeomTs returns the non-empty, non-collection components of g.
+func dumpGeomTs(g geo.Geometry) ([]geom.T, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return nil, err
+	}
+	var ret []geom.T
+	if err := forEachDumpComponent(t, nil /* path */, func(t geom.T, _ []int) error {
+		if !t.Empty() {
+			geo.AdjustGeomTSRID(t, g.SRID())
+			ret = append(ret, t)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// splitLocation is a point at which a LineString is split, identified by the
+// segment it lies on and its fraction along that segment.
+type splitLocation struct {
+	seg   int
+	frac  float64
+	coord geom.Coord
+}
+
+// splitLineStringByPoints splits a LineString at each of the given points that
+// lies on it. Points that lie on the endpoints of the LineString, or not on
+// the LineString at all, do not split it.
+func splitLineStringByPoints(ls *geom.LineString, points []geom.Coord) []geom.T {
+	n := ls.NumCoords()
+	var locs []splitLocation
+	for _, p := range points {
+		for i := 0; i < n-1; i++ {
+			a, b := ls.Coord(i), ls.Coord(i+1)
+			frac, ok := pointOnSegment(a, b, p)
+			if !ok {
+				continue
+			}
+			if frac == 1 && i+1 < n-1 {
+				// The point is on the next vertex, which is found as the start of
+				// the next segment.
+				continue
+			}
+			if (i == 0 && frac == 0) || (i+1 == n-1 && frac == 1) {
+				break
+			}
+			coord := make(geom.Coord, len(a))
+			for d := range coord {
+				coord[d] = a[d] + frac*(b[d]-a[d])
+			}
+			coord[0], coord[1] = p[0], p[1]
+			locs = append(locs, splitLocation{seg: i, frac: frac, coord: coord})
+			break
+		}
+	}
+	if len(locs) == 0 {
+		return []geom.T{ls}
+	}
+	sort.Slice(locs, func(i, j int) bool {
+		if locs[i].seg != locs[j].seg {
+			return locs[i].seg < locs[j].seg
+		}
+		return locs[i].frac < locs[j].frac
+	})
+
+	var parts []geom.T
+	cur := append([]float64(nil), ls.Coord(0)...)
+	next := 0
+	for i := 0; i < n-1; i++ {
+		for ; next < len(locs) && locs[next].seg == i; next++ {
+			l := locs[next]
+			if next > 0 && locs[next-1].seg == l.seg && locs[next-1].frac == l.frac {
+				continue
+			}
+			// A split on a vertex ends the current part at that vertex, which is
+			// already the last coordinate of the part.
+			if l.frac > 0 {
+				cur = append(cur, l.coord...)
+			}
+			parts = append(parts, geom.NewLineStringFlat(ls.Layout(), cur).SetSRID(ls.SRID()))
+			cur = append([]float64(nil), l.coord...)
+		}
+		cur = append(cur, ls.Coord(i+1)...)
+	}
+	return append(parts, geom.NewLineStringFlat(ls.Layout(), cur).SetSRID(ls.SRID()))
+}
+
+// pointOnSegment returns whether p lies on the segment from a to b, along with
+// the fraction of the way from a to b at which it lies.
+func pointOnSegment(a, b, p geom.Coord) (float64, bool) {
+	dx, dy := b.X()-a.X(), b.Y()-a.Y()
+	if dx == 0 && dy == 0 {
+		return 0, false
+	}
+	if (p.X()-a.X())*dy != (p.Y()-a.Y())*dx {
+		return 0, false
+	}
+	var frac float64
+	if math.Abs(dx) >= math.Abs(dy) {
+		frac = (p.X() - a.X()) / dx
+	} else {
+		frac = (p.Y() - a.Y()) / dy
+	}
+	return frac, frac >= 0 && frac <= 1
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestSplit(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		g        string
+		blade    string
+		expected string
+	}{
+		{
+			desc:     "line by point",
+			g:        "LINESTRING (0 0, 2 0, 2 2)",
+			blade:    "POINT (1 0)",
+			expected: "GEOMETRYCOLLECTION (LINESTRING (0 0, 1 0), LINESTRING (1 0, 2 0, 2 2))",
+		},
+		{
+			desc:     "line by points including a vertex",
+			g:        "LINESTRING (0 0, 2 0, 2 2)",
+			blade:    "MULTIPOINT (2 1, 2 0, 5 5)",
+			expected: "GEOMETRYCOLLECTION (LINESTRING (0 0, 2 0), LINESTRING (2 0, 2 1), LINESTRING (2 1, 2 2))",
+		},
+		{
+			desc:     "line by point at its endpoint",
+			g:        "LINESTRING (0 0, 2 0)",
+			blade:    "POINT (2 0)",
+			expected: "GEOMETRYCOLLECTION (LINESTRING (0 0, 2 0))",
+		},
+		{
+			desc:     "line with z by point",
+			g:        "LINESTRING Z (0 0 0, 4 0 8)",
+			blade:    "POINT (1 0)",
+			expected: "GEOMETRYCOLLECTION Z (LINESTRING Z (0 0 0, 1 0 2), LINESTRING Z (1 0 2, 4 0 8))",
+		},
+		{
+			desc:     "multiline by point",
+			g:        "SRID=4326;MULTILINESTRING ((0 0, 2 0), (0 1, 2 1))",
+			blade:    "SRID=4326;POINT (1 1)",
+			expected: "SRID=4326;GEOMETRYCOLLECTION (LINESTRING (0 0, 2 0), LINESTRING (0 1, 1 1), LINESTRING (1 1, 2 1))",
+		},
+		{
+			desc:     "empty input",
+			g:        "LINESTRING EMPTY",
+			blade:    "POINT (1 0)",
+			expected: "GEOMETRYCOLLECTION EMPTY",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := Split(geo.MustParseGeometry(tc.g), geo.MustParseGeometry(tc.blade))
+			require.NoError(t, err)
+			ewkt, err := geo.SpatialObjectToEWKT(got.SpatialObject(), -1)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(ewkt))
+		})
+	}
+
+	t.Run("line by line", func(t *testing.T) {
+		got, err := Split(
+			geo.MustParseGeometry("LINESTRING (0 0, 4 0)"),
+			geo.MustParseGeometry("MULTILINESTRING ((1 -1, 1 1), (3 -1, 3 1))"),
+		)
+		require.NoError(t, err)
+		requireSplitParts(t, got, 3, 4)
+	})
+
+	t.Run("line by polygon", func(t *testing.T) {
+		got, err := Split(
+			geo.MustParseGeometry("LINESTRING (0 0, 4 0)"),
+			geo.MustParseGeometry("POLYGON ((1 -1, 3 -1, 3 1, 1 1, 1 -1))"),
+		)
+		require.NoError(t, err)
+		requireSplitParts(t, got, 3, 4)
+	})
+
+	t.Run("polygon by line", func(t *testing.T) {
+		got, err := Split(
+			geo.MustParseGeometry("POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0))"),
+			geo.MustParseGeometry("LINESTRING (2 -1, 2 5)"),
+		)
+		require.NoError(t, err)
+		requireSplitParts(t, got, 2, 16)
+	})
+
+	t.Run("line overlapping blade", func(t *testing.T) {
+		_, err := Split(
+			geo.MustParseGeometry("LINESTRING (0 0, 4 0)"),
+			geo.MustParseGeometry("LINESTRING (1 0, 2 0)"),
+		)
+		require.EqualError(t, err, "splitter line has linear intersection with input")
+	})
+
+	t.Run("unsupported blade", func(t *testing.T) {
+		_, err := Split(
+			geo.MustParseGeometry("POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0))"),
+			geo.MustParseGeometry("POINT (1 1)"),
+		)
+		require.EqualError(t, err, "splitting a Polygon by a Point is not supported")
+	})
+
+	t.Run("unsupported input", func(t *testing.T) {
+		_, err := Split(geo.MustParseGeometry("POINT (1 1)"), geo.MustParseGeometry("POINT (1 1)"))
+		require.EqualError(t, err, "splitting a Point is not supported")
+	})
+
+	t.Run("mismatching SRIDs", func(t *testing.T) {
+		_, err := Split(
+			geo.MustParseGeometry("LINESTRING (0 0, 4 0)"),
+			geo.MustParseGeometry("SRID=4326;POINT (1 0)"),
+		)
+		require.Error(t, err)
+	})
+}
+
+// requireSplitParts checks that the result of Split has the given number of
+// parts, whose total length (for lines) or area (for polygons) is the given
+// measure.
+func requireSplitParts(t *testing.T, got geo.Geometry, numParts int, measure float64) {
+	gotT, err := got.AsGeomT()
+	require.NoError(t, err)
+	gc := gotT.(*geom.GeometryCollection)
+	require.Equal(t, numParts, gc.NumGeoms())
+	var total float64
+	for i := 0; i < gc.NumGeoms(); i++ {
+		part, err := geo.MakeGeometryFromGeomT(gc.Geom(i))
+		require.NoError(t, err)
+		var m float64
+		if _, ok := gc.Geom(i).(*geom.Polygon); ok {
+			m, err = Area(part)
+		} else {
+			m, err = Length(part)
+		}
+		require.NoError(t, err)
+		total += m
+	}
+	require.InDelta(t, measure, total, 1e-9)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/geo/geos"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
 )
 
 // Boundary returns the boundary of a given Geometry.
@@ -214,6 +215,66 @@ func MinimumRotatedRectangle(g geo.Geometry) (geo.Geometry, error) {
 	return gm, nil
 }
 
+// Polygonize returns a GeometryCollection of the polygons formed by the
+// linework of the given Geometries.
+func Polygonize(gs []geo.Geometry) (geo.Geometry, error) {
+	gc := geom.NewGeometryCollection()
+	for i, g := range gs {
+		if g.SRID() != gs[0].SRID() {
+			return geo.Geometry{}, geo.NewMismatchingSRIDsError(gs[0].SpatialObject(), g.SpatialObject())
+		}
+		t, err := g.AsGeomT()
+		if err != nil {
+			return geo.Geometry{}, err
+		}
+		if i == 0 {
+			gc.SetSRID(t.SRID())
+		}
+		if err := gc.Push(t); err != nil {
+			return geo.Geometry{}, err
+		}
+	}
+	collected, err := geo.MakeGeometryFromGeomT(gc)
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	retEWKB, err := geos.Polygonize(collected.EWKB())
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.ParseGeometryFromEWKB(retEWKB)
+}
+
+// BuildArea returns the areal Geometry formed by the constituent linework of
+// the given Geometry.
+func BuildArea(g geo.Geometry) (geo.Geometry, error) {
+	retEWKB, err := geos.BuildArea(g.EWKB())
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.ParseGeometryFromEWKB(retEWKB)
+}
+
+// ConcaveHull returns a possibly concave Geometry enclosing all the vertices
+// of the given Geometry. targetPercent ranges from 0, which produces the most
+// concave hull, to 1, which produces the convex hull.
+func ConcaveHull(g geo.Geometry, targetPercent float64, allowHoles bool) (geo.Geometry, error) {
+	if targetPercent < 0 || targetPercent > 1 || math.IsNaN(targetPercent) {
+		return geo.Geometry{}, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"target_percent must be between 0 and 1",
+		)
+	}
+	if g.Empty() {
+		return g, nil
+	}
+	retEWKB, err := geos.ConcaveHull(g.EWKB(), targetPercent, allowHoles)
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.ParseGeometryFromEWKB(retEWKB)
+}
+
 // BoundingBoxHasInfiniteCoordinates checks if the bounding box of a Geometry
 // has an infinite coordinate.
 func BoundingBoxHasInfiniteCoordinates(g geo.Geometry) bool {
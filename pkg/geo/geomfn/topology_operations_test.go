@@ -552,3 +552,121 @@ func TestMinimumRotatedRectangle(t *testing.T) {
 		})
 	}
 }
+
+func TestPolygonize(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		numPolygons int
+		area        float64
+	}{
+		{
+			"closed ring from two lines",
+			[]string{"LINESTRING (0 0, 0 1, 1 1)", "LINESTRING (1 1, 1 0, 0 0)"},
+			1,
+			1,
+		},
+		{
+			"shared edge forms two polygons",
+			[]string{
+				"LINESTRING (1 0, 0 0, 0 1, 1 1)",
+				"LINESTRING (1 1, 2 1, 2 0, 1 0)",
+				"LINESTRING (1 0, 1 1)",
+			},
+			2,
+			2,
+		},
+		{
+			"dangling lines do not form polygons",
+			[]string{"LINESTRING (0 0, 1 1)", "LINESTRING (1 1, 2 0)"},
+			0,
+			0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gs []geo.Geometry
+			for _, arg := range tt.args {
+				gs = append(gs, geo.MustParseGeometry(arg))
+			}
+			got, err := Polygonize(gs)
+			require.NoError(t, err)
+			gotT, err := got.AsGeomT()
+			require.NoError(t, err)
+			require.Equal(t, tt.numPolygons, gotT.(*geom.GeometryCollection).NumGeoms())
+			area, err := Area(got)
+			require.NoError(t, err)
+			require.InDelta(t, tt.area, area, 1e-9)
+		})
+	}
+
+	t.Run("mismatching SRIDs", func(t *testing.T) {
+		_, err := Polygonize([]geo.Geometry{
+			geo.MustParseGeometry("LINESTRING (0 0, 1 1)"),
+			geo.MustParseGeometry("SRID=4326;LINESTRING (0 0, 1 1)"),
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestBuildArea(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  geo.Geometry
+		want geo.Geometry
+	}{
+		{
+			"closed linestring",
+			geo.MustParseGeometry("LINESTRING (0 0, 0 2, 2 2, 2 0, 0 0)"),
+			geo.MustParseGeometry("POLYGON ((0 0, 0 2, 2 2, 2 0, 0 0))"),
+		},
+		{
+			"nested rings form a hole",
+			geo.MustParseGeometry("MULTILINESTRING ((0 0, 0 4, 4 4, 4 0, 0 0), (1 1, 1 2, 2 2, 2 1, 1 1))"),
+			geo.MustParseGeometry("POLYGON ((0 0, 0 4, 4 4, 4 0, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildArea(tt.arg)
+			require.NoError(t, err)
+			eq, err := Equals(got, tt.want)
+			require.NoError(t, err)
+			require.True(t, eq)
+		})
+	}
+}
+
+func TestConcaveHull(t *testing.T) {
+	t.Run("target percent of 1 is the convex hull", func(t *testing.T) {
+		g := geo.MustParseGeometry("MULTIPOINT ((0 0), (4 0), (4 4), (0 4), (2 3))")
+		got, err := ConcaveHull(g, 1, false)
+		require.NoError(t, err)
+		eq, err := Equals(got, geo.MustParseGeometry("POLYGON ((0 0, 0 4, 4 4, 4 0, 0 0))"))
+		require.NoError(t, err)
+		require.True(t, eq)
+	})
+
+	t.Run("concave hull is covered by the convex hull", func(t *testing.T) {
+		g := geo.MustParseGeometry("MULTIPOINT ((0 0), (4 0), (4 4), (0 4), (2 3), (1 1), (3 1))")
+		got, err := ConcaveHull(g, 0.5, false)
+		require.NoError(t, err)
+		hull, err := ConvexHull(g)
+		require.NoError(t, err)
+		covers, err := Covers(hull, got)
+		require.NoError(t, err)
+		require.True(t, covers)
+	})
+
+	t.Run("empty geometry", func(t *testing.T) {
+		g := geo.MustParseGeometry("MULTIPOINT EMPTY")
+		got, err := ConcaveHull(g, 0.5, false)
+		require.NoError(t, err)
+		require.Equal(t, g, got)
+	})
+
+	t.Run("invalid target percent", func(t *testing.T) {
+		_, err := ConcaveHull(geo.MustParseGeometry("POINT (0 0)"), 1.5, false)
+		require.EqualError(t, err, "target_percent must be between 0 and 1")
+	})
+}
@@ -174,6 +174,12 @@ typedef char (*CR_GEOS_EqualsExact_r)(CR_GEOS_Handle, CR_GEOS_Geometry,
 
 typedef CR_GEOS_Geometry (*CR_GEOS_MinimumRotatedRectangle_r)(CR_GEOS_Handle, CR_GEOS_Geometry);
 
+typedef CR_GEOS_Geometry (*CR_GEOS_Polygonize_r)(CR_GEOS_Handle, const CR_GEOS_Geometry*,
+                                                 unsigned int);
+typedef CR_GEOS_Geometry (*CR_GEOS_BuildArea_r)(CR_GEOS_Handle, CR_GEOS_Geometry);
+typedef CR_GEOS_Geometry (*CR_GEOS_ConcaveHull_r)(CR_GEOS_Handle, CR_GEOS_Geometry, double,
+                                                  unsigned int);
+
 typedef CR_GEOS_Geometry (*CR_GEOS_Snap_r)(CR_GEOS_Handle, CR_GEOS_Geometry, CR_GEOS_Geometry, double);
 typedef const char* (*CR_GEOS_Version_r)();
 
@@ -283,6 +289,9 @@ struct CR_GEOS {
   CR_GEOS_VoronoiDiagram_r GEOSVoronoiDiagram_r;
   CR_GEOS_EqualsExact_r GEOSEqualsExact_r;
   CR_GEOS_MinimumRotatedRectangle_r GEOSMinimumRotatedRectangle_r;
+  CR_GEOS_Polygonize_r GEOSPolygonize_r;
+  CR_GEOS_BuildArea_r GEOSBuildArea_r;
+  CR_GEOS_ConcaveHull_r GEOSConcaveHull_r;
 
   CR_GEOS_Node_r GEOSNode_r;
 
@@ -375,6 +384,9 @@ struct CR_GEOS {
     INIT(GEOSVoronoiDiagram_r);
     INIT(GEOSEqualsExact_r);
     INIT(GEOSMinimumRotatedRectangle_r);
+    INIT(GEOSPolygonize_r);
+    INIT(GEOSBuildArea_r);
+    INIT(GEOSConcaveHull_r);
     INIT(GEOSRelateBoundaryNodeRule_r);
     INIT(GEOSRelatePattern_r);
     INIT(GEOSSharedPaths_r);
@@ -1561,3 +1573,68 @@ CR_GEOS_Status CR_GEOS_Snap(CR_GEOS* lib, CR_GEOS_Slice input, CR_GEOS_Slice tar
   lib->GEOS_finish_r(handle);
   return toGEOSString(error.data(), error.length());
 }
+
+CR_GEOS_Status CR_GEOS_Polygonize(CR_GEOS* lib, CR_GEOS_Slice a, CR_GEOS_String* ret) {
+  std::string error;
+  auto handle = initHandleWithErrorBuffer(lib, &error);
+  auto geom = CR_GEOS_GeometryFromSlice(lib, handle, a);
+  *ret = {.data = NULL, .len = 0};
+  if (geom != nullptr) {
+    // The polygonizer extracts the linework of every component of the given
+    // geometries, so a single collection of all the inputs can be passed in.
+    auto r = lib->GEOSPolygonize_r(handle, &geom, 1);
+    if (r != NULL) {
+      auto srid = lib->GEOSGetSRID_r(handle, geom);
+      CR_GEOS_writeGeomToEWKB(lib, handle, r, ret, srid);
+      lib->GEOSGeom_destroy_r(handle, r);
+    }
+    lib->GEOSGeom_destroy_r(handle, geom);
+  }
+
+  lib->GEOS_finish_r(handle);
+  return toGEOSString(error.data(), error.length());
+}
+
+CR_GEOS_Status CR_GEOS_BuildArea(CR_GEOS* lib, CR_GEOS_Slice a, CR_GEOS_String* ret) {
+  std::string error;
+  auto handle = initHandleWithErrorBuffer(lib, &error);
+  auto geom = CR_GEOS_GeometryFromSlice(lib, handle, a);
+  *ret = {.data = NULL, .len = 0};
+  if (geom != nullptr) {
+    auto r = lib->GEOSBuildArea_r(handle, geom);
+    if (r != NULL) {
+      auto srid = lib->GEOSGetSRID_r(handle, geom);
+      CR_GEOS_writeGeomToEWKB(lib, handle, r, ret, srid);
+      lib->GEOSGeom_destroy_r(handle, r);
+    }
+    lib->GEOSGeom_destroy_r(handle, geom);
+  }
+
+  lib->GEOS_finish_r(handle);
+  return toGEOSString(error.data(), error.length());
+}
+
+CR_GEOS_Status CR_GEOS_ConcaveHull(CR_GEOS* lib, CR_GEOS_Slice a, double ratio, char allowHoles,
+                                   CR_GEOS_String* ret) {
+  *ret = {.data = NULL, .len = 0};
+  // GEOSConcaveHull_r is only available from GEOS 3.11 onwards.
+  if (lib->GEOSConcaveHull_r == nullptr) {
+    std::string error = "concave hull requires GEOS 3.11 or later";
+    return toGEOSString(error.data(), error.length());
+  }
+  std::string error;
+  auto handle = initHandleWithErrorBuffer(lib, &error);
+  auto geom = CR_GEOS_GeometryFromSlice(lib, handle, a);
+  if (geom != nullptr) {
+    auto r = lib->GEOSConcaveHull_r(handle, geom, ratio, allowHoles);
+    if (r != NULL) {
+      auto srid = lib->GEOSGetSRID_r(handle, geom);
+      CR_GEOS_writeGeomToEWKB(lib, handle, r, ret, srid);
+      lib->GEOSGeom_destroy_r(handle, r);
+    }
+    lib->GEOSGeom_destroy_r(handle, geom);
+  }
+
+  lib->GEOS_finish_r(handle);
+  return toGEOSString(error.data(), error.length());
+}
@@ -1112,6 +1112,54 @@ func MinimumRotatedRectangle(ewkb geopb.EWKB) (geopb.EWKB, error) {
 	return cStringToSafeGoBytes(cEWKB), nil
 }
 
+// Polygonize returns a GeometryCollection of the polygons formed from the
+// linework of the given EWKB.
+func Polygonize(ewkb geopb.EWKB) (geopb.EWKB, error) {
+	g, err := ensureInitInternal()
+	if err != nil {
+		return nil, err
+	}
+	var cEWKB C.CR_GEOS_String
+	if err := statusToError(C.CR_GEOS_Polygonize(g, goToCSlice(ewkb), &cEWKB)); err != nil {
+		return nil, err
+	}
+	return cStringToSafeGoBytes(cEWKB), nil
+}
+
+// BuildArea returns the areal geometry formed by the constituent linework of
+// the given EWKB.
+func BuildArea(ewkb geopb.EWKB) (geopb.EWKB, error) {
+	g, err := ensureInitInternal()
+	if err != nil {
+		return nil, err
+	}
+	var cEWKB C.CR_GEOS_String
+	if err := statusToError(C.CR_GEOS_BuildArea(g, goToCSlice(ewkb), &cEWKB)); err != nil {
+		return nil, err
+	}
+	return cStringToSafeGoBytes(cEWKB), nil
+}
+
+// ConcaveHull returns a concave hull of the given EWKB. ratio ranges from 0,
+// which produces the most concave hull, to 1, which produces the convex hull.
+func ConcaveHull(ewkb geopb.EWKB, ratio float64, allowHoles bool) (geopb.EWKB, error) {
+	g, err := ensureInitInternal()
+	if err != nil {
+		return nil, err
+	}
+	var cEWKB C.CR_GEOS_String
+	flag := 0
+	if allowHoles {
+		flag = 1
+	}
+	if err := statusToError(
+		C.CR_GEOS_ConcaveHull(g, goToCSlice(ewkb), C.double(ratio), C.char(flag), &cEWKB),
+	); err != nil {
+		return nil, err
+	}
+	return cStringToSafeGoBytes(cEWKB), nil
+}
+
 // Snap returns the input EWKB with the vertices snapped to the target
 // EWKB. Tolerance is used to control where snapping is performed.
 // If no snapping occurs then the input geometry is returned unchanged.
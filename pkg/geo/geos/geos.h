@@ -135,6 +135,10 @@ CR_GEOS_Status CR_GEOS_MinimumBoundingCircle(CR_GEOS* lib, CR_GEOS_Slice a, doub
                                               CR_GEOS_String* centerEWKB, CR_GEOS_String* polygonEWKB);
 
 CR_GEOS_Status CR_GEOS_MinimumRotatedRectangle(CR_GEOS* lib, CR_GEOS_Slice g, CR_GEOS_String* ret);
+CR_GEOS_Status CR_GEOS_Polygonize(CR_GEOS* lib, CR_GEOS_Slice a, CR_GEOS_String* ret);
+CR_GEOS_Status CR_GEOS_BuildArea(CR_GEOS* lib, CR_GEOS_Slice a, CR_GEOS_String* ret);
+CR_GEOS_Status CR_GEOS_ConcaveHull(CR_GEOS* lib, CR_GEOS_Slice a, double ratio, char allowHoles,
+                                   CR_GEOS_String* ret);
 //
 // Linear reference.
 //
@@ -0,0 +1,943 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/geo/geoprojbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/ewkb"
+)
+
+// SpatialObjectToGMLFlag maps to the ST_AsGML options for PostGIS.
+type SpatialObjectToGMLFlag int
+
+// These should be kept with ST_AsGML in PostGIS.
+// 0: GML Short CRS (e.g EPSG:4326) (default)
+// 1: GML Long CRS (e.g urn:ogc:def:crs:EPSG::4326)
+// 2: GML 3 only, omit the srsDimension attribute
+// 4: GML 3 only, use <LineString> rather than <Curve> for lines
+// 16: GML 3 only, declare that the data is lat/lng, writing Y before X
+// 32: output the bounding box of the object
+const (
+	SpatialObjectToGMLFlagLongCRS SpatialObjectToGMLFlag = 1 << (iota)
+	SpatialObjectToGMLFlagOmitSRSDimension
+	SpatialObjectToGMLFlagLineString
+	_
+	SpatialObjectToGMLFlagLatLng
+	SpatialObjectToGMLFlagEnvelope
+
+	SpatialObjectToGMLFlagZero = 0
+)
+
+// DefaultGMLPrefix is the namespace prefix used for GML elements.
+const DefaultGMLPrefix = "gml"
+
+// SpatialObjectToGML transforms a given SpatialObject to GML of the given
+// version, which must be 2 or 3. Elements are written with the given
+// namespace prefix, which may be empty.
+func SpatialObjectToGML(
+	so geopb.SpatialObject,
+	version int,
+	maxDecimalDigits int,
+	flag SpatialObjectToGMLFlag,
+	prefix string,
+) (string, error) {
+	if version != 2 && version != 3 {
+		return "", pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"only GML 2 and GML 3 are supported",
+		)
+	}
+	t, err := ewkb.Unmarshal([]byte(so.EWKB))
+	if err != nil {
+		return "", err
+	}
+	e := gmlEncoder{
+		version:          version,
+		maxDecimalDigits: maxDecimalDigits,
+		flag:             flag,
+		dims:             2,
+	}
+	if prefix != "" {
+		e.prefix = prefix + ":"
+	}
+	if t.Layout().ZIndex() != -1 {
+		e.dims = 3
+	}
+	var srsName string
+	if t.SRID() != 0 {
+		projection, err := geoprojbase.Projection(geopb.SRID(t.SRID()))
+		if err != nil {
+			return "", err
+		}
+		if flag&SpatialObjectToGMLFlagLongCRS != 0 {
+			srsName = fmt.Sprintf("urn:ogc:def:crs:%s::%d", projection.AuthName, projection.AuthSRID)
+		} else {
+			srsName = fmt.Sprintf("%s:%d", projection.AuthName, projection.AuthSRID)
+		}
+	}
+	if flag&SpatialObjectToGMLFlagEnvelope != 0 {
+		e.writeEnvelope(t, srsName)
+	} else if err := e.write(t, srsName); err != nil {
+		return "", err
+	}
+	return e.buf.String(), nil
+}
+
+// gmlEncoder writes a geom.T as GML.
+type gmlEncoder struct {
+	buf              strings.Builder
+	version          int
+	maxDecimalDigits int
+	flag             SpatialObjectToGMLFlag
+	prefix           string
+	// dims is the number of dimensions written for each coordinate. GML has
+	// no notion of M coordinates, so these are dropped.
+	dims int
+}
+
+func (e *gmlEncoder) open(name string, srsName string) {
+	e.writeStartTag(name, srsName)
+	e.buf.WriteString(">")
+}
+
+func (e *gmlEncoder) writeStartTag(name string, srsName string) {
+	e.buf.WriteString("<" + e.prefix + name)
+	if srsName != "" {
+		fmt.Fprintf(&e.buf, ` srsName="%s"`, srsName)
+	}
+}
+
+func (e *gmlEncoder) close(name string) {
+	e.buf.WriteString("</" + e.prefix + name + ">")
+}
+
+// writeEmpty writes a self-closing element, used for empty geometries.
+func (e *gmlEncoder) writeEmpty(name string, srsName string) {
+	e.writeStartTag(name, srsName)
+	e.buf.WriteString("/>")
+}
+
+func (e *gmlEncoder) write(t geom.T, srsName string) error {
+	switch t := t.(type) {
+	case *geom.Point:
+		if t.Empty() {
+			e.writeEmpty("Point", srsName)
+			return nil
+		}
+		e.open("Point", srsName)
+		if e.version == 2 {
+			e.writeCoordinates(t.FlatCoords(), t.Layout())
+		} else {
+			e.writePosList("pos", t.FlatCoords(), t.Layout())
+		}
+		e.close("Point")
+	case *geom.LineString:
+		switch {
+		case t.Empty():
+			e.writeEmpty("LineString", srsName)
+		case e.version == 2:
+			e.open("LineString", srsName)
+			e.writeCoordinates(t.FlatCoords(), t.Layout())
+			e.close("LineString")
+		case e.flag&SpatialObjectToGMLFlagLineString != 0:
+			e.open("LineString", srsName)
+			e.writePosList("posList", t.FlatCoords(), t.Layout())
+			e.close("LineString")
+		default:
+			e.open("Curve", srsName)
+			e.open("segments", "")
+			e.open("LineStringSegment", "")
+			e.writePosList("posList", t.FlatCoords(), t.Layout())
+			e.close("LineStringSegment")
+			e.close("segments")
+			e.close("Curve")
+		}
+	case *geom.Polygon:
+		if t.Empty() {
+			e.writeEmpty("Polygon", srsName)
+			return nil
+		}
+		exterior, interior := "exterior", "interior"
+		if e.version == 2 {
+			exterior, interior = "outerBoundaryIs", "innerBoundaryIs"
+		}
+		e.open("Polygon", srsName)
+		for i := 0; i < t.NumLinearRings(); i++ {
+			boundary := exterior
+			if i > 0 {
+				boundary = interior
+			}
+			ring := t.LinearRing(i)
+			e.open(boundary, "")
+			e.open("LinearRing", "")
+			if e.version == 2 {
+				e.writeCoordinates(ring.FlatCoords(), ring.Layout())
+			} else {
+				e.writePosList("posList", ring.FlatCoords(), ring.Layout())
+			}
+			e.close("LinearRing")
+			e.close(boundary)
+		}
+		e.close("Polygon")
+	case *geom.MultiPoint:
+		return e.writeCollection("MultiPoint", "pointMember", srsName, t.NumPoints(), func(i int) geom.T {
+			return t.Point(i)
+		})
+	case *geom.MultiLineString:
+		name, member := "MultiCurve", "curveMember"
+		if e.version == 2 {
+			name, member = "MultiLineString", "lineStringMember"
+		}
+		return e.writeCollection(name, member, srsName, t.NumLineStrings(), func(i int) geom.T {
+			return t.LineString(i)
+		})
+	case *geom.MultiPolygon:
+		name, member := "MultiSurface", "surfaceMember"
+		if e.version == 2 {
+			name, member = "MultiPolygon", "polygonMember"
+		}
+		return e.writeCollection(name, member, srsName, t.NumPolygons(), func(i int) geom.T {
+			return t.Polygon(i)
+		})
+	case *geom.GeometryCollection:
+		return e.writeCollection("MultiGeometry", "geometryMember", srsName, t.NumGeoms(), func(i int) geom.T {
+			return t.Geom(i)
+		})
+	default:
+		return errors.AssertionFailedf("unknown geometry type: %T", t)
+	}
+	return nil
+}
+
+// writeCollection writes a collection element, with each of its n elements
+// wrapped in a member element.
+func (e *gmlEncoder) writeCollection(
+	name string, member string, srsName string, n int, elem func(i int) geom.T,
+) error {
+	if n == 0 {
+		e.writeEmpty(name, srsName)
+		return nil
+	}
+	e.open(name, srsName)
+	for i := 0; i < n; i++ {
+		e.open(member, "")
+		if err := e.write(elem(i), "" /* srsName */); err != nil {
+			return err
+		}
+		e.close(member)
+	}
+	e.close(name)
+	return nil
+}
+
+// writeEnvelope writes the bounding box of t as a GML 2 Box or a GML 3
+// Envelope.
+func (e *gmlEncoder) writeEnvelope(t geom.T, srsName string) {
+	name := "Envelope"
+	if e.version == 2 {
+		name = "Box"
+	}
+	if t.Empty() {
+		e.writeEmpty(name, srsName)
+		return
+	}
+	bounds := t.Bounds()
+	corners := make([]float64, 0, 2*e.dims)
+	corners = append(corners, bounds.Min(0), bounds.Min(1))
+	if e.dims == 3 {
+		corners = append(corners, bounds.Min(t.Layout().ZIndex()))
+	}
+	corners = append(corners, bounds.Max(0), bounds.Max(1))
+	if e.dims == 3 {
+		corners = append(corners, bounds.Max(t.Layout().ZIndex()))
+	}
+	layout := geom.XY
+	if e.dims == 3 {
+		layout = geom.XYZ
+	}
+	e.open(name, srsName)
+	if e.version == 2 {
+		e.writeCoordinates(corners, layout)
+	} else {
+		e.writePosList("lowerCorner", corners[:e.dims], layout)
+		e.writePosList("upperCorner", corners[e.dims:], layout)
+	}
+	e.close(name)
+}
+
+// writeCoordinates writes a GML 2 coordinates element, which separates the
+// values of a coordinate with commas and coordinates with spaces.
+func (e *gmlEncoder) writeCoordinates(flatCoords []float64, layout geom.Layout) {
+	e.open("coordinates", "")
+	e.writeFlatCoords(flatCoords, layout, ",")
+	e.close("coordinates")
+}
+
+// writePosList writes a GML 3 pos or posList element, which separates all
+// values with spaces.
+func (e *gmlEncoder) writePosList(name string, flatCoords []float64, layout geom.Layout) {
+	e.buf.WriteString("<" + e.prefix + name)
+	if e.flag&SpatialObjectToGMLFlagOmitSRSDimension == 0 {
+		fmt.Fprintf(&e.buf, ` srsDimension="%d"`, e.dims)
+	}
+	e.buf.WriteString(">")
+	e.writeFlatCoords(flatCoords, layout, " ")
+	e.close(name)
+}
+
+func (e *gmlEncoder) writeFlatCoords(flatCoords []float64, layout geom.Layout, sep string) {
+	stride := layout.Stride()
+	swapXY := e.version == 3 && e.flag&SpatialObjectToGMLFlagLatLng != 0
+	for i := 0; i < len(flatCoords); i += stride {
+		if i > 0 {
+			e.buf.WriteString(" ")
+		}
+		x, y := flatCoords[i], flatCoords[i+1]
+		if swapXY {
+			x, y = y, x
+		}
+		e.buf.WriteString(formatCoord(x, e.maxDecimalDigits))
+		e.buf.WriteString(sep)
+		e.buf.WriteString(formatCoord(y, e.maxDecimalDigits))
+		if e.dims == 3 {
+			e.buf.WriteString(sep)
+			e.buf.WriteString(formatCoord(flatCoords[i+layout.ZIndex()], e.maxDecimalDigits))
+		}
+	}
+}
+
+// formatCoord formats a coordinate value with at most maxDecimalDigits
+// decimal digits, trimming any trailing zeros. A negative maxDecimalDigits
+// means full precision.
+func formatCoord(f float64, maxDecimalDigits int) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if maxDecimalDigits >= 0 {
+		if dot := strings.IndexByte(s, '.'); dot != -1 && len(s)-dot-1 > maxDecimalDigits {
+			s = strconv.FormatFloat(f, 'f', maxDecimalDigits, 64)
+			if strings.IndexByte(s, '.') != -1 {
+				s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+			}
+		}
+	}
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// gmlNode is an element of a GML document. Namespaces are ignored.
+type gmlNode struct {
+	name     string
+	attrs    map[string]string
+	children []*gmlNode
+	text     string
+}
+
+// child returns the first child with any of the given names, or nil if there
+// is none.
+func (n *gmlNode) child(names ...string) *gmlNode {
+	for _, c := range n.children {
+		for _, name := range names {
+			if c.name == name {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// parseGMLTree parses a GML document into a tree of gmlNodes, returning the
+// root element.
+func parseGMLTree(b []byte) (*gmlNode, error) {
+	d := xml.NewDecoder(bytes.NewReader(b))
+	var root *gmlNode
+	var stack []*gmlNode
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid GML representation")
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &gmlNode{name: tok.Name.Local, attrs: make(map[string]string, len(tok.Attr))}
+			for _, attr := range tok.Attr {
+				n.attrs[attr.Name.Local] = attr.Value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root != nil {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: multiple root elements")
+			} else {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(tok)
+			}
+		}
+	}
+	if root == nil {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: no root element")
+	}
+	return root, nil
+}
+
+// parseGML decodes a GML 2 or GML 3 document and transforms it into a
+// SpatialObject. The defaultSRID is used if the document does not have a
+// srsName, and will overwrite any srsName if overwrite is true.
+func parseGML(
+	soType geopb.SpatialObjectType,
+	b []byte,
+	defaultSRID geopb.SRID,
+	overwrite defaultSRIDOverwriteSetting,
+) (geopb.SpatialObject, error) {
+	root, err := parseGMLTree(b)
+	if err != nil {
+		return geopb.SpatialObject{}, err
+	}
+	d := gmlDecoder{srid: defaultSRID}
+	if srsName, ok := root.attrs["srsName"]; ok && overwrite != DefaultSRIDShouldOverwrite {
+		srid, swapXY, err := parseGMLSRSName(srsName)
+		if err != nil {
+			return geopb.SpatialObject{}, err
+		}
+		d.srid, d.swapXY, d.hasSRSName = srid, swapXY, true
+	}
+	t, err := d.decode(root)
+	if err != nil {
+		return geopb.SpatialObject{}, err
+	}
+	AdjustGeomTSRID(t, d.srid)
+	return spatialObjectFromGeomT(t, soType)
+}
+
+// parseGMLSRSName returns the SRID of a srsName attribute, along with whether
+// its coordinates are written with Y before X. Only EPSG reference systems
+// are supported. Following PostGIS, the URN and URL forms of lat/lng
+// reference systems are taken to use lat/lng axis order.
+func parseGMLSRSName(srsName string) (geopb.SRID, bool, error) {
+	idx := strings.LastIndexAny(srsName, ":#/")
+	if idx == -1 || !strings.Contains(strings.ToUpper(srsName), "EPSG") {
+		return 0, false, pgerror.Newf(pgcode.InvalidParameterValue, "unknown spatial reference system: %s", srsName)
+	}
+	srid, err := strconv.ParseInt(srsName[idx+1:], 10, 32)
+	if err != nil {
+		return 0, false, pgerror.Wrapf(err, pgcode.InvalidParameterValue, "unknown spatial reference system: %s", srsName)
+	}
+	projection, err := geoprojbase.Projection(geopb.SRID(srid))
+	if err != nil {
+		return 0, false, err
+	}
+	swapXY := projection.IsLatLng &&
+		(strings.HasPrefix(srsName, "urn:") || strings.HasPrefix(srsName, "http://www.opengis.net/def/crs/"))
+	return geopb.SRID(srid), swapXY, nil
+}
+
+// gmlDecoder converts a tree of gmlNodes into a geom.T.
+type gmlDecoder struct {
+	srid   geopb.SRID
+	swapXY bool
+	// hasSRSName is whether the SRID was taken from the srsName of the root
+	// element, in which case the srsName of any nested element must match.
+	// Otherwise, nested srsNames are ignored.
+	hasSRSName bool
+}
+
+func (d *gmlDecoder) decode(n *gmlNode) (geom.T, error) {
+	if srsName, ok := n.attrs["srsName"]; ok && d.hasSRSName {
+		srid, _, err := parseGMLSRSName(srsName)
+		if err != nil {
+			return nil, err
+		}
+		if srid != d.srid {
+			return nil, pgerror.Newf(
+				pgcode.InvalidParameterValue,
+				"GML geometries with mixed SRIDs are not supported",
+			)
+		}
+	}
+	switch n.name {
+	case "Point":
+		if len(n.children) == 0 {
+			return geom.NewPointEmpty(geom.XY), nil
+		}
+		layout, flatCoords, err := d.decodeCoords(n)
+		if err != nil {
+			return nil, err
+		}
+		if len(flatCoords) != layout.Stride() {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: point must have exactly one coordinate")
+		}
+		return geom.NewPointFlat(layout, flatCoords), nil
+	case "LineString", "Curve":
+		if len(n.children) == 0 {
+			return geom.NewLineString(geom.XY), nil
+		}
+		layout, flatCoords, err := d.decodeLine(n)
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewLineStringFlat(layout, flatCoords), nil
+	case "Polygon":
+		if len(n.children) == 0 {
+			return geom.NewPolygon(geom.XY), nil
+		}
+		return d.decodePolygon(n)
+	case "Surface":
+		patches := n.child("patches")
+		if patches == nil || len(patches.children) == 0 {
+			return geom.NewPolygon(geom.XY), nil
+		}
+		if len(patches.children) == 1 {
+			return d.decodePolygon(patches.children[0])
+		}
+		polys := make([]*geom.Polygon, len(patches.children))
+		for i, patch := range patches.children {
+			var err error
+			if polys[i], err = d.decodePolygon(patch); err != nil {
+				return nil, err
+			}
+		}
+		return makeGMLMultiPolygon(polys)
+	case "Envelope", "Box":
+		return d.decodeEnvelope(n)
+	case "MultiPoint":
+		members, err := d.decodeMembers(n, "pointMember", "pointMembers")
+		if err != nil {
+			return nil, err
+		}
+		mp := geom.NewMultiPoint(gmlMembersLayout(members))
+		for _, m := range members {
+			p, ok := m.(*geom.Point)
+			if !ok {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: MultiPoint member is not a Point")
+			}
+			if err := mp.Push(p); err != nil {
+				return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid GML representation")
+			}
+		}
+		return mp, nil
+	case "MultiLineString", "MultiCurve":
+		members, err := d.decodeMembers(n, "lineStringMember", "curveMember", "curveMembers")
+		if err != nil {
+			return nil, err
+		}
+		mls := geom.NewMultiLineString(gmlMembersLayout(members))
+		for _, m := range members {
+			ls, ok := m.(*geom.LineString)
+			if !ok {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: %s member is not a LineString", n.name)
+			}
+			if err := mls.Push(ls); err != nil {
+				return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid GML representation")
+			}
+		}
+		return mls, nil
+	case "MultiPolygon", "MultiSurface":
+		members, err := d.decodeMembers(n, "polygonMember", "surfaceMember", "surfaceMembers")
+		if err != nil {
+			return nil, err
+		}
+		var polys []*geom.Polygon
+		for _, m := range members {
+			switch m := m.(type) {
+			case *geom.Polygon:
+				polys = append(polys, m)
+			case *geom.MultiPolygon:
+				for i := 0; i < m.NumPolygons(); i++ {
+					polys = append(polys, m.Polygon(i))
+				}
+			default:
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: %s member is not a Polygon", n.name)
+			}
+		}
+		return makeGMLMultiPolygon(polys)
+	case "MultiGeometry":
+		members, err := d.decodeMembers(n, "geometryMember", "geometryMembers")
+		if err != nil {
+			return nil, err
+		}
+		gc := geom.NewGeometryCollection()
+		if err := gc.Push(members...); err != nil {
+			return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid GML representation")
+		}
+		return gc, nil
+	default:
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unsupported GML geometry type: %s", n.name)
+	}
+}
+
+// decodeMembers decodes the geometries held by the member elements of a
+// collection. A member element with a plural name may hold many geometries.
+func (d *gmlDecoder) decodeMembers(n *gmlNode, memberNames ...string) ([]geom.T, error) {
+	var ret []geom.T
+	for _, c := range n.children {
+		isMember := false
+		for _, name := range memberNames {
+			if c.name == name {
+				isMember = true
+				break
+			}
+		}
+		if !isMember {
+			continue
+		}
+		for _, m := range c.children {
+			t, err := d.decode(m)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, t)
+		}
+	}
+	return ret, nil
+}
+
+// gmlMembersLayout returns the layout of the first non-empty member.
+func gmlMembersLayout(members []geom.T) geom.Layout {
+	for _, m := range members {
+		if !m.Empty() {
+			return m.Layout()
+		}
+	}
+	return geom.XY
+}
+
+func makeGMLMultiPolygon(polys []*geom.Polygon) (*geom.MultiPolygon, error) {
+	layout := geom.XY
+	for _, p := range polys {
+		if !p.Empty() {
+			layout = p.Layout()
+			break
+		}
+	}
+	mp := geom.NewMultiPolygon(layout)
+	for _, p := range polys {
+		if p.Empty() {
+			p = geom.NewPolygon(layout)
+		}
+		if err := mp.Push(p); err != nil {
+			return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid GML representation")
+		}
+	}
+	return mp, nil
+}
+
+// decodePolygon decodes a Polygon or PolygonPatch element.
+func (d *gmlDecoder) decodePolygon(n *gmlNode) (*geom.Polygon, error) {
+	var layout geom.Layout
+	var flatCoords []float64
+	var ends []int
+	for _, c := range n.children {
+		switch c.name {
+		case "outerBoundaryIs", "exterior":
+			if len(ends) > 0 {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: polygon has multiple exterior rings")
+			}
+		case "innerBoundaryIs", "interior":
+			if len(ends) == 0 {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: polygon interior ring precedes its exterior ring")
+			}
+		default:
+			continue
+		}
+		for _, ring := range c.children {
+			if ring.name != "LinearRing" {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unsupported GML ring type: %s", ring.name)
+			}
+			ringLayout, ringCoords, err := d.decodeCoords(ring)
+			if err != nil {
+				return nil, err
+			}
+			if len(ends) > 0 && ringLayout != layout {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: polygon has mixed dimensions")
+			}
+			layout = ringLayout
+			flatCoords = append(flatCoords, ringCoords...)
+			ends = append(ends, len(flatCoords))
+		}
+	}
+	if len(ends) == 0 {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: polygon has no exterior ring")
+	}
+	return geom.NewPolygonFlat(layout, flatCoords, ends), nil
+}
+
+// decodeLine decodes a LineString, or a Curve made up of LineStringSegments.
+func (d *gmlDecoder) decodeLine(n *gmlNode) (geom.Layout, []float64, error) {
+	segments := n.child("segments")
+	if segments == nil {
+		return d.decodeCoords(n)
+	}
+	var layout geom.Layout
+	var flatCoords []float64
+	for i, segment := range segments.children {
+		if segment.name != "LineStringSegment" {
+			return 0, nil, pgerror.Newf(pgcode.InvalidParameterValue, "unsupported GML curve segment type: %s", segment.name)
+		}
+		segmentLayout, segmentCoords, err := d.decodeCoords(segment)
+		if err != nil {
+			return 0, nil, err
+		}
+		if i > 0 {
+			if segmentLayout != layout {
+				return 0, nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: curve has mixed dimensions")
+			}
+			// Consecutive segments share an endpoint, which is only kept once.
+			stride := layout.Stride()
+			if len(flatCoords) >= stride && len(segmentCoords) >= stride &&
+				floatsEqual(flatCoords[len(flatCoords)-stride:], segmentCoords[:stride]) {
+				segmentCoords = segmentCoords[stride:]
+			}
+		}
+		layout = segmentLayout
+		flatCoords = append(flatCoords, segmentCoords...)
+	}
+	return layout, flatCoords, nil
+}
+
+// decodeEnvelope decodes a GML 2 Box or GML 3 Envelope into a Polygon.
+func (d *gmlDecoder) decodeEnvelope(n *gmlNode) (geom.T, error) {
+	var layout geom.Layout
+	var corners []float64
+	lower, upper := n.child("lowerCorner"), n.child("upperCorner")
+	if lower != nil && upper != nil {
+		lowerLayout, lowerCoords, err := d.decodePosList(lower, n.attrs)
+		if err != nil {
+			return nil, err
+		}
+		_, upperCoords, err := d.decodePosList(upper, n.attrs)
+		if err != nil {
+			return nil, err
+		}
+		layout, corners = lowerLayout, append(lowerCoords, upperCoords...)
+	} else {
+		var err error
+		if layout, corners, err = d.decodeCoords(n); err != nil {
+			return nil, err
+		}
+	}
+	if len(corners) != 2*layout.Stride() {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: %s must have exactly two corners", n.name)
+	}
+	loX, loY := corners[0], corners[1]
+	hiX, hiY := corners[layout.Stride()], corners[layout.Stride()+1]
+	return geom.NewPolygonFlat(
+		geom.XY,
+		[]float64{loX, loY, loX, hiY, hiX, hiY, hiX, loY, loX, loY},
+		[]int{10},
+	), nil
+}
+
+// decodeCoords decodes the coordinates of an element, which may be held in a
+// coordinates element, a posList element, or a sequence of pos or coord
+// elements.
+func (d *gmlDecoder) decodeCoords(n *gmlNode) (geom.Layout, []float64, error) {
+	if c := n.child("coordinates"); c != nil {
+		return d.decodeCoordinates(c)
+	}
+	if c := n.child("posList"); c != nil {
+		return d.decodePosList(c, n.attrs)
+	}
+	var layout geom.Layout
+	var flatCoords []float64
+	for _, c := range n.children {
+		var coordLayout geom.Layout
+		var coords []float64
+		var err error
+		switch c.name {
+		case "pos":
+			coordLayout, coords, err = d.decodePosList(c, n.attrs)
+		case "coord":
+			coordLayout, coords, err = d.decodeCoord(c)
+		default:
+			continue
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		if len(flatCoords) > 0 && coordLayout != layout {
+			return 0, nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: mixed coordinate dimensions")
+		}
+		layout = coordLayout
+		flatCoords = append(flatCoords, coords...)
+	}
+	if len(flatCoords) == 0 {
+		return 0, nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: %s has no coordinates", n.name)
+	}
+	return layout, flatCoords, nil
+}
+
+// decodeCoordinates decodes a GML 2 coordinates element.
+func (d *gmlDecoder) decodeCoordinates(n *gmlNode) (geom.Layout, []float64, error) {
+	cs, ts, decimal := ",", " ", "."
+	if v, ok := n.attrs["cs"]; ok {
+		cs = v
+	}
+	if v, ok := n.attrs["ts"]; ok {
+		ts = v
+	}
+	if v, ok := n.attrs["decimal"]; ok {
+		decimal = v
+	}
+	var tuples []string
+	if strings.TrimSpace(ts) == "" {
+		tuples = strings.Fields(n.text)
+	} else {
+		tuples = strings.Split(strings.TrimSpace(n.text), ts)
+	}
+	var layout geom.Layout
+	var flatCoords []float64
+	for i, tuple := range tuples {
+		values := strings.Split(strings.TrimSpace(tuple), cs)
+		tupleLayout, err := gmlLayoutForDims(len(values))
+		if err != nil {
+			return 0, nil, err
+		}
+		if i > 0 && tupleLayout != layout {
+			return 0, nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: mixed coordinate dimensions")
+		}
+		layout = tupleLayout
+		for j, v := range values {
+			if decimal != "." {
+				v = strings.Replace(v, decimal, ".", 1)
+			}
+			values[j] = strings.TrimSpace(v)
+		}
+		if flatCoords, err = d.appendCoord(flatCoords, values); err != nil {
+			return 0, nil, err
+		}
+	}
+	if len(flatCoords) == 0 {
+		return 0, nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: empty coordinates")
+	}
+	return layout, flatCoords, nil
+}
+
+// decodePosList decodes a GML 3 pos, posList, lowerCorner or upperCorner
+// element. The dimension is taken from its srsDimension attribute, falling
+// back to that of its parent.
+func (d *gmlDecoder) decodePosList(
+	n *gmlNode, parentAttrs map[string]string,
+) (geom.Layout, []float64, error) {
+	dims := 2
+	for _, attrs := range []map[string]string{n.attrs, parentAttrs} {
+		v, ok := attrs["srsDimension"]
+		if !ok {
+			v, ok = attrs["dimension"]
+		}
+		if ok {
+			var err error
+			if dims, err = strconv.Atoi(v); err != nil {
+				return 0, nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid GML srsDimension: %s", v)
+			}
+			break
+		}
+	}
+	layout, err := gmlLayoutForDims(dims)
+	if err != nil {
+		return 0, nil, err
+	}
+	values := strings.Fields(n.text)
+	if len(values) == 0 || len(values)%dims != 0 {
+		return 0, nil, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"invalid GML representation: %s does not have a multiple of %d values",
+			n.name,
+			dims,
+		)
+	}
+	var flatCoords []float64
+	for i := 0; i < len(values); i += dims {
+		if flatCoords, err = d.appendCoord(flatCoords, values[i:i+dims]); err != nil {
+			return 0, nil, err
+		}
+	}
+	return layout, flatCoords, nil
+}
+
+// decodeCoord decodes a GML 2 coord element, which has X, Y and optionally Z
+// child elements.
+func (d *gmlDecoder) decodeCoord(n *gmlNode) (geom.Layout, []float64, error) {
+	var values []string
+	for _, name := range []string{"X", "Y", "Z"} {
+		c := n.child(name)
+		if c == nil {
+			break
+		}
+		values = append(values, strings.TrimSpace(c.text))
+	}
+	layout, err := gmlLayoutForDims(len(values))
+	if err != nil {
+		return 0, nil, err
+	}
+	flatCoords, err := d.appendCoord(nil, values)
+	return layout, flatCoords, err
+}
+
+// appendCoord parses the values of a single coordinate, appending them to
+// flatCoords in X, Y order.
+func (d *gmlDecoder) appendCoord(flatCoords []float64, values []string) ([]float64, error) {
+	start := len(flatCoords)
+	for _, v := range values {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid GML coordinate: %q", v)
+		}
+		flatCoords = append(flatCoords, f)
+	}
+	if d.swapXY {
+		flatCoords[start], flatCoords[start+1] = flatCoords[start+1], flatCoords[start]
+	}
+	return flatCoords, nil
+}
+
+func gmlLayoutForDims(dims int) (geom.Layout, error) {
+	switch dims {
+	case 2:
+		return geom.XY, nil
+	case 3:
+		return geom.XYZ, nil
+	default:
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: coordinates must have 2 or 3 dimensions")
+	}
+}
+
+func floatsEqual(a []float64, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,328 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpatialObjectToGML(t *testing.T) {
+	testCases := []struct {
+		ewkt             geopb.EWKT
+		version          int
+		maxDecimalDigits int
+		flag             SpatialObjectToGMLFlag
+		prefix           string
+		expected         string
+	}{
+		{
+			ewkt:             "POINT(1.0 2.123456)",
+			version:          2,
+			maxDecimalDigits: 15,
+			prefix:           DefaultGMLPrefix,
+			expected:         `<gml:Point><gml:coordinates>1,2.123456</gml:coordinates></gml:Point>`,
+		},
+		{
+			ewkt:             "SRID=4326;POINT(1.0 2.123456)",
+			version:          3,
+			maxDecimalDigits: 2,
+			prefix:           DefaultGMLPrefix,
+			expected:         `<gml:Point srsName="EPSG:4326"><gml:pos srsDimension="2">1 2.12</gml:pos></gml:Point>`,
+		},
+		{
+			ewkt:             "POINT EMPTY",
+			version:          2,
+			maxDecimalDigits: 15,
+			prefix:           DefaultGMLPrefix,
+			expected:         `<gml:Point/>`,
+		},
+		{
+			ewkt:             "SRID=4326;LINESTRING(1 2, 3 4)",
+			version:          2,
+			maxDecimalDigits: 15,
+			flag:             SpatialObjectToGMLFlagLongCRS,
+			prefix:           DefaultGMLPrefix,
+			expected:         `<gml:LineString srsName="urn:ogc:def:crs:EPSG::4326"><gml:coordinates>1,2 3,4</gml:coordinates></gml:LineString>`,
+		},
+		{
+			ewkt:             "LINESTRING Z (1 2 3, 4 5 6)",
+			version:          3,
+			maxDecimalDigits: 15,
+			prefix:           DefaultGMLPrefix,
+			expected:         `<gml:Curve><gml:segments><gml:LineStringSegment><gml:posList srsDimension="3">1 2 3 4 5 6</gml:posList></gml:LineStringSegment></gml:segments></gml:Curve>`,
+		},
+		{
+			ewkt:             "LINESTRING M (1 2 3, 4 5 6)",
+			version:          3,
+			maxDecimalDigits: 15,
+			flag:             SpatialObjectToGMLFlagLineString | SpatialObjectToGMLFlagOmitSRSDimension,
+			expected:         `<LineString><posList>1 2 4 5</posList></LineString>`,
+		},
+		{
+			ewkt:             "SRID=4326;LINESTRING(1 2, 3 4)",
+			version:          3,
+			maxDecimalDigits: 15,
+			flag:             SpatialObjectToGMLFlagLineString | SpatialObjectToGMLFlagLatLng,
+			prefix:           "ns",
+			expected:         `<ns:LineString srsName="EPSG:4326"><ns:posList srsDimension="2">2 1 4 3</ns:posList></ns:LineString>`,
+		},
+		{
+			ewkt:             "POLYGON((0 0, 0 1, 1 1, 0 0), (0.1 0.1, 0.1 0.2, 0.2 0.2, 0.1 0.1))",
+			version:          2,
+			maxDecimalDigits: 15,
+			prefix:           DefaultGMLPrefix,
+			expected: `<gml:Polygon><gml:outerBoundaryIs><gml:LinearRing><gml:coordinates>0,0 0,1 1,1 0,0</gml:coordinates></gml:LinearRing></gml:outerBoundaryIs>` +
+				`<gml:innerBoundaryIs><gml:LinearRing><gml:coordinates>0.1,0.1 0.1,0.2 0.2,0.2 0.1,0.1</gml:coordinates></gml:LinearRing></gml:innerBoundaryIs></gml:Polygon>`,
+		},
+		{
+			ewkt:             "POLYGON((0 0, 0 1, 1 1, 0 0))",
+			version:          3,
+			maxDecimalDigits: 15,
+			prefix:           DefaultGMLPrefix,
+			expected:         `<gml:Polygon><gml:exterior><gml:LinearRing><gml:posList srsDimension="2">0 0 0 1 1 1 0 0</gml:posList></gml:LinearRing></gml:exterior></gml:Polygon>`,
+		},
+		{
+			ewkt:             "MULTIPOINT(1 2, 3 4)",
+			version:          2,
+			maxDecimalDigits: 15,
+			prefix:           DefaultGMLPrefix,
+			expected: `<gml:MultiPoint><gml:pointMember><gml:Point><gml:coordinates>1,2</gml:coordinates></gml:Point></gml:pointMember>` +
+				`<gml:pointMember><gml:Point><gml:coordinates>3,4</gml:coordinates></gml:Point></gml:pointMember></gml:MultiPoint>`,
+		},
+		{
+			ewkt:             "MULTILINESTRING((1 2, 3 4))",
+			version:          3,
+			maxDecimalDigits: 15,
+			flag:             SpatialObjectToGMLFlagLineString,
+			prefix:           DefaultGMLPrefix,
+			expected:         `<gml:MultiCurve><gml:curveMember><gml:LineString><gml:posList srsDimension="2">1 2 3 4</gml:posList></gml:LineString></gml:curveMember></gml:MultiCurve>`,
+		},
+		{
+			ewkt:             "MULTIPOLYGON(((0 0, 0 1, 1 1, 0 0)))",
+			version:          3,
+			maxDecimalDigits: 15,
+			prefix:           DefaultGMLPrefix,
+			expected:         `<gml:MultiSurface><gml:surfaceMember><gml:Polygon><gml:exterior><gml:LinearRing><gml:posList srsDimension="2">0 0 0 1 1 1 0 0</gml:posList></gml:LinearRing></gml:exterior></gml:Polygon></gml:surfaceMember></gml:MultiSurface>`,
+		},
+		{
+			ewkt:             "SRID=4326;GEOMETRYCOLLECTION(POINT(1 2), MULTIPOINT EMPTY)",
+			version:          2,
+			maxDecimalDigits: 15,
+			prefix:           DefaultGMLPrefix,
+			expected: `<gml:MultiGeometry srsName="EPSG:4326"><gml:geometryMember><gml:Point><gml:coordinates>1,2</gml:coordinates></gml:Point></gml:geometryMember>` +
+				`<gml:geometryMember><gml:MultiPoint/></gml:geometryMember></gml:MultiGeometry>`,
+		},
+		{
+			ewkt:             "LINESTRING(1 2, 3 -4)",
+			version:          2,
+			maxDecimalDigits: 15,
+			flag:             SpatialObjectToGMLFlagEnvelope,
+			prefix:           DefaultGMLPrefix,
+			expected:         `<gml:Box><gml:coordinates>1,-4 3,2</gml:coordinates></gml:Box>`,
+		},
+		{
+			ewkt:             "SRID=4326;LINESTRING(1 2, 3 -4)",
+			version:          3,
+			maxDecimalDigits: 15,
+			flag:             SpatialObjectToGMLFlagEnvelope,
+			prefix:           DefaultGMLPrefix,
+			expected:         `<gml:Envelope srsName="EPSG:4326"><gml:lowerCorner srsDimension="2">1 -4</gml:lowerCorner><gml:upperCorner srsDimension="2">3 2</gml:upperCorner></gml:Envelope>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s/GML%d/%d", tc.ewkt, tc.version, tc.flag), func(t *testing.T) {
+			so, err := parseEWKT(geopb.SpatialObjectType_GeometryType, tc.ewkt, geopb.DefaultGeometrySRID, DefaultSRIDIsHint)
+			require.NoError(t, err)
+			encoded, err := SpatialObjectToGML(so, tc.version, tc.maxDecimalDigits, tc.flag, tc.prefix)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, encoded)
+		})
+	}
+
+	t.Run("unsupported version", func(t *testing.T) {
+		so, err := parseEWKT(geopb.SpatialObjectType_GeometryType, "POINT(1 2)", geopb.DefaultGeometrySRID, DefaultSRIDIsHint)
+		require.NoError(t, err)
+		_, err = SpatialObjectToGML(so, 1, 15, SpatialObjectToGMLFlagZero, DefaultGMLPrefix)
+		require.EqualError(t, err, "only GML 2 and GML 3 are supported")
+	})
+}
+
+func TestParseGML(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		gml      string
+		expected string
+	}{
+		{
+			desc:     "GML 2 point",
+			gml:      `<gml:Point srsName="EPSG:4326"><gml:coordinates>1,2</gml:coordinates></gml:Point>`,
+			expected: "SRID=4326;POINT(1 2)",
+		},
+		{
+			desc:     "GML 2 point without a namespace",
+			gml:      `<Point><coordinates>1,2,3</coordinates></Point>`,
+			expected: "POINT Z (1 2 3)",
+		},
+		{
+			desc:     "GML 2 coord",
+			gml:      `<gml:Point><gml:coord><gml:X>1</gml:X><gml:Y>2</gml:Y></gml:coord></gml:Point>`,
+			expected: "POINT(1 2)",
+		},
+		{
+			desc: "GML 2 coordinates with custom separators",
+			gml: `<gml:LineString xmlns:gml="http://www.opengis.net/gml">` +
+				`<gml:coordinates cs=";" ts="|" decimal=",">1,5;2|3;4,25</gml:coordinates></gml:LineString>`,
+			expected: "LINESTRING(1.5 2, 3 4.25)",
+		},
+		{
+			desc: "GML 2 polygon",
+			gml: `<gml:Polygon><gml:outerBoundaryIs><gml:LinearRing><gml:coordinates>0,0 0,1 1,1 0,0</gml:coordinates></gml:LinearRing></gml:outerBoundaryIs>` +
+				`<gml:innerBoundaryIs><gml:LinearRing><gml:coordinates>0.1,0.1 0.1,0.2 0.2,0.2 0.1,0.1</gml:coordinates></gml:LinearRing></gml:innerBoundaryIs></gml:Polygon>`,
+			expected: "POLYGON((0 0, 0 1, 1 1, 0 0), (0.1 0.1, 0.1 0.2, 0.2 0.2, 0.1 0.1))",
+		},
+		{
+			desc:     "GML 3 point",
+			gml:      `<gml:Point srsName="EPSG:3857"><gml:pos>1 2</gml:pos></gml:Point>`,
+			expected: "SRID=3857;POINT(1 2)",
+		},
+		{
+			desc:     "GML 3 lat/lng point",
+			gml:      `<gml:Point srsName="urn:ogc:def:crs:EPSG::4326"><gml:pos>2 1</gml:pos></gml:Point>`,
+			expected: "SRID=4326;POINT(1 2)",
+		},
+		{
+			desc:     "GML 3 point with a URL srsName",
+			gml:      `<gml:Point srsName="http://www.opengis.net/gml/srs/epsg.xml#4326"><gml:pos>1 2</gml:pos></gml:Point>`,
+			expected: "SRID=4326;POINT(1 2)",
+		},
+		{
+			desc:     "GML 3 linestring with srsDimension",
+			gml:      `<gml:LineString><gml:posList srsDimension="3">1 2 3 4 5 6</gml:posList></gml:LineString>`,
+			expected: "LINESTRING Z (1 2 3, 4 5 6)",
+		},
+		{
+			desc:     "GML 3 linestring of pos",
+			gml:      `<gml:LineString><gml:pos>1 2</gml:pos><gml:pos>3 4</gml:pos></gml:LineString>`,
+			expected: "LINESTRING(1 2, 3 4)",
+		},
+		{
+			desc: "GML 3 curve with multiple segments",
+			gml: `<gml:Curve><gml:segments>` +
+				`<gml:LineStringSegment><gml:posList>1 2 3 4</gml:posList></gml:LineStringSegment>` +
+				`<gml:LineStringSegment><gml:posList>3 4 5 6</gml:posList></gml:LineStringSegment>` +
+				`</gml:segments></gml:Curve>`,
+			expected: "LINESTRING(1 2, 3 4, 5 6)",
+		},
+		{
+			desc: "GML 3 surface",
+			gml: `<gml:Surface><gml:patches><gml:PolygonPatch><gml:exterior><gml:LinearRing>` +
+				`<gml:posList>0 0 0 1 1 1 0 0</gml:posList></gml:LinearRing></gml:exterior></gml:PolygonPatch></gml:patches></gml:Surface>`,
+			expected: "POLYGON((0 0, 0 1, 1 1, 0 0))",
+		},
+		{
+			desc: "GML 3 multi surface with surfaceMembers",
+			gml: `<gml:MultiSurface><gml:surfaceMembers>` +
+				`<gml:Polygon><gml:exterior><gml:LinearRing><gml:posList>0 0 0 1 1 1 0 0</gml:posList></gml:LinearRing></gml:exterior></gml:Polygon>` +
+				`<gml:Polygon><gml:exterior><gml:LinearRing><gml:posList>5 5 5 6 6 6 5 5</gml:posList></gml:LinearRing></gml:exterior></gml:Polygon>` +
+				`</gml:surfaceMembers></gml:MultiSurface>`,
+			expected: "MULTIPOLYGON(((0 0, 0 1, 1 1, 0 0)), ((5 5, 5 6, 6 6, 5 5)))",
+		},
+		{
+			desc: "GML 2 multi geometry",
+			gml: `<gml:MultiGeometry srsName="EPSG:4326">` +
+				`<gml:geometryMember><gml:Point srsName="EPSG:4326"><gml:coordinates>1,2</gml:coordinates></gml:Point></gml:geometryMember>` +
+				`<gml:geometryMember><gml:MultiLineString><gml:lineStringMember><gml:LineString><gml:coordinates>1,2 3,4</gml:coordinates></gml:LineString></gml:lineStringMember></gml:MultiLineString></gml:geometryMember>` +
+				`</gml:MultiGeometry>`,
+			expected: "SRID=4326;GEOMETRYCOLLECTION(POINT(1 2), MULTILINESTRING((1 2, 3 4)))",
+		},
+		{
+			desc:     "GML 3 envelope",
+			gml:      `<gml:Envelope><gml:lowerCorner>1 2</gml:lowerCorner><gml:upperCorner>3 4</gml:upperCorner></gml:Envelope>`,
+			expected: "POLYGON((1 2, 1 4, 3 4, 3 2, 1 2))",
+		},
+		{
+			desc:     "empty point",
+			gml:      `<?xml version="1.0"?><gml:Point/>`,
+			expected: "POINT EMPTY",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := ParseGeometryFromGML([]byte(tc.gml))
+			require.NoError(t, err)
+			require.Equal(t, MustParseGeometry(tc.expected), g)
+		})
+	}
+
+	t.Run("SRID override", func(t *testing.T) {
+		g, err := ParseGeometryFromGMLAndSRID(
+			[]byte(`<gml:Point srsName="urn:ogc:def:crs:EPSG::4326"><gml:pos>1 2</gml:pos></gml:Point>`),
+			3857,
+		)
+		require.NoError(t, err)
+		require.Equal(t, MustParseGeometry("SRID=3857;POINT(1 2)"), g)
+	})
+
+	errorTestCases := []struct {
+		desc                string
+		gml                 string
+		expectedErrorString string
+	}{
+		{
+			desc:                "not XML",
+			gml:                 `POINT(1 2)`,
+			expectedErrorString: "invalid GML representation: no root element",
+		},
+		{
+			desc:                "unsupported type",
+			gml:                 `<gml:Triangle/>`,
+			expectedErrorString: "unsupported GML geometry type: Triangle",
+		},
+		{
+			desc:                "bad coordinate",
+			gml:                 `<gml:Point><gml:pos>1 a</gml:pos></gml:Point>`,
+			expectedErrorString: `invalid GML coordinate: "a": strconv.ParseFloat: parsing "a": invalid syntax`,
+		},
+		{
+			desc:                "wrong number of values",
+			gml:                 `<gml:LineString><gml:posList srsDimension="3">1 2 3 4</gml:posList></gml:LineString>`,
+			expectedErrorString: "invalid GML representation: posList does not have a multiple of 3 values",
+		},
+		{
+			desc:                "unknown srsName",
+			gml:                 `<gml:Point srsName="CRS:84"><gml:pos>1 2</gml:pos></gml:Point>`,
+			expectedErrorString: "unknown spatial reference system: CRS:84",
+		},
+		{
+			desc: "mixed SRIDs",
+			gml: `<gml:MultiPoint srsName="EPSG:4326"><gml:pointMember>` +
+				`<gml:Point srsName="EPSG:3857"><gml:pos>1 2</gml:pos></gml:Point></gml:pointMember></gml:MultiPoint>`,
+			expectedErrorString: "GML geometries with mixed SRIDs are not supported",
+		},
+		{
+			desc:                "polygon without exterior",
+			gml:                 `<gml:Polygon><gml:interior/></gml:Polygon>`,
+			expectedErrorString: "invalid GML representation: polygon interior ring precedes its exterior ring",
+		},
+	}
+	for _, tc := range errorTestCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := ParseGeometryFromGML([]byte(tc.gml))
+			require.EqualError(t, err, tc.expectedErrorString)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geo
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/errors"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/ewkb"
+)
+
+// SpatialObjectToSVG transforms a given SpatialObject to SVG path data,
+// matching ST_AsSVG in PostGIS. Points are written as the attributes of a
+// circle, and all other shapes as the d attribute of a path. Y coordinates
+// are negated as the SVG Y axis points down. If relative is true, path data
+// is written with relative moves.
+func SpatialObjectToSVG(
+	so geopb.SpatialObject, relative bool, maxDecimalDigits int,
+) (string, error) {
+	t, err := ewkb.Unmarshal([]byte(so.EWKB))
+	if err != nil {
+		return "", err
+	}
+	e := svgEncoder{relative: relative, maxDecimalDigits: maxDecimalDigits}
+	if err := e.write(t); err != nil {
+		return "", err
+	}
+	return e.buf.String(), nil
+}
+
+// svgEncoder writes a geom.T as SVG path data.
+type svgEncoder struct {
+	buf              strings.Builder
+	relative         bool
+	maxDecimalDigits int
+}
+
+func (e *svgEncoder) write(t geom.T) error {
+	if t.Empty() {
+		return nil
+	}
+	switch t := t.(type) {
+	case *geom.Point:
+		x, y := e.formatXY(t.X(), t.Y())
+		if e.relative {
+			e.buf.WriteString(`x="` + x + `" y="` + y + `"`)
+		} else {
+			e.buf.WriteString(`cx="` + x + `" cy="` + y + `"`)
+		}
+	case *geom.LineString:
+		e.writePath(t.FlatCoords(), t.Layout(), false /* closed */)
+	case *geom.Polygon:
+		for i := 0; i < t.NumLinearRings(); i++ {
+			if i > 0 {
+				e.buf.WriteString(" ")
+			}
+			ring := t.LinearRing(i)
+			e.writePath(ring.FlatCoords(), ring.Layout(), true /* closed */)
+		}
+	case *geom.MultiPoint:
+		return e.writeCollection(",", t.NumPoints(), func(i int) geom.T { return t.Point(i) })
+	case *geom.MultiLineString:
+		return e.writeCollection(" ", t.NumLineStrings(), func(i int) geom.T { return t.LineString(i) })
+	case *geom.MultiPolygon:
+		return e.writeCollection(" ", t.NumPolygons(), func(i int) geom.T { return t.Polygon(i) })
+	case *geom.GeometryCollection:
+		return e.writeCollection(";", t.NumGeoms(), func(i int) geom.T { return t.Geom(i) })
+	default:
+		return errors.AssertionFailedf("unknown geometry type: %T", t)
+	}
+	return nil
+}
+
+// writeCollection writes the non-empty elements of a collection, separated by
+// sep.
+func (e *svgEncoder) writeCollection(sep string, n int, elem func(i int) geom.T) error {
+	first := true
+	for i := 0; i < n; i++ {
+		t := elem(i)
+		if t.Empty() {
+			continue
+		}
+		if !first {
+			e.buf.WriteString(sep)
+		}
+		first = false
+		if err := e.write(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writePath writes a path moving to the first coordinate and drawing lines to
+// the rest. Closed paths omit their last coordinate, which is the same as the
+// first, in favor of a closepath command.
+func (e *svgEncoder) writePath(flatCoords []float64, layout geom.Layout, closed bool) {
+	stride := layout.Stride()
+	n := len(flatCoords) / stride
+	if closed && n > 1 {
+		n--
+	}
+	lineTo, closePath := "L", "Z"
+	if e.relative {
+		lineTo, closePath = "l", "z"
+	}
+	for i := 0; i < n; i++ {
+		x, y := flatCoords[i*stride], flatCoords[i*stride+1]
+		switch i {
+		case 0:
+			e.buf.WriteString("M ")
+		case 1:
+			e.buf.WriteString(" " + lineTo + " ")
+		default:
+			e.buf.WriteString(" ")
+		}
+		if e.relative && i > 0 {
+			x, y = x-flatCoords[(i-1)*stride], y-flatCoords[(i-1)*stride+1]
+		}
+		fx, fy := e.formatXY(x, y)
+		e.buf.WriteString(fx + " " + fy)
+	}
+	if closed {
+		e.buf.WriteString(" " + closePath)
+	}
+}
+
+// formatXY formats a coordinate, negating Y.
+func (e *svgEncoder) formatXY(x float64, y float64) (string, string) {
+	return formatCoord(x, e.maxDecimalDigits), formatCoord(-y, e.maxDecimalDigits)
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpatialObjectToSVG(t *testing.T) {
+	testCases := []struct {
+		ewkt             geopb.EWKT
+		relative         bool
+		maxDecimalDigits int
+		expected         string
+	}{
+		{"POINT(1 2)", false, 15, `cx="1" cy="-2"`},
+		{"POINT(1 2)", true, 15, `x="1" y="-2"`},
+		{"POINT EMPTY", false, 15, ``},
+		{"LINESTRING(1 2, 3 4, 5 6)", false, 15, `M 1 -2 L 3 -4 5 -6`},
+		{"LINESTRING(1 2, 3 4, 5 6)", true, 15, `M 1 -2 l 2 -2 2 -2`},
+		{"LINESTRING(1.123 2, 3 4)", false, 1, `M 1.1 -2 L 3 -4`},
+		{"POLYGON((0 0, 0 1, 1 1, 1 0, 0 0))", false, 15, `M 0 0 L 0 -1 1 -1 1 0 Z`},
+		{"POLYGON((0 0, 0 1, 1 1, 1 0, 0 0))", true, 15, `M 0 0 l 0 -1 1 0 0 1 z`},
+		{
+			"POLYGON((0 0, 0 4, 4 4, 0 0), (1 1, 1 2, 2 2, 1 1))",
+			false,
+			15,
+			`M 0 0 L 0 -4 4 -4 Z M 1 -1 L 1 -2 2 -2 Z`,
+		},
+		{"MULTIPOINT(1 2, EMPTY, 3 4)", false, 15, `cx="1" cy="-2",cx="3" cy="-4"`},
+		{"MULTILINESTRING((1 2, 3 4), (5 6, 7 8))", true, 15, `M 1 -2 l 2 -2 M 5 -6 l 2 -2`},
+		{
+			"MULTIPOLYGON(((0 0, 0 1, 1 1, 0 0)), ((5 5, 5 6, 6 6, 5 5)))",
+			false,
+			15,
+			`M 0 0 L 0 -1 1 -1 Z M 5 -5 L 5 -6 6 -6 Z`,
+		},
+		{"GEOMETRYCOLLECTION(POINT(1 2), LINESTRING(1 2, 3 4))", false, 15, `cx="1" cy="-2";M 1 -2 L 3 -4`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s/relative=%t/%d", tc.ewkt, tc.relative, tc.maxDecimalDigits), func(t *testing.T) {
+			so, err := parseEWKT(geopb.SpatialObjectType_GeometryType, tc.ewkt, geopb.DefaultGeometrySRID, DefaultSRIDIsHint)
+			require.NoError(t, err)
+			encoded, err := SpatialObjectToSVG(so, tc.relative, tc.maxDecimalDigits)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, encoded)
+		})
+	}
+}
@@ -362,3 +362,327 @@ func zigzagInt8(x int8) byte {
 	}
 	return uint8(x) << 1
 }
+
+type unmarshaller struct {
+	b   []byte
+	pos int
+}
+
+// Unmarshal converts a TWKB encoded byte array into a geom.T. Bounding boxes,
+// sizes and id lists are skipped over. The returned geom.T has no SRID.
+func Unmarshal(b []byte) (geom.T, error) {
+	u := unmarshaller{b: b}
+	t, err := u.unmarshal()
+	if err != nil {
+		return nil, err
+	}
+	if u.pos != len(u.b) {
+		return nil, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"unexpected %d trailing bytes in TWKB",
+			len(u.b)-u.pos,
+		)
+	}
+	return t, nil
+}
+
+func (u *unmarshaller) unmarshal() (geom.T, error) {
+	typeAndPrecisionHeader, err := u.readByte()
+	if err != nil {
+		return nil, err
+	}
+	typ := twkbType(typeAndPrecisionHeader & 0x0F)
+	precisions := []int8{unzigzagInt8(typeAndPrecisionHeader >> 4)}
+	metadata, err := u.readByte()
+	if err != nil {
+		return nil, err
+	}
+	hasBBox := metadata&0b1 != 0
+	hasSize := metadata&0b10 != 0
+	hasIDList := metadata&0b100 != 0
+	hasExtendedDimensions := metadata&0b1000 != 0
+	isEmpty := metadata&0b10000 != 0
+
+	layout := geom.XY
+	if hasExtendedDimensions {
+		extDimByte, err := u.readByte()
+		if err != nil {
+			return nil, err
+		}
+		precisionZ := int8((extDimByte >> 2) & 0b111)
+		precisionM := int8((extDimByte >> 5) & 0b111)
+		switch extDimByte & 0b11 {
+		case 0b01:
+			layout = geom.XYZ
+			precisions = append(precisions, precisionZ)
+		case 0b10:
+			layout = geom.XYM
+			precisions = append(precisions, precisionM)
+		case 0b11:
+			layout = geom.XYZM
+			precisions = append(precisions, precisionZ, precisionM)
+		}
+	}
+	if hasSize {
+		if _, err := u.readUvarint(); err != nil {
+			return nil, err
+		}
+	}
+	if isEmpty {
+		return emptyGeomT(typ, layout)
+	}
+	if hasBBox {
+		for i := 0; i < 2*layout.Stride(); i++ {
+			if _, err := u.readVarint(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Each geometry has its own set of deltas, which carry over between the
+	// rings and components that make up the geometry.
+	c := coordReader{
+		u:          u,
+		layout:     layout,
+		prevCoords: make([]int64, layout.Stride()),
+		scales:     make([]float64, layout.Stride()),
+	}
+	for i := range c.scales {
+		p := precisions[0]
+		if i >= 2 {
+			p = precisions[i-1]
+		}
+		c.scales[i] = math.Pow(10, float64(p))
+	}
+
+	switch typ {
+	case twkbTypePoint:
+		flatCoords, err := c.readFlatCoords(nil, 1)
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewPointFlat(layout, flatCoords), nil
+	case twkbTypeLineString:
+		flatCoords, err := c.readLenAndFlatCoords(nil)
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewLineStringFlat(layout, flatCoords), nil
+	case twkbTypePolygon:
+		flatCoords, ends, err := c.readGeomWithEnds(nil)
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewPolygonFlat(layout, flatCoords, ends), nil
+	case twkbTypeMultiPoint:
+		n, err := u.readLenAndIDList(hasIDList)
+		if err != nil {
+			return nil, err
+		}
+		flatCoords, err := c.readFlatCoords(nil, n)
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewMultiPointFlat(layout, flatCoords), nil
+	case twkbTypeMultiLineString:
+		n, err := u.readLenAndIDList(hasIDList)
+		if err != nil {
+			return nil, err
+		}
+		var flatCoords []float64
+		ends := make([]int, 0, n)
+		for i := 0; i < n; i++ {
+			if flatCoords, err = c.readLenAndFlatCoords(flatCoords); err != nil {
+				return nil, err
+			}
+			ends = append(ends, len(flatCoords))
+		}
+		return geom.NewMultiLineStringFlat(layout, flatCoords, ends), nil
+	case twkbTypeMultiPolygon:
+		n, err := u.readLenAndIDList(hasIDList)
+		if err != nil {
+			return nil, err
+		}
+		var flatCoords []float64
+		endss := make([][]int, 0, n)
+		for i := 0; i < n; i++ {
+			var ends []int
+			if flatCoords, ends, err = c.readGeomWithEnds(flatCoords); err != nil {
+				return nil, err
+			}
+			endss = append(endss, ends)
+		}
+		return geom.NewMultiPolygonFlat(layout, flatCoords, endss), nil
+	case twkbTypeGeometryCollection:
+		n, err := u.readLenAndIDList(hasIDList)
+		if err != nil {
+			return nil, err
+		}
+		gc := geom.NewGeometryCollection()
+		for i := 0; i < n; i++ {
+			t, err := u.unmarshal()
+			if err != nil {
+				return nil, err
+			}
+			if err := gc.Push(t); err != nil {
+				return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid TWKB geometry collection")
+			}
+		}
+		return gc, nil
+	default:
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unknown TWKB type: %d", typ)
+	}
+}
+
+// emptyGeomT returns an empty geom.T of the given TWKB type.
+func emptyGeomT(typ twkbType, layout geom.Layout) (geom.T, error) {
+	switch typ {
+	case twkbTypePoint:
+		return geom.NewPointEmpty(layout), nil
+	case twkbTypeLineString:
+		return geom.NewLineString(layout), nil
+	case twkbTypePolygon:
+		return geom.NewPolygon(layout), nil
+	case twkbTypeMultiPoint:
+		return geom.NewMultiPoint(layout), nil
+	case twkbTypeMultiLineString:
+		return geom.NewMultiLineString(layout), nil
+	case twkbTypeMultiPolygon:
+		return geom.NewMultiPolygon(layout), nil
+	case twkbTypeGeometryCollection:
+		return geom.NewGeometryCollection(), nil
+	default:
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unknown TWKB type: %d", typ)
+	}
+}
+
+// coordReader reads delta encoded coordinates for a single geometry.
+type coordReader struct {
+	u          *unmarshaller
+	layout     geom.Layout
+	prevCoords []int64
+	// scales holds 10^precision for each dimension.
+	scales []float64
+}
+
+// readGeomWithEnds reads a number of rings, each prefixed by its length,
+// appending their coordinates to flatCoords.
+func (c *coordReader) readGeomWithEnds(flatCoords []float64) ([]float64, []int, error) {
+	n, err := c.u.readLen()
+	if err != nil {
+		return nil, nil, err
+	}
+	ends := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		if flatCoords, err = c.readLenAndFlatCoords(flatCoords); err != nil {
+			return nil, nil, err
+		}
+		ends = append(ends, len(flatCoords))
+	}
+	return flatCoords, ends, nil
+}
+
+// readLenAndFlatCoords reads a number of coordinates followed by the
+// coordinates themselves, appending them to flatCoords.
+func (c *coordReader) readLenAndFlatCoords(flatCoords []float64) ([]float64, error) {
+	n, err := c.u.readLen()
+	if err != nil {
+		return nil, err
+	}
+	return c.readFlatCoords(flatCoords, n)
+}
+
+// readFlatCoords reads n coordinates, appending them to flatCoords.
+func (c *coordReader) readFlatCoords(flatCoords []float64, n int) ([]float64, error) {
+	stride := c.layout.Stride()
+	// Each value takes at least one byte, which bounds the allocation below.
+	if n > (len(c.u.b)-c.u.pos)/stride {
+		return nil, errTWKBTooShort
+	}
+	for i := 0; i < n*stride; i++ {
+		delta, err := c.u.readVarint()
+		if err != nil {
+			return nil, err
+		}
+		c.prevCoords[i%stride] += delta
+		flatCoords = append(flatCoords, float64(c.prevCoords[i%stride])/c.scales[i%stride])
+	}
+	return flatCoords, nil
+}
+
+var errTWKBTooShort = pgerror.Newf(pgcode.InvalidParameterValue, "TWKB is too short")
+
+// readLenAndIDList reads the number of components of a collection, skipping
+// over the id list if there is one.
+func (u *unmarshaller) readLenAndIDList(hasIDList bool) (int, error) {
+	n, err := u.readLen()
+	if err != nil {
+		return 0, err
+	}
+	if hasIDList {
+		for i := 0; i < n; i++ {
+			if _, err := u.readVarint(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// readLen reads a length, checking that it cannot exceed the number of bytes
+// remaining.
+func (u *unmarshaller) readLen() (int, error) {
+	n, err := u.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(u.b)-u.pos) {
+		return 0, errTWKBTooShort
+	}
+	return int(n), nil
+}
+
+func (u *unmarshaller) readByte() (byte, error) {
+	if u.pos >= len(u.b) {
+		return 0, errTWKBTooShort
+	}
+	b := u.b[u.pos]
+	u.pos++
+	return b, nil
+}
+
+// readVarint decodes a zigzag encoded int64.
+func (u *unmarshaller) readVarint() (int64, error) {
+	ux, err := u.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	x := int64(ux >> 1)
+	if ux&1 != 0 {
+		x = ^x
+	}
+	return x, nil
+}
+
+func (u *unmarshaller) readUvarint() (uint64, error) {
+	var x uint64
+	for shift := uint(0); shift < 64; shift += 7 {
+		b, err := u.readByte()
+		if err != nil {
+			return 0, err
+		}
+		x |= uint64(b&0x7F) << shift
+		if b < 0x80 {
+			return x, nil
+		}
+	}
+	return 0, pgerror.Newf(pgcode.InvalidParameterValue, "TWKB varint overflows 64 bits")
+}
+
+func unzigzagInt8(b byte) int8 {
+	b &= 0x0F
+	if b&0x01 != 0 {
+		return -1 - int8(b>>1)
+	}
+	return int8(b >> 1)
+}
@@ -380,6 +380,112 @@ func TestMarshal(t *testing.T) {
 	}
 }
 
+func TestUnmarshal(t *testing.T) {
+	roundTripTestCases := []struct {
+		desc string
+		t    geom.T
+		opts []MarshalOption
+	}{
+		{desc: "empty point", t: geom.NewPointEmpty(geom.XY)},
+		{desc: "point", t: geom.NewPointFlat(geom.XY, []float64{1, -2})},
+		{
+			desc: "4D linestring, precision XY 1, Z 2, M 3",
+			t: geom.NewLineStringFlat(
+				geom.XYZM,
+				[]float64{
+					1.5, 2.5, 3.25, 4.125,
+					-5.5, 6.5, 7.75, 8.875,
+				},
+			),
+			opts: []MarshalOption{
+				MarshalOptionPrecisionXY(1),
+				MarshalOptionPrecisionZ(2),
+				MarshalOptionPrecisionM(3),
+			},
+		},
+		{
+			desc: "M linestring, precision XY -1",
+			t:    geom.NewLineStringFlat(geom.XYM, []float64{10, 20, 1, 30, -40, 2}),
+			opts: []MarshalOption{MarshalOptionPrecisionXY(-1)},
+		},
+		{
+			desc: "POLYGON with ring",
+			t: geom.NewPolygonFlat(
+				geom.XY,
+				[]float64{
+					0, 0, 10, 0, 10, 10, 0, 10, 0, 0,
+					2, 2, 2, 4, 4, 4, 2, 2,
+				},
+				[]int{10, 18},
+			),
+		},
+		{desc: "MULTIPOINT", t: geom.NewMultiPointFlat(geom.XY, []float64{1, 2, 3, 4})},
+		{
+			desc: "MULTILINESTRING",
+			t: geom.NewMultiLineStringFlat(
+				geom.XYZ,
+				[]float64{0, 0, 0, 1, 1, 1, 5, 5, 5, 6, 6, 6},
+				[]int{6, 12},
+			),
+		},
+		{
+			desc: "MULTIPOLYGON with empty",
+			t: geom.NewMultiPolygonFlat(
+				geom.XY,
+				[]float64{
+					0, 0, 1, 0, 1, 1, 0, 0,
+					5, 5, 6, 5, 6, 6, 5, 5,
+				},
+				[][]int{{8}, {}, {16}},
+			),
+		},
+		{
+			desc: "GEOMETRYCOLLECTION",
+			t: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+				geom.NewLineStringFlat(geom.XY, []float64{3, 4, 5, 6}),
+				geom.NewGeometryCollection(),
+			),
+		},
+	}
+
+	for _, tc := range roundTripTestCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			b, err := Marshal(tc.t, tc.opts...)
+			require.NoError(t, err)
+			ret, err := Unmarshal(b)
+			require.NoError(t, err)
+			require.Equal(t, tc.t, ret)
+		})
+	}
+
+	t.Run("bounding box, size and id list", func(t *testing.T) {
+		// MULTIPOINT((1 2), (3 4)) with ids 7 and 8, a bounding box of
+		// (1 2, 3 4) and a size of 11 bytes.
+		ret, err := Unmarshal(mustDecodeHex("0407" + "0b" + "02040404" + "02" + "0e10" + "0204" + "0404"))
+		require.NoError(t, err)
+		require.Equal(t, geom.NewMultiPointFlat(geom.XY, []float64{1, 2, 3, 4}), ret)
+	})
+
+	errorTestCases := []struct {
+		desc                string
+		b                   []byte
+		expectedErrorString string
+	}{
+		{desc: "empty", b: nil, expectedErrorString: "TWKB is too short"},
+		{desc: "truncated point", b: mustDecodeHex("010004"), expectedErrorString: "TWKB is too short"},
+		{desc: "length too large", b: mustDecodeHex("0200ff01"), expectedErrorString: "TWKB is too short"},
+		{desc: "unknown type", b: mustDecodeHex("0800"), expectedErrorString: "unknown TWKB type: 8"},
+		{desc: "trailing bytes", b: mustDecodeHex("0110ff"), expectedErrorString: "unexpected 1 trailing bytes in TWKB"},
+	}
+	for _, tc := range errorTestCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := Unmarshal(tc.b)
+			require.EqualError(t, err, tc.expectedErrorString)
+		})
+	}
+}
+
 func mustDecodeHex(h string) []byte {
 	ret, err := hex.DecodeString(h)
 	if err != nil {
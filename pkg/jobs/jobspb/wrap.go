@@ -137,6 +137,10 @@ func (rs ReplicationStatus) String() string {
 // running CREATE STATISTICS manually.
 const AutoStatsName = "__auto__"
 
+// AutoTargetedStatsName is the name to use for statistics created
+// automatically on column sets whose selectivity was misestimated.
+const AutoTargetedStatsName = "__auto_targeted__"
+
 // ImportStatsName is the name to use for statistics created automatically
 // during import.
 const ImportStatsName = "__import__"
@@ -179,7 +183,7 @@ func DetailsType(d isPayload_Details) (Type, error) {
 		return TypeChangefeed, nil
 	case *Payload_CreateStats:
 		createStatsName := d.CreateStats.Name
-		if createStatsName == AutoStatsName {
+		if createStatsName == AutoStatsName || createStatsName == AutoTargetedStatsName {
 			return TypeAutoCreateStats, nil
 		}
 		return TypeCreateStats, nil
@@ -0,0 +1,88 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/authserver"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetPlanBaseline is the gRPC handler for pinning a plan gist to a statement
+// fingerprint, or removing the plan baseline of a fingerprint if the gist in
+// the request is empty. If the NodeID in the request is empty, the baseline is
+// set on all the nodes.
+func (s *statusServer) SetPlanBaseline(
+	ctx context.Context, req *serverpb.SetPlanBaselineRequest,
+) (*serverpb.SetPlanBaselineResponse, error) {
+	ctx = authserver.ForwardSQLIdentityThroughRPCCalls(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	if err := s.privilegeChecker.RequireRepairClusterPermission(ctx); err != nil {
+		return nil, err
+	}
+
+	localReq := *req
+	localReq.NodeID = "local"
+
+	if len(req.NodeID) > 0 {
+		requestedNodeID, local, err := s.parseNodeID(req.NodeID)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if local {
+			baselines := s.sqlServer.execCfg.PlanBaselines
+			var found bool
+			if req.PlanGist == "" {
+				found = baselines.Unpin(req.Fingerprint)
+			} else {
+				found = baselines.Pin(req.Fingerprint, req.PlanGist, req.Enforce, req.Created)
+			}
+			return &serverpb.SetPlanBaselineResponse{Found: found}, nil
+		}
+
+		statusClient, err := s.dialNode(ctx, requestedNodeID)
+		if err != nil {
+			return nil, err
+		}
+		return statusClient.SetPlanBaseline(ctx, &localReq)
+	}
+
+	resp := &serverpb.SetPlanBaselineResponse{}
+	setPlanBaseline := func(ctx context.Context, statusClient serverpb.StatusClient, _ roachpb.NodeID) (*serverpb.SetPlanBaselineResponse, error) {
+		return statusClient.SetPlanBaseline(ctx, &localReq)
+	}
+	aggFn := func(_ roachpb.NodeID, nodeResp *serverpb.SetPlanBaselineResponse) {
+		resp.Found = resp.Found || nodeResp.Found
+	}
+	var combinedError error
+	errFn := func(_ roachpb.NodeID, nodeFnError error) {
+		combinedError = errors.CombineErrors(combinedError, nodeFnError)
+	}
+
+	if err := iterateNodes(ctx,
+		s.serverIterator, s.stopper,
+		"Setting plan baseline",
+		noTimeout,
+		s.dialNode,
+		setPlanBaseline, aggFn, errFn); err != nil {
+		return nil, err
+	}
+	if combinedError != nil {
+		return nil, combinedError
+	}
+	return resp, nil
+}
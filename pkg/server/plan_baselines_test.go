@@ -0,0 +1,141 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSetPlanBaseline verifies that SetPlanBaseline pins and unpins plan
+// baselines on the requested node, or on all the nodes.
+func TestSetPlanBaseline(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numNodes = 3
+	testCluster := serverutils.StartCluster(t, numNodes, base.TestClusterArgs{})
+
+	ctx := context.Background()
+	defer testCluster.Stopper().Stop(ctx)
+
+	runners := make([]*sqlutils.SQLRunner, numNodes)
+	for i := range runners {
+		runners[i] = sqlutils.MakeSQLRunner(testCluster.ServerConn(i))
+	}
+	// Stop reloading the baselines from system.plan_baselines, so that the
+	// baselines set by the RPC alone stay in place.
+	setPollInterval := func(interval string) {
+		runners[0].Exec(t, "SET CLUSTER SETTING sql.plan_baselines.poll_interval = $1", interval)
+		testutils.SucceedsSoon(t, func() error {
+			for i := range runners {
+				var cur string
+				runners[i].QueryRow(t, "SHOW CLUSTER SETTING sql.plan_baselines.poll_interval").Scan(&cur)
+				if cur != interval {
+					return errors.Newf("node %d: poll interval is %s", i, cur)
+				}
+			}
+			return nil
+		})
+	}
+	setPollInterval("00:00:00")
+	runners[0].Exec(t, "CREATE TABLE t (k INT PRIMARY KEY, v INT, INDEX (v))")
+	var gist string
+	runners[0].QueryRow(t, "EXPLAIN (GIST) SELECT k FROM t@t_pkey WHERE v = 1").Scan(&gist)
+
+	const fingerprint = "SELECT k FROM t WHERE v = _"
+	numPinned := func(i int) int {
+		var n int
+		runners[i].QueryRow(t,
+			"SELECT count(*) FROM crdb_internal.node_plan_baselines WHERE fingerprint = $1", fingerprint,
+		).Scan(&n)
+		return n
+	}
+	expectPinned := func(exp ...int) {
+		t.Helper()
+		for i := range runners {
+			require.Equal(t, exp[i], numPinned(i), "node %d", i)
+		}
+	}
+
+	// Pin the plan on a single remote node.
+	client := testCluster.Server(0).GetStatusClient(t)
+	resp, err := client.SetPlanBaseline(ctx, &serverpb.SetPlanBaselineRequest{
+		NodeID:      testCluster.Server(1).NodeID().String(),
+		Fingerprint: fingerprint,
+		PlanGist:    gist,
+		Created:     timeutil.Now(),
+	})
+	require.NoError(t, err)
+	require.False(t, resp.Found)
+	expectPinned(0, 1, 0)
+
+	// Pin the plan on all the nodes. It replaces the baseline of node 1.
+	resp, err = client.SetPlanBaseline(ctx, &serverpb.SetPlanBaselineRequest{
+		Fingerprint: fingerprint,
+		PlanGist:    gist,
+		Created:     timeutil.Now(),
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Found)
+	expectPinned(1, 1, 1)
+
+	// The pinned plan is used on every node.
+	for i, r := range runners {
+		r.Exec(t, "SELECT k FROM t WHERE v = 2")
+		var hits, mismatches int
+		r.QueryRow(t,
+			"SELECT hits, mismatches FROM crdb_internal.node_plan_baselines WHERE fingerprint = $1",
+			fingerprint,
+		).Scan(&hits, &mismatches)
+		require.Equal(t, 1, hits, "node %d", i)
+		require.Equal(t, 0, mismatches, "node %d", i)
+	}
+
+	// The builtin fans out to all the nodes as well.
+	runners[2].CheckQueryResults(t,
+		"SELECT crdb_internal.unpin_plan('SELECT k FROM t WHERE v = 1')", [][]string{{"true"}})
+	expectPinned(0, 0, 0)
+	runners[2].CheckQueryResults(t,
+		"SELECT crdb_internal.unpin_plan('SELECT k FROM t WHERE v = 1')", [][]string{{"false"}})
+
+	// The builtins store the baselines in system.plan_baselines.
+	runners[1].CheckQueryResults(t,
+		"SELECT crdb_internal.pin_plan('SELECT k FROM t WHERE v = 1', $1, true)", [][]string{{"false"}}, gist)
+	expectPinned(1, 1, 1)
+	runners[0].CheckQueryResults(t,
+		"SELECT fingerprint, plan_gist = $1, enforce FROM system.plan_baselines", [][]string{{fingerprint, "true", "true"}}, gist)
+
+	// Nodes which missed a change, for example because they were down, pick it
+	// up when they reload the table.
+	runners[0].Exec(t, "DELETE FROM system.plan_baselines WHERE true")
+	expectPinned(1, 1, 1)
+	setPollInterval("00:00:00.01")
+	testutils.SucceedsSoon(t, func() error {
+		for i := range runners {
+			if n := numPinned(i); n != 0 {
+				return errors.Newf("node %d: %d baselines", i, n)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/authserver"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EvictCachedPlans is the gRPC handler for evicting the cached query plans
+// which depend on a table. If the NodeID in the request is empty, the plans
+// are evicted on all the nodes.
+func (s *statusServer) EvictCachedPlans(
+	ctx context.Context, req *serverpb.EvictCachedPlansRequest,
+) (*serverpb.EvictCachedPlansResponse, error) {
+	ctx = authserver.ForwardSQLIdentityThroughRPCCalls(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	if err := s.privilegeChecker.RequireRepairClusterPermission(ctx); err != nil {
+		return nil, err
+	}
+
+	localReq := &serverpb.EvictCachedPlansRequest{
+		NodeID:  "local",
+		TableID: req.TableID,
+	}
+
+	if len(req.NodeID) > 0 {
+		requestedNodeID, local, err := s.parseNodeID(req.NodeID)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if local {
+			n := s.sqlServer.execCfg.QueryCache.EvictTable(cat.StableID(req.TableID))
+			return &serverpb.EvictCachedPlansResponse{EvictedCount: int64(n)}, nil
+		}
+
+		statusClient, err := s.dialNode(ctx, requestedNodeID)
+		if err != nil {
+			return nil, err
+		}
+		return statusClient.EvictCachedPlans(ctx, localReq)
+	}
+
+	resp := &serverpb.EvictCachedPlansResponse{}
+	evictCachedPlans := func(ctx context.Context, statusClient serverpb.StatusClient, _ roachpb.NodeID) (*serverpb.EvictCachedPlansResponse, error) {
+		return statusClient.EvictCachedPlans(ctx, localReq)
+	}
+	aggFn := func(_ roachpb.NodeID, nodeResp *serverpb.EvictCachedPlansResponse) {
+		resp.EvictedCount += nodeResp.EvictedCount
+	}
+	var combinedError error
+	errFn := func(_ roachpb.NodeID, nodeFnError error) {
+		combinedError = errors.CombineErrors(combinedError, nodeFnError)
+	}
+
+	if err := iterateNodes(ctx,
+		s.serverIterator, s.stopper,
+		"Evicting cached plans",
+		noTimeout,
+		s.dialNode,
+		evictCachedPlans, aggFn, errFn); err != nil {
+		return nil, err
+	}
+	if combinedError != nil {
+		return nil, combinedError
+	}
+	return resp, nil
+}
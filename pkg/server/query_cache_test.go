@@ -0,0 +1,91 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEvictCachedPlans verifies that EvictCachedPlans evicts the plans from
+// the query cache of the requested node, or of all the nodes.
+func TestEvictCachedPlans(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numNodes = 3
+	testCluster := serverutils.StartCluster(t, numNodes, base.TestClusterArgs{})
+
+	ctx := context.Background()
+	defer testCluster.Stopper().Stop(ctx)
+
+	runners := make([]*sqlutils.SQLRunner, numNodes)
+	for i := range runners {
+		runners[i] = sqlutils.MakeSQLRunner(testCluster.ServerConn(i))
+	}
+	runners[0].Exec(t, "CREATE TABLE t (k INT PRIMARY KEY, v INT)")
+	var tableID uint32
+	runners[0].QueryRow(t, "SELECT 't'::REGCLASS::OID").Scan(&tableID)
+
+	// cachePlans plans a query depending on t on every node. numCached returns
+	// the number of such plans in the query cache of a node.
+	cachePlans := func() {
+		for _, r := range runners {
+			r.Exec(t, "SELECT v FROM t WHERE k = 1")
+		}
+	}
+	numCached := func(i int) int {
+		var n int
+		runners[i].QueryRow(t,
+			"SELECT count(*) FROM crdb_internal.node_plan_cache WHERE fingerprint LIKE 'SELECT v FROM t %'",
+		).Scan(&n)
+		return n
+	}
+	expectCached := func(exp ...int) {
+		t.Helper()
+		for i := range runners {
+			require.Equal(t, exp[i], numCached(i), "node %d", i)
+		}
+	}
+
+	cachePlans()
+	expectCached(1, 1, 1)
+
+	// Evict the plans on a single remote node.
+	client := testCluster.Server(0).GetStatusClient(t)
+	resp, err := client.EvictCachedPlans(ctx, &serverpb.EvictCachedPlansRequest{
+		NodeID:  testCluster.Server(1).NodeID().String(),
+		TableID: tableID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.EvictedCount)
+	expectCached(1, 0, 1)
+
+	// Evict the plans on all the nodes.
+	resp, err = client.EvictCachedPlans(ctx, &serverpb.EvictCachedPlansRequest{TableID: tableID})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.EvictedCount)
+	expectCached(0, 0, 0)
+
+	// The builtin fans out to all the nodes as well.
+	cachePlans()
+	expectCached(1, 1, 1)
+	runners[2].CheckQueryResults(t, "SELECT crdb_internal.evict_cached_plans('t')", [][]string{{"3"}})
+	expectCached(0, 0, 0)
+}
@@ -36,6 +36,8 @@ type SQLStatusServer interface {
 	Profile(context.Context, *ProfileRequest) (*JSONResponse, error)
 	IndexUsageStatistics(context.Context, *IndexUsageStatisticsRequest) (*IndexUsageStatisticsResponse, error)
 	ResetIndexUsageStats(context.Context, *ResetIndexUsageStatsRequest) (*ResetIndexUsageStatsResponse, error)
+	EvictCachedPlans(context.Context, *EvictCachedPlansRequest) (*EvictCachedPlansResponse, error)
+	SetPlanBaseline(context.Context, *SetPlanBaselineRequest) (*SetPlanBaselineResponse, error)
 	TableIndexStats(context.Context, *TableIndexStatsRequest) (*TableIndexStatsResponse, error)
 	UserSQLRoles(context.Context, *UserSQLRolesRequest) (*UserSQLRolesResponse, error)
 	TxnIDResolution(context.Context, *TxnIDResolutionRequest) (*TxnIDResolutionResponse, error)
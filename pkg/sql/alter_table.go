@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -1551,6 +1552,44 @@ func insertJSONStatistic(
 		fullStatisticIDValue = s.FullStatisticID
 	}
 
+	if !s.IsExpression() {
+		_ /* rows */, err := txn.Exec(
+			ctx,
+			"insert-stats",
+			txn.KV(),
+			`INSERT INTO system.table_statistics (
+					"tableID",
+					"name",
+					"columnIDs",
+					"createdAt",
+					"rowCount",
+					"distinctCount",
+					"nullCount",
+					"avgSize",
+					histogram,
+					"partialPredicate",
+					"fullStatisticID"
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+			tableID,
+			name,
+			columnIDs,
+			s.CreatedAt,
+			s.RowCount,
+			s.DistinctCount,
+			s.NullCount,
+			s.AvgSize,
+			histogram,
+			predicateValue,
+			fullStatisticIDValue,
+		)
+		return err
+	}
+
+	if !params.p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_2_TableStatisticsExpression) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"statistics on expressions are not supported until upgrade to 24.2 is finalized",
+		)
+	}
 	_ /* rows */, err := txn.Exec(
 		ctx,
 		"insert-stats",
@@ -1566,8 +1605,9 @@ func insertJSONStatistic(
 					"avgSize",
 					histogram,
 					"partialPredicate",
-					"fullStatisticID"
-				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+					"fullStatisticID",
+					expression
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
 		tableID,
 		name,
 		columnIDs,
@@ -1579,6 +1619,7 @@ func insertJSONStatistic(
 		histogram,
 		predicateValue,
 		fullStatisticIDValue,
+		s.Expression,
 	)
 	return err
 }
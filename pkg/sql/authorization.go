@@ -303,6 +303,12 @@ func (p *planner) CheckPrivilegeForUser(
 
 // CheckPrivilege implements the AuthorizationAccessor interface.
 // Requires a valid transaction to be open.
+//
+// Note that while a SECURITY DEFINER routine is executing, the current user is
+// the owner of the routine (see pushRoutineSessionOverride), so privileges are
+// checked for the owner rather than for the invoker. The invoker only needs the
+// EXECUTE privilege on the routine itself.
+//
 // TODO(arul): This CheckPrivileges method name is rather deceptive,
 // it should be probably be called CheckPrivilegesOrOwnership and return
 // a better error.
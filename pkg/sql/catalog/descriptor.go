@@ -352,6 +352,11 @@ type TableDescriptor interface {
 	// created at, for materialized views and CREATE TABLE AS. Only valid if
 	// IsAs or MaterializedView returns true.
 	GetCreateAsOfTime() hlc.Timestamp
+	// LastRefreshedAsOf returns the timestamp as of which the data of a
+	// materialized view was last computed, either when the view was created or
+	// by the most recent REFRESH MATERIALIZED VIEW. Only valid if
+	// MaterializedView returns true.
+	LastRefreshedAsOf() hlc.Timestamp
 
 	// GetViewQuery returns this view's CREATE VIEW declaration. Only valid if
 	// IsView is true.
@@ -962,6 +967,13 @@ type FunctionDescriptor interface {
 	// GetFunctionBody returns the function body string.
 	GetFunctionBody() string
 
+	// GetSecurity returns the function's security attribute.
+	GetSecurity() catpb.Function_Security
+
+	// GetConfig returns the session variables that are set while the function
+	// is executing, each in the form "name=value".
+	GetConfig() []string
+
 	// GetParams returns a list of all parameters of the function.
 	GetParams() []descpb.FunctionDescriptor_Parameter
 
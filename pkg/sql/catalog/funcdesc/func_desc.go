@@ -12,6 +12,7 @@ package funcdesc
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
@@ -26,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
@@ -287,8 +289,8 @@ func (desc *immutable) ValidateBackReferences(
 		vea.Report(catalog.ValidateOutboundTypeRefBackReference(desc.GetID(), typ))
 	}
 
-	// We support both table and function references, which we will determine based
-	// on the descriptor type.
+	// We support table, function and type references, which we will determine
+	// based on the descriptor type.
 	for _, by := range desc.DependedOnBy {
 		descriptor, err := vdg.GetDescriptor(by.ID)
 		if err != nil {
@@ -299,6 +301,8 @@ func (desc *immutable) ValidateBackReferences(
 			vea.Report(desc.validateInboundTableRef(by, backRef))
 		case catalog.FunctionDescriptor:
 			vea.Report(desc.validateInboundFunctionRef(by, backRef))
+		case catalog.TypeDescriptor:
+			vea.Report(desc.validateInboundTypeRef(by, backRef))
 		}
 	}
 }
@@ -346,6 +350,30 @@ func (desc *immutable) validateInboundFunctionRef(
 	)
 }
 
+// validateInboundTypeRef validates the back reference from a type on whose
+// descriptor a cast performed by this function is stored.
+func (desc *immutable) validateInboundTypeRef(
+	ref descpb.FunctionDescriptor_Reference, backRefTyp catalog.TypeDescriptor,
+) error {
+	if backRefTyp.Dropped() {
+		return errors.AssertionFailedf("depended-on-by type %q (%d) is dropped",
+			backRefTyp.GetName(), backRefTyp.GetID())
+	}
+	if ref.ColumnIDs != nil || ref.IndexIDs != nil || ref.ConstraintIDs != nil {
+		return errors.AssertionFailedf("type reference has invalid references (%v, %v %v)",
+			ref.ColumnIDs, ref.IndexIDs, ref.ConstraintIDs)
+	}
+	fnOID := catid.FuncIDToOID(desc.GetID())
+	for _, c := range backRefTyp.TypeDesc().Casts {
+		if c.Method == descpb.TypeDescriptor_Cast_FUNCTION && c.FunctionOID == fnOID {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("missing cast using function %q (%d) in type %q (%d)",
+		desc.GetName(), desc.GetID(), backRefTyp.GetName(), backRefTyp.GetID(),
+	)
+}
+
 func (desc *immutable) validateInboundTableRef(
 	by descpb.FunctionDescriptor_Reference, backRefTbl catalog.TableDescriptor,
 ) error {
@@ -566,6 +594,53 @@ func (desc *Mutable) SetNullInputBehavior(v catpb.Function_NullInputBehavior) {
 	desc.NullInputBehavior = v
 }
 
+// SetSecurity sets the security attribute.
+func (desc *Mutable) SetSecurity(v catpb.Function_Security) {
+	desc.Security = v
+}
+
+// SetConfigVar sets the value of the given session variable for the duration
+// of the function's execution, replacing the previous value, if any.
+func (desc *Mutable) SetConfigVar(name, value string) {
+	entry := name + "=" + value
+	for i, c := range desc.Config {
+		if configVarName(c) == name {
+			desc.Config[i] = entry
+			return
+		}
+	}
+	desc.Config = append(desc.Config, entry)
+}
+
+// ResetConfigVar removes the given session variable from the function's
+// configuration.
+func (desc *Mutable) ResetConfigVar(name string) {
+	for i, c := range desc.Config {
+		if configVarName(c) == name {
+			desc.Config = append(desc.Config[:i], desc.Config[i+1:]...)
+			return
+		}
+	}
+}
+
+// ResetAllConfigVars removes all session variables from the function's
+// configuration.
+func (desc *Mutable) ResetAllConfigVars() {
+	desc.Config = nil
+}
+
+// SplitConfigVar splits an entry of a function's configuration into the name
+// of the session variable and its value.
+func SplitConfigVar(entry string) (name, value string) {
+	name, value, _ = strings.Cut(entry, "=")
+	return name, value
+}
+
+func configVarName(entry string) string {
+	name, _ := SplitConfigVar(entry)
+	return name
+}
+
 // SetLang sets the function language.
 func (desc *Mutable) SetLang(v catpb.Function_Language) {
 	desc.Lang = v
@@ -661,6 +736,28 @@ func (desc *Mutable) RemoveFunctionReference(id descpb.ID) error {
 	return nil
 }
 
+// AddTypeCastReference adds back reference for a type on whose descriptor a
+// cast performed by this function is stored.
+func (desc *Mutable) AddTypeCastReference(typeID descpb.ID) {
+	for _, d := range desc.DependedOnBy {
+		if d.ID == typeID {
+			return
+		}
+	}
+	desc.DependedOnBy = append(desc.DependedOnBy, descpb.FunctionDescriptor_Reference{ID: typeID})
+}
+
+// RemoveTypeCastReference removes back reference for a type on whose
+// descriptor a cast performed by this function is stored.
+func (desc *Mutable) RemoveTypeCastReference(typeID descpb.ID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == typeID {
+			desc.DependedOnBy = append(desc.DependedOnBy[:i], desc.DependedOnBy[i+1:]...)
+			return
+		}
+	}
+}
+
 // AddColumnReference adds back reference to a column to the function.
 func (desc *Mutable) AddColumnReference(id descpb.ID, colID descpb.ColumnID) error {
 	for _, dep := range desc.DependsOn {
@@ -808,8 +905,19 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 		}
 		ret.RoutineParams = append(ret.RoutineParams, routineParam)
 	}
-	ret.ReturnType = tree.FixedReturnType(desc.ReturnType.Type)
+	if tree.ContainsPolymorphicType(desc.ReturnType.Type) {
+		// The return type is determined by the types of the arguments at the
+		// call site.
+		ret.ReturnType = tree.PolymorphicReturnType(signatureTypes.Types(), desc.ReturnType.Type)
+	} else {
+		ret.ReturnType = tree.FixedReturnType(desc.ReturnType.Type)
+	}
 	ret.ReturnsRecordType = desc.ReturnType.Type.Identical(types.AnyTuple)
+	if desc.Security == catpb.Function_DEFINER {
+		ret.SecurityMode = tree.RoutineDefiner
+		ret.Owner = desc.GetPrivileges().Owner()
+	}
+	ret.Config = desc.Config
 	ret.Types = signatureTypes
 	ret.Volatility, err = desc.getOverloadVolatility()
 	if err != nil {
@@ -885,17 +993,41 @@ func (desc *immutable) ToCreateExpr() (ret *tree.CreateRoutine, err error) {
 			}
 		}
 	}
-	// We only store 5 function attributes at the moment. We may extend the
-	// pre-allocated capacity in the future.
-	ret.Options = make(tree.RoutineOptions, 0, 5)
+	// We store 5 function attributes unconditionally, in addition to the
+	// security attribute and configuration, which are only included if they
+	// differ from the default.
+	ret.Options = make(tree.RoutineOptions, 0, 6+len(desc.Config))
 	ret.Options = append(ret.Options, desc.getCreateExprVolatility())
 	ret.Options = append(ret.Options, tree.RoutineLeakproof(desc.LeakProof))
 	ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
+	if desc.Security == catpb.Function_DEFINER {
+		ret.Options = append(ret.Options, tree.RoutineDefiner)
+	}
+	for _, entry := range desc.Config {
+		ret.Options = append(ret.Options, getCreateExprSetVar(entry))
+	}
 	ret.Options = append(ret.Options, tree.RoutineBodyStr(desc.FunctionBody))
 	ret.Options = append(ret.Options, desc.getCreateExprLang())
 	return ret, nil
 }
 
+// getCreateExprSetVar converts an entry of a function's configuration to a SET
+// clause.
+func getCreateExprSetVar(entry string) *tree.RoutineSetVar {
+	name, value := SplitConfigVar(entry)
+	values := tree.Exprs{tree.NewStrVal(value)}
+	if name == "search_path" {
+		// The search path is a list, so format each schema separately.
+		if paths, err := sessiondata.ParseSearchPath(value); err == nil {
+			values = make(tree.Exprs, len(paths))
+			for i := range paths {
+				values[i] = tree.NewStrVal(paths[i])
+			}
+		}
+	}
+	return &tree.RoutineSetVar{SetVar: tree.SetVar{Name: name, Values: values}}
+}
+
 // IsProcedure implements the FunctionDescriptor interface.
 func (desc *immutable) IsProcedure() bool {
 	return desc.FunctionDescriptor.IsProcedure
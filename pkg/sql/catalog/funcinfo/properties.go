@@ -103,6 +103,18 @@ func NullInputBehaviorToProto(
 	return -1, errors.AssertionFailedf("Unknown function null input behavior %q", v)
 }
 
+// SecurityToProto converts sql statement input security to protobuf type.
+func SecurityToProto(v tree.RoutineSecurity) (catpb.Function_Security, error) {
+	switch v {
+	case tree.RoutineInvoker:
+		return catpb.Function_INVOKER, nil
+	case tree.RoutineDefiner:
+		return catpb.Function_DEFINER, nil
+	}
+
+	return -1, errors.AssertionFailedf("Unknown function security %q", v)
+}
+
 // FunctionLangToProto converts sql statement input language to protobuf type.
 func FunctionLangToProto(v tree.RoutineLanguage) (catpb.Function_Language, error) {
 	switch v {
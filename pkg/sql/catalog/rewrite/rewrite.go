@@ -30,6 +30,7 @@ import (
 	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/screl"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree/utils"
@@ -726,6 +727,32 @@ func SchemaDescs(schemas []*schemadesc.Mutable, descriptorRewrites jobspb.DescRe
 		}
 		sc.Functions = newFns
 
+		// Rewrite the schema IDs of the dictionaries used by text search
+		// configurations. References to dictionaries in schemas which aren't
+		// restored are dropped, like the functions above.
+		for configName, c := range sc.TextSearchConfigurations {
+			mappings := c.Mappings[:0]
+			for _, m := range c.Mappings {
+				dicts := m.Dictionaries[:0]
+				for _, ref := range m.Dictionaries {
+					if ref.SchemaID != catconstants.PgCatalogID {
+						dictSchema, ok := descriptorRewrites[ref.SchemaID]
+						if !ok {
+							continue
+						}
+						ref.SchemaID = dictSchema.ID
+					}
+					dicts = append(dicts, ref)
+				}
+				if len(dicts) > 0 {
+					m.Dictionaries = dicts
+					mappings = append(mappings, m)
+				}
+			}
+			c.Mappings = mappings
+			sc.TextSearchConfigurations[configName] = c
+		}
+
 		if err := rewriteSchemaChangerState(sc, descriptorRewrites); err != nil {
 			return err
 		}
@@ -0,0 +1,137 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/transform"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// ValidateStatisticExpression verifies that an expression is a valid
+// expression to collect table statistics on. If the expression is valid, it
+// returns the serialized expression with the columns dequalified, its type,
+// and the set of columns it references.
+//
+// A statistics expression is valid if all of the following are true:
+//
+//   - It refers only to public columns in the table, and to at least one.
+//   - It does not refer to virtual computed columns.
+//   - It does not include subqueries.
+//   - It does not include non-immutable, aggregate, window, or set returning
+//     functions.
+//   - Its type is not ambiguous.
+func ValidateStatisticExpression(
+	ctx context.Context,
+	desc catalog.TableDescriptor,
+	e tree.Expr,
+	tn *tree.TableName,
+	semaCtx *tree.SemaContext,
+	version clusterversion.ClusterVersion,
+) (string, *types.T, catalog.TableColSet, error) {
+	// Virtual computed columns are not scanned, so statistics collection would
+	// have to render them before the expression.
+	err := iterColDescriptors(desc, e, func(c catalog.Column) error {
+		if !c.Public() || c.IsInaccessible() {
+			return pgerror.Newf(
+				pgcode.UndefinedColumn,
+				"column %q cannot be referenced in a statistics expression",
+				c.GetName(),
+			)
+		}
+		if c.IsVirtual() {
+			return pgerror.Newf(
+				pgcode.InvalidColumnReference,
+				"statistics expression cannot reference virtual computed column %q",
+				c.GetName(),
+			)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", nil, catalog.TableColSet{}, err
+	}
+
+	expr, typ, cols, err := DequalifyAndValidateExpr(
+		ctx,
+		desc,
+		e,
+		types.Any,
+		tree.StatisticsExpr,
+		semaCtx,
+		volatility.Immutable,
+		tn,
+		version,
+	)
+	if err != nil {
+		return "", nil, catalog.TableColSet{}, err
+	}
+	if cols.Empty() {
+		return "", nil, catalog.TableColSet{}, pgerror.Newf(
+			pgcode.InvalidColumnReference,
+			"statistics expression %s does not reference any columns",
+			e,
+		)
+	}
+	if typ.IsAmbiguous() {
+		return "", nil, catalog.TableColSet{}, errors.WithHint(
+			pgerror.Newf(
+				pgcode.InvalidParameterValue,
+				"type of statistics expression %s is ambiguous",
+				e,
+			),
+			"consider adding a type cast to the expression",
+		)
+	}
+	return expr, typ, cols, nil
+}
+
+// MakeStatisticExpr turns the serialized expression of a table statistic into
+// a TypedExpr over the given columns. The IndexedVars in the returned
+// expression refer to the ordinals of cols.
+func MakeStatisticExpr(
+	ctx context.Context,
+	exprStr string,
+	cols []catalog.Column,
+	tableDesc catalog.TableDescriptor,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+) (tree.TypedExpr, error) {
+	expr, err := parser.ParseExpr(exprStr)
+	if err != nil {
+		return nil, err
+	}
+
+	tn := tree.NewUnqualifiedTableName(tree.Name(tableDesc.GetName()))
+	nr := newNameResolver(evalCtx, tableDesc.GetID(), tn, cols)
+	nr.addIVarContainerToSemaCtx(semaCtx)
+	expr, err = nr.resolveNames(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	typedExpr, err := tree.TypeCheck(ctx, expr, semaCtx, types.Any)
+	if err != nil {
+		return nil, err
+	}
+	var txCtx transform.ExprTransformContext
+	return txCtx.NormalizeExpr(ctx, evalCtx, typedExpr)
+}
@@ -280,6 +280,10 @@ CREATE TABLE system.web_sessions (
 	// Design outlined in /docs/RFCS/20170908_sql_optimizer_statistics.md
 	// Note: avgSize is a newer statistic than the RFC above. It contains the
 	// average size of the column group in bytes.
+	//
+	// expression is set for statistics collected on an expression rather than
+	// on a group of columns. In that case columnIDs are the columns referenced
+	// by the expression.
 	TableStatisticsTableSchema = `
 CREATE TABLE system.table_statistics (
 	"tableID"            INT8       NOT NULL,
@@ -294,8 +298,9 @@ CREATE TABLE system.table_statistics (
 	"avgSize"            INT8       NOT NULL DEFAULT 0,
 	"partialPredicate"   STRING,
 	"fullStatisticID"    INT8,
+	expression           STRING,
 	CONSTRAINT "primary" PRIMARY KEY ("tableID", "statisticID"),
-	FAMILY "fam_0_tableID_statisticID_name_columnIDs_createdAt_rowCount_distinctCount_nullCount_histogram" ("tableID", "statisticID", name, "columnIDs", "createdAt", "rowCount", "distinctCount", "nullCount", histogram, "avgSize", "partialPredicate", "fullStatisticID")
+	FAMILY "fam_0_tableID_statisticID_name_columnIDs_createdAt_rowCount_distinctCount_nullCount_histogram" ("tableID", "statisticID", name, "columnIDs", "createdAt", "rowCount", "distinctCount", "nullCount", histogram, "avgSize", "partialPredicate", "fullStatisticID", expression)
 );`
 
 	// locations are used to map a locality specified by a node to geographic
@@ -1160,6 +1165,19 @@ CREATE TABLE system.mvcc_statistics (
 			created
 		)
 	);`
+
+	// PlanBaselinesTableSchema stores the plan gists pinned to statement
+	// fingerprints with crdb_internal.pin_plan. Every node loads the plan
+	// baselines from this table.
+	PlanBaselinesTableSchema = `
+CREATE TABLE system.plan_baselines (
+	fingerprint STRING NOT NULL,
+	plan_gist   STRING NOT NULL,
+	enforce     BOOL NOT NULL,
+	created     TIMESTAMPTZ NOT NULL DEFAULT now(),
+	CONSTRAINT "primary" PRIMARY KEY (fingerprint),
+	FAMILY "primary" (fingerprint, plan_gist, enforce, created)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1212,7 +1230,7 @@ const SystemDatabaseName = catconstants.SystemDatabaseName
 // release version).
 //
 // NB: Don't set this to clusterversion.Latest; use a specific version instead.
-var SystemDatabaseSchemaBootstrapVersion = clusterversion.V24_2_StmtDiagRedacted.Version()
+var SystemDatabaseSchemaBootstrapVersion = clusterversion.V24_2_TableStatisticsExpression.Version()
 
 // MakeSystemDatabaseDesc constructs a copy of the system database
 // descriptor.
@@ -1403,6 +1421,7 @@ func MakeSystemTables() []SystemTable {
 		SystemMVCCStatisticsTable,
 		StatementExecInsightsTable,
 		TransactionExecInsightsTable,
+		PlanBaselinesTable,
 	}
 }
 
@@ -2117,6 +2136,7 @@ var (
 				{Name: "avgSize", ID: 10, Type: types.Int, DefaultExpr: &zeroIntString},
 				{Name: "partialPredicate", ID: 11, Type: types.String, Nullable: true},
 				{Name: "fullStatisticID", ID: 12, Type: types.Int, Nullable: true},
+				{Name: "expression", ID: 13, Type: types.String, Nullable: true},
 			},
 			[]descpb.ColumnFamilyDescriptor{
 				{
@@ -2135,8 +2155,9 @@ var (
 						"avgSize",
 						"partialPredicate",
 						"fullStatisticID",
+						"expression",
 					},
-					ColumnIDs: []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+					ColumnIDs: []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13},
 				},
 			},
 			descpb.IndexDescriptor{
@@ -4720,6 +4741,30 @@ var (
 			tbl.NextConstraintID++
 		},
 	)
+
+	// PlanBaselinesTable is the descriptor for the plan baselines table.
+	PlanBaselinesTable = makeSystemTable(
+		PlanBaselinesTableSchema,
+		systemTable(
+			catconstants.PlanBaselinesTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "fingerprint", ID: 1, Type: types.String},
+				{Name: "plan_gist", ID: 2, Type: types.String},
+				{Name: "enforce", ID: 3, Type: types.Bool},
+				{Name: "created", ID: 4, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"fingerprint", "plan_gist", "enforce", "created"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4},
+				},
+			},
+			pk("fingerprint"),
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
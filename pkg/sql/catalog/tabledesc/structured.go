@@ -1648,6 +1648,8 @@ func (desc *Mutable) MakeMutationComplete(m descpb.DescriptorMutation) error {
 			// indexes with the new indexes that have been backfilled already.
 			desc.SetPrimaryIndex(t.MaterializedViewRefresh.NewPrimaryIndex)
 			desc.SetPublicNonPrimaryIndexes(t.MaterializedViewRefresh.NewIndexes)
+			desc.ViewRefreshedAsOf = t.MaterializedViewRefresh.AsOf
+			desc.ViewDataCleared = !t.MaterializedViewRefresh.ShouldBackfill
 		}
 
 	case descpb.DescriptorMutation_DROP:
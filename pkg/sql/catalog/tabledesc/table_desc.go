@@ -610,6 +610,14 @@ func (desc *wrapper) AllMutations() []catalog.Mutation {
 	return desc.getExistingOrNewMutationCache().all
 }
 
+// LastRefreshedAsOf implements the TableDescriptor interface.
+func (desc *wrapper) LastRefreshedAsOf() hlc.Timestamp {
+	if !desc.ViewRefreshedAsOf.IsEmpty() {
+		return desc.ViewRefreshedAsOf
+	}
+	return desc.CreateAsOfTime
+}
+
 // IsRefreshViewRequired implements the TableDescriptor interface.
 func (desc *wrapper) IsRefreshViewRequired() bool {
 	return desc.IsMaterializedView && desc.RefreshViewRequired
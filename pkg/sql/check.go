@@ -149,6 +149,10 @@ func matchFullUnacceptableKeyQuery(
 // values in the key are excluded from matching (for both MATCH FULL and MATCH
 // SIMPLE).
 //
+// For MATCH PARTIAL, only rows with all null values in the key are excluded,
+// and the other rows are matched on their non-null key columns, e.g.
+// (s.a_id IS NULL OR s.a_id = t.a) AND (s.b_id IS NULL OR s.b_id = t.b).
+//
 // For example, a FK constraint on columns (a_id, b_id) on the table "child",
 // referencing columns (a, b) on the table "parent", would require the following
 // query:
@@ -224,6 +228,22 @@ func nonMatchingRowQuery(
 		targetCols[i] = fmt.Sprintf("t.%s", tree.NameString(referencedColNames[i]))
 		on[i] = fmt.Sprintf("%s = %s", qualifiedSrcCols[i], targetCols[i])
 	}
+	// Sufficient to check the first column to see whether there was no matching
+	// row.
+	noMatch := fmt.Sprintf("%s IS NULL", targetCols[0])
+	if fk.Match == semenumpb.Match_PARTIAL {
+		for i := 0; i < nCols; i++ {
+			on[i] = fmt.Sprintf("(%s IS NULL OR %s)", qualifiedSrcCols[i], on[i])
+		}
+		srcWhere = []string{fmt.Sprintf("(%s)", strings.Join(srcWhere, " OR "))}
+		// A matching row can have NULLs in the columns where the key is NULL,
+		// but not in all of its columns.
+		noMatchCols := make([]string, nCols)
+		for i := range noMatchCols {
+			noMatchCols[i] = fmt.Sprintf("%s IS NULL", targetCols[i])
+		}
+		noMatch = strings.Join(noMatchCols, " AND ")
+	}
 
 	limit := ""
 	if limitResults {
@@ -235,16 +255,15 @@ func nonMatchingRowQuery(
 			LEFT OUTER JOIN
 			[%[5]d AS target] AS t
 			ON %[6]s
-		 WHERE %[7]s IS NULL %[8]s`,
+		 WHERE %[7]s %[8]s`,
 		strings.Join(qualifiedSrcCols, ", "), // 1
 		strings.Join(srcCols, ", "),          // 2
 		srcTbl.GetID(),                       // 3
 		strings.Join(srcWhere, " AND "),      // 4
 		targetTbl.GetID(),                    // 5
 		strings.Join(on, " AND "),            // 6
-		// Sufficient to check the first column to see whether there was no matching row
-		targetCols[0], // 7
-		limit,         // 8
+		noMatch,                              // 7
+		limit,                                // 8
 	)
 	if indexIDForValidation != 0 {
 		query = fmt.Sprintf(
@@ -253,7 +272,7 @@ func nonMatchingRowQuery(
 			LEFT OUTER JOIN
 			[%[6]d AS target] AS t
 			ON %[7]s
-		 WHERE %[8]s IS NULL %[9]s`,
+		 WHERE %[8]s %[9]s`,
 			strings.Join(qualifiedSrcCols, ", "), // 1
 			strings.Join(srcCols, ", "),          // 2
 			srcTbl.GetID(),                       // 3
@@ -261,9 +280,8 @@ func nonMatchingRowQuery(
 			strings.Join(srcWhere, " AND "),      // 5
 			targetTbl.GetID(),                    // 6
 			strings.Join(on, " AND "),            // 7
-			// Sufficient to check the first column to see whether there was no matching row
-			targetCols[0], // 8
-			limit,         // 9
+			noMatch,                              // 8
+			limit,                                // 9
 		)
 	}
 	return query, originColNames, nil
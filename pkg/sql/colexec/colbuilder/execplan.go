@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/col/coldataext"
 	"github.com/cockroachdb/cockroach/pkg/col/typeconv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/colconv"
@@ -136,7 +137,7 @@ type opResult struct {
 // vectorized engine (neither natively nor by wrapping the corresponding row
 // execution processor).
 func IsSupported(mode sessiondatapb.VectorizeExecMode, spec *execinfrapb.ProcessorSpec) error {
-	err := supportedNatively(&spec.Core)
+	err := supportedNatively(spec)
 	if err != nil {
 		if wrapErr := canWrap(mode, &spec.Core); wrapErr == nil {
 			// We don't support this spec natively, but we can wrap the row
@@ -148,9 +149,10 @@ func IsSupported(mode sessiondatapb.VectorizeExecMode, spec *execinfrapb.Process
 }
 
 // supportedNatively checks whether we have a columnar operator equivalent to a
-// processor described by core. Note that it doesn't perform any other checks
+// processor described by spec. Note that it doesn't perform any other checks
 // (like validity of the number of inputs).
-func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
+func supportedNatively(spec *execinfrapb.ProcessorSpec) error {
+	core := &spec.Core
 	switch {
 	case core.Noop != nil:
 		return nil
@@ -162,10 +164,15 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 		return nil
 
 	case core.JoinReader != nil:
-		if !core.JoinReader.IsIndexJoin() {
-			return errLookupJoinUnsupported
+		if core.JoinReader.IsIndexJoin() {
+			return nil
 		}
-		return nil
+		if len(spec.Input) == 1 && core.JoinReader.IsAdaptiveLookupJoin(spec.Input[0].ColumnTypes) {
+			// Adaptive lookup joins are planned natively, even though the
+			// lookup join itself is performed by the wrapped joinReader.
+			return nil
+		}
+		return errLookupJoinUnsupported
 
 	case core.Filterer != nil:
 		return nil
@@ -207,6 +214,11 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 			if wf.FilterColIdx != tree.NoColumnIdx {
 				return errWindowFunctionFilterClause
 			}
+			if wf.Func.WindowFunc != nil {
+				if !colexecwindow.WindowFnIsSupported(*wf.Func.WindowFunc) {
+					return errUnsupportedWindowFunction
+				}
+			}
 			if wf.Func.AggregateFunc != nil {
 				if !colexecagg.IsAggOptimized(*wf.Func.AggregateFunc) {
 					return errDefaultAggregateWindowFunction
@@ -248,6 +260,7 @@ var (
 	errNonInnerMergeJoinWithOnExpr    = errors.New("can't plan vectorized non-inner merge joins with ON expressions")
 	errWindowFunctionFilterClause     = errors.New("window functions with FILTER clause are not supported")
 	errDefaultAggregateWindowFunction = errors.New("default aggregate window functions not supported")
+	errUnsupportedWindowFunction      = errors.New("window function is not supported")
 	errStreamIngestionWrap            = errors.New("core.StreamIngestion{Data,Frontier} is not supported because of #55758")
 )
 
@@ -645,6 +658,163 @@ func makeNewHashJoinerArgs(
 	}, hashJoinerMemMonitorName
 }
 
+// planHashJoiner plans a hash joiner according to core on top of args.Inputs
+// and sets it as the root of r. Unless disk spilling is disabled, the
+// in-memory hash joiner is wrapped into a disk spiller that falls back to the
+// external hash joiner.
+func (r opResult) planHashJoiner(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	args *colexecargs.NewColOperatorArgs,
+	core *execinfrapb.HashJoinerSpec,
+	factory coldata.ColumnFactory,
+) {
+	opName := redact.RedactableString("hash-joiner")
+	hjArgs, hashJoinerMemMonitorName := makeNewHashJoinerArgs(
+		ctx,
+		flowCtx,
+		args,
+		opName,
+		core,
+		factory,
+	)
+	inMemoryHashJoiner := colexecjoin.NewHashJoiner(hjArgs)
+	if args.TestingKnobs.DiskSpillingDisabled {
+		// We will not be creating a disk-backed hash joiner because we're
+		// running a test that explicitly asked for only in-memory hash joiner.
+		r.Root = inMemoryHashJoiner
+	} else {
+		opName := redact.RedactableString("external-hash-joiner")
+		diskAccount := args.MonitorRegistry.CreateDiskAccount(ctx, flowCtx, opName, args.Spec.ProcessorID)
+		diskSpiller := colexecdisk.NewTwoInputDiskSpiller(
+			args.Inputs[0].Root, args.Inputs[1].Root, inMemoryHashJoiner.(colexecop.BufferingInMemoryOperator),
+			[]redact.RedactableString{hashJoinerMemMonitorName},
+			func(inputOne, inputTwo colexecop.Operator) colexecop.Operator {
+				accounts := args.MonitorRegistry.CreateUnlimitedMemAccounts(
+					ctx, flowCtx, opName, args.Spec.ProcessorID, 2, /* numAccounts */
+				)
+				unlimitedAllocator := colmem.NewAllocator(ctx, accounts[0], factory)
+				ehj := colexecdisk.NewExternalHashJoiner(
+					unlimitedAllocator,
+					flowCtx,
+					args,
+					hjArgs.Spec,
+					inputOne, inputTwo,
+					r.makeDiskBackedSorterConstructor(ctx, flowCtx, args, opName, factory),
+					diskAccount,
+					accounts[1],
+				)
+				r.ToClose = append(r.ToClose, ehj)
+				return ehj
+			},
+			args.TestingKnobs.SpillingCallbackFn,
+		)
+		r.Root = diskSpiller
+		r.ToClose = append(r.ToClose, diskSpiller)
+	}
+}
+
+// planAdaptiveLookupJoin plans an adaptive lookup join according to the
+// JoinReaderSpec in args (see colexecjoin.AdaptiveJoiner). The first tuples of
+// input are joined by the wrapped row-execution lookup join, and once the
+// threshold is crossed, the remaining tuples are joined by a hash join against
+// a full scan of the lookup index. The physical planner only sets the threshold
+// if the lookup join has a single stream or runs on the gateway, so that the
+// index is not scanned by every node that runs the lookup join.
+func (r opResult) planAdaptiveLookupJoin(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	args *colexecargs.NewColOperatorArgs,
+	input colexecop.Operator,
+	factory coldata.ColumnFactory,
+) error {
+	spec := args.Spec
+	jr := spec.Core.JoinReader
+	inputTypes := spec.Input[0].ColumnTypes
+	keyOrds, ok := jr.LookupKeyOrdinals()
+	if !ok {
+		return errors.AssertionFailedf("lookup columns of an adaptive lookup join are not fetched")
+	}
+	splitter := colexecjoin.NewAdaptiveJoinSplitter(input, jr.AdaptiveJoinThreshold)
+
+	// Plan the lookup join. Its post-processing is done on top of the adaptive
+	// joiner.
+	lookupSpec := *jr
+	lookupSpec.AdaptiveJoinThreshold = 0
+	lookupResult := opResult{NewColOperatorResult: &colexecargs.NewColOperatorResult{}}
+	if err := lookupResult.createAndWrapRowSource(
+		ctx, flowCtx, args,
+		[]colexecargs.OpWithMetaInfo{{Root: splitter.LookupInput()}},
+		[][]*types.T{inputTypes},
+		&execinfrapb.ProcessorCoreUnion{JoinReader: &lookupSpec},
+		&execinfrapb.PostProcessSpec{}, spec.ProcessorID, factory, errLookupJoinUnsupported,
+	); err != nil {
+		return err
+	}
+
+	// Plan the full scan of the lookup index.
+	indexPrefix := flowCtx.Codec().IndexPrefix(uint32(jr.FetchSpec.TableID), uint32(jr.FetchSpec.IndexID))
+	scanSpec := execinfrapb.TableReaderSpec{
+		FetchSpec:              jr.FetchSpec,
+		Spans:                  []roachpb.Span{{Key: indexPrefix, EndKey: indexPrefix.PrefixEnd()}},
+		IgnoreMisplannedRanges: true,
+	}
+	accounts := args.MonitorRegistry.CreateUnlimitedMemAccounts(
+		ctx, flowCtx, "adaptive-join-cfetcher" /* opName */, spec.ProcessorID, 2, /* numAccounts */
+	)
+	scanOp, scanTypes, err := colfetcher.NewColBatchScan(
+		ctx, colmem.NewAllocator(ctx, accounts[0], factory), accounts[1], flowCtx,
+		spec.ProcessorID, &scanSpec, &execinfrapb.PostProcessSpec{}, 0, /* estimatedRowCount */
+		args.TypeResolver,
+	)
+	if err != nil {
+		return err
+	}
+	scanResult := opResult{NewColOperatorResult: &colexecargs.NewColOperatorResult{}}
+	scanResult.finishScanPlanning(scanOp, scanTypes)
+
+	// Plan the hash join of the remaining tuples against the scan.
+	hjSpec := &execinfrapb.HashJoinerSpec{
+		LeftEqColumns:        jr.LookupColumns,
+		RightEqColumns:       keyOrds,
+		Type:                 jr.Type,
+		RightEqColumnsAreKey: jr.LookupColumnsAreKey,
+	}
+	hjArgs := *args
+	hjArgs.Spec = &execinfrapb.ProcessorSpec{
+		Input:       []execinfrapb.InputSyncSpec{{ColumnTypes: inputTypes}, {ColumnTypes: scanTypes}},
+		ProcessorID: spec.ProcessorID,
+	}
+	hjArgs.Inputs = []colexecargs.OpWithMetaInfo{{Root: splitter.HashInput()}, {Root: scanResult.Root}}
+	hashResult := opResult{NewColOperatorResult: &colexecargs.NewColOperatorResult{}}
+	hashResult.planHashJoiner(ctx, flowCtx, &hjArgs, hjSpec, factory)
+	hashResult.ColumnTypes = jr.Type.MakeOutputTypes(inputTypes, scanTypes)
+	if !jr.OnExpr.Empty() {
+		// Only inner adaptive lookup joins can have an ON expression.
+		if err = hashResult.planAndMaybeWrapFilter(
+			ctx, flowCtx, &hjArgs, spec.ProcessorID, jr.OnExpr, factory,
+		); err != nil {
+			return err
+		}
+	}
+
+	adaptiveJoiner := colexecjoin.NewAdaptiveJoiner(
+		splitter, lookupResult.Root, hashResult.Root, lookupResult.Columnarizer, scanOp,
+	)
+	r.Root = adaptiveJoiner
+	// The adaptive joiner combines the stats of the wrapped lookup join with
+	// the stats of the scan, so it takes the place of the columnarizer.
+	r.Columnarizer = adaptiveJoiner
+	r.ColumnTypes = lookupResult.ColumnTypes
+	for _, sub := range []opResult{lookupResult, scanResult, hashResult} {
+		r.StatsCollectors = append(r.StatsCollectors, sub.StatsCollectors...)
+		r.MetadataSources = append(r.MetadataSources, sub.MetadataSources...)
+		r.ToClose = append(r.ToClose, sub.ToClose...)
+		r.Releasables = append(r.Releasables, sub.Releasables...)
+	}
+	return nil
+}
+
 func makeNewHashAggregatorArgs(
 	ctx context.Context,
 	flowCtx *execinfra.FlowCtx,
@@ -732,7 +902,7 @@ func NewColOperator(
 	core := &spec.Core
 	post := &spec.Post
 
-	if err = supportedNatively(core); err != nil {
+	if err = supportedNatively(spec); err != nil {
 		inputTypes := make([][]*types.T, len(spec.Input))
 		for inputIdx, input := range spec.Input {
 			inputTypes[inputIdx] = input.ColumnTypes
@@ -896,6 +1066,12 @@ func NewColOperator(
 			if err := checkNumIn(inputs, 1); err != nil {
 				return r, err
 			}
+			if core.JoinReader.IsAdaptiveLookupJoin(spec.Input[0].ColumnTypes) {
+				if err = result.planAdaptiveLookupJoin(ctx, flowCtx, args, inputs[0].Root, factory); err != nil {
+					return r, err
+				}
+				break
+			}
 			if !core.JoinReader.IsIndexJoin() {
 				return r, errors.AssertionFailedf("lookup join reader is unsupported in vectorized")
 			}
@@ -1162,50 +1338,7 @@ func NewColOperator(
 				)
 				result.ToClose = append(result.ToClose, result.Root.(colexecop.Closer))
 			} else {
-				opName := redact.RedactableString("hash-joiner")
-				hjArgs, hashJoinerMemMonitorName := makeNewHashJoinerArgs(
-					ctx,
-					flowCtx,
-					args,
-					opName,
-					core.HashJoiner,
-					factory,
-				)
-				inMemoryHashJoiner := colexecjoin.NewHashJoiner(hjArgs)
-				if args.TestingKnobs.DiskSpillingDisabled {
-					// We will not be creating a disk-backed hash joiner because
-					// we're running a test that explicitly asked for only
-					// in-memory hash joiner.
-					result.Root = inMemoryHashJoiner
-				} else {
-					opName := redact.RedactableString("external-hash-joiner")
-					diskAccount := args.MonitorRegistry.CreateDiskAccount(ctx, flowCtx, opName, spec.ProcessorID)
-					diskSpiller := colexecdisk.NewTwoInputDiskSpiller(
-						inputs[0].Root, inputs[1].Root, inMemoryHashJoiner.(colexecop.BufferingInMemoryOperator),
-						[]redact.RedactableString{hashJoinerMemMonitorName},
-						func(inputOne, inputTwo colexecop.Operator) colexecop.Operator {
-							accounts := args.MonitorRegistry.CreateUnlimitedMemAccounts(
-								ctx, flowCtx, opName, spec.ProcessorID, 2, /* numAccounts */
-							)
-							unlimitedAllocator := colmem.NewAllocator(ctx, accounts[0], factory)
-							ehj := colexecdisk.NewExternalHashJoiner(
-								unlimitedAllocator,
-								flowCtx,
-								args,
-								hjArgs.Spec,
-								inputOne, inputTwo,
-								result.makeDiskBackedSorterConstructor(ctx, flowCtx, args, opName, factory),
-								diskAccount,
-								accounts[1],
-							)
-							result.ToClose = append(result.ToClose, ehj)
-							return ehj
-						},
-						args.TestingKnobs.SpillingCallbackFn,
-					)
-					result.Root = diskSpiller
-					result.ToClose = append(result.ToClose, diskSpiller)
-				}
+				result.planHashJoiner(ctx, flowCtx, args, core.HashJoiner, factory)
 			}
 
 			result.ColumnTypes = core.HashJoiner.Type.MakeOutputTypes(spec.Input[0].ColumnTypes, spec.Input[1].ColumnTypes)
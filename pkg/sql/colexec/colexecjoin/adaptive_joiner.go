@@ -0,0 +1,325 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecjoin
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/errors"
+)
+
+// AdaptiveJoinSplitter splits the tuples of the left input of an adaptive join
+// between the lookup join and the hash join that implement it. The first
+// threshold tuples are returned by the lookup join input. Once more tuples are
+// observed, the lookup join input is exhausted, and all of the remaining
+// tuples are returned by the hash join input.
+//
+// Every left tuple is joined independently of the others, so the result of
+// the adaptive join is the output of the lookup join followed by the output of
+// the hash join. Note that the hash join input must only be read once the
+// lookup join has been fully consumed.
+type AdaptiveJoinSplitter struct {
+	colexecop.InitHelper
+
+	input     colexecop.Operator
+	threshold uint64
+
+	// lookupTuples and hashTuples are the numbers of tuples returned by the
+	// lookup join input and the hash join input, respectively.
+	lookupTuples uint64
+	hashTuples   uint64
+	// switched is true once more than threshold tuples have been observed.
+	switched bool
+	// pending, if non-nil, is the batch that crossed the threshold. Its tuples
+	// at the indices in remainder are yet to be returned by the hash join
+	// input.
+	pending   coldata.Batch
+	remainder []int
+}
+
+// NewAdaptiveJoinSplitter returns a new AdaptiveJoinSplitter that switches
+// from the lookup join to the hash join once more than threshold tuples of
+// input have been observed.
+func NewAdaptiveJoinSplitter(input colexecop.Operator, threshold uint64) *AdaptiveJoinSplitter {
+	return &AdaptiveJoinSplitter{input: input, threshold: threshold}
+}
+
+func (s *AdaptiveJoinSplitter) init(ctx context.Context) {
+	if !s.InitHelper.Init(ctx) {
+		return
+	}
+	s.input.Init(s.Ctx)
+}
+
+// LookupInput returns the operator to be used as the input of the lookup join.
+func (s *AdaptiveJoinSplitter) LookupInput() colexecop.Operator {
+	return &adaptiveJoinLookupInput{splitter: s}
+}
+
+// HashInput returns the operator to be used as the left input of the hash
+// join.
+func (s *AdaptiveJoinSplitter) HashInput() colexecop.Operator {
+	return &adaptiveJoinHashInput{splitter: s}
+}
+
+// adaptiveJoinLookupInput returns the tuples of the splitter's input until the
+// threshold is crossed.
+type adaptiveJoinLookupInput struct {
+	colexecop.NonExplainable
+	splitter *AdaptiveJoinSplitter
+}
+
+var _ colexecop.Operator = &adaptiveJoinLookupInput{}
+
+// Init implements the colexecop.Operator interface.
+func (i *adaptiveJoinLookupInput) Init(ctx context.Context) {
+	i.splitter.init(ctx)
+}
+
+// Next implements the colexecop.Operator interface.
+func (i *adaptiveJoinLookupInput) Next() coldata.Batch {
+	s := i.splitter
+	if s.switched {
+		return coldata.ZeroBatch
+	}
+	batch := s.input.Next()
+	n := batch.Length()
+	if s.lookupTuples+uint64(n) <= s.threshold {
+		s.lookupTuples += uint64(n)
+		return batch
+	}
+	// This batch crosses the threshold, so only its first k tuples are joined
+	// by the lookup join, and the rest are left for the hash join.
+	k := int(s.threshold - s.lookupTuples)
+	s.lookupTuples = s.threshold
+	s.switched = true
+	s.pending = batch
+	s.remainder = s.remainder[:0]
+	if sel := batch.Selection(); sel != nil {
+		s.remainder = append(s.remainder, sel[k:n]...)
+	} else {
+		for j := k; j < n; j++ {
+			s.remainder = append(s.remainder, j)
+		}
+		if k > 0 {
+			batch.SetSelection(true)
+			sel = batch.Selection()
+			for j := 0; j < k; j++ {
+				sel[j] = j
+			}
+		}
+	}
+	if k == 0 {
+		return coldata.ZeroBatch
+	}
+	batch.SetLength(k)
+	return batch
+}
+
+// ChildCount implements the execopnode.OpNode interface.
+func (i *adaptiveJoinLookupInput) ChildCount(verbose bool) int {
+	return 1
+}
+
+// Child implements the execopnode.OpNode interface.
+func (i *adaptiveJoinLookupInput) Child(nth int, verbose bool) execopnode.OpNode {
+	if nth == 0 {
+		return i.splitter.input
+	}
+	colexecerror.InternalError(errors.AssertionFailedf("invalid index %d", nth))
+	// This code is unreachable, but the compiler cannot infer that.
+	return nil
+}
+
+// adaptiveJoinHashInput returns the tuples of the splitter's input after the
+// threshold was crossed.
+type adaptiveJoinHashInput struct {
+	colexecop.ZeroInputNode
+	colexecop.NonExplainable
+	splitter *AdaptiveJoinSplitter
+}
+
+var _ colexecop.Operator = &adaptiveJoinHashInput{}
+
+// Init implements the colexecop.Operator interface.
+func (i *adaptiveJoinHashInput) Init(ctx context.Context) {
+	i.splitter.init(ctx)
+}
+
+// Next implements the colexecop.Operator interface.
+func (i *adaptiveJoinHashInput) Next() coldata.Batch {
+	s := i.splitter
+	if !s.switched {
+		return coldata.ZeroBatch
+	}
+	var batch coldata.Batch
+	if s.pending != nil {
+		batch = s.pending
+		s.pending = nil
+		batch.SetSelection(true)
+		copy(batch.Selection(), s.remainder)
+		batch.SetLength(len(s.remainder))
+	} else {
+		batch = s.input.Next()
+	}
+	s.hashTuples += uint64(batch.Length())
+	return batch
+}
+
+type adaptiveJoinState int
+
+const (
+	adaptiveJoinLookup adaptiveJoinState = iota
+	adaptiveJoinHash
+	adaptiveJoinDone
+)
+
+// AdaptiveJoiner is an operator that performs a lookup join for the first
+// tuples of its left input and switches to a hash join against a full scan of
+// the index for all of the remaining tuples once their number crosses a
+// threshold (see AdaptiveJoinSplitter).
+type AdaptiveJoiner struct {
+	colexecop.InitHelper
+
+	splitter   *AdaptiveJoinSplitter
+	lookupJoin colexecop.Operator
+	hashJoin   colexecop.Operator
+	// lookupStats, if non-nil, returns the execution statistics of the lookup
+	// join.
+	lookupStats colexecop.VectorizedStatsCollector
+	// scan, if non-nil, is the operator that reads the right input of the hash
+	// join.
+	scan colexecop.KVReader
+
+	state adaptiveJoinState
+}
+
+var _ colexecop.VectorizedStatsCollector = &AdaptiveJoiner{}
+
+// NewAdaptiveJoiner returns a new AdaptiveJoiner. lookupJoin must read from
+// the splitter's LookupInput, and hashJoin must read from its HashInput.
+func NewAdaptiveJoiner(
+	splitter *AdaptiveJoinSplitter,
+	lookupJoin colexecop.Operator,
+	hashJoin colexecop.Operator,
+	lookupStats colexecop.VectorizedStatsCollector,
+	scan colexecop.KVReader,
+) *AdaptiveJoiner {
+	return &AdaptiveJoiner{
+		splitter:    splitter,
+		lookupJoin:  lookupJoin,
+		hashJoin:    hashJoin,
+		lookupStats: lookupStats,
+		scan:        scan,
+	}
+}
+
+// Init implements the colexecop.Operator interface.
+func (a *AdaptiveJoiner) Init(ctx context.Context) {
+	if !a.InitHelper.Init(ctx) {
+		return
+	}
+	a.lookupJoin.Init(a.Ctx)
+	a.hashJoin.Init(a.Ctx)
+}
+
+// Next implements the colexecop.Operator interface.
+func (a *AdaptiveJoiner) Next() coldata.Batch {
+	for {
+		switch a.state {
+		case adaptiveJoinLookup:
+			if batch := a.lookupJoin.Next(); batch.Length() > 0 {
+				return batch
+			}
+			if !a.splitter.switched {
+				// The left input was exhausted before the threshold was
+				// crossed, so there is no need to perform the hash join.
+				a.state = adaptiveJoinDone
+				continue
+			}
+			a.state = adaptiveJoinHash
+		case adaptiveJoinHash:
+			if batch := a.hashJoin.Next(); batch.Length() > 0 {
+				return batch
+			}
+			a.state = adaptiveJoinDone
+		case adaptiveJoinDone:
+			return coldata.ZeroBatch
+		default:
+			colexecerror.InternalError(errors.AssertionFailedf("unexpected adaptiveJoinState %d", a.state))
+		}
+	}
+}
+
+// ChildCount implements the execopnode.OpNode interface.
+func (a *AdaptiveJoiner) ChildCount(verbose bool) int {
+	return 2
+}
+
+// Child implements the execopnode.OpNode interface.
+func (a *AdaptiveJoiner) Child(nth int, verbose bool) execopnode.OpNode {
+	switch nth {
+	case 0:
+		return a.lookupJoin
+	case 1:
+		return a.hashJoin
+	}
+	colexecerror.InternalError(errors.AssertionFailedf("invalid index %d", nth))
+	// This code is unreachable, but the compiler cannot infer that.
+	return nil
+}
+
+// Switched returns whether the join switched to the hash join.
+func (a *AdaptiveJoiner) Switched() bool {
+	return a.splitter.switched
+}
+
+// GetStats is part of the colexecop.VectorizedStatsCollector interface. It
+// returns the statistics of the lookup join combined with the KV statistics of
+// the scan performed by the hash join.
+func (a *AdaptiveJoiner) GetStats() *execinfrapb.ComponentStats {
+	var s *execinfrapb.ComponentStats
+	if a.lookupStats != nil {
+		s = a.lookupStats.GetStats()
+	} else {
+		s = &execinfrapb.ComponentStats{}
+	}
+	s.AdaptiveJoin.LookupRows.Set(a.splitter.lookupTuples)
+	if !a.splitter.switched {
+		return s
+	}
+	s.AdaptiveJoin.HashRows.Set(a.splitter.hashTuples)
+	if a.scan != nil {
+		s.KV.TuplesRead.Add(a.scan.GetRowsRead())
+		s.KV.BytesRead.Add(a.scan.GetBytesRead())
+		s.KV.KVPairsRead.Add(a.scan.GetKVPairsRead())
+		s.KV.BatchRequestsIssued.Add(a.scan.GetBatchRequestsIssued())
+		s.KV.ContentionTime.Add(a.scan.GetContentionTime())
+		var scanKV execinfrapb.KVStats
+		scanStats := a.scan.GetScanStats()
+		execstats.PopulateKVMVCCStats(&scanKV, &scanStats)
+		s.KV.NumInterfaceSteps.MaybeAdd(scanKV.NumInterfaceSteps)
+		s.KV.NumInternalSteps.MaybeAdd(scanKV.NumInternalSteps)
+		s.KV.NumInterfaceSeeks.MaybeAdd(scanKV.NumInterfaceSeeks)
+		s.KV.NumInternalSeeks.MaybeAdd(scanKV.NumInternalSeeks)
+		// The CPU time spent serving the KV requests of the scan is subtracted
+		// from the SQL CPU time of the join.
+		s.KV.KVCPUTime.Add(a.scan.GetKVCPUTime())
+		s.Exec.ConsumedRU.Add(int64(a.scan.GetConsumedRU()))
+	}
+	return s
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecjoin
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveJoiner(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	typs := []*types.T{types.Int}
+	var tups colexectestutils.Tuples
+	for i := 0; i < 10; i++ {
+		tups = append(tups, colexectestutils.Tuple{i})
+	}
+	for _, threshold := range []uint64{0, 1, 4, 5, 9, 10, 11} {
+		t.Run(fmt.Sprintf("threshold=%d", threshold), func(t *testing.T) {
+			// The lookup join and the hash join are replaced with no-op
+			// operators, so the adaptive joiner must return all of its input
+			// tuples in order regardless of where the switch happens.
+			colexectestutils.RunTests(t, testAllocator, []colexectestutils.Tuples{tups}, tups,
+				colexectestutils.OrderedVerifier,
+				func(inputs []colexecop.Operator) (colexecop.Operator, error) {
+					s := NewAdaptiveJoinSplitter(inputs[0], threshold)
+					return NewAdaptiveJoiner(
+						s, colexecop.NewNoop(s.LookupInput()), colexecop.NewNoop(s.HashInput()),
+						nil /* lookupStats */, nil, /* scan */
+					), nil
+				})
+
+			// Check that the stats show where the switch happened.
+			input := colexectestutils.NewOpTestInput(testAllocator, 3 /* batchSize */, tups, typs)
+			s := NewAdaptiveJoinSplitter(input, threshold)
+			a := NewAdaptiveJoiner(
+				s, colexecop.NewNoop(s.LookupInput()), colexecop.NewNoop(s.HashInput()),
+				nil /* lookupStats */, nil, /* scan */
+			)
+			a.Init(ctx)
+			for b := a.Next(); b.Length() > 0; b = a.Next() {
+			}
+			stats := a.GetStats()
+			numTuples := uint64(len(tups))
+			if threshold < numTuples {
+				require.True(t, a.Switched())
+				require.Equal(t, threshold, stats.AdaptiveJoin.LookupRows.Value())
+				require.Equal(t, numTuples-threshold, stats.AdaptiveJoin.HashRows.Value())
+			} else {
+				require.False(t, a.Switched())
+				require.Equal(t, numTuples, stats.AdaptiveJoin.LookupRows.Value())
+				require.False(t, stats.AdaptiveJoin.HashRows.HasValue())
+			}
+		})
+	}
+}
@@ -1314,6 +1314,9 @@ func BenchmarkWindowFunctions(b *testing.B) {
 
 	for windowFnIdx := 0; windowFnIdx < len(execinfrapb.WindowerSpec_WindowFunc_name); windowFnIdx++ {
 		windowFn := execinfrapb.WindowerSpec_WindowFunc(windowFnIdx)
+		if !WindowFnIsSupported(windowFn) {
+			continue
+		}
 		numArgs := windowFnMaxNumArgs[windowFn]
 		runBench(execinfrapb.WindowerSpec_Func{WindowFunc: &windowFn}, windowFn.String(), numArgs)
 	}
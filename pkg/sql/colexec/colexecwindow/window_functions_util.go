@@ -58,6 +58,14 @@ var windowFnMaxNumArgs = map[execinfrapb.WindowerSpec_WindowFunc]int{
 	execinfrapb.WindowerSpec_NTH_VALUE:    2,
 }
 
+// WindowFnIsSupported returns whether the given window function has a
+// vectorized implementation. Window functions without one (e.g. the spatial
+// clustering functions) are executed by wrapping the row-based windower.
+func WindowFnIsSupported(windowFn execinfrapb.WindowerSpec_WindowFunc) bool {
+	_, ok := windowFnMaxNumArgs[windowFn]
+	return ok
+}
+
 // WindowFnNeedsPeersInfo returns whether a window function pays attention to
 // the concept of "peers" during its computation ("peers" are tuples within the
 // same partition - from PARTITION BY clause - that are not distinct on the
@@ -515,6 +515,12 @@ func makeMetrics(internal bool) Metrics {
 			SQLOptFallbackCount:   metric.NewCounter(getMetricMeta(MetaSQLOptFallback, internal)),
 			SQLOptPlanCacheHits:   metric.NewCounter(getMetricMeta(MetaSQLOptPlanCacheHits, internal)),
 			SQLOptPlanCacheMisses: metric.NewCounter(getMetricMeta(MetaSQLOptPlanCacheMisses, internal)),
+			SQLOptPlanBaselineHits: metric.NewCounter(
+				getMetricMeta(MetaSQLOptPlanBaselineHits, internal),
+			),
+			SQLOptPlanBaselineMismatches: metric.NewCounter(
+				getMetricMeta(MetaSQLOptPlanBaselineMismatches, internal),
+			),
 			// TODO(mrtracy): See HistogramWindowInterval in server/config.go for the 6x factor.
 			DistSQLExecLatency: metric.NewHistogram(metric.HistogramOptions{
 				Mode:         metric.HistogramModePreferHdrLatency,
@@ -1547,6 +1553,13 @@ type connExecutor struct {
 			resumeProc *memo.Memo
 		}
 
+		// chainedTxnModes, if non-nil, contains the characteristics of a
+		// transaction that was just finished by a COMMIT AND CHAIN or ROLLBACK AND
+		// CHAIN statement. The statement is executed again in the NoTxn state,
+		// where chainedTxnModes is used to start the new transaction. It is reset
+		// by the connExecutor in execCmd once the statement buffer advances.
+		chainedTxnModes *tree.TransactionModes
+
 		// shouldExecuteOnTxnRestart indicates that ex.onTxnRestart will be
 		// called when txn is being retried. It is true when txn is started but
 		// can remain false when txn is executed within another higher-level
@@ -2660,6 +2673,12 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		ex.extraTxnState.storedProcTxnState.txnModes = nil
 	}
 
+	// Similarly, the modes for a chained transaction are only needed while a
+	// COMMIT AND CHAIN or ROLLBACK AND CHAIN statement is being re-executed.
+	if advInfo.code != stayInPlace {
+		ex.extraTxnState.chainedTxnModes = nil
+	}
+
 	if err := ex.updateTxnRewindPosMaybe(ctx, cmd, pos, advInfo); err != nil {
 		return err
 	}
@@ -3613,6 +3632,51 @@ func txnPriorityToProto(mode tree.UserPriority) roachpb.UserPriority {
 	return pri
 }
 
+// txnPriorityFromProto is the inverse of txnPriorityToProto. Priorities that
+// do not correspond to LOW or HIGH are reported as NORMAL.
+func txnPriorityFromProto(pri roachpb.UserPriority) tree.UserPriority {
+	switch pri {
+	case roachpb.MinUserPriority:
+		return tree.Low
+	case roachpb.MaxUserPriority:
+		return tree.High
+	default:
+		return tree.Normal
+	}
+}
+
+// makeChainedTxnModes returns the transaction modes for a transaction that is
+// chained to one with the given characteristics through COMMIT AND CHAIN or
+// ROLLBACK AND CHAIN. The new transaction has the same isolation level,
+// priority, read-only mode, and deferrable mode as the old one. DEFERRABLE
+// transactions are not supported, so the old transaction is always NOT
+// DEFERRABLE.
+func makeChainedTxnModes(
+	isoLevel isolation.Level, pri roachpb.UserPriority, readOnly bool,
+) tree.TransactionModes {
+	modes := tree.TransactionModes{
+		Isolation:     tree.IsolationLevelFromKVTxnIsolationLevel(isoLevel),
+		UserPriority:  txnPriorityFromProto(pri),
+		ReadWriteMode: tree.ReadWrite,
+		Deferrable:    tree.NotDeferrable,
+	}
+	if readOnly {
+		modes.ReadWriteMode = tree.ReadOnly
+	}
+	return modes
+}
+
+// chainedTxnModes returns the transaction modes that a transaction chained to
+// the current one should use.
+func (ex *connExecutor) chainedTxnModes() *tree.TransactionModes {
+	ex.state.mu.RLock()
+	defer ex.state.mu.RUnlock()
+	modes := makeChainedTxnModes(
+		ex.state.mu.isolationLevel, ex.state.mu.priority, ex.state.readOnly.Load(),
+	)
+	return &modes
+}
+
 func (ex *connExecutor) txnPriorityWithSessionDefault(mode tree.UserPriority) roachpb.UserPriority {
 	if mode == tree.UnspecifiedUserPriority {
 		mode = tree.UserPriority(ex.sessionData().DefaultTxnPriority)
@@ -172,18 +172,26 @@ func (ex *connExecutor) execStmt(
 		startIdleInTransactionSessionTimeout := func() {
 			switch ast.(type) {
 			case *tree.CommitTransaction, *tree.RollbackTransaction:
-				// Do nothing, the transaction is completed, we do not want to start
-				// an idle timer.
-			default:
-				ex.mu.IdleInTransactionSessionTimeout = timeout{time.AfterFunc(
-					ex.sessionData().IdleInTransactionSessionTimeout,
-					ex.CancelSession,
-				)}
+				if !isChainedTxnEnd(ast) {
+					// Do nothing, the transaction is completed, we do not want to start
+					// an idle timer.
+					return
+				}
 			}
+			ex.mu.IdleInTransactionSessionTimeout = timeout{time.AfterFunc(
+				ex.sessionData().IdleInTransactionSessionTimeout,
+				ex.CancelSession,
+			)}
 		}
 		switch ex.machine.CurState().(type) {
 		case stateAborted, stateCommitWait:
 			startIdleInTransactionSessionTimeout()
+		case stateNoTxn:
+			// COMMIT AND CHAIN and ROLLBACK AND CHAIN start a new explicit
+			// transaction from the NoTxn state.
+			if ex.extraTxnState.chainedTxnModes != nil {
+				startIdleInTransactionSessionTimeout()
+			}
 		case stateOpen:
 			// Only start timeout if the statement is executed in an
 			// explicit transaction.
@@ -860,11 +868,36 @@ func (ex *connExecutor) execStmtInOpenState(
 
 	case *tree.CommitTransaction:
 		// CommitTransaction is executed fully here; there's no plan for it.
+		if s.Chain {
+			if os.ImplicitTxn.Get() {
+				return makeErrEvent(errChainOutsideTxnBlock(s))
+			}
+			// Capture the characteristics of the current transaction before it is
+			// committed. The chained transaction is started once the statement is
+			// executed again in the NoTxn state.
+			ex.extraTxnState.chainedTxnModes = ex.chainedTxnModes()
+			ev, payload := ex.commitSQLTransaction(ctx, ast, ex.commitSQLTransactionInternal)
+			if payload != nil {
+				return ev, payload, nil
+			}
+			return eventTxnFinishCommittedAndChain{}, nil, nil
+		}
 		ev, payload := ex.commitSQLTransaction(ctx, ast, ex.commitSQLTransactionInternal)
 		return ev, payload, nil
 
 	case *tree.RollbackTransaction:
 		// RollbackTransaction is executed fully here; there's no plan for it.
+		if s.Chain {
+			if os.ImplicitTxn.Get() {
+				return makeErrEvent(errChainOutsideTxnBlock(s))
+			}
+			ex.extraTxnState.chainedTxnModes = ex.chainedTxnModes()
+			ev, payload := ex.rollbackSQLTransaction(ctx, s)
+			if payload != nil {
+				return ev, payload, nil
+			}
+			return eventTxnFinishAbortedAndChain{}, nil, nil
+		}
 		ev, payload := ex.rollbackSQLTransaction(ctx, s)
 		return ev, payload, nil
 
@@ -2134,6 +2167,7 @@ func populateQueryLevelStats(
 			cfg.TestingKnobs.DeterministicExplain,
 			p,
 		)
+		ih.notifyMisestimates(cfg)
 	}
 }
 
@@ -2574,8 +2608,29 @@ func (ex *connExecutor) execStmtInNoTxnState(
 			)
 	case *tree.ShowCommitTimestamp:
 		return ex.execShowCommitTimestampInNoTxnState(ctx, s, res)
-	case *tree.CommitTransaction, *tree.ReleaseSavepoint,
-		*tree.RollbackTransaction, *tree.SetTransaction, *tree.Savepoint:
+	case *tree.CommitTransaction, *tree.RollbackTransaction:
+		if modes := ex.extraTxnState.chainedTxnModes; modes != nil {
+			// The previous transaction was finished by this COMMIT AND CHAIN or
+			// ROLLBACK AND CHAIN statement, which was already logged when it was
+			// executed in the Open state. Start the chained transaction.
+			shouldLogToExecAndAudit = false
+			return ex.beginChainedTransaction(ctx, ast, *modes)
+		}
+		if isChainedTxnEnd(ast) {
+			return ex.makeErrEvent(errChainOutsideTxnBlock(ast), ast)
+		}
+		if ex.sessionData().AutoCommitBeforeDDL {
+			if err := ex.planner.SendClientNotice(
+				ctx,
+				pgerror.WithSeverity(errNoTransactionInProgress, "WARNING"),
+			); err != nil {
+				return ex.makeErrEvent(err, ast)
+			}
+			return nil, nil
+		}
+		return ex.makeErrEvent(errNoTransactionInProgress, ast)
+	case *tree.ReleaseSavepoint,
+		*tree.SetTransaction, *tree.Savepoint:
 		if ex.sessionData().AutoCommitBeforeDDL {
 			// If autocommit_before_ddl is set, we allow these statements to be
 			// executed, and send a warning rather than an error.
@@ -2613,6 +2668,50 @@ func (ex *connExecutor) execStmtInNoTxnState(
 	}
 }
 
+// beginChainedTransaction starts the explicit transaction that follows a
+// COMMIT AND CHAIN or ROLLBACK AND CHAIN statement. The new transaction uses
+// the given modes, which describe the transaction that was just finished.
+func (ex *connExecutor) beginChainedTransaction(
+	ctx context.Context, ast tree.Statement, modes tree.TransactionModes,
+) (fsm.Event, fsm.EventPayload) {
+	s := &tree.BeginTransaction{Modes: modes}
+	mode, sqlTs, historicalTs, err := ex.beginTransactionTimestampsAndReadMode(ctx, s)
+	if err != nil {
+		return ex.makeErrEvent(err, ast)
+	}
+	ex.sessionDataStack.PushTopClone()
+	return eventStartExplicitTxn,
+		makeEventTxnStartPayload(
+			ex.txnPriorityWithSessionDefault(modes.UserPriority),
+			mode,
+			sqlTs,
+			historicalTs,
+			ex.transitionCtx,
+			ex.QualityOfService(),
+			ex.txnIsolationLevelToKV(ctx, modes.Isolation),
+			ex.omitInRangefeeds(),
+		)
+}
+
+// isChainedTxnEnd returns true if the statement is COMMIT AND CHAIN or
+// ROLLBACK AND CHAIN.
+func isChainedTxnEnd(ast tree.Statement) bool {
+	switch s := ast.(type) {
+	case *tree.CommitTransaction:
+		return s.Chain
+	case *tree.RollbackTransaction:
+		return s.Chain
+	}
+	return false
+}
+
+// errChainOutsideTxnBlock returns the error for a COMMIT AND CHAIN or ROLLBACK
+// AND CHAIN statement that is executed outside of an explicit transaction.
+func errChainOutsideTxnBlock(ast tree.Statement) error {
+	return pgerror.Newf(pgcode.NoActiveSQLTransaction,
+		"%s AND CHAIN can only be used in transaction blocks", ast.StatementTag())
+}
+
 // beginImplicitTxn starts an implicit transaction. The fsm.Event that is
 // returned does not cause the state machine to advance, so the same command
 // will be executed again, but with an implicit transaction.
@@ -2674,6 +2773,16 @@ func (ex *connExecutor) execStmtInAbortedState(
 			// Note: Postgres replies to COMMIT of failed txn with "ROLLBACK" too.
 			res.ResetStmtType((*tree.RollbackTransaction)(nil))
 		}
+		if isChainedTxnEnd(s) {
+			// Like Postgres, COMMIT AND CHAIN of a failed txn rolls it back and
+			// starts a new transaction with the same characteristics.
+			ex.extraTxnState.chainedTxnModes = ex.chainedTxnModes()
+			ev, payload := ex.rollbackSQLTransaction(ctx, s)
+			if payload != nil {
+				return ev, payload
+			}
+			return eventTxnFinishAbortedAndChain{}, nil
+		}
 		return ex.rollbackSQLTransaction(ctx, s)
 
 	case *tree.RollbackToSavepoint:
@@ -2726,7 +2835,10 @@ func (ex *connExecutor) execStmtInCommitWaitState(
 		// Reply to a rollback with the COMMIT tag, by analogy to what we do when we
 		// get a COMMIT in state Aborted.
 		res.ResetStmtType((*tree.CommitTransaction)(nil))
-		return ex.commitSQLTransaction(
+		if isChainedTxnEnd(s) {
+			ex.extraTxnState.chainedTxnModes = ex.chainedTxnModes()
+		}
+		ev, payload := ex.commitSQLTransaction(
 			ctx,
 			ast,
 			func(ctx context.Context) error {
@@ -2734,6 +2846,10 @@ func (ex *connExecutor) execStmtInCommitWaitState(
 				return nil
 			},
 		)
+		if payload == nil && isChainedTxnEnd(s) {
+			return eventTxnFinishCommittedAndChain{}, nil
+		}
+		return ev, payload
 	}
 	return eventNonRetriableErr{IsCommit: fsm.False},
 		eventNonRetriableErrPayload{
@@ -162,6 +162,14 @@ type eventTxnFinishAborted struct{}
 type eventTxnFinishCommittedPLpgSQL struct{}
 type eventTxnFinishAbortedPLpgSQL struct{}
 
+// eventTxnFinishCommittedAndChain and eventTxnFinishAbortedAndChain are
+// generated by COMMIT AND CHAIN and ROLLBACK AND CHAIN statements in an
+// explicit transaction. The current transaction is finished, but the statement
+// buffer is not advanced, since the same statement is executed again in the
+// NoTxn state in order to start the new (chained) transaction.
+type eventTxnFinishCommittedAndChain struct{}
+type eventTxnFinishAbortedAndChain struct{}
+
 // eventSavepointRollback is generated when we want to move from Aborted to Open
 // through a ROLLBACK TO SAVEPOINT <not cockroach_restart>. Note that it is not
 // generated when such a savepoint is rolled back to from the Open state. In
@@ -237,6 +245,8 @@ func (eventTxnFinishCommitted) Event()                  {}
 func (eventTxnFinishAborted) Event()                    {}
 func (eventTxnFinishCommittedPLpgSQL) Event()           {}
 func (eventTxnFinishAbortedPLpgSQL) Event()             {}
+func (eventTxnFinishCommittedAndChain) Event()          {}
+func (eventTxnFinishAbortedAndChain) Event()            {}
 func (eventSavepointRollback) Event()                   {}
 func (eventNonRetriableErr) Event()                     {}
 func (eventRetriableErr) Event()                        {}
@@ -428,6 +438,23 @@ var TxnStateTransitions = fsm.Compile(fsm.Pattern{
 			Next:        stateCommitWait{},
 			Action:      moveToCommitWaitAfterInternalCommit,
 		},
+		// Handle COMMIT AND CHAIN and ROLLBACK AND CHAIN. Use stayInPlace so that
+		// the statement is executed again in the NoTxn state, where it starts the
+		// chained transaction.
+		eventTxnFinishCommittedAndChain{}: {
+			Description: "COMMIT AND CHAIN",
+			Next:        stateNoTxn{},
+			Action: func(args fsm.Args) error {
+				return args.Extended.(*txnState).finishTxn(txnCommit, stayInPlace)
+			},
+		},
+		eventTxnFinishAbortedAndChain{}: {
+			Description: "ROLLBACK AND CHAIN",
+			Next:        stateNoTxn{},
+			Action: func(args fsm.Args) error {
+				return args.Extended.(*txnState).finishTxn(txnRollback, stayInPlace)
+			},
+		},
 	},
 
 	// Aborted
@@ -446,6 +473,15 @@ var TxnStateTransitions = fsm.Compile(fsm.Pattern{
 				return ts.finishTxn(txnRollback, advanceOne)
 			},
 		},
+		eventTxnFinishAbortedAndChain{}: {
+			Description: "ROLLBACK AND CHAIN",
+			Next:        stateNoTxn{},
+			Action: func(args fsm.Args) error {
+				ts := args.Extended.(*txnState)
+				ts.txnAbortCount.Inc(1)
+				return ts.finishTxn(txnRollback, stayInPlace)
+			},
+		},
 		// Any statement.
 		eventNonRetriableErr{IsCommit: fsm.False}: {
 			// This event doesn't change state, but it returns a skipBatch code.
@@ -517,6 +553,13 @@ var TxnStateTransitions = fsm.Compile(fsm.Pattern{
 				return args.Extended.(*txnState).finishTxn(noEvent, advanceOne)
 			},
 		},
+		eventTxnFinishCommittedAndChain{}: {
+			Description: "COMMIT AND CHAIN",
+			Next:        stateNoTxn{},
+			Action: func(args fsm.Args) error {
+				return args.Extended.(*txnState).finishTxn(noEvent, stayInPlace)
+			},
+		},
 		eventNonRetriableErr{IsCommit: fsm.Any}: {
 			// This event doesn't change state, but it returns a skipBatch code.
 			//
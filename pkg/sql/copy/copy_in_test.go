@@ -11,7 +11,9 @@
 package copy
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"math"
@@ -29,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/randgen"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltestutils"
@@ -42,6 +45,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
@@ -320,8 +325,16 @@ func TestCopyFromBinary(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if _, err := conn.Exec(ctx, `
-		CREATE TABLE t (
+	// The binary format is decoded straight into columnar batches when the
+	// vectorized engine is enabled, so test both paths.
+	for _, vectorize := range []string{"on", "off"} {
+		t.Run("vectorize="+vectorize, func(t *testing.T) {
+			if _, err := conn.Exec(ctx, "SET vectorize = "+vectorize); err != nil {
+				t.Fatal(err)
+			}
+			table := "t_" + vectorize
+			if _, err := conn.Exec(ctx, fmt.Sprintf(`
+		CREATE TABLE %s (
 			id INT8 PRIMARY KEY,
 			u INT, -- NULL test
 			o BOOL,
@@ -332,42 +345,219 @@ func TestCopyFromBinary(t *testing.T) {
 			s STRING,
 			b BYTES
 		);
-	`); err != nil {
-		t.Fatal(err)
+	`, table)); err != nil {
+				t.Fatal(err)
+			}
+
+			input := [][]interface{}{{
+				1,
+				nil,
+				true,
+				int16(1),
+				int32(1),
+				int64(1),
+				float64(1),
+				"s",
+				"b",
+			}, {
+				2,
+				int64(2),
+				false,
+				int16(-2),
+				int32(-2),
+				int64(-2),
+				float64(2.5),
+				"",
+				nil,
+			}}
+			if _, err = conn.CopyFrom(
+				ctx,
+				pgx.Identifier{table},
+				[]string{"id", "u", "o", "i2", "i4", "i8", "f", "s", "b"},
+				pgx.CopyFromRows(input),
+			); err != nil {
+				t.Fatal(err)
+			}
+
+			expect := make([][]string, len(input))
+			for r := range input {
+				expect[r] = make([]string, len(input[r]))
+				for i, v := range input[r] {
+					if v == nil {
+						expect[r][i] = "NULL"
+					} else {
+						expect[r][i] = fmt.Sprintf("%v", v)
+					}
+				}
+			}
+			sqlDB.CheckQueryResults(t, fmt.Sprintf("SELECT * FROM %s ORDER BY id", table), expect)
+		})
 	}
+}
 
-	input := [][]interface{}{{
-		1,
-		nil,
-		true,
-		int16(1),
-		int32(1),
-		int64(1),
-		float64(1),
-		"s",
-		"b",
-	}}
-	if _, err = conn.CopyFrom(
-		ctx,
-		pgx.Identifier{"t"},
-		[]string{"id", "u", "o", "i2", "i4", "i8", "f", "s", "b"},
-		pgx.CopyFromRows(input),
-	); err != nil {
-		t.Fatal(err)
+// chunkedReader returns the data of r at most n bytes at a time. pgconn sends
+// the result of every read as its own CopyData message.
+type chunkedReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *chunkedReader) Read(b []byte) (int, error) {
+	if len(b) > c.n {
+		b = b[:c.n]
 	}
+	return c.r.Read(b)
+}
 
-	expect := func() [][]string {
-		row := make([]string, len(input[0]))
-		for i, v := range input[0] {
-			if v == nil {
-				row[i] = "NULL"
-			} else {
-				row[i] = fmt.Sprintf("%v", v)
+// TestCopyFromSplitMessages checks that rows which are split across CopyData
+// messages are inserted exactly once in every format, both by the vectorized
+// and by the row-at-a-time COPY, when batches are flushed mid-stream.
+func TestCopyFromSplitMessages(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	defer sql.SetCopyFromBatchSize(sql.SetCopyFromBatchSize(3))
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	pgURL, cleanup := s.PGUrl(
+		t, serverutils.CertsDirPrefix("copytest"), serverutils.User(username.RootUser),
+	)
+	defer cleanup()
+	conn, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+
+	_, err = conn.Exec(ctx, "CREATE TABLE t (i INT8 PRIMARY KEY, s STRING)")
+	require.NoError(t, err)
+	// Only the fast path can be vectorized, override the metamorphic value.
+	_, err = conn.Exec(ctx, "SET copy_fast_path_enabled = true")
+	require.NoError(t, err)
+
+	const numRows = 10
+	var text, csv strings.Builder
+	var bin bytes.Buffer
+	csv.WriteString("i,s\n")
+	bin.WriteString("PGCOPY\n\377\r\n\000")
+	_ = binary.Write(&bin, binary.BigEndian, [2]int32{0, 0})
+	for i := 0; i < numRows; i++ {
+		fmt.Fprintf(&text, "%d\tv%d\n", i, i)
+		// The quoted newline makes every CSV record span two lines.
+		fmt.Fprintf(&csv, "%d,\"v\n%d\"\n", i, i)
+		str := fmt.Sprintf("v%d", i)
+		_ = binary.Write(&bin, binary.BigEndian, int16(2))
+		_ = binary.Write(&bin, binary.BigEndian, int32(8))
+		_ = binary.Write(&bin, binary.BigEndian, int64(i))
+		_ = binary.Write(&bin, binary.BigEndian, int32(len(str)))
+		bin.WriteString(str)
+	}
+	_ = binary.Write(&bin, binary.BigEndian, int16(-1))
+
+	for _, tc := range []struct {
+		format string
+		stmt   string
+		data   string
+		// sep is the part of the string values between the "v" and the row
+		// number.
+		sep string
+	}{
+		{format: "text", stmt: "COPY t FROM STDIN", data: text.String()},
+		{format: "csv", stmt: "COPY t FROM STDIN CSV HEADER", data: csv.String(), sep: "\n"},
+		{format: "binary", stmt: "COPY t FROM STDIN BINARY", data: bin.String()},
+	} {
+		for _, vectorize := range []string{"on", "off"} {
+			for _, chunkSize := range []int{1, 7} {
+				t.Run(fmt.Sprintf("%s/vectorize=%s/chunk=%d", tc.format, vectorize, chunkSize), func(t *testing.T) {
+					_, err := conn.Exec(ctx, "SET vectorize = "+vectorize)
+					require.NoError(t, err)
+					res, err := conn.PgConn().CopyFrom(
+						ctx, &chunkedReader{r: strings.NewReader(tc.data), n: chunkSize}, tc.stmt,
+					)
+					require.NoError(t, err)
+					require.Equal(t, int64(numRows), res.RowsAffected())
+					want := make([][]string, numRows)
+					for i := range want {
+						want[i] = []string{strconv.Itoa(i), fmt.Sprintf("v%s%d", tc.sep, i)}
+					}
+					sqlDB.CheckQueryResults(t, "SELECT i, s FROM t ORDER BY i", want)
+					sqlDB.Exec(t, "TRUNCATE t")
+				})
 			}
 		}
-		return [][]string{row}
-	}()
-	sqlDB.CheckQueryResults(t, "SELECT * FROM t ORDER BY id", expect)
+	}
+
+	// -1 is the only negative field size allowed, and stands for NULL.
+	for _, vectorize := range []string{"on", "off"} {
+		_, err := conn.Exec(ctx, "SET vectorize = "+vectorize)
+		require.NoError(t, err)
+		var bad bytes.Buffer
+		bad.Write(bin.Bytes()[:19])
+		_ = binary.Write(&bad, binary.BigEndian, int16(2))
+		_ = binary.Write(&bad, binary.BigEndian, int32(-2))
+		_, err = conn.PgConn().CopyFrom(ctx, &bad, "COPY t FROM STDIN BINARY")
+		var pgErr *pgconn.PgError
+		require.True(t, errors.As(err, &pgErr), "%v", err)
+		require.Equal(t, pgcode.ProtocolViolation.String(), pgErr.Code)
+		require.Contains(t, pgErr.Message, "invalid field size -2")
+	}
+}
+
+// TestCopyFromBinaryTypeModifiers checks that values in the binary format are
+// truncated and rounded to the width and scale of their columns the same way
+// values in the text formats are.
+func TestCopyFromBinaryTypeModifiers(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	pgURL, cleanup := s.PGUrl(
+		t, serverutils.CertsDirPrefix("copytest"), serverutils.User(username.RootUser),
+	)
+	defer cleanup()
+	conn, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+
+	_, err = conn.Exec(ctx, "CREATE TABLE t (i INT8 PRIMARY KEY, v VARCHAR(3), c CHAR(3), d DECIMAL(5, 2))")
+	require.NoError(t, err)
+	_, err = conn.Exec(ctx, "SET copy_fast_path_enabled = true")
+	require.NoError(t, err)
+
+	writeField := func(b *bytes.Buffer, data []byte) {
+		_ = binary.Write(b, binary.BigEndian, int32(len(data)))
+		b.Write(data)
+	}
+	var bin bytes.Buffer
+	bin.WriteString("PGCOPY\n\377\r\n\000")
+	_ = binary.Write(&bin, binary.BigEndian, [2]int32{0, 0})
+	_ = binary.Write(&bin, binary.BigEndian, int16(4))
+	writeField(&bin, []byte{0, 0, 0, 0, 0, 0, 0, 1})
+	writeField(&bin, []byte("abcdef"))
+	writeField(&bin, []byte("ghijkl"))
+	// 1.2345 is encoded as the base 10000 digits 1 and 2345, with a weight of
+	// 0 and a display scale of 4.
+	writeField(&bin, []byte{0, 2, 0, 0, 0, 0, 0, 4, 0, 1, 0x09, 0x29})
+	_ = binary.Write(&bin, binary.BigEndian, int16(-1))
+
+	for _, vectorize := range []string{"on", "off"} {
+		t.Run("vectorize="+vectorize, func(t *testing.T) {
+			_, err := conn.Exec(ctx, "SET vectorize = "+vectorize)
+			require.NoError(t, err)
+			_, err = conn.PgConn().CopyFrom(ctx, bytes.NewReader(bin.Bytes()), "COPY t FROM STDIN BINARY")
+			require.NoError(t, err)
+			sqlDB.CheckQueryResults(t, "SELECT i, v, c, d FROM t", [][]string{{"1", "abc", "ghi", "1.23"}})
+			sqlDB.Exec(t, "TRUNCATE t")
+		})
+	}
 }
 
 func TestCopyFromError(t *testing.T) {
@@ -846,3 +846,57 @@ func BenchmarkCopyCSVEndToEnd(b *testing.B) {
 		b.StartTimer()
 	}
 }
+
+// BenchmarkCopyBinaryEndToEnd is the BINARY format counterpart of
+// BenchmarkCopyCSVEndToEnd, with rows of the same shape, so that the two can be
+// compared.
+func BenchmarkCopyBinaryEndToEnd(b *testing.B) {
+	defer leaktest.AfterTest(b)()
+	defer log.Scope(b).Close(b)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(b, base.TestServerArgs{
+		DefaultTestTenant: base.TestIsForStuffThatShouldWorkWithSecondaryTenantsButDoesntYet(83461),
+	})
+	defer s.Stopper().Stop(ctx)
+
+	pgURL, cleanup, err := sqlutils.PGUrlE(
+		s.AdvSQLAddr(),
+		"BenchmarkCopyBinaryEndToEnd", /* prefix */
+		url.User(username.RootUser),
+	)
+	require.NoError(b, err)
+	s.Stopper().AddCloser(stop.CloserFn(cleanup))
+
+	_, err = db.Exec("CREATE TABLE t (i INT PRIMARY KEY, s STRING)")
+	require.NoError(b, err)
+
+	conn, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(b, err)
+
+	rng, _ := randutil.NewTestRand()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		// Create an input of 1_000_000 rows.
+		rows := make([][]interface{}, 1_000_000)
+		for j := range rows {
+			rows[j] = []interface{}{int64(j), randutil.RandString(rng, rng.Intn(50), "abc123\n")}
+		}
+		b.StartTimer()
+
+		// Run the COPY. pgx always uses the BINARY format.
+		_, err = conn.CopyFrom(ctx, pgx.Identifier{"t"}, []string{"i", "s"}, pgx.CopyFromRows(rows))
+		require.NoError(b, err)
+
+		// Verify that the data was inserted.
+		b.StopTimer()
+		var count int
+		err = db.QueryRow("SELECT count(*) FROM t").Scan(&count)
+		require.NoError(b, err)
+		require.Equal(b, 1_000_000, count)
+		_, err = db.Exec("TRUNCATE t")
+		require.NoError(b, err)
+		b.StartTimer()
+	}
+}
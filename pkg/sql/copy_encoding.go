@@ -0,0 +1,228 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/transform"
+)
+
+// copyEncodings maps the names of the encodings which can be used with the
+// ENCODING option of COPY, as cleaned by builtins.CleanEncodingName, to their
+// implementation. A nil encoding stands for UTF8, which needs no conversion.
+//
+// See pg_encname_tbl in postgres' sources in common/encnames.c.
+var copyEncodings = map[string]struct {
+	name string
+	enc  encoding.Encoding
+}{
+	"utf8":        {"UTF8", nil},
+	"unicode":     {"UTF8", nil},
+	"big5":        {"BIG5", traditionalchinese.Big5},
+	"eucjp":       {"EUC_JP", japanese.EUCJP},
+	"euckr":       {"EUC_KR", korean.EUCKR},
+	"gb18030":     {"GB18030", simplifiedchinese.GB18030},
+	"gbk":         {"GBK", simplifiedchinese.GBK},
+	"iso88591":    {"LATIN1", charmap.ISO8859_1},
+	"iso88592":    {"LATIN2", charmap.ISO8859_2},
+	"iso88593":    {"LATIN3", charmap.ISO8859_3},
+	"iso88594":    {"LATIN4", charmap.ISO8859_4},
+	"iso88595":    {"ISO_8859_5", charmap.ISO8859_5},
+	"iso88596":    {"ISO_8859_6", charmap.ISO8859_6},
+	"iso88597":    {"ISO_8859_7", charmap.ISO8859_7},
+	"iso88598":    {"ISO_8859_8", charmap.ISO8859_8},
+	"iso88599":    {"LATIN5", charmap.ISO8859_9},
+	"iso885910":   {"LATIN6", charmap.ISO8859_10},
+	"iso885913":   {"LATIN7", charmap.ISO8859_13},
+	"iso885914":   {"LATIN8", charmap.ISO8859_14},
+	"iso885915":   {"LATIN9", charmap.ISO8859_15},
+	"iso885916":   {"LATIN10", charmap.ISO8859_16},
+	"koi8":        {"KOI8R", charmap.KOI8R},
+	"koi8r":       {"KOI8R", charmap.KOI8R},
+	"koi8u":       {"KOI8U", charmap.KOI8U},
+	"latin1":      {"LATIN1", charmap.ISO8859_1},
+	"latin2":      {"LATIN2", charmap.ISO8859_2},
+	"latin3":      {"LATIN3", charmap.ISO8859_3},
+	"latin4":      {"LATIN4", charmap.ISO8859_4},
+	"latin5":      {"LATIN5", charmap.ISO8859_9},
+	"latin6":      {"LATIN6", charmap.ISO8859_10},
+	"latin7":      {"LATIN7", charmap.ISO8859_13},
+	"latin8":      {"LATIN8", charmap.ISO8859_14},
+	"latin9":      {"LATIN9", charmap.ISO8859_15},
+	"latin10":     {"LATIN10", charmap.ISO8859_16},
+	"mskanji":     {"SJIS", japanese.ShiftJIS},
+	"shiftjis":    {"SJIS", japanese.ShiftJIS},
+	"sjis":        {"SJIS", japanese.ShiftJIS},
+	"alt":         {"WIN866", charmap.CodePage866},
+	"win":         {"WIN1251", charmap.Windows1251},
+	"win866":      {"WIN866", charmap.CodePage866},
+	"win874":      {"WIN874", charmap.Windows874},
+	"win1250":     {"WIN1250", charmap.Windows1250},
+	"win1251":     {"WIN1251", charmap.Windows1251},
+	"win1252":     {"WIN1252", charmap.Windows1252},
+	"win1253":     {"WIN1253", charmap.Windows1253},
+	"win1254":     {"WIN1254", charmap.Windows1254},
+	"win1255":     {"WIN1255", charmap.Windows1255},
+	"win1256":     {"WIN1256", charmap.Windows1256},
+	"win1257":     {"WIN1257", charmap.Windows1257},
+	"win1258":     {"WIN1258", charmap.Windows1258},
+	"windows866":  {"WIN866", charmap.CodePage866},
+	"windows874":  {"WIN874", charmap.Windows874},
+	"windows1250": {"WIN1250", charmap.Windows1250},
+	"windows1251": {"WIN1251", charmap.Windows1251},
+	"windows1252": {"WIN1252", charmap.Windows1252},
+	"windows1253": {"WIN1253", charmap.Windows1253},
+	"windows1254": {"WIN1254", charmap.Windows1254},
+	"windows1255": {"WIN1255", charmap.Windows1255},
+	"windows1256": {"WIN1256", charmap.Windows1256},
+	"windows1257": {"WIN1257", charmap.Windows1257},
+	"windows1258": {"WIN1258", charmap.Windows1258},
+}
+
+// copyEncoding is the client encoding of the data of a COPY statement.
+type copyEncoding struct {
+	// name is the canonical Postgres name of the encoding.
+	name string
+	// enc is nil for UTF8.
+	enc encoding.Encoding
+}
+
+// lookupCopyEncoding returns the encoding with the given name.
+func lookupCopyEncoding(name string) (copyEncoding, error) {
+	e, ok := copyEncodings[builtins.CleanEncodingName(name)]
+	if !ok {
+		return copyEncoding{}, pgerror.Newf(pgcode.InvalidParameterValue,
+			"argument to option \"encoding\" must be a valid encoding name")
+	}
+	return copyEncoding{name: e.name, enc: e.enc}, nil
+}
+
+// isUTF8 returns whether data in this encoding can be used as is.
+func (e copyEncoding) isUTF8() bool {
+	return e.enc == nil
+}
+
+// copyDecoder converts the data of COPY FROM from the client encoding into
+// UTF-8. Since multi-byte characters can be split across CopyData messages,
+// the incomplete trailing bytes of a message are held back until the next one.
+//
+// The x/text decoders replace invalid input with U+FFFD instead of failing, so
+// any U+FFFD in the output is rejected unless the client encoding can
+// represent it and the input really encoded it.
+type copyDecoder struct {
+	enc     copyEncoding
+	t       transform.Transformer
+	pending []byte
+	scratch [4096]byte
+	// encodesReplacementChar is set if U+FFFD has a representation in the
+	// client encoding, which is only the case for GB18030.
+	encodesReplacementChar bool
+}
+
+var replacementChar = []byte(string(utf8.RuneError))
+
+func newCopyDecoder(enc copyEncoding) *copyDecoder {
+	_, err := enc.enc.NewEncoder().Bytes(replacementChar)
+	return &copyDecoder{enc: enc, t: enc.enc.NewDecoder(), encodesReplacementChar: err == nil}
+}
+
+// checkReplacementChars returns an error if out, the conversion of src,
+// contains a U+FFFD which was not in src.
+func (d *copyDecoder) checkReplacementChars(out, src []byte) error {
+	if !bytes.Contains(out, replacementChar) {
+		return nil
+	}
+	if d.encodesReplacementChar {
+		// Converting back to the client encoding only gives back src if every
+		// U+FFFD came from its encoding in src.
+		if enc, err := d.enc.enc.NewEncoder().Bytes(out); err == nil && bytes.Equal(enc, src) {
+			return nil
+		}
+	}
+	return pgerror.Newf(pgcode.CharacterNotInRepertoire,
+		"invalid byte sequence for encoding %q", d.enc.name)
+}
+
+// decode appends the UTF-8 conversion of data to dst. If final is set, data is
+// the last chunk of input and must not end in an incomplete character.
+func (d *copyDecoder) decode(dst []byte, data string, final bool) ([]byte, error) {
+	src := append(d.pending, data...)
+	d.pending = d.pending[:0]
+	for {
+		nDst, nSrc, err := d.t.Transform(d.scratch[:], src, final)
+		if err := d.checkReplacementChars(d.scratch[:nDst], src[:nSrc]); err != nil {
+			return dst, err
+		}
+		dst = append(dst, d.scratch[:nDst]...)
+		src = src[nSrc:]
+		switch err {
+		case nil:
+			return dst, nil
+		case transform.ErrShortDst:
+			continue
+		case transform.ErrShortSrc:
+			if final {
+				return dst, pgerror.Newf(pgcode.CharacterNotInRepertoire,
+					"invalid byte sequence for encoding %q", d.enc.name)
+			}
+			d.pending = append(d.pending, src...)
+			return dst, nil
+		default:
+			return dst, pgerror.Wrapf(err, pgcode.CharacterNotInRepertoire,
+				"invalid byte sequence for encoding %q", d.enc.name)
+		}
+	}
+}
+
+// copyEncoder converts the UTF-8 data of COPY TO into the client encoding.
+type copyEncoder struct {
+	enc     copyEncoding
+	t       transform.Transformer
+	buf     []byte
+	scratch [4096]byte
+}
+
+func newCopyEncoder(enc copyEncoding) *copyEncoder {
+	return &copyEncoder{enc: enc, t: enc.enc.NewEncoder()}
+}
+
+// encode converts a complete row. The returned slice is only valid until the
+// next call to encode.
+func (e *copyEncoder) encode(src []byte) ([]byte, error) {
+	e.t.Reset()
+	e.buf = e.buf[:0]
+	for {
+		nDst, nSrc, err := e.t.Transform(e.scratch[:], src, true /* atEOF */)
+		e.buf = append(e.buf, e.scratch[:nDst]...)
+		src = src[nSrc:]
+		switch err {
+		case nil:
+			return e.buf, nil
+		case transform.ErrShortDst:
+			continue
+		default:
+			r, _ := utf8.DecodeRune(src)
+			return nil, pgerror.Newf(pgcode.UntranslatableCharacter,
+				"character %q has no representation in encoding %q", r, e.enc.name)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyEncoding(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, name := range []string{"utf8", "UTF-8", "Unicode"} {
+		enc, err := lookupCopyEncoding(name)
+		require.NoError(t, err)
+		require.True(t, enc.isUTF8())
+	}
+	_, err := lookupCopyEncoding("klingon")
+	require.EqualError(t, err, `argument to option "encoding" must be a valid encoding name`)
+
+	t.Run("decode", func(t *testing.T) {
+		latin1, err := lookupCopyEncoding("LATIN1")
+		require.NoError(t, err)
+		d := newCopyDecoder(latin1)
+		out, err := d.decode(nil, "caf\xe9\n", true /* final */)
+		require.NoError(t, err)
+		require.Equal(t, "café\n", string(out))
+
+		// A multi-byte character split across CopyData messages is held back
+		// until the rest of it arrives.
+		sjis, err := lookupCopyEncoding("SJIS")
+		require.NoError(t, err)
+		d = newCopyDecoder(sjis)
+		out, err = d.decode(nil, "a\x93", false /* final */)
+		require.NoError(t, err)
+		require.Equal(t, "a", string(out))
+		out, err = d.decode(out, "\xfa\n", true /* final */)
+		require.NoError(t, err)
+		require.Equal(t, "a日\n", string(out))
+	})
+
+	t.Run("decode invalid", func(t *testing.T) {
+		for _, tc := range []struct {
+			enc, data string
+		}{
+			// 0x81 is undefined in WIN1252.
+			{"WIN1252", "a\x81b\n"},
+			// 0x20 cannot follow the lead byte 0x81 in SJIS.
+			{"SJIS", "a\x81\x20b\n"},
+			{"GB18030", "a\xff\xffb\n"},
+		} {
+			enc, err := lookupCopyEncoding(tc.enc)
+			require.NoError(t, err)
+			_, err = newCopyDecoder(enc).decode(nil, tc.data, true /* final */)
+			require.EqualError(t, err, fmt.Sprintf("invalid byte sequence for encoding %q", enc.name))
+		}
+
+		// GB18030 has an encoding of U+FFFD itself, which is let through.
+		gb18030, err := lookupCopyEncoding("GB18030")
+		require.NoError(t, err)
+		out, err := newCopyDecoder(gb18030).decode(nil, "a\x84\x31\xa4\x37b\n", true /* final */)
+		require.NoError(t, err)
+		require.Equal(t, "a\ufffdb\n", string(out))
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		latin1, err := lookupCopyEncoding("iso-8859-1")
+		require.NoError(t, err)
+		e := newCopyEncoder(latin1)
+		out, err := e.encode([]byte("café\n"))
+		require.NoError(t, err)
+		require.Equal(t, "caf\xe9\n", string(out))
+
+		_, err = e.encode([]byte("a日\n"))
+		require.EqualError(t, err, `character '日' has no representation in encoding "LATIN1"`)
+	})
+}
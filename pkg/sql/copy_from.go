@@ -88,21 +88,40 @@ type copyMachineInterface interface {
 type copyOptions struct {
 	csvEscape       rune
 	csvExpectHeader bool
+	// csvHeaderMatch is set for HEADER MATCH, in which case the header line of
+	// COPY FROM must contain the names of the copied columns.
+	csvHeaderMatch bool
+	// csvForceQuoteAll, csvForceQuote, csvForceNotNull and csvForceNull hold
+	// the FORCE_QUOTE, FORCE_NOT_NULL and FORCE_NULL options; they are
+	// resolved against the copied columns by copyForceColumns.
+	csvForceQuoteAll bool
+	csvForceQuote    tree.NameList
+	csvForceNotNull  tree.NameList
+	csvForceNull     tree.NameList
 
 	delimiter byte
 	format    tree.CopyFormat
 	null      string
-	encoding  string
+	encoding  copyEncoding
+	freeze    bool
+	oids      bool
 }
 
 // TODO(#sql-sessions): copy all pre-condition checks from the PG code
 // https://github.com/postgres/postgres/blob/1de58df4fec7325d91f5a8345757314be7ac05da/src/backend/commands/copy.c#L405
 func processCopyOptions(
-	ctx context.Context, p *planner, opts tree.CopyOptions,
+	ctx context.Context, p *planner, opts tree.CopyOptions, isCopyFrom bool,
 ) (copyOptions, error) {
 	c := copyOptions{
-		format:          opts.CopyFormat,
-		csvExpectHeader: opts.Header,
+		format:           opts.CopyFormat,
+		csvExpectHeader:  opts.Header,
+		csvHeaderMatch:   opts.HeaderMatch,
+		csvForceQuoteAll: opts.ForceQuoteAll,
+		csvForceQuote:    opts.ForceQuote,
+		csvForceNotNull:  opts.ForceNotNull,
+		csvForceNull:     opts.ForceNull,
+		freeze:           opts.Freeze,
+		oids:             opts.Oids,
 	}
 
 	switch c.format {
@@ -117,6 +136,34 @@ func processCopyOptions(
 	if opts.Header && c.format != tree.CopyFormatCSV {
 		return c, pgerror.Newf(pgcode.FeatureNotSupported, "HEADER only supported with CSV format")
 	}
+	if opts.HeaderMatch && !isCopyFrom {
+		return c, pgerror.Newf(pgcode.FeatureNotSupported, `cannot use "match" with HEADER in COPY TO`)
+	}
+
+	if opts.ForceQuoteAll || opts.ForceQuote != nil {
+		if c.format != tree.CopyFormatCSV {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_QUOTE only supported with CSV format")
+		}
+		if isCopyFrom {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_QUOTE cannot be used with COPY FROM")
+		}
+	}
+	if opts.ForceNotNull != nil {
+		if c.format != tree.CopyFormatCSV {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NOT_NULL only supported with CSV format")
+		}
+		if !isCopyFrom {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NOT_NULL cannot be used with COPY TO")
+		}
+	}
+	if opts.ForceNull != nil {
+		if c.format != tree.CopyFormatCSV {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NULL only supported with CSV format")
+		}
+		if !isCopyFrom {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NULL cannot be used with COPY TO")
+		}
+	}
 
 	if opts.Quote != nil {
 		if c.format != tree.CopyFormatCSV {
@@ -191,10 +238,10 @@ func processCopyOptions(
 		if err != nil {
 			return c, err
 		}
-		if strings.ToUpper(e) != "UTF8" {
-			return c, pgerror.New(pgcode.FeatureNotSupported, "only 'utf8' ENCODING is supported")
+		c.encoding, err = lookupCopyEncoding(e)
+		if err != nil {
+			return c, err
 		}
-		c.encoding = "utf8"
 	}
 
 	return c, nil
@@ -227,8 +274,19 @@ type copyMachine struct {
 	// NULL. The spec says this is only supported for CSV, and also must specify
 	// which columns it applies to.
 	forceNotNull bool
-	csvInput     bytes.Buffer
-	csvReader    *csv.Reader
+	// csvForceNotNullCols and csvForceNullCols are the FORCE_NOT_NULL and
+	// FORCE_NULL options resolved against resultColumns. They are nil if the
+	// corresponding option was not specified.
+	csvForceNotNullCols []bool
+	csvForceNullCols    []bool
+	csvInput            bytes.Buffer
+	csvReader           *csv.Reader
+	// binaryFieldOffsets is scratch space used by readBinaryTupleVec to hold
+	// the start and end offsets of the fields of a tuple.
+	binaryFieldOffsets []int
+	// decoder converts the input into UTF-8 if a different ENCODING was
+	// specified, and is nil otherwise.
+	decoder *copyDecoder
 	// buf is used to parse input data into rows. It also accumulates a partial
 	// row between protocol messages.
 	buf []byte
@@ -291,7 +349,7 @@ func newCopyMachine(
 	implicitTxn bool,
 	execInsertPlan func(ctx context.Context, p *planner, res RestrictedCommandResult) error,
 ) (_ *copyMachine, retErr error) {
-	cOpts, err := processCopyOptions(ctx, p, n.Options)
+	cOpts, err := processCopyOptions(ctx, p, n.Options, true /* isCopyFrom */)
 	if err != nil {
 		return nil, err
 	}
@@ -345,6 +403,37 @@ func newCopyMachine(
 		typs[i] = col.GetType()
 	}
 	c.typs = typs
+	if c.oids {
+		return nil, pgerror.Newf(pgcode.UndefinedColumn,
+			"table %q does not have OIDs", tableDesc.GetName())
+	}
+	if c.freeze && !tableDesc.IsUncommittedVersion() {
+		// Like in Postgres, FREEZE is only allowed if the table was created or
+		// truncated in the current transaction, in which case no other
+		// transaction can observe the rows before they are committed. We have
+		// no hint bits to set, so there is nothing else to do.
+		//
+		// IsUncommittedVersion is true for any descriptor written by the
+		// current transaction, so FREEZE is also accepted after other schema
+		// changes to the table in the same transaction. That is more lenient
+		// than Postgres, but harmless since FREEZE changes nothing about how
+		// the rows are written.
+		return nil, pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot perform COPY FREEZE because the table was not created or truncated in the current transaction")
+	}
+	if c.csvForceNotNullCols, err = copyForceColumns(
+		"FORCE_NOT_NULL", c.csvForceNotNull, c.resultColumns,
+	); err != nil {
+		return nil, err
+	}
+	if c.csvForceNullCols, err = copyForceColumns(
+		"FORCE_NULL", c.csvForceNull, c.resultColumns,
+	); err != nil {
+		return nil, err
+	}
+	if !c.encoding.isUTF8() && c.format != tree.CopyFormatBinary {
+		c.decoder = newCopyDecoder(c.encoding)
+	}
 	// If there are no column specifiers and we expect non-visible columns
 	// to have field data then we have to populate the expectedHiddenColumnIdxs
 	// field with the columns indexes we expect to be hidden.
@@ -397,6 +486,32 @@ func newCopyMachine(
 	return c, nil
 }
 
+// copyForceColumns returns, for each of the copied columns, whether it is
+// named by the given FORCE option. It returns nil if the option was not
+// specified, and an error if it names a column which is not copied.
+func copyForceColumns(
+	opt string, names tree.NameList, cols colinfo.ResultColumns,
+) ([]bool, error) {
+	if names == nil {
+		return nil, nil
+	}
+	ret := make([]bool, len(cols))
+	for _, name := range names {
+		found := false
+		for i := range cols {
+			if cols[i].Name == string(name) {
+				ret[i] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, pgerror.Newf(pgcode.InvalidColumnReference,
+				"%s column %q not referenced by COPY", opt, string(name))
+		}
+	}
+	return ret, nil
+}
+
 var copyMaxCommandSizeFraction = settings.RegisterFloatSetting(
 	settings.ApplicationLevel,
 	"sql.copy.max_command_size_fraction",
@@ -408,10 +523,6 @@ var copyMaxCommandSizeFraction = settings.RegisterFloatSetting(
 )
 
 func (c *copyMachine) canSupportVectorized(table catalog.TableDescriptor) bool {
-	// TODO(cucaroach): support vectorized binary.
-	if c.format == tree.CopyFormatBinary {
-		return false
-	}
 	// Vectorized requires avoiding materializing the rows for the optimizer.
 	if !c.copyFastPath {
 		return false
@@ -670,7 +781,14 @@ func (c *copyMachine) processCopyData(ctx context.Context, data string, final bo
 			return err
 		}
 	}
-	c.buf = append(c.buf, data...)
+	if c.decoder != nil {
+		var err error
+		if c.buf, err = c.decoder.decode(c.buf, data, final); err != nil {
+			return err
+		}
+	} else {
+		c.buf = append(c.buf, data...)
+	}
 	var readFn func(ctx context.Context, final bool) (brk bool, err error)
 	switch c.format {
 	case tree.CopyFormatText:
@@ -683,12 +801,16 @@ func (c *copyMachine) processCopyData(ctx context.Context, data string, final bo
 		panic("unknown copy format")
 	}
 	for len(c.buf) > 0 {
+		prevLen := c.currentBatchSize()
 		brk, err := readFn(ctx, final)
 		if err != nil {
 			return err
 		}
 		var batchDone bool
-		if !brk && c.vectorized {
+		// A call that doesn't break adds exactly one row for the text and CSV
+		// formats, but not for the binary one, where reading the signature
+		// doesn't, so also check that the batch grew.
+		if !brk && c.vectorized && c.currentBatchSize() > prevLen {
 			if err := colexecerror.CatchVectorizedRuntimeError(func() {
 				batchDone = c.accHelper.AccountForSet(c.batch.Length() - 1)
 			}); err != nil {
@@ -834,6 +956,17 @@ func (c *copyMachine) readCSVData(ctx context.Context, final bool) (brk bool, er
 	// the header row in all circumstances. Do the same.
 	if c.csvExpectHeader {
 		c.csvExpectHeader = false
+		if c.csvHeaderMatch {
+			c.csvInput.Write(fullLine)
+			record, err := c.csvReader.Read()
+			if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+				return false, pgerror.Wrap(err, pgcode.BadCopyFileFormat,
+					"read CSV header")
+			}
+			if err := c.checkCSVHeader(record); err != nil {
+				return false, err
+			}
+		}
 		return c.readCSVData(ctx, final)
 	}
 
@@ -852,6 +985,36 @@ func (c *copyMachine) readCSVData(ctx context.Context, final bool) (brk bool, er
 	return false, err
 }
 
+// checkCSVHeader verifies that the header line of HEADER MATCH contains the
+// names of the copied columns, in order.
+func (c *copyMachine) checkCSVHeader(record []csv.Record) error {
+	if expected := len(c.resultColumns) + len(c.expectedHiddenColumnIdxs); expected != len(record) {
+		return pgerror.Newf(pgcode.BadCopyFileFormat,
+			"wrong number of fields in header line: got %d, expected %d", len(record), expected)
+	}
+	record = c.maybeIgnoreHiddenColumnsStr(record)
+	for i, s := range record {
+		if expected := c.resultColumns[i].Name; s.Val != expected {
+			return pgerror.Newf(pgcode.BadCopyFileFormat,
+				"column name mismatch in header line field %d: got %q, expected %q", i+1, s.Val, expected)
+		}
+	}
+	return nil
+}
+
+// isCSVNull returns whether the i-th field of a CSV record is NULL. Unquoted
+// fields matching the null string are NULL unless FORCE_NOT_NULL applies to
+// the column, and quoted ones are NULL only if FORCE_NULL does.
+func (c *copyMachine) isCSVNull(i int, s csv.Record) bool {
+	if s.Val != c.null {
+		return false
+	}
+	if s.Quoted {
+		return c.csvForceNullCols != nil && c.csvForceNullCols[i]
+	}
+	return c.csvForceNotNullCols == nil || !c.csvForceNotNullCols[i]
+}
+
 func (c *copyMachine) maybeIgnoreHiddenColumnsStr(in []csv.Record) []csv.Record {
 	if len(c.expectedHiddenColumnIdxs) == 0 {
 		return in
@@ -875,9 +1038,7 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 	if c.vectorized {
 		vh := c.valueHandlers
 		for i, s := range record {
-			// NB: When we implement FORCE_NULL, then quoted values also are allowed
-			// to be treated as NULL.
-			if !s.Quoted && s.Val == c.null {
+			if c.isCSVNull(i, s) {
 				vh[i].Null()
 				continue
 			}
@@ -889,9 +1050,7 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 	} else {
 		datums := c.scratchRow
 		for i, s := range record {
-			// NB: When we implement FORCE_NULL, then quoted values also are allowed
-			// to be treated as NULL.
-			if !s.Quoted && s.Val == c.null {
+			if c.isCSVNull(i, s) {
 				datums[i] = tree.DNull
 				continue
 			}
@@ -970,6 +1129,13 @@ func (c *copyMachine) readBinaryTuple(ctx context.Context) (bytesRead int, err e
 		return bytesRead, pgerror.Newf(pgcode.BadCopyFileFormat,
 			"unexpected field count: %d", fieldCount)
 	}
+	if int(fieldCount) != len(c.resultColumns) {
+		return bytesRead, pgerror.Newf(pgcode.BadCopyFileFormat,
+			"expected %d values, got %d", len(c.resultColumns), fieldCount)
+	}
+	if c.vectorized {
+		return c.readBinaryTupleVec(ctx, bytesRead)
+	}
 	datums := make(tree.Datums, fieldCount)
 	var byteCount int32
 	var byteCountBytes [4]byte
@@ -984,6 +1150,9 @@ func (c *copyMachine) readBinaryTuple(ctx context.Context) (bytesRead int, err e
 			datums[i] = tree.DNull
 			continue
 		}
+		if byteCount < 0 {
+			return bytesRead, invalidBinaryFieldSizeError(byteCount)
+		}
 		data := make([]byte, byteCount)
 		n = copy(data, c.buf[bytesRead:])
 		bytesRead += n
@@ -997,6 +1166,9 @@ func (c *copyMachine) readBinaryTuple(ctx context.Context) (bytesRead int, err e
 			pgwirebase.FormatBinary,
 			data,
 		)
+		if err == nil {
+			d, err = pgwirebase.AdjustDatumToType(c.resultColumns[i].Typ, d)
+		}
 		if err != nil {
 			return bytesRead, pgerror.Wrapf(err, pgcode.BadCopyFileFormat,
 				"decode datum as %s: %s", c.resultColumns[i].Typ.SQLString(), data)
@@ -1010,6 +1182,63 @@ func (c *copyMachine) readBinaryTuple(ctx context.Context) (bytesRead int, err e
 	return bytesRead, nil
 }
 
+// readBinaryTupleVec decodes the fields of a binary tuple, which start at
+// offset bytesRead of the buffer, directly into the current batch.
+func (c *copyMachine) readBinaryTupleVec(
+	ctx context.Context, bytesRead int,
+) (int, error) {
+	// All the fields must be present in the buffer before any of them is
+	// decoded, since a partial row cannot be removed from the batch if the
+	// rest of it only arrives with the next CopyData message.
+	offsets := c.binaryFieldOffsets[:0]
+	end := bytesRead
+	for range c.resultColumns {
+		if len(c.buf)-end < 4 {
+			return len(c.buf), io.ErrUnexpectedEOF
+		}
+		byteCount := int32(binary.BigEndian.Uint32(c.buf[end:]))
+		end += 4
+		offsets = append(offsets, end)
+		if byteCount == -1 {
+			offsets = append(offsets, -1)
+			continue
+		}
+		if byteCount < 0 {
+			return end, invalidBinaryFieldSizeError(byteCount)
+		}
+		if len(c.buf)-end < int(byteCount) {
+			return len(c.buf), io.ErrUnexpectedEOF
+		}
+		end += int(byteCount)
+		offsets = append(offsets, end)
+	}
+	c.binaryFieldOffsets = offsets
+	vh := c.valueHandlers
+	for i := range c.resultColumns {
+		start, fieldEnd := offsets[2*i], offsets[2*i+1]
+		if fieldEnd == -1 {
+			vh[i].Null()
+			continue
+		}
+		data := c.buf[start:fieldEnd]
+		if err := pgwirebase.DecodeBinaryDatumHandler(
+			ctx, c.parsingEvalCtx, c.resultColumns[i].Typ, data, vh[i],
+		); err != nil {
+			return end, pgerror.Wrapf(err, pgcode.BadCopyFileFormat,
+				"decode datum as %s: %s", c.resultColumns[i].Typ.SQLString(), data)
+		}
+	}
+	c.batch.SetLength(c.batch.Length() + 1)
+	return end, nil
+}
+
+// invalidBinaryFieldSizeError is returned for a field of a binary tuple whose
+// length is negative but not -1, which is the only negative length allowed
+// and stands for NULL.
+func invalidBinaryFieldSizeError(byteCount int32) error {
+	return pgerror.Newf(pgcode.ProtocolViolation, "invalid field size %d", byteCount)
+}
+
 // This is the standard 11-byte binary signature with the flags and
 // header 32-bit integers appended since we only support the zero value
 // of them.
@@ -17,6 +17,8 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -76,6 +78,9 @@ type csvCopyToTranslater struct {
 	b      bytes.Buffer
 	fmtCtx *tree.FmtCtx
 	w      *csv.Writer
+	// forceQuoteCols is the FORCE_QUOTE option resolved against the output
+	// columns. It is nil if the option was not specified.
+	forceQuoteCols []bool
 }
 
 func (c *csvCopyToTranslater) translateRow(
@@ -83,7 +88,7 @@ func (c *csvCopyToTranslater) translateRow(
 ) ([]byte, error) {
 	c.b.Reset()
 	c.fmtCtx.Buffer.Reset()
-	for _, d := range datums {
+	for i, d := range datums {
 		if d == tree.DNull {
 			if err := c.w.WriteField(bytes.NewBufferString(c.null)); err != nil {
 				return nil, err
@@ -97,6 +102,11 @@ func (c *csvCopyToTranslater) translateRow(
 			if err := c.w.ForceEmptyField(); err != nil {
 				return nil, err
 			}
+		} else if c.csvForceQuoteAll || (c.forceQuoteCols != nil && c.forceQuoteCols[i]) {
+			// Like in Postgres, FORCE_QUOTE never applies to NULL values.
+			if err := c.w.WriteQuotedField(bytes.NewBuffer(c.fmtCtx.Buffer.Bytes())); err != nil {
+				return nil, err
+			}
 		} else {
 			if err := c.w.WriteField(bytes.NewBuffer(c.fmtCtx.Buffer.Bytes())); err != nil {
 				return nil, err
@@ -131,13 +141,21 @@ func (c *csvCopyToTranslater) headerRow(rcs colinfo.ResultColumns) ([]byte, bool
 func runCopyTo(
 	ctx context.Context, p *planner, txn *kv.Txn, cmd CopyOut, res CopyOutResult,
 ) (numOutputRows int, retErr error) {
-	copyOptions, err := processCopyOptions(ctx, p, cmd.Stmt.Options)
+	copyOptions, err := processCopyOptions(ctx, p, cmd.Stmt.Options, false /* isCopyFrom */)
 	if err != nil {
 		return 0, err
 	}
+	if copyOptions.oids {
+		if cmd.Stmt.Statement != nil {
+			return 0, pgerror.Newf(pgcode.FeatureNotSupported, "COPY (query) WITH OIDS is not supported")
+		}
+		return 0, pgerror.Newf(pgcode.UndefinedColumn,
+			"table %q does not have OIDs", cmd.Stmt.Table.Object())
+	}
 
 	wireFormat := pgwirebase.FormatText
 	var t copyToTranslater
+	var csvTranslater *csvCopyToTranslater
 	switch cmd.Stmt.Options.CopyFormat {
 	case tree.CopyFormatBinary:
 		// wireFormat = pgwirebase.FormatBinary
@@ -146,7 +164,7 @@ func runCopyTo(
 			"binary format for COPY TO not implemented",
 		)
 	case tree.CopyFormatCSV:
-		csvTranslater := &csvCopyToTranslater{
+		csvTranslater = &csvCopyToTranslater{
 			copyOptions: copyOptions,
 			fmtCtx:      p.EvalContext().FmtCtx(tree.FmtPgwireText),
 		}
@@ -202,6 +220,18 @@ func runCopyTo(
 		}
 	}()
 
+	if csvTranslater != nil {
+		if csvTranslater.forceQuoteCols, err = copyForceColumns(
+			"FORCE_QUOTE", copyOptions.csvForceQuote, it.Types(),
+		); err != nil {
+			return 0, err
+		}
+	}
+	var encoder *copyEncoder
+	if !copyOptions.encoding.isUTF8() {
+		encoder = newCopyEncoder(copyOptions.encoding)
+	}
+
 	// Send the message describing the columns to the client.
 	if err := res.SendCopyOut(ctx, it.Types(), wireFormat); err != nil {
 		return 0, err
@@ -213,6 +243,11 @@ func runCopyTo(
 		if row, ok, err := t.headerRow(it.Types()); err != nil {
 			return err
 		} else if ok {
+			if encoder != nil {
+				if row, err = encoder.encode(row); err != nil {
+					return err
+				}
+			}
 			if err := res.SendCopyData(ctx, row, true /* isHeader */); err != nil {
 				return err
 			}
@@ -231,6 +266,11 @@ func runCopyTo(
 			if err != nil {
 				return err
 			}
+			if encoder != nil {
+				if row, err = encoder.encode(row); err != nil {
+					return err
+				}
+			}
 			if err := res.SendCopyData(ctx, row, false /* isHeader */); err != nil {
 				return err
 			}
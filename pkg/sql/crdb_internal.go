@@ -226,6 +226,8 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalPCRStreamsTableID:                  crdbInternalPCRStreamsTable,
 		catconstants.CrdbInternalPCRStreamSpansTableID:              crdbInternalPCRStreamSpansTable,
 		catconstants.CrdbInternalPCRStreamCheckpointsTableID:        crdbInternalPCRStreamCheckpointsTable,
+		catconstants.CrdbInternalNodePlanCacheTableID:               crdbInternalNodePlanCacheTable,
+		catconstants.CrdbInternalNodePlanBaselinesTableID:           crdbInternalNodePlanBaselinesTable,
 	},
 	validWithNoDatabaseContext: true,
 }
@@ -8627,6 +8629,106 @@ CREATE TABLE crdb_internal.node_memory_monitors (
 	},
 }
 
+var crdbInternalNodePlanCacheTable = virtualSchemaTable{
+	comment: `query plans cached on this node (RAM; local node only)`,
+	schema: `
+CREATE TABLE crdb_internal.node_plan_cache (
+  node_id      INT NOT NULL,
+  fingerprint  STRING NOT NULL,
+  query        STRING,
+  memory_bytes INT NOT NULL,
+  hits         INT NOT NULL,
+  last_used    TIMESTAMPTZ NOT NULL,
+  prepared     BOOL NOT NULL,
+  generic      BOOL NOT NULL
+);`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		hasRoleOption, shouldRedact, err := p.HasViewActivityOrViewActivityRedactedRole(ctx)
+		if err != nil {
+			return err
+		}
+		if !hasRoleOption {
+			return noViewActivityOrViewActivityRedactedRoleError(p.User())
+		}
+
+		nodeID, _ := p.execCfg.NodeInfo.NodeID.OptionalNodeID() // zero if not available
+		for _, e := range p.execCfg.QueryCache.Entries() {
+			fingerprint := e.StatementNoConstants
+			if fingerprint == "" {
+				stmt, err := parser.ParseOne(e.SQL)
+				if err != nil {
+					return err
+				}
+				fingerprint = formatStatementHideConstants(stmt.AST)
+			}
+			query := tree.DNull
+			if !shouldRedact {
+				query = tree.NewDString(e.SQL)
+			}
+			lastUsed, err := tree.MakeDTimestampTZ(e.LastUsed, time.Microsecond)
+			if err != nil {
+				return err
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(nodeID)),
+				tree.NewDString(fingerprint),
+				query,
+				tree.NewDInt(tree.DInt(e.MemoryEstimate)),
+				tree.NewDInt(tree.DInt(e.Hits)),
+				lastUsed,
+				tree.MakeDBool(tree.DBool(e.Prepared)),
+				tree.MakeDBool(tree.DBool(e.Generic)),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var crdbInternalNodePlanBaselinesTable = virtualSchemaTable{
+	comment: `plan gists pinned to statement fingerprints on this node (RAM; local node only)`,
+	schema: `
+CREATE TABLE crdb_internal.node_plan_baselines (
+  node_id     INT NOT NULL,
+  fingerprint STRING NOT NULL,
+  plan_gist   STRING NOT NULL,
+  enforced    BOOL NOT NULL,
+  created     TIMESTAMPTZ NOT NULL,
+  hits        INT NOT NULL,
+  mismatches  INT NOT NULL
+);`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		hasRoleOption, _, err := p.HasViewActivityOrViewActivityRedactedRole(ctx)
+		if err != nil {
+			return err
+		}
+		if !hasRoleOption {
+			return noViewActivityOrViewActivityRedactedRoleError(p.User())
+		}
+
+		nodeID, _ := p.execCfg.NodeInfo.NodeID.OptionalNodeID() // zero if not available
+		for _, b := range p.execCfg.PlanBaselines.Baselines() {
+			created, err := tree.MakeDTimestampTZ(b.Created, time.Microsecond)
+			if err != nil {
+				return err
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(nodeID)),
+				tree.NewDString(b.Fingerprint),
+				tree.NewDString(b.PlanGist),
+				tree.MakeDBool(tree.DBool(b.Enforce)),
+				created,
+				tree.NewDInt(tree.DInt(b.Hits())),
+				tree.NewDInt(tree.DInt(b.Mismatches())),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 var crdbInternalShowTenantCapabilitiesCache = virtualSchemaTable{
 	comment: `eventually consistent in-memory tenant capability cache for this node`,
 	schema: `
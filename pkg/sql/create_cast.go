@@ -0,0 +1,476 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+type createCastNode struct {
+	n *tree.CreateCast
+	// desc is the descriptor of the type on which the cast is stored.
+	desc *typedesc.Mutable
+	cast descpb.TypeDescriptor_Cast
+}
+
+// CreateCast creates a user-defined cast. At least one of the source and
+// target types must be a user-defined type owned by the current user.
+func (p *planner) CreateCast(ctx context.Context, n *tree.CreateCast) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE CAST",
+	); err != nil {
+		return nil, err
+	}
+	if n.Method == tree.CastMethodBinary {
+		return nil, unimplemented.New("create cast without function",
+			"CREATE CAST ... WITHOUT FUNCTION is not supported")
+	}
+	from, to, desc, err := p.resolveCastTypes(ctx, n.SourceType, n.TargetType)
+	if err != nil {
+		return nil, err
+	}
+	if desc == nil {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot create a cast between builtin types %s and %s",
+			from.SQLStandardName(), to.SQLStandardName())
+	}
+	if c := findUserDefinedCast(desc, from.Oid(), to.Oid()); c != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"cast from type %s to type %s already exists",
+			from.SQLStandardName(), to.SQLStandardName())
+	}
+
+	c := descpb.TypeDescriptor_Cast{
+		SourceTypeOID: from.Oid(),
+		TargetTypeOID: to.Oid(),
+		Method:        descpb.TypeDescriptor_Cast_INOUT,
+	}
+	switch n.Context {
+	case cast.ContextAssignment:
+		c.Context = descpb.TypeDescriptor_Cast_ASSIGNMENT
+	case cast.ContextImplicit:
+		c.Context = descpb.TypeDescriptor_Cast_IMPLICIT
+	}
+	if n.Method == tree.CastMethodFunction {
+		c.Method = descpb.TypeDescriptor_Cast_FUNCTION
+		if c.FunctionOID, err = p.resolveCastFunction(ctx, &n.Function, from, to); err != nil {
+			return nil, err
+		}
+	}
+	return &createCastNode{n: n, desc: desc, cast: c}, nil
+}
+
+// resolveCastFunction returns the OID of the function which performs a cast
+// from one type to another. The function must take a single argument of the
+// source type, return the target type, and must not be volatile.
+func (p *planner) resolveCastFunction(
+	ctx context.Context, fn *tree.RoutineObj, from, to *types.T,
+) (oid.Oid, error) {
+	path := p.CurrentSearchPath()
+	fnDef, err := p.ResolveFunction(
+		ctx, tree.MakeUnresolvedFunctionName(fn.FuncName.ToUnresolvedObjectName().ToUnresolvedName()), &path,
+	)
+	if err != nil {
+		return 0, err
+	}
+	ol, err := fnDef.MatchOverload(
+		ctx, p, fn, &path, tree.UDFRoutine, false /* inDropContext */, false, /* tryDefaultExprs */
+	)
+	if err != nil {
+		return 0, err
+	}
+	paramTypes := ol.Types.Types()
+	if len(paramTypes) != 1 {
+		return 0, pgerror.New(pgcode.InvalidObjectDefinition,
+			"cast function must take one argument")
+	}
+	if !paramTypes[0].Equivalent(from) {
+		return 0, pgerror.New(pgcode.InvalidObjectDefinition,
+			"argument of cast function must match source data type")
+	}
+	if retType := ol.FixedReturnType(); retType == nil || !retType.Equivalent(to) {
+		return 0, pgerror.New(pgcode.InvalidObjectDefinition,
+			"return data type of cast function must match target data type")
+	}
+	if ol.Volatility == volatility.Volatile {
+		return 0, pgerror.New(pgcode.InvalidObjectDefinition,
+			"cast function must not be volatile")
+	}
+	if ol.Class != tree.NormalClass {
+		return 0, pgerror.New(pgcode.InvalidObjectDefinition,
+			"cast function must be a normal function")
+	}
+	return ol.Oid, nil
+}
+
+func (n *createCastNode) startExec(params runParams) error {
+	// Nodes running older versions drop the casts stored on the type
+	// descriptor when they rewrite it.
+	if !params.p.IsActive(params.ctx, clusterversion.V24_2) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"user-defined casts are not supported until version 24.2")
+	}
+	n.desc.Casts = append(n.desc.Casts, n.cast)
+	if err := params.p.writeTypeSchemaChange(
+		params.ctx, n.desc, tree.AsStringWithFQNames(n.n, params.p.Ann()),
+	); err != nil {
+		return err
+	}
+	// Record that the cast depends on its function, so that the function can't
+	// be dropped without the cast.
+	fnDesc, err := params.p.mutableCastFunction(params.ctx, n.cast)
+	if err != nil || fnDesc == nil {
+		return err
+	}
+	fnDesc.AddTypeCastReference(n.desc.GetID())
+	return params.p.writeFuncDesc(params.ctx, fnDesc)
+}
+
+// mutableCastFunction returns the mutable descriptor of the user-defined
+// function which performs the cast, or nil if the cast isn't performed by a
+// user-defined function.
+func (p *planner) mutableCastFunction(
+	ctx context.Context, c descpb.TypeDescriptor_Cast,
+) (*funcdesc.Mutable, error) {
+	if c.Method != descpb.TypeDescriptor_Cast_FUNCTION || !funcdesc.IsOIDUserDefinedFunc(c.FunctionOID) {
+		return nil, nil
+	}
+	return p.Descriptors().MutableByID(p.txn).Function(
+		ctx, funcdesc.UserDefinedFunctionOIDToID(c.FunctionOID),
+	)
+}
+
+func (n *createCastNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createCastNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createCastNode) Close(ctx context.Context)           {}
+func (n *createCastNode) ReadingOwnWrites()                   {}
+
+type dropCastNode struct {
+	n *tree.DropCast
+	// desc is the descriptor of the type on which the cast is stored.
+	desc    *typedesc.Mutable
+	castIdx int
+}
+
+// DropCast drops a user-defined cast.
+func (p *planner) DropCast(ctx context.Context, n *tree.DropCast) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP CAST",
+	); err != nil {
+		return nil, err
+	}
+	from, to, desc, err := p.resolveCastTypes(ctx, n.SourceType, n.TargetType)
+	if err != nil {
+		if n.IfExists && pgerror.GetPGCode(err) == pgcode.UndefinedObject {
+			p.BufferClientNotice(ctx, pgnotice.Newf("%s, skipping", err.Error()))
+			return newZeroNode(nil /* columns */), nil
+		}
+		return nil, err
+	}
+	if desc != nil {
+		for i := range desc.Casts {
+			if c := &desc.Casts[i]; c.SourceTypeOID == from.Oid() && c.TargetTypeOID == to.Oid() {
+				return &dropCastNode{n: n, desc: desc, castIdx: i}, nil
+			}
+		}
+	}
+	if n.IfExists {
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"cast from type %s to type %s does not exist, skipping",
+			from.SQLStandardName(), to.SQLStandardName()))
+		return newZeroNode(nil /* columns */), nil
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject,
+		"cast from type %s to type %s does not exist",
+		from.SQLStandardName(), to.SQLStandardName())
+}
+
+func (n *dropCastNode) startExec(params runParams) error {
+	c := n.desc.Casts[n.castIdx]
+	n.desc.Casts = append(n.desc.Casts[:n.castIdx], n.desc.Casts[n.castIdx+1:]...)
+	if err := params.p.writeTypeSchemaChange(
+		params.ctx, n.desc, tree.AsStringWithFQNames(n.n, params.p.Ann()),
+	); err != nil {
+		return err
+	}
+	fnDesc, err := params.p.mutableCastFunction(params.ctx, c)
+	if err != nil || fnDesc == nil {
+		return err
+	}
+	// The type may store other casts performed by the same function.
+	for _, other := range n.desc.Casts {
+		if other.Method == c.Method && other.FunctionOID == c.FunctionOID {
+			return nil
+		}
+	}
+	fnDesc.RemoveTypeCastReference(n.desc.GetID())
+	return params.p.writeFuncDesc(params.ctx, fnDesc)
+}
+
+func (n *dropCastNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *dropCastNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *dropCastNode) Close(ctx context.Context)           {}
+func (n *dropCastNode) ReadingOwnWrites()                   {}
+
+// castsUsingFunction returns the descriptions of the user-defined casts
+// performed by the function. They are stored on the types which reference it.
+func (p *planner) castsUsingFunction(
+	ctx context.Context, fnDesc catalog.FunctionDescriptor,
+) (names []string, _ error) {
+	for _, ref := range fnDesc.GetDependedOnBy() {
+		desc, err := p.Descriptors().ByID(p.txn).Get().Desc(ctx, ref.ID)
+		if err != nil {
+			return nil, err
+		}
+		typDesc, ok := desc.(catalog.TypeDescriptor)
+		if !ok {
+			continue
+		}
+		typNames, err := p.castsOnTypeUsingFunction(ctx, typDesc, fnDesc)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, typNames...)
+	}
+	return names, nil
+}
+
+// castsOnTypeUsingFunction returns the descriptions of the user-defined casts
+// stored on the type which are performed by the function.
+func (p *planner) castsOnTypeUsingFunction(
+	ctx context.Context, typDesc catalog.TypeDescriptor, fnDesc catalog.FunctionDescriptor,
+) (names []string, _ error) {
+	fnOID := catid.FuncIDToOID(fnDesc.GetID())
+	for _, c := range typDesc.TypeDesc().Casts {
+		if c.Method != descpb.TypeDescriptor_Cast_FUNCTION || c.FunctionOID != fnOID {
+			continue
+		}
+		name, err := p.castName(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// dropCastsUsingFunction drops the user-defined casts performed by the
+// function, which is being dropped with CASCADE.
+func (p *planner) dropCastsUsingFunction(ctx context.Context, fnDesc *funcdesc.Mutable) error {
+	fnOID := catid.FuncIDToOID(fnDesc.GetID())
+	refs := append([]descpb.FunctionDescriptor_Reference(nil), fnDesc.DependedOnBy...)
+	for _, ref := range refs {
+		desc, err := p.Descriptors().ByID(p.txn).Get().Desc(ctx, ref.ID)
+		if err != nil {
+			return err
+		}
+		if desc.DescriptorType() != catalog.Type {
+			continue
+		}
+		typDesc, err := p.Descriptors().MutableByID(p.txn).Type(ctx, ref.ID)
+		if err != nil {
+			return err
+		}
+		casts := typDesc.Casts[:0]
+		for _, c := range typDesc.Casts {
+			if c.Method != descpb.TypeDescriptor_Cast_FUNCTION || c.FunctionOID != fnOID {
+				casts = append(casts, c)
+				continue
+			}
+			name, err := p.castName(ctx, c)
+			if err != nil {
+				return err
+			}
+			p.BufferClientNotice(ctx, pgnotice.Newf("drop cascades to %s", name))
+		}
+		typDesc.Casts = casts
+		if err := p.writeTypeSchemaChange(ctx, typDesc, fmt.Sprintf(
+			"dropping casts using function %s(%d)", fnDesc.Name, fnDesc.ID,
+		)); err != nil {
+			return err
+		}
+		fnDesc.RemoveTypeCastReference(typDesc.GetID())
+	}
+	return nil
+}
+
+// castName describes a user-defined cast in messages.
+func (p *planner) castName(ctx context.Context, c descpb.TypeDescriptor_Cast) (string, error) {
+	var names [2]string
+	for i, o := range []oid.Oid{c.SourceTypeOID, c.TargetTypeOID} {
+		typ, ok := types.OidToType[o]
+		if !ok {
+			var err error
+			if typ, err = p.ResolveTypeByOID(ctx, o); err != nil {
+				return "", err
+			}
+		}
+		names[i] = typ.SQLStandardName()
+	}
+	return fmt.Sprintf("cast from type %s to type %s", names[0], names[1]), nil
+}
+
+// resolveCastTypes resolves the source and target types of a user-defined
+// cast, and returns the mutable descriptor of the type on which the cast is
+// stored, if any. The current user must own either the source type or the
+// target type.
+func (p *planner) resolveCastTypes(
+	ctx context.Context, source, target tree.ResolvableTypeReference,
+) (from, to *types.T, desc *typedesc.Mutable, _ error) {
+	var err error
+	if from, err = tree.ResolveType(ctx, source, p.semaCtx.GetTypeResolver()); err != nil {
+		return nil, nil, nil, err
+	}
+	if to, err = tree.ResolveType(ctx, target, p.semaCtx.GetTypeResolver()); err != nil {
+		return nil, nil, nil, err
+	}
+	if from.Oid() == to.Oid() {
+		return nil, nil, nil, pgerror.New(pgcode.InvalidObjectDefinition,
+			"source data type and target data type are the same")
+	}
+	if !from.UserDefined() && !to.UserDefined() {
+		return from, to, nil, nil
+	}
+
+	isOwner := false
+	for _, typ := range []*types.T{from, to} {
+		if !typ.UserDefined() {
+			continue
+		}
+		typDesc, err := p.Descriptors().ByIDWithLeased(p.txn).Get().Type(
+			ctx, typedesc.UserDefinedTypeOIDToID(typ.Oid()),
+		)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if typDesc.GetKind() == descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE {
+			return nil, nil, nil, pgerror.Newf(pgcode.WrongObjectType,
+				"casts involving the record type of table %s are not supported",
+				tree.Name(typDesc.GetName()))
+		}
+		if hasOwnership, err := p.HasOwnership(ctx, typDesc); err != nil {
+			return nil, nil, nil, err
+		} else if hasOwnership {
+			isOwner = true
+		}
+	}
+	if !isOwner {
+		if from.UserDefined() && to.UserDefined() {
+			return nil, nil, nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+				"must be owner of type %s or type %s", from.SQLStandardName(), to.SQLStandardName())
+		}
+		typ := from
+		if !typ.UserDefined() {
+			typ = to
+		}
+		return nil, nil, nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of type %s", typ.SQLStandardName())
+	}
+
+	owner := userDefinedCastOwner(from, to)
+	desc, err = p.Descriptors().MutableByID(p.txn).Type(ctx, typedesc.UserDefinedTypeOIDToID(owner.Oid()))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return from, to, desc, nil
+}
+
+// userDefinedCastOwner returns the type on whose descriptor a cast from one
+// type to another is stored: the source type if it is user-defined, and the
+// target type otherwise.
+func userDefinedCastOwner(from, to *types.T) *types.T {
+	if from.UserDefined() {
+		return from
+	}
+	return to
+}
+
+// findUserDefinedCast returns the cast with the given source and target types
+// stored on the descriptor, or nil if there is none.
+func findUserDefinedCast(
+	desc catalog.TypeDescriptor, from, to oid.Oid,
+) *descpb.TypeDescriptor_Cast {
+	casts := desc.TypeDesc().Casts
+	for i := range casts {
+		if casts[i].SourceTypeOID == from && casts[i].TargetTypeOID == to {
+			return &casts[i]
+		}
+	}
+	return nil
+}
+
+// ResolveCast implements the tree.CastResolver interface.
+func (sr *schemaResolver) ResolveCast(
+	ctx context.Context, from, to *types.T,
+) (*tree.UserDefinedCast, error) {
+	owner := userDefinedCastOwner(from, to)
+	if !owner.UserDefined() || sr.txn == nil {
+		return nil, nil
+	}
+	desc, err := sr.byIDGetterBuilder().WithoutNonPublic().Get().Type(
+		ctx, typedesc.UserDefinedTypeOIDToID(owner.Oid()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	c := findUserDefinedCast(desc, from.Oid(), to.Oid())
+	if c == nil {
+		return nil, nil
+	}
+	udc := &tree.UserDefinedCast{
+		Method:     tree.CastMethodInOut,
+		MaxContext: userDefinedCastContext(*c),
+	}
+	switch c.Method {
+	case descpb.TypeDescriptor_Cast_FUNCTION:
+		udc.Method = tree.CastMethodFunction
+		udc.FuncOID = c.FunctionOID
+	case descpb.TypeDescriptor_Cast_INOUT:
+	default:
+		return nil, errors.AssertionFailedf("unexpected cast method %s", c.Method)
+	}
+	return udc, nil
+}
+
+// userDefinedCastContext returns the most permissive context in which a
+// user-defined cast can be applied.
+func userDefinedCastContext(c descpb.TypeDescriptor_Cast) cast.Context {
+	switch c.Context {
+	case descpb.TypeDescriptor_Cast_ASSIGNMENT:
+		return cast.ContextAssignment
+	case descpb.TypeDescriptor_Cast_IMPLICIT:
+		return cast.ContextImplicit
+	default:
+		return cast.ContextExplicit
+	}
+}
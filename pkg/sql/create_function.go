@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -25,10 +26,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
@@ -575,6 +578,24 @@ func setFuncOptions(
 			// Handle the body after the loop, since we don't yet know what language
 			// it is.
 			body = string(t)
+		case tree.RoutineSecurity:
+			if !params.p.IsActive(params.ctx, clusterversion.V24_2) {
+				return pgerror.New(pgcode.FeatureNotSupported,
+					"SECURITY clauses for routines are not supported until version 24.2")
+			}
+			sec, err := funcinfo.SecurityToProto(t)
+			if err != nil {
+				return err
+			}
+			udfDesc.SetSecurity(sec)
+		case *tree.RoutineSetVar:
+			if !params.p.IsActive(params.ctx, clusterversion.V24_2) {
+				return pgerror.New(pgcode.FeatureNotSupported,
+					"SET clauses for routines are not supported until version 24.2")
+			}
+			if err := setFuncConfigVar(params, udfDesc, &t.SetVar); err != nil {
+				return err
+			}
 		default:
 			return pgerror.Newf(pgcode.InvalidParameterValue, "Unknown function option %q", t)
 		}
@@ -596,11 +617,80 @@ func setFuncOptions(
 	return nil
 }
 
+// setFuncConfigVar applies a SET or RESET clause of a routine definition to the
+// configuration of the given function. The value of the session variable is
+// validated against a copy of the current session, so that a routine cannot be
+// created with a configuration that would fail on every invocation.
+func setFuncConfigVar(params runParams, udfDesc *funcdesc.Mutable, n *tree.SetVar) error {
+	if n.ResetAll {
+		udfDesc.ResetAllConfigVars()
+		return nil
+	}
+	name := strings.ToLower(n.Name)
+	_, v, err := getSessionVar(name, false /* missingOk */)
+	if err != nil {
+		return err
+	}
+	if v.Set == nil {
+		// Variables that are set through the planner (e.g. role) or that affect
+		// the transaction (e.g. transaction_isolation) cannot be scoped to the
+		// execution of a routine.
+		return newCannotChangeParameterError(name)
+	}
+	if n.Reset {
+		udfDesc.ResetConfigVar(name)
+		return nil
+	}
+	if len(n.Values) == 1 {
+		if _, ok := n.Values[0].(tree.DefaultVal); ok {
+			// "SET var = DEFAULT" is equivalent to RESET.
+			udfDesc.ResetConfigVar(name)
+			return nil
+		}
+	}
+
+	typedValues := make([]tree.TypedExpr, len(n.Values))
+	for i, expr := range n.Values {
+		expr = paramparse.UnresolvedNameToStrVal(expr)
+		var dummyHelper tree.IndexedVarHelper
+		typedValue, err := params.p.analyzeExpr(
+			params.ctx, expr, dummyHelper, types.String, false, "SET "+name,
+		)
+		if err != nil {
+			return wrapSetVarError(err, name, expr.String())
+		}
+		d, err := eval.Expr(params.ctx, params.EvalContext(), typedValue)
+		if err != nil {
+			return err
+		}
+		typedValues[i] = d
+	}
+	var strVal string
+	if v.GetStringVal != nil {
+		strVal, err = v.GetStringVal(params.ctx, params.extendedEvalCtx, typedValues, params.p.Txn())
+	} else {
+		strVal, err = getStringVal(params.ctx, params.EvalContext(), name, typedValues)
+	}
+	if err != nil {
+		return err
+	}
+	m := params.p.sessionDataMutatorIterator.mutator(
+		false /* applyCallbacks */, params.p.SessionData().Clone(),
+	)
+	if err := v.Set(params.ctx, m, strVal); err != nil {
+		return err
+	}
+	udfDesc.SetConfigVar(name, strVal)
+	return nil
+}
+
 // resetFuncOption sets all function options to default values.
 func resetFuncOption(udfDesc *funcdesc.Mutable) {
 	udfDesc.SetVolatility(catpb.Function_VOLATILE)
 	udfDesc.SetNullInputBehavior(catpb.Function_CALLED_ON_NULL_INPUT)
 	udfDesc.SetLeakProof(false)
+	udfDesc.SetSecurity(catpb.Function_INVOKER)
+	udfDesc.ResetAllConfigVars()
 }
 
 func makeFunctionParam(
@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/featureflag"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -135,14 +136,14 @@ func (n *createStatsNode) runJob(ctx context.Context) error {
 		return err
 	}
 
-	if n.Name != jobspb.AutoStatsName {
+	if n.Name != jobspb.AutoStatsName && n.Name != jobspb.AutoTargetedStatsName {
 		telemetry.Inc(sqltelemetry.CreateStatisticsUseCounter)
 	}
 
 	var job *jobs.StartableJob
 	jobID := n.p.ExecCfg().JobRegistry.MakeJobID()
 	if err := n.p.ExecCfg().InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) (err error) {
-		if n.Name == jobspb.AutoStatsName {
+		if n.Name == jobspb.AutoStatsName || n.Name == jobspb.AutoTargetedStatsName {
 			// Don't start the job if there is already a CREATE STATISTICS job running.
 			// (To handle race conditions we check this again after the job starts,
 			// but this check is used to prevent creating a large number of jobs that
@@ -236,7 +237,11 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 
 	var colStats []jobspb.CreateStatsDetails_ColStat
 	var deleteOtherStats bool
-	if len(n.ColumnNames) == 0 {
+	if n.Expr != nil {
+		if colStats, err = n.makeExprColStats(ctx, tableDesc); err != nil {
+			return nil, err
+		}
+	} else if len(n.ColumnNames) == 0 {
 		virtColEnabled := statsOnVirtualCols.Get(n.p.ExecCfg().SV())
 		// Disable multi-column stats and deleting stats if partial statistics at
 		// the extremes are requested.
@@ -317,6 +322,8 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 	if n.Name == jobspb.AutoStatsName {
 		// Use a user-friendly description for automatic statistics.
 		description = fmt.Sprintf("Table statistics refresh for %s", fqTableName)
+	} else if n.Name == jobspb.AutoTargetedStatsName {
+		description = fmt.Sprintf("Targeted table statistics collection for %s", fqTableName)
 	} else {
 		// This must be a user query, so use the statement (for consistency with
 		// other jobs triggered by statements).
@@ -342,6 +349,47 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 	}, nil
 }
 
+// makeExprColStats returns the column statistic to collect on the expression
+// of a CREATE STATISTICS ... ON (<expr>) statement. The statistic is stored
+// with the serialized expression and the IDs of the columns it references, and
+// the expression is evaluated over the scanned rows when it is collected.
+func (n *createStatsNode) makeExprColStats(
+	ctx context.Context, tableDesc catalog.TableDescriptor,
+) ([]jobspb.CreateStatsDetails_ColStat, error) {
+	if !n.p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_2_TableStatisticsExpression) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"statistics on expressions are not supported until upgrade to 24.2 is finalized",
+		)
+	}
+	if n.Options.UsingExtremes {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"cannot create partial statistics on an expression",
+		)
+	}
+
+	expr, typ, colIDs, err := schemaexpr.ValidateStatisticExpression(
+		ctx,
+		tableDesc,
+		n.Expr,
+		tree.NewUnqualifiedTableName(tree.Name(tableDesc.GetName())),
+		n.p.SemaCtx(),
+		n.p.ExecCfg().Settings.Version.ActiveVersion(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return []jobspb.CreateStatsDetails_ColStat{{
+		ColumnIDs: colIDs.Ordered(),
+		// Histograms are not collected on expressions of types which can only
+		// be indexed by inverted indexes, since there is no inverted index to
+		// generate the histogram keys from.
+		HasHistogram:        !colinfo.ColumnTypeIsOnlyInvertedIndexable(typ),
+		HistogramMaxBuckets: stats.GetDefaultHistogramBuckets(n.p.ExecCfg().SV(), tableDesc),
+		Expression:          expr,
+	}}, nil
+}
+
 // maxNonIndexCols is the maximum number of non-index columns that we will use
 // when choosing a default set of column statistics.
 const maxNonIndexCols = 100
@@ -649,7 +697,7 @@ func (r *createStatsResumer) Resume(ctx context.Context, execCtx interface{}) er
 	// associated txn.
 	jobsPlanner := execCtx.(JobExecContext)
 	details := r.job.Details().(jobspb.CreateStatsDetails)
-	if details.Name == jobspb.AutoStatsName {
+	if details.Name == jobspb.AutoStatsName || details.Name == jobspb.AutoTargetedStatsName {
 		// We want to make sure that an automatic CREATE STATISTICS job only runs if
 		// there are no other CREATE STATISTICS jobs running, automatic or manual.
 		if err := checkRunningJobs(ctx, r.job, jobsPlanner); err != nil {
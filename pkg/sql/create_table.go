@@ -355,6 +355,7 @@ func (n *createTableNode) startExec(params runParams) error {
 
 	var desc *tabledesc.Mutable
 	var affected map[descpb.ID]*tabledesc.Mutable
+	var likeDefs []*tree.LikeTableDef
 	// creationTime is initialized to a zero value and populated at read time.
 	// See the comment in desc.MaybeIncrementVersion.
 	//
@@ -388,11 +389,19 @@ func (n *createTableNode) startExec(params runParams) error {
 		}
 
 		// If we have a single statement txn we want to run CTAS async, and
-		// consequently ensure it gets queued as a SchemaChange.
-		if params.extendedEvalCtx.TxnIsSingleStmt {
+		// consequently ensure it gets queued as a SchemaChange. A table created
+		// WITH NO DATA has nothing to backfill.
+		if params.extendedEvalCtx.TxnIsSingleStmt && !n.n.AsWithNoData {
 			desc.State = descpb.DescriptorState_ADD
 		}
 	} else {
+		// Remember the LIKE table definitions before newTableDesc replaces them
+		// with the definitions they expand to.
+		for _, def := range n.n.Defs {
+			if d, ok := def.(*tree.LikeTableDef); ok {
+				likeDefs = append(likeDefs, d)
+			}
+		}
 		affected = make(map[descpb.ID]*tabledesc.Mutable)
 		desc, err = newTableDesc(params, n.n, n.dbDesc, schema, id, creationTime, privs, affected)
 		if err != nil {
@@ -508,6 +517,12 @@ func (n *createTableNode) startExec(params runParams) error {
 		}
 	}
 
+	for _, d := range likeDefs {
+		if err := params.p.copyLikeTableProperties(params.ctx, d, desc); err != nil {
+			return err
+		}
+	}
+
 	// Log Create Table event. This is an auditable log event and is
 	// recorded in the same transaction as the table descriptor update.
 	if err := params.p.logEvent(params.ctx,
@@ -520,7 +535,7 @@ func (n *createTableNode) startExec(params runParams) error {
 
 	// If we are in a multi-statement txn or the source has placeholders, we
 	// execute the CTAS query synchronously.
-	if n.n.As() && !params.extendedEvalCtx.TxnIsSingleStmt {
+	if n.n.As() && !n.n.AsWithNoData && !params.extendedEvalCtx.TxnIsSingleStmt {
 		err = func() error {
 			// The data fill portion of CREATE AS must operate on a read snapshot,
 			// so that it doesn't end up observing its own writes.
@@ -1004,6 +1019,20 @@ func ResolveFK(
 		targetColIDs[i] = referencedCols[i].GetID()
 	}
 
+	// Nodes running older versions don't know how to enforce MATCH PARTIAL
+	// constraints.
+	if d.Match == tree.MatchPartial && !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_2) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"MATCH PARTIAL foreign keys are not supported until version 24.2")
+	}
+
+	// Cascading updates are not supported with MATCH PARTIAL, since a child
+	// row can match several parent rows with different values.
+	if d.Match == tree.MatchPartial && d.Actions.Update != tree.NoAction && d.Actions.Update != tree.Restrict {
+		return unimplemented.NewWithIssueDetailf(20305, "match partial on update",
+			"ON UPDATE %s is not supported with MATCH PARTIAL", d.Actions.Update)
+	}
+
 	// Don't add a SET NULL action on an index that has any column that is NOT
 	// NULL.
 	if d.Actions.Delete == tree.SetNull || d.Actions.Update == tree.SetNull {
@@ -1260,6 +1289,11 @@ func newTableDescIfAs(
 	if err != nil {
 		return nil, err
 	}
+	if p.AsWithNoData {
+		// The table is never populated from the source query, so it is treated
+		// like any other newly created empty table.
+		return desc, nil
+	}
 	createQuery, err := getFinalSourceQuery(params, p.AsSource, evalContext)
 	if err != nil {
 		return nil, err
@@ -2524,15 +2558,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 		if err != nil {
 			return nil, err
 		}
-		opts := tree.LikeTableOpt(0)
-		// Process ons / offs.
-		for _, opt := range d.Options {
-			if opt.Excluded {
-				opts &^= opt.Opt
-			} else {
-				opts |= opt.Opt
-			}
-		}
+		opts := likeTableOpts(d)
 
 		// Copy defaults of implicitly created columns if they are needed by indexes.
 		// This is required to ensure the newly created table still works as expected
@@ -2573,7 +2599,26 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 			} else {
 				def.Nullable.Nullability = tree.NotNull
 			}
-			if c.DefaultExpr != nil {
+			if c.GeneratedAsIdentityType != catpb.GeneratedAsIdentityType_NOT_IDENTITY_COLUMN &&
+				opts.Has(tree.LikeTableOptIdentity) {
+				// The identity column gets its own sequence, so the source
+				// column's default (which refers to the source sequence) is not
+				// copied.
+				def.GeneratedIdentity.IsGeneratedAsIdentity = true
+				if c.GeneratedAsIdentityType == catpb.GeneratedAsIdentityType_GENERATED_ALWAYS {
+					def.GeneratedIdentity.GeneratedAsIdentityType = tree.GeneratedAlways
+				} else {
+					def.GeneratedIdentity.GeneratedAsIdentityType = tree.GeneratedByDefault
+				}
+				if c.GeneratedAsIdentitySequenceOption != nil {
+					def.GeneratedIdentity.SeqOptions, err = parseIdentitySequenceOptions(
+						*c.GeneratedAsIdentitySequenceOption,
+					)
+					if err != nil {
+						return nil, err
+					}
+				}
+			} else if c.DefaultExpr != nil {
 				_, shouldCopyColumnDefault := shouldCopyColumnDefaultSet[c.Name]
 				if opts.Has(tree.LikeTableOptDefaults) || shouldCopyColumnDefault {
 					def.DefaultExpr.Expr, err = parser.ParseExpr(*c.DefaultExpr)
@@ -2713,6 +2758,39 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 	return newDefs, nil
 }
 
+// likeTableOpts returns the set of options enabled by the INCLUDING and
+// EXCLUDING clauses of a LIKE table definition. Later clauses take precedence
+// over earlier ones.
+func likeTableOpts(d *tree.LikeTableDef) tree.LikeTableOpt {
+	opts := tree.LikeTableOpt(0)
+	// Process ons / offs.
+	for _, opt := range d.Options {
+		if opt.Excluded {
+			opts &^= opt.Opt
+		} else {
+			opts |= opt.Opt
+		}
+	}
+	return opts
+}
+
+// parseIdentitySequenceOptions parses the sequence options stored for an
+// identity column back into their AST form.
+func parseIdentitySequenceOptions(s string) (tree.SequenceOptions, error) {
+	if s == "" {
+		return nil, nil
+	}
+	stmt, err := parser.ParseOne("CREATE SEQUENCE fake_seq " + s)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse sequence option")
+	}
+	createSeq, ok := stmt.AST.(*tree.CreateSequence)
+	if !ok {
+		return nil, errors.AssertionFailedf("cannot convert parsed result to tree.CreateSequence")
+	}
+	return createSeq.Options, nil
+}
+
 // makeShardColumnDesc returns a new column descriptor for a hidden computed shard column
 // based on all the `colNames` and the bucket count. It delegates to one of
 // makeHashShardComputeExpr.
@@ -0,0 +1,246 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/stats"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// copyLikeTableProperties copies the properties of the source table of a LIKE
+// table definition which are not part of the table's column, index and
+// constraint definitions, namely its comments, zone configuration and
+// statistics, onto the newly created table. Columns, indexes and constraints
+// of the two tables are matched by name.
+func (p *planner) copyLikeTableProperties(
+	ctx context.Context, d *tree.LikeTableDef, dst *tabledesc.Mutable,
+) error {
+	opts := likeTableOpts(d)
+	if !opts.Has(tree.LikeTableOptComments | tree.LikeTableOptStorage | tree.LikeTableOptStatistics) {
+		return nil
+	}
+	// The source table is only read from, so it is resolved immutably.
+	src, err := p.ResolveExistingObjectEx(
+		ctx, d.Name.ToUnresolvedObjectName(), true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return err
+	}
+	// The statistics reveal the contents of the source table, so they require
+	// the same privilege as reading it. Comments and zone configurations are
+	// visible to anyone with some privilege on the table, as for SHOW CREATE
+	// and SHOW ZONE CONFIGURATION.
+	if opts.Has(tree.LikeTableOptStatistics) {
+		if err := p.CheckPrivilege(ctx, src, privilege.SELECT); err != nil {
+			return err
+		}
+	}
+	if opts.Has(tree.LikeTableOptComments | tree.LikeTableOptStorage) {
+		if err := p.CheckAnyPrivilege(ctx, src); err != nil {
+			return err
+		}
+	}
+	if opts.Has(tree.LikeTableOptComments) {
+		if err := p.copyLikeTableComments(ctx, src, dst); err != nil {
+			return err
+		}
+	}
+	if opts.Has(tree.LikeTableOptStorage) {
+		if err := p.copyLikeTableZoneConfig(ctx, src, dst); err != nil {
+			return err
+		}
+	}
+	if opts.Has(tree.LikeTableOptStatistics) {
+		if err := p.copyLikeTableStatistics(ctx, src, dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyLikeTableComments copies the column, index and constraint comments of
+// src onto dst. Like in Postgres, the comment on the table itself is not
+// copied.
+func (p *planner) copyLikeTableComments(
+	ctx context.Context, src catalog.TableDescriptor, dst *tabledesc.Mutable,
+) error {
+	for _, srcCol := range src.PublicColumns() {
+		cmt, ok := p.Descriptors().GetColumnComment(src.GetID(), srcCol.GetPGAttributeNum())
+		if !ok {
+			continue
+		}
+		dstCol := catalog.FindColumnByName(dst, srcCol.GetName())
+		if dstCol == nil {
+			continue
+		}
+		if err := p.updateComment(
+			ctx, dst.GetID(), uint32(dstCol.GetPGAttributeNum()), catalogkeys.ColumnCommentType, cmt,
+		); err != nil {
+			return err
+		}
+	}
+	for _, srcIdx := range src.ActiveIndexes() {
+		cmt, ok := p.Descriptors().GetIndexComment(src.GetID(), srcIdx.GetID())
+		if !ok {
+			continue
+		}
+		dstIdx := catalog.FindIndexByName(dst, srcIdx.GetName())
+		if dstIdx == nil {
+			continue
+		}
+		if err := p.updateComment(
+			ctx, dst.GetID(), uint32(dstIdx.GetID()), catalogkeys.IndexCommentType, cmt,
+		); err != nil {
+			return err
+		}
+	}
+	for _, srcC := range src.AllConstraints() {
+		cmt, ok := p.Descriptors().GetConstraintComment(src.GetID(), srcC.GetConstraintID())
+		if !ok {
+			continue
+		}
+		dstC := catalog.FindConstraintByName(dst, srcC.GetName())
+		if dstC == nil {
+			continue
+		}
+		if err := p.updateComment(
+			ctx, dst.GetID(), uint32(dstC.GetConstraintID()), catalogkeys.ConstraintCommentType, cmt,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyLikeTableZoneConfig copies the zone configuration of src onto dst.
+// Index subzones are remapped onto the index of dst with the same name;
+// partition subzones are not copied since LIKE does not copy partitioning.
+func (p *planner) copyLikeTableZoneConfig(
+	ctx context.Context, src catalog.TableDescriptor, dst *tabledesc.Mutable,
+) error {
+	if src.GetLocalityConfig() != nil || dst.GetLocalityConfig() != nil {
+		// The zone configurations of multi-region tables are derived from their
+		// locality and are managed separately.
+		return nil
+	}
+	srcZone, err := p.Descriptors().GetZoneConfig(ctx, p.txn, src.GetID())
+	if err != nil {
+		return err
+	}
+	if srcZone == nil {
+		return nil
+	}
+	zone := *srcZone.Clone().ZoneConfigProto()
+	subzones := zone.Subzones
+	zone.Subzones = nil
+	zone.SubzoneSpans = nil
+	for _, s := range subzones {
+		if s.PartitionName != "" {
+			continue
+		}
+		srcIdx := catalog.FindIndexByID(src, descpb.IndexID(s.IndexID))
+		if srcIdx == nil {
+			continue
+		}
+		dstIdx := catalog.FindIndexByName(dst, srcIdx.GetName())
+		if dstIdx == nil {
+			continue
+		}
+		zone.Subzones = append(zone.Subzones, zonepb.Subzone{
+			IndexID: uint32(dstIdx.GetID()),
+			Config:  s.Config,
+		})
+	}
+	if zone.IsSubzonePlaceholder() && len(zone.Subzones) == 0 {
+		return nil
+	}
+	_, err = writeZoneConfig(
+		ctx,
+		p.InternalSQLTxn(),
+		dst.GetID(),
+		dst,
+		&zone,
+		nil, /* expectedExistingRawBytes */
+		p.ExecCfg(),
+		len(zone.Subzones) > 0, /* hasNewSubzones */
+		p.ExtendedEvalContext().Tracing.KVTracingEnabled(),
+	)
+	return err
+}
+
+// copyLikeTableStatistics injects the table statistics of src into dst. Only
+// statistics on columns which exist in both tables are copied, and partial
+// statistics are skipped since they refer to the full statistics of src.
+func (p *planner) copyLikeTableStatistics(
+	ctx context.Context, src catalog.TableDescriptor, dst *tabledesc.Mutable,
+) error {
+	txn := p.InternalSQLTxn()
+	rows, err := txn.QueryBufferedEx(
+		ctx, "copy-like-table-stats", p.txn, sessiondata.NodeUserSessionDataOverride,
+		fmt.Sprintf(
+			`SELECT "columnIDs", name, "createdAt", "rowCount", "distinctCount", "nullCount", "avgSize", histogram, %s
+       FROM system.table_statistics
+      WHERE "tableID" = $1 AND "partialPredicate" IS NULL`,
+			stats.ExpressionColumnOrNull(ctx, p.ExecCfg().Settings),
+		),
+		src.GetID(),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read statistics of table %q", src.GetName())
+	}
+StatsLoop:
+	for _, row := range rows {
+		columnIDs := tree.NewDArray(types.Int)
+		for _, d := range tree.MustBeDArray(row[0]).Array {
+			srcCol := catalog.FindColumnByID(src, descpb.ColumnID(tree.MustBeDInt(d)))
+			if srcCol == nil {
+				continue StatsLoop
+			}
+			dstCol := catalog.FindColumnByName(dst, srcCol.GetName())
+			if dstCol == nil {
+				continue StatsLoop
+			}
+			if err := columnIDs.Append(tree.NewDInt(tree.DInt(dstCol.GetID()))); err != nil {
+				return err
+			}
+		}
+		insertCols := `"tableID", "columnIDs", name, "createdAt", "rowCount", "distinctCount", "nullCount", "avgSize", histogram`
+		insertVals := `$1, $2, $3, $4, $5, $6, $7, $8, $9`
+		args := []interface{}{dst.GetID(), columnIDs, row[1], row[2], row[3], row[4], row[5], row[6], row[7]}
+		if row[8] != tree.DNull {
+			// Statistics on an expression refer to the columns by name, so the
+			// expression is valid for dst as well.
+			insertCols += `, expression`
+			insertVals += `, $10`
+			args = append(args, row[8])
+		}
+		if _, err := txn.ExecEx(
+			ctx, "insert-like-table-stats", p.txn, sessiondata.NodeUserSessionDataOverride,
+			fmt.Sprintf(`INSERT INTO system.table_statistics (%s) VALUES (%s)`, insertCols, insertVals),
+			args...,
+		); err != nil {
+			return errors.Wrapf(err, "failed to copy statistics to table %q", dst.GetName())
+		}
+	}
+	return nil
+}
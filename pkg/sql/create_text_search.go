@@ -0,0 +1,648 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/evalcatalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+)
+
+// textSearchSchemaChangeNode changes the text search objects of one or more
+// schemas. The changes are computed while planning the statement, and applied
+// when it is executed.
+type textSearchSchemaChangeNode struct {
+	n tree.Statement
+	// descs are the descriptors of the schemas which are changed, in the order
+	// in which changes to them were added.
+	descs   []*schemadesc.Mutable
+	changes []func()
+}
+
+func (n *textSearchSchemaChangeNode) startExec(params runParams) error {
+	// Nodes running older versions drop the text search objects stored on the
+	// schema descriptor when they rewrite it.
+	if !params.p.IsActive(params.ctx, clusterversion.V24_2) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"text search configurations and dictionaries are not supported until version 24.2")
+	}
+	for _, change := range n.changes {
+		change()
+	}
+	for _, desc := range n.descs {
+		if err := params.p.writeSchemaDescChange(
+			params.ctx, desc, tree.AsStringWithFQNames(n.n, params.p.Ann()),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *textSearchSchemaChangeNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *textSearchSchemaChangeNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *textSearchSchemaChangeNode) Close(ctx context.Context)           {}
+func (n *textSearchSchemaChangeNode) ReadingOwnWrites()                   {}
+
+// addChange adds a change to the schema.
+func (n *textSearchSchemaChangeNode) addChange(desc *schemadesc.Mutable, change func()) {
+	n.changes = append(n.changes, change)
+	for _, d := range n.descs {
+		if d == desc {
+			return
+		}
+	}
+	n.descs = append(n.descs, desc)
+}
+
+// CreateTextSearchDictionary creates a text search dictionary from one of the
+// templates of the tsearch package.
+func (p *planner) CreateTextSearchDictionary(
+	ctx context.Context, n *tree.CreateTextSearchDictionary,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE TEXT SEARCH DICTIONARY",
+	); err != nil {
+		return nil, err
+	}
+	sc, err := p.textSearchTargetSchema(ctx, n.Name)
+	if err != nil {
+		return nil, err
+	}
+	name := n.Name.Object()
+	if _, ok := sc.TextSearchDictionaries[name]; ok {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"text search dictionary %q already exists", name)
+	}
+
+	d := descpb.SchemaDescriptor_TextSearchDictionary{
+		Name:       name,
+		OwnerProto: p.User().EncodeProto(),
+	}
+	for _, param := range n.Options {
+		key := strings.ToLower(string(param.Key))
+		expr := paramparse.UnresolvedNameToStrVal(param.Value)
+		var dummyHelper tree.IndexedVarHelper
+		typedExpr, err := p.analyzeExpr(
+			ctx, expr, dummyHelper, types.Any, false /* requireType */, "CREATE TEXT SEARCH DICTIONARY",
+		)
+		if err != nil {
+			return nil, err
+		}
+		val, err := eval.Expr(ctx, p.EvalContext(), typedExpr)
+		if err != nil {
+			return nil, err
+		}
+		value := tree.AsStringWithFlags(val, tree.FmtBareStrings)
+		if key == "template" {
+			if d.Template != "" {
+				return nil, pgerror.New(pgcode.Syntax, "conflicting or redundant options")
+			}
+			d.Template = strings.TrimPrefix(strings.ToLower(value), catconstants.PgCatalogName+".")
+			continue
+		}
+		d.Options = append(d.Options, descpb.SchemaDescriptor_TextSearchDictionary_Option{
+			Name:  key,
+			Value: value,
+		})
+	}
+	if d.Template == "" {
+		return nil, pgerror.New(pgcode.InvalidObjectDefinition,
+			"text search template is required")
+	}
+	// Check that the template accepts the options.
+	if _, err := evalcatalog.MakeTextSearchDictionary(d); err != nil {
+		return nil, err
+	}
+
+	node := &textSearchSchemaChangeNode{n: n}
+	node.addChange(sc, func() {
+		if sc.TextSearchDictionaries == nil {
+			sc.TextSearchDictionaries = make(map[string]descpb.SchemaDescriptor_TextSearchDictionary)
+		}
+		sc.TextSearchDictionaries[name] = d
+	})
+	return node, nil
+}
+
+// CreateTextSearchConfiguration creates a text search configuration, either
+// without mappings or with the mappings of another configuration.
+func (p *planner) CreateTextSearchConfiguration(
+	ctx context.Context, n *tree.CreateTextSearchConfiguration,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE TEXT SEARCH CONFIGURATION",
+	); err != nil {
+		return nil, err
+	}
+	sc, err := p.textSearchTargetSchema(ctx, n.Name)
+	if err != nil {
+		return nil, err
+	}
+	name := n.Name.Object()
+	if _, ok := sc.TextSearchConfigurations[name]; ok {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"text search configuration %q already exists", name)
+	}
+
+	c := descpb.SchemaDescriptor_TextSearchConfiguration{
+		Name:       name,
+		OwnerProto: p.User().EncodeProto(),
+	}
+	if n.Copy != nil {
+		src, err := p.lookupTextSearchObject(ctx, tree.TextSearchConfiguration, n.Copy)
+		if err != nil {
+			return nil, err
+		}
+		if src.sc == nil {
+			dict := descpb.SchemaDescriptor_TextSearchConfiguration_DictionaryReference{
+				SchemaID: catconstants.PgCatalogID,
+				Name:     tsearch.BuiltinConfigDictionary(src.name),
+			}
+			for _, t := range tsearch.TokenTypes {
+				c.Mappings = append(c.Mappings, descpb.SchemaDescriptor_TextSearchConfiguration_Mapping{
+					TokenType:    t.String(),
+					Dictionaries: []descpb.SchemaDescriptor_TextSearchConfiguration_DictionaryReference{dict},
+				})
+			}
+		} else {
+			if src.db.GetID() != sc.GetParentID() {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"cannot copy text search configuration %s from another database", tree.ErrString(n.Copy))
+			}
+			c.Mappings = copyTextSearchMappings(
+				src.sc.SchemaDesc().TextSearchConfigurations[src.name].Mappings,
+			)
+		}
+	}
+
+	node := &textSearchSchemaChangeNode{n: n}
+	node.addChange(sc, func() {
+		if sc.TextSearchConfigurations == nil {
+			sc.TextSearchConfigurations = make(map[string]descpb.SchemaDescriptor_TextSearchConfiguration)
+		}
+		sc.TextSearchConfigurations[name] = c
+	})
+	return node, nil
+}
+
+// AlterTextSearchConfiguration changes the mappings of a text search
+// configuration.
+func (p *planner) AlterTextSearchConfiguration(
+	ctx context.Context, n *tree.AlterTextSearchConfiguration,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER TEXT SEARCH CONFIGURATION",
+	); err != nil {
+		return nil, err
+	}
+	obj, err := p.lookupTextSearchObject(ctx, tree.TextSearchConfiguration, n.Name)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := p.mutableTextSearchSchema(ctx, tree.TextSearchConfiguration, obj)
+	if err != nil {
+		return nil, err
+	}
+	c := sc.TextSearchConfigurations[obj.name]
+	c.Mappings = copyTextSearchMappings(c.Mappings)
+
+	tokenTypes := make([]tsearch.TokenType, len(n.TokenTypes))
+	for i, name := range n.TokenTypes {
+		if tokenTypes[i], err = tsearch.TokenTypeFromString(string(name)); err != nil {
+			return nil, err
+		}
+	}
+	findMapping := func(t tsearch.TokenType) int {
+		for i := range c.Mappings {
+			if c.Mappings[i].TokenType == t.String() {
+				return i
+			}
+		}
+		return -1
+	}
+
+	switch n.Action {
+	case tree.AlterTextSearchAddMapping, tree.AlterTextSearchAlterMapping:
+		dicts := make([]descpb.SchemaDescriptor_TextSearchConfiguration_DictionaryReference, len(n.Dictionaries))
+		for i, name := range n.Dictionaries {
+			if dicts[i], err = p.textSearchDictionaryReference(ctx, sc, name); err != nil {
+				return nil, err
+			}
+		}
+		for _, t := range tokenTypes {
+			if i := findMapping(t); i >= 0 {
+				if n.Action == tree.AlterTextSearchAddMapping {
+					return nil, pgerror.Newf(pgcode.DuplicateObject,
+						"mapping for token type %q already exists", t.String())
+				}
+				c.Mappings[i].Dictionaries = dicts
+				continue
+			}
+			c.Mappings = append(c.Mappings, descpb.SchemaDescriptor_TextSearchConfiguration_Mapping{
+				TokenType:    t.String(),
+				Dictionaries: dicts,
+			})
+		}
+
+	case tree.AlterTextSearchReplaceDictionary:
+		oldDict, err := p.textSearchDictionaryReference(ctx, sc, n.OldDictionary)
+		if err != nil {
+			return nil, err
+		}
+		newDict, err := p.textSearchDictionaryReference(ctx, sc, n.NewDictionary)
+		if err != nil {
+			return nil, err
+		}
+		for i := range c.Mappings {
+			m := &c.Mappings[i]
+			if len(tokenTypes) > 0 {
+				t, err := tsearch.TokenTypeFromString(m.TokenType)
+				if err != nil {
+					return nil, err
+				}
+				if !containsTokenType(tokenTypes, t) {
+					continue
+				}
+			}
+			for j := range m.Dictionaries {
+				if m.Dictionaries[j] == oldDict {
+					m.Dictionaries[j] = newDict
+				}
+			}
+		}
+
+	case tree.AlterTextSearchDropMapping:
+		for _, t := range tokenTypes {
+			i := findMapping(t)
+			if i < 0 {
+				if n.IfExists {
+					p.BufferClientNotice(ctx, pgnotice.Newf(
+						"mapping for token type %q does not exist, skipping", t.String()))
+					continue
+				}
+				return nil, pgerror.Newf(pgcode.UndefinedObject,
+					"mapping for token type %q does not exist", t.String())
+			}
+			c.Mappings = append(c.Mappings[:i], c.Mappings[i+1:]...)
+		}
+	}
+
+	sortTextSearchMappings(c.Mappings)
+	node := &textSearchSchemaChangeNode{n: n}
+	node.addChange(sc, func() {
+		sc.TextSearchConfigurations[obj.name] = c
+	})
+	return node, nil
+}
+
+// DropTextSearchObject drops text search configurations or dictionaries.
+// Dropping a dictionary with CASCADE also drops the configurations which use
+// it.
+func (p *planner) DropTextSearchObject(
+	ctx context.Context, n *tree.DropTextSearchObject,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP TEXT SEARCH "+n.Kind.String(),
+	); err != nil {
+		return nil, err
+	}
+	node := &textSearchSchemaChangeNode{n: n}
+	for _, name := range n.Names {
+		obj, err := p.lookupTextSearchObject(ctx, n.Kind, name)
+		if err != nil {
+			if n.IfExists && pgerror.GetPGCode(err) == pgcode.UndefinedObject {
+				p.BufferClientNotice(ctx, pgnotice.Newf("%s, skipping", err.Error()))
+				continue
+			}
+			return nil, err
+		}
+		sc, err := p.mutableTextSearchSchema(ctx, n.Kind, obj)
+		if err != nil {
+			return nil, err
+		}
+		if n.Kind == tree.TextSearchConfiguration {
+			node.addChange(sc, func() {
+				delete(sc.TextSearchConfigurations, obj.name)
+			})
+			continue
+		}
+
+		ref := descpb.SchemaDescriptor_TextSearchConfiguration_DictionaryReference{
+			SchemaID: sc.GetID(),
+			Name:     obj.name,
+		}
+		if err := p.forEachTextSearchConfigurationUsingDictionary(
+			ctx, obj.db, ref, func(configSC *schemadesc.Mutable, configName string) error {
+				qualifiedName := fmt.Sprintf("%s.%s",
+					tree.NameString(configSC.GetName()), tree.NameString(configName))
+				if n.DropBehavior != tree.DropCascade {
+					return pgerror.Newf(pgcode.DependentObjectsStillExist,
+						"cannot drop text search dictionary %s because text search configuration %s depends on it",
+						tree.ErrString(name), qualifiedName)
+				}
+				p.BufferClientNotice(ctx, pgnotice.Newf(
+					"drop cascades to text search configuration %s", qualifiedName))
+				node.addChange(configSC, func() {
+					delete(configSC.TextSearchConfigurations, configName)
+				})
+				return nil
+			},
+		); err != nil {
+			return nil, err
+		}
+		node.addChange(sc, func() {
+			delete(sc.TextSearchDictionaries, obj.name)
+		})
+	}
+	if len(node.descs) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return node, nil
+}
+
+// textSearchObject identifies a text search configuration or dictionary found
+// by lookupTextSearchObject.
+type textSearchObject struct {
+	db catalog.DatabaseDescriptor
+	// sc is nil for the builtin objects in pg_catalog.
+	sc   catalog.SchemaDescriptor
+	name string
+}
+
+// lookupTextSearchObject looks up the text search object with the given name.
+// Unqualified names are looked up in the schemas of the search path.
+func (p *planner) lookupTextSearchObject(
+	ctx context.Context, kind tree.TextSearchObjectKind, name *tree.UnresolvedObjectName,
+) (textSearchObject, error) {
+	obj := textSearchObject{name: name.Object()}
+	dbName := p.CurrentDatabase()
+	if name.HasExplicitCatalog() {
+		dbName = name.Catalog()
+	}
+	var schemas []string
+	if name.HasExplicitSchema() {
+		schemas = []string{name.Schema()}
+	} else {
+		iter := p.CurrentSearchPath().Iter()
+		for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+			schemas = append(schemas, scName)
+		}
+	}
+	for _, scName := range schemas {
+		if scName == catconstants.PgCatalogName {
+			var err error
+			if kind == tree.TextSearchConfiguration {
+				_, err = tsearch.BuiltinConfig(obj.name)
+			} else {
+				_, err = tsearch.BuiltinDictionary(obj.name)
+			}
+			if err == nil {
+				return obj, nil
+			}
+			continue
+		}
+		if obj.db == nil {
+			if dbName == "" {
+				continue
+			}
+			var err error
+			if obj.db, err = p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, dbName); err != nil {
+				return textSearchObject{}, err
+			}
+		}
+		sc, err := p.Descriptors().ByNameWithLeased(p.txn).MaybeGet().Schema(ctx, obj.db, scName)
+		if err != nil {
+			return textSearchObject{}, err
+		}
+		if sc == nil || sc.SchemaKind() != catalog.SchemaUserDefined {
+			continue
+		}
+		var found bool
+		if kind == tree.TextSearchConfiguration {
+			_, found = sc.SchemaDesc().TextSearchConfigurations[obj.name]
+		} else {
+			_, found = sc.SchemaDesc().TextSearchDictionaries[obj.name]
+		}
+		if found {
+			obj.sc = sc
+			return obj, nil
+		}
+	}
+	return textSearchObject{}, pgerror.Newf(pgcode.UndefinedObject,
+		"text search %s %q does not exist", strings.ToLower(kind.String()), tree.ErrString(name))
+}
+
+// mutableTextSearchSchema returns the mutable descriptor of the schema
+// containing the text search object, after checking that the current user
+// owns the object.
+func (p *planner) mutableTextSearchSchema(
+	ctx context.Context, kind tree.TextSearchObjectKind, obj textSearchObject,
+) (*schemadesc.Mutable, error) {
+	mustBeOwner := pgerror.Newf(pgcode.InsufficientPrivilege,
+		"must be owner of text search %s %s", strings.ToLower(kind.String()), tree.Name(obj.name))
+	if obj.sc == nil {
+		return nil, mustBeOwner
+	}
+	sc, err := p.Descriptors().MutableByID(p.txn).Schema(ctx, obj.sc.GetID())
+	if err != nil {
+		return nil, err
+	}
+	var owner username.SQLUsername
+	if kind == tree.TextSearchConfiguration {
+		owner = sc.TextSearchConfigurations[obj.name].OwnerProto.Decode()
+	} else {
+		owner = sc.TextSearchDictionaries[obj.name].OwnerProto.Decode()
+	}
+	if hasAdmin, err := p.HasAdminRole(ctx); err != nil {
+		return nil, err
+	} else if hasAdmin {
+		return sc, nil
+	}
+	isOwner, err := p.checkRolePredicate(ctx, p.User(), func(role username.SQLUsername) (bool, error) {
+		return role == owner, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, mustBeOwner
+	}
+	return sc, nil
+}
+
+// textSearchTargetSchema returns the mutable descriptor of the schema in which
+// a text search object with the given name is created.
+func (p *planner) textSearchTargetSchema(
+	ctx context.Context, name *tree.UnresolvedObjectName,
+) (*schemadesc.Mutable, error) {
+	db, sc, _, err := p.ResolveTargetObject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.canCreateOnSchema(ctx, sc.GetID(), db.GetID(), p.User(), checkPublicSchema); err != nil {
+		return nil, err
+	}
+	if sc.SchemaKind() == catalog.SchemaTemporary {
+		return nil, unimplemented.New("create text search object in temporary schema",
+			"cannot create text search objects in a temporary schema")
+	}
+	return p.Descriptors().MutableByID(p.txn).Schema(ctx, sc.GetID())
+}
+
+// textSearchDictionaryReference returns a reference to the text search
+// dictionary with the given name, which is used by a configuration in the
+// schema.
+func (p *planner) textSearchDictionaryReference(
+	ctx context.Context, sc *schemadesc.Mutable, name *tree.UnresolvedObjectName,
+) (descpb.SchemaDescriptor_TextSearchConfiguration_DictionaryReference, error) {
+	obj, err := p.lookupTextSearchObject(ctx, tree.TextSearchDictionary, name)
+	if err != nil {
+		return descpb.SchemaDescriptor_TextSearchConfiguration_DictionaryReference{}, err
+	}
+	if obj.sc == nil {
+		return descpb.SchemaDescriptor_TextSearchConfiguration_DictionaryReference{
+			SchemaID: catconstants.PgCatalogID,
+			Name:     obj.name,
+		}, nil
+	}
+	if obj.db.GetID() != sc.GetParentID() {
+		return descpb.SchemaDescriptor_TextSearchConfiguration_DictionaryReference{}, pgerror.Newf(
+			pgcode.FeatureNotSupported,
+			"text search dictionary %s cannot be from another database", tree.ErrString(name))
+	}
+	return descpb.SchemaDescriptor_TextSearchConfiguration_DictionaryReference{
+		SchemaID: obj.sc.GetID(),
+		Name:     obj.name,
+	}, nil
+}
+
+// forEachTextSearchConfigurationUsingDictionary calls fn with the schema and
+// name of each text search configuration in the database which uses the
+// dictionary.
+func (p *planner) forEachTextSearchConfigurationUsingDictionary(
+	ctx context.Context,
+	db catalog.DatabaseDescriptor,
+	dict descpb.SchemaDescriptor_TextSearchConfiguration_DictionaryReference,
+	fn func(sc *schemadesc.Mutable, configName string) error,
+) error {
+	schemas, err := p.Descriptors().GetAllSchemasInDatabase(ctx, p.txn, db)
+	if err != nil {
+		return err
+	}
+	return schemas.ForEachDescriptor(func(desc catalog.Descriptor) error {
+		sc, err := catalog.AsSchemaDescriptor(desc)
+		if err != nil {
+			return err
+		}
+		if sc.SchemaKind() != catalog.SchemaUserDefined {
+			return nil
+		}
+		var configNames []string
+		for configName, c := range sc.SchemaDesc().TextSearchConfigurations {
+			if textSearchConfigurationUsesDictionary(c, dict) {
+				configNames = append(configNames, configName)
+			}
+		}
+		if len(configNames) == 0 {
+			return nil
+		}
+		sort.Strings(configNames)
+		mut, err := p.Descriptors().MutableByID(p.txn).Schema(ctx, sc.GetID())
+		if err != nil {
+			return err
+		}
+		for _, configName := range configNames {
+			if err := fn(mut, configName); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func textSearchConfigurationUsesDictionary(
+	c descpb.SchemaDescriptor_TextSearchConfiguration,
+	dict descpb.SchemaDescriptor_TextSearchConfiguration_DictionaryReference,
+) bool {
+	for _, m := range c.Mappings {
+		for _, ref := range m.Dictionaries {
+			if ref == dict {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// copyTextSearchMappings returns a copy of the mappings of a text search
+// configuration which can be modified without changing the original.
+func copyTextSearchMappings(
+	mappings []descpb.SchemaDescriptor_TextSearchConfiguration_Mapping,
+) []descpb.SchemaDescriptor_TextSearchConfiguration_Mapping {
+	res := make([]descpb.SchemaDescriptor_TextSearchConfiguration_Mapping, len(mappings))
+	for i, m := range mappings {
+		res[i] = descpb.SchemaDescriptor_TextSearchConfiguration_Mapping{
+			TokenType: m.TokenType,
+			Dictionaries: append(
+				[]descpb.SchemaDescriptor_TextSearchConfiguration_DictionaryReference(nil), m.Dictionaries...,
+			),
+		}
+	}
+	return res
+}
+
+// sortTextSearchMappings sorts mappings by the ID of their token type, which
+// is the order in which pg_ts_config_map lists them.
+func sortTextSearchMappings(mappings []descpb.SchemaDescriptor_TextSearchConfiguration_Mapping) {
+	id := func(m descpb.SchemaDescriptor_TextSearchConfiguration_Mapping) tsearch.TokenType {
+		t, _ := tsearch.TokenTypeFromString(m.TokenType)
+		return t
+	}
+	sort.SliceStable(mappings, func(i, j int) bool {
+		return id(mappings[i]) < id(mappings[j])
+	})
+}
+
+func containsTokenType(tokenTypes []tsearch.TokenType, t tsearch.TokenType) bool {
+	for _, other := range tokenTypes {
+		if other == t {
+			return true
+		}
+	}
+	return false
+}
@@ -27,7 +27,7 @@ func (d *delegator) delegateShowClusterSettingList(
 	hasModify := false
 	hasSqlModify := false
 	hasView := false
-	if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.MODIFYCLUSTERSETTING); err == nil {
+	if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, d.catalog.GetCurrentUser(), privilege.MODIFYCLUSTERSETTING); err == nil {
 		hasModify = true
 		hasSqlModify = true
 		hasView = true
@@ -35,7 +35,7 @@ func (d *delegator) delegateShowClusterSettingList(
 		return nil, err
 	}
 	if !hasSqlModify {
-		if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.MODIFYSQLCLUSTERSETTING); err == nil {
+		if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, d.catalog.GetCurrentUser(), privilege.MODIFYSQLCLUSTERSETTING); err == nil {
 			hasSqlModify = true
 			hasView = true
 		} else if pgerror.GetPGCode(err) != pgcode.InsufficientPrivilege {
@@ -43,7 +43,7 @@ func (d *delegator) delegateShowClusterSettingList(
 		}
 	}
 	if !hasView {
-		if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.VIEWCLUSTERSETTING); err == nil {
+		if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, d.catalog.GetCurrentUser(), privilege.VIEWCLUSTERSETTING); err == nil {
 			hasView = true
 		} else if pgerror.GetPGCode(err) != pgcode.InsufficientPrivilege {
 			return nil, err
@@ -95,7 +95,7 @@ func (d *delegator) delegateShowTenantClusterSettingList(
 	// privileged operation than viewing local cluster settings. So we
 	// shouldn't be allowing with just the role option
 	// VIEWCLUSTERSETTINGS.
-	if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.VIEWCLUSTERMETADATA); err != nil {
+	if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, d.catalog.GetCurrentUser(), privilege.VIEWCLUSTERMETADATA); err != nil {
 		return nil, err
 	}
 
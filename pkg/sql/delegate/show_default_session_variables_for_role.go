@@ -29,20 +29,20 @@ func (d *delegator) delegateShowDefaultSessionVariablesForRole(
 
 	// Check if user has at least one of CREATEROLE, MODIFYCLUSTERSETTING, or MODIFYSQLCLUSTERSETTING privileges
 	hasPrivilege := false
-	if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.CREATEROLE); err == nil {
+	if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, d.catalog.GetCurrentUser(), privilege.CREATEROLE); err == nil {
 		hasPrivilege = true
 	} else if pgerror.GetPGCode(err) != pgcode.InsufficientPrivilege {
 		return nil, err
 	}
 	if !hasPrivilege {
-		if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.MODIFYCLUSTERSETTING); err == nil {
+		if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, d.catalog.GetCurrentUser(), privilege.MODIFYCLUSTERSETTING); err == nil {
 			hasPrivilege = true
 		} else if pgerror.GetPGCode(err) != pgcode.InsufficientPrivilege {
 			return nil, err
 		}
 	}
 	if !hasPrivilege {
-		if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.MODIFYSQLCLUSTERSETTING); err == nil {
+		if err := d.catalog.CheckPrivilege(d.ctx, syntheticprivilege.GlobalPrivilegeObject, d.catalog.GetCurrentUser(), privilege.MODIFYSQLCLUSTERSETTING); err == nil {
 			hasPrivilege = true
 		} else if pgerror.GetPGCode(err) != pgcode.InsufficientPrivilege {
 			return nil, err
@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -55,6 +56,9 @@ func (n *discardNode) startExec(params runParams) error {
 		// DEALLOCATE ALL
 		params.p.preparedStatements.DeleteAll(params.ctx)
 
+		// DISCARD PLANS is not needed since all the prepared statements were
+		// deallocated.
+
 		// DISCARD SEQUENCES
 		params.p.sessionDataMutatorIterator.applyOnEachMutator(func(m sessionDataMutator) {
 			m.data.SequenceState = sessiondata.NewSequenceState()
@@ -77,12 +81,29 @@ func (n *discardNode) startExec(params runParams) error {
 		if err != nil {
 			return err
 		}
+	case tree.DiscardModePlans:
+		// The plans of non-prepared statements are kept in the query cache,
+		// which is shared by all the sessions on the node. They are evicted with
+		// crdb_internal.evict_cached_plans instead.
+		for _, ps := range params.p.preparedStatements.List() {
+			ps.discardMemo = true
+		}
 	default:
 		return errors.AssertionFailedf("unknown mode for DISCARD: %d", n.mode)
 	}
 	return nil
 }
 
+// EvictCachedPlans is part of the eval.Planner interface.
+func (p *planner) EvictCachedPlans(ctx context.Context, tableID int64) (int64, error) {
+	resp, err := p.extendedEvalCtx.SQLStatusServer.EvictCachedPlans(ctx,
+		&serverpb.EvictCachedPlansRequest{TableID: uint32(tableID)})
+	if err != nil {
+		return 0, err
+	}
+	return resp.EvictedCount, nil
+}
+
 func deleteTempTables(ctx context.Context, p *planner) error {
 	// If this session has no temp schemas, then there is nothing to do here.
 	// This is the common case.
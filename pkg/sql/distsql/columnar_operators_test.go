@@ -1287,6 +1287,9 @@ func TestWindowFunctionsAgainstProcessor(t *testing.T) {
 
 	for windowFnIdx := 0; windowFnIdx < len(execinfrapb.WindowerSpec_WindowFunc_name); windowFnIdx++ {
 		windowFn := execinfrapb.WindowerSpec_WindowFunc(windowFnIdx)
+		if !colexecwindow.WindowFnIsSupported(windowFn) {
+			continue
+		}
 		var argTypes []*types.T
 		randArgType := types.Int
 		if rand.Float64() < randTypesProbability {
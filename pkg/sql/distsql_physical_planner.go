@@ -3212,6 +3212,13 @@ func (dsp *DistSQLPlanner) createPlanForLookupJoin(
 		}
 	}
 
+	// Allow the vectorized engine to switch to a hash join if the lookup join
+	// ends up reading more input rows than the threshold.
+	if t := planCtx.ExtendedEvalCtx.SessionData().AdaptiveJoinRowThreshold; t > 0 &&
+		joinReaderSpec.CanBeAdaptive(inputTypes) {
+		joinReaderSpec.AdaptiveJoinThreshold = uint64(t)
+	}
+
 	// Instantiate one join reader for every stream. This is also necessary for
 	// correctness of paired-joins where this join is the second join -- it is
 	// necessary to have a one-to-one relationship between the first and second
@@ -3222,6 +3229,19 @@ func (dsp *DistSQLPlanner) createPlanForLookupJoin(
 		outTypes,
 		dsp.convertOrdering(planReqOrdering(n), planToStreamColMap),
 	)
+	if joinReaderSpec.AdaptiveJoinThreshold > 0 && len(plan.ResultRouters) > 1 {
+		// Every join reader which switches to a hash join scans the whole
+		// lookup index, most of which is remote to the join readers on other
+		// nodes. When there are several streams, only the join readers on the
+		// gateway may switch, and the others keep performing lookups.
+		lookupSpec := joinReaderSpec
+		lookupSpec.AdaptiveJoinThreshold = 0
+		for _, pIdx := range plan.ResultRouters {
+			if proc := &plan.Processors[pIdx]; proc.SQLInstanceID != plan.GatewaySQLInstanceID {
+				proc.Spec.Core.JoinReader = &lookupSpec
+			}
+		}
+	}
 	plan.PlanToStreamColMap = planToStreamColMap
 	return plan, nil
 }
@@ -92,6 +92,7 @@ func PlanCDCExpression(
 	p.curPlan.init(&p.stmt, &p.instrumentation)
 	opc := &p.optPlanningCtx
 	opc.init(p)
+	opc.parseHints(ctx)
 	opc.reset(ctx)
 	opc.useCache = false
 	opc.allowMemoReuse = false
@@ -45,6 +45,9 @@ type requestedStat struct {
 	histogramMaxBuckets uint32
 	name                string
 	inverted            bool
+	// expression is the serialized expression of a statistic on an
+	// expression, in which case columns are the columns it references.
+	expression string
 }
 
 // histogramSamples is the number of sample rows to be collected for histogram
@@ -243,7 +246,7 @@ func (dsp *DistSQLPlanner) createPartialStatsPlan(
 	// be the latest due to the newest to oldest ordering property of the cache.
 	for _, t := range tableStats {
 		if len(t.ColumnIDs) == 1 && column.GetID() == t.ColumnIDs[0] &&
-			!t.IsPartial() && !t.IsMerged() && !t.IsForecast() {
+			!t.IsPartial() && !t.IsMerged() && !t.IsForecast() && !t.IsExpression() {
 			if t.HistogramData == nil || t.HistogramData.ColumnType == nil || len(t.Histogram) == 0 {
 				return nil, pgerror.Newf(
 					pgcode.ObjectNotInPrerequisiteState,
@@ -451,8 +454,17 @@ func (dsp *DistSQLPlanner) createStatsPlan(
 		}
 	}
 
-	// Add rendering of virtual computed columns.
-	if len(virtComputedCols) != 0 {
+	var numExprStats int
+	for _, s := range reqStats {
+		if s.expression != "" {
+			numExprStats++
+		}
+	}
+
+	// Add rendering of virtual computed columns and of statistics expressions.
+	// The rendered expressions are output after the requested columns.
+	exprStatIdx := make([]int, len(reqStats))
+	if len(virtComputedCols) != 0 || numExprStats != 0 {
 		// Resolve names and types.
 		virtComputedExprs, _, err := schemaexpr.MakeComputedExprs(
 			ctx,
@@ -468,7 +480,7 @@ func (dsp *DistSQLPlanner) createStatsPlan(
 		}
 
 		// Build render expressions for all requested columns.
-		exprs := make(tree.TypedExprs, len(requestedCols))
+		exprs := make(tree.TypedExprs, len(requestedCols), len(requestedCols)+numExprStats)
 		resultCols := colinfo.ResultColumnsFromColumns(desc.GetID(), requestedCols)
 
 		ivh := tree.MakeIndexedVarHelper(nil /* container */, len(scan.cols))
@@ -503,6 +515,29 @@ func (dsp *DistSQLPlanner) createStatsPlan(
 			}
 		}
 
+		// Statistics expressions only reference scanned columns, so they are
+		// rendered directly over the scan.
+		for i, s := range reqStats {
+			if s.expression == "" {
+				continue
+			}
+			expr, err := schemaexpr.MakeStatisticExpr(
+				ctx, s.expression, scan.cols, desc, planCtx.EvalContext(), semaCtx,
+			)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkExprForDistSQL(expr, &distSQLVisitor); err != nil {
+				return nil, err
+			}
+			exprStatIdx[i] = len(exprs)
+			exprs = append(exprs, expr)
+			resultCols = append(resultCols, colinfo.ResultColumn{
+				Name: s.expression,
+				Typ:  expr.ResolvedType(),
+			})
+		}
+
 		var rb renderBuilder
 		rb.init(exec.Node(planNode(&scan)), exec.OutputOrdering{})
 		rb.setOutput(exprs, resultCols)
@@ -530,8 +565,8 @@ func (dsp *DistSQLPlanner) createStatsPlan(
 	}
 
 	var sketchSpecs, invSketchSpecs []execinfrapb.SketchSpec
-	sampledColumnIDs := make([]descpb.ColumnID, len(requestedCols))
-	for _, s := range reqStats {
+	sampledColumnIDs := make([]descpb.ColumnID, len(requestedCols)+numExprStats)
+	for reqIdx, s := range reqStats {
 		spec := execinfrapb.SketchSpec{
 			SketchType:          execinfrapb.SketchType_HLL_PLUS_PLUS_V1,
 			GenerateHistogram:   s.histogram,
@@ -539,6 +574,14 @@ func (dsp *DistSQLPlanner) createStatsPlan(
 			Columns:             make([]uint32, len(s.columns)),
 			StatName:            s.name,
 		}
+		if s.expression != "" {
+			// The sketch is on the rendered expression, which has no column ID.
+			spec.Columns = []uint32{uint32(p.PlanToStreamColMap[exprStatIdx[reqIdx]])}
+			spec.Expression = s.expression
+			spec.ExpressionColumnIDs = s.columns
+			sketchSpecs = append(sketchSpecs, spec)
+			continue
+		}
 		for i, colID := range s.columns {
 			colIdx, ok := colIdxMap.Get(colID)
 			if !ok {
@@ -615,12 +658,16 @@ func (dsp *DistSQLPlanner) createPlanForCreateStats(
 		if details.ColumnStats[i].Inverted && details.UsingExtremes {
 			return nil, pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "cannot create partial statistics on an inverted index column")
 		}
+		if details.ColumnStats[i].Expression != "" && details.UsingExtremes {
+			return nil, pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "cannot create partial statistics on an expression")
+		}
 		reqStats[i] = requestedStat{
 			columns:             details.ColumnStats[i].ColumnIDs,
 			histogram:           histogram,
 			histogramMaxBuckets: histogramMaxBuckets,
 			name:                details.Name,
 			inverted:            details.ColumnStats[i].Inverted,
+			expression:          details.ColumnStats[i].Expression,
 		}
 	}
 
@@ -202,21 +202,33 @@ func (d *dropCascadeState) resolveCollectedObjects(
 			// legacy schema changer world return an error. The declarative schema changer
 			// knows how to handle function cascades.
 			var idsInOtherSchemas []descpb.ID
+			var castsInOtherSchemas []string
 			for _, dependedOnBy := range fn.DependedOnBy {
 				dependedOnByDesc, err := p.Descriptors().ByID(p.Txn()).Get().Desc(ctx, dependedOnBy.ID)
 				if err != nil {
 					return err
 				}
-				if dependedOnByDesc.GetParentSchemaID() != fn.ParentSchemaID {
-					idsInOtherSchemas = append(idsInOtherSchemas, dependedOnBy.ID)
+				if dependedOnByDesc.GetParentSchemaID() == fn.ParentSchemaID {
+					continue
 				}
+				// Types depend on the function through the casts stored on them.
+				if typDesc, ok := dependedOnByDesc.(catalog.TypeDescriptor); ok {
+					castNames, err := p.castsOnTypeUsingFunction(ctx, typDesc, fn)
+					if err != nil {
+						return err
+					}
+					castsInOtherSchemas = append(castsInOtherSchemas, castNames...)
+					continue
+				}
+				idsInOtherSchemas = append(idsInOtherSchemas, dependedOnBy.ID)
 			}
 
-			if len(idsInOtherSchemas) > 0 {
+			if len(idsInOtherSchemas) > 0 || len(castsInOtherSchemas) > 0 {
 				fullyQualifiedNames, err := p.getFullyQualifiedNamesFromIDs(ctx, idsInOtherSchemas)
 				if err != nil {
 					return err
 				}
+				fullyQualifiedNames = append(fullyQualifiedNames, castsInOtherSchemas...)
 				return pgerror.Newf(
 					pgcode.DependentObjectsStillExist,
 					"cannot drop function %q because other object ([%v]) still depend on it",
@@ -86,6 +86,11 @@ func (p *planner) DropFunction(ctx context.Context, n *tree.DropRoutine) (ret pl
 			if err != nil {
 				return nil, err
 			}
+			castNames, err := p.castsUsingFunction(ctx, mut)
+			if err != nil {
+				return nil, err
+			}
+			depNames = append(depNames, castNames...)
 			return nil, pgerror.Newf(
 				pgcode.DependentObjectsStillExist,
 				"cannot drop function %q because other objects ([%v]) still depend on it",
@@ -242,6 +247,12 @@ func (p *planner) dropFunctionImpl(ctx context.Context, fnMutable *funcdesc.Muta
 		}
 	}
 
+	// Drop the casts performed by this UDF. Unless the drop cascades, it was
+	// rejected when planned if there were any.
+	if err := p.dropCastsUsingFunction(ctx, fnMutable); err != nil {
+		return err
+	}
+
 	// Remove backreference from types referenced by this UDF.
 	jobDesc := fmt.Sprintf(
 		"updating type backreference %v for function %s(%d)",
@@ -16,6 +16,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 )
 
 // Builtins implements methods to evaluate logic that depends on having
@@ -32,6 +34,13 @@ type Builtins struct {
 	codec keys.SQLCodec
 	dc    *descs.Collection
 	txn   *kv.Txn
+
+	// textSearchDictionaries caches the user-defined text search dictionaries
+	// used by the text search builtins.
+	textSearchDictionaries struct {
+		syncutil.Mutex
+		m map[textSearchDictionaryKey]*tsearch.Dictionary
+	}
 }
 
 // Init initializes the fields of a Builtins. The object should not be used
@@ -40,4 +49,7 @@ func (ec *Builtins) Init(codec keys.SQLCodec, txn *kv.Txn, descriptors *descs.Co
 	ec.codec = codec
 	ec.txn = txn
 	ec.dc = descriptors
+	ec.textSearchDictionaries.Lock()
+	defer ec.textSearchDictionaries.Unlock()
+	ec.textSearchDictionaries.m = nil
 }
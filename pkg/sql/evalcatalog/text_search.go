@@ -0,0 +1,215 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package evalcatalog
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+)
+
+// textSearchDictionaryKey identifies a version of a user-defined text search
+// dictionary.
+type textSearchDictionaryKey struct {
+	schemaID descpb.ID
+	version  descpb.DescriptorVersion
+	name     string
+}
+
+// ResolveTextSearchConfig is part of the eval.CatalogBuiltins interface.
+func (b *Builtins) ResolveTextSearchConfig(
+	ctx context.Context, name *tree.UnresolvedObjectName, sd *sessiondata.SessionData,
+) (*tsearch.Config, error) {
+	var config *tsearch.Config
+	found, err := b.lookupTextSearchObject(ctx, name, sd, func(sc catalog.SchemaDescriptor) (bool, error) {
+		if sc == nil {
+			var err error
+			config, err = tsearch.BuiltinConfig(name.Object())
+			return err == nil, nil //nolint:returnerrcheck
+		}
+		c, ok := sc.SchemaDesc().TextSearchConfigurations[name.Object()]
+		if !ok {
+			return false, nil
+		}
+		var err error
+		config, err = b.makeTextSearchConfig(ctx, c)
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search configuration %q does not exist", tree.ErrString(name))
+	}
+	return config, nil
+}
+
+// ResolveTextSearchDictionary is part of the eval.CatalogBuiltins interface.
+func (b *Builtins) ResolveTextSearchDictionary(
+	ctx context.Context, name *tree.UnresolvedObjectName, sd *sessiondata.SessionData,
+) (*tsearch.Dictionary, error) {
+	var dict *tsearch.Dictionary
+	found, err := b.lookupTextSearchObject(ctx, name, sd, func(sc catalog.SchemaDescriptor) (bool, error) {
+		if sc == nil {
+			var err error
+			dict, err = tsearch.BuiltinDictionary(name.Object())
+			return err == nil, nil //nolint:returnerrcheck
+		}
+		if _, ok := sc.SchemaDesc().TextSearchDictionaries[name.Object()]; !ok {
+			return false, nil
+		}
+		var err error
+		dict, err = b.textSearchDictionary(sc, name.Object())
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", tree.ErrString(name))
+	}
+	return dict, nil
+}
+
+// lookupTextSearchObject calls lookup with each schema in which a text search
+// object with the given name may be found, until it returns true. Unqualified
+// names are looked up in the schemas of the search path. The schema is nil for
+// pg_catalog, which contains the builtin objects.
+func (b *Builtins) lookupTextSearchObject(
+	ctx context.Context,
+	name *tree.UnresolvedObjectName,
+	sd *sessiondata.SessionData,
+	lookup func(sc catalog.SchemaDescriptor) (bool, error),
+) (found bool, _ error) {
+	dbName := sd.Database
+	if name.HasExplicitCatalog() {
+		dbName = name.Catalog()
+	}
+	var schemas []string
+	if name.HasExplicitSchema() {
+		schemas = []string{name.Schema()}
+	} else {
+		iter := sd.SearchPath.Iter()
+		for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+			schemas = append(schemas, scName)
+		}
+	}
+	var db catalog.DatabaseDescriptor
+	for _, scName := range schemas {
+		if scName == catconstants.PgCatalogName {
+			if found, err := lookup(nil /* sc */); err != nil || found {
+				return found, err
+			}
+			continue
+		}
+		if db == nil {
+			if dbName == "" {
+				continue
+			}
+			var err error
+			db, err = b.dc.ByNameWithLeased(b.txn).Get().Database(ctx, dbName)
+			if err != nil {
+				return false, err
+			}
+		}
+		sc, err := b.dc.ByNameWithLeased(b.txn).MaybeGet().Schema(ctx, db, scName)
+		if err != nil {
+			return false, err
+		}
+		if sc == nil || sc.SchemaKind() == catalog.SchemaVirtual {
+			continue
+		}
+		if found, err := lookup(sc); err != nil || found {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+// makeTextSearchConfig returns the configuration described by the descriptor
+// of a user-defined text search configuration.
+func (b *Builtins) makeTextSearchConfig(
+	ctx context.Context, c descpb.SchemaDescriptor_TextSearchConfiguration,
+) (*tsearch.Config, error) {
+	config := tsearch.NewConfig()
+	for _, m := range c.Mappings {
+		tokenType, err := tsearch.TokenTypeFromString(m.TokenType)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range m.Dictionaries {
+			var dict *tsearch.Dictionary
+			if ref.SchemaID == catconstants.PgCatalogID {
+				dict, err = tsearch.BuiltinDictionary(ref.Name)
+			} else {
+				var sc catalog.SchemaDescriptor
+				sc, err = b.dc.ByIDWithLeased(b.txn).WithoutNonPublic().Get().Schema(ctx, ref.SchemaID)
+				if err == nil {
+					dict, err = b.textSearchDictionary(sc, ref.Name)
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+			config.AddMapping(tokenType, dict)
+		}
+	}
+	return config, nil
+}
+
+// textSearchDictionary returns the user-defined text search dictionary with
+// the given name in the schema. Dictionaries are cached, since building them
+// may require parsing long lists of words.
+func (b *Builtins) textSearchDictionary(
+	sc catalog.SchemaDescriptor, name string,
+) (*tsearch.Dictionary, error) {
+	key := textSearchDictionaryKey{schemaID: sc.GetID(), version: sc.GetVersion(), name: name}
+	b.textSearchDictionaries.Lock()
+	defer b.textSearchDictionaries.Unlock()
+	if dict, ok := b.textSearchDictionaries.m[key]; ok {
+		return dict, nil
+	}
+	d, ok := sc.SchemaDesc().TextSearchDictionaries[name]
+	if !ok {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", name)
+	}
+	dict, err := MakeTextSearchDictionary(d)
+	if err != nil {
+		return nil, err
+	}
+	if b.textSearchDictionaries.m == nil {
+		b.textSearchDictionaries.m = make(map[textSearchDictionaryKey]*tsearch.Dictionary)
+	}
+	b.textSearchDictionaries.m[key] = dict
+	return dict, nil
+}
+
+// MakeTextSearchDictionary returns the dictionary described by the descriptor
+// of a user-defined text search dictionary.
+func MakeTextSearchDictionary(
+	d descpb.SchemaDescriptor_TextSearchDictionary,
+) (*tsearch.Dictionary, error) {
+	options := make([]tsearch.DictionaryOption, len(d.Options))
+	for i, opt := range d.Options {
+		options[i] = tsearch.DictionaryOption{Name: opt.Name, Value: opt.Value}
+	}
+	return tsearch.NewDictionary(d.Template, options)
+}
@@ -86,6 +86,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirecancel"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/planbaseline"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
@@ -777,6 +778,18 @@ var (
 		Measurement: "SQL Statements",
 		Unit:        metric.Unit_COUNT,
 	}
+	MetaSQLOptPlanBaselineHits = metric.Metadata{
+		Name:        "sql.optimizer.plan_baseline.hits",
+		Help:        "Number of statements planned with a pinned plan baseline",
+		Measurement: "SQL Statements",
+		Unit:        metric.Unit_COUNT,
+	}
+	MetaSQLOptPlanBaselineMismatches = metric.Metadata{
+		Name:        "sql.optimizer.plan_baseline.mismatches",
+		Help:        "Number of statements with a pinned plan baseline whose plan did not match the baseline",
+		Measurement: "SQL Statements",
+		Unit:        metric.Unit_COUNT,
+	}
 	MetaDistSQLSelect = metric.Metadata{
 		Name:        "sql.distsql.select.count",
 		Help:        "Number of DistSQL SELECT statements",
@@ -1277,6 +1290,7 @@ type ExecutorConfig struct {
 	TableStatsCache    *stats.TableStatisticsCache
 	StatsRefresher     *stats.Refresher
 	QueryCache         *querycache.C
+	PlanBaselines      *planbaseline.Registry
 
 	SchemaChangerMetrics *SchemaChangerMetrics
 	FeatureFlagMetrics   *featureflag.DenialMetrics
@@ -3587,6 +3601,10 @@ func (m *sessionDataMutator) SetParallelizeMultiKeyLookupJoinsEnabled(val bool)
 	m.data.ParallelizeMultiKeyLookupJoinsEnabled = val
 }
 
+func (m *sessionDataMutator) SetAdaptiveJoinRowThreshold(val int64) {
+	m.data.AdaptiveJoinRowThreshold = val
+}
+
 // TODO(harding): Remove this when costing scans based on average column size
 // is fully supported.
 func (m *sessionDataMutator) SetCostScansWithDefaultColSize(val bool) {
@@ -3625,6 +3643,14 @@ func (m *sessionDataMutator) SetTrigramSimilarityThreshold(val float64) {
 	m.data.TrigramSimilarityThreshold = val
 }
 
+func (m *sessionDataMutator) SetTrigramWordSimilarityThreshold(val float64) {
+	m.data.TrigramWordSimilarityThreshold = val
+}
+
+func (m *sessionDataMutator) SetTrigramStrictWordSimilarityThreshold(val float64) {
+	m.data.TrigramStrictWordSimilarityThreshold = val
+}
+
 func (m *sessionDataMutator) SetUnconstrainedNonCoveringIndexScanEnabled(val bool) {
 	m.data.UnconstrainedNonCoveringIndexScanEnabled = val
 }
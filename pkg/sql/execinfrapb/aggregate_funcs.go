@@ -78,4 +78,6 @@ const (
 	MergeStatementStats         = AggregatorSpec_MERGE_STATEMENT_STATS
 	MergeTransactionStats       = AggregatorSpec_MERGE_TRANSACTION_STATS
 	MergeAggregatedStmtMetadata = AggregatorSpec_MERGE_AGGREGATED_STMT_METADATA
+	StClusterWithin             = AggregatorSpec_ST_CLUSTERWITHIN
+	StPolygonize                = AggregatorSpec_ST_POLYGONIZE
 )
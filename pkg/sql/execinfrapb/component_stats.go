@@ -215,6 +215,14 @@ func (s *ComponentStats) formatStats(fn func(suffix string, value interface{}))
 		fn("sql cpu time", humanizeutil.Duration(s.Exec.CPUTime.Value()))
 	}
 
+	// Adaptive join stats.
+	if s.AdaptiveJoin.LookupRows.HasValue() {
+		fn("adaptive join lookup rows", humanizeutil.Count(s.AdaptiveJoin.LookupRows.Value()))
+	}
+	if s.AdaptiveJoin.HashRows.HasValue() {
+		fn("adaptive join hash rows", humanizeutil.Count(s.AdaptiveJoin.HashRows.Value()))
+	}
+
 	// Output stats.
 	if s.Output.NumBatches.HasValue() {
 		fn("batches output", humanizeutil.Count(s.Output.NumBatches.Value()))
@@ -338,6 +346,14 @@ func (s *ComponentStats) Union(other *ComponentStats) *ComponentStats {
 		result.Exec.CPUTime = other.Exec.CPUTime
 	}
 
+	// Adaptive join stats.
+	if !result.AdaptiveJoin.LookupRows.HasValue() {
+		result.AdaptiveJoin.LookupRows = other.AdaptiveJoin.LookupRows
+	}
+	if !result.AdaptiveJoin.HashRows.HasValue() {
+		result.AdaptiveJoin.HashRows = other.AdaptiveJoin.HashRows
+	}
+
 	// Output stats.
 	if !result.Output.NumBatches.HasValue() {
 		result.Output.NumBatches = other.Output.NumBatches
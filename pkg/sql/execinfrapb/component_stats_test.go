@@ -309,6 +309,22 @@ max sql temp disk usage: 1.0 KiB
 batches output: 10
 rows output: 100`,
 		},
+		{ // 4
+			a: ComponentStats{
+				AdaptiveJoin: AdaptiveJoinStats{
+					LookupRows: optional.MakeUint(100),
+				},
+			},
+			b: ComponentStats{
+				AdaptiveJoin: AdaptiveJoinStats{
+					LookupRows: optional.MakeUint(10),
+					HashRows:   optional.MakeUint(1000),
+				},
+			},
+			expected: `
+adaptive join lookup rows: 100
+adaptive join hash rows: 1,000`,
+		},
 	}
 
 	for i, tc := range testCases {
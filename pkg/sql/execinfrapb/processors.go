@@ -16,12 +16,14 @@ import (
 	"unsafe"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/errors"
 )
@@ -381,6 +383,80 @@ func (spec *JoinReaderSpec) IsIndexJoin() bool {
 	return len(spec.LookupColumns) == 0 && spec.LookupExpr.Empty()
 }
 
+// IsAdaptiveLookupJoin returns true if spec defines a lookup join that can
+// switch to a hash join at runtime (see AdaptiveJoinThreshold).
+func (spec *JoinReaderSpec) IsAdaptiveLookupJoin(inputTypes []*types.T) bool {
+	return spec.AdaptiveJoinThreshold > 0 && spec.CanBeAdaptive(inputTypes)
+}
+
+// CanBeAdaptive returns true if the lookup join defined by spec can switch to
+// a hash join against a full scan of the index at runtime. This is the case
+// when the lookup join can be performed as a hash join with LookupColumns as
+// the left equality columns and the corresponding index columns as the right
+// equality columns.
+func (spec *JoinReaderSpec) CanBeAdaptive(inputTypes []*types.T) bool {
+	if len(spec.LookupColumns) == 0 || !spec.LookupExpr.Empty() || !spec.RemoteLookupExpr.Empty() {
+		return false
+	}
+	if spec.MaintainOrdering || spec.LeftJoinWithPairedJoiner || spec.OutputGroupContinuationForLeftRow {
+		return false
+	}
+	if spec.LockingStrength != descpb.ScanLockingStrength_FOR_NONE {
+		return false
+	}
+	switch spec.Type {
+	case descpb.InnerJoin:
+	case descpb.LeftOuterJoin, descpb.LeftSemiJoin, descpb.LeftAntiJoin:
+		// The vectorized hash joiner only supports ON expressions for inner
+		// joins.
+		if !spec.OnExpr.Empty() {
+			return false
+		}
+	default:
+		return false
+	}
+	keyOrds, ok := spec.LookupKeyOrdinals()
+	if !ok {
+		return false
+	}
+	for i, col := range spec.LookupColumns {
+		if int(col) >= len(inputTypes) ||
+			!inputTypes[col].Identical(spec.FetchSpec.FetchedColumns[keyOrds[i]].Type) {
+			return false
+		}
+		// Values of composite types that are equal according to the key
+		// encoding (like 1.0 and 1.00) might not be equal in the hash join.
+		if spec.FetchSpec.KeyAndSuffixColumns[i].IsComposite {
+			return false
+		}
+	}
+	return true
+}
+
+// LookupKeyOrdinals returns the ordinals among the fetched columns of the
+// index columns that LookupColumns are looked up in. ok is false if any of
+// them is not fetched.
+func (spec *JoinReaderSpec) LookupKeyOrdinals() (_ []uint32, ok bool) {
+	if len(spec.LookupColumns) > len(spec.FetchSpec.KeyAndSuffixColumns) {
+		return nil, false
+	}
+	ords := make([]uint32, len(spec.LookupColumns))
+	for i := range spec.LookupColumns {
+		colID := spec.FetchSpec.KeyAndSuffixColumns[i].ColumnID
+		found := false
+		for j := range spec.FetchSpec.FetchedColumns {
+			if spec.FetchSpec.FetchedColumns[j].ColumnID == colID {
+				ords[i], found = uint32(j), true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return ords, true
+}
+
 // init performs some sanity checks for the invariants required by the
 // upperBuffer type.
 func init() {
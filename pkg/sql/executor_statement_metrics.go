@@ -33,6 +33,10 @@ type EngineMetrics struct {
 	SQLOptFallbackCount   *metric.Counter
 	SQLOptPlanCacheHits   *metric.Counter
 	SQLOptPlanCacheMisses *metric.Counter
+	// The statements planned with a plan baseline, and the subset of them
+	// whose plan did not match the baseline.
+	SQLOptPlanBaselineHits       *metric.Counter
+	SQLOptPlanBaselineMismatches *metric.Counter
 
 	DistSQLExecLatency    metric.IHistogram
 	SQLExecLatency        metric.IHistogram
@@ -316,6 +320,12 @@ func (ex *connExecutor) updateOptCounters(planFlags planFlags) {
 	} else if planFlags.IsSet(planFlagOptCacheMiss) {
 		m.SQLOptPlanCacheMisses.Inc(1)
 	}
+	if planFlags.IsSet(planFlagPlanBaselineUsed) {
+		m.SQLOptPlanBaselineHits.Inc(1)
+		if planFlags.IsSet(planFlagPlanBaselineMismatch) {
+			m.SQLOptPlanBaselineMismatches.Inc(1)
+		}
+	}
 }
 
 // We only want to keep track of DML (Data Manipulation Language) statements in our latency metrics.
@@ -50,12 +50,30 @@ type explainPlanNodeRun struct {
 
 func (e *explainPlanNode) startExec(params runParams) error {
 	ob := explain.NewOutputBuilder(e.flags)
-	plan := e.plan.WrappedPlan.(*planComponents)
 
 	var rows []string
 	if e.options.Mode == tree.ExplainGist {
 		rows = []string{e.plan.Gist.String()}
+	} else if len(e.options.HypotheticalIndexes) > 0 {
+		// The plan was built without planNodes (see ConstructExplain), so it
+		// cannot be distributed or vectorized.
+		if err := emitExplain(params.ctx, ob, params.EvalContext(), params.p.ExecCfg().Codec, e.plan); err != nil {
+			return err
+		}
+		rows = ob.BuildStringRows()
+		rows = append(rows, "")
+		rows = append(rows, fmt.Sprintf("hypothetical index changes: %d", len(e.options.HypotheticalIndexes)))
+		for i, stmt := range e.options.HypotheticalIndexes {
+			rows = append(rows, fmt.Sprintf("%d. %s", i+1, tree.AsString(stmt)))
+		}
+		rows = append(rows, fmt.Sprintf(
+			"estimated cost: %.2f (without index changes: %.2f)",
+			params.p.instrumentation.explainHypotheticalCost,
+			params.p.instrumentation.explainOriginalCost,
+		))
 	} else {
+		plan := e.plan.WrappedPlan.(*planComponents)
+
 		// Determine the "distribution" and "vectorized" values, which we will emit as
 		// special rows.
 
@@ -209,6 +227,9 @@ func emitExplain(
 		if table.IsVirtualTable() {
 			return "<virtual table spans>"
 		}
+		if table.IsHypothetical() {
+			return "<hypothetical table spans>"
+		}
 		tabDesc := table.(*optTable).desc
 		idx := index.(*optIndex).idx
 		spans, err := generateScanSpans(evalCtx, codec, tabDesc, idx, scanParams)
@@ -269,7 +290,10 @@ func closeExplainPlan(ctx context.Context, ep *explain.Plan) {
 }
 
 func (e *explainPlanNode) Close(ctx context.Context) {
-	closeExplainPlan(ctx, e.plan)
+	// Plans with hypothetical index changes are built without planNodes.
+	if e.plan.WrappedPlan != nil {
+		closeExplainPlan(ctx, e.plan)
+	}
 	if e.run.results != nil {
 		e.run.results.Close(ctx)
 	}
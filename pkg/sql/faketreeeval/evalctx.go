@@ -310,6 +310,30 @@ func (*DummyEvalPlanner) IsConstraintActive(
 	return false, errors.WithStack(errEvalPlanner)
 }
 
+// MaterializedViewLastRefresh is part of the EvalPlanner interface.
+func (*DummyEvalPlanner) MaterializedViewLastRefresh(
+	ctx context.Context, viewID int,
+) (hlc.Timestamp, bool, error) {
+	return hlc.Timestamp{}, false, errors.WithStack(errEvalPlanner)
+}
+
+// EvictCachedPlans is part of the Planner interface.
+func (*DummyEvalPlanner) EvictCachedPlans(ctx context.Context, tableID int64) (int64, error) {
+	return 0, errors.WithStack(errEvalPlanner)
+}
+
+// PinPlan is part of the Planner interface.
+func (*DummyEvalPlanner) PinPlan(
+	ctx context.Context, fingerprint, planGist string, enforce bool,
+) (bool, error) {
+	return false, errors.WithStack(errEvalPlanner)
+}
+
+// UnpinPlan is part of the Planner interface.
+func (*DummyEvalPlanner) UnpinPlan(ctx context.Context, fingerprint string) (bool, error) {
+	return false, errors.WithStack(errEvalPlanner)
+}
+
 // ValidateTTLScheduledJobsInCurrentDB is part of the Planner interface.
 func (*DummyEvalPlanner) ValidateTTLScheduledJobsInCurrentDB(ctx context.Context) error {
 	return errors.WithStack(errEvalPlanner)
@@ -251,7 +251,8 @@ func (r *insertFastPathRun) addFKChecks(
 				return c.errorForRow(inputRow)
 			}
 			// We have a row with only NULLS, or a row with some NULLs and match
-			// method PARTIAL. We can skip this FK check for this row.
+			// method SIMPLE. We can skip this FK check for this row. (MATCH PARTIAL
+			// FKs are never checked in the fast path.)
 			continue
 		}
 
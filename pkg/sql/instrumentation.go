@@ -42,6 +42,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/sslocal"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/stmtdiagnostics"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
 	"github.com/cockroachdb/cockroach/pkg/util/fsm"
@@ -176,6 +177,11 @@ type instrumentationHelper struct {
 	// explainIndexRecs contains index recommendations for EXPLAIN statements.
 	explainIndexRecs []indexrec.Rec
 
+	// explainOriginalCost and explainHypotheticalCost are the estimated costs of
+	// the optimal plan of an EXPLAIN (HYPOTHETICAL INDEXES ...) statement without
+	// and with its hypothetical index changes.
+	explainOriginalCost, explainHypotheticalCost float64
+
 	// maxFullScanRows is the maximum number of rows scanned by a full scan, as
 	// estimated by the optimizer.
 	maxFullScanRows float64
@@ -999,6 +1005,8 @@ func (m execNodeTraceMetadata) annotateExplain(
 				nodeStats.VectorizedBatchCount.MaybeAdd(stats.Output.NumBatches)
 				nodeStats.MaxAllocatedMem.MaybeAdd(stats.Exec.MaxAllocatedMem)
 				nodeStats.MaxAllocatedDisk.MaybeAdd(stats.Exec.MaxAllocatedDisk)
+				nodeStats.AdaptiveJoinLookupRows.MaybeAdd(stats.AdaptiveJoin.LookupRows)
+				nodeStats.AdaptiveJoinHashRows.MaybeAdd(stats.AdaptiveJoin.HashRows)
 				if noMutations && !makeDeterministic {
 					// Currently we cannot separate SQL CPU time from local KV CPU time
 					// for mutations, since they do not collect statistics. Additionally,
@@ -1054,6 +1062,22 @@ func (m execNodeTraceMetadata) annotateExplain(
 	}
 }
 
+// notifyMisestimates notifies the stats refresher of the filters whose
+// selectivity was misestimated according to the execution statistics that the
+// explain plan was annotated with.
+func (ih *instrumentationHelper) notifyMisestimates(cfg *ExecutorConfig) {
+	if cfg.StatsRefresher == nil || !stats.AutomaticTargetedStatisticsClusterMode.Get(cfg.SV()) {
+		return
+	}
+	for _, m := range explain.MisestimatedFilters(ih.explainPlan) {
+		columnIDs := make([]descpb.ColumnID, len(m.Columns))
+		for i, c := range m.Columns {
+			columnIDs[i] = descpb.ColumnID(c)
+		}
+		cfg.StatsRefresher.NotifyMisestimate(descpb.ID(m.Table), columnIDs)
+	}
+}
+
 // SetIndexRecommendations checks if we should generate a new index recommendation.
 // If true it will generate and update the idx recommendations cache,
 // if false, uses the value on index recommendations cache and updates its counter.
@@ -36,15 +36,24 @@ const (
 	T__box2d     = oid.Oid(90005)
 )
 
-// ExtensionTypeName returns a mapping from extension oids
+// OIDs in this block are defined by postgres, but are not yet included in
+// `github.com/lib/pq/oid`.
+const (
+	T_anycompatible      = oid.Oid(5077)
+	T_anycompatiblearray = oid.Oid(5078)
+)
+
+// ExtensionTypeName returns a mapping from the oids defined in this package
 // to their type name.
 var ExtensionTypeName = map[oid.Oid]string{
-	T_geometry:   "GEOMETRY",
-	T__geometry:  "_GEOMETRY",
-	T_geography:  "GEOGRAPHY",
-	T__geography: "_GEOGRAPHY",
-	T_box2d:      "BOX2D",
-	T__box2d:     "_BOX2D",
+	T_geometry:           "GEOMETRY",
+	T__geometry:          "_GEOMETRY",
+	T_geography:          "GEOGRAPHY",
+	T__geography:         "_GEOGRAPHY",
+	T_box2d:              "BOX2D",
+	T__box2d:             "_BOX2D",
+	T_anycompatible:      "ANYCOMPATIBLE",
+	T_anycompatiblearray: "ANYCOMPATIBLEARRAY",
 }
 
 // TypeName checks the name for a given type by first looking up oid.TypeName
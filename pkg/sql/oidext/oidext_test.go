@@ -30,6 +30,7 @@ func TestTypeName(t *testing.T) {
 	}{
 		{oid.T_int4, "INT4", true},
 		{T_geometry, "GEOMETRY", true},
+		{T_anycompatible, "ANYCOMPATIBLE", true},
 		{oid.Oid(99988199), "", false},
 	}
 
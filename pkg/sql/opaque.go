@@ -139,6 +139,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.alterTenantService(ctx, n)
 	case *tree.AlterType:
 		return p.AlterType(ctx, n)
+	case *tree.AlterTextSearchConfiguration:
+		return p.AlterTextSearchConfiguration(ctx, n)
 	case *tree.AlterRole:
 		return p.AlterRole(ctx, n)
 	case *tree.AlterRoleSet:
@@ -178,6 +180,12 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.CreateSequence(ctx, n)
 	case *tree.CreateExtension:
 		return p.CreateExtension(ctx, n)
+	case *tree.CreateCast:
+		return p.CreateCast(ctx, n)
+	case *tree.CreateTextSearchConfiguration:
+		return p.CreateTextSearchConfiguration(ctx, n)
+	case *tree.CreateTextSearchDictionary:
+		return p.CreateTextSearchDictionary(ctx, n)
 	case *tree.CreateExternalConnection:
 		return p.CreateExternalConnection(ctx, n)
 	case *tree.CreateTenant:
@@ -208,6 +216,10 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.DropTable(ctx, n)
 	case *tree.DropTenant:
 		return p.DropTenant(ctx, n)
+	case *tree.DropCast:
+		return p.DropCast(ctx, n)
+	case *tree.DropTextSearchObject:
+		return p.DropTextSearchObject(ctx, n)
 	case *tree.DropType:
 		return p.DropType(ctx, n)
 	case *tree.DropView:
@@ -331,6 +343,7 @@ func init() {
 		&tree.AlterTenantSetClusterSetting{},
 		&tree.AlterTenantService{},
 		&tree.AlterType{},
+		&tree.AlterTextSearchConfiguration{},
 		&tree.AlterSequence{},
 		&tree.AlterRole{},
 		&tree.AlterRoleSet{},
@@ -345,6 +358,9 @@ func init() {
 		&tree.CreateDatabase{},
 		&tree.CreateExtension{},
 		&tree.CreateExternalConnection{},
+		&tree.CreateCast{},
+		&tree.CreateTextSearchConfiguration{},
+		&tree.CreateTextSearchDictionary{},
 		&tree.CreateTenant{},
 		&tree.CreateIndex{},
 		&tree.CreateSchema{},
@@ -364,6 +380,8 @@ func init() {
 		&tree.DropSequence{},
 		&tree.DropTable{},
 		&tree.DropTenant{},
+		&tree.DropCast{},
+		&tree.DropTextSearchObject{},
 		&tree.DropType{},
 		&tree.DropView{},
 		&tree.FetchCursor{},
@@ -165,18 +165,21 @@ type Catalog interface {
 	// ResolveFunctionByOID resolves a function overload by OID.
 	ResolveFunctionByOID(ctx context.Context, oid oid.Oid) (*tree.RoutineName, *tree.Overload, error)
 
-	// CheckPrivilege verifies that the current user has the given privilege on
+	// CheckPrivilege verifies that the given user has the given privilege on
 	// the given catalog object. If not, then CheckPrivilege returns an error.
-	CheckPrivilege(ctx context.Context, o Object, priv privilege.Kind) error
+	CheckPrivilege(ctx context.Context, o Object, user username.SQLUsername, priv privilege.Kind) error
 
 	// CheckAnyPrivilege verifies that the current user has any privilege on
 	// the given catalog object. If not, then CheckAnyPrivilege returns an error.
 	CheckAnyPrivilege(ctx context.Context, o Object) error
 
-	// CheckExecutionPrivilege verifies that the current user has execution
+	// CheckExecutionPrivilege verifies that the given user has execution
 	// privileges for the UDF with the given OID. If not, then CheckPrivilege
 	// returns an error.
-	CheckExecutionPrivilege(ctx context.Context, oid oid.Oid) error
+	CheckExecutionPrivilege(ctx context.Context, oid oid.Oid, user username.SQLUsername) error
+
+	// GetCurrentUser returns the username.SQLUsername of the current session.
+	GetCurrentUser() username.SQLUsername
 
 	// HasAdminRole checks that the current user has admin privileges. If yes,
 	// returns true. Returns an error if query on the `system.users` table failed
@@ -257,6 +257,11 @@ type TableStatistic interface {
 
 	// IsAuto returns true if this statistic was collected automatically.
 	IsAuto() bool
+
+	// Expression returns the serialized scalar expression the statistic was
+	// collected on, or the empty string if it was collected on its columns. If
+	// set, the statistic columns are the columns referenced by the expression.
+	Expression() string
 }
 
 // HistogramBucket contains the data for a single histogram bucket. Note
@@ -215,6 +215,7 @@ func (b *Builder) tryBuildFastPathInsert(
 	}
 
 	//  - there are no self-referencing foreign keys;
+	//  - there are no MATCH PARTIAL foreign keys;
 	//  - all FK checks can be performed using direct lookups into unique indexes.
 	fkChecks := make([]exec.InsertFastPathCheck, len(ins.FKChecks))
 	for i := range ins.FKChecks {
@@ -224,6 +225,11 @@ func (b *Builder) tryBuildFastPathInsert(
 			return execPlan{}, colOrdMap{}, false, nil
 		}
 		fk := tab.OutboundForeignKey(c.FKOrdinal)
+		if fk.MatchMethod() == tree.MatchPartial {
+			// The fast path can't look up rows matching only the non-NULL
+			// columns of a MATCH PARTIAL key.
+			return execPlan{}, colOrdMap{}, false, nil
+		}
 		lookupJoin, isLookupJoin := c.Check.(*memo.LookupJoinExpr)
 		if !isLookupJoin || lookupJoin.JoinType != opt.AntiJoinOp {
 			// Not a lookup anti-join.
@@ -952,9 +958,10 @@ func mkFKCheckErr(md *opt.Metadata, c *memo.FKChecksItem, keyVals tree.Datums) e
 			if i > 0 {
 				details.WriteString(", ")
 			}
-			if d == tree.DNull {
+			if d == tree.DNull && fk.MatchMethod() != tree.MatchPartial {
 				// If we see a NULL, this must be a MATCH FULL failure (otherwise the
-				// row would have been filtered out).
+				// row would have been filtered out). MATCH PARTIAL checks the non-NULL
+				// columns of the key, so the NULLs are part of the key.
 				sawNull = true
 				break
 			}
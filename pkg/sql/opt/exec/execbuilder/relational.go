@@ -430,10 +430,52 @@ func (b *Builder) maybeAnnotateWithEstimates(node exec.Node, e memo.RelExpr) {
 				}
 			}
 		}
+		if sel, ok := e.(*memo.SelectExpr); ok {
+			val.FilteredTable, val.FilteredColumns = b.filteredTableColumns(sel)
+		}
 		ef.AnnotateNode(node, exec.EstimatedStatsID, &val)
 	}
 }
 
+// filteredTableColumns returns the table scanned below the given filter and
+// the IDs of its columns that are constrained either by the filter or by the
+// scan. Nothing is returned if the input of the filter is not a (possibly
+// index-joined) scan or if fewer than two columns are constrained.
+func (b *Builder) filteredTableColumns(sel *memo.SelectExpr) (cat.StableID, []cat.StableID) {
+	input := sel.Input
+	if ij, ok := input.(*memo.IndexJoinExpr); ok {
+		input = ij.Input
+	}
+	scan, ok := input.(*memo.ScanExpr)
+	if !ok {
+		return 0, nil
+	}
+	cols := sel.Filters.OuterCols()
+	if c := scan.Constraint; c != nil {
+		for i, n := 0, c.ConstrainedColumns(b.evalCtx); i < n; i++ {
+			cols.Add(c.Columns.Get(i).ID())
+		}
+	}
+	md := b.mem.Metadata()
+	tab := md.Table(scan.Table)
+	var colIDs []cat.StableID
+	for col, ok := cols.Next(0); ok; col, ok = cols.Next(col + 1) {
+		if md.ColumnMeta(col).Table != scan.Table {
+			continue
+		}
+		tabCol := tab.Column(scan.Table.ColumnOrdinal(col))
+		if tabCol.Kind() != cat.Ordinary {
+			// Statistics can only be collected on ordinary columns.
+			continue
+		}
+		colIDs = append(colIDs, tabCol.ColID())
+	}
+	if len(colIDs) < 2 {
+		return 0, nil
+	}
+	return tab.ID(), colIDs
+}
+
 func (b *Builder) buildValues(
 	values *memo.ValuesExpr,
 ) (_ execPlan, outputCols colOrdMap, err error) {
@@ -693,6 +735,10 @@ func (b *Builder) scanParams(
 			// scanned, we still will parallelize this scan.
 			parallelize = b.evalCtx.SessionData().UnboundedParallelScans
 		}
+		// A Parallel plan hint for the table overrides the above heuristics.
+		if h, ok := b.mem.ParallelHint(scan.Table); ok {
+			parallelize = h.Enabled()
+		}
 	}
 
 	// Figure out if we need to scan in reverse (ScanPrivateCanProvide takes
@@ -3399,6 +3445,7 @@ func (b *Builder) buildCall(c *memo.CallExpr) (_ execPlan, outputCols colOrdMap,
 		nil,   /* blockState */
 		nil,   /* cursorDeclaration */
 	)
+	r.SessionOverride = udf.Def.SessionOverride
 
 	var ep execPlan
 	ep.root, err = b.factory.ConstructCall(r)
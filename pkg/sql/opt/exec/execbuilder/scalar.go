@@ -981,10 +981,13 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 	enableStepping := udf.Def.Volatility == volatility.Volatile
 
 	// The calling routine, if any, will have already determined whether this
-	// routine is in tail-call position.
+	// routine is in tail-call position. A routine that modifies the session
+	// cannot be executed by its parent, since the modifications must only be in
+	// effect while the routine itself is executing.
 	_, tailCall := b.tailCalls[udf]
+	tailCall = tailCall && udf.Def.SessionOverride == nil
 
-	return tree.NewTypedRoutineExpr(
+	r := tree.NewTypedRoutineExpr(
 		udf.Def.Name,
 		args,
 		planGen,
@@ -998,7 +1001,9 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		udf.Def.BlockStart,
 		blockState,
 		udf.Def.CursorDeclaration,
-	), nil
+	)
+	r.SessionOverride = udf.Def.SessionOverride
+	return r, nil
 }
 
 func (b *Builder) buildRoutineArgs(
@@ -1292,6 +1297,6 @@ func (b *Builder) buildTxnControl(
 		return f.DetachMemo(), nil
 	}
 	return tree.NewTxnControlExpr(
-		txnExpr.TxnOp, txnExpr.TxnModes, args, gen, txnExpr.Def.Name, txnExpr.Def.Typ,
+		txnExpr.TxnOp, txnExpr.Chain, txnExpr.TxnModes, args, gen, txnExpr.Def.Name, txnExpr.Def.Typ,
 	), nil
 }
@@ -434,6 +434,19 @@ func omitStats(n *Node) bool {
 	return false
 }
 
+const (
+	inaccurateFactor   = 2
+	inaccurateAdditive = 100
+)
+
+// isInaccurateEstimate returns whether the actual row count of an operator is
+// far enough outside of its estimated range for the estimate to be considered
+// inaccurate.
+func isInaccurateEstimate(minEstimatedRowCount, maxEstimatedRowCount, actualRowCount uint64) bool {
+	return actualRowCount*inaccurateFactor+inaccurateAdditive < minEstimatedRowCount ||
+		maxEstimatedRowCount*inaccurateFactor+inaccurateAdditive < actualRowCount
+}
+
 func (e *emitter) emitNodeAttributes(n *Node) error {
 	var actualRowCount uint64
 	var hasActualRowCount bool
@@ -482,6 +495,18 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		if s.KVBatchRequestsIssued.HasValue() {
 			e.ob.AddField("KV gRPC calls", string(humanizeutil.Count(s.KVBatchRequestsIssued.Value())))
 		}
+		if s.AdaptiveJoinHashRows.HasValue() {
+			e.ob.AddField("adaptive join", fmt.Sprintf(
+				"switched to hash join after %s lookup rows (%s hash join rows)",
+				humanizeutil.Count(s.AdaptiveJoinLookupRows.Value()),
+				humanizeutil.Count(s.AdaptiveJoinHashRows.Value()),
+			))
+		} else if s.AdaptiveJoinLookupRows.HasValue() {
+			e.ob.AddField("adaptive join", fmt.Sprintf(
+				"did not switch to hash join (%s lookup rows)",
+				humanizeutil.Count(s.AdaptiveJoinLookupRows.Value()),
+			))
+		}
 		if s.MaxAllocatedMem.HasValue() {
 			e.ob.AddField("estimated max memory allocated", humanize.IBytes(s.MaxAllocatedMem.Value()))
 		}
@@ -506,8 +531,6 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 	}
 
 	var inaccurateEstimate bool
-	const inaccurateFactor = 2
-	const inaccurateAdditive = 100
 	if stats, ok := n.annotations[exec.EstimatedStatsID]; ok && !omitStats(n) {
 		s := stats.(*exec.EstimatedStats)
 
@@ -519,8 +542,7 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			if hasActualRowCount && s.TableStatsAvailable {
 				// If we have both the actual row count and the table stats
 				// available, check whether the estimate is inaccurate.
-				inaccurateEstimate = actualRowCount*inaccurateFactor+inaccurateAdditive < minEstimatedRowCount ||
-					maxEstimatedRowCount*inaccurateFactor+inaccurateAdditive < actualRowCount
+				inaccurateEstimate = isInaccurateEstimate(minEstimatedRowCount, maxEstimatedRowCount, actualRowCount)
 			}
 		} else {
 			estimatedRowCount := uint64(math.Round(s.RowCount))
@@ -528,8 +550,7 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			if hasActualRowCount && s.TableStatsAvailable {
 				// If we have both the actual row count and the table stats
 				// available, check whether the estimate is inaccurate.
-				inaccurateEstimate = actualRowCount*inaccurateFactor+inaccurateAdditive < estimatedRowCount ||
-					estimatedRowCount*inaccurateFactor+inaccurateAdditive < actualRowCount
+				inaccurateEstimate = isInaccurateEstimate(estimatedRowCount, estimatedRowCount, actualRowCount)
 			}
 		}
 
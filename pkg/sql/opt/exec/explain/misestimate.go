@@ -0,0 +1,72 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package explain
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+)
+
+// MisestimatedFilter identifies the table columns constrained by a filter on
+// top of a scan whose estimated row count turned out to be inaccurate.
+type MisestimatedFilter struct {
+	Table   cat.StableID
+	Columns []cat.StableID
+}
+
+// MisestimatedFilters returns the filters in the plan (including subqueries
+// and checks) that constrain multiple columns of the scanned table and whose
+// estimated row counts were inaccurate according to the execution statistics
+// that the plan was annotated with. Filters that were planned without table
+// statistics are ignored.
+func MisestimatedFilters(plan *Plan) []MisestimatedFilter {
+	var res []MisestimatedFilter
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		for i := range n.children {
+			walk(n.children[i])
+		}
+		estimated, ok := n.annotations[exec.EstimatedStatsID].(*exec.EstimatedStats)
+		if !ok || !estimated.TableStatsAvailable || len(estimated.FilteredColumns) == 0 {
+			return
+		}
+		actual, ok := n.annotations[exec.ExecutionStatsID].(*exec.ExecutionStats)
+		if !ok || !actual.RowCount.HasValue() {
+			return
+		}
+		minEstimatedRowCount := uint64(math.Round(estimated.RowCount))
+		maxEstimatedRowCount := minEstimatedRowCount
+		if estimated.LimitHint > 0 && estimated.LimitHint != estimated.RowCount {
+			maxEstimatedRowCount = uint64(math.Ceil(math.Max(estimated.LimitHint, estimated.RowCount)))
+			minEstimatedRowCount = uint64(math.Ceil(math.Min(estimated.LimitHint, estimated.RowCount)))
+		}
+		if isInaccurateEstimate(minEstimatedRowCount, maxEstimatedRowCount, actual.RowCount.Value()) {
+			res = append(res, MisestimatedFilter{
+				Table:   estimated.FilteredTable,
+				Columns: estimated.FilteredColumns,
+			})
+		}
+	}
+	if plan.Root != nil {
+		walk(plan.Root)
+	}
+	for i := range plan.Subqueries {
+		if root, ok := plan.Subqueries[i].Root.(*Node); ok {
+			walk(root)
+		}
+	}
+	for i := range plan.Checks {
+		walk(plan.Checks[i])
+	}
+	return res
+}
@@ -0,0 +1,247 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package explain
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/hints"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+)
+
+// PlanGistHints returns the plan hints which direct the optimizer to the plan
+// described by the given gist: the join order, the join methods and the index
+// used to read each table. Planning the same statement with these hints
+// reproduces the shape of the plan as long as the referenced tables and
+// indexes still exist; the gist does not record enough to reproduce other
+// choices, such as the order of the columns of a lookup join.
+//
+// An error is returned if the gist references a table or index which no longer
+// exists, or if it reads the same table more than once, since hints cannot
+// distinguish between the two occurrences.
+func PlanGistHints(gist string, catalog cat.Catalog) (_ *hints.Hints, retErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// See DecodePlanGistToRows.
+			if ok, e := errorutil.ShouldCatch(r); ok {
+				retErr = e
+			} else {
+				panic(r)
+			}
+		}
+	}()
+
+	plan, err := DecodePlanGistToPlan(gist, catalog)
+	if err != nil {
+		return nil, err
+	}
+	b := gistHintsBuilder{h: &hints.Hints{}}
+	b.addRoot(plan.Root)
+	for i := range plan.Subqueries {
+		b.addRoot(plan.Subqueries[i].Root.(*Node))
+	}
+	for _, n := range plan.Checks {
+		b.addRoot(n)
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	// A Leading hint can only describe a single join tree.
+	var leading *hints.JoinOrder
+	for _, o := range b.roots {
+		if !o.IsLeaf() {
+			if leading != nil {
+				leading = nil
+				break
+			}
+			leading = o
+		}
+	}
+	b.h.Leading = leading
+	return b.h, nil
+}
+
+// gistHintsBuilder accumulates the hints for the operators of a decoded plan
+// gist.
+type gistHintsBuilder struct {
+	h *hints.Hints
+
+	// roots contains the join trees of the parts of the plan which are not
+	// joined to each other, for example the inputs of a union.
+	roots []*hints.JoinOrder
+
+	// tables is the set of relations which have been scanned.
+	tables intsets.Fast
+
+	err error
+}
+
+func (b *gistHintsBuilder) addRoot(n *Node) {
+	if n == nil {
+		return
+	}
+	if o := b.build(n); o != nil {
+		b.roots = append(b.roots, o)
+	}
+}
+
+// build adds the hints for the given operator and its inputs, and returns the
+// join tree of the relations it reads, or nil if it reads no tables. The
+// children of operators which do not join their inputs are added as separate
+// roots.
+func (b *gistHintsBuilder) build(n *Node) *hints.JoinOrder {
+	if b.err != nil {
+		return nil
+	}
+	switch n.op {
+	case scanOp:
+		a := n.args.(*scanArgs)
+		rel, ok := b.table(a.Table)
+		if !ok {
+			return nil
+		}
+		if a.Index.Ordinal() == cat.PrimaryIndex && a.Params.IndexConstraint == nil &&
+			a.Params.InvertedConstraint == nil {
+			b.scan(rel, hints.SeqScan, nil /* index */)
+		} else {
+			b.scan(rel, hints.IndexScan, a.Index)
+		}
+		return hints.Leaf(rel)
+
+	case zigzagJoinOp:
+		a := n.args.(*zigzagJoinArgs)
+		rel, ok := b.table(a.LeftTable)
+		if !ok {
+			return nil
+		}
+		b.scan(rel, hints.IndexScan, a.LeftIndex)
+		b.scan(rel, hints.IndexScan, a.RightIndex)
+		return hints.Leaf(rel)
+
+	case hashJoinOp:
+		return b.join(b.build(n.children[0]), b.build(n.children[1]), hints.HashJoin)
+
+	case mergeJoinOp:
+		return b.join(b.build(n.children[0]), b.build(n.children[1]), hints.MergeJoin)
+
+	case lookupJoinOp:
+		a := n.args.(*lookupJoinArgs)
+		return b.lookupJoin(b.build(n.children[0]), a.Table, a.Index)
+
+	case invertedJoinOp:
+		a := n.args.(*invertedJoinArgs)
+		return b.lookupJoin(b.build(n.children[0]), a.Table, a.Index)
+
+	case applyJoinOp:
+		// The right side of an apply join is planned separately for each row,
+		// and is not part of the gist.
+		return b.build(n.children[0])
+
+	case indexJoinOp:
+		return b.build(n.children[0])
+	}
+
+	switch len(n.children) {
+	case 0:
+		return nil
+	case 1:
+		return b.build(n.children[0])
+	}
+	for _, c := range n.children {
+		b.addRoot(c)
+	}
+	return nil
+}
+
+// table returns the relation for the given table, which must not have been
+// read before.
+func (b *gistHintsBuilder) table(tab cat.Table) (rel int, ok bool) {
+	if _, unknown := tab.(*unknownTable); unknown {
+		b.err = pgerror.New(pgcode.UndefinedTable, "plan gist references a table which no longer exists")
+		return 0, false
+	}
+	rel = b.h.Relation(string(tab.Name()))
+	if b.tables.Contains(rel) {
+		b.err = pgerror.Newf(pgcode.FeatureNotSupported,
+			"plan gist reads table %s more than once", tab.Name())
+		return 0, false
+	}
+	b.tables.Add(rel)
+	return rel, true
+}
+
+// scan adds a scan method hint for the given relation.
+func (b *gistHintsBuilder) scan(rel int, method hints.ScanMethod, index cat.Index) {
+	sh := hints.ScanMethodHint{Rel: rel, Method: method}
+	if index != nil {
+		if _, unknown := index.(*unknownIndex); unknown {
+			b.err = pgerror.New(pgcode.UndefinedObject, "plan gist references an index which no longer exists")
+			return
+		}
+		// A zigzag join reads two indexes of the same table, so both are added
+		// to the same hint.
+		for i := range b.h.Scans {
+			if sh := &b.h.Scans[i]; sh.Rel == rel {
+				if sh.Indexes[0] != string(index.Name()) {
+					sh.Indexes = append(sh.Indexes, string(index.Name()))
+				}
+				return
+			}
+		}
+		sh.Indexes = []string{string(index.Name())}
+	}
+	b.h.Scans = append(b.h.Scans, sh)
+}
+
+// join returns the join tree which joins the given inputs with the given
+// method. If one of the inputs reads no tables, the join is not visible to
+// the hints and the other input is returned.
+func (b *gistHintsBuilder) join(
+	left, right *hints.JoinOrder, method hints.JoinMethod,
+) *hints.JoinOrder {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	o := hints.Join(left, right, true /* directed */)
+	b.h.Joins = append(b.h.Joins, hints.JoinMethodHint{Rels: o.Rels, Method: method})
+	return o
+}
+
+// lookupJoin returns the join tree for a lookup or inverted join of the given
+// input into an index of the given table.
+func (b *gistHintsBuilder) lookupJoin(
+	input *hints.JoinOrder, tab cat.Table, index cat.Index,
+) *hints.JoinOrder {
+	if b.err != nil {
+		return nil
+	}
+	if input != nil {
+		for _, rel := range input.Rels.Ordered() {
+			if b.h.Relations[rel] == string(tab.Name()) {
+				// The lookup fetches the remaining columns of a table read by the
+				// input, like an index join. This includes the second join of a
+				// paired joiner.
+				return input
+			}
+		}
+	}
+	rel, ok := b.table(tab)
+	if !ok {
+		return nil
+	}
+	b.scan(rel, hints.IndexScan, index)
+	return b.join(input, hints.Leaf(rel), hints.NestLoop)
+}
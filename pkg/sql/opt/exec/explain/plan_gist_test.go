@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/opttester"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/testutils/datapathutils"
@@ -115,6 +116,12 @@ func TestPlanGistBuilder(t *testing.T) {
 			return plan(ot, t)
 		case "hash":
 			return fmt.Sprintf("%d\n", makeGist(ot, t).Hash())
+		case "gist-hints":
+			h, err := explain.PlanGistHints(makeGist(ot, t).String(), catalog)
+			if err != nil {
+				return fmt.Sprintf("error (%s): %v\n", pgerror.GetPGCode(err), err)
+			}
+			return fmt.Sprintf("%s\n", h)
 		default:
 			return ot.RunCommand(t, d)
 		}
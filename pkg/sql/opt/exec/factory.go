@@ -402,6 +402,13 @@ type EstimatedStats struct {
 	// ForecastAt is set only for scans with forecasted stats; it is the time the
 	// forecast was for (which could be in the past, present, or future).
 	ForecastAt time.Time
+	// FilteredTable and FilteredColumns are set only for filters on top of
+	// scans that constrain at least two columns of the scanned table; they
+	// identify the table and the constrained columns. The estimated row count
+	// of such a filter assumes that the columns are independent unless a
+	// multi-column statistic exists on them.
+	FilteredTable   cat.StableID
+	FilteredColumns []cat.StableID
 }
 
 // ExecutionStats contain statistics about a given operator gathered from the
@@ -501,6 +508,13 @@ type ExecutionStats struct {
 	MaxAllocatedDisk optional.Uint
 	SQLCPUTime       optional.Duration
 
+	// AdaptiveJoinLookupRows and AdaptiveJoinHashRows are the numbers of input
+	// rows of an adaptive lookup join that were joined by performing lookups
+	// and by a hash join, respectively. AdaptiveJoinHashRows is only set if the
+	// join switched to the hash join.
+	AdaptiveJoinLookupRows optional.Uint
+	AdaptiveJoinHashRows   optional.Uint
+
 	// Nodes on which this operator was executed.
 	Nodes []string
 
@@ -0,0 +1,463 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package hints contains the representation and parser for plan hints given in
+// a comment at the start of a statement, in the style of the pg_hint_plan
+// Postgres extension. For example:
+//
+//	/*+ Leading((a b) c) HashJoin(a b) IndexScan(c c_idx) Rows(a b #1000) */
+//	SELECT * FROM a, b, c WHERE ...
+//
+// Hints refer to relations by the alias they are given in the statement, or by
+// their table name if they have no alias. A name which is not the alias of any
+// table in the statement also refers to the tables with that name, so that
+// hints derived from a plan, which only knows table names, apply to aliased
+// tables as well. Unlike the hints in the SQL syntax
+// (e.g. t@idx or INNER HASH JOIN), comment hints never cause planning to fail:
+// if no plan satisfies a hint, the optimizer falls back to the lowest cost plan
+// that violates the fewest hints.
+package hints
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+)
+
+// Hints is the set of plan hints given in the hint comment of a statement.
+type Hints struct {
+	// Relations is the list of distinct relation names referenced by the hints,
+	// in the order they first appear. The relations in each hint are identified
+	// by their ordinal in this list.
+	Relations []string
+
+	// Leading, if non-nil, is the join order required by a Leading hint.
+	Leading *JoinOrder
+
+	// Joins contains the join method hints.
+	Joins []JoinMethodHint
+
+	// Scans contains the scan method hints.
+	Scans []ScanMethodHint
+
+	// Rows contains the row count corrections for joins.
+	Rows []RowsHint
+
+	// Parallel contains the hints controlling parallel scans.
+	Parallel []ParallelHint
+}
+
+// RelSet is a set of relations referenced by part of a statement. Relations
+// named in the hints are identified by their ordinal in Hints.Relations.
+type RelSet struct {
+	// Named is the set of relations named in the hints.
+	Named intsets.Fast
+
+	// Other is true if the set also contains relations which are not named in
+	// the hints.
+	Other bool
+}
+
+// Union returns the union of s and other.
+func (s RelSet) Union(other RelSet) RelSet {
+	return RelSet{Named: s.Named.Union(other.Named), Other: s.Other || other.Other}
+}
+
+// Equals returns true if s contains exactly the given named relations.
+func (s RelSet) Equals(named intsets.Fast) bool {
+	return !s.Other && s.Named.Equals(named)
+}
+
+// JoinOrder is a node in the join tree required by a Leading hint. A leaf node
+// is a single relation; other nodes join the relations of their two inputs.
+type JoinOrder struct {
+	// Rel is the ordinal of the relation of a leaf node.
+	Rel int
+
+	// Left and Right are the inputs of a join node. They are nil for a leaf
+	// node.
+	Left, Right *JoinOrder
+
+	// Directed is true if the relations of Left must be the left (outer) input
+	// of the join and the relations of Right must be its right (inner) input. If
+	// false, the inputs may be joined in either order.
+	Directed bool
+
+	// Rels is the set of relations under the node.
+	Rels intsets.Fast
+}
+
+// IsLeaf returns true if the node is a single relation.
+func (o *JoinOrder) IsLeaf() bool {
+	return o.Left == nil
+}
+
+// find returns the node in the tree with exactly the given set of relations, or
+// nil if there is none.
+func (o *JoinOrder) find(rels intsets.Fast) *JoinOrder {
+	if o.Rels.Equals(rels) {
+		return o
+	}
+	if o.IsLeaf() || !rels.SubsetOf(o.Rels) {
+		return nil
+	}
+	if n := o.Left.find(rels); n != nil {
+		return n
+	}
+	return o.Right.find(rels)
+}
+
+// allows returns true if a set of relations can be produced by some
+// subexpression of a plan that follows the join order. The relations under
+// the tree must either all be joined together before being joined with any
+// other relations, or not be part of the set at all.
+func (o *JoinOrder) allows(s RelSet) bool {
+	hinted := s.Named.Intersection(o.Rels)
+	if hinted.Empty() || hinted.Equals(o.Rels) {
+		return true
+	}
+	if s.Other || !s.Named.SubsetOf(o.Rels) {
+		return false
+	}
+	return o.find(hinted) != nil
+}
+
+// AllowsJoin returns true if a join of the given left and right inputs can be
+// part of a plan that follows the join order.
+func (o *JoinOrder) AllowsJoin(left, right RelSet) bool {
+	if !o.allows(left) || !o.allows(right) {
+		return false
+	}
+	joined := left.Union(right)
+	if !o.allows(joined) {
+		return false
+	}
+	leftHinted := left.Named.Intersection(o.Rels)
+	rightHinted := right.Named.Intersection(o.Rels)
+	if leftHinted.Empty() || rightHinted.Empty() {
+		return true
+	}
+	// The join combines two nodes of the tree, so it must produce their parent.
+	// Check that the inputs are on the required sides.
+	n := o.find(leftHinted.Union(rightHinted))
+	if n == nil || n.IsLeaf() {
+		return false
+	}
+	if n.Left.Rels.Equals(leftHinted) {
+		return true
+	}
+	return !n.Directed && n.Right.Rels.Equals(leftHinted)
+}
+
+// Leaf returns a join order node for the given relation.
+func Leaf(rel int) *JoinOrder {
+	o := &JoinOrder{Rel: rel}
+	o.Rels.Add(rel)
+	return o
+}
+
+// Join returns a join order node which joins the relations of left and right.
+// If directed is true, they must be the left and right inputs of the join,
+// respectively.
+func Join(left, right *JoinOrder, directed bool) *JoinOrder {
+	return &JoinOrder{
+		Left:     left,
+		Right:    right,
+		Directed: directed,
+		Rels:     left.Rels.Union(right.Rels),
+	}
+}
+
+// JoinMethod identifies a join algorithm.
+type JoinMethod uint8
+
+const (
+	// HashJoin is a hash join.
+	HashJoin JoinMethod = iota
+	// MergeJoin is a merge join.
+	MergeJoin
+	// NestLoop is a nested loop join: a lookup or inverted join which probes
+	// an index of the right input for each row of the left input, or an apply
+	// join which re-evaluates the right input for each row of the left input.
+	NestLoop
+)
+
+var joinMethodNames = [...]string{
+	HashJoin:  "HashJoin",
+	MergeJoin: "MergeJoin",
+	NestLoop:  "NestLoop",
+}
+
+// String implements the fmt.Stringer interface.
+func (m JoinMethod) String() string {
+	return joinMethodNames[m]
+}
+
+// JoinMethodHint requires or disallows a join method for the join of a set of
+// relations, e.g. HashJoin(a b) or NoNestLoop(a b c).
+type JoinMethodHint struct {
+	// Rels is the set of relations joined by the hinted join.
+	Rels intsets.Fast
+
+	// Method is the join method that is required, or disallowed if Disallow is
+	// true.
+	Method JoinMethod
+
+	// Disallow is true if the hint disallows the method rather than requiring
+	// it.
+	Disallow bool
+}
+
+// Allows returns true if the hint allows the given join method.
+func (h *JoinMethodHint) Allows(method JoinMethod) bool {
+	return (method == h.Method) != h.Disallow
+}
+
+// ScanMethod identifies a way of reading a relation.
+type ScanMethod uint8
+
+const (
+	// SeqScan is an unconstrained scan of the primary index.
+	SeqScan ScanMethod = iota
+	// IndexScan is any other read of an index, including constrained scans of
+	// the primary index and lookups into an index by a join.
+	IndexScan
+)
+
+var scanMethodNames = [...]string{
+	SeqScan:   "SeqScan",
+	IndexScan: "IndexScan",
+}
+
+// String implements the fmt.Stringer interface.
+func (m ScanMethod) String() string {
+	return scanMethodNames[m]
+}
+
+// ScanMethodHint requires or disallows a scan method for a relation, e.g.
+// SeqScan(a) or IndexScan(b b_idx).
+type ScanMethodHint struct {
+	// Rel is the hinted relation.
+	Rel int
+
+	// Method is the scan method that is required, or disallowed if Disallow is
+	// true.
+	Method ScanMethod
+
+	// Disallow is true if the hint disallows the method rather than requiring
+	// it.
+	Disallow bool
+
+	// Indexes restricts an IndexScan hint to the named indexes. If empty, any
+	// index may be used.
+	Indexes []string
+}
+
+// Allows returns true if the hint allows reading the relation with the given
+// method and index.
+func (h *ScanMethodHint) Allows(method ScanMethod, index string) bool {
+	if method != h.Method {
+		return h.Disallow
+	}
+	if h.Disallow {
+		return false
+	}
+	if len(h.Indexes) == 0 {
+		return true
+	}
+	for _, name := range h.Indexes {
+		if name == index {
+			return true
+		}
+	}
+	return false
+}
+
+// RowsOp identifies how a Rows hint corrects a row count.
+type RowsOp uint8
+
+const (
+	// RowsSet replaces the row count.
+	RowsSet RowsOp = iota
+	// RowsAdd adds to the row count.
+	RowsAdd
+	// RowsSub subtracts from the row count.
+	RowsSub
+	// RowsMul multiplies the row count.
+	RowsMul
+)
+
+var rowsOpPrefixes = [...]byte{
+	RowsSet: '#',
+	RowsAdd: '+',
+	RowsSub: '-',
+	RowsMul: '*',
+}
+
+// RowsHint corrects the estimated row count of the join of a set of
+// relations, e.g. Rows(a b #1000) or Rows(a b *10).
+type RowsHint struct {
+	// Rels is the set of relations joined by the hinted join.
+	Rels intsets.Fast
+
+	// Op and Value describe the correction.
+	Op    RowsOp
+	Value float64
+}
+
+// Apply returns the given row count corrected by the hint.
+func (h *RowsHint) Apply(rowCount float64) float64 {
+	switch h.Op {
+	case RowsSet:
+		rowCount = h.Value
+	case RowsAdd:
+		rowCount += h.Value
+	case RowsSub:
+		rowCount -= h.Value
+	case RowsMul:
+		rowCount *= h.Value
+	}
+	if rowCount < 0 {
+		return 0
+	}
+	return rowCount
+}
+
+// ParallelHint controls parallel scans of a relation, e.g. Parallel(a) or
+// Parallel(a 0). Scans are parallelized across the ranges they touch rather
+// than by a number of workers, so unlike in pg_hint_plan the only number of
+// workers that can be given is zero, which disables parallel scans of the
+// relation. Without a number, scans of the relation are sent to all of their
+// ranges in parallel even when the number of rows they return is unbounded.
+type ParallelHint struct {
+	// Rel is the hinted relation.
+	Rel int
+
+	// Disable is true if the hint disables parallel scans rather than
+	// enabling them.
+	Disable bool
+}
+
+// Enabled returns true if the hint allows parallel scans.
+func (h *ParallelHint) Enabled() bool {
+	return !h.Disable
+}
+
+// Relation returns the ordinal of the relation with the given name, adding it
+// to the list of relations if necessary.
+func (h *Hints) Relation(name string) int {
+	for i := range h.Relations {
+		if h.Relations[i] == name {
+			return i
+		}
+	}
+	h.Relations = append(h.Relations, name)
+	return len(h.Relations) - 1
+}
+
+// String returns the hints in the syntax they are parsed from.
+func (h *Hints) String() string {
+	var buf strings.Builder
+	sep := func() {
+		if buf.Len() > 0 {
+			buf.WriteByte(' ')
+		}
+	}
+	if h.Leading != nil {
+		sep()
+		buf.WriteString("Leading(")
+		h.formatJoinOrder(&buf, h.Leading)
+		buf.WriteByte(')')
+	}
+	for i := range h.Joins {
+		sep()
+		if h.Joins[i].Disallow {
+			buf.WriteString("No")
+		}
+		buf.WriteString(h.Joins[i].Method.String())
+		h.formatRels(&buf, h.Joins[i].Rels)
+		buf.WriteByte(')')
+	}
+	for i := range h.Scans {
+		sep()
+		if h.Scans[i].Disallow {
+			buf.WriteString("No")
+		}
+		buf.WriteString(h.Scans[i].Method.String())
+		buf.WriteByte('(')
+		buf.WriteString(h.formatName(h.Relations[h.Scans[i].Rel]))
+		for _, idx := range h.Scans[i].Indexes {
+			buf.WriteByte(' ')
+			buf.WriteString(h.formatName(idx))
+		}
+		buf.WriteByte(')')
+	}
+	for i := range h.Rows {
+		sep()
+		buf.WriteString("Rows")
+		h.formatRels(&buf, h.Rows[i].Rels)
+		fmt.Fprintf(&buf, " %c%g)", rowsOpPrefixes[h.Rows[i].Op], h.Rows[i].Value)
+	}
+	for i := range h.Parallel {
+		sep()
+		buf.WriteString("Parallel(")
+		buf.WriteString(h.formatName(h.Relations[h.Parallel[i].Rel]))
+		if h.Parallel[i].Disable {
+			buf.WriteString(" 0")
+		}
+		buf.WriteByte(')')
+	}
+	return buf.String()
+}
+
+// formatRels writes an opening parenthesis followed by the names of the given
+// relations.
+func (h *Hints) formatRels(buf *strings.Builder, rels intsets.Fast) {
+	buf.WriteByte('(')
+	first := true
+	rels.ForEach(func(i int) {
+		if !first {
+			buf.WriteByte(' ')
+		}
+		first = false
+		buf.WriteString(h.formatName(h.Relations[i]))
+	})
+}
+
+// formatJoinOrder writes the given join tree. Undirected joins can only be
+// written as the list form of the hint, e.g. Leading(a b c), so they only
+// appear along the left spine of the tree.
+func (h *Hints) formatJoinOrder(buf *strings.Builder, o *JoinOrder) {
+	if o.IsLeaf() {
+		buf.WriteString(h.formatName(h.Relations[o.Rel]))
+		return
+	}
+	if o.Directed {
+		buf.WriteByte('(')
+	}
+	h.formatJoinOrder(buf, o.Left)
+	buf.WriteByte(' ')
+	h.formatJoinOrder(buf, o.Right)
+	if o.Directed {
+		buf.WriteByte(')')
+	}
+}
+
+// formatName returns the given name, quoted if it would not be parsed back as
+// the same name.
+func (h *Hints) formatName(name string) string {
+	if name != "" && lexbase.NormalizeName(name) == name &&
+		strings.IndexFunc(name, isSpecial) == -1 {
+		return name
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
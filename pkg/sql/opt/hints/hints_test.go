@@ -0,0 +1,207 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package hints
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		in       string
+		expected string
+		err      string
+	}{
+		{in: "", expected: ""},
+		{in: "HashJoin(a b)", expected: "HashJoin(a b)"},
+		{in: " hashjoin ( A  b ) NoMergeJoin(a b c)", expected: "HashJoin(a b) NoMergeJoin(a b c)"},
+		{in: "NestLoop(a b) NoNestLoop(b c) NoHashJoin(a c)",
+			expected: "NestLoop(a b) NoNestLoop(b c) NoHashJoin(a c)"},
+		{in: "SeqScan(a) NoSeqScan(b) IndexScan(c) IndexScan(d d_idx d_idx2) NoIndexScan(e)",
+			expected: "SeqScan(a) NoSeqScan(b) IndexScan(c) IndexScan(d d_idx d_idx2) NoIndexScan(e)"},
+		{in: `IndexScan("T" "Idx")`, expected: `IndexScan("T" "Idx")`},
+		{in: `SeqScan("a""b")`, expected: `SeqScan("a""b")`},
+		{in: "Leading(a b c)", expected: "Leading(a b c)"},
+		{in: "Leading((a b) c)", expected: "Leading((a b) c)"},
+		{in: "Leading(((a b) c))", expected: "Leading(((a b) c))"},
+		{in: "Leading((a (b c)))", expected: "Leading((a (b c)))"},
+		{in: "Rows(a b #1000) Rows(a c +10) Rows(b c -5) Rows(a b c *2.5)",
+			expected: "Rows(a b #1000) Rows(a c +10) Rows(b c -5) Rows(a b c *2.5)"},
+		{in: "Parallel(a) Parallel(b 0)", expected: "Parallel(a) Parallel(b 0)"},
+		{in: "Leading(b a) HashJoin(a b) SeqScan(c) Rows(a b #1) Parallel(c)",
+			expected: "Leading(b a) HashJoin(b a) SeqScan(c) Rows(b a #1) Parallel(c)"},
+
+		{in: "Foo(a b)", err: `unrecognized hint "Foo"`},
+		{in: "HashJoin(a b", err: `expected name or ")"`},
+		{in: "HashJoin a b)", err: `expected '('`},
+		{in: "HashJoin(a)", err: "HashJoin hint requires at least two relations"},
+		{in: "SeqScan()", err: "SeqScan hint requires a relation"},
+		{in: "SeqScan(a b)", err: "SeqScan hint accepts a single relation"},
+		{in: "NoIndexScan(a a_idx)", err: "NoIndexScan hint accepts a single relation"},
+		{in: "Leading(a)", err: "Leading hint requires at least two relations"},
+		{in: "Leading((a))", err: "join pair in Leading hint requires two items"},
+		{in: "Leading((a b c))", err: "join pair in Leading hint requires two items"},
+		{in: "Leading(a b a)", err: "Leading hint contains duplicate relations"},
+		{in: "Leading(a b) Leading(b a)", err: "duplicate Leading hint"},
+		{in: "Rows(a #10)", err: "Rows hint requires at least two relations"},
+		{in: "Rows(a b)", err: "Rows hint requires a correction"},
+		{in: "Rows(a b #x)", err: `invalid Rows hint correction "#x"`},
+		{in: "Parallel(a 4)", err: `Parallel hint only supports 0 workers, which disables parallel scans, got "4"`},
+		{in: "Parallel(a -1)", err: `Parallel hint only supports 0 workers, which disables parallel scans, got "-1"`},
+		{in: "Parallel(a 0 hard)", err: "Parallel hint does not support an enforcement strength"},
+		{in: `SeqScan("a)`, err: "unterminated quoted name"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			h, err := Parse(tc.in)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := h.String(); actual != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFromComments(t *testing.T) {
+	h, err := FromComments([]string{"/*+ HashJoin(a b) */", "/*+ SeqScan(a) */"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := h.String(); actual != "HashJoin(a b)" {
+		t.Fatalf("expected hints from the first comment, got %q", actual)
+	}
+	for _, comments := range [][]string{
+		nil,
+		{"/* HashJoin(a b) */"},
+		{"-- HashJoin(a b)"},
+		{"/* comment */", "/*+ HashJoin(a b) */"},
+	} {
+		if h, err := FromComments(comments); err != nil || h != nil {
+			t.Fatalf("expected no hints for %q, got %v, %v", comments, h, err)
+		}
+	}
+}
+
+func TestRowsHint(t *testing.T) {
+	testCases := []struct {
+		hint     string
+		rowCount float64
+		expected float64
+	}{
+		{hint: "#1000", rowCount: 10, expected: 1000},
+		{hint: "+10", rowCount: 10, expected: 20},
+		{hint: "-10", rowCount: 15, expected: 5},
+		{hint: "-10", rowCount: 5, expected: 0},
+		{hint: "*2.5", rowCount: 10, expected: 25},
+	}
+	for _, tc := range testCases {
+		h, err := Parse("Rows(a b " + tc.hint + ")")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := h.Rows[0].Apply(tc.rowCount); actual != tc.expected {
+			t.Errorf("%s applied to %g: expected %g, got %g", tc.hint, tc.rowCount, tc.expected, actual)
+		}
+	}
+}
+
+func TestJoinOrderAllowsJoin(t *testing.T) {
+	testCases := []struct {
+		leading string
+		// left and right list the relations of each input; "x" is a relation
+		// that is not named in the hints.
+		left, right string
+		expected    bool
+	}{
+		// Directed pairs must be joined in the given order.
+		{leading: "((a b) c)", left: "a", right: "b", expected: true},
+		{leading: "((a b) c)", left: "b", right: "a", expected: false},
+		{leading: "((a b) c)", left: "a b", right: "c", expected: true},
+		{leading: "((a b) c)", left: "c", right: "a b", expected: false},
+		{leading: "((a b) c)", left: "a", right: "c", expected: false},
+		{leading: "((a b) c)", left: "a c", right: "b", expected: false},
+
+		// The list form allows either direction.
+		{leading: "a b c", left: "b", right: "a", expected: true},
+		{leading: "a b c", left: "c", right: "a b", expected: true},
+		{leading: "a b c", left: "b c", right: "a", expected: false},
+		{leading: "(a b) c", left: "b", right: "a", expected: false},
+		{leading: "(a b) c", left: "c", right: "a b", expected: true},
+
+		// Other relations can only be joined before any hinted relation or after
+		// all of them.
+		{leading: "a b", left: "x", right: "x", expected: true},
+		{leading: "a b", left: "a b", right: "x", expected: true},
+		{leading: "a b", left: "x", right: "a b", expected: true},
+		{leading: "a b", left: "a", right: "x", expected: false},
+		{leading: "a b", left: "a x", right: "b", expected: false},
+		{leading: "a b", left: "a b x", right: "x", expected: true},
+	}
+	for _, tc := range testCases {
+		h, err := Parse("Leading(" + tc.leading + ")")
+		if err != nil {
+			t.Fatal(err)
+		}
+		relSet := func(names string) (s RelSet) {
+			for _, n := range strings.Fields(names) {
+				if n == "x" {
+					s.Other = true
+					continue
+				}
+				s.Named.Add(h.Relation(n))
+			}
+			return s
+		}
+		if actual := h.Leading.AllowsJoin(relSet(tc.left), relSet(tc.right)); actual != tc.expected {
+			t.Errorf("Leading(%s) join of (%s) and (%s): expected %t, got %t",
+				tc.leading, tc.left, tc.right, tc.expected, actual)
+		}
+	}
+}
+
+func TestJoinMethodHintAllows(t *testing.T) {
+	h, err := Parse("HashJoin(a b) NoNestLoop(a b)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rels intsets.Fast
+	rels.AddRange(0, 1)
+	if !h.Joins[0].Rels.Equals(rels) {
+		t.Fatalf("unexpected relations %s", h.Joins[0].Rels)
+	}
+	for _, tc := range []struct {
+		hint     int
+		method   JoinMethod
+		expected bool
+	}{
+		{hint: 0, method: HashJoin, expected: true},
+		{hint: 0, method: MergeJoin, expected: false},
+		{hint: 0, method: NestLoop, expected: false},
+		{hint: 1, method: HashJoin, expected: true},
+		{hint: 1, method: MergeJoin, expected: true},
+		{hint: 1, method: NestLoop, expected: false},
+	} {
+		if actual := h.Joins[tc.hint].Allows(tc.method); actual != tc.expected {
+			t.Errorf("%s allows %s: expected %t, got %t", h.Joins[tc.hint].Method, tc.method, tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package hints
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	commentPrefix = "/*+"
+	commentSuffix = "*/"
+)
+
+// FromComments returns the hints in the hint comment among the given comments
+// of a statement, or nil if there is no hint comment. Like pg_hint_plan, only
+// the first comment of the statement is considered, and only if it is a block
+// comment starting with "/*+".
+func FromComments(comments []string) (*Hints, error) {
+	if len(comments) == 0 || !strings.HasPrefix(comments[0], commentPrefix) {
+		return nil, nil
+	}
+	c := comments[0]
+	return Parse(c[len(commentPrefix) : len(c)-len(commentSuffix)])
+}
+
+// Parse parses a list of hints, e.g. "HashJoin(a b) SeqScan(c)".
+func Parse(s string) (*Hints, error) {
+	p := parser{in: s, h: &Hints{}}
+	for {
+		p.skipSpace()
+		if p.pos == len(p.in) {
+			return p.h, nil
+		}
+		if err := p.parseHint(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+type parser struct {
+	in  string
+	pos int
+	h   *Hints
+}
+
+// isSpecial returns true for the characters which end an unquoted word.
+func isSpecial(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')' || r == '"'
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.in) && unicode.IsSpace(rune(p.in[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return errors.Wrap(pgerror.Newf(pgcode.Syntax, format, args...), "invalid hint")
+}
+
+// peek returns the next character after any whitespace, or 0 at the end of the
+// input.
+func (p *parser) peek() byte {
+	p.skipSpace()
+	if p.pos == len(p.in) {
+		return 0
+	}
+	return p.in[p.pos]
+}
+
+func (p *parser) expect(c byte) error {
+	if p.peek() != c {
+		return p.errorf("expected %q at position %d", c, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+// word parses an unquoted word, e.g. a hint name or a row count correction.
+func (p *parser) word() string {
+	p.skipSpace()
+	start := p.pos
+	if i := strings.IndexFunc(p.in[start:], isSpecial); i >= 0 {
+		p.pos += i
+	} else {
+		p.pos = len(p.in)
+	}
+	return p.in[start:p.pos]
+}
+
+// name parses a relation or index name. Unquoted names are normalized like SQL
+// identifiers; quoted names are kept as is.
+func (p *parser) name() (string, error) {
+	if p.peek() != '"' {
+		w := p.word()
+		if w == "" {
+			return "", p.errorf("expected name at position %d", p.pos)
+		}
+		return lexbase.NormalizeName(w), nil
+	}
+	var buf strings.Builder
+	for p.pos++; p.pos < len(p.in); p.pos++ {
+		if p.in[p.pos] != '"' {
+			buf.WriteByte(p.in[p.pos])
+			continue
+		}
+		if p.pos+1 < len(p.in) && p.in[p.pos+1] == '"' {
+			buf.WriteByte('"')
+			p.pos++
+			continue
+		}
+		p.pos++
+		return buf.String(), nil
+	}
+	return "", p.errorf("unterminated quoted name")
+}
+
+// names parses names up to the closing parenthesis of a hint.
+func (p *parser) names() ([]string, error) {
+	var names []string
+	for c := p.peek(); c != ')'; c = p.peek() {
+		if c == 0 || c == '(' {
+			return nil, p.errorf("expected name or \")\" at position %d", p.pos)
+		}
+		n, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, n)
+	}
+	return names, nil
+}
+
+// rels returns the set of relations with the given names.
+func (p *parser) rels(names []string) (rels intsets.Fast) {
+	for _, n := range names {
+		rels.Add(p.h.Relation(n))
+	}
+	return rels
+}
+
+func (p *parser) parseHint() error {
+	name := p.word()
+	if name == "" {
+		return p.errorf("expected hint name at position %d", p.pos)
+	}
+	if err := p.expect('('); err != nil {
+		return err
+	}
+	// Hint names are case-insensitive. Join and scan method hints can be
+	// prefixed with "No" to disallow the method.
+	lower := strings.ToLower(name)
+	method, disallow := lower, false
+	if strings.HasPrefix(lower, "no") {
+		method, disallow = lower[len("no"):], true
+	}
+	var err error
+	switch {
+	case lower == "leading":
+		err = p.parseLeading()
+
+	case method == "hashjoin" || method == "mergejoin" || method == "nestloop":
+		err = p.parseJoinMethod(name, method, disallow)
+
+	case method == "seqscan" || method == "indexscan":
+		err = p.parseScanMethod(name, method, disallow)
+
+	case lower == "rows":
+		err = p.parseRows()
+
+	case lower == "parallel":
+		err = p.parseParallel()
+
+	default:
+		return p.errorf("unrecognized hint %q", name)
+	}
+	if err != nil {
+		return err
+	}
+	return p.expect(')')
+}
+
+func (p *parser) parseLeading() error {
+	if p.h.Leading != nil {
+		return p.errorf("duplicate Leading hint")
+	}
+	// The list form, e.g. Leading(a b c), joins its items from left to right in
+	// either direction. Each item is a relation or a pair of items in
+	// parentheses, e.g. ((a b) c), which must be joined in the given direction.
+	var order *JoinOrder
+	n := 0
+	for p.peek() != ')' {
+		item, err := p.parseJoinOrderItem()
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			order = item
+		} else {
+			order = Join(order, item, false /* directed */)
+		}
+		n++
+	}
+	if order == nil || (n == 1 && order.IsLeaf()) {
+		return p.errorf("Leading hint requires at least two relations")
+	}
+	if order.Rels.Len() != countLeaves(order) {
+		return p.errorf("Leading hint contains duplicate relations")
+	}
+	p.h.Leading = order
+	return nil
+}
+
+func countLeaves(o *JoinOrder) int {
+	if o.IsLeaf() {
+		return 1
+	}
+	return countLeaves(o.Left) + countLeaves(o.Right)
+}
+
+func (p *parser) parseJoinOrderItem() (*JoinOrder, error) {
+	if p.peek() != '(' {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		return Leaf(p.h.Relation(name)), nil
+	}
+	p.pos++
+	left, err := p.parseJoinOrderItem()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() == ')' {
+		return nil, p.errorf("join pair in Leading hint requires two items")
+	}
+	right, err := p.parseJoinOrderItem()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != ')' {
+		return nil, p.errorf("join pair in Leading hint requires two items")
+	}
+	p.pos++
+	return Join(left, right, true /* directed */), nil
+}
+
+func (p *parser) parseJoinMethod(hint, method string, disallow bool) error {
+	names, err := p.names()
+	if err != nil {
+		return err
+	}
+	if len(names) < 2 {
+		return p.errorf("%s hint requires at least two relations", hint)
+	}
+	h := JoinMethodHint{Rels: p.rels(names), Disallow: disallow}
+	switch method {
+	case "hashjoin":
+		h.Method = HashJoin
+	case "mergejoin":
+		h.Method = MergeJoin
+	case "nestloop":
+		h.Method = NestLoop
+	}
+	p.h.Joins = append(p.h.Joins, h)
+	return nil
+}
+
+func (p *parser) parseScanMethod(hint, method string, disallow bool) error {
+	names, err := p.names()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return p.errorf("%s hint requires a relation", hint)
+	}
+	h := ScanMethodHint{Rel: p.h.Relation(names[0]), Disallow: disallow}
+	switch method {
+	case "seqscan":
+		h.Method = SeqScan
+	case "indexscan":
+		h.Method = IndexScan
+		if !disallow {
+			h.Indexes = names[1:]
+		}
+	}
+	if len(names) > 1 && len(h.Indexes) == 0 {
+		return p.errorf("%s hint accepts a single relation", hint)
+	}
+	p.h.Scans = append(p.h.Scans, h)
+	return nil
+}
+
+func (p *parser) parseRows() error {
+	var names []string
+	for c := p.peek(); c != ')'; c = p.peek() {
+		if c == '#' || c == '+' || c == '-' || c == '*' {
+			break
+		}
+		if c == 0 || c == '(' {
+			return p.errorf("expected name at position %d", p.pos)
+		}
+		n, err := p.name()
+		if err != nil {
+			return err
+		}
+		names = append(names, n)
+	}
+	if len(names) < 2 {
+		return p.errorf("Rows hint requires at least two relations")
+	}
+	correction := p.word()
+	if correction == "" {
+		return p.errorf("Rows hint requires a correction")
+	}
+	h := RowsHint{Rels: p.rels(names)}
+	switch correction[0] {
+	case '#':
+		h.Op = RowsSet
+	case '+':
+		h.Op = RowsAdd
+	case '-':
+		h.Op = RowsSub
+	case '*':
+		h.Op = RowsMul
+	}
+	v, err := strconv.ParseFloat(correction[1:], 64)
+	if err != nil || v < 0 {
+		return p.errorf("invalid Rows hint correction %q", correction)
+	}
+	h.Value = v
+	p.h.Rows = append(p.h.Rows, h)
+	return nil
+}
+
+func (p *parser) parseParallel() error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	h := ParallelHint{Rel: p.h.Relation(name)}
+	if p.peek() != ')' {
+		// The number of ranges scanned in parallel cannot be limited, so the
+		// only number of workers which can be honored is zero.
+		if workers := p.word(); workers != "0" {
+			return p.errorf(
+				"Parallel hint only supports 0 workers, which disables parallel scans, got %q", workers,
+			)
+		}
+		h.Disable = true
+	}
+	if p.peek() != ')' {
+		// The hint is always enforced, so a pg_hint_plan enforcement strength
+		// would have no effect.
+		return p.errorf("Parallel hint does not support an enforcement strength")
+	}
+	p.h.Parallel = append(p.h.Parallel, h)
+	return nil
+}
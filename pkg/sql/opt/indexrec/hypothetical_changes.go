@@ -0,0 +1,236 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package indexrec
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// BuildHypotheticalTables builds a HypotheticalTable for each table changed by
+// the given CREATE INDEX and DROP INDEX statements, which are the index changes
+// of an EXPLAIN (HYPOTHETICAL INDEXES ...) statement. Created indexes are added
+// to the tables as hypothetical indexes, and dropped indexes are made not
+// visible, so that a query optimized with the returned tables is planned as if
+// the index changes were made. Like the hypothetical tables returned by
+// BuildOptAndHypTableMaps, the returned map is keyed by each table's
+// cat.StableID, and can be used to update the table query metadata.
+//
+// The UNIQUE and VISIBLE options of created indexes are ignored: hypothetical
+// indexes are never unique, and are always visible.
+func BuildHypotheticalTables(
+	ctx context.Context, c cat.Catalog, stmts tree.Statements,
+) (map[cat.StableID]cat.Table, error) {
+	b := hypotheticalTablesBuilder{
+		ctx:    ctx,
+		c:      c,
+		tables: make(map[cat.StableID]*HypotheticalTable),
+	}
+	for _, stmt := range stmts {
+		var err error
+		switch stmt := stmt.(type) {
+		case *tree.CreateIndex:
+			err = b.createIndex(stmt)
+		case *tree.DropIndex:
+			err = b.dropIndexes(stmt)
+		default:
+			err = errors.AssertionFailedf("unexpected hypothetical index change %T", stmt)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	hypTables := make(map[cat.StableID]cat.Table, len(b.tables))
+	for id, t := range b.tables {
+		hypTables[id] = t
+	}
+	return hypTables, nil
+}
+
+// hypotheticalTablesBuilder applies hypothetical index changes to the tables
+// they reference.
+type hypotheticalTablesBuilder struct {
+	ctx    context.Context
+	c      cat.Catalog
+	tables map[cat.StableID]*HypotheticalTable
+}
+
+// table returns the HypotheticalTable for the table with the given name, which
+// the current user must have a privilege on. All the changes to the same table
+// are made to the same HypotheticalTable.
+func (b *hypotheticalTablesBuilder) table(tn *tree.TableName) (*HypotheticalTable, error) {
+	ds, _, err := b.c.ResolveDataSource(b.ctx, cat.Flags{}, tn)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := ds.(cat.Table)
+	if !ok || t.IsVirtualTable() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a table", tn.String())
+	}
+	// The index changes reveal the columns and indexes of the table, like SHOW
+	// INDEXES, even if the query does not read it.
+	if err := b.c.CheckAnyPrivilege(b.ctx, t); err != nil {
+		return nil, err
+	}
+	if ht, ok := b.tables[t.ID()]; ok {
+		return ht, nil
+	}
+	ht := &HypotheticalTable{}
+	ht.init(b.c, t)
+	b.tables[t.ID()] = ht
+	return ht, nil
+}
+
+// createIndex adds a hypothetical index for the given CREATE INDEX statement.
+func (b *hypotheticalTablesBuilder) createIndex(n *tree.CreateIndex) error {
+	switch {
+	case n.Predicate != nil:
+		return unimplemented.New("hypothetical partial index",
+			"partial indexes are not supported as hypothetical indexes")
+	case n.Sharded != nil:
+		return unimplemented.New("hypothetical hash-sharded index",
+			"hash-sharded indexes are not supported as hypothetical indexes")
+	case n.PartitionByIndex.ContainsPartitions():
+		return unimplemented.New("hypothetical partitioned index",
+			"partitioned indexes are not supported as hypothetical indexes")
+	}
+	ht, err := b.table(&n.Table)
+	if err != nil {
+		return err
+	}
+	if n.Name != "" {
+		if _, ok := ht.findIndex(n.Name); ok {
+			if n.IfNotExists {
+				return nil
+			}
+			return pgerror.Newf(pgcode.DuplicateRelation, "index with name %q already exists", n.Name)
+		}
+	}
+
+	cols := make([]cat.IndexColumn, len(n.Columns))
+	var keyColOrds []int
+	for i := range n.Columns {
+		elem := &n.Columns[i]
+		if elem.Expr != nil {
+			return unimplemented.New("hypothetical expression index",
+				"expression indexes are not supported as hypothetical indexes")
+		}
+		col, err := ht.findColumn(elem.Column)
+		if err != nil {
+			return err
+		}
+		cols[i] = cat.IndexColumn{Column: col, Descending: elem.Direction == tree.Descending}
+		keyColOrds = append(keyColOrds, col.Ordinal())
+	}
+	forwardCols := cols
+	if n.Inverted {
+		forwardCols = cols[:len(cols)-1]
+		last := cols[len(cols)-1]
+		if !colinfo.ColumnTypeIsInvertedIndexable(last.DatumType()) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %s of type %s is not allowed as the last column in an inverted index",
+				last.ColName(), last.DatumType().Name())
+		}
+	}
+	for _, col := range forwardCols {
+		if !colinfo.ColumnTypeIsIndexable(col.DatumType()) {
+			return unimplemented.NewWithIssueDetailf(35730, col.DatumType().DebugString(),
+				"column %s is of type %s and thus is not indexable", col.ColName(), col.DatumType().Name())
+		}
+	}
+
+	var storedCols []cat.IndexColumn
+	for _, name := range n.Storing {
+		col, err := ht.findColumn(name)
+		if err != nil {
+			return err
+		}
+		for _, ord := range keyColOrds {
+			if ord == col.Ordinal() {
+				return pgerror.Newf(pgcode.DuplicateColumn,
+					"index %q already contains column %q", n.Name, name)
+			}
+		}
+		if !ht.primaryKeyColsOrdSet.Contains(col.Ordinal()) {
+			storedCols = append(storedCols, cat.IndexColumn{Column: col})
+		}
+	}
+
+	if n.Inverted {
+		invertedCol := ht.addInvertedCol(cols[len(cols)-1].Column)
+		cols[len(cols)-1] = cat.IndexColumn{Column: invertedCol}
+	}
+	indexOrd := ht.IndexCount()
+	name := n.Name
+	if name == "" {
+		name = tree.Name(fmt.Sprintf("_hyp_%d", indexOrd))
+	}
+	var hypIndex hypotheticalIndex
+	hypIndex.init(ht, name, cols, indexOrd, n.Inverted, ht.Table.Zone())
+	// Unlike the indexes built for recommendations, which store all the columns
+	// of the table, the index only stores the columns of its STORING clause.
+	if !n.Inverted {
+		hypIndex.storedCols = storedCols
+	}
+	ht.hypotheticalIndexes = append(ht.hypotheticalIndexes, hypIndex)
+	return nil
+}
+
+// dropIndexes makes the indexes of the given DROP INDEX statement not visible.
+func (b *hypotheticalTablesBuilder) dropIndexes(n *tree.DropIndex) error {
+	for _, idxName := range n.IndexList {
+		if idxName.Table.ObjectName == "" {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"hypothetically dropped index %q must be qualified with its table name", idxName.Index)
+		}
+		ht, err := b.table(&idxName.Table)
+		if err != nil {
+			return err
+		}
+		ord, ok := ht.findIndex(tree.Name(idxName.Index))
+		if !ok {
+			if n.IfExists {
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedObject, "index %q does not exist", idxName.Index)
+		}
+		if ord == cat.PrimaryIndex {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"the primary index of table %s cannot be dropped", ht.Name())
+		}
+		if ht.droppedIndexes == nil {
+			ht.droppedIndexes = make(map[cat.IndexOrdinal]cat.Index)
+		}
+		ht.droppedIndexes[ord] = &droppedIndex{Index: ht.Index(ord)}
+	}
+	return nil
+}
+
+// droppedIndex is an index which is dropped by a hypothetical index change.
+// It is not visible, so that the optimizer does not use it to read the table.
+type droppedIndex struct {
+	cat.Index
+}
+
+var _ cat.Index = &droppedIndex{}
+
+// GetInvisibility is part of the cat.Index interface.
+func (di *droppedIndex) GetInvisibility() float64 {
+	return 1.0
+}
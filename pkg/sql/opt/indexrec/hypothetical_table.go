@@ -78,14 +78,17 @@ func BuildOptAndHypTableMaps(
 }
 
 // HypotheticalTable is a wrapper around cat.Table, used for creating index
-// recommendations. The hypotheticalIndexes slice stores fake indexes that could
-// potentially speed up queries to this table.
+// recommendations and for EXPLAIN (HYPOTHETICAL INDEXES ...). The
+// hypotheticalIndexes slice stores fake indexes that could potentially speed up
+// queries to this table, and the droppedIndexes map stores the existing indexes
+// which are hypothetically dropped, keyed by their ordinal.
 type HypotheticalTable struct {
 	cat.Table
 	c                    cat.Catalog
 	invertedCols         []*cat.Column
 	primaryKeyColsOrdSet intsets.Fast
 	hypotheticalIndexes  []hypotheticalIndex
+	droppedIndexes       map[cat.IndexOrdinal]cat.Index
 }
 
 var _ cat.Table = &HypotheticalTable{}
@@ -137,6 +140,9 @@ func (ht *HypotheticalTable) DeletableIndexCount() int {
 
 // Index is part of the cat.Table interface.
 func (ht *HypotheticalTable) Index(i cat.IndexOrdinal) cat.Index {
+	if idx, ok := ht.droppedIndexes[i]; ok {
+		return idx
+	}
 	existingIndexCount := ht.Table.IndexCount()
 	if i < existingIndexCount {
 		return ht.Table.Index(i)
@@ -173,6 +179,28 @@ func (ht *HypotheticalTable) existingRedundantIndex(index *hypotheticalIndex) ca
 	return nil
 }
 
+// findIndex returns the ordinal of the index with the given name, ignoring
+// dropped indexes.
+func (ht *HypotheticalTable) findIndex(name tree.Name) (_ cat.IndexOrdinal, ok bool) {
+	for i, n := 0, ht.IndexCount(); i < n; i++ {
+		if _, dropped := ht.droppedIndexes[i]; !dropped && ht.Index(i).Name() == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// findColumn returns the ordinary column of the embedded table with the given
+// name.
+func (ht *HypotheticalTable) findColumn(name tree.Name) (*cat.Column, error) {
+	for i, n := 0, ht.Table.ColumnCount(); i < n; i++ {
+		if col := ht.Table.Column(i); col.Kind() == cat.Ordinary && col.ColName() == name {
+			return col, nil
+		}
+	}
+	return nil, colinfo.NewUndefinedColumnError(string(name))
+}
+
 // addInvertedCol adds an inverted column corresponding to a source column to
 // the HypotheticalTable.
 func (ht *HypotheticalTable) addInvertedCol(invertedSourceCol *cat.Column) *cat.Column {
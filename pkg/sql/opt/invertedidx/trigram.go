@@ -85,6 +85,25 @@ func (t *trigramFilterPlanner) extractInvertedFilterConditionFromLeaf(
 		allMustMatch = false
 		// Similarity is commutative.
 		commutative = true
+	case *memo.WordSimilarExpr, *memo.WordSimilarCommutatorExpr:
+		// A zero threshold matches every row, including those that share no
+		// trigrams with the constant, so the index cannot be used.
+		if evalCtx.SessionData().TrigramWordSimilarityThreshold == 0 {
+			return inverted.NonInvertedColExpression{}, expr, nil
+		}
+		left, right = expr.Child(0).(opt.ScalarExpr), expr.Child(1).(opt.ScalarExpr)
+		// Any row that passes a non-zero word similarity threshold must share
+		// at least one trigram with the constant, regardless of which side of
+		// the operator the constant is on.
+		allMustMatch = false
+		commutative = true
+	case *memo.StrictWordSimilarExpr, *memo.StrictWordSimilarCommutatorExpr:
+		if evalCtx.SessionData().TrigramStrictWordSimilarityThreshold == 0 {
+			return inverted.NonInvertedColExpression{}, expr, nil
+		}
+		left, right = expr.Child(0).(opt.ScalarExpr), expr.Child(1).(opt.ScalarExpr)
+		allMustMatch = false
+		commutative = true
 	default:
 		// Only the above types are supported.
 		return inverted.NonInvertedColExpression{}, expr, nil
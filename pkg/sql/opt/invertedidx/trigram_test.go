@@ -28,6 +28,8 @@ func TestTryFilterTrigram(t *testing.T) {
 	semaCtx := tree.MakeSemaContext(nil /* resolver */)
 	st := cluster.MakeTestingClusterSettings()
 	evalCtx := eval.NewTestingEvalContext(st)
+	evalCtx.SessionData().TrigramWordSimilarityThreshold = 0.6
+	evalCtx.SessionData().TrigramStrictWordSimilarityThreshold = 0.5
 
 	tc := testcat.New()
 	if _, err := tc.ExecuteDDL(
@@ -92,6 +94,14 @@ func TestTryFilterTrigram(t *testing.T) {
 		{filters: "s % 'lkj' AND s LIKE 'blort'", ok: true, unique: false},
 		{filters: "s % 'lkj' OR s LIKE 'blort'", ok: true, unique: false},
 
+		// Word similarity queries, with the constant on either side.
+		{filters: "'lkj' <% s", ok: true, unique: false},
+		{filters: "s %> 'lkj'", ok: true, unique: false},
+		{filters: "s <% 'lkjsdlkj'", ok: true, unique: false},
+		{filters: "'lkj' <<% s", ok: true, unique: false},
+		{filters: "s %>> 'lkj'", ok: true, unique: false},
+		{filters: "'lkj' <% s OR s LIKE 'blort'", ok: true, unique: false},
+
 		// Equality queries.
 		{filters: "s = 'lkjsdlkj'", ok: true, unique: false},
 		{filters: "s = 'lkj'", ok: true, unique: true},
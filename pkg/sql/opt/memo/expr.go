@@ -760,6 +760,11 @@ type UDFDefinition struct {
 	// result of the routine. This invariant is enforced when the PLpgSQL routine
 	// is built. CursorDeclaration may be unset.
 	CursorDeclaration *tree.RoutineOpenCursor
+
+	// SessionOverride, if set, describes changes to the session that are in
+	// effect while the routine is executing, e.g. for SECURITY DEFINER routines
+	// and routines with SET clauses.
+	SessionOverride *tree.RoutineSessionOverride
 }
 
 // ExceptionBlock contains the information needed to match and handle errors in
@@ -776,6 +781,15 @@ type ExceptionBlock struct {
 	Actions []*UDFDefinition
 }
 
+// TxnOpString returns a string representation of the transaction control
+// operation, including the AND CHAIN clause if it was specified.
+func (p *TxnControlPrivate) TxnOpString() string {
+	if p.Chain {
+		return p.TxnOp.String() + " AND CHAIN"
+	}
+	return p.TxnOp.String()
+}
+
 // WindowFrame denotes the definition of a window frame for an individual
 // window function, excluding the OFFSET expressions, if present.
 type WindowFrame struct {
@@ -1079,7 +1079,7 @@ func (f *ExprFmtCtx) formatScalarWithLabel(
 
 	case opt.TxnControlOp:
 		controlExpr := scalar.(*TxnControlExpr)
-		fmt.Fprintf(f.Buffer, "%s; CALL %s", controlExpr.TxnOp, controlExpr.Def.Name)
+		fmt.Fprintf(f.Buffer, "%s; CALL %s", controlExpr.TxnOpString(), controlExpr.Def.Name)
 		f.FormatScalarProps(scalar)
 		tp = tp.Child(f.Buffer.String())
 		formatRoutineArgs(controlExpr.Args, tp)
@@ -1144,7 +1144,7 @@ func (f *ExprFmtCtx) formatScalarWithLabel(
 			intercepted = true
 		case *TxnControlExpr:
 			// As for UDFCallExpr, the arguments and body will be printed below.
-			fmt.Fprintf(f.Buffer, "%s; CALL %s", t.TxnOp, t.Def.Name)
+			fmt.Fprintf(f.Buffer, "%s; CALL %s", t.TxnOpString(), t.Def.Name)
 			intercepted = true
 		}
 	}
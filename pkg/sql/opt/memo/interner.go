@@ -552,6 +552,9 @@ func (h *hasher) HashExplainOptions(val tree.ExplainOptions) {
 		}
 	}
 	h.hash = hash
+	for _, stmt := range val.HypotheticalIndexes {
+		h.HashUint64(uint64(reflect.ValueOf(stmt).Pointer()))
+	}
 }
 
 func (h *hasher) HashStatementReturnType(val tree.StatementReturnType) {
@@ -1023,7 +1026,16 @@ func (h *hasher) IsFKCascadesEqual(l, r FKCascades) bool {
 }
 
 func (h *hasher) IsExplainOptionsEqual(l, r tree.ExplainOptions) bool {
-	return l == r
+	if l.Mode != r.Mode || l.Flags != r.Flags ||
+		len(l.HypotheticalIndexes) != len(r.HypotheticalIndexes) {
+		return false
+	}
+	for i := range l.HypotheticalIndexes {
+		if l.HypotheticalIndexes[i] != r.HypotheticalIndexes[i] {
+			return false
+		}
+	}
+	return true
 }
 
 func (h *hasher) IsStatementReturnTypeEqual(l, r tree.StatementReturnType) bool {
@@ -1344,6 +1356,19 @@ func (h *hasher) IsUDFDefinitionEqual(l, r *UDFDefinition) bool {
 	} else if r.CursorDeclaration != nil {
 		return false
 	}
+	if l.SessionOverride != nil {
+		if r.SessionOverride == nil || l.SessionOverride.User != r.SessionOverride.User ||
+			len(l.SessionOverride.Config) != len(r.SessionOverride.Config) {
+			return false
+		}
+		for i := range l.SessionOverride.Config {
+			if l.SessionOverride.Config[i] != r.SessionOverride.Config[i] {
+				return false
+			}
+		}
+	} else if r.SessionOverride != nil {
+		return false
+	}
 	return h.IsColListEqual(l.Params, r.Params) && l.IsRecursive == r.IsRecursive
 }
 
@@ -487,6 +487,7 @@ func (b *logicalPropsBuilder) buildJoinProps(join RelExpr, rel *props.Relational
 	// ----------
 	if !b.disableStats {
 		b.sb.buildJoin(join, rel, &h)
+		b.sb.applyRowsHint(join, rel)
 	}
 }
 
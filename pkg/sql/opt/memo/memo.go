@@ -193,6 +193,8 @@ type Memo struct {
 	useImprovedDistinctOnLimitHintCosting      bool
 	useImprovedTrigramSimilaritySelectivity    bool
 	trigramSimilarityThreshold                 float64
+	trigramWordSimilarityThreshold             float64
+	trigramStrictWordSimilarityThreshold       float64
 	splitScanLimit                             int32
 	useImprovedZigzagJoinCosting               bool
 	useImprovedMultiColumnSelectivityEstimate  bool
@@ -204,6 +206,10 @@ type Memo struct {
 	// memo staleness calculation.
 	txnIsoLevel isolation.Level
 
+	// hints contains the plan hints given in the hint comment of the statement,
+	// if any. They are set via a call to SetHints after Init.
+	hints planHints
+
 	// curRank is the highest currently in-use scalar expression rank.
 	curRank opt.ScalarRank
 
@@ -277,6 +283,8 @@ func (m *Memo) Init(ctx context.Context, evalCtx *eval.Context) {
 		useImprovedDistinctOnLimitHintCosting:      evalCtx.SessionData().OptimizerUseImprovedDistinctOnLimitHintCosting,
 		useImprovedTrigramSimilaritySelectivity:    evalCtx.SessionData().OptimizerUseImprovedTrigramSimilaritySelectivity,
 		trigramSimilarityThreshold:                 evalCtx.SessionData().TrigramSimilarityThreshold,
+		trigramWordSimilarityThreshold:             evalCtx.SessionData().TrigramWordSimilarityThreshold,
+		trigramStrictWordSimilarityThreshold:       evalCtx.SessionData().TrigramStrictWordSimilarityThreshold,
 		splitScanLimit:                             evalCtx.SessionData().OptSplitScanLimit,
 		useImprovedZigzagJoinCosting:               evalCtx.SessionData().OptimizerUseImprovedZigzagJoinCosting,
 		useImprovedMultiColumnSelectivityEstimate:  evalCtx.SessionData().OptimizerUseImprovedMultiColumnSelectivityEstimate,
@@ -439,6 +447,8 @@ func (m *Memo) IsStale(
 		m.useImprovedDistinctOnLimitHintCosting != evalCtx.SessionData().OptimizerUseImprovedDistinctOnLimitHintCosting ||
 		m.useImprovedTrigramSimilaritySelectivity != evalCtx.SessionData().OptimizerUseImprovedTrigramSimilaritySelectivity ||
 		m.trigramSimilarityThreshold != evalCtx.SessionData().TrigramSimilarityThreshold ||
+		m.trigramWordSimilarityThreshold != evalCtx.SessionData().TrigramWordSimilarityThreshold ||
+		m.trigramStrictWordSimilarityThreshold != evalCtx.SessionData().TrigramStrictWordSimilarityThreshold ||
 		m.splitScanLimit != evalCtx.SessionData().OptSplitScanLimit ||
 		m.useImprovedZigzagJoinCosting != evalCtx.SessionData().OptimizerUseImprovedZigzagJoinCosting ||
 		m.useImprovedMultiColumnSelectivityEstimate != evalCtx.SessionData().OptimizerUseImprovedMultiColumnSelectivityEstimate ||
@@ -574,6 +584,9 @@ func (m *Memo) Detach() {
 	// It is important to not hold on to the EvalCtx in the logicalPropsBuilder
 	// (#57059).
 	m.logPropsBuilder = logicalPropsBuilder{}
+	// The cached relations of each group refer to expressions which will not be
+	// reused.
+	m.hints.groupRels = nil
 
 	// Clear all column statistics from every relational expression in the memo.
 	// This is used to free up the potentially large amount of memory used by
@@ -465,6 +465,18 @@ func TestMemoIsStale(t *testing.T) {
 	stale()
 	evalCtx.SessionData().TrigramSimilarityThreshold = 0
 
+	// Stale pg_trgm.word_similarity_threshold.
+	evalCtx.SessionData().TrigramWordSimilarityThreshold = 0.5
+	stale()
+	evalCtx.SessionData().TrigramWordSimilarityThreshold = 0
+	notStale()
+
+	// Stale pg_trgm.strict_word_similarity_threshold.
+	evalCtx.SessionData().TrigramStrictWordSimilarityThreshold = 0.5
+	stale()
+	evalCtx.SessionData().TrigramStrictWordSimilarityThreshold = 0
+	notStale()
+
 	// Stale opt_split_scan_limit.
 	evalCtx.SessionData().OptSplitScanLimit = 100
 	stale()
@@ -0,0 +1,182 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package memo
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/hints"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+)
+
+// planHints holds the plan hints of a statement along with the state needed to
+// match the relations they name against memo expressions.
+type planHints struct {
+	hints *hints.Hints
+
+	// tableRels maps the tables in the metadata to the relations named in the
+	// hints which refer to them. present is the set of relations which refer to
+	// at least one table; like pg_hint_plan, hints naming unknown relations are
+	// ignored. numTables is the number of tables in the metadata when tableRels
+	// and present were computed.
+	tableRels map[opt.TableID]int
+	present   intsets.Fast
+	numTables int
+
+	// groupRels caches the relations referenced by each memo group, keyed by
+	// the first expression in the group.
+	groupRels map[RelExpr]hints.RelSet
+}
+
+// SetHints sets the plan hints that apply to the memo. It must be called after
+// Init and before any expressions are added to the memo, since the hints can
+// affect their logical properties.
+func (m *Memo) SetHints(h *hints.Hints) {
+	m.hints = planHints{hints: h}
+}
+
+// Hints returns the plan hints that apply to the memo, or nil if there are
+// none.
+func (m *Memo) Hints() *hints.Hints {
+	return m.hints.hints
+}
+
+// resolveHintRelations maps the tables in the metadata to the relations named
+// in the hints, if tables were added to the metadata since the last call. A
+// relation refers to the tables with that alias or, if no table has that
+// alias, to the tables with that name.
+func (m *Memo) resolveHintRelations() {
+	tables := m.metadata.AllTables()
+	if m.hints.numTables == len(tables) {
+		return
+	}
+	m.hints.numTables = len(tables)
+	m.hints.tableRels = make(map[opt.TableID]int, len(tables))
+	m.hints.present = intsets.Fast{}
+	relation := func(name string) (int, bool) {
+		for i, n := range m.hints.hints.Relations {
+			if n == name {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+	var aliases intsets.Fast
+	for i := range tables {
+		if rel, ok := relation(string(tables[i].Alias.ObjectName)); ok {
+			m.hints.tableRels[tables[i].MetaID] = rel
+			m.hints.present.Add(rel)
+			aliases.Add(rel)
+		}
+	}
+	for i := range tables {
+		if _, ok := m.hints.tableRels[tables[i].MetaID]; ok {
+			continue
+		}
+		if rel, ok := relation(string(tables[i].Table.Name())); ok && !aliases.Contains(rel) {
+			m.hints.tableRels[tables[i].MetaID] = rel
+			m.hints.present.Add(rel)
+		}
+	}
+}
+
+// HintRelation returns the ordinal in the hints of the relation referring to
+// the given table, or ok=false if no relation in the hints refers to it.
+func (m *Memo) HintRelation(tabID opt.TableID) (rel int, ok bool) {
+	if m.hints.hints == nil {
+		return 0, false
+	}
+	m.resolveHintRelations()
+	rel, ok = m.hints.tableRels[tabID]
+	return rel, ok
+}
+
+// HintApplies returns true if all of the given relations named in the hints
+// refer to tables in the statement.
+func (m *Memo) HintApplies(rels intsets.Fast) bool {
+	m.resolveHintRelations()
+	return rels.SubsetOf(m.hints.present)
+}
+
+// HintRelations returns the set of relations referenced by the memo group of
+// the given expression. It must only be called if the memo has hints.
+func (m *Memo) HintRelations(e RelExpr) hints.RelSet {
+	e = e.FirstExpr()
+	if rels, ok := m.hints.groupRels[e]; ok {
+		return rels
+	}
+	var rels hints.RelSet
+	addTable := func(tabID opt.TableID) {
+		if rel, ok := m.HintRelation(tabID); ok {
+			rels.Named.Add(rel)
+		} else {
+			rels.Other = true
+		}
+	}
+	switch t := e.(type) {
+	case *ScanExpr:
+		addTable(t.Table)
+	case *PlaceholderScanExpr:
+		addTable(t.Table)
+	case *ZigzagJoinExpr:
+		addTable(t.LeftTable)
+	default:
+		for i, n := 0, e.ChildCount(); i < n; i++ {
+			if child, ok := e.Child(i).(RelExpr); ok {
+				rels = rels.Union(m.HintRelations(child))
+			}
+		}
+		switch t := e.(type) {
+		case *LookupJoinExpr:
+			addTable(t.Table)
+		case *InvertedJoinExpr:
+			addTable(t.Table)
+		}
+	}
+	if m.hints.groupRels == nil {
+		m.hints.groupRels = make(map[RelExpr]hints.RelSet)
+	}
+	m.hints.groupRels[e] = rels
+	return rels
+}
+
+// RowsHint returns the Rows hint which corrects the row count of the given
+// join, or ok=false if there is none.
+func (m *Memo) RowsHint(join RelExpr) (_ *hints.RowsHint, ok bool) {
+	if m.hints.hints == nil || len(m.hints.hints.Rows) == 0 {
+		return nil, false
+	}
+	rels := m.HintRelations(join)
+	for i := range m.hints.hints.Rows {
+		h := &m.hints.hints.Rows[i]
+		if rels.Equals(h.Rels) && m.HintApplies(h.Rels) {
+			return h, true
+		}
+	}
+	return nil, false
+}
+
+// ParallelHint returns the Parallel hint for the given table, or ok=false if
+// there is none.
+func (m *Memo) ParallelHint(tabID opt.TableID) (_ *hints.ParallelHint, ok bool) {
+	if m.hints.hints == nil || len(m.hints.hints.Parallel) == 0 {
+		return nil, false
+	}
+	rel, ok := m.HintRelation(tabID)
+	if !ok {
+		return nil, false
+	}
+	for i := range m.hints.hints.Parallel {
+		if m.hints.hints.Parallel[i].Rel == rel {
+			return &m.hints.hints.Parallel[i], true
+		}
+	}
+	return nil, false
+}
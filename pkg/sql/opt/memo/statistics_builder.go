@@ -621,7 +621,8 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 		return stats
 	}
 
-	tab := sb.md.Table(tabID)
+	tabMeta := sb.md.TableMeta(tabID)
+	tab := tabMeta.Table
 	// Create a mapping from table column ordinals to inverted index column
 	// ordinals. This allows us to do a fast lookup while iterating over all
 	// stats from a statistic's column to any associated inverted columns.
@@ -681,7 +682,20 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 
 			var cols opt.ColSet
 			var colOrd int
-			for i := 0; i < stat.ColumnCount(); i++ {
+			isExprStat := stat.Expression() != ""
+			if isExprStat {
+				// Statistics on expressions are stored on the column synthesized for
+				// the expression, which is treated like a virtual computed column. The
+				// expression is not added to the metadata if the session does not use
+				// statistics on virtual computed columns, or if it is not immutable.
+				col, ok := tabMeta.StatisticExprCol(stat.Expression())
+				if !ok {
+					continue
+				}
+				cols.Add(col)
+				stats.VirtualCols.Add(col)
+			}
+			for i := 0; i < stat.ColumnCount() && !isExprStat; i++ {
 				colOrd = stat.ColumnOrdinal(i)
 				col := tabID.ColumnID(colOrd)
 				cols.Add(col)
@@ -698,8 +712,10 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 			}
 
 			// We currently only use average column sizes of single column
-			// statistics, so we can ignore multi-column average sizes.
-			if stat.ColumnCount() == 1 && stat.AvgSize() != 0 {
+			// statistics, so we can ignore multi-column average sizes. Columns
+			// synthesized for expressions are not table columns, so they have no
+			// average size.
+			if !isExprStat && stat.ColumnCount() == 1 && stat.AvgSize() != 0 {
 				if stats.AvgColSizes == nil {
 					stats.AvgColSizes = make([]uint64, tab.ColumnCount())
 				}
@@ -711,7 +727,11 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 			seenInvertedStat := false
 			invertedStatistic := false
 			var invertedColOrds []int
-			if needHistogram {
+			if needHistogram && isExprStat {
+				// The type of the expression may have changed since the statistic was
+				// collected.
+				needHistogram = stat.HistogramType().Identical(sb.md.ColumnMeta(cols.SingleColumn()).Type)
+			} else if needHistogram {
 				info := invertedIndexCols[stat.ColumnOrdinal(0)]
 				invertedColOrds = info.invIdxColOrds
 				seenInvertedStat = info.foundInvertedHistogram
@@ -1777,6 +1797,34 @@ func (sb *statisticsBuilder) adjustNullCountsForOuterJoins(
 	}
 }
 
+// applyRowsHint corrects the estimated row count of a join according to the
+// Rows hint for its relations, if there is one.
+func (sb *statisticsBuilder) applyRowsHint(join RelExpr, relProps *props.Relational) {
+	h, ok := join.Memo().RowsHint(join)
+	if !ok {
+		return
+	}
+	s := relProps.Statistics()
+	rowCount := h.Apply(s.RowCount)
+	if rowCount == s.RowCount {
+		return
+	}
+	s.RowCount = rowCount
+	if s.RowCount > float64(relProps.Cardinality.Max) && relProps.Cardinality.Max != math.MaxUint32 {
+		s.RowCount = float64(relProps.Cardinality.Max)
+	} else if s.RowCount < float64(relProps.Cardinality.Min) {
+		s.RowCount = float64(relProps.Cardinality.Min)
+	}
+	if s.RowCount <= 0 && relProps.Cardinality.Max > 0 {
+		s.RowCount = epsilon
+	}
+
+	// The column stats were estimated from the original row count. If any
+	// column stats are needed, colStatJoin will estimate them again from the
+	// input, capped at the corrected row count.
+	s.ColStats.Clear()
+}
+
 // colStatfromJoinLeft returns a column statistic from the left input of a join.
 func (sb *statisticsBuilder) colStatFromJoinLeft(
 	cols opt.ColSet, join RelExpr,
@@ -5123,6 +5171,19 @@ func (sb *statisticsBuilder) factorOutVirtualCols(
 	virtExprs := make([]virtExpr, virtualCols.Len())
 	virtualCols.ForEach(func(colID opt.ColumnID) {
 		col := sb.md.ColumnMeta(colID)
+		if col.Table == 0 {
+			// The column was synthesized for a statistic on an expression.
+			statExpr, ok := sb.statisticExpr(colID)
+			if !ok {
+				panic(errors.AssertionFailedf(
+					"could not find statistic expression for column %v", colID,
+				))
+			}
+			if statExpr.OuterCols.SubsetOf(shared.OuterCols) {
+				virtExprs = append(virtExprs, virtExpr{colID: colID, expr: statExpr.Scalar})
+			}
+			return
+		}
 		tab := sb.md.TableMeta(col.Table)
 		if !tab.ColsInComputedColsExpressions.Intersects(shared.OuterCols) {
 			return
@@ -5153,6 +5214,20 @@ func (sb *statisticsBuilder) factorOutVirtualCols(
 	return replace(e)
 }
 
+// statisticExpr returns the expression of the table statistic collected on an
+// expression for which the given column was synthesized.
+func (sb *statisticsBuilder) statisticExpr(colID opt.ColumnID) (opt.StatisticExpr, bool) {
+	tables := sb.md.AllTables()
+	for i := range tables {
+		for _, e := range tables[i].StatisticExprs() {
+			if e.Col == colID {
+				return e, true
+			}
+		}
+	}
+	return opt.StatisticExpr{}, false
+}
+
 // filterCount tracks counts of different types of filters. It is used to track
 // the number of filters which are not applied to selectivities via more exact
 // means like constraints and histogram filtering.
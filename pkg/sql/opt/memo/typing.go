@@ -497,6 +497,14 @@ func NormalizeComparison(op opt.Operator) (newOp opt.Operator, flipped, not bool
 	case opt.GeOp:
 		// Ge(left, right) is implemented as Le(right, left)
 		return opt.LeOp, true, false
+	case opt.WordSimilarCommutatorOp:
+		// WordSimilarCommutator(left, right) is implemented as
+		// WordSimilar(right, left)
+		return opt.WordSimilarOp, true, false
+	case opt.StrictWordSimilarCommutatorOp:
+		// StrictWordSimilarCommutator(left, right) is implemented as
+		// StrictWordSimilar(right, left)
+		return opt.StrictWordSimilarOp, true, false
 	case opt.NotInOp:
 		// NotIn(left, right) is implemented as !In(left, right)
 		return opt.InOp, false, true
@@ -16,6 +16,7 @@ import (
 	"math/bits"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/multiregion"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
@@ -43,9 +44,33 @@ type SchemaID int32
 // 1 << privilege.Kind, so that multiple privileges can be stored.
 type privilegeBitmap uint64
 
+// ownerDep identifies an object on which the privileges needed by the body of
+// a SECURITY DEFINER routine were checked for the owner of the routine.
+type ownerDep struct {
+	id    cat.StableID
+	owner username.SQLUsername
+}
+
+// builtinRef is a name used to reference a builtin function, along with the
+// search_path used to resolve it.
+type builtinRef struct {
+	name       tree.UnresolvedName
+	searchPath searchPathID
+}
+
+// searchPathID identifies the search_path used to resolve a name referenced by
+// the query. Zero means the search_path of the session. Other values are one
+// plus an index into Metadata.searchPaths.
+type searchPathID int
+
 type udfDep struct {
 	overload        *tree.Overload
 	invocationTypes []*types.T
+	// checkPrivilege is true if the execution privilege of the current user
+	// must be re-checked on reuse of the memo. It is false for routines that are
+	// only invoked from within SECURITY DEFINER routines, since their privileges
+	// were checked for the owner of the calling routine.
+	checkPrivilege bool
 }
 
 // Metadata assigns unique ids to the columns, tables, and other metadata used
@@ -140,11 +165,29 @@ type Metadata struct {
 	// query depends on.
 	privileges map[cat.StableID]privilegeBitmap
 
+	// ownerPrivileges stores the privileges needed by the bodies of SECURITY
+	// DEFINER routines, which are checked for the owners of the routines rather
+	// than for the current user. For routines, the EXECUTE privilege is stored.
+	ownerPrivileges map[ownerDep]privilegeBitmap
+
 	// builtinRefsByName stores the names used to reference builtin functions in
 	// the query. This is necessary to handle the case where changes to the search
 	// path cause a function call to be resolved to a UDF with the same signature
 	// as a builtin function.
-	builtinRefsByName map[tree.UnresolvedName]struct{}
+	builtinRefsByName map[builtinRef]struct{}
+
+	// searchPaths stores the search_paths set by the SET clauses of the
+	// routines invoked by the query, and refSearchPaths maps the names in
+	// objectRefsByName which were resolved in the bodies of these routines to
+	// the search_path used to resolve them. Names are resolved again with the
+	// same search_path when the dependencies are checked.
+	searchPaths    [][]string
+	refSearchPaths map[*tree.UnresolvedObjectName]searchPathID
+
+	// routineSearchPath is the search_path set by the SET clause of the routine
+	// whose body is being built, or zero if there is none. It is only used
+	// while the query is built.
+	routineSearchPath searchPathID
 
 	// NOTE! When adding fields here, update Init (if reusing allocated
 	// data structures is desired), CopyFrom and TestMetadata.
@@ -211,12 +254,26 @@ func (md *Metadata) Init() {
 		delete(md.privileges, id)
 	}
 
+	ownerPrivileges := md.ownerPrivileges
+	for dep := range md.ownerPrivileges {
+		delete(md.ownerPrivileges, dep)
+	}
+
 	builtinRefsByName := md.builtinRefsByName
 	if builtinRefsByName == nil {
-		builtinRefsByName = make(map[tree.UnresolvedName]struct{})
+		builtinRefsByName = make(map[builtinRef]struct{})
 	}
-	for name := range md.builtinRefsByName {
-		delete(md.builtinRefsByName, name)
+	for ref := range md.builtinRefsByName {
+		delete(md.builtinRefsByName, ref)
+	}
+
+	searchPaths := md.searchPaths
+	for i := range searchPaths {
+		searchPaths[i] = nil
+	}
+	refSearchPaths := md.refSearchPaths
+	for name := range md.refSearchPaths {
+		delete(md.refSearchPaths, name)
 	}
 
 	// This initialization pattern ensures that fields are not unwittingly
@@ -231,7 +288,10 @@ func (md *Metadata) Init() {
 	md.udfDeps = udfDeps
 	md.objectRefsByName = objectRefsByName
 	md.privileges = privileges
+	md.ownerPrivileges = ownerPrivileges
 	md.builtinRefsByName = builtinRefsByName
+	md.searchPaths = searchPaths[:0]
+	md.refSearchPaths = refSearchPaths
 }
 
 // CopyFrom initializes the metadata with a copy of the provided metadata.
@@ -247,7 +307,8 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 		len(md.sequences) != 0 || len(md.views) != 0 || len(md.userDefinedTypes) != 0 ||
 		len(md.userDefinedTypesSlice) != 0 || len(md.dataSourceDeps) != 0 ||
 		len(md.udfDeps) != 0 || len(md.objectRefsByName) != 0 || len(md.privileges) != 0 ||
-		len(md.builtinRefsByName) != 0 {
+		len(md.ownerPrivileges) != 0 || len(md.builtinRefsByName) != 0 ||
+		len(md.searchPaths) != 0 || len(md.refSearchPaths) != 0 {
 		panic(errors.AssertionFailedf("CopyFrom requires empty destination"))
 	}
 	md.schemas = append(md.schemas, from.schemas...)
@@ -313,11 +374,28 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 		md.privileges[id] = privilegeSet
 	}
 
-	for name := range from.builtinRefsByName {
+	for dep, privilegeSet := range from.ownerPrivileges {
+		if md.ownerPrivileges == nil {
+			md.ownerPrivileges = make(map[ownerDep]privilegeBitmap)
+		}
+		md.ownerPrivileges[dep] = privilegeSet
+	}
+
+	for ref := range from.builtinRefsByName {
 		if md.builtinRefsByName == nil {
-			md.builtinRefsByName = make(map[tree.UnresolvedName]struct{})
+			md.builtinRefsByName = make(map[builtinRef]struct{})
 		}
-		md.builtinRefsByName[name] = struct{}{}
+		md.builtinRefsByName[ref] = struct{}{}
+	}
+
+	// The names are shared with the copied metadata, so the search_paths used
+	// to resolve them can be looked up by pointer.
+	md.searchPaths = append(md.searchPaths, from.searchPaths...)
+	for name, searchPath := range from.refSearchPaths {
+		if md.refSearchPaths == nil {
+			md.refSearchPaths = make(map[*tree.UnresolvedObjectName]searchPathID)
+		}
+		md.refSearchPaths[name] = searchPath
 	}
 
 	md.sequences = append(md.sequences, from.sequences...)
@@ -362,10 +440,70 @@ func (md *Metadata) AddDependency(name MDDepName, ds cat.DataSource, priv privil
 	md.privileges[id] = md.privileges[id] | (1 << priv)
 	if name.byID == 0 {
 		// This data source was referenced by name.
-		md.objectRefsByName[id] = append(md.objectRefsByName[id], name.byName.ToUnresolvedObjectName())
+		md.addObjectRef(id, name.byName.ToUnresolvedObjectName())
 	}
 }
 
+// AddOwnerPrivilege tracks a privilege needed by the body of a SECURITY
+// DEFINER routine, which was checked for the owner of the routine. The object
+// must also be added as a dependency with AddDependency or
+// AddUserDefinedFunction. For routines, the privilege is EXECUTE. The privilege
+// is checked again for the owner by CheckDependencies, since the owner may have
+// lost it, for example because it was removed from a role, without any change
+// to the descriptor of the object.
+func (md *Metadata) AddOwnerPrivilege(
+	id cat.StableID, owner username.SQLUsername, priv privilege.Kind,
+) {
+	if md.ownerPrivileges == nil {
+		md.ownerPrivileges = make(map[ownerDep]privilegeBitmap)
+	}
+	dep := ownerDep{id: id, owner: owner}
+	md.ownerPrivileges[dep] = md.ownerPrivileges[dep] | (1 << priv)
+}
+
+// SetRoutineSearchPath sets the search_path used to resolve the names which are
+// added to the metadata while the body of a routine with a SET search_path
+// clause is built. It returns a function that restores the previous
+// search_path.
+func (md *Metadata) SetRoutineSearchPath(paths []string) (restore func()) {
+	prev := md.routineSearchPath
+	md.searchPaths = append(md.searchPaths, paths)
+	md.routineSearchPath = searchPathID(len(md.searchPaths))
+	return func() { md.routineSearchPath = prev }
+}
+
+// addObjectRef adds a name used to reference the object with the given ID,
+// along with the search_path used to resolve it.
+func (md *Metadata) addObjectRef(id cat.StableID, name *tree.UnresolvedObjectName) {
+	md.objectRefsByName[id] = append(md.objectRefsByName[id], name)
+	if md.routineSearchPath != 0 {
+		if md.refSearchPaths == nil {
+			md.refSearchPaths = make(map[*tree.UnresolvedObjectName]searchPathID)
+		}
+		md.refSearchPaths[name] = md.routineSearchPath
+	}
+}
+
+// withSearchPath calls fn with the given search_path set in the session data of
+// evalCtx, so that names are resolved as they were when they were added to the
+// metadata.
+func (md *Metadata) withSearchPath(
+	evalCtx *eval.Context, searchPath searchPathID, fn func() error,
+) error {
+	if searchPath == 0 {
+		return fn()
+	}
+	stack := evalCtx.SessionDataStack
+	stack.PushTopClone()
+	sd := stack.Top()
+	sd.SearchPath = sd.SearchPath.UpdatePaths(md.searchPaths[searchPath-1])
+	err := fn()
+	if popErr := stack.Pop(); popErr != nil {
+		return errors.CombineErrors(err, popErr)
+	}
+	return err
+}
+
 // CheckDependencies resolves (again) each database object on which this
 // metadata depends, in order to check the following conditions:
 //  1. The object has not been modified.
@@ -390,7 +528,10 @@ func (md *Metadata) CheckDependencies(
 			// The data source was referenced by name at least once.
 			for _, name := range names {
 				tableName := name.ToTableName()
-				toCheck, _, err = optCatalog.ResolveDataSource(ctx, cat.Flags{}, &tableName)
+				err = md.withSearchPath(evalCtx, md.refSearchPaths[name], func() (err error) {
+					toCheck, _, err = optCatalog.ResolveDataSource(ctx, cat.Flags{}, &tableName)
+					return err
+				})
 				if err != nil || !dataSource.Equals(toCheck) {
 					return false, maybeSwallowMetadataResolveErr(err)
 				}
@@ -414,7 +555,11 @@ func (md *Metadata) CheckDependencies(
 		id := cat.StableID(catid.UserDefinedOIDToID(typ.Oid()))
 		if names, ok := md.objectRefsByName[id]; ok {
 			for _, name := range names {
-				toCheck, err := optCatalog.ResolveType(ctx, name)
+				var toCheck *types.T
+				err := md.withSearchPath(evalCtx, md.refSearchPaths[name], func() (err error) {
+					toCheck, err = optCatalog.ResolveType(ctx, name)
+					return err
+				})
 				if err != nil || typ.Oid() != toCheck.Oid() ||
 					typ.TypeMeta.Version != toCheck.TypeMeta.Version {
 					return false, maybeSwallowMetadataResolveErr(err)
@@ -433,50 +578,12 @@ func (md *Metadata) CheckDependencies(
 		overload := dep.overload
 		if names, ok := md.objectRefsByName[id]; ok {
 			for _, name := range names {
-				definition, err := optCatalog.ResolveFunction(
-					ctx, tree.MakeUnresolvedFunctionName(name.ToUnresolvedName()),
-					&evalCtx.SessionData().SearchPath,
-				)
-				if err != nil {
-					return false, maybeSwallowMetadataResolveErr(err)
-				}
-				routineObj := tree.RoutineObj{
-					FuncName: name.ToRoutineName(),
-					Params:   make(tree.RoutineParams, len(dep.invocationTypes)),
-				}
-				for i := 0; i < len(routineObj.Params); i++ {
-					routineObj.Params[i] = tree.RoutineParam{
-						Type: dep.invocationTypes[i],
-						// Since we're not in the DROP context, it's sufficient
-						// to specify only the input parameters.
-						Class: tree.RoutineParamIn,
-						// Note that we don't need to specify the DefaultVal
-						// here because invocationTypes specifies the argument
-						// schema that was actually used. Instead, we will ask
-						// for matching overloads to use their DEFAULT
-						// expressions if necessary.
-					}
-				}
-				// NOTE: We match for all types of routines here, including
-				// procedures so that if a function has been dropped and a
-				// procedure is created with the same signature, we do not get a
-				// "<func> is not a function" error here. Instead, we'll return
-				// false and attempt to rebuild the statement.
-				routineType := tree.UDFRoutine | tree.BuiltinRoutine | tree.ProcedureRoutine
-				// Always allowing using DEFAULT expressions for input
-				// parameters since the signature of the routine might have
-				// changed even though the invocation remained the same.
-				const tryDefaultExprs = true
-				toCheck, err := definition.MatchOverload(
-					ctx,
-					optCatalog,
-					&routineObj,
-					&evalCtx.SessionData().SearchPath,
-					routineType,
-					false, /* inDropContext */
-					tryDefaultExprs,
-				)
-				if err != nil || toCheck.Oid != overload.Oid || toCheck.Version != overload.Version {
+				var upToDate bool
+				err := md.withSearchPath(evalCtx, md.refSearchPaths[name], func() (err error) {
+					upToDate, err = checkRoutineRef(ctx, evalCtx, optCatalog, name, dep)
+					return err
+				})
+				if err != nil || !upToDate {
 					return false, maybeSwallowMetadataResolveErr(err)
 				}
 			}
@@ -491,17 +598,35 @@ func (md *Metadata) CheckDependencies(
 	// Check that the role still has execution privilege on the user defined
 	// functions.
 	for _, dep := range md.udfDeps {
-		if err := optCatalog.CheckExecutionPrivilege(ctx, dep.overload.Oid); err != nil {
+		if !dep.checkPrivilege {
+			continue
+		}
+		if err := optCatalog.CheckExecutionPrivilege(
+			ctx, dep.overload.Oid, optCatalog.GetCurrentUser(),
+		); err != nil {
 			return false, err
 		}
 	}
+	// Check that the owners of SECURITY DEFINER routines still have the
+	// execution privilege on the routines invoked by their bodies.
+	for dep := range md.ownerPrivileges {
+		if udf, ok := md.udfDeps[dep.id]; ok {
+			if err := optCatalog.CheckExecutionPrivilege(ctx, udf.overload.Oid, dep.owner); err != nil {
+				return false, err
+			}
+		}
+	}
 
 	// Check that any references to builtin functions do not now resolve to a UDF
 	// with the same signature (e.g. after changes to the search path).
-	for name := range md.builtinRefsByName {
-		definition, err := optCatalog.ResolveFunction(
-			ctx, tree.MakeUnresolvedFunctionName(&name), &evalCtx.SessionData().SearchPath,
-		)
+	for ref := range md.builtinRefsByName {
+		var definition *tree.ResolvedFunctionDefinition
+		err := md.withSearchPath(evalCtx, ref.searchPath, func() (err error) {
+			definition, err = optCatalog.ResolveFunction(
+				ctx, tree.MakeUnresolvedFunctionName(&ref.name), &evalCtx.SessionData().SearchPath,
+			)
+			return err
+		})
 		if err != nil {
 			return false, maybeSwallowMetadataResolveErr(err)
 		}
@@ -515,6 +640,65 @@ func (md *Metadata) CheckDependencies(
 	return true, nil
 }
 
+// checkRoutineRef resolves the given name used to reference a user-defined
+// routine again, and returns false if it does not resolve to the same version of
+// the same routine.
+func checkRoutineRef(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	optCatalog cat.Catalog,
+	name *tree.UnresolvedObjectName,
+	dep udfDep,
+) (upToDate bool, _ error) {
+	definition, err := optCatalog.ResolveFunction(
+		ctx, tree.MakeUnresolvedFunctionName(name.ToUnresolvedName()),
+		&evalCtx.SessionData().SearchPath,
+	)
+	if err != nil {
+		return false, err
+	}
+	routineObj := tree.RoutineObj{
+		FuncName: name.ToRoutineName(),
+		Params:   make(tree.RoutineParams, len(dep.invocationTypes)),
+	}
+	for i := 0; i < len(routineObj.Params); i++ {
+		routineObj.Params[i] = tree.RoutineParam{
+			Type: dep.invocationTypes[i],
+			// Since we're not in the DROP context, it's sufficient
+			// to specify only the input parameters.
+			Class: tree.RoutineParamIn,
+			// Note that we don't need to specify the DefaultVal
+			// here because invocationTypes specifies the argument
+			// schema that was actually used. Instead, we will ask
+			// for matching overloads to use their DEFAULT
+			// expressions if necessary.
+		}
+	}
+	// NOTE: We match for all types of routines here, including
+	// procedures so that if a function has been dropped and a
+	// procedure is created with the same signature, we do not get a
+	// "<func> is not a function" error here. Instead, we'll return
+	// false and attempt to rebuild the statement.
+	routineType := tree.UDFRoutine | tree.BuiltinRoutine | tree.ProcedureRoutine
+	// Always allowing using DEFAULT expressions for input
+	// parameters since the signature of the routine might have
+	// changed even though the invocation remained the same.
+	const tryDefaultExprs = true
+	toCheck, err := definition.MatchOverload(
+		ctx,
+		optCatalog,
+		&routineObj,
+		&evalCtx.SessionData().SearchPath,
+		routineType,
+		false, /* inDropContext */
+		tryDefaultExprs,
+	)
+	if err != nil {
+		return false, err
+	}
+	return toCheck.Oid == dep.overload.Oid && toCheck.Version == dep.overload.Version, nil
+}
+
 // handleMetadataResolveErr swallows errors that are thrown when a database
 // object is dropped, since such an error potentially only means that the
 // metadata is stale and should be re-resolved.
@@ -547,7 +731,9 @@ func (md *Metadata) checkDataSourcePrivileges(ctx context.Context, optCatalog ca
 			// privileges do not need to be checked). Ignore the "zero privilege".
 			priv := privilege.Kind(bits.TrailingZeros32(uint32(privs)))
 			if priv != 0 {
-				if err := optCatalog.CheckPrivilege(ctx, dataSource, priv); err != nil {
+				if err := optCatalog.CheckPrivilege(
+					ctx, dataSource, optCatalog.GetCurrentUser(), priv,
+				); err != nil {
 					return err
 				}
 			}
@@ -555,6 +741,21 @@ func (md *Metadata) checkDataSourcePrivileges(ctx context.Context, optCatalog ca
 			privs &= ^(1 << priv)
 		}
 	}
+	// The privileges needed by the bodies of SECURITY DEFINER routines are
+	// checked for the owners of the routines.
+	for dep, privileges := range md.ownerPrivileges {
+		dataSource, ok := md.dataSourceDeps[dep.id]
+		if !ok {
+			continue
+		}
+		for privs := privileges; privs != 0; {
+			priv := privilege.Kind(bits.TrailingZeros32(uint32(privs)))
+			if err := optCatalog.CheckPrivilege(ctx, dataSource, dep.owner, priv); err != nil {
+				return err
+			}
+			privs &= ^(1 << priv)
+		}
+	}
 	return nil
 }
 
@@ -585,7 +786,7 @@ func (md *Metadata) AddUserDefinedType(typ *types.T, name *tree.UnresolvedObject
 	}
 	if name != nil {
 		id := cat.StableID(catid.UserDefinedOIDToID(typ.Oid()))
-		md.objectRefsByName[id] = append(md.objectRefsByName[id], name)
+		md.addObjectRef(id, name)
 	}
 }
 
@@ -601,8 +802,13 @@ func (md *Metadata) HasUserDefinedFunctions() bool {
 
 // AddUserDefinedFunction adds a user-defined function to the metadata for this
 // query. If the function was resolved by name, the name will also be tracked.
+// If checkPrivilege is true, the execution privilege of the current user on the
+// function is re-checked on reuse of the memo.
 func (md *Metadata) AddUserDefinedFunction(
-	overload *tree.Overload, invocationTypes []*types.T, name *tree.UnresolvedObjectName,
+	overload *tree.Overload,
+	invocationTypes []*types.T,
+	name *tree.UnresolvedObjectName,
+	checkPrivilege bool,
 ) {
 	if overload.Type != tree.UDFRoutine {
 		return
@@ -611,9 +817,10 @@ func (md *Metadata) AddUserDefinedFunction(
 	md.udfDeps[id] = udfDep{
 		overload:        overload,
 		invocationTypes: invocationTypes,
+		checkPrivilege:  checkPrivilege || md.udfDeps[id].checkPrivilege,
 	}
 	if name != nil {
-		md.objectRefsByName[id] = append(md.objectRefsByName[id], name)
+		md.addObjectRef(id, name)
 	}
 }
 
@@ -625,9 +832,11 @@ func (md *Metadata) AddBuiltin(name *tree.UnresolvedObjectName) {
 		return
 	}
 	if md.builtinRefsByName == nil {
-		md.builtinRefsByName = make(map[tree.UnresolvedName]struct{})
+		md.builtinRefsByName = make(map[builtinRef]struct{})
 	}
-	md.builtinRefsByName[*name.ToUnresolvedName()] = struct{}{}
+	md.builtinRefsByName[builtinRef{
+		name: *name.ToUnresolvedName(), searchPath: md.routineSearchPath,
+	}] = struct{}{}
 }
 
 // AddTable indexes a new reference to a table within the query. Separate
@@ -724,6 +933,22 @@ func (md *Metadata) DuplicateTable(
 			tabMeta.ColsInComputedColsExpressions.CopyAndMaybeRemap(colMap)
 	}
 
+	// Create new statistic expressions with new synthesized columns by
+	// remapping the column IDs in each ScalarExpr.
+	var statisticExprs []StatisticExpr
+	if len(tabMeta.statisticExprs) > 0 {
+		statisticExprs = make([]StatisticExpr, len(tabMeta.statisticExprs))
+		for i, e := range tabMeta.statisticExprs {
+			oldCol := md.ColumnMeta(e.Col)
+			statisticExprs[i] = StatisticExpr{
+				Expr:      e.Expr,
+				Col:       md.AddColumn(oldCol.Alias, oldCol.Type),
+				Scalar:    remapColumnIDs(e.Scalar, colMap),
+				OuterCols: e.OuterCols.CopyAndMaybeRemap(colMap),
+			}
+		}
+	}
+
 	// Create new partial index predicate expressions by remapping the column
 	// IDs in each ScalarExpr.
 	var partialIndexPredicates map[cat.IndexOrdinal]ScalarExpr
@@ -761,6 +986,7 @@ func (md *Metadata) DuplicateTable(
 		Constraints:                   constraints,
 		ComputedCols:                  computedCols,
 		ColsInComputedColsExpressions: referencedColsInComputedExpressions,
+		statisticExprs:                statisticExprs,
 		partialIndexPredicates:        partialIndexPredicates,
 		indexPartitionLocalities:      tabMeta.indexPartitionLocalities,
 		checkConstraintsStats:         checkConstraintsStats,
@@ -974,6 +1200,13 @@ func (md *Metadata) AllViews() []cat.View {
 	return md.views
 }
 
+// DependsOnDataSource returns true if the query depends on the data source
+// with the given ID.
+func (md *Metadata) DependsOnDataSource(id cat.StableID) bool {
+	_, ok := md.dataSourceDeps[id]
+	return ok
+}
+
 // getAllReferenceTables returns all the tables referenced by the metadata. This
 // includes all tables that are directly stored in the metadata in md.tables, as
 // well as recursive references from foreign keys (both referenced and
@@ -1193,6 +1426,20 @@ func (md *Metadata) TestingObjectRefsByName() map[cat.StableID][]*tree.Unresolve
 	return md.objectRefsByName
 }
 
+// TestingOwnerPrivilegesEqual returns whether the privileges checked for the
+// owners of SECURITY DEFINER routines are equal in both Metadata.
+func (md *Metadata) TestingOwnerPrivilegesEqual(other *Metadata) bool {
+	if len(md.ownerPrivileges) != len(other.ownerPrivileges) {
+		return false
+	}
+	for dep, privileges := range other.ownerPrivileges {
+		if md.ownerPrivileges[dep] != privileges {
+			return false
+		}
+	}
+	return true
+}
+
 // TestingPrivileges exposes the privileges for testing.
 func (md *Metadata) TestingPrivileges() map[cat.StableID]privilegeBitmap {
 	return md.privileges
@@ -17,6 +17,7 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
@@ -113,7 +114,9 @@ func TestMetadata(t *testing.T) {
 		&tree.Overload{Oid: catid.FuncIDToOID(1111)},
 		types.OneIntCol,
 		udfName.ToUnresolvedObjectName(),
+		true, /* checkPrivilege */
 	)
+	md.AddOwnerPrivilege(tab.ID(), username.TestUserName(), privilege.SELECT)
 
 	// Call CopyFrom and verify that same objects are present in new metadata.
 	expr := &memo.ProjectExpr{}
@@ -201,6 +204,10 @@ func TestMetadata(t *testing.T) {
 		}
 	}
 
+	if !mdNew.TestingOwnerPrivilegesEqual(md) {
+		t.Fatalf("expected owner privileges to be copied")
+	}
+
 	depsUpToDate, err = md.CheckDependencies(context.Background(), &evalCtx, testCat)
 	if err == nil || depsUpToDate {
 		t.Fatalf("expected table privilege to be revoked in metadata copy")
@@ -327,6 +327,9 @@ func (f *Factory) CopyMetadataFrom(from *memo.Memo) {
 	// existing expressions.
 	f.mem.CopyNextRankFrom(from)
 
+	// Copy the plan hints, which continue to apply to the copied expressions.
+	f.mem.SetHints(from.Hints())
+
 	// Copy all metadata to the target memo so that referenced tables and
 	// columns can keep the same ids they had in the "from" memo. Scalar
 	// expressions in the metadata cannot have placeholders, so we simply copy
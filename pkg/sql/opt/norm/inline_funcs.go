@@ -413,6 +413,7 @@ func (c *CustomFuncs) InlineConstVar(f memo.FiltersExpr) memo.FiltersExpr {
 //  4. Its arguments are only Variable or Const expressions.
 //  5. It is not a record-returning function.
 //  6. It does not recursively call itself.
+//  7. It is not SECURITY DEFINER and has no SET clauses.
 //
 // UDFs with mutations (INSERT, UPDATE, UPSERT, DELETE) cannot be inlined, but
 // we do not need an explicit check for this because immutable UDFs cannot
@@ -452,6 +453,10 @@ func (c *CustomFuncs) IsInlinableUDF(args memo.ScalarListExpr, udfp *memo.UDFCal
 		len(udfp.Def.Body) != 1 || udfp.Def.SetReturning || udfp.Def.MultiColDataSource {
 		return false
 	}
+	if udfp.Def.SessionOverride != nil {
+		// The body must be executed with the modified session.
+		return false
+	}
 	if !args.IsConstantsAndPlaceholdersAndVariables() {
 		return false
 	}
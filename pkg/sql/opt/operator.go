@@ -146,6 +146,11 @@ var ComparisonOpReverseMap = map[Operator]treecmp.ComparisonOperatorSymbol{
 	BBoxCoversOp:     treecmp.RegMatch,
 	BBoxIntersectsOp: treecmp.Overlaps,
 	TSMatchesOp:      treecmp.TSMatches,
+
+	WordSimilarOp:                 treecmp.WordSimilar,
+	WordSimilarCommutatorOp:       treecmp.WordSimilarCommutator,
+	StrictWordSimilarOp:           treecmp.StrictWordSimilar,
+	StrictWordSimilarCommutatorOp: treecmp.StrictWordSimilarCommutator,
 }
 
 // BinaryOpReverseMap maps from an optimizer operator type to a semantic tree
@@ -228,6 +233,8 @@ var AggregateOpReverseMap = map[Operator]string{
 	STMakeLineOp:                  "st_makeline",
 	STUnionOp:                     "st_union",
 	STCollectOp:                   "st_collect",
+	STClusterWithinOp:             "st_clusterwithin",
+	STPolygonizeOp:                "st_polygonize",
 	STExtentOp:                    "st_extent",
 	MergeAggregatedStmtMetadataOp: "merge_aggregated_stmt_metadata",
 	MergeStatsMetadataOp:          "merge_stats_metadata",
@@ -238,17 +245,20 @@ var AggregateOpReverseMap = map[Operator]string{
 // WindowOpReverseMap maps from an optimizer operator type to the name of a
 // window function.
 var WindowOpReverseMap = map[Operator]string{
-	RankOp:        "rank",
-	RowNumberOp:   "row_number",
-	DenseRankOp:   "dense_rank",
-	PercentRankOp: "percent_rank",
-	CumeDistOp:    "cume_dist",
-	NtileOp:       "ntile",
-	LagOp:         "lag",
-	LeadOp:        "lead",
-	FirstValueOp:  "first_value",
-	LastValueOp:   "last_value",
-	NthValueOp:    "nth_value",
+	RankOp:               "rank",
+	RowNumberOp:          "row_number",
+	DenseRankOp:          "dense_rank",
+	PercentRankOp:        "percent_rank",
+	CumeDistOp:           "cume_dist",
+	NtileOp:              "ntile",
+	LagOp:                "lag",
+	LeadOp:               "lead",
+	FirstValueOp:         "first_value",
+	LastValueOp:          "last_value",
+	NthValueOp:           "nth_value",
+	STClusterDBSCANOp:    "st_clusterdbscan",
+	STClusterKMeansOp:    "st_clusterkmeans",
+	STClusterWithinWinOp: "st_clusterwithinwin",
 }
 
 // NegateOpMap maps from a comparison operator type to its negated operator
@@ -332,7 +342,8 @@ func AggregateIgnoresNulls(op Operator) bool {
 		VarPopOp, CovarPopOp, CovarSampOp, RegressionAvgXOp, RegressionAvgYOp,
 		RegressionInterceptOp, RegressionR2Op, RegressionSlopeOp, RegressionSXXOp,
 		RegressionSXYOp, RegressionSYYOp, RegressionCountOp, MergeStatsMetadataOp,
-		MergeStatementStatsOp, MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp:
+		MergeStatementStatsOp, MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp,
+		STClusterWithinOp, STPolygonizeOp:
 		return true
 
 	case ArrayAggOp, ArrayCatAggOp, ConcatAggOp, ConstAggOp, CountRowsOp,
@@ -359,7 +370,8 @@ func AggregateIsNullOnEmpty(op Operator) bool {
 		VarPopOp, CovarPopOp, CovarSampOp, RegressionAvgXOp, RegressionAvgYOp,
 		RegressionInterceptOp, RegressionR2Op, RegressionSlopeOp, RegressionSXXOp,
 		RegressionSXYOp, RegressionSYYOp, MergeStatsMetadataOp, MergeStatementStatsOp,
-		MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp:
+		MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp, STClusterWithinOp,
+		STPolygonizeOp:
 		return true
 
 	case CountOp, CountRowsOp, RegressionCountOp:
@@ -388,7 +400,8 @@ func AggregateIsNeverNullOnNonNullInput(op Operator) bool {
 		JsonObjectAggOp, JsonbObjectAggOp, StdDevPopOp, STCollectOp, STUnionOp,
 		VarPopOp, CovarPopOp, RegressionAvgXOp, RegressionAvgYOp, RegressionSXXOp,
 		RegressionSXYOp, RegressionSYYOp, RegressionCountOp, MergeStatsMetadataOp,
-		MergeStatementStatsOp, MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp:
+		MergeStatementStatsOp, MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp,
+		STClusterWithinOp, STPolygonizeOp:
 		return true
 
 	case VarianceOp, StdDevOp, CorrOp, CovarSampOp, RegressionInterceptOp,
@@ -444,7 +457,8 @@ func AggregatesCanMerge(inner, outer Operator) bool {
 		VarPopOp, CovarPopOp, CovarSampOp, RegressionAvgXOp, RegressionAvgYOp,
 		RegressionInterceptOp, RegressionR2Op, RegressionSlopeOp, RegressionSXXOp,
 		RegressionSXYOp, RegressionSYYOp, RegressionCountOp, MergeStatsMetadataOp,
-		MergeStatementStatsOp, MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp:
+		MergeStatementStatsOp, MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp,
+		STClusterWithinOp, STPolygonizeOp:
 		return false
 
 	default:
@@ -470,7 +484,8 @@ func AggregateIgnoresDuplicates(op Operator) bool {
 		CovarSampOp, RegressionAvgXOp, RegressionAvgYOp, RegressionInterceptOp,
 		RegressionR2Op, RegressionSlopeOp, RegressionSXXOp, RegressionSXYOp,
 		RegressionSYYOp, RegressionCountOp, MergeStatsMetadataOp, MergeStatementStatsOp,
-		MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp:
+		MergeTransactionStatsOp, MergeAggregatedStmtMetadataOp, STClusterWithinOp,
+		STPolygonizeOp:
 		return false
 
 	default:
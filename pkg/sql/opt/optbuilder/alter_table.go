@@ -34,7 +34,7 @@ func (b *Builder) buildAlterTableSplit(split *tree.Split, inScope *scope) (outSc
 		panic(err)
 	}
 	table := index.Table()
-	if err := b.catalog.CheckPrivilege(b.ctx, table, privilege.INSERT); err != nil {
+	if err := b.catalog.CheckPrivilege(b.ctx, table, b.checkPrivilegeUser, privilege.INSERT); err != nil {
 		panic(err)
 	}
 
@@ -98,7 +98,7 @@ func (b *Builder) buildAlterTableUnsplit(unsplit *tree.Unsplit, inScope *scope)
 		panic(err)
 	}
 	table := index.Table()
-	if err := b.catalog.CheckPrivilege(b.ctx, table, privilege.INSERT); err != nil {
+	if err := b.catalog.CheckPrivilege(b.ctx, table, b.checkPrivilegeUser, privilege.INSERT); err != nil {
 		panic(err)
 	}
 
@@ -148,7 +148,7 @@ func (b *Builder) buildAlterTableRelocate(
 		panic(err)
 	}
 	table := index.Table()
-	if err := b.catalog.CheckPrivilege(b.ctx, table, privilege.INSERT); err != nil {
+	if err := b.catalog.CheckPrivilege(b.ctx, table, b.checkPrivilegeUser, privilege.INSERT); err != nil {
 		panic(err)
 	}
 
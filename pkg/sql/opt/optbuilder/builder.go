@@ -15,6 +15,7 @@ import (
 	"strconv"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/delegate"
@@ -122,6 +123,18 @@ type Builder struct {
 	// be used with care.
 	skipSelectPrivilegeChecks bool
 
+	// checkPrivilegeUser is the user whose privileges are checked when
+	// resolving data sources and routines. It is the current user, except while
+	// building the body of a SECURITY DEFINER routine, in which case it is the
+	// owner of that routine.
+	checkPrivilegeUser username.SQLUsername
+
+	// insideSecurityDefiner is true when the current expressions are being
+	// built within a SECURITY DEFINER routine. Privileges checked in this
+	// context belong to the routine owner, so they are not re-checked for the
+	// current user on reuse of the memo.
+	insideSecurityDefiner bool
+
 	// views contains a cache of views that have already been parsed, in case they
 	// are referenced multiple times in the same query.
 	views map[cat.View]*tree.Select
@@ -197,13 +210,14 @@ func New(
 	stmt tree.Statement,
 ) *Builder {
 	return &Builder{
-		factory:        factory,
-		stmt:           stmt,
-		ctx:            ctx,
-		verboseTracing: log.ExpensiveLogEnabled(ctx, 2),
-		semaCtx:        semaCtx,
-		evalCtx:        evalCtx,
-		catalog:        catalog,
+		factory:            factory,
+		stmt:               stmt,
+		ctx:                ctx,
+		verboseTracing:     log.ExpensiveLogEnabled(ctx, 2),
+		semaCtx:            semaCtx,
+		evalCtx:            evalCtx,
+		catalog:            catalog,
+		checkPrivilegeUser: catalog.GetCurrentUser(),
 	}
 }
 
@@ -247,6 +261,16 @@ func (b *Builder) Build() (err error) {
 	}
 	b.semaCtx.TypeResolver = typeTracker
 
+	// Similarly, record the types involved in user-defined cast lookups, since
+	// the casts are stored on the type descriptors.
+	if existingCastResolver := b.semaCtx.CastResolver; existingCastResolver != nil {
+		defer func() { b.semaCtx.CastResolver = existingCastResolver }()
+		b.semaCtx.CastResolver = &optTrackingCastResolver{
+			res:      existingCastResolver,
+			metadata: b.factory.Metadata(),
+		}
+	}
+
 	// Special case for CannedOptPlan.
 	if canned, ok := b.stmt.(*tree.CannedOptPlan); ok {
 		b.factory.DisableOptimizations()
@@ -575,3 +599,20 @@ func (o *optTrackingTypeResolver) ResolveTypeByOID(
 	o.metadata.AddUserDefinedType(typ, nil /* name */)
 	return typ, nil
 }
+
+// optTrackingCastResolver is a wrapper around a CastResolver that remembers
+// the user-defined types of all cast lookups in the provided Metadata, so that
+// the memo is invalidated when a cast is created or dropped.
+type optTrackingCastResolver struct {
+	res      tree.CastResolver
+	metadata *opt.Metadata
+}
+
+// ResolveCast implements the tree.CastResolver interface.
+func (o *optTrackingCastResolver) ResolveCast(
+	ctx context.Context, from, to *types.T,
+) (*tree.UserDefinedCast, error) {
+	o.metadata.AddUserDefinedType(from, nil /* name */)
+	o.metadata.AddUserDefinedType(to, nil /* name */)
+	return o.res.ResolveCast(ctx, from, to)
+}
@@ -13,6 +13,7 @@ package optbuilder
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcinfo"
@@ -175,6 +176,12 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	// labels in the output RECORD type.
 	var outParamNames []string
 	var sawDefaultExpr bool
+	// placeholderTypes records which families of polymorphic types, e.g.
+	// ANYELEMENT or ANYCOMPATIBLE, are bound by the input parameters of the
+	// routine. The concrete types of these parameters are only known at the
+	// call site, so INT4 is used as a stand-in when checking that a polymorphic
+	// result type can be determined.
+	var placeholderTypes tree.PolymorphicTypes
 	for i := range cf.Params {
 		param := &cf.Params[i]
 		typ, err := tree.ResolveType(b.ctx, param.Type, b.semaCtx.TypeResolver)
@@ -205,6 +212,18 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 				))
 			}
 		}
+		if tree.IsPolymorphicType(typ) {
+			if param.IsInParam() {
+				if tree.IsAnyCompatibleType(typ) {
+					placeholderTypes.Compatible = types.Int4
+				} else {
+					placeholderTypes.Elem = types.Int4
+				}
+			} else if cf.IsProcedure {
+				panic(unimplemented.NewWithIssue(122945,
+					"polymorphic OUT parameters are not yet supported for procedures"))
+			}
+		}
 		if param.DefaultVal != nil && param.Class == tree.RoutineParamOut {
 			panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
 				"only input parameters can have default values"))
@@ -262,6 +281,9 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			})
 		}
 	}
+	// hasPolymorphicParams is true if the routine has input parameters of a
+	// polymorphic type.
+	hasPolymorphicParams := placeholderTypes.Elem != nil || placeholderTypes.Compatible != nil
 
 	// Determine OUT parameter based return type.
 	var outParamType *types.T
@@ -309,6 +331,22 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "PL/pgSQL functions cannot return type unknown"))
 		}
 	}
+	// A polymorphic result type can only be determined from the arguments
+	// passed to polymorphic input parameters of the same kind.
+	boundReturnType := tree.ReplacePolymorphicType(funcReturnType, placeholderTypes)
+	if tree.ContainsPolymorphicType(boundReturnType) {
+		inputTypes := "anyelement, anyarray, or anyenum"
+		if tree.ContainsPolymorphicType(
+			tree.ReplacePolymorphicType(boundReturnType, tree.PolymorphicTypes{Elem: types.Int4}),
+		) {
+			inputTypes = "anycompatible or anycompatiblearray"
+		}
+		panic(errors.WithDetailf(
+			pgerror.New(pgcode.InvalidFunctionDefinition, "cannot determine result data type"),
+			"A result of type %s requires at least one input of type %s.",
+			funcReturnType.Name(), inputTypes,
+		))
+	}
 	// Collect the user defined type dependency of the return type.
 	typedesc.GetTypeDescriptorClosure(funcReturnType).ForEach(func(id descpb.ID) {
 		typeDeps.Add(int(id))
@@ -317,6 +355,13 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	targetVolatility := tree.GetRoutineVolatility(cf.Options)
 	fmtCtx := tree.NewFmtCtx(tree.FmtSerializable)
 
+	// Resolve the names in the body with the search_path configured by a SET
+	// clause of the routine, if any, since that is the search_path that is
+	// used when the routine is invoked.
+	if paths, ok := routineSearchPath(cf.Options); ok {
+		defer b.pushSearchPath(paths)()
+	}
+
 	defer func(origValue bool) {
 		b.insideSQLRoutine = origValue
 	}(b.insideSQLRoutine)
@@ -342,13 +387,19 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			b.semaCtx.Annotations = ann
 			b.evalCtx.Annotations = &ann
 
-			// We need to disable stable function folding because we want to catch the
-			// volatility of stable functions. If folded, we only get a scalar and
-			// lose the volatility.
-			b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
-				stmtScope = b.buildStmtAtRootWithScope(stmts[i].AST, nil /* desiredTypes */, bodyScope)
-			})
-			checkStmtVolatility(targetVolatility, stmtScope, stmt.AST)
+			// The body of a routine with polymorphic parameters cannot be analyzed,
+			// since the types of the parameters are unknown until the routine is
+			// called. Like Postgres, we only check the syntax of the body in this
+			// case; it is type-checked at the call site with the resolved types.
+			if !hasPolymorphicParams {
+				// We need to disable stable function folding because we want to catch
+				// the volatility of stable functions. If folded, we only get a scalar
+				// and lose the volatility.
+				b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
+					stmtScope = b.buildStmtAtRootWithScope(stmts[i].AST, nil /* desiredTypes */, bodyScope)
+				})
+				checkStmtVolatility(targetVolatility, stmtScope, stmt.AST)
+			}
 
 			// Format the statements with qualified datasource names.
 			formatFuncBodyStmt(fmtCtx, stmt.AST, language, i > 0 /* newLine */)
@@ -380,17 +431,21 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			}
 		}
 
-		// We need to disable stable function folding because we want to catch the
-		// volatility of stable functions. If folded, we only get a scalar and lose
-		// the volatility.
-		b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
-			plBuilder := newPLpgSQLBuilder(
-				b, cf.Name.Object(), stmt.AST.Label, nil, /* colRefs */
-				routineParams, funcReturnType, cf.IsProcedure, nil, /* outScope */
-			)
-			stmtScope = plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
-		})
-		checkStmtVolatility(targetVolatility, stmtScope, stmt)
+		// As with SQL routines, the body of a routine with polymorphic parameters
+		// is only analyzed at the call site.
+		if !hasPolymorphicParams {
+			// We need to disable stable function folding because we want to catch
+			// the volatility of stable functions. If folded, we only get a scalar
+			// and lose the volatility.
+			b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
+				plBuilder := newPLpgSQLBuilder(
+					b, cf.Name.Object(), stmt.AST.Label, nil, /* colRefs */
+					routineParams, funcReturnType, cf.IsProcedure, nil, /* outScope */
+				)
+				stmtScope = plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
+			})
+			checkStmtVolatility(targetVolatility, stmtScope, stmt)
+		}
 
 		// Format the statements with qualified datasource names.
 		formatFuncBodyStmt(fmtCtx, stmt.AST, language, false /* newLine */)
@@ -598,3 +653,29 @@ func checkDuplicateParamName(param tree.RoutineParam, seen map[tree.Name]struct{
 	}
 	seen[param.Name] = struct{}{}
 }
+
+// routineSearchPath returns the search_path configured by a SET clause in the
+// given routine options, if any. Values that are not constant strings or
+// names are ignored here; they are validated when the routine is created.
+func routineSearchPath(options tree.RoutineOptions) (paths []string, ok bool) {
+	for _, option := range options {
+		setVar, isSetVar := option.(*tree.RoutineSetVar)
+		if !isSetVar || setVar.Reset || setVar.ResetAll ||
+			strings.ToLower(setVar.Name) != "search_path" {
+			continue
+		}
+		paths = make([]string, 0, len(setVar.Values))
+		for _, v := range setVar.Values {
+			switch t := v.(type) {
+			case *tree.StrVal:
+				paths = append(paths, t.RawString())
+			case *tree.UnresolvedName:
+				paths = append(paths, tree.AsStringWithFlags(t, tree.FmtBareIdentifiers))
+			default:
+				return nil, false
+			}
+		}
+		ok = true
+	}
+	return paths, ok
+}
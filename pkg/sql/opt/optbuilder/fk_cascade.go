@@ -103,7 +103,7 @@ func (cb *onDeleteCascadeBuilder) Build(
 		// for each public table column, making it appropriate to set it as
 		// mb.fetchScope.
 		mb.fetchScope = b.buildDeleteCascadeMutationInput(
-			cb.childTable, &mb.alias, fk, binding, bindingProps, oldValues,
+			cb.mutatedTable, cb.childTable, &mb.alias, fk, binding, bindingProps, oldValues,
 		)
 		mb.outScope = mb.fetchScope
 
@@ -164,6 +164,12 @@ func tryNewOnDeleteFastCascadeBuilder(
 	parentTab, childTab cat.Table,
 	mutationInputScope *scope,
 ) (_ *onDeleteFastCascadeBuilder, ok bool) {
+	if fk.MatchMethod() == tree.MatchPartial {
+		// Under MATCH PARTIAL, a child row is only affected if none of the
+		// remaining parent rows match it, which can't be expressed as a filter
+		// on the child table.
+		return nil, false
+	}
 	fkCols := make(opt.ColList, fk.ColumnCount())
 	for i := range fkCols {
 		tabOrd := fk.ReferencedColumnOrdinal(parentTab, i)
@@ -447,7 +453,7 @@ func (cb *onDeleteSetBuilder) Build(
 		// for each public table column, making it appropriate to set it as
 		// mb.fetchScope.
 		mb.fetchScope = b.buildDeleteCascadeMutationInput(
-			cb.childTable, &mb.alias, fk, binding, bindingProps, oldValues,
+			cb.mutatedTable, cb.childTable, &mb.alias, fk, binding, bindingProps, oldValues,
 		)
 		mb.outScope = mb.fetchScope
 
@@ -506,7 +512,12 @@ func (cb *onDeleteSetBuilder) Build(
 //
 // Note that NULL values in the mutation input don't require any special
 // handling - they will be effectively ignored by the semi-join.
+//
+// For a MATCH PARTIAL foreign key, the semi-join matches the child rows on
+// their non-NULL FK columns, and child rows which still match a row of the
+// parent table are removed by an anti-join with the parent table.
 func (b *Builder) buildDeleteCascadeMutationInput(
+	parentTable cat.Table,
 	childTable cat.Table,
 	childTableAlias *tree.TableName,
 	fk cat.ForeignKeyConstraint,
@@ -552,6 +563,36 @@ func (b *Builder) buildDeleteCascadeMutationInput(
 		ID:      md.NextUniqueID(),
 	})
 
+	if fk.MatchMethod() == tree.MatchPartial {
+		childCols := make(opt.ColList, numFKCols)
+		parentOrdinals := make([]int, numFKCols)
+		for i := range childCols {
+			childCols[i] = outScope.getColumnForTableOrdinal(fk.OriginColumnOrdinal(childTable, i)).id
+			parentOrdinals[i] = fk.ReferencedColumnOrdinal(parentTable, i)
+		}
+		outScope.expr = b.factory.ConstructSemiJoin(
+			outScope.expr,
+			mutationInput,
+			b.buildFKPartialMatchFilters(childCols, outCols, opt.ColSet{}),
+			memo.EmptyJoinPrivate,
+		)
+		parentScope := b.buildScan(
+			b.addTable(parentTable, tree.NewUnqualifiedTableName(parentTable.Name())),
+			parentOrdinals,
+			&tree.IndexFlags{IgnoreForeignKeys: true},
+			noRowLocking,
+			b.allocScope(),
+			true, /* disableNotVisibleIndex */
+		)
+		outScope.expr = b.factory.ConstructAntiJoin(
+			outScope.expr,
+			parentScope.expr,
+			b.buildFKPartialMatchFilters(childCols, parentScope.colList(), opt.ColSet{}),
+			memo.EmptyJoinPrivate,
+		)
+		return outScope
+	}
+
 	on := make(memo.FiltersExpr, numFKCols)
 	for i := range on {
 		tabOrd := fk.OriginColumnOrdinal(childTable, i)
@@ -253,7 +253,7 @@ func (a aggregateInfo) isOrderingSensitive() bool {
 	switch a.def.Name {
 	case "array_agg", "array_cat_agg", "concat_agg", "string_agg", "json_agg",
 		"jsonb_agg", "json_object_agg", "jsonb_object_agg", "st_makeline",
-		"st_collect", "st_memcollect":
+		"st_collect", "st_memcollect", "st_clusterwithin":
 		return true
 	default:
 		return false
@@ -799,6 +799,12 @@ func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.Scal
 		return b.factory.ConstructLastValue(args[0])
 	case "nth_value":
 		return b.factory.ConstructNthValue(args[0], args[1])
+	case "st_clusterdbscan":
+		return b.factory.ConstructSTClusterDBSCAN(args[0], args[1], args[2])
+	case "st_clusterkmeans":
+		return b.factory.ConstructSTClusterKMeans(args[0], args[1], args[2])
+	case "st_clusterwithinwin":
+		return b.factory.ConstructSTClusterWithinWin(args[0], args[1])
 	default:
 		return b.constructAggregate(name, args)
 	}
@@ -872,6 +878,10 @@ func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.Sca
 		return b.factory.ConstructSTMakeLine(args[0])
 	case "st_collect", "st_memcollect":
 		return b.factory.ConstructSTCollect(args[0])
+	case "st_clusterwithin":
+		return b.factory.ConstructSTClusterWithin(args[0], args[1])
+	case "st_polygonize":
+		return b.factory.ConstructSTPolygonize(args[0])
 	case "st_extent":
 		return b.factory.ConstructSTExtent(args[0])
 	case "st_union", "st_memunion":
@@ -1418,15 +1418,34 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 			continue
 		}
 
-		// Check if an assignment cast is available from the inScope column
-		// type to the out type.
-		if !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
-			panic(sqlerrors.NewInvalidAssignmentCastError(srcType, targetType, string(targetCol.ColName())))
+		// A user-defined cast which can be applied in an assignment context
+		// takes precedence over the builtin assignment casts.
+		udc, err := tree.ResolveUserDefinedCast(
+			mb.b.ctx, mb.b.semaCtx, srcType, targetType, cast.ContextAssignment,
+		)
+		if err != nil {
+			panic(err)
 		}
 
 		// Create the cast expression.
-		variable := mb.b.factory.ConstructVariable(colID)
-		cast := mb.b.factory.ConstructAssignmentCast(variable, targetType)
+		var castExpr opt.ScalarExpr
+		if udc != nil {
+			texpr, err := tree.TypeCheck(mb.b.ctx, &tree.CastExpr{
+				Expr: mb.outScope.getColumn(colID), Type: targetType, SyntaxMode: tree.CastShort,
+			}, mb.b.semaCtx, targetType)
+			if err != nil {
+				panic(err)
+			}
+			castExpr = mb.b.buildScalar(texpr, mb.outScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+		} else {
+			// Check if an assignment cast is available from the inScope column
+			// type to the out type.
+			if !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
+				panic(sqlerrors.NewInvalidAssignmentCastError(srcType, targetType, string(targetCol.ColName())))
+			}
+			variable := mb.b.factory.ConstructVariable(colID)
+			castExpr = mb.b.factory.ConstructAssignmentCast(variable, targetType)
+		}
 
 		// Lazily create the new scope.
 		if projectionScope == nil {
@@ -1441,7 +1460,7 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 		// column, we perform a lookup with the ID and the name. See #61520.
 		scopeCol := projectionScope.getColumnWithIDAndReferenceName(colID, targetCol.ColName())
 		scopeCol.name = scopeCol.name.WithMetadataName(fmt.Sprintf("%s_cast", targetCol.ColName()))
-		mb.b.populateSynthesizedColumn(scopeCol, cast)
+		mb.b.populateSynthesizedColumn(scopeCol, castExpr)
 
 		// Replace old source column with the new one.
 		srcCols[ord] = scopeCol.id
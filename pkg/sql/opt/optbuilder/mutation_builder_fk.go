@@ -693,6 +693,10 @@ func (h *fkCheckHelper) buildInsertionCheck() memo.FKChecksItem {
 		//  - MATCH FULL: only the case where *all* the columns are NULL is
 		//                allowed, and the row doesn't need to have a match in the
 		//                referenced table.
+		//  - MATCH PARTIAL: if *all* the columns are NULL, the row doesn't need
+		//                   to have a match in the referenced table. Otherwise,
+		//                   the row must match a referenced row on its non-NULL
+		//                   columns.
 		//
 		// Note that rows that have NULLs will never have a match in the anti
 		// join and will generate errors. To handle these cases, we filter the
@@ -702,7 +706,9 @@ func (h *fkCheckHelper) buildInsertionCheck() memo.FKChecksItem {
 		// For SIMPLE, we filter out any rows which have a NULL. For FULL, we
 		// filter out any rows where all the columns are NULL (rows which have
 		// NULLs a subset of columns are let through and will generate FK errors
-		// because they will never have a match in the anti join).
+		// because they will never have a match in the anti join). For PARTIAL, we
+		// also filter out any rows where all the columns are NULL, and the anti
+		// join filters below ignore the NULL columns of the remaining rows.
 		switch m := h.fk.MatchMethod(); m {
 		case tree.MatchSimple:
 			// Filter out any rows which have a NULL; build filters of the form
@@ -720,7 +726,7 @@ func (h *fkCheckHelper) buildInsertionCheck() memo.FKChecksItem {
 			}
 			withScanScope.expr = f.ConstructSelect(withScanScope.expr, filters)
 
-		case tree.MatchFull:
+		case tree.MatchFull, tree.MatchPartial:
 			// Filter out any rows which have NULLs on all referencing columns.
 			if !notNullWithScanCols.Empty() {
 				// We statically know that some of the referencing columns can't be
@@ -758,14 +764,21 @@ func (h *fkCheckHelper) buildInsertionCheck() memo.FKChecksItem {
 
 	// Build the join filters:
 	//   (origin_a = referenced_a) AND (origin_b = referenced_b) AND ...
-	antiJoinFilters := make(memo.FiltersExpr, numCols)
-	for j := 0; j < numCols; j++ {
-		antiJoinFilters[j] = f.ConstructFiltersItem(
-			f.ConstructEq(
-				f.ConstructVariable(withScanScope.cols[j].id),
-				f.ConstructVariable(scanScope.cols[j].id),
-			),
+	var antiJoinFilters memo.FiltersExpr
+	if h.fk.MatchMethod() == tree.MatchPartial {
+		antiJoinFilters = h.mb.b.buildFKPartialMatchFilters(
+			withScanScope.colList(), scanScope.colList(), notNullWithScanCols,
 		)
+	} else {
+		antiJoinFilters = make(memo.FiltersExpr, numCols)
+		for j := 0; j < numCols; j++ {
+			antiJoinFilters[j] = f.ConstructFiltersItem(
+				f.ConstructEq(
+					f.ConstructVariable(withScanScope.cols[j].id),
+					f.ConstructVariable(scanScope.cols[j].id),
+				),
+			)
+		}
 	}
 	var p memo.JoinPrivate
 	if h.mb.b.evalCtx.SessionData().PreferLookupJoinsForFKs {
@@ -790,6 +803,10 @@ func (h *fkCheckHelper) buildInsertionCheck() memo.FKChecksItem {
 func (h *fkCheckHelper) buildDeletionCheck(
 	deletedRows memo.RelExpr, deleteCols opt.ColList,
 ) memo.FKChecksItem {
+	if h.fk.MatchMethod() == tree.MatchPartial {
+		return h.buildPartialMatchDeletionCheck(deletedRows, deleteCols)
+	}
+
 	// Build a semi join, with the referenced FK columns on the left and the
 	// origin columns on the right.
 	scanScope, origTabMeta := h.buildOtherTableScan(false /* parent */)
@@ -824,3 +841,101 @@ func (h *fkCheckHelper) buildDeletionCheck(
 		OpName:          h.mb.opName,
 	})
 }
+
+// buildPartialMatchDeletionCheck creates a FK check for rows which are removed
+// from a table referenced by a MATCH PARTIAL foreign key. Under MATCH PARTIAL,
+// a child row can match several rows of the referenced table, so removing one
+// of them is only a violation if the child row is left without any match:
+//
+//	semi-join
+//	 ├── <deleted rows>
+//	 ├── anti-join
+//	 │    ├── scan child
+//	 │    ├── scan parent
+//	 │    └── filters
+//	 │         └── <child matches parent>
+//	 └── filters
+//	      └── <child matches deleted row>
+//
+// The check runs after the mutation, so the scan of the parent table no longer
+// returns the removed rows.
+func (h *fkCheckHelper) buildPartialMatchDeletionCheck(
+	deletedRows memo.RelExpr, deleteCols opt.ColList,
+) memo.FKChecksItem {
+	b := h.mb.b
+	f := b.factory
+	scanScope, origTabMeta := h.buildOtherTableScan(false /* parent */)
+	parentTabMeta := b.addTable(h.mb.tab, tree.NewUnqualifiedTableName(h.mb.tab.Name()))
+	parentScope := b.buildScan(
+		parentTabMeta,
+		h.tabOrdinals,
+		&tree.IndexFlags{IgnoreForeignKeys: true},
+		noRowLocking,
+		b.allocScope(),
+		true, /* disableNotVisibleIndex */
+	)
+
+	childCols := scanScope.colList()
+	orphans := f.ConstructAntiJoin(
+		scanScope.expr,
+		parentScope.expr,
+		b.buildFKPartialMatchFilters(childCols, parentScope.colList(), opt.ColSet{}),
+		memo.EmptyJoinPrivate,
+	)
+	semiJoin := f.ConstructSemiJoin(
+		deletedRows,
+		orphans,
+		b.buildFKPartialMatchFilters(childCols, deleteCols, opt.ColSet{}),
+		memo.EmptyJoinPrivate,
+	)
+
+	return f.ConstructFKChecksItem(semiJoin, &memo.FKChecksItemPrivate{
+		OriginTable:     origTabMeta.MetaID,
+		ReferencedTable: h.mb.tabID,
+		FKOutbound:      false,
+		FKOrdinal:       h.fkOrdinal,
+		KeyCols:         deleteCols,
+		OpName:          h.mb.opName,
+	})
+}
+
+// buildFKPartialMatchFilters builds the filters which match the FK columns of
+// a child row to a row of key values under MATCH PARTIAL: the child row must
+// have at least one non-NULL FK column, and all its non-NULL FK columns must be
+// equal to the corresponding key values:
+//
+//	(child_a IS NULL OR child_a = key_a) AND (child_b IS NULL OR child_b = key_b)
+//	AND (child_a IS NOT NULL OR child_b IS NOT NULL)
+//
+// Columns in notNullChildCols are known not to be NULL, and only need an
+// equality filter.
+func (b *Builder) buildFKPartialMatchFilters(
+	childCols, keyCols opt.ColList, notNullChildCols opt.ColSet,
+) memo.FiltersExpr {
+	f := b.factory
+	filters := make(memo.FiltersExpr, 0, len(childCols)+1)
+	var anyNotNull opt.ScalarExpr
+	knownNotNull := false
+	for i, col := range childCols {
+		eq := f.ConstructEq(f.ConstructVariable(col), f.ConstructVariable(keyCols[i]))
+		if notNullChildCols.Contains(col) {
+			filters = append(filters, f.ConstructFiltersItem(eq))
+			knownNotNull = true
+			continue
+		}
+		filters = append(filters, f.ConstructFiltersItem(f.ConstructOr(
+			f.ConstructIs(f.ConstructVariable(col), memo.NullSingleton),
+			eq,
+		)))
+		isNotNull := f.ConstructIsNot(f.ConstructVariable(col), memo.NullSingleton)
+		if anyNotNull == nil {
+			anyNotNull = isNotNull
+		} else {
+			anyNotNull = f.ConstructOr(anyNotNull, isNotNull)
+		}
+	}
+	if !knownNotNull {
+		filters = append(filters, f.ConstructFiltersItem(anyNotNull))
+	}
+	return filters
+}
@@ -177,6 +177,10 @@ type plpgsqlBuilder struct {
 	routineName  string
 	isProcedure  bool
 	identCounter int
+
+	// hasSessionOverride is true if the routine modifies the session while it
+	// is executing; i.e., it is SECURITY DEFINER or has SET clauses.
+	hasSessionOverride bool
 }
 
 // routineParam is similar to tree.RoutineParam but stores the resolved type.
@@ -285,6 +289,9 @@ func (b *plpgsqlBuilder) buildRootBlock(
 		var tc transactionControlVisitor
 		ast.Walk(&tc, astBlock)
 		if tc.foundTxnControlStatement {
+			if b.hasSessionOverride {
+				panic(txnWithSessionOverrideErr)
+			}
 			if b.ob.insideNestedPLpgSQLCall {
 				// Disallow transaction control statements in nested routines for now.
 				// TODO(#122266): once we support this, make sure to validate that
@@ -909,9 +916,10 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// During execution, a TxnControlExpr directs the session to commit or
 			// rollback the transaction, and supplies a plan for the continuation to
 			// run in the new transaction.
-			if t.Chain {
-				panic(txnControlWithChainErr)
-			}
+			//
+			// COMMIT AND CHAIN and ROLLBACK AND CHAIN are resolved at execution time,
+			// when the characteristics of the current transaction are known.
+			//
 			// NOTE: postgres doesn't make the following checks until runtime (see
 			// also #119750).
 			// TODO(#88198): check the calling context, since transaction control
@@ -950,7 +958,7 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			con := b.makeContinuation(name)
 			con.def.Volatility = volatility.Volatile
 			b.appendPlpgSQLStmts(&con, stmts)
-			return b.callContinuationWithTxnOp(&con, s, txnOpType, txnModes)
+			return b.callContinuationWithTxnOp(&con, s, txnOpType, t.Chain, txnModes)
 
 		case *ast.Call:
 			// Build a continuation that will execute the procedure, and then the
@@ -1784,7 +1792,11 @@ func (b *plpgsqlBuilder) callContinuation(con *continuation, s *scope) *scope {
 // continuation in a TxnControlExpr that will commit or abort the current
 // transaction before resuming execution with the continuation.
 func (b *plpgsqlBuilder) callContinuationWithTxnOp(
-	con *continuation, s *scope, txnOp tree.StoredProcTxnOp, txnModes tree.TransactionModes,
+	con *continuation,
+	s *scope,
+	txnOp tree.StoredProcTxnOp,
+	chain bool,
+	txnModes tree.TransactionModes,
 ) *scope {
 	if con == nil {
 		panic(errors.AssertionFailedf("nil continuation with transaction control"))
@@ -1795,7 +1807,9 @@ func (b *plpgsqlBuilder) callContinuationWithTxnOp(
 	b.addBarrier(s)
 	returnScope := s.push()
 	args := b.makeContinuationArgs(con, s)
-	txnPrivate := &memo.TxnControlPrivate{TxnOp: txnOp, TxnModes: txnModes, Def: con.def}
+	txnPrivate := &memo.TxnControlPrivate{
+		TxnOp: txnOp, Chain: chain, TxnModes: txnModes, Def: con.def,
+	}
 	if b.outScope != nil {
 		txnPrivate.Props = b.outScope.makePhysicalProps()
 		txnPrivate.OutCols = b.outScope.colList()
@@ -2302,9 +2316,9 @@ var (
 	txnInUDFErr = errors.WithDetail(
 		pgerror.Newf(pgcode.InvalidTransactionTermination, "invalid transaction termination"),
 		"PL/pgSQL COMMIT/ROLLBACK is not allowed inside a user-defined function")
-	txnControlWithChainErr = unimplemented.NewWithIssue(119646,
-		"COMMIT or ROLLBACK with AND CHAIN syntax is not yet implemented",
-	)
+	txnWithSessionOverrideErr = errors.WithDetail(
+		pgerror.Newf(pgcode.InvalidTransactionTermination, "invalid transaction termination"),
+		"PL/pgSQL COMMIT/ROLLBACK is not allowed in a procedure with SECURITY DEFINER or SET clauses")
 	setTxnNotAfterControlStmtErr = errors.WithHint(
 		pgerror.New(pgcode.ActiveSQLTransaction, "SET TRANSACTION must be called before any query"),
 		"PL/pgSQL SET TRANSACTION statements must immediately follow COMMIT or ROLLBACK",
@@ -13,7 +13,9 @@ package optbuilder
 import (
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
@@ -22,8 +24,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
@@ -55,7 +60,7 @@ func (b *Builder) buildUDF(
 	// Check for execution privileges for user-defined overloads. Built-in
 	// overloads do not need to be checked.
 	if o.Type == tree.UDFRoutine {
-		if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid); err != nil {
+		if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid, b.checkPrivilegeUser); err != nil {
 			panic(err)
 		}
 	}
@@ -183,7 +188,7 @@ func (b *Builder) resolveProcedureDefinition(
 	}
 
 	// Check for execution privileges.
-	if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid); err != nil {
+	if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid, b.checkPrivilegeUser); err != nil {
 		panic(err)
 	}
 	return f, def
@@ -212,7 +217,16 @@ func (b *Builder) buildRoutine(
 		}
 		invocationTypes[i] = texpr.ResolvedType()
 	}
-	b.factory.Metadata().AddUserDefinedFunction(o, invocationTypes, f.Func.ReferenceByName)
+	b.factory.Metadata().AddUserDefinedFunction(
+		o, invocationTypes, f.Func.ReferenceByName, !b.insideSecurityDefiner,
+	)
+	if b.insideSecurityDefiner && o.Type == tree.UDFRoutine {
+		// The execution privilege was checked for the owner of the enclosing
+		// SECURITY DEFINER routine.
+		b.factory.Metadata().AddOwnerPrivilege(
+			cat.StableID(catid.UserDefinedOIDToID(o.Oid)), b.checkPrivilegeUser, privilege.EXECUTE,
+		)
+	}
 
 	// Validate that the return types match the original return types defined in
 	// the function. Return types like user defined return types may change
@@ -264,6 +278,9 @@ func (b *Builder) buildRoutine(
 	// CTEs that mutate and are not at the top-level.
 	bodyScope := b.allocScope()
 	var params opt.ColList
+	// polyTypes are the concrete types bound to the polymorphic parameters of
+	// the routine, if any.
+	var polyTypes tree.PolymorphicTypes
 	if o.Types.Length() > 0 {
 		// Check whether some arguments were omitted. We need to use the
 		// corresponding DEFAULT expressions if so.
@@ -329,13 +346,31 @@ func (b *Builder) buildRoutine(
 				"different number of static parameters %d and actual arguments %d", len(paramTypes), len(args),
 			))
 		}
+		argTypes := make([]*types.T, len(args))
+		for i := range args {
+			argTypes[i] = args[i].DataType()
+		}
+		var err error
+		polyTypes, err = tree.ResolvePolymorphicTypes(paramTypes.Types(), argTypes)
+		if err != nil {
+			panic(err)
+		}
 		params = make(opt.ColList, len(paramTypes))
 		for i := range paramTypes {
 			paramType := &paramTypes[i]
 			argColName := funcParamColName(tree.Name(paramType.Name), i)
 			// Use the statically defined parameter type (unless it is a wildcard, in
-			// which case we use the actual argument type).
+			// which case we use the actual argument type). Arguments passed to
+			// polymorphic parameters are cast to the type bound to the parameter,
+			// which is the common type in the case of ANYCOMPATIBLE.
 			argType := paramType.Typ
+			if tree.IsPolymorphicType(argType) {
+				argType = tree.ReplacePolymorphicType(argType, polyTypes)
+				if !argType.IsWildcardType() && argType.Family() != types.UnknownFamily &&
+					!args[i].DataType().Identical(argType) {
+					args[i] = b.factory.ConstructCast(args[i], argType)
+				}
+			}
 			if argType.IsWildcardType() {
 				argType = args[i].DataType()
 			}
@@ -365,6 +400,18 @@ func (b *Builder) buildRoutine(
 	b.insideSQLRoutine = o.Language == tree.RoutineLangSQL
 	isSetReturning := o.Class == tree.GeneratorClass
 
+	// The body of a SECURITY DEFINER routine is built with the privileges of
+	// the routine's owner.
+	if o.SecurityMode == tree.RoutineDefiner {
+		defer func(checkPrivilegeUser username.SQLUsername, insideSecurityDefiner bool) {
+			b.checkPrivilegeUser = checkPrivilegeUser
+			b.insideSecurityDefiner = insideSecurityDefiner
+		}(b.checkPrivilegeUser, b.insideSecurityDefiner)
+		b.checkPrivilegeUser = o.Owner
+		b.insideSecurityDefiner = true
+	}
+	defer b.maybeOverrideSearchPath(o)()
+
 	// Build an expression for each statement in the function body.
 	var body []memo.RelExpr
 	var bodyProps []*physical.Required
@@ -429,7 +476,7 @@ func (b *Builder) buildRoutine(
 			}
 			routineParams = append(routineParams, routineParam{
 				name:  param.Name,
-				typ:   typ,
+				typ:   tree.ReplacePolymorphicType(typ, polyTypes),
 				class: param.Class,
 			})
 		}
@@ -438,6 +485,7 @@ func (b *Builder) buildRoutine(
 		plBuilder := newPLpgSQLBuilder(
 			b, def.Name, stmt.AST.Label, colRefs, routineParams, originalReturnType, isProc, outScope,
 		)
+		plBuilder.hasSessionOverride = o.SessionOverride() != nil
 		stmtScope := plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
 		expr, physProps = b.finishBuildLastStmt(
 			stmtScope, bodyScope, inScope, isSetReturning, oldInsideDataSource, f,
@@ -471,12 +519,57 @@ func (b *Builder) buildRoutine(
 				BodyProps:          bodyProps,
 				BodyStmts:          bodyStmts,
 				Params:             params,
+				SessionOverride:    o.SessionOverride(),
 			},
 		},
 	)
 	return routine
 }
 
+// maybeOverrideSearchPath overrides the search_path with the one configured
+// by a SET clause of the given routine, if any, so that names in the routine
+// body are resolved as they are during its execution. It returns a function
+// that restores the original search_path.
+func (b *Builder) maybeOverrideSearchPath(o *tree.Overload) (restore func()) {
+	for _, entry := range o.Config {
+		name, value, _ := strings.Cut(entry, "=")
+		if name != "search_path" {
+			continue
+		}
+		paths, err := sessiondata.ParseSearchPath(value)
+		if err != nil {
+			panic(err)
+		}
+		pop := b.pushSearchPath(paths)
+		restore := b.factory.Metadata().SetRoutineSearchPath(paths)
+		return func() {
+			restore()
+			pop()
+		}
+	}
+	return func() {}
+}
+
+// pushSearchPath pushes a copy of the session data with the given search_path
+// onto the session data stack, as pushRoutineSessionOverride does when a
+// routine is executed. It returns a function that pops the session data.
+func (b *Builder) pushSearchPath(paths []string) (pop func()) {
+	stack := b.evalCtx.SessionDataStack
+	stack.PushTopClone()
+	sd := stack.Top()
+	sd.SearchPath = sd.SearchPath.UpdatePaths(paths)
+	// The semantic context refers to the search_path of the session data that
+	// was at the top of the stack when the planner was initialized.
+	oldSearchPath := b.semaCtx.SearchPath
+	b.semaCtx.SearchPath = &sd.SearchPath
+	return func() {
+		b.semaCtx.SearchPath = oldSearchPath
+		if err := stack.Pop(); err != nil {
+			panic(err)
+		}
+	}
+}
+
 // finishBuildLastStmt manages the columns returned by the last statement of a
 // routine. Depending on the context and return type of the routine, this may
 // mean expanding a tuple into multiple columns, or combining multiple columns
@@ -811,6 +811,14 @@ func (b *Builder) constructComparison(
 		return b.factory.ConstructOverlaps(left, right)
 	case treecmp.TSMatches:
 		return b.factory.ConstructTSMatches(left, right)
+	case treecmp.WordSimilar:
+		return b.factory.ConstructWordSimilar(left, right)
+	case treecmp.WordSimilarCommutator:
+		return b.factory.ConstructWordSimilarCommutator(left, right)
+	case treecmp.StrictWordSimilar:
+		return b.factory.ConstructStrictWordSimilar(left, right)
+	case treecmp.StrictWordSimilarCommutator:
+		return b.factory.ConstructStrictWordSimilarCommutator(left, right)
 	}
 	panic(errors.AssertionFailedf("unhandled comparison operator: %s", redact.Safe(cmp.Operator)))
 }
@@ -780,6 +780,7 @@ func (b *Builder) buildScan(
 
 	b.addCheckConstraintsForTable(tabMeta)
 	b.addComputedColsForTable(tabMeta, virtualMutationColOrds)
+	b.addStatisticExprsForTable(tabMeta)
 	tabMeta.CacheIndexPartitionLocalities(b.evalCtx)
 
 	outScope.expr = b.factory.ConstructScan(&private)
@@ -979,6 +980,65 @@ func (b *Builder) addComputedColsForTable(
 	}
 }
 
+// addStatisticExprsForTable finds all table statistics collected on
+// expressions and caches their expressions in the table metadata as scalar
+// expressions, each with a synthesized column to hold its statistics. The
+// statistics builder uses them to estimate filters and projections on those
+// expressions. Any expressions which contain non-immutable operators are
+// omitted.
+func (b *Builder) addStatisticExprsForTable(tabMeta *opt.TableMeta) {
+	if !b.evalCtx.SessionData().OptimizerUseVirtualComputedColumnStats {
+		return
+	}
+	// We do not want to track view deps here, for the same reason as in
+	// addComputedColsForTable.
+	if b.trackSchemaDeps {
+		b.trackSchemaDeps = false
+		defer func() {
+			b.trackSchemaDeps = true
+		}()
+	}
+	var tableScope *scope
+	tab := tabMeta.Table
+	for i, n := 0, tab.StatisticCount(); i < n; i++ {
+		exprStr := tab.Statistic(i).Expression()
+		if exprStr == "" {
+			continue
+		}
+		if _, ok := tabMeta.StatisticExprCol(exprStr); ok {
+			// Several statistics may have been collected on the same expression.
+			continue
+		}
+		expr, err := parser.ParseExpr(exprStr)
+		if err != nil {
+			panic(err)
+		}
+
+		if tableScope == nil {
+			tableScope = b.allocScope()
+			tableScope.appendOrdinaryColumnsFromTable(tabMeta, &tabMeta.Alias)
+		}
+
+		if texpr := tableScope.resolveType(expr, types.Any); texpr != nil {
+			var scalar opt.ScalarExpr
+			b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
+				scalar = b.buildScalar(texpr, tableScope, nil, nil, nil)
+			})
+			// Check if the expression contains non-immutable operators.
+			var sharedProps props.Shared
+			memo.BuildSharedProps(scalar, &sharedProps, b.evalCtx)
+			if !sharedProps.VolatilitySet.HasStable() && !sharedProps.VolatilitySet.HasVolatile() {
+				tabMeta.AddStatisticExpr(opt.StatisticExpr{
+					Expr:      exprStr,
+					Col:       b.factory.Metadata().AddColumn(exprStr, scalar.DataType()),
+					Scalar:    scalar,
+					OuterCols: sharedProps.OuterCols,
+				})
+			}
+		}
+	}
+}
+
 func (b *Builder) buildSequenceSelect(
 	seq cat.Sequence, seqName *tree.TableName, inScope *scope,
 ) (outScope *scope) {
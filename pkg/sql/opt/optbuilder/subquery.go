@@ -75,7 +75,7 @@ type subquery struct {
 
 // isMultiRow returns whether the subquery can return multiple rows.
 func (s *subquery) isMultiRow() bool {
-	return s.wrapInTuple && !s.Exists
+	return s.wrapInTuple && !s.Exists && !s.Unique
 }
 
 // Walk is part of the tree.Expr interface.
@@ -106,7 +106,7 @@ func (s *subquery) TypeCheck(
 
 	// The typing for subqueries is complex, but regular.
 	//
-	// * If the subquery is part of an EXISTS statement:
+	// * If the subquery is part of an EXISTS or UNIQUE predicate:
 	//
 	//   The type of the subquery is always "bool".
 	//
@@ -160,7 +160,7 @@ func (s *subquery) TypeCheck(
 	// Without that auto-unwrapping of single-column subqueries, this query would
 	// type check as "<int> IN <tuple{tuple{int}}>" which would fail.
 
-	if s.Exists {
+	if s.Exists || s.Unique {
 		s.typ = types.Bool
 		return s, nil
 	}
@@ -330,6 +330,47 @@ func (b *Builder) buildSubqueryProjection(
 	return out, outScope
 }
 
+// buildUniquePredicateDuplicates builds an expression which returns one row
+// for each group of duplicate rows in the subquery of a UNIQUE predicate. Rows
+// containing a NULL are not considered, so the predicate
+//
+//	UNIQUE (SELECT a, b FROM t)
+//
+// is lowered to:
+//
+//	NOT EXISTS (
+//	  SELECT a, b FROM t
+//	  WHERE a IS NOT NULL AND b IS NOT NULL
+//	  GROUP BY a, b
+//	  HAVING count(*) > 1
+//	)
+func (b *Builder) buildUniquePredicateDuplicates(s *subquery) memo.RelExpr {
+	f := b.factory
+	var groupingCols opt.ColSet
+	notNullFilters := make(memo.FiltersExpr, len(s.cols))
+	for i := range s.cols {
+		colID := s.cols[i].id
+		groupingCols.Add(colID)
+		notNullFilters[i] = f.ConstructFiltersItem(
+			f.ConstructIsNot(f.ConstructVariable(colID), memo.NullSingleton),
+		)
+	}
+	input := f.ConstructSelect(s.node, notNullFilters)
+
+	countCol := f.Metadata().AddColumn("count", types.Int)
+	aggs := memo.AggregationsExpr{
+		f.ConstructAggregationsItem(f.ConstructCountRows(), countCol),
+	}
+	input = f.ConstructGroupBy(input, aggs, &memo.GroupingPrivate{GroupingCols: groupingCols})
+
+	return f.ConstructSelect(input, memo.FiltersExpr{f.ConstructFiltersItem(
+		f.ConstructGt(
+			f.ConstructVariable(countCol),
+			f.ConstructConstVal(tree.NewDInt(1), types.Int),
+		),
+	)})
+}
+
 // buildSingleRowSubquery builds a set of memo groups that represent the given
 // subquery. This function should only be called for subqueries in a single-row
 // context, such as `SELECT (1, 'a') = (SELECT 1, 'a')`.
@@ -348,6 +389,15 @@ func (b *Builder) buildSingleRowSubquery(
 		}
 		return b.factory.ConstructExists(s.node, &ex), inScope
 	}
+	if s.Unique {
+		col := b.factory.Metadata().AddColumn("unique", types.Bool)
+		ex := memo.ExistsPrivate{
+			LazyEvalProjectionCol: col,
+			SubqueryPrivate:       subqueryPrivate,
+		}
+		duplicates := b.buildUniquePredicateDuplicates(s)
+		return b.factory.ConstructNot(b.factory.ConstructExists(duplicates, &ex)), inScope
+	}
 
 	var input memo.RelExpr
 	input, outScope = b.buildSubqueryProjection(s, inScope)
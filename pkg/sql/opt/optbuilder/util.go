@@ -510,7 +510,7 @@ func (b *Builder) resolveSchemaForCreate(
 		panic(err)
 	}
 
-	if err := b.catalog.CheckPrivilege(b.ctx, sch, privilege.CREATE); err != nil {
+	if err := b.catalog.CheckPrivilege(b.ctx, sch, b.checkPrivilegeUser, privilege.CREATE); err != nil {
 		panic(err)
 	}
 
@@ -703,17 +703,24 @@ func (b *Builder) resolveDataSourceRef(
 	return ds, depName
 }
 
-// checkPrivilege ensures that the current user has the privilege needed to
-// access the given object in the catalog. If not, then checkPrivilege raises an
-// error. It also adds the object and it's original unresolved name as a
-// dependency to the metadata, so that the privileges can be re-checked on reuse
-// of the memo.
+// checkPrivilege ensures that the current user (or the owner of the enclosing
+// SECURITY DEFINER routine) has the privilege needed to access the given object
+// in the catalog. If not, then checkPrivilege raises an error. It also adds the
+// object and it's original unresolved name as a dependency to the metadata, so
+// that the privileges can be re-checked for the same user on reuse of the memo.
 func (b *Builder) checkPrivilege(name opt.MDDepName, ds cat.DataSource, priv privilege.Kind) {
 	if !(priv == privilege.SELECT && b.skipSelectPrivilegeChecks) {
-		err := b.catalog.CheckPrivilege(b.ctx, ds, priv)
+		err := b.catalog.CheckPrivilege(b.ctx, ds, b.checkPrivilegeUser, priv)
 		if err != nil {
 			panic(err)
 		}
+		if b.insideSecurityDefiner {
+			// The privilege was checked for the owner of a SECURITY DEFINER
+			// routine, so it is re-checked for the owner rather than for the
+			// current user.
+			b.factory.Metadata().AddOwnerPrivilege(ds.ID(), b.checkPrivilegeUser, priv)
+			priv = 0
+		}
 	} else {
 		// The check is skipped, so don't recheck when dependencies are checked.
 		priv = 0
@@ -98,7 +98,9 @@ func (c *customFuncs) MakePhysProps(
 // ExplainOptions creates a tree.ExplainOptions from a comma-separated list of
 // options.
 func (c *customFuncs) ExplainOptions(opts string) tree.ExplainOptions {
-	explain, err := tree.MakeExplain(strings.Split(opts, ","), &tree.Select{})
+	explain, err := tree.MakeExplain(
+		tree.ExplainOptionList{Names: strings.Split(opts, ",")}, &tree.Select{},
+	)
 	if err != nil {
 		panic(exprGenErr{err})
 	}
@@ -62,7 +62,9 @@ type Statistics struct {
 	// VirtualCols is the set of virtual computed columns produced by our input
 	// that we have statistics on. Any of these could appear in ColStats. This set
 	// is maintained separately from OutputCols to allow lookup of statistics on
-	// virtual columns for expressions that synthesize virtual columns.
+	// virtual columns for expressions that synthesize virtual columns. It also
+	// contains the columns synthesized for table statistics collected on
+	// expressions (see opt.TableMeta.StatisticExprs).
 	VirtualCols opt.ColSet
 
 	// ColStats is a collection of statistics that pertain to columns in an
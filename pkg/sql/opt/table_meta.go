@@ -180,6 +180,17 @@ type TableMeta struct {
 	// expressions used to build the column data of computed columns.
 	ColsInComputedColsExpressions ColSet
 
+	// statisticExprs stores ScalarExprs for the expressions of table statistics
+	// collected on expressions, along with the columns synthesized to hold
+	// them. The statisticsBuilder uses them to look up the statistics of filters
+	// and projections on those expressions, in the same way it uses statistics
+	// on virtual computed columns. The synthesized columns do not belong to the
+	// table (their ColumnMeta.Table is zero), since they are not part of its
+	// schema.
+	//
+	// Expressions with non-immutable operators are omitted.
+	statisticExprs []StatisticExpr
+
 	// partialIndexPredicates is a map from index ordinals on the table to
 	// *FiltersExprs representing the predicate on the corresponding partial
 	// index. If an index is not a partial index, it will not have an entry in
@@ -313,6 +324,14 @@ func (tm *TableMeta) copyFrom(from *TableMeta, copyScalarFn func(Expr) Expr) {
 		tm.ColsInComputedColsExpressions = from.ColsInComputedColsExpressions
 	}
 
+	if from.statisticExprs != nil {
+		tm.statisticExprs = make([]StatisticExpr, len(from.statisticExprs))
+		for i := range from.statisticExprs {
+			tm.statisticExprs[i] = from.statisticExprs[i]
+			tm.statisticExprs[i].Scalar = copyScalarFn(from.statisticExprs[i].Scalar).(ScalarExpr)
+		}
+	}
+
 	if from.partialIndexPredicates != nil {
 		tm.partialIndexPredicates = make(map[cat.IndexOrdinal]ScalarExpr, len(from.partialIndexPredicates))
 		for idx, e := range from.partialIndexPredicates {
@@ -418,6 +437,45 @@ func (tm *TableMeta) ComputedColExpr(id ColumnID) (_ ScalarExpr, ok bool) {
 	return e, ok
 }
 
+// StatisticExpr is the expression of a table statistic collected on an
+// expression. See TableMeta.statisticExprs.
+type StatisticExpr struct {
+	// Expr is the serialized expression, as stored with the statistic.
+	Expr string
+
+	// Col is the column synthesized to hold the value of the expression.
+	Col ColumnID
+
+	// Scalar is the built expression.
+	Scalar ScalarExpr
+
+	// OuterCols is the set of table columns referenced by the expression.
+	OuterCols ColSet
+}
+
+// AddStatisticExpr adds the expression of a table statistic collected on an
+// expression to the table's metadata.
+func (tm *TableMeta) AddStatisticExpr(e StatisticExpr) {
+	tm.statisticExprs = append(tm.statisticExprs, e)
+}
+
+// StatisticExprs returns the expressions of table statistics collected on
+// expressions which were added with AddStatisticExpr.
+func (tm *TableMeta) StatisticExprs() []StatisticExpr {
+	return tm.statisticExprs
+}
+
+// StatisticExprCol returns the column synthesized for the given serialized
+// statistic expression, if the expression was added with AddStatisticExpr.
+func (tm *TableMeta) StatisticExprCol(expr string) (_ ColumnID, ok bool) {
+	for i := range tm.statisticExprs {
+		if tm.statisticExprs[i].Expr == expr {
+			return tm.statisticExprs[i].Col, true
+		}
+	}
+	return 0, false
+}
+
 // AddCheckConstraintsStats adds a column, ColumnStatistic pair to the
 // checkConstraintsStats map. When the table is duplicated, the mapping from the
 // new check constraint ColumnID back to the original ColumnStatistic is
@@ -47,6 +47,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/execbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/hints"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/indexrec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
@@ -474,6 +475,12 @@ func New(catalog cat.Catalog, sql string) *OptTester {
 //     Walks through the SQL statement and recommends indexes to add in order to
 //     speed up its execution, if these indexes exist. See the indexrec package.
 //
+//   - hypothetical-indexes
+//
+//     Fully optimizes an EXPLAIN (HYPOTHETICAL INDEXES (...)) statement as if
+//     the index changes of the HYPOTHETICAL INDEXES option were made, and
+//     outputs the resulting memo. See the indexrec package.
+//
 // Supported flags:
 //
 //   - format: controls the formatting of expressions for build, opt, and
@@ -866,6 +873,21 @@ func (ot *OptTester) RunCommand(tb testing.TB, d *datadriven.TestData) string {
 		}
 		return result
 
+	case "hypothetical-indexes":
+		result, err := ot.HypotheticalIndexes()
+		if err != nil {
+			if errors.HasAssertionFailure(err) {
+				d.Fatalf(tb, "%+v", err)
+			}
+			pgerr := pgerror.Flatten(err)
+			text := strings.TrimSpace(pgerr.Error())
+			if pgcode.MakeCode(pgerr.Code) != pgcode.Uncategorized {
+				return fmt.Sprintf("error (%s): %s\n", pgerr.Code, text)
+			}
+			return fmt.Sprintf("error: %s\n", text)
+		}
+		return result
+
 	case "statement-bundle":
 		if err := ot.StatementBundle(tb); err != nil {
 			d.Fatalf(tb, "%+v", err)
@@ -2325,6 +2347,29 @@ func (ot *OptTester) IndexRecommendations() (string, error) {
 	return sb.String(), nil
 }
 
+// HypotheticalIndexes is used with the hypothetical-indexes option. It
+// optimizes an EXPLAIN (HYPOTHETICAL INDEXES (...)) statement with hypothetical
+// tables which reflect the index changes, and formats the optimized expression.
+func (ot *OptTester) HypotheticalIndexes() (string, error) {
+	stmt, err := parser.ParseOne(ot.sql)
+	if err != nil {
+		return "", err
+	}
+	explain, ok := stmt.AST.(*tree.Explain)
+	if !ok || len(explain.HypotheticalIndexes) == 0 {
+		return "", errors.New("hypothetical-indexes requires an EXPLAIN (HYPOTHETICAL INDEXES ...) statement")
+	}
+	hypTables, err := indexrec.BuildHypotheticalTables(ot.ctx, ot.catalog, explain.HypotheticalIndexes)
+	if err != nil {
+		return "", err
+	}
+	optExpr, err := ot.OptimizeWithTables(hypTables)
+	if err != nil {
+		return "", err
+	}
+	return ot.FormatExpr(optExpr), nil
+}
+
 func (ot *OptTester) buildExpr(factory *norm.Factory) error {
 	stmt, err := parser.ParseOne(ot.sql)
 	if err != nil {
@@ -2333,6 +2378,11 @@ func (ot *OptTester) buildExpr(factory *norm.Factory) error {
 	ot.semaCtx.Placeholders.Init(stmt.NumPlaceholders, nil /* typeHints */)
 	ot.semaCtx.Annotations = tree.MakeAnnotations(stmt.NumAnnotations)
 	ot.semaCtx.TypeResolver = ot.catalog
+	h, err := hints.FromComments(stmt.Comments)
+	if err != nil {
+		return err
+	}
+	factory.Memo().SetHints(h)
 	b := optbuilder.New(ot.ctx, &ot.semaCtx, &ot.evalCtx, ot.catalog, factory, stmt.AST)
 	return b.Build()
 }
@@ -0,0 +1,274 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package workloadindexrec
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/errors"
+)
+
+// HypotheticalIndexImpact is the expected impact of a hypothetical index
+// change on a statement of the workload.
+type HypotheticalIndexImpact struct {
+	// Query is the fingerprint of the statement.
+	Query string
+	// Executions is the number of times the statement was executed.
+	Executions int64
+	// IndexChange is the CREATE INDEX or DROP INDEX statement of the index
+	// change.
+	IndexChange string
+	// Improves is true if the index change is expected to improve the plan of
+	// the statement, and false if it is expected to regress it.
+	Improves bool
+}
+
+// FindHypotheticalIndexImpacts finds the statements executed after the
+// timestamp ts which are expected to be impacted by the given semicolon
+// separated CREATE INDEX and DROP INDEX statements, based on the statement
+// statistics:
+//
+//   - A created index is expected to improve a statement if it can serve as
+//     one of the indexes recommended for the statement: the index is on the
+//     same table, its columns start with the columns of the recommended index,
+//     and it contains all the stored columns of the recommended index.
+//   - A dropped index is expected to regress a statement if the statement read
+//     from the index.
+//
+// Statements whose fingerprint was not recorded are ignored. The impacts are
+// ordered by the number of executions of the statements, in descending order.
+func FindHypotheticalIndexImpacts(
+	ctx context.Context, evalCtx *eval.Context, changes string, ts *tree.DTimestampTZ,
+) ([]HypotheticalIndexImpact, error) {
+	stmts, err := parser.Parse(changes)
+	if err != nil {
+		return nil, err
+	}
+	// droppedIndexes contains the "tableID@indexID" identifiers of the dropped
+	// indexes, in the format of the indexes in the statement statistics.
+	droppedIndexes := make([]string, len(stmts))
+	for i, stmt := range stmts {
+		switch stmt := stmt.AST.(type) {
+		case *tree.CreateIndex:
+		case *tree.DropIndex:
+			if len(stmt.IndexList) != 1 {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"each hypothetical DROP INDEX statement must drop exactly one index: %s", stmt)
+			}
+			droppedIndexes[i], err = resolveIndex(ctx, evalCtx, stmt.IndexList[0])
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"%s is not a CREATE INDEX or DROP INDEX statement", stmt.AST.StatementTag())
+		}
+	}
+
+	workload, err := collectStatements(ctx, evalCtx, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	var impacts []HypotheticalIndexImpact
+	for _, s := range workload {
+		for i, stmt := range stmts {
+			impact := HypotheticalIndexImpact{
+				Query:       s.query,
+				Executions:  s.executions,
+				IndexChange: stmt.AST.String(),
+			}
+			switch stmt := stmt.AST.(type) {
+			case *tree.CreateIndex:
+				if s.isCoveredBy(stmt) {
+					impact.Improves = true
+					impacts = append(impacts, impact)
+				}
+			case *tree.DropIndex:
+				if s.indexes[droppedIndexes[i]] {
+					impacts = append(impacts, impact)
+				}
+			}
+		}
+	}
+	sort.SliceStable(impacts, func(i, j int) bool {
+		if impacts[i].Executions != impacts[j].Executions {
+			return impacts[i].Executions > impacts[j].Executions
+		}
+		return impacts[i].Query < impacts[j].Query
+	})
+	return impacts, nil
+}
+
+// resolveIndex returns the "tableID@indexID" identifier of the given index.
+func resolveIndex(
+	ctx context.Context, evalCtx *eval.Context, index *tree.TableIndexName,
+) (string, error) {
+	if index.Table.ObjectName == "" {
+		return "", pgerror.Newf(pgcode.InvalidParameterValue,
+			"hypothetically dropped index %q must be qualified with its table name", index.Index)
+	}
+	query := `SELECT descriptor_id, index_id FROM crdb_internal.table_indexes
+						 WHERE descriptor_id = $1::STRING::REGCLASS::INT8 AND index_name = $2`
+	row, err := evalCtx.Planner.QueryRowEx(ctx, "resolve-hypothetically-dropped-index",
+		sessiondata.NoSessionDataOverride, query, index.Table.String(), string(index.Index))
+	if err != nil {
+		return "", err
+	}
+	if row == nil {
+		return "", pgerror.Newf(pgcode.UndefinedObject, "index %q does not exist", index.Index)
+	}
+	return fmt.Sprintf("%d@%d", tree.MustBeDInt(row[0]), tree.MustBeDInt(row[1])), nil
+}
+
+// workloadStatement contains the statistics of a statement of the workload
+// which are used to determine the impact of hypothetical index changes.
+type workloadStatement struct {
+	query      string
+	executions int64
+	// recs contains the indexes recommended for the statement.
+	recs []*tree.CreateIndex
+	// indexes contains the "tableID@indexID" identifiers of the indexes read by
+	// the statement.
+	indexes map[string]bool
+}
+
+// isCoveredBy returns true if the given index can serve as one of the indexes
+// recommended for the statement.
+func (s *workloadStatement) isCoveredBy(ci *tree.CreateIndex) bool {
+	for _, rec := range s.recs {
+		if rec.Table.ObjectName == ci.Table.ObjectName && indexCovers(ci, rec) {
+			return true
+		}
+	}
+	return false
+}
+
+// indexCovers returns true if the columns of the index ci start with the
+// columns of the index rec, and ci contains all the stored columns of rec.
+func indexCovers(ci, rec *tree.CreateIndex) bool {
+	if len(rec.Columns) > len(ci.Columns) {
+		return false
+	}
+	cols := make(map[tree.Name]bool, len(ci.Columns)+len(ci.Storing))
+	for i := range ci.Columns {
+		if i < len(rec.Columns) {
+			recCol, col := &rec.Columns[i], &ci.Columns[i]
+			if recCol.Column != col.Column ||
+				(recCol.Direction == tree.Descending) != (col.Direction == tree.Descending) {
+				return false
+			}
+		}
+		cols[ci.Columns[i].Column] = true
+	}
+	for _, col := range ci.Storing {
+		cols[col] = true
+	}
+	for _, col := range rec.Storing {
+		if !cols[col] {
+			return false
+		}
+	}
+	return true
+}
+
+// collectStatements collects the statistics of the statements stored in the
+// system.statement_statistics with the time later than ts. The statistics of
+// the same statement fingerprint are combined.
+func collectStatements(
+	ctx context.Context, evalCtx *eval.Context, ts *tree.DTimestampTZ,
+) (_ []*workloadStatement, retErr error) {
+	query := `SELECT metadata ->> 'query',
+						 COALESCE((statistics -> 'statistics' ->> 'cnt')::INT8, 0),
+						 COALESCE(index_recommendations, ARRAY[]::STRING[]),
+						 COALESCE(statistics -> 'statistics' -> 'indexes', '[]'::JSONB)
+						 FROM system.statement_statistics
+						 WHERE (statistics -> 'statistics' ->> 'lastExecAt')::TIMESTAMPTZ > $1
+						 AND metadata ->> 'query' IS NOT NULL;`
+	it, err := evalCtx.Planner.QueryIteratorEx(ctx, "get-statements-for-hypothetical-indexes",
+		sessiondata.NoSessionDataOverride, query, ts.Time)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		retErr = errors.CombineErrors(retErr, it.Close())
+	}()
+
+	// The index recommendation starts with "creation", "replacement" or
+	// "alteration". Only the created indexes are relevant.
+	r := regexp.MustCompile(`\s*(creation|replacement)\s*:\s*(.*)`)
+
+	var stmts []*workloadStatement
+	byQuery := make(map[string]*workloadStatement)
+	var ok bool
+	for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+		row := it.Cur()
+		query := string(tree.MustBeDString(row[0]))
+		s, found := byQuery[query]
+		if !found {
+			s = &workloadStatement{query: query, indexes: make(map[string]bool)}
+			byQuery[query] = s
+			stmts = append(stmts, s)
+		}
+		s.executions += int64(tree.MustBeDInt(row[1]))
+
+		for _, rec := range tree.MustBeDArray(row[2]).Array {
+			recStr, ok := rec.(*tree.DString)
+			if !ok {
+				continue
+			}
+			recStrArr := r.FindStringSubmatch(string(*recStr))
+			if recStrArr == nil {
+				continue
+			}
+			recStmts, err := parser.Parse(recStrArr[2])
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s is not a valid index operation", recStrArr[2])
+			}
+			for _, recStmt := range recStmts {
+				if ci, ok := recStmt.AST.(*tree.CreateIndex); ok && !ci.Inverted && ci.Predicate == nil {
+					s.recs = append(s.recs, ci)
+				}
+			}
+		}
+
+		indexes := tree.MustBeDJSON(row[3]).JSON
+		for i := 0; i < indexes.Len(); i++ {
+			index, err := indexes.FetchValIdx(i)
+			if err != nil {
+				return nil, err
+			}
+			if index == nil {
+				continue
+			}
+			text, err := index.AsText()
+			if err != nil {
+				return nil, err
+			}
+			if text != nil {
+				s.indexes[*text] = true
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stmts, nil
+}
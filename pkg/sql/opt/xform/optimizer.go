@@ -291,20 +291,21 @@ func (o *Optimizer) Optimize() (_ opt.Expr, err error) {
 }
 
 // optimizeExpr calls either optimizeGroup or optimizeScalarExpr depending on
-// the type of the expression (relational or scalar).
+// the type of the expression (relational or scalar). It returns the cost of
+// the best expression tree and the number of plan hints that tree violates.
 func (o *Optimizer) optimizeExpr(
 	e opt.Expr, required *physical.Required,
-) (cost memo.Cost, fullyOptimized bool) {
+) (cost memo.Cost, hintViolations int, fullyOptimized bool) {
 	switch t := e.(type) {
 	case memo.RelExpr:
 		state := o.optimizeGroup(t, required)
-		return state.cost, state.fullyOptimized
+		return state.cost, state.hintViolations, state.fullyOptimized
 
 	case memo.ScalarPropsExpr:
 		// Short-circuit traversal of scalar expressions with no nested subquery,
 		// since there's only one possible tree.
 		if !t.ScalarProps().HasSubquery {
-			return 0, true
+			return 0, 0, true
 		}
 		return o.optimizeScalarExpr(t)
 
@@ -570,13 +571,15 @@ func (o *Optimizer) optimizeGroupMember(
 	// enforcers to provide the remainder.
 	if CanProvidePhysicalProps(o.ctx, o.evalCtx, member, required) {
 		var cost memo.Cost
+		hintViolations := planHintViolations(o.mem, member)
 		for i, n := 0, member.ChildCount(); i < n; i++ {
 			// Given required parent properties, get the properties required from
 			// the nth child.
 			childRequired := BuildChildPhysicalProps(o.mem, member, i, required)
 
 			// Optimize the child with respect to those properties.
-			childCost, childOptimized := o.optimizeExpr(member.Child(i), childRequired)
+			childCost, childViolations, childOptimized := o.optimizeExpr(member.Child(i), childRequired)
+			hintViolations += childViolations
 
 			// Accumulate cost of children.
 			if member.Op() == opt.LocalityOptimizedSearchOp && i > 0 {
@@ -603,7 +606,7 @@ func (o *Optimizer) optimizeGroupMember(
 
 		// Check whether this is the new lowest cost expression.
 		cost += o.coster.ComputeCost(member, required)
-		o.ratchetCost(state, member, cost)
+		o.ratchetCost(state, member, cost, hintViolations)
 	}
 
 	return fullyOptimized
@@ -615,14 +618,15 @@ func (o *Optimizer) optimizeGroupMember(
 // plan.
 func (o *Optimizer) optimizeScalarExpr(
 	scalar opt.ScalarExpr,
-) (cost memo.Cost, fullyOptimized bool) {
+) (cost memo.Cost, hintViolations int, fullyOptimized bool) {
 	fullyOptimized = true
 	for i, n := 0, scalar.ChildCount(); i < n; i++ {
 		childProps := BuildChildPhysicalPropsScalar(o.mem, scalar, i)
-		childCost, childOptimized := o.optimizeExpr(scalar.Child(i), childProps)
+		childCost, childViolations, childOptimized := o.optimizeExpr(scalar.Child(i), childProps)
 
 		// Accumulate cost of children.
 		cost += childCost
+		hintViolations += childViolations
 
 		// If any child expression is not fully optimized, then the parent
 		// expression is also not fully optimized.
@@ -630,7 +634,7 @@ func (o *Optimizer) optimizeScalarExpr(
 			fullyOptimized = false
 		}
 	}
-	return cost, fullyOptimized
+	return cost, hintViolations, fullyOptimized
 }
 
 // enforceProps costs an expression where one of the physical properties has
@@ -722,7 +726,7 @@ func (o *Optimizer) optimizeEnforcer(
 	// Check whether this is the new lowest cost expression with the enforcer
 	// added.
 	cost := innerState.cost + o.coster.ComputeCost(enforcer, enforcerProps)
-	if o.ratchetCost(state, enforcer, cost) {
+	if o.ratchetCost(state, enforcer, cost, innerState.hintViolations) {
 		if _, ok := enforcer.(*memo.SortExpr); ok {
 			// The expression was added to the memo, so lose the reference.
 			o.scratchSort = nil
@@ -825,11 +829,17 @@ func (o *Optimizer) setLowestCostTree(parent opt.Expr, parentProps *physical.Req
 // ratchetCost computes the cost of the candidate expression, and then checks
 // whether it's lower than the cost of the existing best expression in the
 // group. If so, then the candidate becomes the new lowest cost expression.
-// ratchetCost returns true if the candidate is the new lowest-cost expression.
-func (o *Optimizer) ratchetCost(state *groupState, candidate memo.RelExpr, cost memo.Cost) bool {
-	if state.best == nil || cost.Less(state.cost) {
+// Candidates violating fewer plan hints are preferred regardless of their
+// cost. ratchetCost returns true if the candidate is the new lowest-cost
+// expression.
+func (o *Optimizer) ratchetCost(
+	state *groupState, candidate memo.RelExpr, cost memo.Cost, hintViolations int,
+) bool {
+	if state.best == nil || hintViolations < state.hintViolations ||
+		(hintViolations == state.hintViolations && cost.Less(state.cost)) {
 		state.best = candidate
 		state.cost = cost
+		state.hintViolations = hintViolations
 		return true
 	}
 	return false
@@ -973,6 +983,11 @@ type groupState struct {
 	// expression with the lowest cost.
 	cost memo.Cost
 
+	// hintViolations is the number of plan hints violated by the best
+	// expression tree. An expression tree violating fewer hints is better than
+	// one violating more, no matter its cost.
+	hintViolations int
+
 	// fullyOptimized is set to true once the lowest cost expression has been
 	// found for a memo group, with respect to the required properties. A lower
 	// cost expression will never be found, no matter how many additional
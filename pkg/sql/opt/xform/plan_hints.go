@@ -0,0 +1,127 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package xform
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/hints"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+)
+
+// Unlike index and join hints in the query syntax, plan hints are not
+// enforced by restricting the plans the optimizer considers, so a plan is
+// still produced if no plan satisfies them. Instead, the optimizer counts the
+// hints each expression tree violates, and prefers the trees with the fewest
+// violations regardless of their cost. The count is kept apart from the cost
+// so that it doesn't interfere with cost comparisons or with the huge costs
+// the coster uses to avoid other expressions.
+
+// planHintViolations returns the number of plan hints of the memo that the
+// given candidate violates. It only considers the candidate itself, not its
+// children.
+func planHintViolations(mem *memo.Memo, candidate memo.RelExpr) (violations int) {
+	h := mem.Hints()
+	if h == nil {
+		return 0
+	}
+	md := mem.Metadata()
+
+	// scanned checks the scan hints for a read of the given index of a table.
+	scanned := func(tabID opt.TableID, method hints.ScanMethod, index cat.IndexOrdinal) {
+		rel, ok := mem.HintRelation(tabID)
+		if !ok {
+			return
+		}
+		indexName := string(md.Table(tabID).Index(index).Name())
+		for i := range h.Scans {
+			if h.Scans[i].Rel == rel && !h.Scans[i].Allows(method, indexName) {
+				violations++
+			}
+		}
+	}
+	// tableRels returns the relations of the table read by a lookup or inverted
+	// join.
+	tableRels := func(tabID opt.TableID) (rels hints.RelSet) {
+		if rel, ok := mem.HintRelation(tabID); ok {
+			rels.Named.Add(rel)
+		} else {
+			rels.Other = true
+		}
+		return rels
+	}
+
+	var method hints.JoinMethod
+	var left, right hints.RelSet
+	switch t := candidate.(type) {
+	case *memo.ScanExpr:
+		if t.Index == cat.PrimaryIndex && t.Constraint == nil && t.InvertedConstraint == nil {
+			scanned(t.Table, hints.SeqScan, t.Index)
+		} else {
+			scanned(t.Table, hints.IndexScan, t.Index)
+		}
+		return violations
+
+	case *memo.ZigzagJoinExpr:
+		scanned(t.LeftTable, hints.IndexScan, t.LeftIndex)
+		scanned(t.RightTable, hints.IndexScan, t.RightIndex)
+		return violations
+
+	case *memo.InnerJoinExpr, *memo.LeftJoinExpr, *memo.RightJoinExpr, *memo.FullJoinExpr,
+		*memo.SemiJoinExpr, *memo.AntiJoinExpr:
+		method = hints.HashJoin
+		left = mem.HintRelations(candidate.Child(0).(memo.RelExpr))
+		right = mem.HintRelations(candidate.Child(1).(memo.RelExpr))
+
+	case *memo.MergeJoinExpr:
+		method = hints.MergeJoin
+		left, right = mem.HintRelations(t.Left), mem.HintRelations(t.Right)
+
+	case *memo.InnerJoinApplyExpr, *memo.LeftJoinApplyExpr, *memo.SemiJoinApplyExpr,
+		*memo.AntiJoinApplyExpr:
+		method = hints.NestLoop
+		left = mem.HintRelations(candidate.Child(0).(memo.RelExpr))
+		right = mem.HintRelations(candidate.Child(1).(memo.RelExpr))
+
+	case *memo.LookupJoinExpr:
+		if t.IsSecondJoinInPairedJoiner || t.Input.Relational().OutputCols.Intersects(
+			md.TableMeta(t.Table).IndexKeyColumns(cat.PrimaryIndex),
+		) {
+			// The lookup fetches the remaining columns of a table which was already
+			// read by the input, like an index join. This includes the second join
+			// of a paired joiner.
+			return violations
+		}
+		scanned(t.Table, hints.IndexScan, t.Index)
+		method = hints.NestLoop
+		left, right = mem.HintRelations(t.Input), tableRels(t.Table)
+
+	case *memo.InvertedJoinExpr:
+		scanned(t.Table, hints.IndexScan, t.Index)
+		method = hints.NestLoop
+		left, right = mem.HintRelations(t.Input), tableRels(t.Table)
+
+	default:
+		return violations
+	}
+	joined := left.Union(right)
+	for i := range h.Joins {
+		jh := &h.Joins[i]
+		if joined.Equals(jh.Rels) && mem.HintApplies(jh.Rels) && !jh.Allows(method) {
+			violations++
+		}
+	}
+	if h.Leading != nil && mem.HintApplies(h.Leading.Rels) &&
+		!h.Leading.AllowsJoin(left, right) {
+		violations++
+	}
+	return violations
+}
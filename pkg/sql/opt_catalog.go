@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/indexrec"
@@ -402,15 +403,17 @@ func getDescForDataSource(o cat.DataSource) (catalog.TableDescriptor, error) {
 }
 
 // CheckPrivilege is part of the cat.Catalog interface.
-func (oc *optCatalog) CheckPrivilege(ctx context.Context, o cat.Object, priv privilege.Kind) error {
+func (oc *optCatalog) CheckPrivilege(
+	ctx context.Context, o cat.Object, user username.SQLUsername, priv privilege.Kind,
+) error {
 	if o.ID() == 0 {
-		return oc.planner.CheckPrivilege(ctx, syntheticprivilege.GlobalPrivilegeObject, priv)
+		return oc.planner.CheckPrivilegeForUser(ctx, syntheticprivilege.GlobalPrivilegeObject, priv, user)
 	}
 	desc, err := getDescFromCatalogObjectForPermissions(o)
 	if err != nil {
 		return err
 	}
-	return oc.planner.CheckPrivilege(ctx, desc, priv)
+	return oc.planner.CheckPrivilegeForUser(ctx, desc, priv, user)
 }
 
 // CheckAnyPrivilege is part of the cat.Catalog interface.
@@ -423,12 +426,19 @@ func (oc *optCatalog) CheckAnyPrivilege(ctx context.Context, o cat.Object) error
 }
 
 // CheckExecutionPrivilege is part of the cat.Catalog interface.
-func (oc *optCatalog) CheckExecutionPrivilege(ctx context.Context, oid oid.Oid) error {
+func (oc *optCatalog) CheckExecutionPrivilege(
+	ctx context.Context, oid oid.Oid, user username.SQLUsername,
+) error {
 	desc, err := oc.planner.FunctionDesc(ctx, oid)
 	if err != nil {
 		return errors.WithAssertionFailure(err)
 	}
-	return oc.planner.CheckPrivilege(ctx, desc, privilege.EXECUTE)
+	return oc.planner.CheckPrivilegeForUser(ctx, desc, privilege.EXECUTE, user)
+}
+
+// GetCurrentUser is part of the cat.Catalog interface.
+func (oc *optCatalog) GetCurrentUser() username.SQLUsername {
+	return oc.planner.User()
 }
 
 // HasAdminRole is part of the cat.Catalog interface.
@@ -1796,6 +1806,20 @@ func (os *optTableStat) init(tab *optTable, stat *stats.TableStatistic) (ok bool
 		}
 	}
 
+	if stat.IsExpression() {
+		// The expression refers to columns by name. Skip the statistic if the
+		// names no longer resolve to the columns the statistic was collected on
+		// (this is possible if a column was renamed).
+		expr, err := parser.ParseExpr(stat.Expression)
+		if err != nil {
+			return false, err
+		}
+		colIDs, err := schemaexpr.ExtractColumnIDs(tab.desc, expr)
+		if err != nil || !colIDs.Equals(catalog.MakeTableColSet(stat.ColumnIDs...)) {
+			return false, nil //nolint:returnerrcheck
+		}
+	}
+
 	return true, nil
 }
 
@@ -1805,6 +1829,9 @@ func (os *optTableStat) equals(other *optTableStat) bool {
 	if os.CreatedAt() != other.CreatedAt() || len(os.columnOrdinals) != len(other.columnOrdinals) {
 		return false
 	}
+	if os.Expression() != other.Expression() {
+		return false
+	}
 	for i, c := range os.columnOrdinals {
 		if c != other.columnOrdinals[i] {
 			return false
@@ -1878,6 +1905,11 @@ func (os *optTableStat) IsAuto() bool {
 	return os.stat.IsAuto()
 }
 
+// Expression is part of the cat.TableStatistic interface.
+func (os *optTableStat) Expression() string {
+	return os.stat.Expression
+}
+
 // optFamily is a wrapper around descpb.ColumnFamilyDescriptor that keeps a
 // reference to the table wrapper.
 type optFamily struct {
@@ -2160,11 +2160,17 @@ func (ef *execFactory) ConstructExplain(
 		return nil, errors.New("ENV only supported with (OPT) option")
 	}
 
-	plan, err := buildFn(&execFactory{
+	var wrappedFactory exec.Factory = &execFactory{
 		ctx:       ef.ctx,
 		planner:   ef.planner,
 		isExplain: true,
-	})
+	}
+	if len(options.HypotheticalIndexes) > 0 {
+		// The plan may read hypothetical indexes, which can only be explained, so
+		// build it without planNodes.
+		wrappedFactory = exec.StubFactory{}
+	}
+	plan, err := buildFn(wrappedFactory)
 	if err != nil {
 		return nil, err
 	}
@@ -591,6 +591,15 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE PROCEDURE ??`, `CREATE PROCEDURE`},
 		{`ALTER PROCEDURE ??`, `ALTER PROCEDURE`},
 		{`DROP PROCEDURE ??`, `DROP PROCEDURE`},
+
+		{`CREATE CAST ??`, `CREATE CAST`},
+		{`CREATE CAST (a AS b) ??`, `CREATE CAST`},
+		{`DROP CAST ??`, `DROP CAST`},
+
+		{`CREATE TEXT SEARCH ??`, `CREATE TEXT SEARCH`},
+		{`CREATE TEXT SEARCH DICTIONARY d ??`, `CREATE TEXT SEARCH`},
+		{`ALTER TEXT SEARCH CONFIGURATION ??`, `ALTER TEXT SEARCH CONFIGURATION`},
+		{`DROP TEXT SEARCH ??`, `DROP TEXT SEARCH`},
 	}
 
 	// The following checks that the test definition above exercises all
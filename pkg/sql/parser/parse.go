@@ -94,6 +94,8 @@ func (p *Parser) scanOneStmt() (sql string, tokens []sqlSymType, done bool) {
 	tokens = p.tokBuf[:0]
 	tokens = append(tokens, sqlSymType{})
 	lval := &p.tokBuf[0]
+	// Only keep the comments of the statement being scanned.
+	p.scanner.Comments = nil
 
 	// Scan the first token.
 	for {
@@ -39,6 +39,8 @@ func TestScanner(t *testing.T) {
 		{`<=`, []int{LESS_EQUALS}},
 		{`<<`, []int{LSHIFT}},
 		{`<<=`, []int{INET_CONTAINED_BY_OR_EQUALS}},
+		{`<%`, []int{WORD_SIMILAR}},
+		{`<<%`, []int{STRICT_WORD_SIMILAR}},
 		{`>`, []int{'>'}},
 		{`>=`, []int{GREATER_EQUALS}},
 		{`>>`, []int{RSHIFT}},
@@ -59,6 +61,8 @@ func TestScanner(t *testing.T) {
 		{`/`, []int{'/'}},
 		{`//`, []int{FLOORDIV}},
 		{`%`, []int{'%'}},
+		{`%>`, []int{WORD_SIMILAR_COMMUTATOR}},
+		{`%>>`, []int{STRICT_WORD_SIMILAR_COMMUTATOR}},
 		{`^`, []int{'^'}},
 		{`$`, []int{'$'}},
 		{`&`, []int{'&'}},
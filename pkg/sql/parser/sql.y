@@ -36,6 +36,7 @@ import (
     "github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
     "github.com/cockroachdb/cockroach/pkg/sql/privilege"
     "github.com/cockroachdb/cockroach/pkg/sql/scanner"
+    "github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
     "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
     "github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
     "github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
@@ -645,6 +646,9 @@ func (u *sqlSymUnion) indexInvisibility() tree.IndexInvisibility {
 func (u *sqlSymUnion) dropBehavior() tree.DropBehavior {
     return u.val.(tree.DropBehavior)
 }
+func (u *sqlSymUnion) castContext() cast.Context {
+    return u.val.(cast.Context)
+}
 func (u *sqlSymUnion) validationBehavior() tree.ValidationBehavior {
     return u.val.(tree.ValidationBehavior)
 }
@@ -853,6 +857,9 @@ func (u *sqlSymUnion) routineParamClass() tree.RoutineParamClass {
 func (u *sqlSymUnion) stmts() tree.Statements {
     return u.val.(tree.Statements)
 }
+func (u *sqlSymUnion) explainOptionList() *tree.ExplainOptionList {
+    return u.val.(*tree.ExplainOptionList)
+}
 func (u *sqlSymUnion) routineBody() *tree.RoutineBody {
     return u.val.(*tree.RoutineBody)
 }
@@ -901,6 +908,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> TYPECAST TYPEANNOTATE DOT_DOT
 %token <str> LESS_EQUALS GREATER_EQUALS NOT_EQUALS
 %token <str> NOT_REGMATCH REGIMATCH NOT_REGIMATCH
+%token <str> WORD_SIMILAR WORD_SIMILAR_COMMUTATOR STRICT_WORD_SIMILAR STRICT_WORD_SIMILAR_COMMUTATOR
 %token <str> ERROR
 
 // If you want to make any keyword changes, add the new keyword here as well as
@@ -910,13 +918,13 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 // Ordinary key words in alphabetical order.
 %token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
 %token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT
-%token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
+%token <str> ASENSITIVE ASSIGNMENT ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
 
 %token <str> BACKUP BACKUPS BACKWARD BATCH BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
 %token <str> BOOLEAN BOTH BOX2D BUNDLE BY
 
-%token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
+%token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHAIN CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLOSE
 %token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
@@ -927,7 +935,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> CURRENT_USER CURSOR CYCLE
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_IDS DEBUG_PAUSE_ON DEC DEBUG_DUMP_METADATA_SST DECIMAL DEFAULT DEFAULTS DEFINER
-%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
+%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS DICTIONARY
 %token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
 %token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
@@ -946,10 +954,10 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> GEOMETRYCOLLECTION GEOMETRYCOLLECTIONM GEOMETRYCOLLECTIONZ GEOMETRYCOLLECTIONZM
 %token <str> GLOBAL GOAL GRANT GRANTEE GRANTS GREATEST GROUP GROUPING GROUPS
 
-%token <str> HAVING HASH HEADER HIGH HISTOGRAM HOLD HOUR
+%token <str> HAVING HASH HEADER HIGH HISTOGRAM HOLD HOUR HYPOTHETICAL
 
 %token <str> IDENTITY
-%token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMEDIATELY IMMUTABLE IMPORT IN INCLUDE
+%token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMEDIATELY IMMUTABLE IMPLICIT IMPORT IN INCLUDE
 %token <str> INCLUDING INCLUDE_ALL_SECONDARY_TENANTS INCLUDE_ALL_VIRTUAL_CLUSTERS INCREMENT INCREMENTAL INCREMENTAL_LOCATION
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
 %token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
@@ -966,7 +974,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
 %token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
+%token <str> MAPPING MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
@@ -981,7 +989,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD_KMS ON ONLY OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
-%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PER PHYSICAL PLACEMENT PLACING
+%token <str> PARALLEL PARENT PARSER PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PER PHYSICAL PLACEMENT PLACING
 %token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIOR PRIORITY PRIVILEGES
 %token <str> PROCEDURAL PROCEDURE PROCEDURES PUBLIC PUBLICATION
@@ -1207,6 +1215,11 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_func_stmt
 %type <tree.Statement> create_proc_stmt
+%type <tree.Statement> create_cast_stmt
+%type <cast.Context> opt_cast_context
+%type <tree.Statement> create_text_search_stmt
+%type <tree.Statement> alter_text_search_stmt
+%type <[]*tree.UnresolvedObjectName> text_search_name_list
 
 %type <*tree.LikeTenantSpec> opt_like_virtual_cluster
 
@@ -1232,6 +1245,8 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> drop_sequence_stmt
 %type <tree.Statement> drop_func_stmt
 %type <tree.Statement> drop_proc_stmt
+%type <tree.Statement> drop_cast_stmt
+%type <tree.Statement> drop_text_search_stmt
 %type <tree.Statement> drop_virtual_cluster_stmt
 %type <bool>           opt_immediate
 
@@ -1346,6 +1361,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> move_cursor_stmt
 %type <tree.CursorStmt> cursor_movement_specifier
 %type <bool> opt_hold opt_binary
+%type <bool> opt_transaction_chain
 %type <tree.CursorSensitivity> opt_sensitivity
 %type <tree.CursorScrollOption> opt_scroll
 %type <int64> opt_forward_backward forward_backward
@@ -1461,7 +1477,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <[]tree.RangePartition> range_partitions
 %type <empty> opt_all_clause
 %type <empty> opt_privileges_clause
-%type <bool> distinct_clause opt_with_data
+%type <bool> distinct_clause opt_with_data opt_create_as_data
 %type <tree.DistinctOn> distinct_on_clause
 %type <tree.NameList> opt_column_list insert_column_list opt_stats_columns query_stats_cols
 // Note that "no index" variants exist to disable custom ORDER BY <index> syntax
@@ -1579,7 +1595,10 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <str> opt_changefeed_family
 
 %type <str> explain_option_name
-%type <[]string> explain_option_list opt_enum_val_list enum_val_list
+%type <*tree.ExplainOptionList> explain_option_list
+%type <tree.Statements> hypothetical_index_stmt_list
+%type <tree.Statement> hypothetical_index_stmt
+%type <[]string> opt_enum_val_list enum_val_list
 %type <[]tree.CompositeTypeElem> composite_type_list opt_composite_type_list
 
 %type <tree.ResolvableTypeReference> typename simple_typename cast_target
@@ -1751,7 +1770,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 // funny behavior of UNBOUNDED on the SQL standard, though.
 %nonassoc  UNBOUNDED         // ideally should have same precedence as IDENT
 %nonassoc  IDENT NULL PARTITION RANGE ROWS GROUPS PRECEDING FOLLOWING CUBE ROLLUP
-%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT  // multi-character ops
+%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT WORD_SIMILAR WORD_SIMILAR_COMMUTATOR STRICT_WORD_SIMILAR STRICT_WORD_SIMILAR_COMMUTATOR  // multi-character ops
 %left      '|'
 %left      '#'
 %left      '&'
@@ -1849,6 +1868,7 @@ alter_ddl_stmt:
 | alter_func_stmt               // EXTEND WITH HELP: ALTER FUNCTION
 | alter_proc_stmt               // EXTEND WITH HELP: ALTER PROCEDURE
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
+| alter_text_search_stmt        // EXTEND WITH HELP: ALTER TEXT SEARCH CONFIGURATION
 
 // %Help: ALTER TABLE - change the definition of a table
 // %Category: DDL
@@ -3245,7 +3265,7 @@ alter_attribute_action:
 // %Help: REFRESH - recalculate a materialized view
 // %Category: Misc
 // %Text:
-// REFRESH MATERIALIZED VIEW [CONCURRENTLY] view_name [WITH [NO] DATA]
+// REFRESH MATERIALIZED VIEW [CONCURRENTLY] view_name [WITH [NO] DATA | INCREMENTAL]
 refresh_stmt:
   REFRESH MATERIALIZED VIEW opt_concurrently view_name opt_clear_data
   {
@@ -3255,6 +3275,14 @@ refresh_stmt:
       RefreshDataOption: $6.refreshDataOption(),
     }
   }
+| REFRESH MATERIALIZED VIEW opt_concurrently view_name INCREMENTAL
+  {
+    $$.val = &tree.RefreshMaterializedView{
+      Name: $5.unresolvedObjectName(),
+      Concurrently: $4.bool(),
+      Incremental: true,
+    }
+  }
 | REFRESH error // SHOW HELP: REFRESH
 
 opt_clear_data:
@@ -4265,13 +4293,13 @@ copy_options:
   {
     $$.val = &tree.CopyOptions{Null: $2.expr()}
   }
-| OIDS error
+| OIDS
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "oids")
+    $$.val = &tree.CopyOptions{Oids: true, HasOids: true}
   }
-| FREEZE error
+| FREEZE
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "freeze")
+    $$.val = &tree.CopyOptions{Freeze: true, HasFreeze: true}
   }
 | HEADER
   {
@@ -4285,17 +4313,21 @@ copy_options:
   {
     $$.val = &tree.CopyOptions{Escape: tree.NewStrVal($2)}
   }
-| FORCE QUOTE error
+| FORCE QUOTE name_list
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_quote")
+    $$.val = &tree.CopyOptions{ForceQuote: $3.nameList()}
   }
-| FORCE NOT NULL error
+| FORCE QUOTE '*'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_not_null")
+    $$.val = &tree.CopyOptions{ForceQuoteAll: true}
   }
-| FORCE NULL error
+| FORCE NOT NULL name_list
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_null")
+    $$.val = &tree.CopyOptions{ForceNotNull: $4.nameList()}
+  }
+| FORCE NULL name_list
+  {
+    $$.val = &tree.CopyOptions{ForceNull: $3.nameList()}
   }
 | ENCODING SCONST
   {
@@ -4342,13 +4374,29 @@ copy_generic_options:
   {
     $$.val = &tree.CopyOptions{Null: $2.expr()}
   }
-| OIDS error
+| OIDS
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "oids")
+    $$.val = &tree.CopyOptions{Oids: true, HasOids: true}
   }
-| FREEZE error
+| OIDS TRUE
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "freeze")
+    $$.val = &tree.CopyOptions{Oids: true, HasOids: true}
+  }
+| OIDS FALSE
+  {
+    $$.val = &tree.CopyOptions{Oids: false, HasOids: true}
+  }
+| FREEZE
+  {
+    $$.val = &tree.CopyOptions{Freeze: true, HasFreeze: true}
+  }
+| FREEZE TRUE
+  {
+    $$.val = &tree.CopyOptions{Freeze: true, HasFreeze: true}
+  }
+| FREEZE FALSE
+  {
+    $$.val = &tree.CopyOptions{Freeze: false, HasFreeze: true}
   }
 | HEADER
   {
@@ -4362,6 +4410,10 @@ copy_generic_options:
   {
     $$.val = &tree.CopyOptions{Header: false, HasHeader: true}
   }
+| HEADER MATCH
+  {
+    $$.val = &tree.CopyOptions{Header: true, HeaderMatch: true, HasHeader: true}
+  }
 | QUOTE SCONST
   {
     $$.val = &tree.CopyOptions{Quote: tree.NewStrVal($2)}
@@ -4370,17 +4422,21 @@ copy_generic_options:
   {
     $$.val = &tree.CopyOptions{Escape: tree.NewStrVal($2)}
   }
-| FORCE_QUOTE error
+| FORCE_QUOTE '(' name_list ')'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_quote")
+    $$.val = &tree.CopyOptions{ForceQuote: $3.nameList()}
   }
-| FORCE_NOT_NULL error
+| FORCE_QUOTE '*'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_not_null")
+    $$.val = &tree.CopyOptions{ForceQuoteAll: true}
   }
-| FORCE_NULL error
+| FORCE_NOT_NULL '(' name_list ')'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_null")
+    $$.val = &tree.CopyOptions{ForceNotNull: $3.nameList()}
+  }
+| FORCE_NULL '(' name_list ')'
+  {
+    $$.val = &tree.CopyOptions{ForceNull: $3.nameList()}
   }
 | ENCODING SCONST
   {
@@ -4942,19 +4998,19 @@ common_routine_opt_item:
   }
 | EXTERNAL SECURITY DEFINER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineDefiner
   }
 | EXTERNAL SECURITY INVOKER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineInvoker
   }
 | SECURITY DEFINER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineDefiner
   }
 | SECURITY INVOKER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineInvoker
   }
 | LEAKPROOF
   {
@@ -4977,10 +5033,22 @@ common_routine_opt_item:
     return unimplemented(sqllex, "create function/procedure ... support")
   }
 
-// In theory we should parse the a whole set/reset statement here. But it's fine
-// to just return fast on SET/RESET keyword for now since it's not supported
-// yet.
-| SET { return unimplemented(sqllex, "create function/procedure ... set") }
+| SET generic_set
+  {
+    $$.val = &tree.RoutineSetVar{SetVar: *$2.setVar()}
+  }
+| SET var_name FROM CURRENT
+  {
+    return unimplemented(sqllex, "create function/procedure ... set from current")
+  }
+| RESET session_var
+  {
+    $$.val = &tree.RoutineSetVar{SetVar: tree.SetVar{Name: $2, Values: tree.Exprs{tree.DefaultVal{}}, Reset: true}}
+  }
+| RESET_ALL ALL
+  {
+    $$.val = &tree.RoutineSetVar{SetVar: tree.SetVar{ResetAll: true, Reset: true}}
+  }
 | PARALLEL { return unimplemented(sqllex, "create function/procedure ... parallel") }
 
 routine_as:
@@ -5219,6 +5287,253 @@ alter_proc_owner_stmt:
     }
   }
 
+// %Help: CREATE CAST - define a new cast
+// %Category: DDL
+// %Text:
+// CREATE CAST (<source_type> AS <target_type>)
+//    WITH FUNCTION <name> [ ( <argtype> [, ...] ) ]
+//    [ AS ASSIGNMENT | AS IMPLICIT ]
+//
+// CREATE CAST (<source_type> AS <target_type>)
+//    WITH INOUT
+//    [ AS ASSIGNMENT | AS IMPLICIT ]
+//
+// CREATE CAST (<source_type> AS <target_type>)
+//    WITHOUT FUNCTION
+//    [ AS ASSIGNMENT | AS IMPLICIT ]
+// %SeeAlso: DROP CAST
+create_cast_stmt:
+  CREATE CAST '(' typename AS typename ')' WITH FUNCTION function_with_paramtypes opt_cast_context
+  {
+    $$.val = &tree.CreateCast{
+      SourceType: $4.typeReference(),
+      TargetType: $6.typeReference(),
+      Method: tree.CastMethodFunction,
+      Function: $10.functionObj(),
+      Context: $11.castContext(),
+    }
+  }
+| CREATE CAST '(' typename AS typename ')' WITH INOUT opt_cast_context
+  {
+    $$.val = &tree.CreateCast{
+      SourceType: $4.typeReference(),
+      TargetType: $6.typeReference(),
+      Method: tree.CastMethodInOut,
+      Context: $10.castContext(),
+    }
+  }
+| CREATE CAST '(' typename AS typename ')' WITHOUT FUNCTION opt_cast_context
+  {
+    $$.val = &tree.CreateCast{
+      SourceType: $4.typeReference(),
+      TargetType: $6.typeReference(),
+      Method: tree.CastMethodBinary,
+      Context: $10.castContext(),
+    }
+  }
+| CREATE CAST error // SHOW HELP: CREATE CAST
+
+opt_cast_context:
+  AS ASSIGNMENT
+  {
+    $$.val = cast.ContextAssignment
+  }
+| AS IMPLICIT
+  {
+    $$.val = cast.ContextImplicit
+  }
+| /* EMPTY */
+  {
+    $$.val = cast.ContextExplicit
+  }
+
+// %Help: DROP CAST - remove a cast
+// %Category: DDL
+// %Text: DROP CAST [IF EXISTS] (<source_type> AS <target_type>) [CASCADE | RESTRICT]
+// %SeeAlso: CREATE CAST
+drop_cast_stmt:
+  DROP CAST '(' typename AS typename ')' opt_drop_behavior
+  {
+    $$.val = &tree.DropCast{
+      SourceType: $4.typeReference(),
+      TargetType: $6.typeReference(),
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP CAST IF EXISTS '(' typename AS typename ')' opt_drop_behavior
+  {
+    $$.val = &tree.DropCast{
+      SourceType: $6.typeReference(),
+      TargetType: $8.typeReference(),
+      IfExists: true,
+      DropBehavior: $10.dropBehavior(),
+    }
+  }
+| DROP CAST error // SHOW HELP: DROP CAST
+
+// %Help: CREATE TEXT SEARCH - define a new text search configuration or dictionary
+// %Category: DDL
+// %Text:
+// CREATE TEXT SEARCH CONFIGURATION <name>
+//    ( PARSER = default | COPY = <source_config> )
+//
+// CREATE TEXT SEARCH DICTIONARY <name>
+//    ( TEMPLATE = { simple | synonym | snowball } [, <option> = <value> [, ...] ] )
+// %SeeAlso: ALTER TEXT SEARCH CONFIGURATION, DROP TEXT SEARCH
+create_text_search_stmt:
+  CREATE TEXT SEARCH CONFIGURATION db_object_name '(' PARSER '=' DEFAULT ')'
+  {
+    $$.val = &tree.CreateTextSearchConfiguration{Name: $5.unresolvedObjectName()}
+  }
+| CREATE TEXT SEARCH CONFIGURATION db_object_name '(' PARSER '=' name '.' DEFAULT ')'
+  {
+    if $9 != "pg_catalog" {
+      return setErr(sqllex, pgerror.Newf(pgcode.UndefinedObject,
+        "text search parser \"%s.default\" does not exist", $9))
+    }
+    $$.val = &tree.CreateTextSearchConfiguration{Name: $5.unresolvedObjectName()}
+  }
+| CREATE TEXT SEARCH CONFIGURATION db_object_name '(' COPY '=' db_object_name ')'
+  {
+    $$.val = &tree.CreateTextSearchConfiguration{
+      Name: $5.unresolvedObjectName(),
+      Copy: $9.unresolvedObjectName(),
+    }
+  }
+| CREATE TEXT SEARCH DICTIONARY db_object_name '(' storage_parameter_list ')'
+  {
+    $$.val = &tree.CreateTextSearchDictionary{
+      Name: $5.unresolvedObjectName(),
+      Options: $7.storageParams(),
+    }
+  }
+| CREATE TEXT SEARCH error // SHOW HELP: CREATE TEXT SEARCH
+
+// %Help: ALTER TEXT SEARCH CONFIGURATION - change the mappings of a text search configuration
+// %Category: DDL
+// %Text:
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//    ADD MAPPING FOR <token_type> [, ...] WITH <dictionary> [, ...]
+//
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//    ALTER MAPPING FOR <token_type> [, ...] WITH <dictionary> [, ...]
+//
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//    ALTER MAPPING [ FOR <token_type> [, ...] ] REPLACE <old_dictionary> WITH <new_dictionary>
+//
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//    DROP MAPPING [ IF EXISTS ] FOR <token_type> [, ...]
+// %SeeAlso: CREATE TEXT SEARCH, DROP TEXT SEARCH
+alter_text_search_stmt:
+  ALTER TEXT SEARCH CONFIGURATION db_object_name ADD MAPPING FOR name_list WITH text_search_name_list
+  {
+    $$.val = &tree.AlterTextSearchConfiguration{
+      Name: $5.unresolvedObjectName(),
+      Action: tree.AlterTextSearchAddMapping,
+      TokenTypes: $9.nameList(),
+      Dictionaries: $11.unresolvedObjectNames(),
+    }
+  }
+| ALTER TEXT SEARCH CONFIGURATION db_object_name ALTER MAPPING FOR name_list WITH text_search_name_list
+  {
+    $$.val = &tree.AlterTextSearchConfiguration{
+      Name: $5.unresolvedObjectName(),
+      Action: tree.AlterTextSearchAlterMapping,
+      TokenTypes: $9.nameList(),
+      Dictionaries: $11.unresolvedObjectNames(),
+    }
+  }
+| ALTER TEXT SEARCH CONFIGURATION db_object_name ALTER MAPPING REPLACE db_object_name WITH db_object_name
+  {
+    $$.val = &tree.AlterTextSearchConfiguration{
+      Name: $5.unresolvedObjectName(),
+      Action: tree.AlterTextSearchReplaceDictionary,
+      OldDictionary: $9.unresolvedObjectName(),
+      NewDictionary: $11.unresolvedObjectName(),
+    }
+  }
+| ALTER TEXT SEARCH CONFIGURATION db_object_name ALTER MAPPING FOR name_list REPLACE db_object_name WITH db_object_name
+  {
+    $$.val = &tree.AlterTextSearchConfiguration{
+      Name: $5.unresolvedObjectName(),
+      Action: tree.AlterTextSearchReplaceDictionary,
+      TokenTypes: $9.nameList(),
+      OldDictionary: $11.unresolvedObjectName(),
+      NewDictionary: $13.unresolvedObjectName(),
+    }
+  }
+| ALTER TEXT SEARCH CONFIGURATION db_object_name DROP MAPPING FOR name_list
+  {
+    $$.val = &tree.AlterTextSearchConfiguration{
+      Name: $5.unresolvedObjectName(),
+      Action: tree.AlterTextSearchDropMapping,
+      TokenTypes: $9.nameList(),
+    }
+  }
+| ALTER TEXT SEARCH CONFIGURATION db_object_name DROP MAPPING IF EXISTS FOR name_list
+  {
+    $$.val = &tree.AlterTextSearchConfiguration{
+      Name: $5.unresolvedObjectName(),
+      Action: tree.AlterTextSearchDropMapping,
+      TokenTypes: $11.nameList(),
+      IfExists: true,
+    }
+  }
+| ALTER TEXT SEARCH CONFIGURATION error // SHOW HELP: ALTER TEXT SEARCH CONFIGURATION
+
+// %Help: DROP TEXT SEARCH - remove a text search configuration or dictionary
+// %Category: DDL
+// %Text:
+// DROP TEXT SEARCH CONFIGURATION [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// DROP TEXT SEARCH DICTIONARY [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE TEXT SEARCH
+drop_text_search_stmt:
+  DROP TEXT SEARCH CONFIGURATION text_search_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTextSearchObject{
+      Kind: tree.TextSearchConfiguration,
+      Names: $5.unresolvedObjectNames(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TEXT SEARCH CONFIGURATION IF EXISTS text_search_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTextSearchObject{
+      Kind: tree.TextSearchConfiguration,
+      Names: $7.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TEXT SEARCH DICTIONARY text_search_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTextSearchObject{
+      Kind: tree.TextSearchDictionary,
+      Names: $5.unresolvedObjectNames(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TEXT SEARCH DICTIONARY IF EXISTS text_search_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTextSearchObject{
+      Kind: tree.TextSearchDictionary,
+      Names: $7.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TEXT SEARCH error // SHOW HELP: DROP TEXT SEARCH
+
+text_search_name_list:
+  db_object_name
+  {
+    $$.val = []*tree.UnresolvedObjectName{$1.unresolvedObjectName()}
+  }
+| text_search_name_list ',' db_object_name
+  {
+    $$.val = append($1.unresolvedObjectNames(), $3.unresolvedObjectName())
+  }
+
 opt_no:
   NO
   {
@@ -5232,7 +5547,6 @@ opt_no:
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
 | CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
-| CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
 | CREATE DEFAULT CONVERSION error { return unimplemented(sqllex, "create def conv") }
@@ -5259,7 +5573,6 @@ opt_procedural:
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
 | DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
-| DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
 | DROP DOMAIN error { return unimplementedWithIssueDetail(sqllex, 27796, "drop") }
@@ -5289,12 +5602,14 @@ create_ddl_stmt:
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
 | create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_cast_stmt     // EXTEND WITH HELP: CREATE CAST
+| create_text_search_stmt // EXTEND WITH HELP: CREATE TEXT SEARCH
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
 // %Text:
 // CREATE STATISTICS <statisticname>
-//   [ON <colname> [, ...]]
+//   [ON <colname> [, ...] | ON (<expr>)]
 //   FROM <tablename> [AS OF SYSTEM TIME <expr>]
 create_stats_stmt:
   CREATE STATISTICS statistics_name opt_stats_columns FROM create_stats_target opt_create_stats_options
@@ -5306,6 +5621,15 @@ create_stats_stmt:
       Options: *$7.createStatsOptions(),
     }
   }
+| CREATE STATISTICS statistics_name ON '(' a_expr ')' FROM create_stats_target opt_create_stats_options
+  {
+    $$.val = &tree.CreateStats{
+      Name: tree.Name($3),
+      Expr: $6.expr(),
+      Table: $9.tblExpr(),
+      Options: *$10.createStatsOptions(),
+    }
+  }
 | CREATE STATISTICS error // SHOW HELP: CREATE STATISTICS
 
 opt_stats_columns:
@@ -5630,13 +5954,16 @@ opt_using_clause:
 
 // %Help: DISCARD - reset the session to its initial state
 // %Category: Cfg
-// %Text: DISCARD ALL
+// %Text: DISCARD { ALL | PLANS | SEQUENCES | TEMP | TEMPORARY }
 discard_stmt:
   DISCARD ALL
   {
     $$.val = &tree.Discard{Mode: tree.DiscardModeAll}
   }
-| DISCARD PLANS { return unimplemented(sqllex, "discard plans") }
+| DISCARD PLANS
+  {
+    $$.val = &tree.Discard{Mode: tree.DiscardModePlans}
+  }
 | DISCARD SEQUENCES
   {
     $$.val = &tree.Discard{Mode: tree.DiscardModeSequences}
@@ -5675,6 +6002,8 @@ drop_ddl_stmt:
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
 | drop_proc_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_cast_stmt     // EXTEND WITH HELP: DROP CAST
+| drop_text_search_stmt // EXTEND WITH HELP: DROP TEXT SEARCH
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -5949,6 +6278,7 @@ analyze_target:
 // EXPLAIN (DISTSQL) <statement>
 // EXPLAIN ANALYZE [(DISTSQL)] <statement>
 // EXPLAIN ANALYZE (PLAN <planoptions...>) <statement>
+// EXPLAIN ([PLAN | OPT ,] HYPOTHETICAL INDEXES (<create_or_drop_index>; ...)) <select>
 //
 // Explainable statements:
 //     SELECT, CREATE, DROP, ALTER, INSERT, UPSERT, UPDATE, DELETE,
@@ -5962,7 +6292,7 @@ explain_stmt:
   EXPLAIN explainable_stmt
   {
     var err error
-    $$.val, err = tree.MakeExplain(nil /* options */, $2.stmt())
+    $$.val, err = tree.MakeExplain(tree.ExplainOptionList{}, $2.stmt())
     if err != nil {
       return setErr(sqllex, err)
     }
@@ -5971,7 +6301,7 @@ explain_stmt:
 | EXPLAIN '(' explain_option_list ')' explainable_stmt
   {
     var err error
-    $$.val, err = tree.MakeExplain($3.strs(), $5.stmt())
+    $$.val, err = tree.MakeExplain(*$3.explainOptionList(), $5.stmt())
     if err != nil {
       return setErr(sqllex, err)
     }
@@ -5979,7 +6309,7 @@ explain_stmt:
 | EXPLAIN ANALYZE explainable_stmt
   {
     var err error
-    $$.val, err = tree.MakeExplain([]string{"ANALYZE"}, $3.stmt())
+    $$.val, err = tree.MakeExplain(tree.ExplainOptionList{Names: []string{"ANALYZE"}}, $3.stmt())
     if err != nil {
       return setErr(sqllex, err)
     }
@@ -5987,7 +6317,7 @@ explain_stmt:
 | EXPLAIN ANALYSE explainable_stmt
   {
     var err error
-    $$.val, err = tree.MakeExplain([]string{"ANALYZE"}, $3.stmt())
+    $$.val, err = tree.MakeExplain(tree.ExplainOptionList{Names: []string{"ANALYZE"}}, $3.stmt())
     if err != nil {
       return setErr(sqllex, err)
     }
@@ -5995,7 +6325,9 @@ explain_stmt:
 | EXPLAIN ANALYZE '(' explain_option_list ')' explainable_stmt
   {
     var err error
-    $$.val, err = tree.MakeExplain(append($4.strs(), "ANALYZE"), $6.stmt())
+    options := $4.explainOptionList()
+    options.Names = append(options.Names, "ANALYZE")
+    $$.val, err = tree.MakeExplain(*options, $6.stmt())
     if err != nil {
       return setErr(sqllex, err)
     }
@@ -6003,7 +6335,9 @@ explain_stmt:
 | EXPLAIN ANALYSE '(' explain_option_list ')' explainable_stmt
   {
     var err error
-    $$.val, err = tree.MakeExplain(append($4.strs(), "ANALYZE"), $6.stmt())
+    options := $4.explainOptionList()
+    options.Names = append(options.Names, "ANALYZE")
+    $$.val, err = tree.MakeExplain(*options, $6.stmt())
     if err != nil {
       return setErr(sqllex, err)
     }
@@ -6073,13 +6407,39 @@ copy_to_stmt:
 explain_option_list:
   explain_option_name
   {
-    $$.val = []string{$1}
+    $$.val = &tree.ExplainOptionList{Names: []string{$1}}
+  }
+| HYPOTHETICAL INDEXES '(' hypothetical_index_stmt_list ')'
+  {
+    $$.val = &tree.ExplainOptionList{HypotheticalIndexes: $4.stmts()}
   }
 | explain_option_list ',' explain_option_name
   {
-    $$.val = append($1.strs(), $3)
+    options := $1.explainOptionList()
+    options.Names = append(options.Names, $3)
+    $$.val = options
+  }
+| explain_option_list ',' HYPOTHETICAL INDEXES '(' hypothetical_index_stmt_list ')'
+  {
+    options := $1.explainOptionList()
+    options.HypotheticalIndexes = append(options.HypotheticalIndexes, $6.stmts()...)
+    $$.val = options
   }
 
+hypothetical_index_stmt_list:
+  hypothetical_index_stmt
+  {
+    $$.val = tree.Statements{$1.stmt()}
+  }
+| hypothetical_index_stmt_list ';' hypothetical_index_stmt
+  {
+    $$.val = append($1.stmts(), $3.stmt())
+  }
+
+hypothetical_index_stmt:
+  create_index_stmt
+| drop_index_stmt
+
 // %Help: ALTER CHANGEFEED - alter an existing changefeed
 // %Category: CCL
 // %Text:
@@ -9914,6 +10274,7 @@ create_table_as_stmt:
       IfNotExists: false,
       Defs: $5.tblDefs(),
       AsSource: $8.slct(),
+      AsWithNoData: $9.bool(),
       StorageParams: $6.storageParams(),
       OnCommit: $10.createTableOnCommitSetting(),
       Persistence: $2.persistence(),
@@ -9927,6 +10288,7 @@ create_table_as_stmt:
       IfNotExists: true,
       Defs: $8.tblDefs(),
       AsSource: $11.slct(),
+      AsWithNoData: $12.bool(),
       StorageParams: $9.storageParams(),
       OnCommit: $13.createTableOnCommitSetting(),
       Persistence: $2.persistence(),
@@ -9934,9 +10296,20 @@ create_table_as_stmt:
   }
 
 opt_create_as_data:
-  /* EMPTY */  { /* no error */ }
-| WITH DATA    { /* SKIP DOC */ /* This is the default */ }
-| WITH NO DATA { return unimplemented(sqllex, "create table as with no data") }
+  /* EMPTY */
+  {
+    $$.val = false
+  }
+| WITH DATA
+  {
+    /* SKIP DOC */
+    /* This is the default */
+    $$.val = false
+  }
+| WITH NO DATA
+  {
+    $$.val = true
+  }
 
 /*
  * Redundancy here is needed to avoid shift/reduce conflicts,
@@ -10028,14 +10401,14 @@ like_table_option_list:
   }
 
 like_table_option:
-  COMMENTS			{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding comments") }
+  COMMENTS			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptComments} }
 | CONSTRAINTS		{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptConstraints} }
 | DEFAULTS			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptDefaults} }
-| IDENTITY	  	{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding identity") }
+| IDENTITY	  	{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptIdentity} }
 | GENERATED			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptGenerated} }
 | INDEXES			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptIndexes} }
-| STATISTICS		{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding statistics") }
-| STORAGE			{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding storage") }
+| STATISTICS		{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptStatistics} }
+| STORAGE			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptStorage} }
 | ALL				{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptAll} }
 
 
@@ -10682,7 +11055,7 @@ key_match:
   }
 | MATCH PARTIAL
   {
-    return unimplementedWithIssueDetail(sqllex, 20305, "match partial")
+    $$.val = tree.MatchPartial
   }
 | /* EMPTY */
   {
@@ -12132,20 +12505,20 @@ begin_stmt:
 // %Help: COMMIT - commit the current transaction
 // %Category: Txn
 // %Text:
-// COMMIT [TRANSACTION]
-// END [TRANSACTION]
+// COMMIT [TRANSACTION] [AND [NO] CHAIN]
+// END [TRANSACTION] [AND [NO] CHAIN]
 // %SeeAlso: BEGIN, ROLLBACK, WEBDOCS/commit-transaction.html
 commit_stmt:
-  COMMIT opt_transaction
+  COMMIT opt_transaction opt_transaction_chain
   {
-    $$.val = &tree.CommitTransaction{}
+    $$.val = &tree.CommitTransaction{Chain: $3.bool()}
   }
 | COMMIT error // SHOW HELP: COMMIT
 
 abort_stmt:
-  ABORT opt_abort_mod
+  ABORT opt_abort_mod opt_transaction_chain
   {
-    $$.val = &tree.RollbackTransaction{}
+    $$.val = &tree.RollbackTransaction{Chain: $3.bool()}
   }
 
 opt_abort_mod:
@@ -12156,13 +12529,13 @@ opt_abort_mod:
 // %Help: ROLLBACK - abort the current (sub-)transaction
 // %Category: Txn
 // %Text:
-// ROLLBACK [TRANSACTION]
+// ROLLBACK [TRANSACTION] [AND [NO] CHAIN]
 // ROLLBACK [TRANSACTION] TO [SAVEPOINT] <savepoint name>
 // %SeeAlso: BEGIN, COMMIT, SAVEPOINT, WEBDOCS/rollback-transaction.html
 rollback_stmt:
-  ROLLBACK opt_transaction
+  ROLLBACK opt_transaction opt_transaction_chain
   {
-     $$.val = &tree.RollbackTransaction{}
+     $$.val = &tree.RollbackTransaction{Chain: $3.bool()}
   }
 | ROLLBACK opt_transaction TO savepoint_name
   {
@@ -12185,9 +12558,9 @@ legacy_begin_stmt:
 | BEGIN error // SHOW HELP: BEGIN
 
 legacy_end_stmt:
-  END opt_transaction
+  END opt_transaction opt_transaction_chain
   {
-    $$.val = &tree.CommitTransaction{}
+    $$.val = &tree.CommitTransaction{Chain: $3.bool()}
   }
 | END error // SHOW HELP: COMMIT
 
@@ -12196,6 +12569,20 @@ opt_transaction:
   TRANSACTION {}
 | /* EMPTY */ {}
 
+opt_transaction_chain:
+  AND CHAIN
+  {
+    $$.val = true
+  }
+| AND NO CHAIN
+  {
+    $$.val = false
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
 savepoint_name:
   SAVEPOINT name
   {
@@ -14892,6 +15279,22 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr WORD_SIMILAR a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.WordSimilar), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr WORD_SIMILAR_COMMUTATOR a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.WordSimilarCommutator), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr STRICT_WORD_SIMILAR a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.StrictWordSimilar), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr STRICT_WORD_SIMILAR_COMMUTATOR a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.StrictWordSimilarCommutator), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr INET_CONTAINS_OR_EQUALS a_expr
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction("inet_contains_or_equals"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
@@ -15144,8 +15547,11 @@ a_expr:
     $$.val = tree.DefaultVal{}
   }
 // The UNIQUE predicate is a standard SQL feature but not yet implemented
-// in PostgreSQL (as of 10.5).
-| UNIQUE '(' error { return unimplemented(sqllex, "UNIQUE predicate") }
+// in PostgreSQL (as of 16).
+| UNIQUE select_with_parens
+  {
+    $$.val = &tree.Subquery{Select: $2.selectStmt(), Unique: true}
+  }
 
 // Restricted expressions
 //
@@ -16104,6 +16510,10 @@ all_op:
 | NOT_REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegIMatch) }
 | AND_AND { $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps) }
 | AT_AT { $$.val = treecmp.MakeComparisonOperator(treecmp.TSMatches) }
+| WORD_SIMILAR { $$.val = treecmp.MakeComparisonOperator(treecmp.WordSimilar) }
+| WORD_SIMILAR_COMMUTATOR { $$.val = treecmp.MakeComparisonOperator(treecmp.WordSimilarCommutator) }
+| STRICT_WORD_SIMILAR { $$.val = treecmp.MakeComparisonOperator(treecmp.StrictWordSimilar) }
+| STRICT_WORD_SIMILAR_COMMUTATOR { $$.val = treecmp.MakeComparisonOperator(treecmp.StrictWordSimilarCommutator) }
 | '~' { $$.val = tree.MakeUnaryOperator(tree.UnaryComplement) }
 | SQRT { $$.val = tree.MakeUnaryOperator(tree.UnarySqrt) }
 | CBRT { $$.val = tree.MakeUnaryOperator(tree.UnaryCbrt) }
@@ -17050,6 +17460,7 @@ unreserved_keyword:
 | ALTER
 | ALWAYS
 | ASENSITIVE
+| ASSIGNMENT
 | AS_JSON
 | AT
 | ATOMIC
@@ -17074,6 +17485,7 @@ unreserved_keyword:
 | CAPABILITIES
 | CAPABILITY
 | CASCADE
+| CHAIN
 | CHANGEFEED
 | CHECK_FILES
 | CLOSE
@@ -17127,6 +17539,7 @@ unreserved_keyword:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DICTIONARY
 | DISCARD
 | DOMAIN
 | DOUBLE
@@ -17189,10 +17602,12 @@ unreserved_keyword:
 | HISTOGRAM
 | HOLD
 | HOUR
+| HYPOTHETICAL
 | IDENTITY
 | IMMEDIATE
 | IMMEDIATELY
 | IMMUTABLE
+| IMPLICIT
 | IMPORT
 | INCLUDE
 | INCLUDING
@@ -17240,6 +17655,7 @@ unreserved_keyword:
 | LOCALITY
 | LOOKUP
 | LOW
+| MAPPING
 | MATCH
 | MATERIALIZED
 | MAXVALUE
@@ -17309,6 +17725,7 @@ unreserved_keyword:
 | OWNER
 | PARALLEL
 | PARENT
+| PARSER
 | PARTIAL
 | PARTITION
 | PARTITIONS
@@ -17530,6 +17947,7 @@ bare_label_keywords:
 | ANY
 | ASC
 | ASENSITIVE
+| ASSIGNMENT
 | ASYMMETRIC
 | AS_JSON
 | AT
@@ -17564,6 +17982,7 @@ bare_label_keywords:
 | CASCADE
 | CASE
 | CAST
+| CHAIN
 | CHANGEFEED
 | CHARACTERISTICS
 | CHECK
@@ -17636,6 +18055,7 @@ bare_label_keywords:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DICTIONARY
 | DISCARD
 | DISTINCT
 | DO
@@ -17712,6 +18132,7 @@ bare_label_keywords:
 | HIGH
 | HISTOGRAM
 | HOLD
+| HYPOTHETICAL
 | IDENTITY
 | IF
 | IFERROR
@@ -17721,6 +18142,7 @@ bare_label_keywords:
 | IMMEDIATE
 | IMMEDIATELY
 | IMMUTABLE
+| IMPLICIT
 | IMPORT
 | IN
 | INCLUDE
@@ -17789,6 +18211,7 @@ bare_label_keywords:
 | LOGIN
 | LOOKUP
 | LOW
+| MAPPING
 | MATCH
 | MATERIALIZED
 | MAXVALUE
@@ -17865,6 +18288,7 @@ bare_label_keywords:
 | OWNER
 | PARALLEL
 | PARENT
+| PARSER
 | PARTIAL
 | PARTITION
 | PARTITIONS
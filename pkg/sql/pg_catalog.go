@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"hash"
 	"hash/fnv"
+	"sort"
 	"strings"
 	"time"
 	"unicode"
@@ -36,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -59,6 +61,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -575,7 +578,7 @@ var pgCatalogCastTable = virtualSchemaTable{
 	comment: `casts (empty - needs filling out)
 https://www.postgresql.org/docs/9.6/catalog-pg-cast.html`,
 	schema: vtable.PGCatalogCast,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		h := makeOidHasher()
 		cast.ForEachCast(func(src, tgt oid.Oid, cCtx cast.Context, ctxOrigin cast.ContextOrigin, _ volatility.V) {
 			if ctxOrigin == cast.ContextOriginPgCast {
@@ -597,7 +600,29 @@ https://www.postgresql.org/docs/9.6/catalog-pg-cast.html`,
 				)
 			}
 		})
-		return nil
+		// User-defined casts are stored on the descriptors of the types they
+		// involve.
+		return forEachTypeDesc(ctx, p, dbContext, func(
+			ctx context.Context, _ catalog.DatabaseDescriptor, _ catalog.SchemaDescriptor, typ catalog.TypeDescriptor,
+		) error {
+			for _, c := range typ.TypeDesc().Casts {
+				castFunc, castMethod := tree.DNull, "i"
+				if c.Method == descpb.TypeDescriptor_Cast_FUNCTION {
+					castFunc, castMethod = tree.NewDOid(c.FunctionOID), "f"
+				}
+				if err := addRow(
+					h.CastOid(c.SourceTypeOID, c.TargetTypeOID),           // oid
+					tree.NewDOid(c.SourceTypeOID),                         // castsource
+					tree.NewDOid(c.TargetTypeOID),                         // casttarget
+					castFunc,                                              // castfunc
+					tree.NewDString(userDefinedCastContext(c).PGString()), // castcontext
+					tree.NewDString(castMethod),                           // castmethod
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	},
 }
 
@@ -2718,6 +2743,17 @@ func addPgProcUDFRow(
 	if nArgDefaults > 0 {
 		argDefaults = tree.NewDString("(" + argDefaultsBuilder.String() + ")")
 	}
+	securityDefiner := tree.MakeDBool(fnDesc.GetSecurity() == catpb.Function_DEFINER)
+	procConfig := tree.DNull
+	if cfg := fnDesc.GetConfig(); len(cfg) > 0 {
+		configArray := tree.NewDArray(types.String)
+		for _, entry := range cfg {
+			if err := configArray.Append(tree.NewDString(entry)); err != nil {
+				return err
+			}
+		}
+		procConfig = configArray
+	}
 	return addRow(
 		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())), // oid
 		tree.NewDName(fnDesc.GetName()),                 // proname
@@ -2729,7 +2765,7 @@ func addPgProcUDFRow(
 		oidZero,         // provariadic // TODO(88947): this might need an adjustment.
 		tree.DNull,      // prosupport
 		kind,            // prokind
-		tree.DBoolFalse, // prosecdef
+		securityDefiner, // prosecdef
 		tree.MakeDBool(tree.DBool(fnDesc.GetLeakProof())),                                    // proleakproof
 		tree.MakeDBool(fnDesc.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT), // proisstrict
 		tree.MakeDBool(tree.DBool(fnDesc.GetReturnType().ReturnSet)),                         // proretset
@@ -2747,7 +2783,7 @@ func addPgProcUDFRow(
 		tree.NewDString(fnDesc.GetFunctionBody()),       // prosrc
 		tree.DNull,                                      // probin
 		tree.DNull,                                      // prosqlbody
-		tree.DNull,                                      // proconfig
+		procConfig,                                      // proconfig
 		tree.DNull,                                      // proacl
 	)
 }
@@ -4161,13 +4197,46 @@ var pgCatalogStatioSysTablesTable = virtualSchemaTable{
 	unimplemented: true,
 }
 
+// textSearchDefaultParserOID is the OID of the default text search parser in
+// Postgres, which is the only parser.
+const textSearchDefaultParserOID = 3722
+
 var pgCatalogTsConfigTable = virtualSchemaTable{
-	comment: "pg_ts_config was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsConfig,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `text search configurations
+https://www.postgresql.org/docs/16/catalog-pg-ts-config.html`,
+	schema: vtable.PgCatalogTsConfig,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		nspOid := tree.NewDOid(catconstants.PgCatalogID)
+		for _, name := range tsearch.BuiltinConfigs() {
+			if err := addRow(
+				h.TextSearchConfigOid(catconstants.PgCatalogID, name), // oid
+				tree.NewDName(name),                      // cfgname
+				nspOid,                                   // cfgnamespace
+				tree.DNull,                               // cfgowner
+				tree.NewDOid(textSearchDefaultParserOID), // cfgparser
+			); err != nil {
+				return err
+			}
+		}
+		return forEachSchema(ctx, p, dbContext, true /* requiresPrivileges */, func(
+			ctx context.Context, sc catalog.SchemaDescriptor,
+		) error {
+			configs := sc.SchemaDesc().TextSearchConfigurations
+			for _, name := range sortedTextSearchNames(configs) {
+				if err := addRow(
+					h.TextSearchConfigOid(sc.GetID(), name),      // oid
+					tree.NewDName(name),                          // cfgname
+					schemaOid(sc.GetID()),                        // cfgnamespace
+					h.UserOid(configs[name].OwnerProto.Decode()), // cfgowner
+					tree.NewDOid(textSearchDefaultParserOID),     // cfgparser
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatsTable = virtualSchemaTable{
@@ -4349,12 +4418,51 @@ var pgCatalogStatAllIndexesTable = virtualSchemaTable{
 }
 
 var pgCatalogTsConfigMapTable = virtualSchemaTable{
-	comment: "pg_ts_config_map was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsConfigMap,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `mappings of text search configurations
+https://www.postgresql.org/docs/16/catalog-pg-ts-config-map.html`,
+	schema: vtable.PgCatalogTsConfigMap,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		for _, name := range tsearch.BuiltinConfigs() {
+			cfgOid := h.TextSearchConfigOid(catconstants.PgCatalogID, name)
+			dictOid := h.TextSearchDictOid(catconstants.PgCatalogID, tsearch.BuiltinConfigDictionary(name))
+			for _, t := range tsearch.TokenTypes {
+				if err := addRow(
+					cfgOid,                     // mapcfg
+					tree.NewDInt(tree.DInt(t)), // maptokentype
+					tree.NewDInt(1),            // mapseqno
+					dictOid,                    // mapdict
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return forEachSchema(ctx, p, dbContext, true /* requiresPrivileges */, func(
+			ctx context.Context, sc catalog.SchemaDescriptor,
+		) error {
+			configs := sc.SchemaDesc().TextSearchConfigurations
+			for _, name := range sortedTextSearchNames(configs) {
+				cfgOid := h.TextSearchConfigOid(sc.GetID(), name)
+				for _, m := range configs[name].Mappings {
+					t, err := tsearch.TokenTypeFromString(m.TokenType)
+					if err != nil {
+						return err
+					}
+					for i, ref := range m.Dictionaries {
+						if err := addRow(
+							cfgOid,                       // mapcfg
+							tree.NewDInt(tree.DInt(t)),   // maptokentype
+							tree.NewDInt(tree.DInt(i+1)), // mapseqno
+							h.TextSearchDictOid(ref.SchemaID, ref.Name), // mapdict
+						); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			return nil
+		})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatBgwriterTable = virtualSchemaTable{
@@ -4535,12 +4643,72 @@ func addRowForTimezoneNames(tz string, t time.Time, addRow func(...tree.Datum) e
 }
 
 var pgCatalogTsDictTable = virtualSchemaTable{
-	comment: "pg_ts_dict was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsDict,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `text search dictionaries
+https://www.postgresql.org/docs/16/catalog-pg-ts-dict.html`,
+	schema: vtable.PgCatalogTsDict,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		nspOid := tree.NewDOid(catconstants.PgCatalogID)
+		for _, name := range tsearch.BuiltinDictionaries() {
+			template, initOption := tsearch.SimpleTemplate, tree.DNull
+			if language, ok := strings.CutSuffix(name, "_stem"); ok {
+				template = tsearch.SnowballTemplate
+				initOption = tree.NewDString(fmt.Sprintf(
+					"language = '%s', stopwords = '%s'", language, language))
+			}
+			if err := addRow(
+				h.TextSearchDictOid(catconstants.PgCatalogID, name), // oid
+				tree.NewDName(name),               // dictname
+				nspOid,                            // dictnamespace
+				tree.DNull,                        // dictowner
+				h.TextSearchTemplateOid(template), // dicttemplate
+				initOption,                        // dictinitoption
+			); err != nil {
+				return err
+			}
+		}
+		return forEachSchema(ctx, p, dbContext, true /* requiresPrivileges */, func(
+			ctx context.Context, sc catalog.SchemaDescriptor,
+		) error {
+			dicts := sc.SchemaDesc().TextSearchDictionaries
+			for _, name := range sortedTextSearchNames(dicts) {
+				d := dicts[name]
+				initOption := tree.DNull
+				if len(d.Options) > 0 {
+					var buf strings.Builder
+					for i, opt := range d.Options {
+						if i > 0 {
+							buf.WriteString(", ")
+						}
+						fmt.Fprintf(&buf, "%s = %s", opt.Name, lexbase.EscapeSQLString(opt.Value))
+					}
+					initOption = tree.NewDString(buf.String())
+				}
+				if err := addRow(
+					h.TextSearchDictOid(sc.GetID(), name), // oid
+					tree.NewDName(name),                   // dictname
+					schemaOid(sc.GetID()),                 // dictnamespace
+					h.UserOid(d.OwnerProto.Decode()),      // dictowner
+					h.TextSearchTemplateOid(d.Template),   // dicttemplate
+					initOption,                            // dictinitoption
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	},
-	unimplemented: true,
+}
+
+// sortedTextSearchNames returns the names of the text search objects of a
+// schema in order.
+func sortedTextSearchNames[T any](objects map[string]T) []string {
+	names := make([]string, 0, len(objects))
+	for name := range objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 var pgCatalogStatUserTablesTable = virtualSchemaTable{
@@ -4994,6 +5162,9 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	textSearchConfigTypeTag
+	textSearchDictTypeTag
+	textSearchTemplateTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -5193,6 +5364,26 @@ func (h oidHasher) CastOid(srcID oid.Oid, tgtID oid.Oid) *tree.DOid {
 	return h.getOid()
 }
 
+func (h oidHasher) TextSearchConfigOid(scID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(textSearchConfigTypeTag)
+	h.writeSchema(scID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+func (h oidHasher) TextSearchDictOid(scID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(textSearchDictTypeTag)
+	h.writeSchema(scID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+func (h oidHasher) TextSearchTemplateOid(name string) *tree.DOid {
+	h.writeTypeTag(textSearchTemplateTypeTag)
+	h.writeStr(name)
+	return h.getOid()
+}
+
 func funcVolatility(v catpb.Function_Volatility) string {
 	switch v {
 	case catpb.Function_IMMUTABLE:
@@ -296,6 +296,33 @@ func TestEncodings(t *testing.T) {
 			}
 		}
 	})
+	t.Run("decode-columnar", func(t *testing.T) {
+		for _, tc := range tests {
+			switch tc.Datum.(type) {
+			case *tree.DTuple, *tree.DCollatedString:
+				// Unsupported, see above.
+				continue
+			}
+			// Decode the binary encoding straight into a vector and verify that
+			// encoding it back produces the same bytes.
+			batch := coldata.NewMemBatchWithCapacity([]*types.T{tc.T}, 1 /* capacity */, coldataext.NewExtendedColumnFactory(&evalCtx))
+			vh := coldataext.MakeVecHandler(batch.ColVec(0))
+			if err := pgwirebase.DecodeBinaryDatumHandler(ctx, &evalCtx, tc.T, tc.Binary, vh); err != nil {
+				t.Fatal(err)
+			}
+			batch.SetLength(1)
+			var vecs coldata.TypedVecs
+			vecs.SetBatch(batch)
+			buf.reset()
+			buf.writeBinaryColumnarElement(ctx, &vecs, 0 /* vecIdx */, 0 /* rowIdx */, loc)
+			if buf.err != nil {
+				t.Fatal(buf.err)
+			}
+			if got := verifyLen(t); !bytes.Equal(got, tc.Binary) {
+				t.Errorf("%s: unexpected binary encoding:\n\t%v found,\n\t%v expected", tc.SQL, got, tc.Binary)
+			}
+		}
+	})
 }
 
 // TestExoticNumericEncodings goes through specific, legal pgwire encodings
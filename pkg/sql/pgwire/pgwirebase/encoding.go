@@ -23,6 +23,7 @@ import (
 	"unicode/utf8"
 	"unsafe"
 
+	"github.com/cockroachdb/cockroach/pkg/col/typeconv"
 	"github.com/cockroachdb/cockroach/pkg/geo"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
@@ -33,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -42,6 +44,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 	"github.com/dustin/go-humanize"
@@ -866,6 +869,153 @@ func DecodeDatum(
 		"unsupported OID %v with format code %s", errors.Safe(id), errors.Safe(code))
 }
 
+// DecodeBinaryDatumHandler decodes bytes in the binary format of the specified
+// type and passes the value directly to a ValueHandler. It is the binary
+// counterpart of tree.ParseAndRequireStringHandler: values of types supported
+// natively by the vector engine are decoded without allocating a datum, and
+// other types are decoded with DecodeDatum. b is not retained.
+func DecodeBinaryDatumHandler(
+	ctx context.Context, evalCtx *eval.Context, typ *types.T, b []byte, vh tree.ValueHandler,
+) error {
+	switch typ.Oid() {
+	case oid.T_bool:
+		if len(b) > 0 && b[0] <= 1 {
+			vh.Bool(b[0] == 1)
+			return nil
+		}
+		return pgerror.Newf(pgcode.Syntax, "unsupported binary bool: %x", b)
+	case oid.T_int2:
+		if len(b) < 2 {
+			return pgerror.Newf(pgcode.Syntax, "int2 requires 2 bytes for binary format")
+		}
+		vh.Int16(int16(binary.BigEndian.Uint16(b)))
+		return nil
+	case oid.T_int4:
+		if len(b) < 4 {
+			return pgerror.Newf(pgcode.Syntax, "int4 requires 4 bytes for binary format")
+		}
+		vh.Int32(int32(binary.BigEndian.Uint32(b)))
+		return nil
+	case oid.T_int8:
+		if len(b) < 8 {
+			return pgerror.Newf(pgcode.Syntax, "int8 requires 8 bytes for binary format")
+		}
+		vh.Int(int64(binary.BigEndian.Uint64(b)))
+		return nil
+	case oid.T_float4:
+		if len(b) < 4 {
+			return pgerror.Newf(pgcode.Syntax, "float4 requires 4 bytes for binary format")
+		}
+		vh.Float(float64(math.Float32frombits(binary.BigEndian.Uint32(b))))
+		return nil
+	case oid.T_float8:
+		if len(b) < 8 {
+			return pgerror.Newf(pgcode.Syntax, "float8 requires 8 bytes for binary format")
+		}
+		vh.Float(math.Float64frombits(binary.BigEndian.Uint64(b)))
+		return nil
+	case oid.T_bytea:
+		vh.Bytes(b)
+		return nil
+	case oid.T_text, oid.T_varchar:
+		if err := validateStringBytes(b); err != nil {
+			return err
+		}
+		if typ.Width() > 0 {
+			// As when parsing text, values are truncated to the width of the
+			// type: 'hello' into a VARCHAR(2) becomes 'he'.
+			vh.String(util.TruncateString(string(b), int(typ.Width())))
+			return nil
+		}
+		vh.Bytes(b)
+		return nil
+	case oid.T_interval:
+		if len(b) < 16 {
+			return pgerror.Newf(pgcode.Syntax, "interval requires 16 bytes for binary format")
+		}
+		nanos := (int64(binary.BigEndian.Uint64(b)) / int64(time.Nanosecond)) * int64(time.Microsecond)
+		days := int32(binary.BigEndian.Uint32(b[8:]))
+		months := int32(binary.BigEndian.Uint32(b[12:]))
+		vh.Duration(duration.MakeDuration(nanos, int64(days), int64(months)))
+		return nil
+	case oid.T_uuid:
+		if len(b) != uuid.Size {
+			return pgerror.Newf(pgcode.Syntax, "uuid requires %d bytes for binary format", uuid.Size)
+		}
+		vh.Bytes(b)
+		return nil
+	}
+	// Some datums decoded by DecodeDatum alias their input, so give it a copy
+	// the caller is free to reuse b.
+	d, err := DecodeDatum(ctx, evalCtx, typ, FormatBinary, append([]byte(nil), b...))
+	if err != nil {
+		return err
+	}
+	if d, err = AdjustDatumToType(typ, d); err != nil {
+		return err
+	}
+	return setValueHandler(typ, d, vh)
+}
+
+// AdjustDatumToType adjusts a datum decoded by DecodeDatum to the width,
+// precision and scale of the specified type, which DecodeDatum ignores. Strings
+// are truncated to the width of the type and other values are adjusted the
+// same way tree.ParseAndRequireString adjusts the values it parses.
+func AdjustDatumToType(typ *types.T, d tree.Datum) (tree.Datum, error) {
+	if s, ok := tree.AsDString(d); ok && typ.Family() == types.StringFamily && typ.Width() > 0 {
+		d = tree.NewDString(util.TruncateString(string(s), int(typ.Width())))
+	}
+	return tree.AdjustValueToType(typ, d)
+}
+
+// setValueHandler passes a datum of the specified type to a ValueHandler.
+func setValueHandler(typ *types.T, d tree.Datum, vh tree.ValueHandler) error {
+	if typeconv.TypeFamilyToCanonicalTypeFamily(typ.Family()) == typeconv.DatumVecCanonicalTypeFamily {
+		vh.Datum(d)
+		return nil
+	}
+	switch t := d.(type) {
+	case *tree.DOidWrapper:
+		return setValueHandler(typ, t.Wrapped, vh)
+	case *tree.DBool:
+		vh.Bool(bool(*t))
+	case *tree.DInt:
+		switch typ.Width() {
+		case 16:
+			vh.Int16(int16(*t))
+		case 32:
+			vh.Int32(int32(*t))
+		default:
+			vh.Int(int64(*t))
+		}
+	case *tree.DFloat:
+		vh.Float(float64(*t))
+	case *tree.DDecimal:
+		vh.Decimal().Set(&t.Decimal)
+	case *tree.DString:
+		vh.String(string(*t))
+	case *tree.DBytes:
+		vh.String(string(*t))
+	case *tree.DUuid:
+		vh.Bytes(t.GetBytes())
+	case *tree.DEnum:
+		vh.Bytes(t.PhysicalRep)
+	case *tree.DDate:
+		vh.Date(t.Date)
+	case *tree.DTimestamp:
+		vh.TimestampTZ(t.Time)
+	case *tree.DTimestampTZ:
+		vh.TimestampTZ(t.Time)
+	case *tree.DInterval:
+		vh.Duration(t.Duration)
+	case *tree.DJSON:
+		vh.JSON(t.JSON)
+	default:
+		return errors.AssertionFailedf("unexpected datum %T for type %s", d, typ.SQLString())
+	}
+	return nil
+}
+
 // Values which are going to be converted to strings (STRING and NAME) need to
 // be valid UTF-8 for us to accept them.
 func validateStringBytes(b []byte) error {
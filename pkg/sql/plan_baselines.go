@@ -0,0 +1,129 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/explain"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// PinPlan is part of the eval.Planner interface.
+func (p *planner) PinPlan(
+	ctx context.Context, fingerprint, planGist string, enforce bool,
+) (replaced bool, _ error) {
+	if err := p.checkPlanBaselinesSupported(ctx); err != nil {
+		return false, err
+	}
+	fingerprint, err := p.normalizeFingerprint(fingerprint)
+	if err != nil {
+		return false, err
+	}
+	if planGist == "" {
+		return false, pgerror.New(pgcode.InvalidParameterValue, "plan gist must not be empty")
+	}
+	// Make sure that the gist can be turned into hints, so that errors are
+	// reported when the plan is pinned rather than when it is used.
+	if _, err := explain.PlanGistHints(planGist, p.optPlanningCtx.catalog); err != nil {
+		return false, pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid plan gist %q", planGist)
+	}
+	// The creation time is rounded to the precision of the created column, so
+	// that the nodes recognize the baseline when they reload it.
+	created := timeutil.Now().Round(time.Microsecond)
+	if err := p.ExecCfg().InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		row, err := txn.QueryRowEx(ctx, "pin-plan-exists", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT 1 FROM system.plan_baselines WHERE fingerprint = $1`, fingerprint,
+		)
+		if err != nil {
+			return err
+		}
+		replaced = row != nil
+		_, err = txn.ExecEx(ctx, "pin-plan", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`UPSERT INTO system.plan_baselines (fingerprint, plan_gist, enforce, created) VALUES ($1, $2, $3, $4)`,
+			fingerprint, planGist, enforce, created,
+		)
+		return err
+	}); err != nil {
+		return false, err
+	}
+	p.notifyPlanBaseline(ctx, &serverpb.SetPlanBaselineRequest{
+		Fingerprint: fingerprint,
+		PlanGist:    planGist,
+		Enforce:     enforce,
+		Created:     created,
+	})
+	return replaced, nil
+}
+
+// UnpinPlan is part of the eval.Planner interface.
+func (p *planner) UnpinPlan(ctx context.Context, fingerprint string) (bool, error) {
+	if err := p.checkPlanBaselinesSupported(ctx); err != nil {
+		return false, err
+	}
+	fingerprint, err := p.normalizeFingerprint(fingerprint)
+	if err != nil {
+		return false, err
+	}
+	n, err := p.ExecCfg().InternalDB.Executor().ExecEx(ctx, "unpin-plan", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.plan_baselines WHERE fingerprint = $1`, fingerprint,
+	)
+	if err != nil {
+		return false, err
+	}
+	p.notifyPlanBaseline(ctx, &serverpb.SetPlanBaselineRequest{Fingerprint: fingerprint})
+	return n > 0, nil
+}
+
+// checkPlanBaselinesSupported returns an error if system.plan_baselines might
+// not exist yet.
+func (p *planner) checkPlanBaselinesSupported(ctx context.Context) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_2_PlanBaselinesTable) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"plan baselines are not supported until upgrade to 24.2 is finalized")
+	}
+	return nil
+}
+
+// notifyPlanBaseline applies a change to system.plan_baselines to the plan
+// baselines of all the nodes right away. Nodes which cannot be reached pick up
+// the change the next time they reload the table, so errors are only logged.
+func (p *planner) notifyPlanBaseline(ctx context.Context, req *serverpb.SetPlanBaselineRequest) {
+	if _, err := p.extendedEvalCtx.SQLStatusServer.SetPlanBaseline(ctx, req); err != nil {
+		log.Warningf(ctx, "unable to notify all nodes of plan baseline change: %v", err)
+	}
+}
+
+// normalizeFingerprint returns the fingerprint of the given statement, which
+// can either be a fingerprint or a statement with constants. Fingerprints are
+// formatted the same way as the fingerprints of executed statements, so that
+// fingerprints copied from the statement statistics are left unchanged.
+func (p *planner) normalizeFingerprint(stmt string) (string, error) {
+	parsed, err := parser.ParseOne(stmt)
+	if err != nil {
+		return "", pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid statement fingerprint")
+	}
+	return formatStatementHideConstants(parsed.AST,
+		tree.FmtFlags(queryFormattingForFingerprintsMask.Get(&p.execCfg.Settings.SV))), nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/execbuilder"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/explain"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/hints"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/indexrec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
@@ -31,8 +32,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/planbaseline"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -62,6 +65,7 @@ func (p *planner) prepareUsingOptimizer(
 	stmt := &p.stmt
 
 	opc := &p.optPlanningCtx
+	opc.parseHints(ctx)
 	opc.reset(ctx)
 	if origin == PreparedStatementOriginSessionMigration {
 		opc.flags.Set(planFlagSessionMigration)
@@ -136,6 +140,9 @@ func (p *planner) prepareUsingOptimizer(
 		}
 	}
 
+	// Record the generation of the query cache before building the memo, so
+	// that the memo is rebuilt if plans are evicted while it is being built.
+	memoGeneration := p.execCfg.QueryCache.Generation()
 	if opc.useCache {
 		cachedData, ok := p.execCfg.QueryCache.Find(&p.queryCacheSession, stmt.SQL)
 		if ok && cachedData.PrepareMetadata != nil {
@@ -155,6 +162,7 @@ func (p *planner) prepareUsingOptimizer(
 					stmt.Prepared.Columns = pm.Columns
 					stmt.Prepared.Types = pm.Types
 					stmt.Prepared.Memo = cachedData.Memo
+					stmt.Prepared.memoGeneration = memoGeneration
 					return opc.flags, nil
 				}
 				opc.log(ctx, "query cache hit but memo is stale (prepare)")
@@ -218,6 +226,7 @@ func (p *planner) prepareUsingOptimizer(
 	stmt.Prepared.Types = p.semaCtx.Placeholders.Types
 	if opc.allowMemoReuse {
 		stmt.Prepared.Memo = memo
+		stmt.Prepared.memoGeneration = memoGeneration
 		if opc.useCache {
 			// execPrepare sets the PrepareMetadata.InferredTypes field after this
 			// point. However, once the PrepareMetadata goes into the cache, it
@@ -245,8 +254,56 @@ func (p *planner) makeOptimizerPlan(ctx context.Context) error {
 	p.curPlan.init(&p.stmt, &p.instrumentation)
 
 	opc := &p.optPlanningCtx
+	opc.parseHints(ctx)
 	opc.reset(ctx)
 
+	if opc.baselineErr != nil {
+		return opc.baselineErr
+	}
+	baseline := opc.baseline
+	if err := p.makeOptimizerPlanInternal(ctx); err != nil {
+		return err
+	}
+	if baseline == nil {
+		return nil
+	}
+	// Plan gists are not collected if they are disabled for the session, in
+	// which case the plan cannot be verified.
+	if gist := p.curPlan.instrumentation.planGist.String(); gist != "" {
+		mismatch := gist != baseline.PlanGist
+		baseline.RecordUse(mismatch)
+		p.curPlan.flags.Set(planFlagPlanBaselineUsed)
+		if mismatch {
+			p.curPlan.flags.Set(planFlagPlanBaselineMismatch)
+			if baseline.Enforce {
+				// The pinned plan could not be reproduced, for example because the
+				// gist was captured for a different statement or the statement was
+				// changed by a schema change. An enforced baseline still constrains
+				// the plan, so keep the plan built with its hints.
+				opc.log(ctx, "plan does not match enforced plan baseline")
+			} else {
+				// A baseline which is not enforced is only a preference, so fall
+				// back to the plan chosen by the optimizer without it.
+				opc.log(ctx, "plan does not match plan baseline; planning without it")
+				p.curPlan.close(ctx)
+				p.curPlan.init(&p.stmt, &p.instrumentation)
+				opc.reset(ctx)
+				opc.baseline = nil
+				opc.hints = nil
+				if err := p.makeOptimizerPlanInternal(ctx); err != nil {
+					return err
+				}
+				p.curPlan.flags.Set(planFlagPlanBaselineUsed | planFlagPlanBaselineMismatch)
+			}
+		}
+	}
+	return nil
+}
+
+// makeOptimizerPlanInternal builds the plan for the statement in the planner,
+// using the planning context which was reset by makeOptimizerPlan.
+func (p *planner) makeOptimizerPlanInternal(ctx context.Context) error {
+	opc := &p.optPlanningCtx
 	execMemo, err := opc.buildExecMemo(ctx)
 	if err != nil {
 		return err
@@ -349,6 +406,25 @@ type optPlanningCtx struct {
 	// allowMemoReuse is false.
 	useCache bool
 
+	// commentHints are the plan hints given in the hint comment of the
+	// statement, and commentHintsErr is set if the comment is malformed. They
+	// are set once per statement by parseHints, so that a statement which is
+	// planned more than once reports a malformed comment only once.
+	commentHints    *hints.Hints
+	commentHintsErr bool
+
+	// hints are the plan hints given in the hint comment of the statement or
+	// derived from its plan baseline, or nil if there are none.
+	hints *hints.Hints
+
+	// baseline is the plan baseline of the statement fingerprint, or nil if
+	// there is none. If set, hints are derived from the pinned plan gist.
+	baseline *planbaseline.Baseline
+
+	// baselineErr is set if the statement has an enforced plan baseline from
+	// which no hints can be derived, in which case it cannot be planned.
+	baselineErr error
+
 	flags planFlags
 }
 
@@ -360,6 +436,20 @@ func (opc *optPlanningCtx) init(p *planner) {
 	opc.catalog.init(p)
 }
 
+// parseHints parses the hint comment of the statement in the planner. It must
+// be called once for each statement, before reset.
+func (opc *optPlanningCtx) parseHints(ctx context.Context) {
+	// Malformed plan hints are ignored, like in pg_hint_plan, rather than
+	// failing the statement.
+	h, err := hints.FromComments(opc.p.stmt.Comments)
+	if err != nil {
+		opc.p.BufferClientNotice(ctx, pgnotice.Newf("%v; ignoring hint comment", err))
+		h = nil
+	}
+	opc.commentHints = h
+	opc.commentHintsErr = err != nil
+}
+
 // reset initializes the planning context for the statement in the planner.
 func (opc *optPlanningCtx) reset(ctx context.Context) {
 	p := opc.p
@@ -367,6 +457,10 @@ func (opc *optPlanningCtx) reset(ctx context.Context) {
 	opc.optimizer.Init(ctx, p.EvalContext(), opc.catalog)
 	opc.flags = 0
 
+	opc.hints = opc.commentHints
+	opc.baseline = nil
+	opc.baselineErr = nil
+
 	// We only allow memo caching for SELECT/INSERT/UPDATE/DELETE. We could
 	// support it for all statements in principle, but it would increase the
 	// surface of potential issues (conditions we need to detect to invalidate a
@@ -392,6 +486,26 @@ func (opc *optPlanningCtx) reset(ctx context.Context) {
 			// dependencies and check permissions.
 			opc.useCache = false
 		}
+		if opc.hints == nil && !opc.commentHintsErr {
+			opc.resetBaseline(ctx)
+		}
+		if opc.hints != nil {
+			// The query cache is keyed by the SQL of the statement, which does not
+			// include the hint comment or the plan baseline.
+			opc.useCache = false
+		}
+		if opc.baseline != nil {
+			// Hints derived from a plan baseline are not part of the memo of a
+			// prepared statement, so it is rebuilt for each execution.
+			opc.allowMemoReuse = false
+		}
+
+	case *tree.Explain:
+		opc.allowMemoReuse = false
+		opc.useCache = false
+		if opc.hints == nil && !opc.commentHintsErr {
+			opc.resetBaseline(ctx)
+		}
 
 	default:
 		opc.allowMemoReuse = false
@@ -399,6 +513,53 @@ func (opc *optPlanningCtx) reset(ctx context.Context) {
 	}
 }
 
+// resetBaseline looks up the plan baseline of the statement fingerprint, and
+// sets the hints of the planning context to the hints derived from its plan
+// gist. Hint comments take precedence over plan baselines, and internal
+// statements never use them.
+//
+// EXPLAIN uses the plan baseline of the explained statement, so that it shows
+// the plan the statement would use, but it does not count as a use of the
+// baseline.
+func (opc *optPlanningCtx) resetBaseline(ctx context.Context) {
+	p := opc.p
+	if p.execCfg.PlanBaselines == nil || p.SessionData().Internal {
+		return
+	}
+	fingerprint := p.stmt.StmtNoConstants
+	e, isExplain := p.stmt.AST.(*tree.Explain)
+	if isExplain {
+		fingerprint = formatStatementHideConstants(e.Statement,
+			tree.FmtFlags(queryFormattingForFingerprintsMask.Get(&p.execCfg.Settings.SV)))
+	}
+	baseline := p.execCfg.PlanBaselines.Find(fingerprint)
+	if baseline == nil {
+		return
+	}
+	h, err := explain.PlanGistHints(baseline.PlanGist, opc.catalog)
+	if err != nil {
+		// The pinned plan can no longer be used, for example because a table
+		// it reads was dropped. Treat it like a plan which does not match the
+		// baseline. An enforced baseline cannot be ignored, so the statement
+		// fails until the baseline is unpinned.
+		if !isExplain {
+			baseline.RecordUse(true /* mismatch */)
+			opc.flags.Set(planFlagPlanBaselineUsed | planFlagPlanBaselineMismatch)
+			if baseline.Enforce {
+				opc.baselineErr = pgerror.Wrapf(err, pgcode.ObjectNotInPrerequisiteState,
+					"cannot use enforced plan baseline of fingerprint %q", fingerprint)
+				return
+			}
+		}
+		log.VEventf(ctx, 1, "ignoring plan baseline: %v", err)
+		return
+	}
+	opc.hints = h
+	if !isExplain {
+		opc.baseline = baseline
+	}
+}
+
 func (opc *optPlanningCtx) log(ctx context.Context, msg redact.SafeString) {
 	if log.VDepth(1, 1) {
 		log.InfofDepth(ctx, 1, "%s: %s", msg, opc.p.stmt)
@@ -442,6 +603,7 @@ func (opc *optPlanningCtx) buildReusableMemo(ctx context.Context) (_ *memo.Memo,
 	// that there's even less to do during the EXECUTE phase.
 	//
 	f := opc.optimizer.Factory()
+	f.Memo().SetHints(opc.hints)
 	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), opc.catalog, f, opc.p.stmt.AST)
 	bld.KeepPlaceholders = true
 	if opc.flags.IsSet(planFlagSessionMigration) {
@@ -548,15 +710,21 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 		// available.
 
 		// If the prepared memo has been invalidated by schema or other changes,
+		// or discarded with DISCARD PLANS or crdb_internal.evict_cached_plans,
 		// re-prepare it.
+		queryCache := p.execCfg.QueryCache
 		if isStale, err := prepared.Memo.IsStale(ctx, p.EvalContext(), opc.catalog); err != nil {
 			return nil, err
-		} else if isStale {
+		} else if isStale || prepared.discardMemo ||
+			queryCache.EvictedSince(prepared.Memo, prepared.memoGeneration) {
 			opc.log(ctx, "rebuilding cached memo")
+			memoGeneration := queryCache.Generation()
 			prepared.Memo, err = opc.buildReusableMemo(ctx)
 			if err != nil {
 				return nil, err
 			}
+			prepared.memoGeneration = memoGeneration
+			prepared.discardMemo = false
 		}
 		opc.log(ctx, "reusing cached memo")
 		return opc.reuseMemo(ctx, prepared.Memo)
@@ -595,6 +763,7 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 	// available.
 	f := opc.optimizer.Factory()
 	f.FoldingControl().AllowStableFolds()
+	f.Memo().SetHints(opc.hints)
 	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), opc.catalog, f, opc.p.stmt.AST)
 	if err := bld.Build(); err != nil {
 		return nil, err
@@ -608,7 +777,13 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 		return m == tree.ExplainPlan || m == tree.ExplainDistSQL || m == tree.ExplainGist
 	}
 	e, isExplain := opc.p.stmt.AST.(*tree.Explain)
-	if isExplain && explainModeShowsRec(e.Mode) && p.SessionData().IndexRecommendationsEnabled {
+	if isExplain && len(e.HypotheticalIndexes) > 0 {
+		// Index recommendations are not made for EXPLAIN statements with
+		// hypothetical index changes, since they would not reflect the changes.
+		if err := opc.applyHypotheticalIndexes(ctx, e.HypotheticalIndexes); err != nil {
+			return nil, err
+		}
+	} else if isExplain && explainModeShowsRec(e.Mode) && p.SessionData().IndexRecommendationsEnabled {
 		indexRecs, err := opc.makeQueryIndexRecommendation(ctx)
 		if err != nil {
 			return nil, err
@@ -621,6 +796,9 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 			return nil, err
 		}
 	}
+	if isExplain && len(e.HypotheticalIndexes) > 0 {
+		opc.p.instrumentation.explainHypotheticalCost = explainInputCost(f.Memo())
+	}
 
 	// If this statement doesn't have placeholders and we have not constant-folded
 	// any VolatilityStable operators, add it to the cache.
@@ -825,6 +1003,73 @@ func (opc *optPlanningCtx) makeQueryIndexRecommendation(
 	return indexRecs, nil
 }
 
+// applyHypotheticalIndexes fully optimizes the EXPLAIN statement being planned
+// to determine the estimated cost of its optimal plan. It then prepares the
+// optimizer to re-optimize the statement as if the given CREATE INDEX and DROP
+// INDEX statements were executed. See indexrec.BuildHypotheticalTables.
+func (opc *optPlanningCtx) applyHypotheticalIndexes(
+	ctx context.Context, changes tree.Statements,
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// This code allows us to propagate internal errors without having to add
+			// error checks everywhere throughout the code. This is only possible
+			// because the code does not update shared state and does not manipulate
+			// locks.
+			if ok, e := errorutil.ShouldCatch(r); ok {
+				err = e
+				log.VEventf(ctx, 1, "%v", err)
+			} else {
+				// Other panic objects can't be considered "safe" and thus are
+				// propagated as crashes that terminate the session.
+				panic(r)
+			}
+		}
+	}()
+
+	hypTables, err := indexrec.BuildHypotheticalTables(ctx, opc.catalog, changes)
+	if err != nil {
+		return err
+	}
+
+	// Save the normalized memo created by the optbuilder, and optimize a copy of
+	// it with the original tables.
+	savedMemo := opc.optimizer.DetachMemo(ctx)
+	f := opc.optimizer.Factory()
+	f.FoldingControl().AllowStableFolds()
+	f.CopyAndReplace(
+		savedMemo.RootExpr().(memo.RelExpr),
+		savedMemo.RootProps(),
+		f.CopyWithoutAssigningPlaceholders,
+	)
+	if _, err = opc.optimizer.Optimize(); err != nil {
+		return err
+	}
+	opc.p.instrumentation.explainOriginalCost = explainInputCost(f.Memo())
+
+	// Re-initialize the optimizer and update the metadata of a new copy of the
+	// saved memo with the hypothetical tables. Prepare to re-optimize and create
+	// an explain plan.
+	opc.optimizer.Init(ctx, f.EvalContext(), opc.catalog)
+	f.FoldingControl().AllowStableFolds()
+	f.CopyAndReplace(
+		savedMemo.RootExpr().(memo.RelExpr),
+		savedMemo.RootProps(),
+		f.CopyWithoutAssigningPlaceholders,
+	)
+	opc.optimizer.Memo().Metadata().UpdateTableMeta(f.EvalContext(), hypTables)
+	return nil
+}
+
+// explainInputCost returns the estimated cost of the optimal plan of the
+// statement explained by the root EXPLAIN expression of the given memo.
+func explainInputCost(mem *memo.Memo) float64 {
+	if e, ok := mem.RootExpr().(*memo.ExplainExpr); ok {
+		return float64(e.Input.Cost())
+	}
+	return float64(mem.RootExpr().(memo.RelExpr).Cost())
+}
+
 // Optimizer returns the Optimizer associated with this planning context.
 func (opc *optPlanningCtx) Optimizer() interface{} {
 	return &opc.optimizer
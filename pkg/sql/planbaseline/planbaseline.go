@@ -0,0 +1,288 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package planbaseline contains the registry of plan baselines: plan gists
+// pinned to statement fingerprints. When a statement with a pinned
+// fingerprint is planned, the optimizer is given the hints derived from the
+// pinned gist, so that it keeps choosing the same plan when the table
+// statistics change.
+//
+// Plan baselines are stored in system.plan_baselines. Every node keeps them in
+// its registry, which it reloads from the table periodically.
+package planbaseline
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// PollingInterval is the interval at which every node reloads the plan
+// baselines.
+var PollingInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.plan_baselines.poll_interval",
+	"rate at which every node reloads the plan baselines from system.plan_baselines, "+
+		"set to zero to disable",
+	10*time.Second,
+	settings.NonNegativeDuration,
+)
+
+// Baseline is a plan gist pinned to a statement fingerprint.
+type Baseline struct {
+	// Fingerprint is the statement fingerprint, i.e. the statement with its
+	// constants removed.
+	Fingerprint string
+
+	// PlanGist is the encoded gist of the pinned plan.
+	PlanGist string
+
+	// Enforce is true if the statement must always be planned with the hints
+	// derived from the pinned gist. If the resulting plan does not match the
+	// gist, it is used anyway. If Enforce is false, the optimizer only prefers
+	// the pinned plan: if the hints do not reproduce it, the statement is
+	// planned again without the baseline.
+	Enforce bool
+
+	// Created is the time at which the baseline was pinned.
+	Created time.Time
+
+	// hits is the number of times the baseline was used to plan a statement.
+	hits atomic.Int64
+
+	// mismatches is the number of times a statement planned with the baseline
+	// produced a plan which did not match the pinned gist.
+	mismatches atomic.Int64
+}
+
+// Hits returns the number of times the baseline was used to plan a statement.
+func (b *Baseline) Hits() int64 {
+	return b.hits.Load()
+}
+
+// Mismatches returns the number of times planning with the baseline did not
+// produce the pinned plan.
+func (b *Baseline) Mismatches() int64 {
+	return b.mismatches.Load()
+}
+
+// RecordUse records that the baseline was used to plan a statement, and
+// whether the resulting plan did not match the pinned gist.
+func (b *Baseline) RecordUse(mismatch bool) {
+	b.hits.Add(1)
+	if mismatch {
+		b.mismatches.Add(1)
+	}
+}
+
+// Registry contains the plan baselines of a node. It can be used by multiple
+// threads in parallel.
+type Registry struct {
+	// count is the number of baselines in the registry. It allows Find to
+	// return without locking when there are no baselines, which is the common
+	// case.
+	count atomic.Int32
+
+	mu struct {
+		syncutil.RWMutex
+
+		// baselines maps each fingerprint to its baseline.
+		baselines map[string]*Baseline
+
+		// epoch is incremented whenever a baseline is pinned or unpinned
+		// directly. It is observed before reading system.plan_baselines, and
+		// checked again before loading the contents of the table. If it
+		// changed in between, the contents might be stale.
+		epoch int
+	}
+
+	st *cluster.Settings
+	db isql.DB
+}
+
+// NewRegistry returns an empty registry, which loads the plan baselines from
+// the given database once it is started.
+func NewRegistry(db isql.DB, st *cluster.Settings) *Registry {
+	r := &Registry{db: db, st: st}
+	r.mu.baselines = make(map[string]*Baseline)
+	return r
+}
+
+// Start loads the plan baselines and keeps reloading them, so that the node
+// picks up the baselines it was not notified of, for example because it was
+// down when they were pinned.
+func (r *Registry) Start(ctx context.Context, stopper *stop.Stopper) {
+	ctx, _ = stopper.WithCancelOnQuiesce(ctx)
+	// NB: The only error that should occur here would be if the server were
+	// shutting down so let's swallow it.
+	_ = stopper.RunAsyncTask(ctx, "plan-baselines-poll", r.poll)
+}
+
+func (r *Registry) poll(ctx context.Context) {
+	var (
+		timer               timeutil.Timer
+		lastPoll            time.Time
+		deadline            time.Time
+		pollIntervalChanged = make(chan struct{}, 1)
+		maybeResetTimer     = func() {
+			if interval := PollingInterval.Get(&r.st.SV); interval == 0 {
+				// Setting the interval to zero stops the polling.
+				timer.Stop()
+			} else {
+				newDeadline := lastPoll.Add(interval)
+				if deadline.IsZero() || !deadline.Equal(newDeadline) {
+					deadline = newDeadline
+					timer.Reset(timeutil.Until(deadline))
+				}
+			}
+		}
+	)
+	PollingInterval.SetOnChange(&r.st.SV, func(ctx context.Context) {
+		select {
+		case pollIntervalChanged <- struct{}{}:
+		default:
+		}
+	})
+	for {
+		maybeResetTimer()
+		select {
+		case <-pollIntervalChanged:
+			continue // go back around and maybe reset the timer
+		case <-timer.C:
+			timer.Read = true
+		case <-ctx.Done():
+			return
+		}
+		if err := r.load(ctx); err != nil && ctx.Err() == nil {
+			log.Warningf(ctx, "error loading plan baselines: %s", err)
+		}
+		lastPoll = timeutil.Now()
+	}
+}
+
+// load replaces the baselines in the registry with the ones stored in
+// system.plan_baselines. Baselines which did not change keep their counters.
+func (r *Registry) load(ctx context.Context) error {
+	if !r.st.Version.IsActive(ctx, clusterversion.V24_2_PlanBaselinesTable) {
+		return nil
+	}
+	var rows []tree.Datums
+	// Loop until we run the query without straddling an epoch increment.
+	for {
+		r.mu.RLock()
+		epoch := r.mu.epoch
+		r.mu.RUnlock()
+
+		var err error
+		rows, err = r.db.Executor().QueryBufferedEx(ctx, "plan-baselines-poll", nil, /* txn */
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT fingerprint, plan_gist, enforce, created FROM system.plan_baselines`,
+		)
+		if err != nil {
+			return err
+		}
+
+		r.mu.Lock()
+		// If the epoch changed, a baseline was pinned or unpinned while the
+		// query was running, and the rows might not include that change.
+		if r.mu.epoch != epoch {
+			r.mu.Unlock()
+			continue
+		}
+		break
+	}
+	defer r.mu.Unlock()
+
+	baselines := make(map[string]*Baseline, len(rows))
+	for _, row := range rows {
+		b := &Baseline{
+			Fingerprint: string(tree.MustBeDString(row[0])),
+			PlanGist:    string(tree.MustBeDString(row[1])),
+			Enforce:     bool(tree.MustBeDBool(row[2])),
+			Created:     tree.MustBeDTimestampTZ(row[3]).Time,
+		}
+		if old, ok := r.mu.baselines[b.Fingerprint]; ok && old.PlanGist == b.PlanGist &&
+			old.Enforce == b.Enforce && old.Created.Equal(b.Created) {
+			b = old
+		}
+		baselines[b.Fingerprint] = b
+	}
+	r.mu.baselines = baselines
+	r.count.Store(int32(len(baselines)))
+	return nil
+}
+
+// Pin adds a baseline for the given fingerprint, replacing any existing
+// baseline for it. It returns true if a baseline was replaced.
+func (r *Registry) Pin(fingerprint, planGist string, enforce bool, now time.Time) (replaced bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.epoch++
+	_, replaced = r.mu.baselines[fingerprint]
+	r.mu.baselines[fingerprint] = &Baseline{
+		Fingerprint: fingerprint,
+		PlanGist:    planGist,
+		Enforce:     enforce,
+		Created:     now,
+	}
+	r.count.Store(int32(len(r.mu.baselines)))
+	return replaced
+}
+
+// Unpin removes the baseline for the given fingerprint. It returns false if
+// there was no such baseline.
+func (r *Registry) Unpin(fingerprint string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.epoch++
+	if _, ok := r.mu.baselines[fingerprint]; !ok {
+		return false
+	}
+	delete(r.mu.baselines, fingerprint)
+	r.count.Store(int32(len(r.mu.baselines)))
+	return true
+}
+
+// Find returns the baseline for the given fingerprint, or nil if there is
+// none.
+func (r *Registry) Find(fingerprint string) *Baseline {
+	if r.count.Load() == 0 {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.mu.baselines[fingerprint]
+}
+
+// Baselines returns all the baselines, ordered by fingerprint.
+func (r *Registry) Baselines() []*Baseline {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	res := make([]*Baseline, 0, len(r.mu.baselines))
+	for _, b := range r.mu.baselines {
+		res = append(res, b)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Fingerprint < res[j].Fingerprint
+	})
+	return res
+}
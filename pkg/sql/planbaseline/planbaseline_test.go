@@ -0,0 +1,69 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planbaseline
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(nil /* db */, nil /* st */)
+	now := time.Unix(1700000000, 0)
+
+	if b := r.Find("SELECT _"); b != nil {
+		t.Fatalf("expected no baseline, found %+v", b)
+	}
+	if r.Pin("SELECT * FROM t WHERE a = _", "AgHQAQIAAwAAAAYC", false /* enforce */, now) {
+		t.Error("expected no baseline to be replaced")
+	}
+	if !r.Pin("SELECT * FROM t WHERE a = _", "AgHQAQIAAgAAAAYC", true /* enforce */, now) {
+		t.Error("expected the baseline to be replaced")
+	}
+	r.Pin("DELETE FROM t", "AgHQAQIAAQAAAAYC", false /* enforce */, now)
+
+	b := r.Find("SELECT * FROM t WHERE a = _")
+	if b == nil || b.PlanGist != "AgHQAQIAAgAAAAYC" || !b.Enforce {
+		t.Fatalf("unexpected baseline %+v", b)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			b.RecordUse(i%2 == 0 /* mismatch */)
+		}(i)
+	}
+	wg.Wait()
+	if b.Hits() != 10 || b.Mismatches() != 5 {
+		t.Errorf("expected 10 hits and 5 mismatches, found %d and %d", b.Hits(), b.Mismatches())
+	}
+
+	all := r.Baselines()
+	if len(all) != 2 || all[0].Fingerprint != "DELETE FROM t" || all[1] != b {
+		t.Errorf("unexpected baselines %+v", all)
+	}
+
+	if !r.Unpin("DELETE FROM t") {
+		t.Error("expected the baseline to be removed")
+	}
+	if r.Unpin("DELETE FROM t") {
+		t.Error("expected no baseline to be removed")
+	}
+	if r.Find("DELETE FROM t") != nil {
+		t.Error("expected no baseline")
+	}
+	if len(r.Baselines()) != 1 {
+		t.Errorf("expected one baseline, found %d", len(r.Baselines()))
+	}
+}
@@ -60,6 +60,14 @@ type PreparedStatement struct {
 	// if it is used by the optimizer as a starting point.
 	Memo *memo.Memo
 
+	// memoGeneration is the generation of the query cache when Memo was built.
+	// The memo is rebuilt if plans for one of the tables it depends on are
+	// evicted from the query cache afterwards. See querycache.C.EvictedSince.
+	memoGeneration uint64
+	// discardMemo is set by DISCARD PLANS. It forces Memo to be rebuilt before
+	// its next use.
+	discardMemo bool
+
 	// refCount keeps track of the number of references to this PreparedStatement.
 	// New references are registered through incRef().
 	// Once refCount hits 0 (through calls to decRef()), the following memAcc is
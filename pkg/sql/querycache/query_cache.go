@@ -12,9 +12,13 @@ package querycache
 
 import (
 	"math/rand"
+	"sync/atomic"
+	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
 
@@ -26,6 +30,10 @@ import (
 type C struct {
 	totalMem int64
 
+	// generation is incremented every time the plans depending on a table are
+	// evicted with EvictTable. See EvictedSince.
+	generation atomic.Uint64
+
 	mu struct {
 		syncutil.Mutex
 
@@ -38,9 +46,24 @@ type C struct {
 
 		// Map with an entry for each used entry.
 		m map[string]*entry
+
+		// evictedTables maps the ID of each table passed to EvictTable to the
+		// generation of its latest eviction. It holds at most maxEvictedTables
+		// entries; see evictedFloor.
+		evictedTables map[cat.StableID]uint64
+
+		// evictedFloor is the highest generation of the evictions dropped from
+		// evictedTables to bound its size. Plans built before this generation
+		// are conservatively considered evicted by EvictedSince.
+		evictedFloor uint64
 	}
 }
 
+// maxEvictedTables is the maximum number of tables tracked in evictedTables.
+// When it is exceeded, the oldest eviction is forgotten and the plans built
+// before it are re-planned, whichever tables they depend on.
+const maxEvictedTables = 128
+
 // avgCachedSize is used to preallocate the number of "slots" in the cache.
 // Specifically, the cache will be able to store at most
 // (<size> / avgCachedSize) queries, even if their memory usage is small.
@@ -70,6 +93,11 @@ func (cd *CachedData) memoryEstimate() int64 {
 type entry struct {
 	CachedData
 
+	// hits is the number of times the entry was returned by Find.
+	hits int64
+	// lastUsed is the last time the entry was added or returned by Find.
+	lastUsed time.Time
+
 	// Linked list pointers.
 	prev, next *entry
 }
@@ -77,6 +105,8 @@ type entry struct {
 // clear resets the CachedData in the entry.
 func (e *entry) clear() {
 	e.CachedData = CachedData{}
+	e.hits = 0
+	e.lastUsed = time.Time{}
 }
 
 // remove removes the entry from the linked list it is part of.
@@ -106,6 +136,7 @@ func New(memorySize int64) *C {
 	c := &C{totalMem: memorySize}
 	c.mu.availableMem = memorySize
 	c.mu.m = make(map[string]*entry, numEntries)
+	c.mu.evictedTables = make(map[cat.StableID]uint64)
 	entries := make([]entry, numEntries)
 	// The used list is empty.
 	c.mu.used.next = &c.mu.used
@@ -142,6 +173,8 @@ func (c *C) Find(session *Session, sql string) (_ CachedData, ok bool) {
 		return CachedData{}, false
 	}
 	session.registerHit()
+	e.hits++
+	e.lastUsed = timeutil.Now()
 	// Move the entry to the front of the used list.
 	e.remove()
 	e.insertAfter(&c.mu.used)
@@ -184,6 +217,7 @@ func (c *C) Add(session *Session, d *CachedData) {
 	}
 
 	e.CachedData = *d
+	e.lastUsed = timeutil.Now()
 
 	// Evict more entries if necessary.
 	c.makeSpace(mem)
@@ -249,14 +283,129 @@ func (c *C) Purge(sql string) {
 	defer c.mu.Unlock()
 
 	if e := c.mu.m[sql]; e != nil {
-		c.mu.availableMem += e.memoryEstimate()
-		delete(c.mu.m, sql)
-		e.clear()
-		e.remove()
-		e.insertAfter(&c.mu.free)
+		c.purgeLocked(e)
 	}
 }
 
+func (c *C) purgeLocked(e *entry) {
+	c.mu.availableMem += e.memoryEstimate()
+	delete(c.mu.m, e.SQL)
+	e.clear()
+	e.remove()
+	e.insertAfter(&c.mu.free)
+}
+
+// EvictTable removes all the entries whose plan depends on the given table
+// and returns how many were removed. Prepared statements that were planned
+// before the call are re-planned before their next execution; see
+// EvictedSince.
+func (c *C) EvictTable(id cat.StableID) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.mu.evictedTables[id] = c.generation.Add(1)
+	if len(c.mu.evictedTables) > maxEvictedTables {
+		c.forgetOldestEvictionLocked()
+	}
+	n := 0
+	for e := c.mu.used.next; e != &c.mu.used; {
+		next := e.next
+		if e.Memo.Metadata().DependsOnDataSource(id) {
+			c.purgeLocked(e)
+			n++
+		}
+		e = next
+	}
+	return n
+}
+
+// forgetOldestEvictionLocked removes the oldest eviction from evictedTables
+// and raises evictedFloor accordingly.
+func (c *C) forgetOldestEvictionLocked() {
+	var oldestID cat.StableID
+	oldest := uint64(0)
+	for id, g := range c.mu.evictedTables {
+		if oldest == 0 || g < oldest {
+			oldestID, oldest = id, g
+		}
+	}
+	delete(c.mu.evictedTables, oldestID)
+	if oldest > c.mu.evictedFloor {
+		c.mu.evictedFloor = oldest
+	}
+}
+
+// Generation returns the current generation of the cache. It should be
+// recorded before building a plan that is kept outside of the cache, so that
+// it can later be passed to EvictedSince.
+func (c *C) Generation() uint64 {
+	return c.generation.Load()
+}
+
+// EvictedSince returns true if EvictTable was called, after the cache was at
+// the given generation, for a table that the given plan depends on.
+func (c *C) EvictedSince(m *memo.Memo, generation uint64) bool {
+	if c.generation.Load() == generation {
+		// Fast path: nothing was evicted.
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation < c.mu.evictedFloor {
+		// The plan predates an eviction that is no longer tracked.
+		return true
+	}
+	md := m.Metadata()
+	for id, g := range c.mu.evictedTables {
+		if g > generation && md.DependsOnDataSource(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// EntryInfo describes an entry of the cache.
+type EntryInfo struct {
+	SQL string
+	// StatementNoConstants is only set for prepared queries.
+	StatementNoConstants string
+	MemoryEstimate       int64
+	Hits                 int64
+	LastUsed             time.Time
+	// Prepared is true if the entry was added when preparing the query.
+	Prepared bool
+	// Generic is true if the plan contains placeholders, i.e. it was not
+	// built for specific placeholder values.
+	Generic bool
+}
+
+// Entries returns a description of the entries in the cache, from the most
+// recently used to the least recently used.
+func (c *C) Entries() []EntryInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := make([]EntryInfo, 0, len(c.mu.m))
+	for e := c.mu.used.next; e != &c.mu.used; e = e.next {
+		info := EntryInfo{
+			SQL:            e.SQL,
+			MemoryEstimate: e.memoryEstimate(),
+			Hits:           e.hits,
+			LastUsed:       e.lastUsed,
+			Prepared:       e.PrepareMetadata != nil,
+		}
+		if e.PrepareMetadata != nil {
+			info.StatementNoConstants = e.PrepareMetadata.StatementNoConstants
+		}
+		if _, ok := e.Memo.RootExpr().(memo.RelExpr); ok {
+			info.Generic = e.Memo.HasPlaceholders()
+		}
+		res = append(res, info)
+	}
+	return res
+}
+
 // check performs various assertions on the internal consistency of the cache
 // structures. Used by testing code.
 func (c *C) check() {
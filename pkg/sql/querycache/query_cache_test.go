@@ -16,7 +16,11 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/errors"
 )
@@ -181,6 +185,78 @@ func TestCacheMemory(t *testing.T) {
 	expect(t, c, "y,x,4,2,1,5,0,9,8,7")
 }
 
+// TestEvictTable tests the eviction of the entries depending on a table.
+func TestEvictTable(t *testing.T) {
+	memoWithDeps := func(ids ...cat.StableID) *memo.Memo {
+		m := &memo.Memo{}
+		m.Metadata().Init()
+		for _, id := range ids {
+			m.Metadata().AddDependency(opt.DepByID(id), &testcat.Table{TabID: id}, privilege.SELECT)
+		}
+		return m
+	}
+	sa := memoWithDeps(1)
+	sb := memoWithDeps(1, 2)
+	sc := memoWithDeps(2)
+	prepared := memoWithDeps(1)
+
+	c := New(3 * avgCachedSize)
+
+	var s Session
+	s.Init()
+
+	c.Add(&s, data("a", sa, avgCachedSize))
+	c.Add(&s, data("b", sb, avgCachedSize))
+	c.Add(&s, data("c", sc, avgCachedSize))
+	_, _ = c.Find(&s, "a")
+	_, _ = c.Find(&s, "a")
+	expect(t, c, "a,c,b")
+	if entries := c.Entries(); len(entries) != 3 {
+		t.Errorf("expected 3 entries, got %d", len(entries))
+	} else if entries[0].SQL != "a" || entries[0].Hits != 2 || entries[1].Hits != 0 {
+		t.Errorf("unexpected entries %+v", entries)
+	}
+
+	gen := c.Generation()
+	if n := c.EvictTable(1); n != 2 {
+		t.Errorf("expected 2 evicted entries, got %d", n)
+	}
+	expect(t, c, "c")
+	if !c.EvictedSince(prepared, gen) {
+		t.Errorf("plan depending on table 1 should be evicted")
+	}
+	if c.EvictedSince(sc, gen) {
+		t.Errorf("plan not depending on table 1 shouldn't be evicted")
+	}
+	if c.EvictedSince(prepared, c.Generation()) {
+		t.Errorf("plan built after the eviction shouldn't be evicted")
+	}
+
+	if n := c.EvictTable(3); n != 0 {
+		t.Errorf("expected no evicted entries, got %d", n)
+	}
+	expect(t, c, "c")
+
+	// Evicting many tables doesn't grow the set of tracked evictions without
+	// bound; plans built before a forgotten eviction are re-planned.
+	gen = c.Generation()
+	for id := cat.StableID(100); id < 100+2*maxEvictedTables; id++ {
+		c.EvictTable(id)
+	}
+	c.mu.Lock()
+	numTracked := len(c.mu.evictedTables)
+	c.mu.Unlock()
+	if numTracked > maxEvictedTables {
+		t.Errorf("expected at most %d tracked evictions, got %d", maxEvictedTables, numTracked)
+	}
+	if !c.EvictedSince(sc, gen) {
+		t.Errorf("plan built before a forgotten eviction should be evicted")
+	}
+	if c.EvictedSince(sc, c.Generation()) {
+		t.Errorf("plan built after the evictions shouldn't be evicted")
+	}
+}
+
 // TestSynchronization verifies that the cache doesn't crash (or cause a race
 // detector error) when multiple goroutines are using it in parallel.
 func TestSynchronization(t *testing.T) {
@@ -21,11 +21,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 )
 
 type refreshMaterializedViewNode struct {
 	n    *tree.RefreshMaterializedView
 	desc *tabledesc.Mutable
+	// incremental is set if the view is refreshed incrementally.
+	incremental *incrementalRefresh
 }
 
 func (p *planner) RefreshMaterializedView(
@@ -60,7 +63,13 @@ func (p *planner) RefreshMaterializedView(
 		)
 	}
 
-	return &refreshMaterializedViewNode{n: n, desc: desc}, nil
+	node := &refreshMaterializedViewNode{n: n, desc: desc}
+	if n.Incremental {
+		if node.incremental, err = p.planIncrementalRefresh(ctx, desc); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
 }
 
 func (n *refreshMaterializedViewNode) startExec(params runParams) error {
@@ -85,6 +94,30 @@ func (n *refreshMaterializedViewNode) startExec(params runParams) error {
 		)
 	}
 
+	// An incremental refresh writes the changes to the rows of the view
+	// directly in this transaction, so only the new refresh timestamp has to
+	// be published. If too many rows of the base tables changed, the view is
+	// refreshed fully instead.
+	if n.incremental != nil {
+		ok, err := n.incremental.run(params.ctx, params.p)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return params.p.writeSchemaChange(
+				params.ctx,
+				n.desc,
+				descpb.InvalidMutationID,
+				tree.AsStringWithFQNames(n.n, params.Ann()),
+			)
+		}
+		params.p.BufferClientNotice(
+			params.ctx,
+			pgnotice.Newf("refreshing materialized view %q fully since too many rows of its base tables changed",
+				n.desc.GetName()),
+		)
+	}
+
 	// Prepare the new set of indexes by cloning all existing indexes on the view.
 	newPrimaryIndex := n.desc.GetPrimaryIndex().IndexDescDeepCopy()
 	newIndexes := make([]descpb.IndexDescriptor, len(n.desc.PublicNonPrimaryIndexes()))
@@ -126,3 +159,20 @@ func (n *refreshMaterializedViewNode) Next(params runParams) (bool, error) { ret
 func (n *refreshMaterializedViewNode) Values() tree.Datums                 { return tree.Datums{} }
 func (n *refreshMaterializedViewNode) Close(ctx context.Context)           {}
 func (n *refreshMaterializedViewNode) ReadingOwnWrites()                   {}
+
+// MaterializedViewLastRefresh is part of the eval.Planner interface.
+func (p *planner) MaterializedViewLastRefresh(
+	ctx context.Context, viewID int,
+) (hlc.Timestamp, bool, error) {
+	desc, err := p.Descriptors().ByIDWithLeased(p.Txn()).WithoutNonPublic().Get().Table(ctx, descpb.ID(viewID))
+	if err != nil {
+		return hlc.Timestamp{}, false, err
+	}
+	if !desc.MaterializedView() {
+		return hlc.Timestamp{}, false, pgerror.Newf(pgcode.WrongObjectType, "%q is not a materialized view", desc.GetName())
+	}
+	if desc.IsRefreshViewRequired() || desc.TableDesc().ViewDataCleared {
+		return hlc.Timestamp{}, false, nil
+	}
+	return desc.LastRefreshedAsOf(), true, nil
+}
@@ -0,0 +1,1349 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// REFRESH MATERIALIZED VIEW ... INCREMENTAL applies the changes made to the
+// base tables of a materialized view since its last refresh to the stored
+// rows of the view, instead of recomputing the view from scratch. It supports
+// views whose query filters and projects base tables combined with inner
+// joins, optionally grouped and aggregated with sum and count.
+//
+// The refresh runs in three steps:
+//
+//  1. A rangefeed over the base tables collects the primary keys of the rows
+//     written between the timestamp the view was last computed as of and the
+//     read timestamp of the refreshing transaction.
+//  2. The view query, restricted to the result rows that are derived from at
+//     least one of the changed rows, is evaluated as of both timestamps. All
+//     other result rows are the same at both timestamps, so the difference
+//     between the two restricted results is the change to the view.
+//  3. The difference is applied to the stored rows of the view. Rows of
+//     ungrouped views are deleted and inserted as a multiset. The aggregates of
+//     the affected groups of grouped views are adjusted by subtracting the
+//     partial aggregates of the old rows and adding those of the new rows,
+//     except for sums of FLOAT values, which are recomputed for the affected
+//     groups.
+//
+// The refresh fails if the history it needs is no longer available, for
+// example because it has been garbage collected or a base table was rewritten
+// by a bulk operation. A full refresh is needed in that case.
+//
+// If more rows of the base tables changed than
+// sql.materialized_view.incremental_refresh.max_changed_rows, restricting the
+// view query to them is no cheaper than recomputing the view, and the refresh
+// falls back to a full one.
+
+var incrementalRefreshMaxChangedRows = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.materialized_view.incremental_refresh.max_changed_rows",
+	"maximum number of changed base table rows for which a materialized view is "+
+		"refreshed incrementally; the view is refreshed fully if more rows changed",
+	10000,
+	settings.PositiveInt,
+)
+
+// incrementalRefresh describes how a materialized view is refreshed
+// incrementally.
+type incrementalRefresh struct {
+	view *tabledesc.Mutable
+	// sources are the occurrences of base tables in the FROM clause of the
+	// view query.
+	sources []incrementalRefreshSource
+	// grouped is set if the view query aggregates its input.
+	grouped bool
+	// groupCols are the ordinals of the select list items that the view query
+	// is grouped by.
+	groupCols []int
+	// aggs are the aggregates in the select list of a grouped view query.
+	aggs []incrementalRefreshAgg
+	// rowIDCol is the ordinal of the hidden primary key column of the view
+	// among its public columns.
+	rowIDCol int
+}
+
+// incrementalRefreshSource is an occurrence of a base table in the FROM
+// clause of the query of a materialized view.
+type incrementalRefreshSource struct {
+	table catalog.TableDescriptor
+	// name is the alias of the table, or its name as written in the query,
+	// and qualifies the references to its columns.
+	name []string
+	// keyCols are the primary key columns of the table.
+	keyCols []catalog.Column
+}
+
+type incrementalRefreshAggKind int
+
+const (
+	// incrementalRefreshCountRows is count(*).
+	incrementalRefreshCountRows incrementalRefreshAggKind = iota
+	// incrementalRefreshCount is count of an argument.
+	incrementalRefreshCount
+	// incrementalRefreshSum is sum of an argument.
+	incrementalRefreshSum
+)
+
+// incrementalRefreshAgg is an aggregate in the select list of the query of a
+// grouped materialized view.
+type incrementalRefreshAgg struct {
+	// col is the ordinal of the aggregate in the select list.
+	col  int
+	kind incrementalRefreshAggKind
+	// recompute is set if the aggregate is computed from all the rows of the
+	// affected groups instead of being adjusted by the changes. This is the
+	// case for sums of FLOAT values, since adding and subtracting the partial
+	// sums accumulates rounding errors with every refresh.
+	recompute bool
+}
+
+// incrementalRefreshPartial holds the aggregates of the changed rows of one
+// group of a grouped materialized view.
+type incrementalRefreshPartial struct {
+	key tree.Datums
+	// rows is the number of changed rows of the group.
+	rows int64
+	// counts holds count of the argument of each aggregate that has one.
+	counts []int64
+	// sums holds sum of the argument of each sum aggregate.
+	sums tree.Datums
+}
+
+func incrementalRefreshUnsupported(view catalog.TableDescriptor, reason string) error {
+	return pgerror.Newf(pgcode.FeatureNotSupported,
+		"materialized view %q cannot be refreshed incrementally: %s", view.GetName(), reason)
+}
+
+// planIncrementalRefresh returns an error if the given materialized view can't
+// be refreshed incrementally, and otherwise describes how to do so.
+func (p *planner) planIncrementalRefresh(
+	ctx context.Context, view *tabledesc.Mutable,
+) (*incrementalRefresh, error) {
+	if view.IsRefreshViewRequired() || view.ViewDataCleared {
+		return nil, errors.WithHint(
+			pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"materialized view %q has not been populated", view.GetName()),
+			"use REFRESH MATERIALIZED VIEW without INCREMENTAL",
+		)
+	}
+	clause, err := parseIncrementalRefreshQuery(view)
+	if err != nil {
+		return nil, err
+	}
+	r := &incrementalRefresh{view: view}
+
+	var conds tree.Exprs
+	for _, t := range clause.From.Tables {
+		if err := p.addIncrementalRefreshSources(ctx, r, t, nil /* alias */, &conds); err != nil {
+			return nil, err
+		}
+	}
+
+	v := incrementalRefreshExprVisitor{}
+	for i := range clause.Exprs {
+		switch clause.Exprs[i].Expr.(type) {
+		case tree.UnqualifiedStar, *tree.AllColumnsSelector:
+			return nil, incrementalRefreshUnsupported(view, "* in the select list is not supported")
+		}
+		tree.WalkExprConst(&v, clause.Exprs[i].Expr)
+	}
+	if clause.Where != nil {
+		tree.WalkExprConst(&v, clause.Where.Expr)
+	}
+	for _, e := range clause.GroupBy {
+		tree.WalkExprConst(&v, e)
+	}
+	for _, e := range conds {
+		tree.WalkExprConst(&v, e)
+	}
+	if v.reason != "" {
+		return nil, incrementalRefreshUnsupported(view, v.reason)
+	}
+
+	r.grouped = v.aggregates || len(clause.GroupBy) > 0
+	if r.grouped {
+		isAgg := make(map[int]bool)
+		for i := range clause.Exprs {
+			if kind, ok := incrementalRefreshAggKindOf(clause.Exprs[i].Expr); ok {
+				r.aggs = append(r.aggs, incrementalRefreshAgg{col: i, kind: kind})
+				isAgg[i] = true
+			}
+		}
+		isGroupCol := make(map[int]bool)
+		for _, e := range clause.GroupBy {
+			col, ok := incrementalRefreshGroupCol(clause, e)
+			if !ok || isAgg[col] {
+				return nil, incrementalRefreshUnsupported(view,
+					"GROUP BY expressions must appear in the select list")
+			}
+			r.groupCols = append(r.groupCols, col)
+			isGroupCol[col] = true
+		}
+		for i := range clause.Exprs {
+			if !isAgg[i] && !isGroupCol[i] {
+				return nil, incrementalRefreshUnsupported(view,
+					"select list items must be GROUP BY expressions or sum and count aggregates")
+			}
+		}
+	}
+
+	// The rows of the view are written directly, so its columns must be the
+	// select list items followed by the hidden row ID.
+	if len(view.VisibleColumns()) != len(clause.Exprs) {
+		return nil, errors.AssertionFailedf(
+			"materialized view %q has %d columns but its query has %d",
+			view.GetName(), len(view.VisibleColumns()), len(clause.Exprs))
+	}
+	for i := range r.aggs {
+		agg := &r.aggs[i]
+		agg.recompute = agg.kind == incrementalRefreshSum &&
+			view.VisibleColumns()[agg.col].GetType().Family() == types.FloatFamily
+	}
+	pk := view.GetPrimaryIndex()
+	if pk.NumKeyColumns() != 1 {
+		return nil, errors.AssertionFailedf("materialized view %q has an unexpected primary key", view.GetName())
+	}
+	r.rowIDCol = -1
+	for i, col := range view.PublicColumns() {
+		if col.GetID() == pk.GetKeyColumnID(0) && col.IsHidden() {
+			r.rowIDCol = i
+		} else if col.IsHidden() || col.IsInaccessible() || col.IsComputed() {
+			return nil, incrementalRefreshUnsupported(view, "indexes on expressions are not supported")
+		}
+	}
+	if r.rowIDCol < 0 {
+		return nil, errors.AssertionFailedf("materialized view %q has an unexpected primary key", view.GetName())
+	}
+	for _, idx := range view.PublicNonPrimaryIndexes() {
+		if idx.IsPartial() {
+			return nil, incrementalRefreshUnsupported(view, "partial indexes are not supported")
+		}
+	}
+	return r, nil
+}
+
+// parseIncrementalRefreshQuery parses the query of the given materialized
+// view and checks that it has the shape incremental refresh supports.
+func parseIncrementalRefreshQuery(view catalog.TableDescriptor) (*tree.SelectClause, error) {
+	unsupported := func(reason string) error {
+		return incrementalRefreshUnsupported(view, reason)
+	}
+	stmt, err := parser.ParseOne(view.GetViewQuery())
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		return nil, unsupported("view query is not a SELECT")
+	}
+	for {
+		if sel.With != nil {
+			return nil, unsupported("WITH clauses are not supported")
+		}
+		if len(sel.OrderBy) > 0 || sel.Limit != nil {
+			return nil, unsupported("ORDER BY, LIMIT and OFFSET are not supported")
+		}
+		paren, ok := sel.Select.(*tree.ParenSelect)
+		if !ok {
+			break
+		}
+		sel = paren.Select
+	}
+	clause, ok := sel.Select.(*tree.SelectClause)
+	if !ok || clause.TableSelect {
+		return nil, unsupported("set operations and VALUES are not supported")
+	}
+	if clause.Distinct || clause.DistinctOn != nil {
+		return nil, unsupported("DISTINCT is not supported")
+	}
+	if clause.Having != nil {
+		return nil, unsupported("HAVING is not supported")
+	}
+	if len(clause.Window) > 0 {
+		return nil, unsupported("window functions are not supported")
+	}
+	if clause.From.AsOf.Expr != nil {
+		return nil, unsupported("AS OF SYSTEM TIME is not supported")
+	}
+	if len(clause.From.Tables) == 0 {
+		return nil, unsupported("queries without a FROM clause are not supported")
+	}
+	return clause, nil
+}
+
+// addIncrementalRefreshSources adds the base tables of the given FROM clause
+// item to the sources of r, and the conditions of its joins to conds.
+func (p *planner) addIncrementalRefreshSources(
+	ctx context.Context, r *incrementalRefresh, t tree.TableExpr, alias *tree.AliasClause, conds *tree.Exprs,
+) error {
+	switch t := t.(type) {
+	case *tree.ParenTableExpr:
+		return p.addIncrementalRefreshSources(ctx, r, t.Expr, alias, conds)
+	case *tree.AliasedTableExpr:
+		if t.Ordinality || t.Lateral {
+			return incrementalRefreshUnsupported(r.view, "WITH ORDINALITY and LATERAL are not supported")
+		}
+		if len(t.As.Cols) > 0 {
+			return incrementalRefreshUnsupported(r.view, "column aliases are not supported")
+		}
+		if t.As.Alias == "" {
+			return p.addIncrementalRefreshSources(ctx, r, t.Expr, alias, conds)
+		}
+		return p.addIncrementalRefreshSources(ctx, r, t.Expr, &t.As, conds)
+	case *tree.JoinTableExpr:
+		if t.JoinType != "" && t.JoinType != tree.AstInner && t.JoinType != tree.AstCross {
+			return incrementalRefreshUnsupported(r.view, "only inner joins are supported")
+		}
+		if alias != nil {
+			return incrementalRefreshUnsupported(r.view, "aliased joins are not supported")
+		}
+		if on, ok := t.Cond.(*tree.OnJoinCond); ok {
+			*conds = append(*conds, on.Expr)
+		}
+		if err := p.addIncrementalRefreshSources(ctx, r, t.Left, nil /* alias */, conds); err != nil {
+			return err
+		}
+		return p.addIncrementalRefreshSources(ctx, r, t.Right, nil /* alias */, conds)
+	case *tree.UnresolvedObjectName:
+		return p.addIncrementalRefreshSource(ctx, r, t, alias)
+	case *tree.TableRef:
+		return incrementalRefreshUnsupported(r.view, "numeric table references are not supported")
+	case *tree.Subquery:
+		return incrementalRefreshUnsupported(r.view, "subqueries are not supported")
+	default:
+		return incrementalRefreshUnsupported(r.view, "only tables and joins of tables are supported in the FROM clause")
+	}
+}
+
+// addIncrementalRefreshSource adds the given base table to the sources of r.
+func (p *planner) addIncrementalRefreshSource(
+	ctx context.Context, r *incrementalRefresh, un *tree.UnresolvedObjectName, alias *tree.AliasClause,
+) error {
+	tn := un.ToTableName()
+	_, table, err := resolver.ResolveExistingTableObject(ctx, p, &tn, tree.ObjectLookupFlags{
+		Required:             true,
+		DesiredObjectKind:    tree.TableObject,
+		DesiredTableDescKind: tree.ResolveAnyTableKind,
+	})
+	if err != nil {
+		return err
+	}
+	if table.IsView() || table.IsSequence() || table.IsVirtualTable() {
+		return incrementalRefreshUnsupported(r.view,
+			fmt.Sprintf("%q is not a table", table.GetName()))
+	}
+	src := incrementalRefreshSource{table: table}
+	if alias != nil {
+		src.name = []string{string(alias.Alias)}
+	} else {
+		for i := un.NumParts - 1; i >= 0; i-- {
+			src.name = append(src.name, un.Parts[i])
+		}
+	}
+	pk := table.GetPrimaryIndex()
+	for i := 0; i < pk.NumKeyColumns(); i++ {
+		col, err := catalog.MustFindColumnByID(table, pk.GetKeyColumnID(i))
+		if err != nil {
+			return err
+		}
+		if col.GetType().Family() == types.CollatedStringFamily {
+			return incrementalRefreshUnsupported(r.view,
+				fmt.Sprintf("primary key column %q of %q has a collated string type", col.GetName(), table.GetName()))
+		}
+		src.keyCols = append(src.keyCols, col)
+	}
+	r.sources = append(r.sources, src)
+	return nil
+}
+
+// incrementalRefreshAggKindOf returns the kind of the given select list item
+// if it is a supported aggregate.
+func incrementalRefreshAggKindOf(e tree.Expr) (incrementalRefreshAggKind, bool) {
+	f, ok := e.(*tree.FuncExpr)
+	if !ok || len(f.Exprs) != 1 {
+		return 0, false
+	}
+	name, ok := f.Func.FunctionReference.(*tree.UnresolvedName)
+	if !ok {
+		return 0, false
+	}
+	switch name.Parts[0] {
+	case "count":
+		if _, ok := f.Exprs[0].(tree.UnqualifiedStar); ok {
+			return incrementalRefreshCountRows, true
+		}
+		return incrementalRefreshCount, true
+	case "sum":
+		return incrementalRefreshSum, true
+	}
+	return 0, false
+}
+
+// incrementalRefreshGroupCol returns the ordinal of the select list item that
+// the given GROUP BY expression refers to.
+func incrementalRefreshGroupCol(clause *tree.SelectClause, e tree.Expr) (int, bool) {
+	if n, ok := e.(*tree.NumVal); ok {
+		if ord, err := n.AsInt64(); err == nil && ord >= 1 && ord <= int64(len(clause.Exprs)) {
+			return int(ord - 1), true
+		}
+		return 0, false
+	}
+	s := tree.AsString(e)
+	for i := range clause.Exprs {
+		if tree.AsString(clause.Exprs[i].Expr) == s {
+			return i, true
+		}
+	}
+	if name, ok := e.(*tree.UnresolvedName); ok && name.NumParts == 1 {
+		for i := range clause.Exprs {
+			if string(clause.Exprs[i].As) == name.Parts[0] {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// incrementalRefreshExprVisitor records the reason the first expression it
+// encounters that prevents incremental refresh does so.
+type incrementalRefreshExprVisitor struct {
+	reason string
+	// aggregates is set if an aggregate function was encountered.
+	aggregates bool
+}
+
+var _ tree.Visitor = &incrementalRefreshExprVisitor{}
+
+// VisitPre is part of the tree.Visitor interface.
+func (v *incrementalRefreshExprVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if v.reason != "" {
+		return false, expr
+	}
+	switch t := expr.(type) {
+	case *tree.Subquery:
+		v.reason = "subqueries are not supported"
+		return false, expr
+	case *tree.FuncExpr:
+		if t.WindowDef != nil {
+			v.reason = "window functions are not supported"
+			return false, expr
+		}
+		name, ok := t.Func.FunctionReference.(*tree.UnresolvedName)
+		if !ok {
+			v.reason = "user-defined functions are not supported"
+			return false, expr
+		}
+		fnName := name.Parts[0]
+		_, overloads := builtinsregistry.GetBuiltinProperties(fnName)
+		if len(overloads) == 0 {
+			v.reason = "user-defined functions are not supported"
+			return false, expr
+		}
+		switch overloads[0].Class {
+		case tree.AggregateClass:
+			if fnName != "sum" && fnName != "count" {
+				v.reason = fmt.Sprintf("aggregate function %s is not supported", fnName)
+				return false, expr
+			}
+			if t.Type == tree.DistinctFuncType || t.Filter != nil || len(t.OrderBy) > 0 {
+				v.reason = "DISTINCT, FILTER and ORDER BY in aggregate functions are not supported"
+				return false, expr
+			}
+			v.aggregates = true
+		case tree.GeneratorClass:
+			v.reason = "set-returning functions are not supported"
+			return false, expr
+		default:
+			// The query is evaluated at two timestamps and only for the changed
+			// rows, so it must compute the same result for the same input.
+			for i := range overloads {
+				if overloads[i].Volatility > volatility.Immutable {
+					v.reason = fmt.Sprintf("function %s is not immutable", fnName)
+					return false, expr
+				}
+			}
+		}
+	}
+	return true, expr
+}
+
+// VisitPost is part of the tree.Visitor interface.
+func (v *incrementalRefreshExprVisitor) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// run applies the changes made to the base tables of the view since its last
+// refresh to its stored rows, and records the new refresh timestamp in the
+// view descriptor. The caller is responsible for writing the descriptor. It
+// returns false without changing the view if too many rows changed, in which
+// case the view has to be refreshed fully.
+func (r *incrementalRefresh) run(ctx context.Context, p *planner) (bool, error) {
+	from, to := r.view.LastRefreshedAsOf(), p.Txn().ReadTimestamp()
+	if from.Less(to) {
+		changes, ok, err := r.changedKeys(ctx, p, from, to)
+		if err != nil || !ok {
+			return false, err
+		}
+		if changed := r.changedRowsFilter(changes); changed != nil {
+			if r.grouped {
+				err = r.applyGroupDelta(ctx, p, changed, from, to)
+			} else {
+				err = r.applyRowDelta(ctx, p, changed, from, to)
+			}
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+	r.view.ViewRefreshedAsOf = to
+	return true, nil
+}
+
+// changedKeys returns the primary keys of the rows of each base table that
+// were written after from and at or before to. It returns false if more rows
+// changed than sql.materialized_view.incremental_refresh.max_changed_rows.
+func (r *incrementalRefresh) changedKeys(
+	ctx context.Context, p *planner, from, to hlc.Timestamp,
+) (_ map[descpb.ID][]tree.Datums, ok bool, _ error) {
+	execCfg := p.ExecCfg()
+	if execCfg.Codec.ForSystemTenant() {
+		if s, ok, _ := settings.LookupForLocalAccess("kv.rangefeed.enabled", true /* forSystemTenant */); ok {
+			if enabled, ok := s.(*settings.BoolSetting); ok && !enabled.Get(&execCfg.Settings.SV) {
+				return nil, false, errors.WithHint(
+					pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+						"incremental refresh of materialized views requires rangefeeds"),
+					"enable the kv.rangefeed.enabled cluster setting",
+				)
+			}
+		}
+	}
+
+	c := incrementalRefreshKeyCollector{
+		codec:   execCfg.Codec,
+		limit:   int(incrementalRefreshMaxChangedRows.Get(&execCfg.Settings.SV)),
+		tables:  make(map[descpb.ID]catalog.TableDescriptor),
+		seen:    make(map[string]struct{}),
+		changes: make(map[descpb.ID][]tree.Datums),
+	}
+	var spans []roachpb.Span
+	for _, src := range r.sources {
+		if _, ok := c.tables[src.table.GetID()]; !ok {
+			c.tables[src.table.GetID()] = src.table
+			spans = append(spans, src.table.TableSpan(execCfg.Codec))
+		}
+	}
+
+	var mu syncutil.Mutex
+	var feedErr error
+	var doneOnce sync.Once
+	done := make(chan struct{})
+	finish := func(err error) {
+		doneOnce.Do(func() {
+			feedErr = err
+			close(done)
+		})
+	}
+	historyUnavailable := func(reason string) error {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"cannot refresh materialized view %q incrementally: %s", r.view.GetName(), reason),
+			"use REFRESH MATERIALIZED VIEW without INCREMENTAL",
+		)
+	}
+	onValue := func(ctx context.Context, value *kvpb.RangeFeedValue) {
+		if to.Less(value.Timestamp()) {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if err := c.add(value.Key); err != nil {
+			finish(err)
+		} else if c.tooMany {
+			// The remaining changes aren't needed for a full refresh.
+			finish(nil)
+		}
+	}
+	feed, err := execCfg.RangeFeedFactory.RangeFeed(
+		ctx, "refresh-materialized-view", spans, from, onValue,
+		rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+			if to.LessEq(ts) {
+				finish(nil)
+			}
+		}),
+		rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+			finish(errors.CombineErrors(historyUnavailable("the changes to its base tables are no longer available"), err))
+		}),
+		rangefeed.WithOnDeleteRange(func(ctx context.Context, value *kvpb.RangeFeedDeleteRange) {
+			if !to.Less(value.Timestamp) {
+				finish(historyUnavailable("a base table was cleared by a range deletion"))
+			}
+		}),
+		rangefeed.WithOnSSTable(func(ctx context.Context, sst *kvpb.RangeFeedSSTable, registeredSpan roachpb.Span) {
+			if !to.Less(sst.WriteTS) {
+				finish(historyUnavailable("a base table was written by a bulk operation"))
+			}
+		}),
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	select {
+	case <-done:
+	case <-ctx.Done():
+		finish(ctx.Err())
+	}
+	feed.Close()
+	if feedErr != nil {
+		return nil, false, feedErr
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if c.tooMany {
+		return nil, false, nil
+	}
+	return c.changes, true, nil
+}
+
+// incrementalRefreshKeyCollector decodes the primary keys of the rows of the
+// base tables of a materialized view from the keys of their changed values.
+type incrementalRefreshKeyCollector struct {
+	codec keys.SQLCodec
+	// limit is the maximum number of rows to collect.
+	limit  int
+	tables map[descpb.ID]catalog.TableDescriptor
+	alloc  tree.DatumAlloc
+	// seen holds the key prefixes of the rows that have already been added.
+	seen    map[string]struct{}
+	changes map[descpb.ID][]tree.Datums
+	// tooMany is set once more than limit rows were added. No more rows are
+	// collected after that.
+	tooMany bool
+}
+
+// add records the primary key of the row that the given key belongs to.
+func (c *incrementalRefreshKeyCollector) add(key roachpb.Key) error {
+	if c.tooMany {
+		return nil
+	}
+	_, tableID, indexID, err := c.codec.DecodeIndexPrefix(key)
+	if err != nil {
+		return err
+	}
+	table, ok := c.tables[descpb.ID(tableID)]
+	if !ok {
+		return nil
+	}
+	// Every change to a row writes to the primary index, so the keys of the
+	// secondary indexes and of dropped indexes are not needed.
+	pk := table.GetPrimaryIndex()
+	if descpb.IndexID(indexID) != pk.GetID() {
+		return nil
+	}
+	n, err := keys.GetRowPrefixLength(key)
+	if err != nil {
+		return err
+	}
+	if _, ok := c.seen[string(key[:n])]; ok {
+		return nil
+	}
+	if len(c.seen) >= c.limit {
+		c.tooMany = true
+		c.seen, c.changes = nil, nil
+		return nil
+	}
+	c.seen[string(key[:n])] = struct{}{}
+
+	vals := make([]rowenc.EncDatum, pk.NumKeyColumns())
+	if _, err := rowenc.DecodeIndexKey(c.codec, vals, pk.IndexDesc().KeyColumnDirections, key); err != nil {
+		return err
+	}
+	datums := make(tree.Datums, len(vals))
+	for i := range vals {
+		col, err := catalog.MustFindColumnByID(table, pk.GetKeyColumnID(i))
+		if err != nil {
+			return err
+		}
+		if err := vals[i].EnsureDecoded(col.GetType(), &c.alloc); err != nil {
+			return err
+		}
+		datums[i] = vals[i].Datum
+	}
+	c.changes[table.GetID()] = append(c.changes[table.GetID()], datums)
+	return nil
+}
+
+// changedRowsFilter returns a filter for the view query that only keeps the
+// result rows derived from at least one changed row, or nil if no rows
+// changed.
+func (r *incrementalRefresh) changedRowsFilter(changes map[descpb.ID][]tree.Datums) tree.Expr {
+	var filter tree.Expr
+	for _, src := range r.sources {
+		changed := changes[src.table.GetID()]
+		if len(changed) == 0 {
+			continue
+		}
+		var left tree.Expr
+		right := make(tree.Exprs, len(changed))
+		if len(src.keyCols) == 1 {
+			left = r.sourceColumn(src, src.keyCols[0])
+			for i, key := range changed {
+				right[i] = key[0]
+			}
+		} else {
+			cols := make(tree.Exprs, len(src.keyCols))
+			for i, col := range src.keyCols {
+				cols[i] = r.sourceColumn(src, col)
+			}
+			left = &tree.Tuple{Exprs: cols}
+			for i, key := range changed {
+				vals := make(tree.Exprs, len(key))
+				for j := range key {
+					vals[j] = key[j]
+				}
+				right[i] = &tree.Tuple{Exprs: vals}
+			}
+		}
+		in := &tree.ComparisonExpr{
+			Operator: treecmp.MakeComparisonOperator(treecmp.In),
+			Left:     left,
+			Right:    &tree.Tuple{Exprs: right},
+		}
+		if filter == nil {
+			filter = in
+		} else {
+			filter = &tree.OrExpr{Left: filter, Right: in}
+		}
+	}
+	return filter
+}
+
+func (r *incrementalRefresh) sourceColumn(
+	src incrementalRefreshSource, col catalog.Column,
+) *tree.UnresolvedName {
+	parts := append(append([]string(nil), src.name...), col.GetName())
+	return tree.NewUnresolvedName(parts...)
+}
+
+// addIncrementalRefreshFilter adds the given filter to the WHERE clause of
+// the given query.
+func addIncrementalRefreshFilter(clause *tree.SelectClause, filter tree.Expr) {
+	if clause.Where == nil {
+		clause.Where = tree.NewWhere(tree.AstWhere, filter)
+		return
+	}
+	clause.Where.Expr = &tree.AndExpr{
+		Left:  &tree.ParenExpr{Expr: clause.Where.Expr},
+		Right: &tree.ParenExpr{Expr: filter},
+	}
+}
+
+// queryAsOf evaluates the given query as of the given timestamp.
+func (r *incrementalRefresh) queryAsOf(
+	ctx context.Context, p *planner, clause *tree.SelectClause, limit *tree.Limit, ts hlc.Timestamp,
+) ([]tree.Datums, error) {
+	clause.From.AsOf = tree.AsOfClause{Expr: tree.NewStrVal(ts.AsOfSystemTime())}
+	stmt := tree.AsStringWithFlags(&tree.Select{Select: clause, Limit: limit}, tree.FmtSerializable)
+	rows, err := p.ExecCfg().InternalDB.Executor().QueryBufferedEx(
+		ctx, "refresh-materialized-view-delta", nil /* txn */, sessiondata.NodeUserSessionDataOverride, stmt,
+	)
+	if err != nil && errors.HasType(err, &kvpb.BatchTimestampBeforeGCError{}) {
+		return nil, errors.WithHint(err, "use REFRESH MATERIALIZED VIEW without INCREMENTAL")
+	}
+	return rows, err
+}
+
+// storedRows returns the values of the public columns of the stored rows of
+// the view whose visible columns cols hold vals. At most limit rows are
+// returned if limit is positive.
+func (r *incrementalRefresh) storedRows(
+	ctx context.Context, p *planner, cols []int, vals tree.Datums, limit int,
+) ([]tree.Datums, error) {
+	var buf strings.Builder
+	buf.WriteString("SELECT ")
+	for i, col := range r.view.PublicColumns() {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(tree.NameString(col.GetName()))
+	}
+	fmt.Fprintf(&buf, " FROM [%d AS v]", r.view.GetID())
+	visible := r.view.VisibleColumns()
+	args := make([]interface{}, len(cols))
+	for i, col := range cols {
+		if i == 0 {
+			buf.WriteString(" WHERE ")
+		} else {
+			buf.WriteString(" AND ")
+		}
+		fmt.Fprintf(&buf, "%s IS NOT DISTINCT FROM $%d", tree.NameString(visible[col].GetName()), i+1)
+		args[i] = vals[i]
+	}
+	if limit > 0 {
+		fmt.Fprintf(&buf, " LIMIT %d", limit)
+	}
+	return p.InternalSQLTxn().QueryBufferedEx(
+		ctx, "refresh-materialized-view-rows", p.txn, sessiondata.NodeUserSessionDataOverride,
+		buf.String(), args...,
+	)
+}
+
+// outOfSync returns the error for stored rows that don't match the last
+// computed result of the view query.
+func (r *incrementalRefresh) outOfSync() error {
+	return errors.WithHint(
+		pgerror.Newf(pgcode.DataException,
+			"stored rows of materialized view %q do not match its query", r.view.GetName()),
+		"use REFRESH MATERIALIZED VIEW without INCREMENTAL",
+	)
+}
+
+// visibleValue returns the value of the given visible column in the given
+// stored row of the view.
+func (r *incrementalRefresh) visibleValue(stored tree.Datums, col int) tree.Datum {
+	if col >= r.rowIDCol {
+		return stored[col+1]
+	}
+	return stored[col]
+}
+
+// publicRow returns the values of the public columns of a new row of the view
+// with the given visible column values.
+func (r *incrementalRefresh) publicRow(p *planner, vals tree.Datums) tree.Datums {
+	res := make(tree.Datums, len(vals)+1)
+	copy(res, vals[:r.rowIDCol])
+	res[r.rowIDCol] = tree.NewDInt(builtins.GenerateUniqueInt(
+		builtins.ProcessUniqueID(p.EvalContext().NodeID.SQLInstanceID()),
+	))
+	copy(res[r.rowIDCol+1:], vals[r.rowIDCol:])
+	return res
+}
+
+// applyRowDelta applies the change to an ungrouped view.
+func (r *incrementalRefresh) applyRowDelta(
+	ctx context.Context, p *planner, changed tree.Expr, from, to hlc.Timestamp,
+) error {
+	var results [2][]tree.Datums
+	for i, ts := range []hlc.Timestamp{from, to} {
+		clause, err := parseIncrementalRefreshQuery(r.view)
+		if err != nil {
+			return err
+		}
+		addIncrementalRefreshFilter(clause, changed)
+		if results[i], err = r.queryAsOf(ctx, p, clause, nil /* limit */, ts); err != nil {
+			return err
+		}
+	}
+	oldRows, newRows := results[0], results[1]
+
+	// Rows that are in both results are not affected by the changes. What is
+	// left of the old rows is deleted and what is left of the new rows is
+	// inserted.
+	counts := make(map[string]int, len(newRows))
+	for _, vals := range newRows {
+		counts[incrementalRefreshRowKey(vals)]++
+	}
+	deleted := make(map[string]int)
+	var deletedRows []tree.Datums
+	for _, vals := range oldRows {
+		k := incrementalRefreshRowKey(vals)
+		if counts[k] > 0 {
+			counts[k]--
+			continue
+		}
+		if deleted[k] == 0 {
+			deletedRows = append(deletedRows, vals)
+		}
+		deleted[k]++
+	}
+	var inserted []tree.Datums
+	for _, vals := range newRows {
+		if k := incrementalRefreshRowKey(vals); counts[k] > 0 {
+			counts[k]--
+			inserted = append(inserted, r.publicRow(p, vals))
+		}
+	}
+
+	allCols := make([]int, len(r.view.VisibleColumns()))
+	for i := range allCols {
+		allCols[i] = i
+	}
+	var stored []tree.Datums
+	for _, vals := range deletedRows {
+		n := deleted[incrementalRefreshRowKey(vals)]
+		rows, err := r.storedRows(ctx, p, allCols, vals, n)
+		if err != nil {
+			return err
+		}
+		if len(rows) < n {
+			return r.outOfSync()
+		}
+		stored = append(stored, rows...)
+	}
+	return r.write(ctx, p, stored, inserted)
+}
+
+// applyGroupDelta applies the change to a grouped view.
+func (r *incrementalRefresh) applyGroupDelta(
+	ctx context.Context, p *planner, changed tree.Expr, from, to hlc.Timestamp,
+) error {
+	oldGroups, err := r.partialAggregates(ctx, p, changed, from)
+	if err != nil {
+		return err
+	}
+	newGroups, err := r.partialAggregates(ctx, p, changed, to)
+	if err != nil {
+		return err
+	}
+	old := make(map[string]*incrementalRefreshPartial, len(oldGroups))
+	for _, g := range oldGroups {
+		old[incrementalRefreshRowKey(g.key)] = g
+	}
+	type affectedGroup struct {
+		oldPartial, newPartial *incrementalRefreshPartial
+	}
+	var affected []affectedGroup
+	for _, g := range newGroups {
+		k := incrementalRefreshRowKey(g.key)
+		affected = append(affected, affectedGroup{oldPartial: old[k], newPartial: g})
+		delete(old, k)
+	}
+	for _, g := range oldGroups {
+		if _, ok := old[incrementalRefreshRowKey(g.key)]; ok {
+			affected = append(affected, affectedGroup{oldPartial: g})
+		}
+	}
+
+	var deleted, inserted []tree.Datums
+	for _, g := range affected {
+		oldPartial, newPartial := g.oldPartial, g.newPartial
+		if oldPartial == nil {
+			oldPartial = r.emptyPartial(newPartial.key)
+		}
+		if newPartial == nil {
+			newPartial = r.emptyPartial(oldPartial.key)
+		}
+		rows, err := r.storedRows(ctx, p, r.groupCols, newPartial.key, 0 /* limit */)
+		if err != nil {
+			return err
+		}
+		if len(rows) > 1 {
+			return r.outOfSync()
+		}
+		var stored tree.Datums
+		if len(rows) == 1 {
+			stored = rows[0]
+		}
+		vals, err := r.groupRow(ctx, p, stored, oldPartial, newPartial, to)
+		if err != nil {
+			return err
+		}
+		if stored != nil && vals != nil {
+			unchanged := true
+			for i := range vals {
+				if incrementalRefreshRowKey(tree.Datums{vals[i]}) !=
+					incrementalRefreshRowKey(tree.Datums{r.visibleValue(stored, i)}) {
+					unchanged = false
+					break
+				}
+			}
+			if unchanged {
+				continue
+			}
+		}
+		if stored != nil {
+			deleted = append(deleted, stored)
+		}
+		if vals != nil {
+			inserted = append(inserted, r.publicRow(p, vals))
+		}
+	}
+	return r.write(ctx, p, deleted, inserted)
+}
+
+func (r *incrementalRefresh) emptyPartial(key tree.Datums) *incrementalRefreshPartial {
+	g := &incrementalRefreshPartial{
+		key:    key,
+		counts: make([]int64, len(r.aggs)),
+		sums:   make(tree.Datums, len(r.aggs)),
+	}
+	for i := range g.sums {
+		g.sums[i] = tree.DNull
+	}
+	return g
+}
+
+// partialAggregates evaluates the aggregates of the view query over the
+// changed rows as of the given timestamp, grouped like the view query.
+func (r *incrementalRefresh) partialAggregates(
+	ctx context.Context, p *planner, changed tree.Expr, ts hlc.Timestamp,
+) ([]*incrementalRefreshPartial, error) {
+	clause, err := parseIncrementalRefreshQuery(r.view)
+	if err != nil {
+		return nil, err
+	}
+	aggregate := func(name string, arg tree.Expr) tree.SelectExpr {
+		return tree.SelectExpr{Expr: &tree.FuncExpr{Func: tree.WrapFunction(name), Exprs: tree.Exprs{arg}}}
+	}
+	groupBy := make(tree.GroupBy, len(r.groupCols))
+	exprs := make(tree.SelectExprs, 0, len(r.groupCols)+1+2*len(r.aggs))
+	for i, col := range r.groupCols {
+		groupBy[i] = clause.Exprs[col].Expr
+		exprs = append(exprs, tree.SelectExpr{Expr: groupBy[i]})
+	}
+	exprs = append(exprs, aggregate("count", tree.StarExpr()))
+	for _, agg := range r.aggs {
+		if agg.kind == incrementalRefreshCountRows {
+			continue
+		}
+		arg := clause.Exprs[agg.col].Expr.(*tree.FuncExpr).Exprs[0]
+		exprs = append(exprs, aggregate("count", arg))
+		if agg.kind == incrementalRefreshSum {
+			exprs = append(exprs, aggregate("sum", arg))
+		}
+	}
+	clause.Exprs = exprs
+	clause.GroupBy = groupBy
+	addIncrementalRefreshFilter(clause, changed)
+	rows, err := r.queryAsOf(ctx, p, clause, nil /* limit */, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*incrementalRefreshPartial, len(rows))
+	for i, vals := range rows {
+		g := r.emptyPartial(vals[:len(r.groupCols)])
+		j := len(r.groupCols)
+		g.rows = int64(tree.MustBeDInt(vals[j]))
+		j++
+		for k, agg := range r.aggs {
+			switch agg.kind {
+			case incrementalRefreshCount:
+				g.counts[k] = int64(tree.MustBeDInt(vals[j]))
+				j++
+			case incrementalRefreshSum:
+				g.counts[k] = int64(tree.MustBeDInt(vals[j]))
+				g.sums[k] = vals[j+1]
+				j += 2
+			}
+		}
+		groups[i] = g
+	}
+	return groups, nil
+}
+
+// groupRow returns the new values of the visible columns of the row of the
+// view for the group of the given partial aggregates, or nil if the group no
+// longer exists. stored is the current row of the group, if any.
+func (r *incrementalRefresh) groupRow(
+	ctx context.Context,
+	p *planner,
+	stored tree.Datums,
+	oldPartial, newPartial *incrementalRefreshPartial,
+	to hlc.Timestamp,
+) (tree.Datums, error) {
+	exists, err := r.groupExists(ctx, p, stored, oldPartial, newPartial, to)
+	if err != nil || !exists {
+		return nil, err
+	}
+	vals := make(tree.Datums, len(r.view.VisibleColumns()))
+	for i, col := range r.groupCols {
+		vals[col] = newPartial.key[i]
+	}
+	for i, agg := range r.aggs {
+		var cur tree.Datum = tree.DNull
+		if stored != nil {
+			cur = r.visibleValue(stored, agg.col)
+		}
+		switch agg.kind {
+		case incrementalRefreshCountRows:
+			vals[agg.col] = incrementalRefreshAddCount(cur, newPartial.rows-oldPartial.rows)
+		case incrementalRefreshCount:
+			vals[agg.col] = incrementalRefreshAddCount(cur, newPartial.counts[i]-oldPartial.counts[i])
+		case incrementalRefreshSum:
+			if vals[agg.col], err = r.sum(ctx, p, agg, i, cur, oldPartial, newPartial, to); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return vals, nil
+}
+
+// groupExists returns whether the group of the given partial aggregates has
+// any rows as of to.
+func (r *incrementalRefresh) groupExists(
+	ctx context.Context,
+	p *planner,
+	stored tree.Datums,
+	oldPartial, newPartial *incrementalRefreshPartial,
+	to hlc.Timestamp,
+) (bool, error) {
+	switch {
+	case len(r.groupCols) == 0:
+		// An aggregation without GROUP BY always returns one row.
+		return true, nil
+	case newPartial.rows > 0:
+		return true, nil
+	case stored == nil:
+		return false, nil
+	}
+	for _, agg := range r.aggs {
+		if agg.kind == incrementalRefreshCountRows {
+			n := int64(tree.MustBeDInt(r.visibleValue(stored, agg.col)))
+			return n+newPartial.rows-oldPartial.rows > 0, nil
+		}
+	}
+	// Without count(*) in the view, whether unchanged rows are left in the
+	// group has to be checked.
+	return r.probe(ctx, p, newPartial.key, -1 /* argCol */, to)
+}
+
+// sum returns the new value of the given sum aggregate, whose current value
+// is cur.
+func (r *incrementalRefresh) sum(
+	ctx context.Context,
+	p *planner,
+	agg incrementalRefreshAgg,
+	i int,
+	cur tree.Datum,
+	oldPartial, newPartial *incrementalRefreshPartial,
+	to hlc.Timestamp,
+) (tree.Datum, error) {
+	if agg.recompute {
+		return r.recompute(ctx, p, newPartial.key, agg.col, to)
+	}
+	// The sum is NULL if none of the rows of the group has a non-NULL
+	// argument. If the current sum is non-NULL and none of the changed rows
+	// had a non-NULL argument, the unchanged rows still do.
+	nonNull := newPartial.counts[i] > 0
+	if !nonNull && cur != tree.DNull {
+		nonNull = oldPartial.counts[i] == 0
+		if !nonNull {
+			var err error
+			if nonNull, err = r.probe(ctx, p, newPartial.key, agg.col, to); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if !nonNull {
+		return tree.DNull, nil
+	}
+	if cur == tree.DNull {
+		return newPartial.sums[i], nil
+	}
+	res := cur
+	var err error
+	if newPartial.sums[i] != tree.DNull {
+		if res, err = incrementalRefreshArith(ctx, p.EvalContext(), treebin.Plus, res, newPartial.sums[i]); err != nil {
+			return nil, err
+		}
+	}
+	if oldPartial.sums[i] != tree.DNull {
+		if res, err = incrementalRefreshArith(ctx, p.EvalContext(), treebin.Minus, res, oldPartial.sums[i]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// probe returns whether the group with the given key has any rows as of the
+// given timestamp. If argCol is not negative, only rows for which the
+// argument of the aggregate at that ordinal of the select list is not NULL
+// are considered.
+func (r *incrementalRefresh) probe(
+	ctx context.Context, p *planner, key tree.Datums, argCol int, ts hlc.Timestamp,
+) (bool, error) {
+	clause, err := r.groupQuery(key)
+	if err != nil {
+		return false, err
+	}
+	if argCol >= 0 {
+		arg := clause.Exprs[argCol].Expr.(*tree.FuncExpr).Exprs[0]
+		addIncrementalRefreshFilter(clause, &tree.IsNotNullExpr{Expr: &tree.ParenExpr{Expr: arg}})
+	}
+	clause.Exprs = tree.SelectExprs{{Expr: tree.DBoolTrue}}
+	clause.GroupBy = nil
+	rows, err := r.queryAsOf(ctx, p, clause, &tree.Limit{Count: tree.NewDInt(1)}, ts)
+	if err != nil {
+		return false, err
+	}
+	return len(rows) > 0, nil
+}
+
+// recompute returns the value of the aggregate at the given ordinal of the
+// select list for the group with the given key, computed from all the rows of
+// the group as of the given timestamp.
+func (r *incrementalRefresh) recompute(
+	ctx context.Context, p *planner, key tree.Datums, col int, ts hlc.Timestamp,
+) (tree.Datum, error) {
+	clause, err := r.groupQuery(key)
+	if err != nil {
+		return nil, err
+	}
+	clause.Exprs = tree.SelectExprs{clause.Exprs[col]}
+	clause.GroupBy = nil
+	rows, err := r.queryAsOf(ctx, p, clause, nil /* limit */, ts)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) != 1 {
+		return nil, errors.AssertionFailedf("expected 1 row, got %d", len(rows))
+	}
+	return rows[0][0], nil
+}
+
+// groupQuery returns the view query restricted to the rows of the group with
+// the given key.
+func (r *incrementalRefresh) groupQuery(key tree.Datums) (*tree.SelectClause, error) {
+	clause, err := parseIncrementalRefreshQuery(r.view)
+	if err != nil {
+		return nil, err
+	}
+	for i, col := range r.groupCols {
+		addIncrementalRefreshFilter(clause, &tree.ComparisonExpr{
+			Operator: treecmp.MakeComparisonOperator(treecmp.IsNotDistinctFrom),
+			Left:     &tree.ParenExpr{Expr: clause.Exprs[col].Expr},
+			Right:    key[i],
+		})
+	}
+	return clause, nil
+}
+
+func incrementalRefreshAddCount(cur tree.Datum, delta int64) tree.Datum {
+	var n tree.DInt
+	if cur != tree.DNull {
+		n = tree.MustBeDInt(cur)
+	}
+	return tree.NewDInt(n + tree.DInt(delta))
+}
+
+func incrementalRefreshArith(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	op treebin.BinaryOperatorSymbol,
+	left, right tree.Datum,
+) (tree.Datum, error) {
+	expr := tree.NewBinExprIfValidOverload(treebin.MakeBinaryOperator(op), left, right)
+	if expr == nil {
+		return nil, errors.AssertionFailedf(
+			"no %s operator for %s and %s", op, left.ResolvedType(), right.ResolvedType())
+	}
+	return eval.Expr(ctx, evalCtx, expr)
+}
+
+// incrementalRefreshRowKey returns a string that is equal for rows with the
+// same values.
+func incrementalRefreshRowKey(vals tree.Datums) string {
+	f := tree.NewFmtCtx(tree.FmtSerializable)
+	for i, d := range vals {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		f.FormatNode(d)
+	}
+	return f.CloseAndGetString()
+}
+
+// write deletes and inserts the given rows of the view, which hold the values
+// of its public columns.
+func (r *incrementalRefresh) write(
+	ctx context.Context, p *planner, deleted, inserted []tree.Datums,
+) error {
+	execCfg := p.ExecCfg()
+	internal := p.SessionData().Internal
+	desc := r.view.ImmutableCopy().(catalog.TableDescriptor)
+	if len(deleted) > 0 {
+		td := &tableDeleter{
+			rd: row.MakeDeleter(
+				execCfg.Codec, desc, desc.PublicColumns(), &execCfg.Settings.SV, internal,
+				execCfg.GetRowMetrics(internal),
+			),
+			alloc: &tree.DatumAlloc{},
+		}
+		if err := r.writeRows(ctx, p, td, &td.tableWriterBase, deleted); err != nil {
+			return err
+		}
+	}
+	if len(inserted) > 0 {
+		ri, err := row.MakeInserter(
+			ctx, p.txn, execCfg.Codec, desc, desc.PublicColumns(), &tree.DatumAlloc{},
+			&execCfg.Settings.SV, internal, execCfg.GetRowMetrics(internal),
+		)
+		if err != nil {
+			return err
+		}
+		ti := &tableInserter{ri: ri}
+		if err := r.writeRows(ctx, p, ti, &ti.tableWriterBase, inserted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *incrementalRefresh) writeRows(
+	ctx context.Context, p *planner, tw tableWriter, tb *tableWriterBase, rows []tree.Datums,
+) error {
+	defer tw.close(ctx)
+	if err := tw.init(ctx, p.txn, p.EvalContext()); err != nil {
+		return err
+	}
+	traceKV := p.extendedEvalCtx.Tracing.KVTracingEnabled()
+	for _, vals := range rows {
+		if err := p.cancelChecker.Check(); err != nil {
+			return err
+		}
+		// Periodically flush out the batches, so that we don't issue gigantic
+		// raft commands.
+		if tb.currentBatchSize >= tb.maxBatchSize ||
+			tb.b.ApproximateMutationBytes() >= tb.maxBatchByteSize {
+			if err := tw.flushAndStartNewBatch(ctx); err != nil {
+				return err
+			}
+		}
+		// Materialized views may not have partial indexes, so an empty
+		// row.PartialIndexUpdateHelper is used.
+		var pm row.PartialIndexUpdateHelper
+		if err := tw.row(ctx, vals, pm, traceKV); err != nil {
+			return err
+		}
+	}
+	return tw.finalize(ctx)
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -228,6 +229,20 @@ func (g *routineGenerator) ResolvedType() *types.T {
 
 // Start is part of the eval.ValueGenerator interface.
 func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
+	if override := g.expr.SessionOverride; override != nil {
+		// Apply the session modifications of a SECURITY DEFINER routine or a
+		// routine with SET clauses for the duration of its execution. Note that
+		// all statements in the routine are fully executed in Start, so it is
+		// safe to revert the modifications once Start returns.
+		defer func() {
+			if popErr := g.p.EvalContext().SessionDataStack.Pop(); popErr != nil {
+				err = errors.CombineErrors(err, popErr)
+			}
+		}()
+		if err = g.p.pushRoutineSessionOverride(ctx, override); err != nil {
+			return err
+		}
+	}
 	for {
 		err = g.startInternal(ctx, txn)
 		if err != nil || g.deferredRoutine.expr == nil {
@@ -241,6 +256,40 @@ func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
 	}
 }
 
+// pushRoutineSessionOverride pushes a copy of the current session data onto the
+// session data stack, and applies the given routine session override to it. The
+// caller is responsible for popping the session data once the routine has
+// finished executing, even if an error is returned.
+func (p *planner) pushRoutineSessionOverride(
+	ctx context.Context, override *tree.RoutineSessionOverride,
+) error {
+	p.EvalContext().SessionDataStack.PushTopClone()
+	return p.sessionDataMutatorIterator.applyOnTopMutator(func(m sessionDataMutator) error {
+		if !override.User.Undefined() {
+			// As with SET ROLE, the current user changes, but the session user
+			// does not.
+			if m.data.SessionUserProto == "" {
+				m.data.SessionUserProto = m.data.UserProto
+			}
+			m.data.UserProto = override.User.EncodeProto()
+		}
+		for _, entry := range override.Config {
+			name, value := funcdesc.SplitConfigVar(entry)
+			_, v, err := getSessionVar(name, false /* missingOk */)
+			if err != nil {
+				return err
+			}
+			if v.Set == nil {
+				return newCannotChangeParameterError(name)
+			}
+			if err := v.Set(ctx, m, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // startInternal implements logic for a single execution of a routine.
 // TODO(mgartner): We can cache results for future invocations of the routine by
 // creating a new iterator over an existing row container helper if the routine
@@ -738,6 +787,41 @@ func (p *planner) EvalTxnControlExpr(
 	if err != nil {
 		return nil, err
 	}
-	p.storedProcTxnState.setStoredProcTxnState(expr.Op, &expr.Modes, resumeProc.(*memo.Memo))
+	txnModes := expr.Modes
+	if expr.Chain {
+		txnModes = chainedStoredProcTxnModes(p, expr.Modes)
+	}
+	p.storedProcTxnState.setStoredProcTxnState(expr.Op, &txnModes, resumeProc.(*memo.Memo))
 	return tree.DNull, nil
 }
+
+// chainedStoredProcTxnModes returns the transaction modes for the transaction
+// that follows a PL/pgSQL COMMIT AND CHAIN or ROLLBACK AND CHAIN statement. The
+// new transaction inherits the characteristics of the current transaction,
+// except for those that are overridden by SET TRANSACTION statements following
+// the COMMIT or ROLLBACK.
+func chainedStoredProcTxnModes(
+	p *planner, overrides tree.TransactionModes,
+) tree.TransactionModes {
+	modes := makeChainedTxnModes(
+		p.Txn().IsoLevel(), p.Txn().UserPriority(), p.EvalContext().TxnReadOnly,
+	)
+	if overrides.Isolation != tree.UnspecifiedIsolation {
+		modes.Isolation = overrides.Isolation
+	}
+	if overrides.UserPriority != tree.UnspecifiedUserPriority {
+		modes.UserPriority = overrides.UserPriority
+	}
+	if overrides.ReadWriteMode != tree.UnspecifiedReadWriteMode {
+		modes.ReadWriteMode = overrides.ReadWriteMode
+	}
+	if overrides.Deferrable != tree.UnspecifiedDeferrableMode {
+		modes.Deferrable = overrides.Deferrable
+	}
+	modes.AsOf = overrides.AsOf
+	if modes.AsOf.Expr != nil && overrides.ReadWriteMode == tree.UnspecifiedReadWriteMode {
+		// AS OF SYSTEM TIME implies READ ONLY, so don't carry over READ WRITE.
+		modes.ReadWriteMode = tree.UnspecifiedReadWriteMode
+	}
+	return modes
+}
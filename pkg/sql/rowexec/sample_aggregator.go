@@ -516,19 +516,18 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 				histogram = &h
 			}
 
-			columnIDs := make([]descpb.ColumnID, len(si.spec.Columns))
-			for i, c := range si.spec.Columns {
-				columnIDs[i] = s.sampledCols[c]
-			}
+			columnIDs := s.statColumnIDs(&si)
 
 			// Delete old stats that have been superseded,
 			// if the new statistic is not partial
 			if si.spec.PartialPredicate == "" {
 				if err := stats.DeleteOldStatsForColumns(
 					ctx,
+					s.FlowCtx.Cfg.Settings,
 					txn,
 					s.tableID,
 					columnIDs,
+					si.spec.Expression,
 				); err != nil {
 					return err
 				}
@@ -549,6 +548,7 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 				histogram,
 				si.spec.PartialPredicate,
 				si.spec.FullStatisticID,
+				si.spec.Expression,
 			); err != nil {
 				return err
 			}
@@ -564,12 +564,8 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 
 	if s.spec.DeleteOtherStats {
 		columnsUsed := make([][]descpb.ColumnID, len(s.sketches))
-		for i, si := range s.sketches {
-			columnIDs := make([]descpb.ColumnID, len(si.spec.Columns))
-			for j, c := range si.spec.Columns {
-				columnIDs[j] = s.sampledCols[c]
-			}
-			columnsUsed[i] = columnIDs
+		for i := range s.sketches {
+			columnsUsed[i] = s.statColumnIDs(&s.sketches[i])
 		}
 		keepTime := stats.TableStatisticsRetentionPeriod.Get(&s.FlowCtx.Cfg.Settings.SV)
 		if err := s.FlowCtx.Cfg.DB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
@@ -578,6 +574,7 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 			// multi-column stats from deleted indexes from persisting indefinitely.
 			return stats.DeleteOldStatsForOtherColumns(
 				ctx,
+				s.FlowCtx.Cfg.Settings,
 				txn,
 				s.tableID,
 				columnsUsed,
@@ -591,6 +588,20 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 	return nil
 }
 
+// statColumnIDs returns the IDs of the table columns of the statistic computed
+// by the given sketch. For a statistic on an expression, these are the columns
+// referenced by the expression.
+func (s *sampleAggregator) statColumnIDs(si *sketchInfo) []descpb.ColumnID {
+	if si.spec.Expression != "" {
+		return si.spec.ExpressionColumnIDs
+	}
+	columnIDs := make([]descpb.ColumnID, len(si.spec.Columns))
+	for i, c := range si.spec.Columns {
+		columnIDs[i] = s.sampledCols[c]
+	}
+	return columnIDs
+}
+
 // getAvgSize returns the average number of bytes per row in the given
 // sketch.
 func (s *sampleAggregator) getAvgSize(si *sketchInfo) int64 {
@@ -306,6 +306,10 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 				s.pos++
 				lval.SetID(lexbase.INET_CONTAINED_BY_OR_EQUALS)
 				return
+			case '%': // <<%
+				s.pos++
+				lval.SetID(lexbase.STRICT_WORD_SIMILAR)
+				return
 			}
 			lval.SetID(lexbase.LSHIFT)
 			return
@@ -321,6 +325,25 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.CONTAINED_BY)
 			return
+		case '%': // <%
+			s.pos++
+			lval.SetID(lexbase.WORD_SIMILAR)
+			return
+		}
+		return
+
+	case '%':
+		switch s.peek() {
+		case '>': // %>
+			s.pos++
+			switch s.peek() {
+			case '>': // %>>
+				s.pos++
+				lval.SetID(lexbase.STRICT_WORD_SIMILAR_COMMUTATOR)
+				return
+			}
+			lval.SetID(lexbase.WORD_SIMILAR_COMMUTATOR)
+			return
 		}
 		return
 
@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -216,6 +217,20 @@ func alterTableAddForeignKey(
 		}
 	}
 
+	// Nodes running older versions don't know how to enforce MATCH PARTIAL
+	// constraints.
+	if fkDef.Match == tree.MatchPartial && !b.ClusterSettings().Version.IsActive(b, clusterversion.V24_2) {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"MATCH PARTIAL foreign keys are not supported until version 24.2"))
+	}
+
+	// Cascading updates are not supported with MATCH PARTIAL, since a child
+	// row can match several parent rows with different values.
+	if fkDef.Match == tree.MatchPartial && fkDef.Actions.Update != tree.NoAction && fkDef.Actions.Update != tree.Restrict {
+		panic(unimplemented.NewWithIssueDetailf(20305, "match partial on update",
+			"ON UPDATE %s is not supported with MATCH PARTIAL", fkDef.Actions.Update))
+	}
+
 	// 2. If this FK has SET NULL action (ON UPDATE or ON DELETE) && any one of
 	// the originColumns is NOT NULL, then panic with error.
 	if fkDef.Actions.Delete == tree.SetNull || fkDef.Actions.Update == tree.SetNull {
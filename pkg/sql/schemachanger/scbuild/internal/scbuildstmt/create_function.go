@@ -33,6 +33,14 @@ func CreateFunction(b BuildCtx, n *tree.CreateRoutine) {
 	if n.Replace {
 		panic(scerrors.NotImplementedError(n))
 	}
+	// SECURITY and SET clauses have no corresponding elements, so fall back to
+	// the legacy schema changer.
+	for _, option := range n.Options {
+		switch option.(type) {
+		case tree.RoutineSecurity, *tree.RoutineSetVar:
+			panic(scerrors.NotImplementedError(n))
+		}
+	}
 	b.IncrementSchemaChangeCreateCounter("function")
 
 	dbElts, scElts := b.ResolveTargetObject(n.Name.ToUnresolvedObjectName(), privilege.CREATE)
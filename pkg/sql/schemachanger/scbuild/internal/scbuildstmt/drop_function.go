@@ -44,6 +44,9 @@ func DropFunction(b BuildCtx, n *tree.DropRoutine) {
 			continue
 		}
 		f.FuncName.ObjectNamePrefix = b.NamePrefix(fn)
+		if usedByCast(b, fn.FunctionID) {
+			panic(scerrors.NotImplementedErrorf(n, "dropping a function used by a cast"))
+		}
 		if dropRestrictDescriptor(b, fn.FunctionID) {
 			toCheckBackRefs = append(toCheckBackRefs, fn.FunctionID)
 			_, _, fnName := scpb.FindFunctionName(elts)
@@ -66,3 +69,18 @@ func DropFunction(b BuildCtx, n *tree.DropRoutine) {
 		}
 	}
 }
+
+// usedByCast returns whether a user-defined cast is performed by the function.
+// Such casts are stored on the descriptors of types, which reference the
+// function from its back references, but aren't represented by elements.
+func usedByCast(b BuildCtx, fnID catid.DescID) bool {
+	return !b.BackReferences(fnID).Filter(func(
+		_ scpb.Status, target scpb.TargetStatus, e scpb.Element,
+	) bool {
+		switch e.(type) {
+		case *scpb.EnumType, *scpb.AliasType, *scpb.CompositeType:
+			return target == scpb.ToPublic
+		}
+		return false
+	}).IsEmpty()
+}
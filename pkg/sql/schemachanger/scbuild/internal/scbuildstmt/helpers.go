@@ -186,6 +186,11 @@ func dropCascadeDescriptor(b BuildCtx, id catid.DescID) {
 			}
 		case *scpb.EnumType, *scpb.AliasType, *scpb.CompositeType:
 			break
+		case *scpb.Function:
+			if usedByCast(b, t.FunctionID) {
+				panic(scerrors.NotImplementedErrorf(nil, "dropping a function used by a cast"))
+			}
+			return
 		default:
 			return
 		}
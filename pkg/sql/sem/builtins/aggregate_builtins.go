@@ -20,6 +20,7 @@ import (
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geomfn"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/geo/geos"
 	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
@@ -593,6 +594,39 @@ var aggregates = map[string]builtinDefinition{
 	"st_memunion":   makeSTUnionBuiltin(),
 	"st_collect":    makeSTCollectBuiltin(),
 	"st_memcollect": makeSTCollectBuiltin(),
+	"st_clusterwithin": makeBuiltin(
+		tree.FunctionProperties{
+			AvailableOnPublicSchema: true,
+		},
+		makeAggOverload(
+			[]*types.T{types.Geometry, types.Float},
+			types.MakeArray(types.Geometry),
+			newSTClusterWithinAgg,
+			infoBuilder{
+				info: "Groups geometries into GeometryCollections of geometries that are connected by a chain " +
+					"of geometries, each within the given distance of the next. The distance is taken from the " +
+					"first row with a non-NULL geometry.",
+			}.String(),
+			volatility.Immutable,
+			true, /* calledOnNullInput */
+		),
+	),
+	"st_polygonize": makeBuiltin(
+		tree.FunctionProperties{
+			AvailableOnPublicSchema: true,
+		},
+		makeAggOverload(
+			[]*types.T{types.Geometry},
+			types.Geometry,
+			newSTPolygonizeAgg,
+			infoBuilder{
+				info:         "Returns a GeometryCollection of the polygons formed by the linework of the given geometries.",
+				libraryUsage: usesGEOS,
+			}.String(),
+			volatility.Immutable,
+			true, /* calledOnNullInput */
+		),
+	),
 
 	AnyNotNull: makePrivate(makeBuiltin(tree.FunctionProperties{},
 		makeImmutableAggOverloadWithReturnType(
@@ -1226,6 +1260,123 @@ func (agg *stCollectAgg) Size() int64 {
 	return sizeOfSTCollectAggregate
 }
 
+type stClusterWithinAgg struct {
+	acc      mon.BoundAccount
+	gs       []geo.Geometry
+	distance tree.Datum
+}
+
+func newSTClusterWithinAgg(_ []*types.T, evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+	return &stClusterWithinAgg{
+		acc: evalCtx.Planner.Mon().MakeBoundAccount(),
+	}
+}
+
+// Add implements the AggregateFunc interface.
+func (agg *stClusterWithinAgg) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	if firstArg == tree.DNull {
+		return nil
+	}
+	if agg.distance == nil {
+		agg.distance = otherArgs[0]
+	}
+	if err := agg.acc.Grow(ctx, int64(firstArg.Size())); err != nil {
+		return err
+	}
+	agg.gs = append(agg.gs, tree.MustBeDGeometry(firstArg).Geometry)
+	return nil
+}
+
+// Result implements the AggregateFunc interface.
+func (agg *stClusterWithinAgg) Result() (tree.Datum, error) {
+	if len(agg.gs) == 0 || agg.distance == tree.DNull {
+		return tree.DNull, nil
+	}
+	colls, err := geomfn.ClusterWithin(agg.gs, float64(tree.MustBeDFloat(agg.distance)))
+	if err != nil {
+		return nil, err
+	}
+	arr := tree.NewDArray(types.Geometry)
+	for _, c := range colls {
+		if err := arr.Append(tree.NewDGeometry(c)); err != nil {
+			return nil, err
+		}
+	}
+	return arr, nil
+}
+
+// Reset implements the AggregateFunc interface.
+func (agg *stClusterWithinAgg) Reset(ctx context.Context) {
+	agg.gs = nil
+	agg.distance = nil
+	agg.acc.Empty(ctx)
+}
+
+// Close implements the AggregateFunc interface.
+func (agg *stClusterWithinAgg) Close(ctx context.Context) {
+	agg.acc.Close(ctx)
+}
+
+// Size implements the AggregateFunc interface.
+func (agg *stClusterWithinAgg) Size() int64 {
+	return sizeOfSTClusterWithinAggregate
+}
+
+type stPolygonizeAgg struct {
+	acc mon.BoundAccount
+	gs  []geo.Geometry
+}
+
+func newSTPolygonizeAgg(_ []*types.T, evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+	return &stPolygonizeAgg{
+		acc: evalCtx.Planner.Mon().MakeBoundAccount(),
+	}
+}
+
+// Add implements the AggregateFunc interface.
+func (agg *stPolygonizeAgg) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	if firstArg == tree.DNull {
+		return nil
+	}
+	if err := agg.acc.Grow(ctx, int64(firstArg.Size())); err != nil {
+		return err
+	}
+	agg.gs = append(agg.gs, tree.MustBeDGeometry(firstArg).Geometry)
+	return nil
+}
+
+// Result implements the AggregateFunc interface.
+func (agg *stPolygonizeAgg) Result() (tree.Datum, error) {
+	if len(agg.gs) == 0 {
+		return tree.DNull, nil
+	}
+	g, err := geomfn.Polygonize(agg.gs)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDGeometry(g), nil
+}
+
+// Reset implements the AggregateFunc interface.
+func (agg *stPolygonizeAgg) Reset(ctx context.Context) {
+	agg.gs = nil
+	agg.acc.Empty(ctx)
+}
+
+// Close implements the AggregateFunc interface.
+func (agg *stPolygonizeAgg) Close(ctx context.Context) {
+	agg.acc.Close(ctx)
+}
+
+// Size implements the AggregateFunc interface.
+func (agg *stPolygonizeAgg) Size() int64 {
+	return sizeOfSTPolygonizeAggregate
+}
+
 type stExtentAgg struct {
 	bbox *geo.CartesianBoundingBox
 }
@@ -1386,6 +1537,8 @@ const sizeOfPercentileContAggregate = int64(unsafe.Sizeof(percentileContAggregat
 const sizeOfSTMakeLineAggregate = int64(unsafe.Sizeof(stMakeLineAgg{}))
 const sizeOfSTUnionAggregate = int64(unsafe.Sizeof(stUnionAgg{}))
 const sizeOfSTCollectAggregate = int64(unsafe.Sizeof(stCollectAgg{}))
+const sizeOfSTClusterWithinAggregate = int64(unsafe.Sizeof(stClusterWithinAgg{}))
+const sizeOfSTPolygonizeAggregate = int64(unsafe.Sizeof(stPolygonizeAgg{}))
 const sizeOfSTExtentAggregate = int64(unsafe.Sizeof(stExtentAgg{}))
 const sizeOfStatementStatistics = int64(unsafe.Sizeof(aggStatementStatistics{}))
 const sizeOfAggStatementMetadata = int64(unsafe.Sizeof(aggStatementMetadata{}))
@@ -3943,21 +3943,13 @@ value if you rely on the HLC for accuracy.`,
 	"tsvector_cmp":                   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"tsvector_concat":                makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"ts_debug":                       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_headline":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_lexize":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"websearch_to_tsquery":           makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"array_to_tsvector":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"get_current_ts_config":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"numnode":                        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"querytree":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"setweight":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"strip":                          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"json_to_tsvector":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"jsonb_to_tsvector":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"ts_delete":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"ts_filter":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_rank_cd":                     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_rewrite":                     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"tsquery_phrase":                 makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"tsvector_to_array":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"tsvector_update_trigger":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
@@ -4062,8 +4054,32 @@ value if you rely on the HLC for accuracy.`,
 			Volatility: volatility.Immutable,
 		},
 	),
-	"dmetaphone":     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 56820, Category: builtinconstants.CategoryFuzzyStringMatching}),
-	"dmetaphone_alt": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 56820, Category: builtinconstants.CategoryFuzzyStringMatching}),
+	"dmetaphone": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFuzzyStringMatching},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "source", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				primary, _ := fuzzystrmatch.DoubleMetaphone(string(tree.MustBeDString(args[0])))
+				return tree.NewDString(primary), nil
+			},
+			Info:       "Convert a string to its primary Double Metaphone code.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"dmetaphone_alt": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFuzzyStringMatching},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "source", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				_, alternate := fuzzystrmatch.DoubleMetaphone(string(tree.MustBeDString(args[0])))
+				return tree.NewDString(alternate), nil
+			},
+			Info:       "Convert a string to its alternate Double Metaphone code.",
+			Volatility: volatility.Immutable,
+		},
+	),
 
 	// JSON functions.
 	// The behavior of both the JSON and JSONB data types in CockroachDB is
@@ -7323,6 +7339,98 @@ table's zone configuration this will return NULL.`,
 			Volatility: volatility.Volatile,
 		},
 	),
+	"crdb_internal.evict_cached_plans": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true, // applicable only on the gateway
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "table", Typ: types.RegClass}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if err := evalCtx.SessionAccessor.CheckPrivilege(
+					ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.REPAIRCLUSTER,
+				); err != nil {
+					return nil, err
+				}
+				oid := tree.MustBeDOid(args[0])
+				n, err := evalCtx.Planner.EvictCachedPlans(ctx, int64(oid.Oid))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDInt(tree.DInt(n)), nil
+			},
+			Info: "Evicts the query plans which depend on the given table from the query caches of all " +
+				"the nodes, and forces the prepared statements which depend on it to be re-planned " +
+				"before their next execution. Returns the number of evicted query cache entries. This " +
+				"can be used to take fresh table statistics into account without waiting for the plans " +
+				"to be evicted.",
+			Volatility: volatility.Volatile,
+		},
+	),
+	"crdb_internal.pin_plan": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true, // applicable only on the gateway
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "fingerprint", Typ: types.String},
+				{Name: "plan_gist", Typ: types.String},
+				{Name: "enforce", Typ: types.Bool},
+			},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if err := evalCtx.SessionAccessor.CheckPrivilege(
+					ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.REPAIRCLUSTER,
+				); err != nil {
+					return nil, err
+				}
+				replaced, err := evalCtx.Planner.PinPlan(ctx,
+					string(tree.MustBeDString(args[0])),
+					string(tree.MustBeDString(args[1])),
+					bool(tree.MustBeDBool(args[2])),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(replaced)), nil
+			},
+			Info: "Pins the plan with the given gist to the fingerprint of the given statement on all " +
+				"the nodes. Statements with the fingerprint are planned with hints derived from the " +
+				"gist, so that the plan does not change when table statistics change. If the pinned " +
+				"plan cannot be reproduced, the plan closest to it is used if enforce is true; " +
+				"otherwise the statement is planned again without it. Returns true if an existing plan " +
+				"baseline of the fingerprint was replaced. Plan baselines are stored in " +
+				"system.plan_baselines.",
+			Volatility: volatility.Volatile,
+		},
+	),
+	"crdb_internal.unpin_plan": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true, // applicable only on the gateway
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "fingerprint", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if err := evalCtx.SessionAccessor.CheckPrivilege(
+					ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.REPAIRCLUSTER,
+				); err != nil {
+					return nil, err
+				}
+				found, err := evalCtx.Planner.UnpinPlan(ctx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(found)), nil
+			},
+			Info: "Removes the plan baseline of the fingerprint of the given statement on all the " +
+				"nodes. Returns false if the fingerprint had no plan baseline.",
+			Volatility: volatility.Volatile,
+		},
+	),
 	"crdb_internal.reset_sql_stats": makeBuiltin(
 		tree.FunctionProperties{
 			Category:         builtinconstants.CategorySystemInfo,
@@ -7707,6 +7815,36 @@ active for the current transaction.`,
 		},
 	),
 
+	"crdb_internal.materialized_view_last_refresh": makeBuiltin(
+		tree.FunctionProperties{
+			Category: builtinconstants.CategorySystemInfo,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "view_name", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.TimestampTZ),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				viewName := tree.MustBeDString(args[0])
+				dOid, err := eval.ParseDOid(ctx, evalCtx, string(viewName), types.RegClass)
+				if err != nil {
+					return nil, err
+				}
+				ts, ok, err := evalCtx.Planner.MaterializedViewLastRefresh(ctx, int(dOid.Oid))
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					return tree.DNull, nil
+				}
+				return tree.MakeDTimestampTZ(ts.GoTime(), time.Microsecond)
+			},
+			Info: "Returns the time as of which the data of the given materialized view was last computed, " +
+				"either by its creation or by REFRESH MATERIALIZED VIEW. Returns NULL if the view has no " +
+				"data because it was created or last refreshed WITH NO DATA. The staleness of the view can be " +
+				"computed as the difference between the current time and the result.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
 	"crdb_internal.kv_set_queue_active": makeBuiltin(
 		tree.FunctionProperties{
 			Category:         builtinconstants.CategorySystemRepair,
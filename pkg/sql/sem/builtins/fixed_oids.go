@@ -2581,6 +2581,102 @@ var builtinOidsArray = []string{
 	2613: `crdb_internal.split_at(key: bytes, ttl: interval) -> void`,
 	2614: `crdb_internal.scatter(key: bytes) -> void`,
 	2615: `crdb_internal.scatter(key: bytes, end_key: bytes) -> void`,
+	2616: `ts_rank_cd(weights: float[], vector: tsvector, query: tsquery, normalization: int) -> float4`,
+	2617: `ts_rank_cd(weights: float[], vector: tsvector, query: tsquery) -> float4`,
+	2618: `ts_rank_cd(vector: tsvector, query: tsquery, normalization: int) -> float4`,
+	2619: `ts_rank_cd(vector: tsvector, query: tsquery) -> float4`,
+	2620: `websearch_to_tsquery(config: string, text: string) -> tsquery`,
+	2621: `websearch_to_tsquery(text: string) -> tsquery`,
+	2622: `ts_headline(config: string, document: string, query: tsquery, options: string) -> string`,
+	2623: `ts_headline(config: string, document: string, query: tsquery) -> string`,
+	2624: `ts_headline(document: string, query: tsquery, options: string) -> string`,
+	2625: `ts_headline(document: string, query: tsquery) -> string`,
+	2626: `setweight(vector: tsvector, weight: "char") -> tsvector`,
+	2627: `setweight(vector: tsvector, weight: "char", lexemes: string[]) -> tsvector`,
+	2628: `ts_rewrite(query: tsquery, target: tsquery, substitute: tsquery) -> tsquery`,
+	2629: `json_to_tsvector(config: string, document: jsonb, filter: jsonb) -> tsvector`,
+	2630: `json_to_tsvector(document: jsonb, filter: jsonb) -> tsvector`,
+	2631: `jsonb_to_tsvector(config: string, document: jsonb, filter: jsonb) -> tsvector`,
+	2632: `jsonb_to_tsvector(document: jsonb, filter: jsonb) -> tsvector`,
+	2633: `crdb_internal.materialized_view_last_refresh(view_name: string) -> timestamptz`,
+	2634: `crdb_internal.evict_cached_plans(table: regclass) -> int`,
+	2635: `st_clusterdbscan(geometry: geometry, eps: float, minpoints: int) -> int`,
+	2636: `st_clusterkmeans(geometry: geometry, number_of_clusters: int) -> int`,
+	2637: `st_clusterkmeans(geometry: geometry, number_of_clusters: int, max_radius: float) -> int`,
+	2638: `st_clusterwithinwin(geometry: geometry, distance: float) -> int`,
+	2639: `st_clusterwithin(arg1: geometry, arg2: float) -> geometry[]`,
+	2640: `st_dump(geometry: geometry) -> tuple{int[] AS path, geometry AS geom}`,
+	2641: `st_dumppoints(geometry: geometry) -> tuple{int[] AS path, geometry AS geom}`,
+	2642: `st_dumprings(geometry: geometry) -> tuple{int[] AS path, geometry AS geom}`,
+	2643: `st_split(input: geometry, blade: geometry) -> geometry`,
+	2644: `st_concavehull(geometry: geometry, target_percent: float) -> geometry`,
+	2645: `st_concavehull(geometry: geometry, target_percent: float, allow_holes: bool) -> geometry`,
+	2646: `st_buildarea(geometry: geometry) -> geometry`,
+	2647: `st_polygonize(arg1: geometry) -> geometry`,
+	2648: `st_asgml(geometry: geometry) -> string`,
+	2649: `st_asgml(geometry: geometry, max_decimal_digits: int) -> string`,
+	2650: `st_asgml(geometry: geometry, max_decimal_digits: int, options: int) -> string`,
+	2651: `st_asgml(version: int, geometry: geometry) -> string`,
+	2652: `st_asgml(version: int, geometry: geometry, max_decimal_digits: int) -> string`,
+	2653: `st_asgml(version: int, geometry: geometry, max_decimal_digits: int, options: int) -> string`,
+	2654: `st_asgml(version: int, geometry: geometry, max_decimal_digits: int, options: int, nprefix: string) -> string`,
+	2655: `st_asgml(geography: geography) -> string`,
+	2656: `st_asgml(geography: geography, max_decimal_digits: int) -> string`,
+	2657: `st_asgml(geography: geography, max_decimal_digits: int, options: int) -> string`,
+	2658: `st_asgml(version: int, geography: geography) -> string`,
+	2659: `st_asgml(version: int, geography: geography, max_decimal_digits: int) -> string`,
+	2660: `st_asgml(version: int, geography: geography, max_decimal_digits: int, options: int) -> string`,
+	2661: `st_asgml(version: int, geography: geography, max_decimal_digits: int, options: int, nprefix: string) -> string`,
+	2662: `st_asgml(geometry_str: string) -> string`,
+	2663: `st_asgml(geometry_str: string, max_decimal_digits: int) -> string`,
+	2664: `st_asgml(geometry_str: string, max_decimal_digits: int, options: int) -> string`,
+	2665: `st_asgml(version: int, geometry_str: string) -> string`,
+	2666: `st_asgml(version: int, geometry_str: string, max_decimal_digits: int) -> string`,
+	2667: `st_asgml(version: int, geometry_str: string, max_decimal_digits: int, options: int) -> string`,
+	2668: `st_asgml(version: int, geometry_str: string, max_decimal_digits: int, options: int, nprefix: string) -> string`,
+	2669: `st_assvg(geometry: geometry) -> string`,
+	2670: `st_assvg(geometry: geometry, rel: int) -> string`,
+	2671: `st_assvg(geometry: geometry, rel: int, max_decimal_digits: int) -> string`,
+	2672: `st_assvg(geography: geography) -> string`,
+	2673: `st_assvg(geography: geography, rel: int) -> string`,
+	2674: `st_assvg(geography: geography, rel: int, max_decimal_digits: int) -> string`,
+	2675: `st_assvg(geometry_str: string) -> string`,
+	2676: `st_assvg(geometry_str: string, rel: int) -> string`,
+	2677: `st_assvg(geometry_str: string, rel: int, max_decimal_digits: int) -> string`,
+	2678: `st_geomfromgml(val: string) -> geometry`,
+	2679: `st_geomfromgml(val: string, srid: int) -> geometry`,
+	2680: `st_geomfromtwkb(val: bytes) -> geometry`,
+	2681: `st_gmltosql(val: string) -> geometry`,
+	2682: `st_gmltosql(val: string, srid: int) -> geometry`,
+	2683: `st_lengthspheroid(geometry: geometry, spheroid: string) -> float`,
+	2684: `st_length2dspheroid(geometry: geometry, spheroid: string) -> float`,
+	2685: `st_isvaliddetail(geometry: geometry) -> tuple{bool AS valid, string AS reason, geometry AS location}`,
+	2686: `st_isvaliddetail(geometry: geometry, flags: int) -> tuple{bool AS valid, string AS reason, geometry AS location}`,
+	2687: `st_geometricmedian(geometry: geometry) -> geometry`,
+	2688: `st_geometricmedian(geometry: geometry, tolerance: float) -> geometry`,
+	2689: `st_geometricmedian(geometry: geometry, tolerance: float, max_iter: int) -> geometry`,
+	2690: `st_geometricmedian(geometry: geometry, tolerance: float, max_iter: int, fail_if_not_converged: bool) -> geometry`,
+	2691: `st_simplifyvw(geometry: geometry, tolerance: float) -> geometry`,
+	2692: `st_seteffectivearea(geometry: geometry) -> geometry`,
+	2693: `st_seteffectivearea(geometry: geometry, threshold: float) -> geometry`,
+	2694: `st_seteffectivearea(geometry: geometry, threshold: float, set_area: int) -> geometry`,
+	2695: `st_chaikinsmoothing(geometry: geometry) -> geometry`,
+	2696: `st_chaikinsmoothing(geometry: geometry, n_iterations: int) -> geometry`,
+	2697: `st_chaikinsmoothing(geometry: geometry, n_iterations: int, preserve_end_points: bool) -> geometry`,
+	2698: `st_quantizecoordinates(geometry: geometry, prec_x: int) -> geometry`,
+	2699: `st_quantizecoordinates(geometry: geometry, prec_x: int, prec_y: int) -> geometry`,
+	2700: `st_quantizecoordinates(geometry: geometry, prec_x: int, prec_y: int, prec_z: int) -> geometry`,
+	2701: `st_quantizecoordinates(geometry: geometry, prec_x: int, prec_y: int, prec_z: int, prec_m: int) -> geometry`,
+	2702: `dmetaphone(source: string) -> string`,
+	2703: `dmetaphone_alt(source: string) -> string`,
+	2704: `word_similarity(left: string, right: string) -> float`,
+	2705: `strict_word_similarity(left: string, right: string) -> float`,
+	2706: `show_limit() -> float4`,
+	2707: `set_limit(threshold: float4) -> float4`,
+	2708: `crdb_internal.pin_plan(fingerprint: string, plan_gist: string, enforce: bool) -> bool`,
+	2709: `crdb_internal.unpin_plan(fingerprint: string) -> bool`,
+	2710: `workload_hypothetical_indexes(index_changes: string) -> tuple{string AS statement, int AS executions, string AS index_change, string AS impact}`,
+	2711: `workload_hypothetical_indexes(index_changes: string, timestamptz: timestamptz) -> tuple{string AS statement, int AS executions, string AS index_change, string AS impact}`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geomfn"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
@@ -102,6 +104,13 @@ func jsonGenPropsWithLabels(returnLabels []string) tree.FunctionProperties {
 	}
 }
 
+func spatialGenProps() tree.FunctionProperties {
+	return tree.FunctionProperties{
+		Category:                builtinconstants.CategorySpatial,
+		AvailableOnPublicSchema: true,
+	}
+}
+
 func recordGenProps() tree.FunctionProperties {
 	return tree.FunctionProperties{
 		Category:          builtinconstants.CategoryGenerator,
@@ -298,6 +307,29 @@ var generators = map[string]builtinDefinition{
 		),
 	),
 
+	"workload_hypothetical_indexes": makeBuiltin(genProps(),
+		makeGeneratorOverload(
+			tree.ParamTypes{{Name: "index_changes", Typ: types.String}},
+			hypotheticalIndexImpactsGeneratorType,
+			makeHypotheticalIndexImpactsGeneratorFactory(false /* hasTimestamp */),
+			"Returns the statements of the workload which are expected to be "+
+				"improved or regressed by the given CREATE INDEX and DROP INDEX statements",
+			volatility.Immutable,
+		),
+		makeGeneratorOverload(
+			tree.ParamTypes{
+				{Name: "index_changes", Typ: types.String},
+				{Name: "timestamptz", Typ: types.TimestampTZ},
+			},
+			hypotheticalIndexImpactsGeneratorType,
+			makeHypotheticalIndexImpactsGeneratorFactory(true /* hasTimestamp */),
+			"Returns the statements of the workload executed after the given time "+
+				"which are expected to be improved or regressed by the given CREATE "+
+				"INDEX and DROP INDEX statements",
+			volatility.Immutable,
+		),
+	),
+
 	"workload_index_recs": makeBuiltin(genProps(),
 		makeGeneratorOverload(
 			tree.ParamTypes{},
@@ -407,6 +439,39 @@ var generators = map[string]builtinDefinition{
 		),
 	),
 
+	"st_dump": makeBuiltin(spatialGenProps(),
+		makeGeneratorOverload(
+			tree.ParamTypes{{Name: "geometry", Typ: types.Geometry}},
+			geometryDumpGeneratorType,
+			makeGeometryDumpGeneratorFactory(geomfn.Dump),
+			"Returns a set of (path, geom) rows for each non-collection component of the given geometry. "+
+				"The path holds the 1-based index of the component within each enclosing collection, "+
+				"and is empty if the geometry is not a collection.",
+			volatility.Immutable,
+		),
+	),
+	"st_dumppoints": makeBuiltin(spatialGenProps(),
+		makeGeneratorOverload(
+			tree.ParamTypes{{Name: "geometry", Typ: types.Geometry}},
+			geometryDumpGeneratorType,
+			makeGeometryDumpGeneratorFactory(geomfn.DumpPoints),
+			"Returns a set of (path, geom) rows for each vertex of the given geometry. "+
+				"The path holds the 1-based index of the vertex's component within each enclosing collection, "+
+				"followed by the index of its ring for polygons, followed by the index of the vertex.",
+			volatility.Immutable,
+		),
+	),
+	"st_dumprings": makeBuiltin(spatialGenProps(),
+		makeGeneratorOverload(
+			tree.ParamTypes{{Name: "geometry", Typ: types.Geometry}},
+			geometryDumpGeneratorType,
+			makeGeometryDumpGeneratorFactory(geomfn.DumpRings),
+			"Returns a set of (path, geom) rows for each ring of the given polygon. "+
+				"The path of the exterior ring is {0}, and the path of the n-th interior ring is {n}.",
+			volatility.Immutable,
+		),
+	),
+
 	"json_array_elements":       makeBuiltin(jsonGenPropsWithLabels(jsonArrayGeneratorLabels), jsonArrayElementsImpl),
 	"jsonb_array_elements":      makeBuiltin(jsonGenPropsWithLabels(jsonArrayGeneratorLabels), jsonArrayElementsImpl),
 	"json_array_elements_text":  makeBuiltin(jsonGenPropsWithLabels(jsonArrayGeneratorLabels), jsonArrayElementsTextImpl),
@@ -1233,6 +1298,75 @@ func makeWorkloadIndexRecsGeneratorFactory(hasTimestamp bool) eval.GeneratorOver
 	}
 }
 
+var hypotheticalIndexImpactsGeneratorType = types.MakeLabeledTuple(
+	[]*types.T{types.String, types.Int, types.String, types.String},
+	[]string{"statement", "executions", "index_change", "impact"},
+)
+
+// makeHypotheticalIndexImpactsGeneratorFactory returns a generator of the
+// statements impacted by hypothetical index changes, as found by
+// workloadindexrec.FindHypotheticalIndexImpacts. The hasTimestamp represents
+// whether there is a timestamp filter.
+func makeHypotheticalIndexImpactsGeneratorFactory(hasTimestamp bool) eval.GeneratorOverload {
+	return func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (eval.ValueGenerator, error) {
+		changes := string(tree.MustBeDString(args[0]))
+		var ts tree.DTimestampTZ
+		if hasTimestamp {
+			ts = tree.MustBeDTimestampTZ(args[1])
+		} else {
+			ts = tree.DTimestampTZ{Time: tree.MinSupportedTime}
+		}
+
+		impacts, err := workloadindexrec.FindHypotheticalIndexImpacts(ctx, evalCtx, changes, &ts)
+		if err != nil {
+			return nil, err
+		}
+		return &hypotheticalIndexImpactsGenerator{impacts: impacts}, nil
+	}
+}
+
+// hypotheticalIndexImpactsGenerator supports the execution of
+// workload_hypothetical_indexes().
+type hypotheticalIndexImpactsGenerator struct {
+	impacts []workloadindexrec.HypotheticalIndexImpact
+	idx     int
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (*hypotheticalIndexImpactsGenerator) ResolvedType() *types.T {
+	return hypotheticalIndexImpactsGeneratorType
+}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *hypotheticalIndexImpactsGenerator) Start(_ context.Context, _ *kv.Txn) error {
+	g.idx = -1
+	return nil
+}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *hypotheticalIndexImpactsGenerator) Next(_ context.Context) (bool, error) {
+	g.idx++
+	return g.idx < len(g.impacts), nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *hypotheticalIndexImpactsGenerator) Values() (tree.Datums, error) {
+	impact := &g.impacts[g.idx]
+	impactStr := "regresses"
+	if impact.Improves {
+		impactStr = "improves"
+	}
+	return tree.Datums{
+		tree.NewDString(impact.Query),
+		tree.NewDInt(tree.DInt(impact.Executions)),
+		tree.NewDString(impact.IndexChange),
+		tree.NewDString(impactStr),
+	}, nil
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (*hypotheticalIndexImpactsGenerator) Close(_ context.Context) {}
+
 func makeArrayGenerator(
 	_ context.Context, _ *eval.Context, args tree.Datums,
 ) (eval.ValueGenerator, error) {
@@ -3729,3 +3863,60 @@ func (qi *internallyExecutedQueryIterator) Close(context.Context) {
 func (qi *internallyExecutedQueryIterator) ResolvedType() *types.T {
 	return internallyExecutedQueryGeneratorType
 }
+
+var geometryDumpGeneratorType = types.MakeLabeledTuple(
+	[]*types.T{types.IntArray, types.Geometry},
+	[]string{"path", "geom"},
+)
+
+// makeGeometryDumpGeneratorFactory returns a generator that returns the
+// result of dumpFn applied to its argument.
+func makeGeometryDumpGeneratorFactory(
+	dumpFn func(geo.Geometry) ([]geomfn.GeometryDump, error),
+) eval.GeneratorOverload {
+	return func(_ context.Context, _ *eval.Context, args tree.Datums) (eval.ValueGenerator, error) {
+		g := tree.MustBeDGeometry(args[0])
+		dumps, err := dumpFn(g.Geometry)
+		if err != nil {
+			return nil, err
+		}
+		return &geometryDumpGenerator{dumps: dumps, curr: -1}, nil
+	}
+}
+
+// geometryDumpGenerator implements the eval.ValueGenerator interface for
+// st_dump, st_dumppoints and st_dumprings.
+type geometryDumpGenerator struct {
+	dumps []geomfn.GeometryDump
+	curr  int
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *geometryDumpGenerator) ResolvedType() *types.T { return geometryDumpGeneratorType }
+
+// Start implements the eval.ValueGenerator interface.
+func (g *geometryDumpGenerator) Start(_ context.Context, _ *kv.Txn) error {
+	g.curr = -1
+	return nil
+}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *geometryDumpGenerator) Next(_ context.Context) (bool, error) {
+	g.curr++
+	return g.curr < len(g.dumps), nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *geometryDumpGenerator) Values() (tree.Datums, error) {
+	d := g.dumps[g.curr]
+	path := tree.NewDArray(types.Int)
+	for _, idx := range d.Path {
+		if err := path.Append(tree.NewDInt(tree.DInt(idx))); err != nil {
+			return nil, err
+		}
+	}
+	return tree.Datums{path, tree.NewDGeometry(d.Geom)}, nil
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (g *geometryDumpGenerator) Close(_ context.Context) {}
@@ -69,6 +69,10 @@ const (
 	defaultWKTDecimalDigits = 15
 	// defaultGeoJSONDecimalDigits is the default number of digits coordinates for builtins in GeoJSON.
 	defaultGeoJSONDecimalDigits = 9
+	// defaultGMLDecimalDigits is the default number of digits coordinates for builtins in GML.
+	defaultGMLDecimalDigits = 15
+	// defaultSVGDecimalDigits is the default number of digits coordinates for builtins in SVG.
+	defaultSVGDecimalDigits = 15
 )
 
 // infoBuilder is used to build a detailed info string that is consistent between
@@ -154,7 +158,7 @@ func geometryFromTextCheckShapeBuiltin(shapeType geopb.ShapeType) builtinDefinit
 			volatility.Immutable,
 		),
 		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "str", Typ: types.String}, {Name: "srid", Typ: types.Int}},
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.String}, {Name: "srid", Typ: types.Int}},
 			ReturnType: tree.FixedReturnType(types.Geometry),
 			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
 				s := string(tree.MustBeDString(args[0]))
@@ -378,6 +382,356 @@ func fitMaxDecimalDigitsToBounds(maxDecimalDigits int) int {
 	return maxDecimalDigits
 }
 
+const gmlOptionsInfo = `
+
+Options is a flag that can be bitmasked. The options are:
+* 0: GML Short CRS (e.g EPSG:4326) (default)
+* 1: GML Long CRS (e.g urn:ogc:def:crs:EPSG::4326)
+* 2: GML 3 only, omit the srsDimension attribute
+* 4: GML 3 only, use LineString rather than Curve elements for lines
+* 16: GML 3 only, declare that the data is lat/lng, writing Y before X
+* 32: output the bounding box of the object
+`
+
+// stAsGMLOverloads returns the ST_AsGML overloads for a spatial type, which
+// optionally take a leading GML version and trailing max_decimal_digits,
+// options and nprefix arguments. nprefix may only be given with a version.
+func stAsGMLOverloads(typ *types.T, typName string) []tree.Overload {
+	var ret []tree.Overload
+	for _, hasVersion := range []bool{false, true} {
+		maxNumOptionalArgs := 2
+		if hasVersion {
+			maxNumOptionalArgs = 3
+		}
+		for numOptionalArgs := 0; numOptionalArgs <= maxNumOptionalArgs; numOptionalArgs++ {
+			hasVersion, numOptionalArgs := hasVersion, numOptionalArgs
+			var paramTypes tree.ParamTypes
+			info := fmt.Sprintf("Returns the GML representation of a given %s.", typName)
+			if hasVersion {
+				paramTypes = append(paramTypes, tree.ParamType{Name: "version", Typ: types.Int})
+				info += " version is the GML version to output, which must be 2 or 3."
+			} else {
+				info += " GML 2 is output."
+			}
+			paramTypes = append(paramTypes, tree.ParamType{Name: strings.ToLower(typName), Typ: typ})
+			if numOptionalArgs >= 1 {
+				paramTypes = append(paramTypes, tree.ParamType{Name: "max_decimal_digits", Typ: types.Int})
+				info += " max_decimal_digits will be output for each coordinate value."
+			} else {
+				info += fmt.Sprintf(" Coordinates have a maximum of %d decimal digits.", defaultGMLDecimalDigits)
+			}
+			if numOptionalArgs >= 3 {
+				paramTypes = append(paramTypes, tree.ParamType{Name: "options", Typ: types.Int})
+				paramTypes = append(paramTypes, tree.ParamType{Name: "nprefix", Typ: types.String})
+				info += " Elements are prefixed by the namespace prefix nprefix, which may be empty."
+				info += gmlOptionsInfo
+			} else if numOptionalArgs >= 2 {
+				paramTypes = append(paramTypes, tree.ParamType{Name: "options", Typ: types.Int})
+				info += gmlOptionsInfo
+			}
+			ret = append(ret, tree.Overload{
+				Types:      paramTypes,
+				ReturnType: tree.FixedReturnType(types.String),
+				Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+					version := 2
+					if hasVersion {
+						version = int(tree.MustBeDInt(args[0]))
+						args = args[1:]
+					}
+					var so geopb.SpatialObject
+					switch g := args[0].(type) {
+					case *tree.DGeometry:
+						so = g.Geometry.SpatialObject()
+					case *tree.DGeography:
+						so = g.Geography.SpatialObject()
+					default:
+						return nil, errors.AssertionFailedf("unexpected type: %T", g)
+					}
+					maxDecimalDigits := defaultGMLDecimalDigits
+					if numOptionalArgs >= 1 {
+						maxDecimalDigits = fitMaxDecimalDigitsToBounds(int(tree.MustBeDInt(args[1])))
+					}
+					flag := geo.SpatialObjectToGMLFlag(geo.SpatialObjectToGMLFlagZero)
+					if numOptionalArgs >= 2 {
+						flag = geo.SpatialObjectToGMLFlag(tree.MustBeDInt(args[2]))
+					}
+					prefix := geo.DefaultGMLPrefix
+					if numOptionalArgs >= 3 {
+						prefix = string(tree.MustBeDString(args[3]))
+					}
+					gml, err := geo.SpatialObjectToGML(so, version, maxDecimalDigits, flag, prefix)
+					if err != nil {
+						return nil, err
+					}
+					return tree.NewDString(gml), nil
+				},
+				Info:       infoBuilder{info: info}.String(),
+				Volatility: volatility.Immutable,
+			})
+		}
+	}
+	return ret
+}
+
+// stAsSVGOverloads returns the ST_AsSVG overloads for a spatial type, which
+// take optional trailing rel and max_decimal_digits arguments.
+func stAsSVGOverloads(typ *types.T, typName string) []tree.Overload {
+	var ret []tree.Overload
+	for numOptionalArgs := 0; numOptionalArgs <= 2; numOptionalArgs++ {
+		numOptionalArgs := numOptionalArgs
+		paramTypes := tree.ParamTypes{{Name: strings.ToLower(typName), Typ: typ}}
+		info := fmt.Sprintf(
+			"Returns the SVG path data of a given %s. Points are output as the cx and cy attributes of a circle, "+
+				"and all other shapes as the d attribute of a path. Y coordinates are negated as the Y axis points down in SVG.",
+			typName,
+		)
+		if numOptionalArgs >= 1 {
+			paramTypes = append(paramTypes, tree.ParamType{Name: "rel", Typ: types.Int})
+			info += " If rel is 1, path data is output as relative moves, and points as x and y attributes."
+		}
+		if numOptionalArgs >= 2 {
+			paramTypes = append(paramTypes, tree.ParamType{Name: "max_decimal_digits", Typ: types.Int})
+			info += " max_decimal_digits will be output for each coordinate value."
+		} else {
+			info += fmt.Sprintf(" Coordinates have a maximum of %d decimal digits.", defaultSVGDecimalDigits)
+		}
+		ret = append(ret, tree.Overload{
+			Types:      paramTypes,
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				var so geopb.SpatialObject
+				switch g := args[0].(type) {
+				case *tree.DGeometry:
+					so = g.Geometry.SpatialObject()
+				case *tree.DGeography:
+					so = g.Geography.SpatialObject()
+				default:
+					return nil, errors.AssertionFailedf("unexpected type: %T", g)
+				}
+				relative := false
+				if numOptionalArgs >= 1 {
+					relative = tree.MustBeDInt(args[1]) != 0
+				}
+				maxDecimalDigits := defaultSVGDecimalDigits
+				if numOptionalArgs >= 2 {
+					maxDecimalDigits = fitMaxDecimalDigitsToBounds(int(tree.MustBeDInt(args[2])))
+				}
+				svg, err := geo.SpatialObjectToSVG(so, relative, maxDecimalDigits)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDString(svg), nil
+			},
+			Info:       infoBuilder{info: info}.String(),
+			Volatility: volatility.Immutable,
+		})
+	}
+	return ret
+}
+
+// lengthSpheroidOverload returns the overload of st_lengthspheroid or
+// st_length2dspheroid.
+func lengthSpheroidOverload(use3D bool, info string) tree.Overload {
+	return tree.Overload{
+		Types: tree.ParamTypes{
+			{Name: "geometry", Typ: types.Geometry},
+			{Name: "spheroid", Typ: types.String},
+		},
+		ReturnType: tree.FixedReturnType(types.Float),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			g := tree.MustBeDGeometry(args[0])
+			spheroid, err := geomfn.ParseSpheroid(string(tree.MustBeDString(args[1])))
+			if err != nil {
+				return nil, err
+			}
+			ret, err := geomfn.LengthSpheroid(g.Geometry, spheroid, use3D)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDFloat(tree.DFloat(ret)), nil
+		},
+		Info: infoBuilder{
+			info: info + ` Coordinates are taken to be longitudes and latitudes in degrees, and polygons contribute their perimeter.
+
+The spheroid is given in the form SPHEROID["name",semi-major axis,inverse flattening], e.g. SPHEROID["GRS_1980",6378137,298.257222101].`,
+			libraryUsage: usesGeographicLib,
+		}.String(),
+		Volatility: volatility.Immutable,
+	}
+}
+
+// geometricMedianOverloads returns the overloads of st_geometricmedian, each
+// taking one more optional argument than the last.
+func geometricMedianOverloads() []tree.Overload {
+	params := tree.ParamTypes{
+		{Name: "geometry", Typ: types.Geometry},
+		{Name: "tolerance", Typ: types.Float},
+		{Name: "max_iter", Typ: types.Int},
+		{Name: "fail_if_not_converged", Typ: types.Bool},
+	}
+	overloads := make([]tree.Overload, 0, len(params))
+	for numParams := 1; numParams <= len(params); numParams++ {
+		overloads = append(overloads, tree.Overload{
+			Types:      params[:numParams],
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				tolerance := geomfn.DefaultGeometricMedianTolerance(g.Geometry)
+				if len(args) > 1 {
+					tolerance = float64(tree.MustBeDFloat(args[1]))
+				}
+				maxIterations := geomfn.DefaultGeometricMedianMaxIterations
+				if len(args) > 2 {
+					maxIterations = int(tree.MustBeDInt(args[2]))
+				}
+				failIfNotConverged := false
+				if len(args) > 3 {
+					failIfNotConverged = bool(tree.MustBeDBool(args[3]))
+				}
+				ret, err := geomfn.GeometricMedian(g.Geometry, tolerance, maxIterations, failIfNotConverged)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: fmt.Sprintf(`Returns the geometric median of the given Point or MultiPoint, which is the point minimizing the sum of distances to its points. If the input has M coordinates, they are used as weights. The median is computed in 3D if the input has Z coordinates.
+
+Iteration stops once the median moves less than tolerance, which defaults to a value scaled by the magnitude of the coordinates, or after max_iter iterations, which defaults to %d. If fail_if_not_converged is true, an error is returned if the median has not converged by then.`,
+					geomfn.DefaultGeometricMedianMaxIterations,
+				),
+			}.String(),
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
+// setEffectiveAreaOverloads returns the overloads of st_seteffectivearea.
+func setEffectiveAreaOverloads() []tree.Overload {
+	params := tree.ParamTypes{
+		{Name: "geometry", Typ: types.Geometry},
+		{Name: "threshold", Typ: types.Float},
+		{Name: "set_area", Typ: types.Int},
+	}
+	overloads := make([]tree.Overload, 0, len(params))
+	for numParams := 1; numParams <= len(params); numParams++ {
+		overloads = append(overloads, tree.Overload{
+			Types:      params[:numParams],
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				threshold := 0.0
+				if len(args) > 1 {
+					threshold = float64(tree.MustBeDFloat(args[1]))
+				}
+				setArea := true
+				if len(args) > 2 {
+					setArea = tree.MustBeDInt(args[2]) != 0
+				}
+				var ret geo.Geometry
+				var err error
+				if setArea {
+					ret, err = geomfn.SetEffectiveArea(g.Geometry, threshold)
+				} else {
+					ret, err = geomfn.SimplifyVW(g.Geometry, threshold)
+				}
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: `Sets the M coordinate of each vertex of the given geometry to its effective area as computed by the Visvalingam-Whyatt algorithm. End points, which are never removed, have an effective area of the largest float4.
+
+Vertices with an effective area less than threshold, which defaults to 0, are removed. If set_area is 0, M coordinates are left as is.`,
+			}.String(),
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
+// chaikinSmoothingOverloads returns the overloads of st_chaikinsmoothing.
+func chaikinSmoothingOverloads() []tree.Overload {
+	params := tree.ParamTypes{
+		{Name: "geometry", Typ: types.Geometry},
+		{Name: "n_iterations", Typ: types.Int},
+		{Name: "preserve_end_points", Typ: types.Bool},
+	}
+	overloads := make([]tree.Overload, 0, len(params))
+	for numParams := 1; numParams <= len(params); numParams++ {
+		overloads = append(overloads, tree.Overload{
+			Types:      params[:numParams],
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				numIterations := 1
+				if len(args) > 1 {
+					numIterations = int(tree.MustBeDInt(args[1]))
+				}
+				preserveEndPoints := false
+				if len(args) > 2 {
+					preserveEndPoints = bool(tree.MustBeDBool(args[2]))
+				}
+				ret, err := geomfn.ChaikinSmoothing(g.Geometry, numIterations, preserveEndPoints)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: `Smooths the given geometry using Chaikin's algorithm, which replaces each segment with points a quarter and three quarters along it. Each of n_iterations, which defaults to 1 and is at most 5, doubles the number of points.
+
+If preserve_end_points is true, the end points of linestrings are kept in place. Polygon rings are always kept closed.`,
+			}.String(),
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
+// quantizeCoordinatesOverloads returns the overloads of
+// st_quantizecoordinates.
+func quantizeCoordinatesOverloads() []tree.Overload {
+	params := tree.ParamTypes{
+		{Name: "geometry", Typ: types.Geometry},
+		{Name: "prec_x", Typ: types.Int},
+		{Name: "prec_y", Typ: types.Int},
+		{Name: "prec_z", Typ: types.Int},
+		{Name: "prec_m", Typ: types.Int},
+	}
+	overloads := make([]tree.Overload, 0, len(params)-1)
+	for numParams := 2; numParams <= len(params); numParams++ {
+		overloads = append(overloads, tree.Overload{
+			Types:      params[:numParams],
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				var precision [4]int
+				for i := range precision {
+					// Precisions which are not given default to prec_x.
+					if i+1 < len(args) {
+						precision[i] = int(tree.MustBeDInt(args[i+1]))
+					} else {
+						precision[i] = precision[0]
+					}
+				}
+				ret, err := geomfn.QuantizeCoordinates(g.Geometry, precision)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: `Zeroes out the bits of each coordinate of the given geometry which are not needed to represent the given number of digits after the decimal point, so the geometry compresses better. The precision of each ordinate which is not given defaults to prec_x.`,
+			}.String(),
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
 func makeMinimumBoundGenerator(
 	_ context.Context, _ *eval.Context, args tree.Datums,
 ) (eval.ValueGenerator, error) {
@@ -428,6 +782,66 @@ func (m *minimumBoundRadiusGen) Values() (tree.Datums, error) {
 
 func (m *minimumBoundRadiusGen) Close(_ context.Context) {}
 
+var isValidDetailReturnType = types.MakeLabeledTuple(
+	[]*types.T{types.Bool, types.String, types.Geometry},
+	[]string{"valid", "reason", "location"},
+)
+
+func makeIsValidDetailGenerator(
+	_ context.Context, _ *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	g := tree.MustBeDGeometry(args[0])
+	flags := 0
+	if len(args) > 1 {
+		flags = int(tree.MustBeDInt(args[1]))
+	}
+	validDetail, err := geomfn.IsValidDetail(g.Geometry, flags)
+	if err != nil {
+		return nil, err
+	}
+	return &isValidDetailGen{validDetail: validDetail, next: true}, nil
+}
+
+// isValidDetailGen returns a single row describing the validity of a
+// geometry. The reason and location are NULL for valid geometries.
+type isValidDetailGen struct {
+	validDetail geomfn.ValidDetail
+	next        bool
+}
+
+func (v *isValidDetailGen) ResolvedType() *types.T {
+	return isValidDetailReturnType
+}
+
+func (v *isValidDetailGen) Start(ctx context.Context, txn *kv.Txn) error {
+	return nil
+}
+
+func (v *isValidDetailGen) Next(ctx context.Context) (bool, error) {
+	if v.next {
+		v.next = false
+		return true, nil
+	}
+	return false, nil
+}
+
+func (v *isValidDetailGen) Values() (tree.Datums, error) {
+	if v.validDetail.IsValid {
+		return tree.Datums{tree.DBoolTrue, tree.DNull, tree.DNull}, nil
+	}
+	var location tree.Datum = tree.DNull
+	if len(v.validDetail.InvalidLocation.EWKB()) > 0 {
+		location = tree.NewDGeometry(v.validDetail.InvalidLocation)
+	}
+	return tree.Datums{
+		tree.DBoolFalse,
+		tree.NewDString(v.validDetail.Reason),
+		location,
+	}, nil
+}
+
+func (v *isValidDetailGen) Close(_ context.Context) {}
+
 func makeSubdividedGeometriesGeneratorFactory(expectMaxVerticesArg bool) eval.GeneratorOverload {
 	return func(
 		_ context.Context, _ *eval.Context, args tree.Datums,
@@ -692,7 +1106,7 @@ var geoBuiltins = map[string]builtinDefinition{
 		defProps(),
 		geomFromWKTOverload,
 		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "str", Typ: types.String}, {Name: "srid", Typ: types.Int}},
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.String}, {Name: "srid", Typ: types.Int}},
 			ReturnType: tree.FixedReturnType(types.Geometry),
 			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
 				s := string(tree.MustBeDString(args[0]))
@@ -762,6 +1176,59 @@ var geoBuiltins = map[string]builtinDefinition{
 			volatility.Immutable,
 		),
 	),
+	"st_geomfromgml": makeBuiltin(
+		defProps(),
+		stringOverload1(
+			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+				g, err := geo.ParseGeometryFromGML([]byte(s))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(g), nil
+			},
+			types.Geometry,
+			infoBuilder{
+				info: "Returns the Geometry from a GML 2 or GML 3 representation. The SRID is taken from the srsName of the GML.",
+			}.String(),
+			volatility.Immutable,
+		),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.String}, {Name: "srid", Typ: types.Int}},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				s := string(tree.MustBeDString(args[0]))
+				srid := geopb.SRID(tree.MustBeDInt(args[1]))
+				g, err := geo.ParseGeometryFromGMLAndSRID([]byte(s), srid)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(g), nil
+			},
+			Info: infoBuilder{
+				info: `Returns the Geometry from a GML 2 or GML 3 representation with the given SRID set, ignoring the srsName of the GML.`,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
+	"st_geomfromtwkb": makeBuiltin(
+		defProps(),
+		bytesOverload1(
+			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+				t, err := twkb.Unmarshal([]byte(s))
+				if err != nil {
+					return nil, err
+				}
+				g, err := geo.MakeGeometryFromGeomT(t)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(g), nil
+			},
+			types.Geometry,
+			infoBuilder{info: "Returns the Geometry from a TWKB representation."}.String(),
+			volatility.Immutable,
+		),
+	),
 	"st_geomfromgeojson": makeBuiltin(
 		defProps(),
 		tree.Overload{
@@ -989,7 +1456,7 @@ var geoBuiltins = map[string]builtinDefinition{
 			volatility.Immutable,
 		),
 		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "str", Typ: types.String}, {Name: "srid", Typ: types.Int}},
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.String}, {Name: "srid", Typ: types.Int}},
 			ReturnType: tree.FixedReturnType(types.Geography),
 			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
 				s := string(tree.MustBeDString(args[0]))
@@ -1698,6 +2165,20 @@ var geoBuiltins = map[string]builtinDefinition{
 			volatility.Immutable,
 		),
 	),
+	"st_asgml": makeBuiltin(
+		defProps(),
+		append(
+			stAsGMLOverloads(types.Geometry, "Geometry"),
+			stAsGMLOverloads(types.Geography, "Geography")...,
+		)...,
+	),
+	"st_assvg": makeBuiltin(
+		defProps(),
+		append(
+			stAsSVGOverloads(types.Geometry, "Geometry"),
+			stAsSVGOverloads(types.Geography, "Geography")...,
+		)...,
+	),
 	"st_geohash": makeBuiltin(
 		defProps(),
 		geometryOverload1(
@@ -3131,6 +3612,20 @@ The requested number of points must be not larger than 65336.`,
 		defProps(),
 		lengthOverloadGeometry1,
 	),
+	"st_lengthspheroid": makeBuiltin(
+		defProps(),
+		lengthSpheroidOverload(
+			true, /* use3D */
+			"Returns the length of the given geometry on the given spheroid in meters, taking Z coordinates into account as heights in meters.",
+		),
+	),
+	"st_length2dspheroid": makeBuiltin(
+		defProps(),
+		lengthSpheroidOverload(
+			false, /* use3D */
+			"Returns the length of the given geometry on the given spheroid in meters, ignoring Z coordinates.",
+		),
+	),
 	"st_perimeter": makeBuiltin(
 		defProps(),
 		append(
@@ -4187,6 +4682,37 @@ For flags=1, validity considers self-intersecting rings forming holes as valid a
 
 For flags=0, validity is defined by the OGC spec.
 
+For flags=1, validity considers self-intersecting rings forming holes as valid as per ESRI. This is not valid under OGC and CRDB spatial operations may not operate correctly.`,
+				libraryUsage: usesGEOS,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
+	"st_isvaliddetail": makeBuiltin(genProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "geometry", Typ: types.Geometry}},
+			ReturnType: tree.FixedReturnType(isValidDetailReturnType),
+			Generator:  eval.GeneratorOverload(makeIsValidDetailGenerator),
+			Class:      tree.GeneratorClass,
+			Info: infoBuilder{
+				info:         `Returns a record containing whether the geometry is valid, and if not, the reason it is invalid and the location of the problem. Validity is defined by the OGC spec.`,
+				libraryUsage: usesGEOS,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "flags", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(isValidDetailReturnType),
+			Generator:  eval.GeneratorOverload(makeIsValidDetailGenerator),
+			Class:      tree.GeneratorClass,
+			Info: infoBuilder{
+				info: `Returns a record containing whether the geometry is valid, and if not, the reason it is invalid and the location of the problem.
+
+For flags=0, validity is defined by the OGC spec.
+
 For flags=1, validity considers self-intersecting rings forming holes as valid as per ESRI. This is not valid under OGC and CRDB spatial operations may not operate correctly.`,
 				libraryUsage: usesGEOS,
 			}.String(),
@@ -4253,6 +4779,10 @@ Note the geometry must be a LineString with M coordinates.`,
 			volatility.Immutable,
 		),
 	),
+	"st_geometricmedian": makeBuiltin(
+		defProps(),
+		geometricMedianOverloads()...,
+	),
 	"st_centroid": makeBuiltin(
 		defProps(),
 		append(
@@ -4325,6 +4855,75 @@ Note the geometry must be a LineString with M coordinates.`,
 			volatility.Immutable,
 		),
 	),
+	"st_concavehull": makeBuiltin(
+		defProps(),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "target_percent", Typ: types.Float},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				targetPercent := tree.MustBeDFloat(args[1])
+				ret, err := geomfn.ConcaveHull(g.Geometry, float64(targetPercent), false /* allowHoles */)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: "Returns a possibly concave geometry which encloses all the vertices of the given geometry. " +
+					"target_percent ranges from 0, which produces the most concave hull, to 1, which produces the convex hull.",
+				libraryUsage: usesGEOS,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "target_percent", Typ: types.Float},
+				{Name: "allow_holes", Typ: types.Bool},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				targetPercent := tree.MustBeDFloat(args[1])
+				allowHoles := tree.MustBeDBool(args[2])
+				ret, err := geomfn.ConcaveHull(g.Geometry, float64(targetPercent), bool(allowHoles))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: "Returns a possibly concave geometry which encloses all the vertices of the given geometry. " +
+					"target_percent ranges from 0, which produces the most concave hull, to 1, which produces the convex hull. " +
+					"If allow_holes is true, the result may contain holes.",
+				libraryUsage: usesGEOS,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
+	"st_buildarea": makeBuiltin(
+		defProps(),
+		geometryOverload1(
+			func(_ context.Context, _ *eval.Context, g *tree.DGeometry) (tree.Datum, error) {
+				ret, err := geomfn.BuildArea(g.Geometry)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			types.Geometry,
+			infoBuilder{
+				info: "Returns an areal geometry formed by the constituent linework of the given geometry. " +
+					"Rings which are nested in an odd number of other rings become holes.",
+				libraryUsage: usesGEOS,
+			},
+			volatility.Immutable,
+		),
+	),
 	"st_difference": makeBuiltin(
 		defProps(),
 		geometryOverload2(
@@ -4589,6 +5188,39 @@ The paths themselves are given in the direction of the first geometry.`,
 			Volatility: volatility.Immutable,
 		},
 	),
+	"st_simplifyvw": makeBuiltin(
+		defProps(),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "tolerance", Typ: types.Float},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				tolerance := float64(tree.MustBeDFloat(args[1]))
+				ret, err := geomfn.SimplifyVW(g.Geometry, tolerance)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: `Simplifies the given geometry using the Visvalingam-Whyatt algorithm, removing vertices whose effective area is less than the given tolerance.
+
+Linestrings keep at least 2 points and polygon exterior rings keep at least 4 points. Interior rings which collapse are removed.`,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
+	"st_seteffectivearea": makeBuiltin(
+		defProps(),
+		setEffectiveAreaOverloads()...,
+	),
+	"st_chaikinsmoothing": makeBuiltin(
+		defProps(),
+		chaikinSmoothingOverloads()...,
+	),
 
 	//
 	// Transformations
@@ -5452,6 +6084,10 @@ The calculations are done on a sphere.`,
 			Volatility: volatility.Immutable,
 		},
 	),
+	"st_quantizecoordinates": makeBuiltin(
+		defProps(),
+		quantizeCoordinatesOverloads()...,
+	),
 	"st_snaptogrid": makeBuiltin(
 		defProps(),
 		tree.Overload{
@@ -5596,6 +6232,31 @@ If no snapping occurs then the input geometry is returned unchanged.`,
 			Volatility: volatility.Immutable,
 		},
 	),
+	"st_split": makeBuiltin(
+		defProps(),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "input", Typ: types.Geometry},
+				{Name: "blade", Typ: types.Geometry},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				blade := tree.MustBeDGeometry(args[1])
+				ret, err := geomfn.Split(g.Geometry, blade.Geometry)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: `Returns a GeometryCollection of the parts of the input geometry split by the blade geometry.
+LineStrings can be split by points, linestrings or polygons, and Polygons can be split by linestrings.`,
+				libraryUsage: usesGEOS,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
 	"st_buffer": makeBuiltin(
 		defProps(),
 		tree.Overload{
@@ -7117,7 +7778,7 @@ Note that the top vertex of the segment touching another line does not count as
 	"st_bdpolyfromtext": makeBuiltin(
 		defProps(),
 		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "str", Typ: types.String}, {Name: "srid", Typ: types.Int}},
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.String}, {Name: "srid", Typ: types.Int}},
 			ReturnType: tree.FixedReturnType(types.Geometry),
 			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
 				s := string(tree.MustBeDString(args[0]))
@@ -7271,36 +7932,13 @@ Can be used to define the tile bounds required by ST_AsMVTGeom to convert geomet
 	// Unimplemented.
 	//
 
-	"st_asgml":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48877}),
 	"st_aslatlontext":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48882}),
-	"st_assvg":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48883}),
 	"st_boundingdiagonal":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48889}),
-	"st_buildarea":           makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48892}),
-	"st_chaikinsmoothing":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48894}),
 	"st_cleangeometry":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48895}),
-	"st_clusterdbscan":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48898}),
 	"st_clusterintersecting": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48899}),
-	"st_clusterkmeans":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48900}),
-	"st_clusterwithin":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48901}),
-	"st_concavehull":         makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48906}),
 	"st_delaunaytriangles":   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48915}),
-	"st_dump":                makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49785}),
-	"st_dumppoints":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49786}),
-	"st_dumprings":           makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49787}),
-	"st_geometricmedian":     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48944}),
 	"st_interpolatepoint":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48950}),
-	"st_isvaliddetail":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48962}),
-	"st_length2dspheroid":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48967}),
-	"st_lengthspheroid":      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48968}),
-	"st_polygonize":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49011}),
-	"st_quantizecoordinates": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49012}),
-	"st_seteffectivearea":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49030}),
-	"st_simplifyvw":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49039}),
-	"st_split":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49045}),
 	"st_wrapx":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49068}),
-	"st_geomfromgml":         makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48807}),
-	"st_geomfromtwkb":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48809}),
-	"st_gmltosql":            makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48810}),
 }
 
 var compatFixedStringInfo = infoBuilder{
@@ -7537,6 +8175,7 @@ func init() {
 		{"st_numinteriorring", "st_numinteriorrings"},
 		{"st_symmetricdifference", "st_symdifference"},
 		{"st_force3d", "st_force3dz"},
+		{"st_geomfromgml", "st_gmltosql"},
 	} {
 		if _, ok := geoBuiltins[alias.builtinName]; !ok {
 			panic("expected builtin definition for alias: " + alias.builtinName)
@@ -7577,11 +8216,9 @@ func init() {
 		"st_area",
 		"st_asewkt",
 		"st_asgeojson",
-		// TODO(#48877): uncomment
-		// "st_asgml",
+		"st_asgml",
 		"st_askml",
-		// TODO(#48883): uncomment
-		// "st_assvg",
+		"st_assvg",
 		// TODO(#48886): uncomment
 		// "st_astwkb",
 		"st_astext",
@@ -7589,8 +8226,6 @@ func init() {
 		"st_centroid",
 		// TODO(#48899): uncomment
 		// "st_clusterintersecting",
-		// TODO(#48901): uncomment
-		// "st_clusterwithin",
 		"st_coveredby",
 		"st_covers",
 		"st_distance",
@@ -12,6 +12,7 @@ package builtins
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -70,8 +71,75 @@ var trigramBuiltins = map[string]builtinDefinition{
 			Volatility: volatility.Immutable,
 		},
 	),
-	"word_similarity":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 41285, Category: builtinconstants.CategoryTrigram}),
-	"strict_word_similarity": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 41285, Category: builtinconstants.CategoryTrigram}),
-	"show_limit":             makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 41285, Category: builtinconstants.CategoryTrigram}),
-	"set_limit":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 41285, Category: builtinconstants.CategoryTrigram}),
+	"word_similarity": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryTrigram},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: types.String}, {Name: "right", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1]))
+				f := trigram.WordSimilarity(l, r)
+				return tree.NewDFloat(tree.DFloat(f)), nil
+			},
+			Info: "Returns a number that indicates the greatest similarity between the" +
+				" set of trigrams in the first string and any continuous extent of an" +
+				" ordered set of trigrams in the second string. The range of the result" +
+				" is zero (indicating that no trigrams are shared) to one (indicating that" +
+				" all trigrams of the first string appear in an extent of the second).",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"strict_word_similarity": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryTrigram},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: types.String}, {Name: "right", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1]))
+				f := trigram.StrictWordSimilarity(l, r)
+				return tree.NewDFloat(tree.DFloat(f)), nil
+			},
+			Info: "Same as word_similarity, but forces extent boundaries to match word" +
+				" boundaries. Since there are no cross-word trigrams, this returns the" +
+				" greatest similarity between the first string and any continuous sequence" +
+				" of words of the second string.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"show_limit": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryTrigram},
+		tree.Overload{
+			Types:      tree.ParamTypes{},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, evalCtx *eval.Context, _ tree.Datums) (tree.Datum, error) {
+				return tree.NewDFloat(tree.DFloat(evalCtx.SessionData().TrigramSimilarityThreshold)), nil
+			},
+			Info: "Returns the current similarity threshold used by the % operator. This is" +
+				" the value of pg_trgm.similarity_threshold.",
+			Volatility: volatility.Stable,
+		},
+	),
+	"set_limit": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategoryTrigram,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "threshold", Typ: types.Float4}},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				f := float64(tree.MustBeDFloat(args[0]))
+				if err := setSessionVar(
+					ctx, evalCtx, "pg_trgm.similarity_threshold",
+					strconv.FormatFloat(f, 'g', -1, 64), false, /* isLocal */
+				); err != nil {
+					return nil, err
+				}
+				return tree.NewDFloat(tree.DFloat(f)), nil
+			},
+			Info: "Sets the current similarity threshold that is used by the % operator and" +
+				" returns it. This is equivalent to setting pg_trgm.similarity_threshold.",
+			Volatility: volatility.Volatile,
+		},
+	),
 }
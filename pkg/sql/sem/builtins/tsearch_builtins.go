@@ -12,8 +12,10 @@ package builtins
 
 import (
 	"context"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
@@ -21,7 +23,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/errors"
 )
 
 func init() {
@@ -98,7 +102,10 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			ReturnType: tree.FixedReturnType(types.TSVector),
 			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
 				// Parse, stem, and stopword the input.
-				config := string(tree.MustBeDString(args[0]))
+				config, err := textSearchConfig(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				document := string(tree.MustBeDString(args[1]))
 				vector, err := tsearch.DocumentToTSVector(config, document)
 				if err != nil {
@@ -113,8 +120,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSVector),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := defaultTextSearchConfig(ctx, evalCtx)
+				if err != nil {
+					return nil, err
+				}
 				document := string(tree.MustBeDString(args[0]))
 				vector, err := tsearch.DocumentToTSVector(config, document)
 				if err != nil {
@@ -124,7 +134,8 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			},
 			Info: "Converts text to a tsvector, normalizing words according to the default configuration. " +
 				"Position information is included in the result.",
-			Volatility: volatility.Stable,
+			Volatility:       volatility.Stable,
+			DistsqlBlocklist: true,
 		},
 	),
 	"to_tsquery": makeBuiltin(
@@ -133,7 +144,10 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
 			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+				config, err := textSearchConfig(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.ToTSQuery(config, input)
 				if err != nil {
@@ -149,8 +163,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := defaultTextSearchConfig(ctx, evalCtx)
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.ToTSQuery(config, input)
 				if err != nil {
@@ -161,7 +178,8 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			Info: "Converts the input text into a tsquery by normalizing each word in the input according to " +
 				"the default configuration. The input must already be formatted like a tsquery, in other words, " +
 				"subsequent tokens must be connected by a tsquery operator (&, |, <->, !).",
-			Volatility: volatility.Stable,
+			Volatility:       volatility.Stable,
+			DistsqlBlocklist: true,
 		},
 	),
 	"plainto_tsquery": makeBuiltin(
@@ -170,7 +188,10 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
 			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+				config, err := textSearchConfig(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.PlainToTSQuery(config, input)
 				if err != nil {
@@ -185,8 +206,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := defaultTextSearchConfig(ctx, evalCtx)
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.PlainToTSQuery(config, input)
 				if err != nil {
@@ -196,7 +220,8 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			},
 			Info: "Converts text to a tsquery, normalizing words according to the default configuration." +
 				" The & operator is inserted between each token in the input.",
-			Volatility: volatility.Stable,
+			Volatility:       volatility.Stable,
+			DistsqlBlocklist: true,
 		},
 	),
 	"phraseto_tsquery": makeBuiltin(
@@ -205,7 +230,10 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
 			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+				config, err := textSearchConfig(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.PhraseToTSQuery(config, input)
 				if err != nil {
@@ -220,8 +248,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := defaultTextSearchConfig(ctx, evalCtx)
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.PhraseToTSQuery(config, input)
 				if err != nil {
@@ -231,7 +262,8 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			},
 			Info: "Converts text to a tsquery, normalizing words according to the default configuration." +
 				" The <-> operator is inserted between each token in the input.",
-			Volatility: volatility.Stable,
+			Volatility:       volatility.Stable,
+			DistsqlBlocklist: true,
 		},
 	),
 	"ts_rank": makeBuiltin(
@@ -333,6 +365,600 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			Volatility: volatility.Immutable,
 		},
 	),
+	"ts_rank_cd": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "weights", Typ: types.FloatArray},
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "normalization", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				weights, err := getWeights(tree.MustBeDArray(args[0]))
+				if err != nil {
+					return nil, err
+				}
+				rank, err := tsearch.RankCD(
+					weights,
+					tree.MustBeDTSVector(args[1]).TSVector,
+					tree.MustBeDTSQuery(args[2]).TSQuery,
+					int(tree.MustBeDInt(args[3])),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDFloat(tree.DFloat(rank)), nil
+			},
+			Info:       "Ranks vectors using the cover density method, based on the proximity of their matching lexemes.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "weights", Typ: types.FloatArray},
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				weights, err := getWeights(tree.MustBeDArray(args[0]))
+				if err != nil {
+					return nil, err
+				}
+				rank, err := tsearch.RankCD(
+					weights,
+					tree.MustBeDTSVector(args[1]).TSVector,
+					tree.MustBeDTSQuery(args[2]).TSQuery,
+					0,
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDFloat(tree.DFloat(rank)), nil
+			},
+			Info:       "Ranks vectors using the cover density method, based on the proximity of their matching lexemes.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "normalization", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				rank, err := tsearch.RankCD(
+					nil, /* weights */
+					tree.MustBeDTSVector(args[0]).TSVector,
+					tree.MustBeDTSQuery(args[1]).TSQuery,
+					int(tree.MustBeDInt(args[2])),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDFloat(tree.DFloat(rank)), nil
+			},
+			Info:       "Ranks vectors using the cover density method, based on the proximity of their matching lexemes.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				rank, err := tsearch.RankCD(
+					nil, /* weights */
+					tree.MustBeDTSVector(args[0]).TSVector,
+					tree.MustBeDTSQuery(args[1]).TSQuery,
+					0, /* method */
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDFloat(tree.DFloat(rank)), nil
+			},
+			Info:       "Ranks vectors using the cover density method, based on the proximity of their matching lexemes.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"websearch_to_tsquery": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := textSearchConfig(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				input := string(tree.MustBeDString(args[1]))
+				query, err := tsearch.WebSearchToTSQuery(config, input)
+				if err != nil {
+					return nil, err
+				}
+				return &tree.DTSQuery{TSQuery: query}, nil
+			},
+			Info: "Converts text to a tsquery, normalizing words according to the specified configuration. " +
+				"Quoted text is converted to lexemes separated by the <-> operator, the word `or` is converted " +
+				"to the | operator, a leading - is converted to the ! operator, and other lexemes are separated " +
+				"by the & operator.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := defaultTextSearchConfig(ctx, evalCtx)
+				if err != nil {
+					return nil, err
+				}
+				input := string(tree.MustBeDString(args[0]))
+				query, err := tsearch.WebSearchToTSQuery(config, input)
+				if err != nil {
+					return nil, err
+				}
+				return &tree.DTSQuery{TSQuery: query}, nil
+			},
+			Info: "Converts text to a tsquery, normalizing words according to the default configuration. " +
+				"Quoted text is converted to lexemes separated by the <-> operator, the word `or` is converted " +
+				"to the | operator, a leading - is converted to the ! operator, and other lexemes are separated " +
+				"by the & operator.",
+			Volatility:       volatility.Stable,
+			DistsqlBlocklist: true,
+		},
+	),
+	"ts_lexize": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "dict", Typ: types.String},
+				{Name: "token", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.StringArray),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dict, err := textSearchDictionary(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				lexemes, ok := dict.Lexize(string(tree.MustBeDString(args[1])))
+				if !ok {
+					return tree.DNull, nil
+				}
+				arr := tree.NewDArray(types.String)
+				for _, lexeme := range lexemes {
+					if err := arr.Append(tree.NewDString(lexeme)); err != nil {
+						return nil, err
+					}
+				}
+				return arr, nil
+			},
+			Info: "Returns the lexemes the dictionary produces for the token, an empty array if the " +
+				"token is a stop word, or NULL if the dictionary doesn't recognize the token.",
+			Volatility:       volatility.Stable,
+			DistsqlBlocklist: true,
+		},
+	),
+	"ts_headline": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "config", Typ: types.String},
+				{Name: "document", Typ: types.String},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "options", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := textSearchConfig(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return tsHeadline(
+					config,
+					string(tree.MustBeDString(args[1])),
+					tree.MustBeDTSQuery(args[2]).TSQuery,
+					string(tree.MustBeDString(args[3])),
+				)
+			},
+			Info:       headlineInfo + " The options are a comma-separated list of option=value pairs.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "config", Typ: types.String},
+				{Name: "document", Typ: types.String},
+				{Name: "query", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := textSearchConfig(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return tsHeadline(
+					config,
+					string(tree.MustBeDString(args[1])),
+					tree.MustBeDTSQuery(args[2]).TSQuery,
+					"", /* options */
+				)
+			},
+			Info:       headlineInfo,
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "document", Typ: types.String},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "options", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := defaultTextSearchConfig(ctx, evalCtx)
+				if err != nil {
+					return nil, err
+				}
+				return tsHeadline(
+					config,
+					string(tree.MustBeDString(args[0])),
+					tree.MustBeDTSQuery(args[1]).TSQuery,
+					string(tree.MustBeDString(args[2])),
+				)
+			},
+			Info:             headlineInfo + " The options are a comma-separated list of option=value pairs.",
+			Volatility:       volatility.Stable,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "document", Typ: types.String},
+				{Name: "query", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := defaultTextSearchConfig(ctx, evalCtx)
+				if err != nil {
+					return nil, err
+				}
+				return tsHeadline(
+					config,
+					string(tree.MustBeDString(args[0])),
+					tree.MustBeDTSQuery(args[1]).TSQuery,
+					"", /* options */
+				)
+			},
+			Info:             headlineInfo,
+			Volatility:       volatility.Stable,
+			DistsqlBlocklist: true,
+		},
+	),
+	"setweight": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "weight", Typ: types.QChar},
+			},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				vector, err := tsearch.SetWeight(
+					tree.MustBeDTSVector(args[0]).TSVector,
+					string(tree.MustBeDString(args[1])),
+					nil, /* lexemes */
+				)
+				if err != nil {
+					return nil, err
+				}
+				return &tree.DTSVector{TSVector: vector}, nil
+			},
+			Info:       "Assigns the given weight to each element of the vector.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "weight", Typ: types.QChar},
+				{Name: "lexemes", Typ: types.StringArray},
+			},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				arr := tree.MustBeDArray(args[2])
+				lexemes := make([]string, 0, arr.Len())
+				for _, d := range arr.Array {
+					if d == tree.DNull {
+						return nil, pgerror.New(pgcode.NullValueNotAllowed, "lexeme array may not contain nulls")
+					}
+					lexemes = append(lexemes, string(tree.MustBeDString(d)))
+				}
+				vector, err := tsearch.SetWeight(
+					tree.MustBeDTSVector(args[0]).TSVector,
+					string(tree.MustBeDString(args[1])),
+					lexemes,
+				)
+				if err != nil {
+					return nil, err
+				}
+				return &tree.DTSVector{TSVector: vector}, nil
+			},
+			Info:       "Assigns the given weight to the elements of the vector that are listed in lexemes.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_rewrite": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "target", Typ: types.TSQuery},
+				{Name: "substitute", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				query := tsearch.Rewrite(
+					tree.MustBeDTSQuery(args[0]).TSQuery,
+					tree.MustBeDTSQuery(args[1]).TSQuery,
+					tree.MustBeDTSQuery(args[2]).TSQuery,
+				)
+				return &tree.DTSQuery{TSQuery: query}, nil
+			},
+			Info:       "Replaces occurrences of target with substitute within the query.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"json_to_tsvector":  makeJSONToTSVectorBuiltin(),
+	"jsonb_to_tsvector": makeJSONToTSVectorBuiltin(),
+}
+
+// makeJSONToTSVectorBuiltin returns the definition shared by json_to_tsvector
+// and jsonb_to_tsvector.
+func makeJSONToTSVectorBuiltin() builtinDefinition {
+	return makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryFullTextSearch},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "config", Typ: types.String},
+				{Name: "document", Typ: types.Jsonb},
+				{Name: "filter", Typ: types.Jsonb},
+			},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := textSearchConfig(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return jsonToTSVector(config, tree.MustBeDJSON(args[1]).JSON, tree.MustBeDJSON(args[2]).JSON)
+			},
+			Info:       jsonToTSVectorInfo + " Words are normalized according to the specified configuration.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "document", Typ: types.Jsonb},
+				{Name: "filter", Typ: types.Jsonb},
+			},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := defaultTextSearchConfig(ctx, evalCtx)
+				if err != nil {
+					return nil, err
+				}
+				return jsonToTSVector(config, tree.MustBeDJSON(args[0]).JSON, tree.MustBeDJSON(args[1]).JSON)
+			},
+			Info:             jsonToTSVectorInfo + " Words are normalized according to the default configuration.",
+			Volatility:       volatility.Stable,
+			DistsqlBlocklist: true,
+		},
+	)
+}
+
+const jsonToTSVectorInfo = "Converts the values of a JSON document selected by filter to a tsvector. " +
+	"The filter is a JSON array containing any of `string`, `numeric`, `boolean`, `key` or `all`, " +
+	"selecting which kinds of elements are included in the result."
+
+// jsonToTSVectorFilter is a bitmask of the kinds of JSON elements that are
+// included in the result of jsonb_to_tsvector.
+type jsonToTSVectorFilter int
+
+const (
+	jsonToTSVectorString jsonToTSVectorFilter = 1 << iota
+	jsonToTSVectorNumeric
+	jsonToTSVectorBoolean
+	jsonToTSVectorKey
+	jsonToTSVectorAll = jsonToTSVectorString | jsonToTSVectorNumeric | jsonToTSVectorBoolean | jsonToTSVectorKey
+)
+
+const jsonToTSVectorFilterHint = `Possible values are: "string", "numeric", "boolean", "key", and "all".`
+
+// parseJSONToTSVectorFilter parses the filter argument of jsonb_to_tsvector,
+// which is either a JSON array of strings or a single JSON string.
+func parseJSONToTSVectorFilter(j json.JSON) (jsonToTSVectorFilter, error) {
+	var elems []json.JSON
+	switch j.Type() {
+	case json.ArrayJSONType:
+		elems = make([]json.JSON, j.Len())
+		for i := range elems {
+			elem, err := j.FetchValIdx(i)
+			if err != nil {
+				return 0, err
+			}
+			elems[i] = elem
+		}
+	case json.ObjectJSONType:
+		return 0, pgerror.New(pgcode.InvalidParameterValue,
+			"wrong flag type, only arrays and scalars are allowed")
+	default:
+		elems = []json.JSON{j}
+	}
+	var filter jsonToTSVectorFilter
+	for _, elem := range elems {
+		if elem.Type() != json.StringJSONType {
+			return 0, errors.WithHint(
+				pgerror.New(pgcode.InvalidParameterValue, "flag array element is not a string"),
+				jsonToTSVectorFilterHint,
+			)
+		}
+		text, err := elem.AsText()
+		if err != nil {
+			return 0, err
+		}
+		switch strings.ToLower(*text) {
+		case "string":
+			filter |= jsonToTSVectorString
+		case "numeric":
+			filter |= jsonToTSVectorNumeric
+		case "boolean":
+			filter |= jsonToTSVectorBoolean
+		case "key":
+			filter |= jsonToTSVectorKey
+		case "all":
+			filter |= jsonToTSVectorAll
+		default:
+			return 0, errors.WithHint(
+				pgerror.Newf(pgcode.InvalidParameterValue, "wrong flag in flag array: %q", *text),
+				jsonToTSVectorFilterHint,
+			)
+		}
+	}
+	return filter, nil
+}
+
+// jsonToTSVector implements the json_to_tsvector and jsonb_to_tsvector
+// builtins.
+func jsonToTSVector(config *tsearch.Config, document json.JSON, filterJSON json.JSON) (tree.Datum, error) {
+	filter, err := parseJSONToTSVectorFilter(filterJSON)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := collectJSONToTSVectorInputs(document, filter, nil /* inputs */)
+	if err != nil {
+		return nil, err
+	}
+	vector, err := tsearch.DocumentsToTSVector(config, inputs)
+	if err != nil {
+		return nil, err
+	}
+	return &tree.DTSVector{TSVector: vector}, nil
+}
+
+// collectJSONToTSVectorInputs appends the text of every element of j that is
+// selected by filter to inputs, in document order.
+func collectJSONToTSVectorInputs(
+	j json.JSON, filter jsonToTSVectorFilter, inputs []string,
+) ([]string, error) {
+	var include bool
+	switch j.Type() {
+	case json.NullJSONType:
+		return inputs, nil
+	case json.StringJSONType:
+		include = filter&jsonToTSVectorString != 0
+	case json.NumberJSONType:
+		include = filter&jsonToTSVectorNumeric != 0
+	case json.FalseJSONType, json.TrueJSONType:
+		include = filter&jsonToTSVectorBoolean != 0
+	case json.ArrayJSONType:
+		for i, n := 0, j.Len(); i < n; i++ {
+			elem, err := j.FetchValIdx(i)
+			if err != nil {
+				return nil, err
+			}
+			if inputs, err = collectJSONToTSVectorInputs(elem, filter, inputs); err != nil {
+				return nil, err
+			}
+		}
+		return inputs, nil
+	case json.ObjectJSONType:
+		iter, err := j.ObjectIter()
+		if err != nil {
+			return nil, err
+		}
+		for iter.Next() {
+			if filter&jsonToTSVectorKey != 0 {
+				inputs = append(inputs, iter.Key())
+			}
+			if inputs, err = collectJSONToTSVectorInputs(iter.Value(), filter, inputs); err != nil {
+				return nil, err
+			}
+		}
+		return inputs, nil
+	default:
+		return nil, errors.AssertionFailedf("unexpected JSON type %d", j.Type())
+	}
+	if !include {
+		return inputs, nil
+	}
+	text, err := j.AsText()
+	if err != nil {
+		return nil, err
+	}
+	return append(inputs, *text), nil
+}
+
+// textSearchConfig returns the builtin text search configuration with the
+// given name. Functions taking the configuration as an argument are immutable,
+// so they can only use the builtin configurations: a user-defined configuration
+// can change or be dropped after the function was evaluated into a stored
+// computed column or index, and it can't be resolved on remote nodes.
+func textSearchConfig(name string) (*tsearch.Config, error) {
+	config, err := tsearch.BuiltinConfig(tsearch.GetConfigKey(name))
+	if err != nil {
+		return nil, errors.WithHint(err,
+			"user-defined text search configurations can only be used through default_text_search_config")
+	}
+	return config, nil
+}
+
+// defaultTextSearchConfig returns the text search configuration named by the
+// default_text_search_config session variable. Unlike textSearchConfig, it may
+// resolve a user-defined configuration, so the functions which call it are
+// stable, and are blocklisted from DistSQL since the catalog is only available
+// on the gateway.
+func defaultTextSearchConfig(ctx context.Context, evalCtx *eval.Context) (*tsearch.Config, error) {
+	name := evalCtx.SessionData().DefaultTextSearchConfig
+	if config, err := tsearch.BuiltinConfig(tsearch.GetConfigKey(name)); err == nil {
+		return config, nil
+	}
+	un, err := parser.ParseTableName(name)
+	if err != nil || evalCtx.CatalogBuiltins == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search configuration %q does not exist", name)
+	}
+	return evalCtx.CatalogBuiltins.ResolveTextSearchConfig(ctx, un, evalCtx.SessionData())
+}
+
+// textSearchDictionary returns the text search dictionary with the given name.
+// As with defaultTextSearchConfig, user-defined dictionaries are resolved on
+// the gateway.
+func textSearchDictionary(
+	ctx context.Context, evalCtx *eval.Context, name string,
+) (*tsearch.Dictionary, error) {
+	if dict, err := tsearch.BuiltinDictionary(strings.TrimPrefix(name, "pg_catalog.")); err == nil {
+		return dict, nil
+	}
+	un, err := parser.ParseTableName(name)
+	if err != nil || evalCtx.CatalogBuiltins == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", name)
+	}
+	return evalCtx.CatalogBuiltins.ResolveTextSearchDictionary(ctx, un, evalCtx.SessionData())
+}
+
+const headlineInfo = "Returns an excerpt of the document in which the terms matching the query are highlighted."
+
+// tsHeadline implements the ts_headline builtin.
+func tsHeadline(
+	config *tsearch.Config, document string, query tsearch.TSQuery, options string,
+) (tree.Datum, error) {
+	opts, err := tsearch.ParseHeadlineOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	headline, err := tsearch.Headline(config, document, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDString(headline), nil
 }
 
 func getWeights(arr *tree.DArray) ([]float32, error) {
@@ -12,9 +12,14 @@ package builtins
 
 import (
 	"context"
+	"math"
 
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geomfn"
+	"github.com/cockroachdb/cockroach/pkg/sql/memsize"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
@@ -198,6 +203,77 @@ var windows = map[string]builtinDefinition{
 				volatility.Immutable,
 			)
 		}),
+	"st_clusterdbscan": makeBuiltin(
+		tree.FunctionProperties{
+			Category:                builtinconstants.CategorySpatial,
+			AvailableOnPublicSchema: true,
+		},
+		makeWindowOverload(
+			tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "eps", Typ: types.Float},
+				{Name: "minpoints", Typ: types.Int},
+			},
+			types.Int,
+			newSTClusterDBSCANWindow,
+			"Returns the cluster number of each geometry within its partition using the 2D DBSCAN algorithm. "+
+				"A geometry within `eps` of at least `minpoints` geometries (including itself) starts or extends "+
+				"a cluster; geometries that are not part of any cluster return null. "+
+				"`eps` and `minpoints` are evaluated with respect to the first row of the partition.",
+			volatility.Immutable,
+		),
+	),
+	"st_clusterkmeans": makeBuiltin(
+		tree.FunctionProperties{
+			Category:                builtinconstants.CategorySpatial,
+			AvailableOnPublicSchema: true,
+		},
+		makeWindowOverload(
+			tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "number_of_clusters", Typ: types.Int},
+			},
+			types.Int,
+			newSTClusterKMeansWindow,
+			"Returns the cluster number of each geometry within its partition using the 2D k-means algorithm "+
+				"on the geometries' centroids. Empty geometries return null. "+
+				"`number_of_clusters` is evaluated with respect to the first row of the partition.",
+			volatility.Volatile,
+		),
+		makeWindowOverload(
+			tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "number_of_clusters", Typ: types.Int},
+				{Name: "max_radius", Typ: types.Float},
+			},
+			types.Int,
+			newSTClusterKMeansWindow,
+			"Returns the cluster number of each geometry within its partition using the 2D k-means algorithm "+
+				"on the geometries' centroids. If `max_radius` is not null, more clusters are used as needed so "+
+				"that no geometry is farther than `max_radius` from the center of its cluster. "+
+				"Empty geometries return null. "+
+				"`number_of_clusters` and `max_radius` are evaluated with respect to the first row of the partition.",
+			volatility.Volatile,
+		),
+	),
+	"st_clusterwithinwin": makeBuiltin(
+		tree.FunctionProperties{
+			Category:                builtinconstants.CategorySpatial,
+			AvailableOnPublicSchema: true,
+		},
+		makeWindowOverload(
+			tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "distance", Typ: types.Float},
+			},
+			types.Int,
+			newSTClusterWithinWinWindow,
+			"Returns the cluster number of each geometry within its partition, where geometries are in the "+
+				"same cluster if they are connected by a chain of geometries each within `distance` of the next. "+
+				"`distance` is evaluated with respect to the first row of the partition.",
+			volatility.Immutable,
+		),
+	),
 }
 
 func makeWindowOverload(
@@ -225,6 +301,7 @@ var _ eval.WindowFunc = &leadLagWindow{}
 var _ eval.WindowFunc = &firstValueWindow{}
 var _ eval.WindowFunc = &lastValueWindow{}
 var _ eval.WindowFunc = &nthValueWindow{}
+var _ eval.WindowFunc = &spatialClusterWindow{}
 
 // aggregateWindowFunc aggregates over the current row's window frame, using
 // the internal eval.AggregateFunc to perform the aggregation.
@@ -855,3 +932,137 @@ func (nthValueWindow) Compute(
 func (nthValueWindow) Reset(context.Context) {}
 
 func (nthValueWindow) Close(context.Context, *eval.Context) {}
+
+// spatialClusterWindow computes a cluster number for every row of the
+// partition on the first call to Compute, and then returns the number of the
+// current row. Rows with a NULL geometry, as well as rows that are not part of
+// any cluster, evaluate to NULL. The remaining arguments are evaluated with
+// respect to the first row of the partition. The geometries of the partition
+// and the results are accounted for in the memory account of the windower.
+type spatialClusterWindow struct {
+	singleDatumAggregateBase
+
+	// cluster returns the cluster number of each of the given geometries, or
+	// geomfn.NoCluster. A nil result means that every row evaluates to NULL.
+	cluster func(ctx context.Context, gs []geo.Geometry, params tree.Datums) ([]int, error)
+	ids     tree.Datums
+}
+
+// spatialClusterMemPerGeometry estimates the memory used by the clustering
+// algorithms for each geometry, such as the DBSCAN queue and neighbor lists or
+// the k-means centroids and assignments.
+const spatialClusterMemPerGeometry = 8 * memsize.Int
+
+func newSTClusterDBSCANWindow(_ []*types.T, evalCtx *eval.Context) eval.WindowFunc {
+	return &spatialClusterWindow{
+		singleDatumAggregateBase: makeSingleDatumAggregateBase(evalCtx),
+		cluster: func(ctx context.Context, gs []geo.Geometry, params tree.Datums) ([]int, error) {
+			if params[0] == tree.DNull || params[1] == tree.DNull {
+				return nil, nil
+			}
+			eps := float64(tree.MustBeDFloat(params[0]))
+			minPoints := int(tree.MustBeDInt(params[1]))
+			return geomfn.ClusterDBSCAN(ctx, gs, eps, minPoints)
+		},
+	}
+}
+
+func newSTClusterKMeansWindow(_ []*types.T, evalCtx *eval.Context) eval.WindowFunc {
+	return &spatialClusterWindow{
+		singleDatumAggregateBase: makeSingleDatumAggregateBase(evalCtx),
+		cluster: func(ctx context.Context, gs []geo.Geometry, params tree.Datums) ([]int, error) {
+			if params[0] == tree.DNull {
+				return nil, nil
+			}
+			k := int(tree.MustBeDInt(params[0]))
+			maxRadius := math.Inf(1)
+			if len(params) > 1 && params[1] != tree.DNull {
+				maxRadius = float64(tree.MustBeDFloat(params[1]))
+			}
+			return geomfn.ClusterKMeans(ctx, gs, k, maxRadius)
+		},
+	}
+}
+
+func newSTClusterWithinWinWindow(_ []*types.T, evalCtx *eval.Context) eval.WindowFunc {
+	return &spatialClusterWindow{
+		singleDatumAggregateBase: makeSingleDatumAggregateBase(evalCtx),
+		cluster: func(_ context.Context, gs []geo.Geometry, params tree.Datums) ([]int, error) {
+			if params[0] == tree.DNull {
+				return nil, nil
+			}
+			return geomfn.ClusterWithinIDs(gs, float64(tree.MustBeDFloat(params[0])))
+		},
+	}
+}
+
+// Compute implements the eval.WindowFunc interface.
+func (w *spatialClusterWindow) Compute(
+	ctx context.Context, _ *eval.Context, wfr *eval.WindowFrameRun,
+) (tree.Datum, error) {
+	if w.ids == nil {
+		// If this is the first call to Compute for the partition, cluster all of
+		// its geometries at once.
+		if err := w.clusterPartition(ctx, wfr); err != nil {
+			return nil, err
+		}
+	}
+	return w.ids[wfr.RowIdx], nil
+}
+
+func (w *spatialClusterWindow) clusterPartition(
+	ctx context.Context, wfr *eval.WindowFrameRun,
+) error {
+	n := wfr.PartitionSize()
+	usage := int64(n) * (memsize.DatumOverhead + memsize.Int)
+	if err := w.updateMemoryUsage(ctx, usage); err != nil {
+		return err
+	}
+	w.ids = make(tree.Datums, n)
+	var gs []geo.Geometry
+	var rowIdxs []int
+	var params tree.Datums
+	for i := 0; i < n; i++ {
+		w.ids[i] = tree.DNull
+		args, err := wfr.ArgsByRowIdx(ctx, i)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			params = args[1:]
+		}
+		if args[0] == tree.DNull {
+			continue
+		}
+		g := tree.MustBeDGeometry(args[0])
+		usage += int64(g.Size()) + spatialClusterMemPerGeometry
+		if err := w.updateMemoryUsage(ctx, usage); err != nil {
+			return err
+		}
+		gs = append(gs, g.Geometry)
+		rowIdxs = append(rowIdxs, i)
+	}
+	if len(gs) == 0 {
+		return nil
+	}
+	ids, err := w.cluster(ctx, gs, params)
+	if err != nil {
+		return err
+	}
+	for i, id := range ids {
+		if id != geomfn.NoCluster {
+			w.ids[rowIdxs[i]] = tree.NewDInt(tree.DInt(id))
+		}
+	}
+	return nil
+}
+
+// Reset implements eval.WindowFunc interface.
+func (w *spatialClusterWindow) Reset(ctx context.Context) {
+	w.ids = nil
+	w.reset(ctx)
+}
+
+func (w *spatialClusterWindow) Close(ctx context.Context, _ *eval.Context) {
+	w.close(ctx)
+}
@@ -101,6 +101,7 @@ const (
 	MVCCStatistics                         SystemTableName = "mvcc_statistics"
 	StmtExecInsightsTableName              SystemTableName = "statement_execution_insights"
 	TxnExecInsightsTableName               SystemTableName = "transaction_execution_insights"
+	PlanBaselinesTableName                 SystemTableName = "plan_baselines"
 )
 
 // Oid for virtual database and table.
@@ -430,7 +431,9 @@ const (
 	PgExtensionGeographyColumnsTableID
 	PgExtensionGeometryColumnsTableID
 	PgExtensionSpatialRefSysTableID
-	MinVirtualID = PgExtensionSpatialRefSysTableID
+	CrdbInternalNodePlanCacheTableID
+	CrdbInternalNodePlanBaselinesTableID
+	MinVirtualID = CrdbInternalNodePlanBaselinesTableID
 )
 
 // ConstraintType is used to identify the type of a constraint.
@@ -1229,6 +1229,28 @@ func (e *evaluator) EvalTSMatchesVectorQueryOp(
 	return tree.MakeDBool(tree.DBool(ret)), err
 }
 
+func (e *evaluator) EvalWordSimilarOp(
+	ctx context.Context, _ *tree.WordSimilarOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	// The string <% string operator returns whether the trigram
+	// word_similarity() of the two strings is greater than or equal to the
+	// threshold in pg_trgm.word_similarity_threshold.
+	l, r := tree.MustBeDString(left), tree.MustBeDString(right)
+	f := trigram.WordSimilarity(string(l), string(r))
+	return tree.MakeDBool(f >= e.ctx().SessionData().TrigramWordSimilarityThreshold), nil
+}
+
+func (e *evaluator) EvalStrictWordSimilarOp(
+	ctx context.Context, _ *tree.StrictWordSimilarOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	// The string <<% string operator returns whether the trigram
+	// strict_word_similarity() of the two strings is greater than or equal to
+	// the threshold in pg_trgm.strict_word_similarity_threshold.
+	l, r := tree.MustBeDString(left), tree.MustBeDString(right)
+	f := trigram.StrictWordSimilarity(string(l), string(r))
+	return tree.MakeDBool(f >= e.ctx().SessionData().TrigramStrictWordSimilarityThreshold), nil
+}
+
 func (e *evaluator) EvalPlusDateIntOp(
 	ctx context.Context, _ *tree.PlusDateIntOp, left, right tree.Datum,
 ) (tree.Datum, error) {
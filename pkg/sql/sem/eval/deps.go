@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/rangedesc"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/lib/pq/oid"
 )
 
@@ -136,6 +137,19 @@ type CatalogBuiltins interface {
 		nonTerminalJobIDMightExist func(id jobspb.JobID) bool,
 		roleExists func(username username.SQLUsername) bool,
 	) ([]byte, error)
+
+	// ResolveTextSearchConfig returns the text search configuration with the
+	// given name. Unqualified names are resolved in the current database of the
+	// session using its search path.
+	ResolveTextSearchConfig(
+		ctx context.Context, name *tree.UnresolvedObjectName, sd *sessiondata.SessionData,
+	) (*tsearch.Config, error)
+
+	// ResolveTextSearchDictionary is like ResolveTextSearchConfig, but returns
+	// a text search dictionary.
+	ResolveTextSearchDictionary(
+		ctx context.Context, name *tree.UnresolvedObjectName, sd *sessiondata.SessionData,
+	) (*tsearch.Dictionary, error)
 }
 
 // HasPrivilegeSpecifier specifies an object to lookup privilege for.
@@ -349,6 +363,27 @@ type Planner interface {
 	// for the current transaction.
 	IsConstraintActive(ctx context.Context, tableID int, constraintName string) (bool, error)
 
+	// MaterializedViewLastRefresh returns the timestamp as of which the data of
+	// the given materialized view was last computed. ok is false if the view
+	// has no data because it was created or last refreshed WITH NO DATA.
+	MaterializedViewLastRefresh(ctx context.Context, viewID int) (ts hlc.Timestamp, ok bool, err error)
+
+	// EvictCachedPlans evicts the query plans which depend on the given table
+	// from the query caches of all the nodes, and forces the prepared
+	// statements depending on it to be re-planned. It returns the number of
+	// query cache entries which were evicted.
+	EvictCachedPlans(ctx context.Context, tableID int64) (int64, error)
+
+	// PinPlan pins the given plan gist to the fingerprint of the given
+	// statement on all the nodes. If enforce is true, plans which do not match
+	// the gist are not used. It returns true if the fingerprint already had a
+	// plan baseline, which was replaced.
+	PinPlan(ctx context.Context, fingerprint, planGist string, enforce bool) (replaced bool, _ error)
+
+	// UnpinPlan removes the plan baseline of the fingerprint of the given
+	// statement on all the nodes. It returns false if there was none.
+	UnpinPlan(ctx context.Context, fingerprint string) (bool, error)
+
 	// ValidateTTLScheduledJobsInCurrentDB checks scheduled jobs for each table
 	// in the database maps to a scheduled job.
 	ValidateTTLScheduledJobsInCurrentDB(ctx context.Context) error
@@ -125,6 +125,9 @@ func ComputeColNameInternal(
 		if e.Exists {
 			return 2, "exists", nil
 		}
+		if e.Unique {
+			return 2, "unique", nil
+		}
 		return computeColNameInternalSubquery(ctx, sp, e.Select, funcResolver)
 
 	case *CaseExpr:
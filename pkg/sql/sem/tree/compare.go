@@ -29,6 +29,14 @@ func FoldComparisonExpr(
 	case treecmp.GE:
 		// GE(left, right) is implemented as LE(right, left)
 		return treecmp.MakeComparisonOperator(treecmp.LE), right, left, true, false
+	case treecmp.WordSimilarCommutator:
+		// WordSimilarCommutator(left, right) is implemented as
+		// WordSimilar(right, left)
+		return treecmp.MakeComparisonOperator(treecmp.WordSimilar), right, left, true, false
+	case treecmp.StrictWordSimilarCommutator:
+		// StrictWordSimilarCommutator(left, right) is implemented as
+		// StrictWordSimilar(right, left)
+		return treecmp.MakeComparisonOperator(treecmp.StrictWordSimilar), right, left, true, false
 	case treecmp.NotIn:
 		// NotIn(left, right) is implemented as !IN(left, right)
 		return treecmp.MakeComparisonOperator(treecmp.In), left, right, false, true
@@ -72,6 +80,14 @@ func FoldComparisonExprWithDatums(
 	case treecmp.GE:
 		// GE(left, right) is implemented as LE(right, left)
 		return treecmp.MakeComparisonOperator(treecmp.LE), right, left, true, false
+	case treecmp.WordSimilarCommutator:
+		// WordSimilarCommutator(left, right) is implemented as
+		// WordSimilar(right, left)
+		return treecmp.MakeComparisonOperator(treecmp.WordSimilar), right, left, true, false
+	case treecmp.StrictWordSimilarCommutator:
+		// StrictWordSimilarCommutator(left, right) is implemented as
+		// StrictWordSimilar(right, left)
+		return treecmp.MakeComparisonOperator(treecmp.StrictWordSimilar), right, left, true, false
 	case treecmp.NotIn:
 		// NotIn(left, right) is implemented as !IN(left, right)
 		return treecmp.MakeComparisonOperator(treecmp.In), left, right, false, true
@@ -94,7 +94,7 @@ type CompositeKeyMatchMethod semenumpb.Match
 const (
 	MatchSimple CompositeKeyMatchMethod = iota
 	MatchFull
-	MatchPartial // Note: PARTIAL not actually supported at this point.
+	MatchPartial
 )
 
 // CompositeKeyMatchMethodType allows the conversion from a
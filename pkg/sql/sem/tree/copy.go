@@ -63,11 +63,24 @@ type CopyOptions struct {
 	Header      bool
 	Quote       *StrVal
 	Encoding    *StrVal
+	// HeaderMatch is set for HEADER MATCH, which requires the column names in
+	// the header line of COPY FROM to match those of the table.
+	HeaderMatch bool
+	Freeze      bool
+	Oids        bool
+	// ForceQuoteAll is set for FORCE_QUOTE *, in which case ForceQuote is
+	// empty.
+	ForceQuoteAll bool
+	ForceQuote    NameList
+	ForceNotNull  NameList
+	ForceNull     NameList
 
 	// Additional flags are needed to keep track of whether explicit default
 	// values were already set.
 	HasFormat bool
 	HasHeader bool
+	HasFreeze bool
+	HasOids   bool
 }
 
 var _ NodeFormatter = &CopyOptions{}
@@ -147,7 +160,9 @@ func (o *CopyOptions) Format(ctx *FmtCtx) {
 	if o.HasHeader {
 		maybeAddSep()
 		ctx.WriteString("HEADER ")
-		if o.Header {
+		if o.HeaderMatch {
+			ctx.WriteString("MATCH")
+		} else if o.Header {
 			ctx.WriteString("true")
 		} else {
 			ctx.WriteString("false")
@@ -158,12 +173,56 @@ func (o *CopyOptions) Format(ctx *FmtCtx) {
 		ctx.WriteString("QUOTE ")
 		ctx.FormatNode(o.Quote)
 	}
+	if o.ForceQuoteAll {
+		maybeAddSep()
+		ctx.WriteString("FORCE_QUOTE *")
+	} else if len(o.ForceQuote) > 0 {
+		maybeAddSep()
+		ctx.WriteString("FORCE_QUOTE (")
+		ctx.FormatNode(&o.ForceQuote)
+		ctx.WriteString(")")
+	}
+	if len(o.ForceNotNull) > 0 {
+		maybeAddSep()
+		ctx.WriteString("FORCE_NOT_NULL (")
+		ctx.FormatNode(&o.ForceNotNull)
+		ctx.WriteString(")")
+	}
+	if len(o.ForceNull) > 0 {
+		maybeAddSep()
+		ctx.WriteString("FORCE_NULL (")
+		ctx.FormatNode(&o.ForceNull)
+		ctx.WriteString(")")
+	}
+	if o.HasFreeze {
+		maybeAddSep()
+		ctx.WriteString("FREEZE ")
+		if o.Freeze {
+			ctx.WriteString("true")
+		} else {
+			ctx.WriteString("false")
+		}
+	}
+	if o.HasOids {
+		maybeAddSep()
+		ctx.WriteString("OIDS ")
+		if o.Oids {
+			ctx.WriteString("true")
+		} else {
+			ctx.WriteString("false")
+		}
+	}
 	ctx.WriteString(")")
 }
 
 // IsDefault returns true if this struct has default value.
 func (o CopyOptions) IsDefault() bool {
-	return o == CopyOptions{}
+	return o.Destination == nil && o.CopyFormat == 0 && o.Delimiter == nil &&
+		o.Null == nil && o.Escape == nil && !o.Header && o.Quote == nil &&
+		o.Encoding == nil && !o.HeaderMatch && !o.Freeze && !o.Oids &&
+		!o.ForceQuoteAll && o.ForceQuote == nil && o.ForceNotNull == nil &&
+		o.ForceNull == nil && !o.HasFormat && !o.HasHeader && !o.HasFreeze &&
+		!o.HasOids
 }
 
 // CombineWith merges other options into this struct. An error is returned if
@@ -211,6 +270,7 @@ func (o *CopyOptions) CombineWith(other *CopyOptions) error {
 			return pgerror.Newf(pgcode.Syntax, "header option specified multiple times")
 		}
 		o.Header = other.Header
+		o.HeaderMatch = other.HeaderMatch
 		o.HasHeader = true
 	}
 	if other.Quote != nil {
@@ -219,6 +279,39 @@ func (o *CopyOptions) CombineWith(other *CopyOptions) error {
 		}
 		o.Quote = other.Quote
 	}
+	if other.ForceQuoteAll || other.ForceQuote != nil {
+		if o.ForceQuoteAll || o.ForceQuote != nil {
+			return pgerror.Newf(pgcode.Syntax, "force_quote option specified multiple times")
+		}
+		o.ForceQuoteAll = other.ForceQuoteAll
+		o.ForceQuote = other.ForceQuote
+	}
+	if other.ForceNotNull != nil {
+		if o.ForceNotNull != nil {
+			return pgerror.Newf(pgcode.Syntax, "force_not_null option specified multiple times")
+		}
+		o.ForceNotNull = other.ForceNotNull
+	}
+	if other.ForceNull != nil {
+		if o.ForceNull != nil {
+			return pgerror.Newf(pgcode.Syntax, "force_null option specified multiple times")
+		}
+		o.ForceNull = other.ForceNull
+	}
+	if other.HasFreeze {
+		if o.HasFreeze {
+			return pgerror.Newf(pgcode.Syntax, "freeze option specified multiple times")
+		}
+		o.Freeze = other.Freeze
+		o.HasFreeze = true
+	}
+	if other.HasOids {
+		if o.HasOids {
+			return pgerror.Newf(pgcode.Syntax, "oids option specified multiple times")
+		}
+		o.Oids = other.Oids
+		o.HasOids = true
+	}
 	return nil
 }
 
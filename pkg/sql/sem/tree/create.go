@@ -1501,7 +1501,11 @@ type CreateTable struct {
 	// these columns.
 	Defs     TableDefs
 	AsSource *Select
-	Locality *Locality
+	// AsWithNoData is set for CREATE TABLE ... AS ... WITH NO DATA, in which
+	// case the table is created with the columns of AsSource but is not
+	// populated.
+	AsWithNoData bool
+	Locality     *Locality
 }
 
 // As returns true if this table represents a CREATE TABLE ... AS statement,
@@ -1558,6 +1562,9 @@ func (node *CreateTable) FormatBody(ctx *FmtCtx) {
 		}
 		ctx.WriteString(" AS ")
 		ctx.FormatNode(node.AsSource)
+		if node.AsWithNoData {
+			ctx.WriteString(" WITH NO DATA")
+		}
 	} else {
 		ctx.WriteString(" (")
 		ctx.FormatNode(&node.Defs)
@@ -1859,6 +1866,10 @@ const (
 	LikeTableOptDefaults
 	LikeTableOptGenerated
 	LikeTableOptIndexes
+	LikeTableOptComments
+	LikeTableOptIdentity
+	LikeTableOptStatistics
+	LikeTableOptStorage
 
 	// Make sure this field stays last!
 	likeTableOptInvalid
@@ -1882,6 +1893,14 @@ func (o LikeTableOpt) String() string {
 		return "GENERATED"
 	case LikeTableOptIndexes:
 		return "INDEXES"
+	case LikeTableOptComments:
+		return "COMMENTS"
+	case LikeTableOptIdentity:
+		return "IDENTITY"
+	case LikeTableOptStatistics:
+		return "STATISTICS"
+	case LikeTableOptStorage:
+		return "STORAGE"
 	case LikeTableOptAll:
 		return "ALL"
 	default:
@@ -1998,6 +2017,9 @@ type RefreshMaterializedView struct {
 	Name              *UnresolvedObjectName
 	Concurrently      bool
 	RefreshDataOption RefreshDataOption
+	// Incremental is set if the INCREMENTAL option was specified, requesting
+	// that only the changes to the base tables be applied to the view.
+	Incremental bool
 }
 
 // RefreshDataOption corresponds to arguments for the REFRESH MATERIALIZED VIEW
@@ -2029,14 +2051,20 @@ func (node *RefreshMaterializedView) Format(ctx *FmtCtx) {
 	case RefreshDataClear:
 		ctx.WriteString(" WITH NO DATA")
 	}
+	if node.Incremental {
+		ctx.WriteString(" INCREMENTAL")
+	}
 }
 
 // CreateStats represents a CREATE STATISTICS statement.
 type CreateStats struct {
 	Name        Name
 	ColumnNames NameList
-	Table       TableExpr
-	Options     CreateStatsOptions
+	// Expr, if set, is the expression to collect statistics on instead of
+	// ColumnNames.
+	Expr    Expr
+	Table   TableExpr
+	Options CreateStatsOptions
 }
 
 // Format implements the NodeFormatter interface.
@@ -2047,6 +2075,10 @@ func (node *CreateStats) Format(ctx *FmtCtx) {
 	if len(node.ColumnNames) > 0 {
 		ctx.WriteString(" ON ")
 		ctx.FormatNode(&node.ColumnNames)
+	} else if node.Expr != nil {
+		ctx.WriteString(" ON (")
+		ctx.FormatNode(node.Expr)
+		ctx.WriteByte(')')
 	}
 
 	ctx.WriteString(" FROM ")
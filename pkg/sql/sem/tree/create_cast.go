@@ -0,0 +1,135 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
+)
+
+// CastMethod indicates how a user-defined cast is performed.
+type CastMethod uint8
+
+const (
+	// CastMethodFunction performs the cast by calling a function.
+	CastMethodFunction CastMethod = iota
+	// CastMethodInOut performs the cast by formatting the input value as a
+	// string and parsing it as the target type.
+	CastMethodInOut
+	// CastMethodBinary reinterprets the input value as the target type
+	// without any conversion.
+	CastMethodBinary
+)
+
+// CreateCast represents a CREATE CAST statement.
+type CreateCast struct {
+	SourceType ResolvableTypeReference
+	TargetType ResolvableTypeReference
+	Method     CastMethod
+	// Function is only set when Method is CastMethodFunction.
+	Function RoutineObj
+	// Context is the most permissive context in which the cast can be used.
+	Context cast.Context
+}
+
+var _ Statement = &CreateCast{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateCast) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE CAST (")
+	ctx.FormatTypeReference(node.SourceType)
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.TargetType)
+	ctx.WriteString(") ")
+	switch node.Method {
+	case CastMethodFunction:
+		ctx.WriteString("WITH FUNCTION ")
+		ctx.FormatNode(&node.Function)
+	case CastMethodInOut:
+		ctx.WriteString("WITH INOUT")
+	case CastMethodBinary:
+		ctx.WriteString("WITHOUT FUNCTION")
+	}
+	switch node.Context {
+	case cast.ContextAssignment:
+		ctx.WriteString(" AS ASSIGNMENT")
+	case cast.ContextImplicit:
+		ctx.WriteString(" AS IMPLICIT")
+	}
+}
+
+// DropCast represents a DROP CAST statement.
+type DropCast struct {
+	SourceType   ResolvableTypeReference
+	TargetType   ResolvableTypeReference
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropCast{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropCast) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP CAST ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.WriteString("(")
+	ctx.FormatTypeReference(node.SourceType)
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.TargetType)
+	ctx.WriteString(")")
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// UserDefinedCast is a cast created with CREATE CAST.
+type UserDefinedCast struct {
+	// Method is either CastMethodFunction or CastMethodInOut.
+	Method CastMethod
+	// FuncOID is the OID of the function performing the cast. It is only set
+	// when Method is CastMethodFunction.
+	FuncOID oid.Oid
+	// MaxContext is the most permissive context in which the cast can be
+	// applied.
+	MaxContext cast.Context
+}
+
+// CastResolver looks up user-defined casts.
+type CastResolver interface {
+	// ResolveCast returns the user-defined cast from one type to another, or
+	// nil if there is none. Only casts involving at least one user-defined
+	// type can exist.
+	ResolveCast(ctx context.Context, from, to *types.T) (*UserDefinedCast, error)
+}
+
+// ResolveUserDefinedCast returns the user-defined cast from one type to
+// another which can be applied in the given context, or nil if there is none.
+func ResolveUserDefinedCast(
+	ctx context.Context, semaCtx *SemaContext, from, to *types.T, castCtx cast.Context,
+) (*UserDefinedCast, error) {
+	if semaCtx == nil || semaCtx.CastResolver == nil {
+		return nil, nil
+	}
+	if !from.UserDefined() && !to.UserDefined() {
+		return nil, nil
+	}
+	c, err := semaCtx.CastResolver.ResolveCast(ctx, from, to)
+	if err != nil || c == nil || c.MaxContext < castCtx {
+		return nil, err
+	}
+	return c, nil
+}
@@ -203,6 +203,8 @@ func (RoutineVolatility) routineOption()        {}
 func (RoutineLeakproof) routineOption()         {}
 func (RoutineBodyStr) routineOption()           {}
 func (RoutineLanguage) routineOption()          {}
+func (RoutineSecurity) routineOption()          {}
+func (*RoutineSetVar) routineOption()           {}
 
 // RoutineNullInputBehavior represent the UDF property on null parameters.
 type RoutineNullInputBehavior int
@@ -275,6 +277,43 @@ func (node RoutineLeakproof) Format(ctx *FmtCtx) {
 	ctx.WriteString("LEAKPROOF")
 }
 
+// RoutineSecurity indicates whether a routine is executed with the privileges
+// of the user that invokes it, or with the privileges of the routine's owner.
+type RoutineSecurity int
+
+const (
+	// RoutineInvoker indicates that the routine is executed with the privileges
+	// of the invoking user. This is the default if no security is provided.
+	RoutineInvoker RoutineSecurity = iota
+	// RoutineDefiner indicates that the routine is executed with the privileges
+	// of its owner.
+	RoutineDefiner
+)
+
+// Format implements the NodeFormatter interface.
+func (node RoutineSecurity) Format(ctx *FmtCtx) {
+	switch node {
+	case RoutineInvoker:
+		ctx.WriteString("SECURITY INVOKER")
+	case RoutineDefiner:
+		ctx.WriteString("SECURITY DEFINER")
+	default:
+		panic(pgerror.New(pgcode.InvalidParameterValue, "unknown routine option"))
+	}
+}
+
+// RoutineSetVar represents a SET or RESET clause of a routine definition. The
+// session variable is set to the given value while the routine is executing,
+// and restored to its previous value when the routine returns.
+type RoutineSetVar struct {
+	SetVar
+}
+
+// Format implements the NodeFormatter interface.
+func (node *RoutineSetVar) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.SetVar)
+}
+
 // RoutineLanguage indicates the language of the statements in the routine body.
 type RoutineLanguage string
 
@@ -628,6 +667,7 @@ const (
 	TTLExpirationExpr               SchemaExprContext = "TTL EXPIRATION EXPRESSION"
 	TTLDefaultExpr                  SchemaExprContext = "TTL DEFAULT"
 	TTLUpdateExpr                   SchemaExprContext = "TTL UPDATE"
+	StatisticsExpr                  SchemaExprContext = "STATISTICS EXPRESSION"
 )
 
 func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
@@ -640,7 +680,7 @@ func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
 // ValidateRoutineOptions checks whether there are conflicting or redundant
 // routine options in the given slice.
 func ValidateRoutineOptions(options RoutineOptions, isProc bool) error {
-	var hasLang, hasBody, hasLeakProof, hasVolatility, hasNullInputBehavior bool
+	var hasLang, hasBody, hasLeakProof, hasVolatility, hasNullInputBehavior, hasSecurity bool
 	conflictingErr := func(opt RoutineOption) error {
 		return errors.Wrapf(ErrConflictingRoutineOption, "%s", AsString(opt))
 	}
@@ -680,6 +720,13 @@ func ValidateRoutineOptions(options RoutineOptions, isProc bool) error {
 				return conflictingErr(option)
 			}
 			hasNullInputBehavior = true
+		case RoutineSecurity:
+			if hasSecurity {
+				return conflictingErr(option)
+			}
+			hasSecurity = true
+		case *RoutineSetVar:
+			// Multiple SET clauses are allowed, and are applied in order.
 		default:
 			return pgerror.Newf(pgcode.InvalidParameterValue, "unknown function option: ", AsString(option))
 		}
@@ -29,6 +29,9 @@ const (
 
 	// DiscardModeTemp represents a DISCARD TEMPORARY statement
 	DiscardModeTemp
+
+	// DiscardModePlans represents a DISCARD PLANS statement
+	DiscardModePlans
 )
 
 // Format implements the NodeFormatter interface.
@@ -40,6 +43,8 @@ func (node *Discard) Format(ctx *FmtCtx) {
 		ctx.WriteString("DISCARD SEQUENCES")
 	case DiscardModeTemp:
 		ctx.WriteString("DISCARD TEMPORARY")
+	case DiscardModePlans:
+		ctx.WriteString("DISCARD PLANS")
 	}
 }
 
@@ -81,6 +81,12 @@ type TSMatchesVectorQueryOp struct{}
 // TSMatchesQueryVectorOp is a BinaryEvalOp.
 type TSMatchesQueryVectorOp struct{}
 
+// WordSimilarOp is a BinaryEvalOp.
+type WordSimilarOp struct{}
+
+// StrictWordSimilarOp is a BinaryEvalOp.
+type StrictWordSimilarOp struct{}
+
 // AppendToMaybeNullArrayOp is a BinaryEvalOp.
 type AppendToMaybeNullArrayOp struct {
 	Typ *types.T
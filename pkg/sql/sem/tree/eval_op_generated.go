@@ -185,8 +185,10 @@ type BinaryOpEvaluator interface {
 	EvalRShiftIntOp(context.Context, *RShiftIntOp, Datum, Datum) (Datum, error)
 	EvalRShiftVarBitIntOp(context.Context, *RShiftVarBitIntOp, Datum, Datum) (Datum, error)
 	EvalSimilarToOp(context.Context, *SimilarToOp, Datum, Datum) (Datum, error)
+	EvalStrictWordSimilarOp(context.Context, *StrictWordSimilarOp, Datum, Datum) (Datum, error)
 	EvalTSMatchesQueryVectorOp(context.Context, *TSMatchesQueryVectorOp, Datum, Datum) (Datum, error)
 	EvalTSMatchesVectorQueryOp(context.Context, *TSMatchesVectorQueryOp, Datum, Datum) (Datum, error)
+	EvalWordSimilarOp(context.Context, *WordSimilarOp, Datum, Datum) (Datum, error)
 }
 
 
@@ -895,6 +897,11 @@ func (op *SimilarToOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Dat
 	return e.EvalSimilarToOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *StrictWordSimilarOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalStrictWordSimilarOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *TSMatchesQueryVectorOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalTSMatchesQueryVectorOp(ctx, op, a, b)
@@ -905,3 +912,8 @@ func (op *TSMatchesVectorQueryOp) Eval(ctx context.Context, e OpEvaluator, a, b
 	return e.EvalTSMatchesVectorQueryOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *WordSimilarOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalWordSimilarOp(ctx, op, a, b)
+}
+
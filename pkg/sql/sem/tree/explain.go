@@ -42,6 +42,22 @@ type ExplainAnalyze struct {
 type ExplainOptions struct {
 	Mode  ExplainMode
 	Flags [numExplainFlags + 1]bool
+
+	// HypotheticalIndexes contains the CREATE INDEX and DROP INDEX statements
+	// of the HYPOTHETICAL INDEXES option. The statement is planned as if these
+	// index changes were made, without making them.
+	HypotheticalIndexes Statements
+}
+
+// ExplainOptionList is the list of options of an EXPLAIN statement, as
+// parsed. It is validated and converted to ExplainOptions by MakeExplain.
+type ExplainOptionList struct {
+	// Names contains the names of the mode and flags options.
+	Names []string
+
+	// HypotheticalIndexes contains the statements of the HYPOTHETICAL INDEXES
+	// option.
+	HypotheticalIndexes Statements
 }
 
 // ExplainMode indicates the mode of the explain. The default is ExplainPlan.
@@ -170,6 +186,11 @@ func (node *Explain) Format(ctx *FmtCtx) {
 			b.Add(ctx, f.String())
 		}
 	}
+	if len(node.HypotheticalIndexes) > 0 {
+		b.Add(ctx, "HYPOTHETICAL INDEXES (")
+		formatHypotheticalIndexes(ctx, node.HypotheticalIndexes)
+		ctx.WriteByte(')')
+	}
 	b.Finish(ctx)
 	ctx.FormatNode(node.Statement)
 }
@@ -186,6 +207,9 @@ func (node *Explain) doc(p *PrettyCfg) pretty.Doc {
 			opts = append(opts, pretty.Keyword(f.String()))
 		}
 	}
+	if len(node.HypotheticalIndexes) > 0 {
+		opts = append(opts, p.hypotheticalIndexesDoc(node.HypotheticalIndexes))
+	}
 	if len(opts) > 0 {
 		d = pretty.ConcatSpace(
 			d,
@@ -208,6 +232,11 @@ func (node *ExplainAnalyze) Format(ctx *FmtCtx) {
 			b.Add(ctx, f.String())
 		}
 	}
+	if len(node.HypotheticalIndexes) > 0 {
+		b.Add(ctx, "HYPOTHETICAL INDEXES (")
+		formatHypotheticalIndexes(ctx, node.HypotheticalIndexes)
+		ctx.WriteByte(')')
+	}
 	b.Finish(ctx)
 	ctx.FormatNode(node.Statement)
 }
@@ -224,6 +253,9 @@ func (node *ExplainAnalyze) doc(p *PrettyCfg) pretty.Doc {
 			opts = append(opts, pretty.Keyword(f.String()))
 		}
 	}
+	if len(node.HypotheticalIndexes) > 0 {
+		opts = append(opts, p.hypotheticalIndexesDoc(node.HypotheticalIndexes))
+	}
 	if len(opts) > 0 {
 		d = pretty.ConcatSpace(
 			d,
@@ -233,9 +265,34 @@ func (node *ExplainAnalyze) doc(p *PrettyCfg) pretty.Doc {
 	return p.nestUnder(d, p.Doc(node.Statement))
 }
 
-// MakeExplain parses the EXPLAIN option strings and generates an Explain
-// or ExplainAnalyze statement.
-func MakeExplain(options []string, stmt Statement) (Statement, error) {
+// formatHypotheticalIndexes formats the statements of the HYPOTHETICAL INDEXES
+// option, separated by semicolons.
+func formatHypotheticalIndexes(ctx *FmtCtx, stmts Statements) {
+	for i, stmt := range stmts {
+		if i > 0 {
+			ctx.WriteString("; ")
+		}
+		ctx.FormatNode(stmt)
+	}
+}
+
+// hypotheticalIndexesDoc returns the pretty-printed HYPOTHETICAL INDEXES
+// option.
+func (p *PrettyCfg) hypotheticalIndexesDoc(stmts Statements) pretty.Doc {
+	docs := make([]pretty.Doc, len(stmts))
+	for i, stmt := range stmts {
+		docs[i] = p.Doc(stmt)
+	}
+	return pretty.ConcatSpace(
+		pretty.Keyword("HYPOTHETICAL INDEXES"),
+		p.bracket("(", pretty.Join(";", docs...), ")"),
+	)
+}
+
+// MakeExplain parses the EXPLAIN options and generates an Explain or
+// ExplainAnalyze statement.
+func MakeExplain(optionList ExplainOptionList, stmt Statement) (Statement, error) {
+	options := optionList.Names
 	for i := range options {
 		options[i] = strings.ToUpper(options[i])
 	}
@@ -299,6 +356,23 @@ func MakeExplain(options []string, stmt Statement) (Statement, error) {
 		}
 	}
 
+	if len(optionList.HypotheticalIndexes) > 0 {
+		if analyze {
+			return nil, pgerror.Newf(pgcode.Syntax, "the HYPOTHETICAL INDEXES option cannot be used with ANALYZE")
+		}
+		if opts.Mode != ExplainPlan && opts.Mode != ExplainOpt {
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"the HYPOTHETICAL INDEXES option cannot be used with %s", opts.Mode)
+		}
+		switch stmt.(type) {
+		case *Select, *ParenSelect:
+		default:
+			return nil, unimplemented.Newf("EXPLAIN (HYPOTHETICAL INDEXES)",
+				"the HYPOTHETICAL INDEXES option can only be used with SELECT statements")
+		}
+		opts.HypotheticalIndexes = optionList.HypotheticalIndexes
+	}
+
 	if analyze {
 		if opts.Mode != ExplainDistSQL && opts.Mode != ExplainDebug && opts.Mode != ExplainPlan {
 			return nil, pgerror.Newf(pgcode.Syntax, "EXPLAIN ANALYZE cannot be used with %s", opts.Mode)
@@ -959,6 +959,9 @@ func (node *TypedExprs) String() string {
 type Subquery struct {
 	Select SelectStatement
 	Exists bool
+	// Unique is set for the UNIQUE predicate, which is true if the subquery
+	// returns no two equal rows. Rows containing NULLs are ignored.
+	Unique bool
 
 	// Idx is a query-unique index for the subquery.
 	// Subqueries are 1-indexed to ensure that the default
@@ -997,6 +1000,8 @@ func (node *Subquery) Format(ctx *FmtCtx) {
 		ctx.WithFlags(ctx.flags & ^FmtShowTypes, func() {
 			if node.Exists {
 				ctx.WriteString("EXISTS ")
+			} else if node.Unique {
+				ctx.WriteString("UNIQUE ")
 			}
 			if node.Select == nil {
 				// If the subquery is generated by the optimizer, we
@@ -18,8 +18,11 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
@@ -295,6 +298,28 @@ type Overload struct {
 	// UDFContainsOnlySignature is false, then DEFAULT expressions are included
 	// into RoutineParams.
 	DefaultExprs Exprs
+	// SecurityMode determines whether a UDF is executed with the privileges of
+	// the invoking user, or with the privileges of Owner.
+	SecurityMode RoutineSecurity
+	// Owner is the owner of a UDF. It is only set for SECURITY DEFINER
+	// routines.
+	Owner username.SQLUsername
+	// Config contains the session variables that are set while a UDF is
+	// executing, each in the form "name=value".
+	Config []string
+}
+
+// SessionOverride returns the modifications to the session that are in effect
+// while the routine is executing, or nil if there are none.
+func (b Overload) SessionOverride() *RoutineSessionOverride {
+	if b.SecurityMode != RoutineDefiner && len(b.Config) == 0 {
+		return nil
+	}
+	override := &RoutineSessionOverride{Config: b.Config}
+	if b.SecurityMode == RoutineDefiner {
+		override.User = b.Owner
+	}
+	return override
 }
 
 // params implements the overloadImpl interface.
@@ -745,6 +770,201 @@ func FirstNonNullReturnType() ReturnTyper {
 	}
 }
 
+// PolymorphicReturnType returns a ReturnTyper for a user-defined routine with
+// a polymorphic return type. The concrete return type is determined from the
+// types of the arguments passed to the polymorphic parameters of the routine.
+// UnknownReturnType is returned if the polymorphic type cannot be determined,
+// e.g. because the arguments are all NULL or do not agree on a single type.
+func PolymorphicReturnType(params []*types.T, typ *types.T) ReturnTyper {
+	return func(args []TypedExpr) *types.T {
+		if len(args) == 0 {
+			return UnknownReturnType
+		}
+		argTypes := make([]*types.T, len(args))
+		for i := range args {
+			argTypes[i] = args[i].ResolvedType()
+		}
+		bound, err := ResolvePolymorphicTypes(params, argTypes)
+		if err != nil {
+			return UnknownReturnType
+		}
+		res := ReplacePolymorphicType(typ, bound)
+		if ContainsPolymorphicType(res) || res.Family() == types.UnknownFamily {
+			return UnknownReturnType
+		}
+		return res
+	}
+}
+
+// IsPolymorphicType returns true if typ is one of the polymorphic pseudo-types
+// ANYELEMENT, ANYARRAY, ANYENUM, ANYCOMPATIBLE, or ANYCOMPATIBLEARRAY that can
+// be used in the signature of a user-defined routine.
+func IsPolymorphicType(typ *types.T) bool {
+	return isAnyElementType(typ) || IsAnyCompatibleType(typ)
+}
+
+// isAnyElementType returns true if typ is one of the polymorphic types that
+// bind a single, identical type across all arguments.
+func isAnyElementType(typ *types.T) bool {
+	switch typ.Oid() {
+	case oid.T_anyelement, oid.T_anyarray, oid.T_anyenum:
+		return true
+	}
+	return false
+}
+
+// IsAnyCompatibleType returns true if typ is one of the polymorphic types that
+// bind the common type of all arguments.
+func IsAnyCompatibleType(typ *types.T) bool {
+	switch typ.Oid() {
+	case oidext.T_anycompatible, oidext.T_anycompatiblearray:
+		return true
+	}
+	return false
+}
+
+// ContainsPolymorphicType returns true if typ is a polymorphic type, or is a
+// tuple type with a polymorphic element (as is the case for the return type of
+// a routine with polymorphic OUT parameters).
+func ContainsPolymorphicType(typ *types.T) bool {
+	if IsPolymorphicType(typ) {
+		return true
+	}
+	if typ.Family() == types.TupleFamily {
+		for _, t := range typ.TupleContents() {
+			if ContainsPolymorphicType(t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PolymorphicTypes describes the concrete types bound to the polymorphic
+// parameters of a routine for a given set of arguments. A nil field indicates
+// that the routine has no parameters of the corresponding polymorphic types,
+// and types.Unknown indicates that all of the corresponding arguments are NULL.
+type PolymorphicTypes struct {
+	// Elem is the type bound to ANYELEMENT and ANYENUM parameters. ANYARRAY
+	// parameters are bound to an array of this type.
+	Elem *types.T
+	// Compatible is the common type bound to ANYCOMPATIBLE parameters.
+	// ANYCOMPATIBLEARRAY parameters are bound to an array of this type.
+	Compatible *types.T
+}
+
+// ResolvePolymorphicTypes determines the concrete types that are bound to the
+// polymorphic parameters of a routine, given the declared parameter types and
+// the types of the arguments. As in Postgres, the two families of polymorphic
+// types are resolved independently:
+//
+//   - ANYELEMENT and ANYENUM parameters bind the type of their argument, while
+//     ANYARRAY parameters bind the element type of their argument. All of
+//     these arguments must agree on the bound type.
+//   - ANYCOMPATIBLE parameters and the elements of ANYCOMPATIBLEARRAY
+//     parameters are bound to a common type to which all of the arguments can
+//     be implicitly cast. The arguments need not have identical types.
+//
+// NULL arguments are ignored in both cases.
+func ResolvePolymorphicTypes(params []*types.T, args []*types.T) (PolymorphicTypes, error) {
+	var res PolymorphicTypes
+	for i := range params {
+		if i >= len(args) || !IsPolymorphicType(params[i]) {
+			continue
+		}
+		argType := args[i]
+		if argType.Family() != types.UnknownFamily && params[i].Family() == types.ArrayFamily {
+			if argType.Family() != types.ArrayFamily {
+				return PolymorphicTypes{}, pgerror.Newf(pgcode.DatatypeMismatch,
+					"argument declared %s is not an array but type %s",
+					params[i].SQLStandardName(), argType.SQLStandardName(),
+				)
+			}
+			argType = argType.ArrayContents()
+		}
+		if IsAnyCompatibleType(params[i]) {
+			common, err := commonCompatibleType(res.Compatible, argType)
+			if err != nil {
+				return PolymorphicTypes{}, err
+			}
+			res.Compatible = common
+			continue
+		}
+		if res.Elem == nil || res.Elem.Family() == types.UnknownFamily {
+			res.Elem = argType
+			continue
+		}
+		if argType.Family() != types.UnknownFamily && !res.Elem.Equivalent(argType) {
+			return PolymorphicTypes{}, errors.WithDetailf(
+				pgerror.New(pgcode.DatatypeMismatch, "arguments declared anyelement are not all alike"),
+				"%s versus %s", res.Elem.SQLStandardName(), argType.SQLStandardName(),
+			)
+		}
+	}
+	return res, nil
+}
+
+// commonCompatibleType returns the common type of the ANYCOMPATIBLE arguments
+// seen so far, given the common type of the previous arguments (nil if there
+// were none) and the type of the next argument. The common type is the type to
+// which both can be implicitly cast, preferring the current common type if
+// casts are possible in both directions.
+func commonCompatibleType(common, argType *types.T) (*types.T, error) {
+	if common == nil || common.Family() == types.UnknownFamily {
+		return argType, nil
+	}
+	if argType.Family() == types.UnknownFamily || argType.Identical(common) {
+		return common, nil
+	}
+	if cast.ValidCast(argType, common, cast.ContextImplicit) {
+		return common, nil
+	}
+	if cast.ValidCast(common, argType, cast.ContextImplicit) {
+		return argType, nil
+	}
+	return nil, errors.WithDetailf(
+		pgerror.New(pgcode.DatatypeMismatch, "arguments declared anycompatible cannot be cast to a common type"),
+		"%s versus %s", common.SQLStandardName(), argType.SQLStandardName(),
+	)
+}
+
+// ReplacePolymorphicType returns typ with any polymorphic types replaced by the
+// concrete types bound to them, which are determined by
+// ResolvePolymorphicTypes. Tuple types are handled recursively. Polymorphic
+// types without a bound type are returned unchanged.
+func ReplacePolymorphicType(typ *types.T, bound PolymorphicTypes) *types.T {
+	bind := func(elemType *types.T, isArray bool) *types.T {
+		if elemType == nil {
+			return typ
+		}
+		if !isArray {
+			return elemType
+		}
+		if elemType.Family() == types.UnknownFamily {
+			return types.Unknown
+		}
+		return types.MakeArray(elemType)
+	}
+	switch typ.Oid() {
+	case oid.T_anyelement, oid.T_anyenum:
+		return bind(bound.Elem, false /* isArray */)
+	case oid.T_anyarray:
+		return bind(bound.Elem, true /* isArray */)
+	case oidext.T_anycompatible:
+		return bind(bound.Compatible, false /* isArray */)
+	case oidext.T_anycompatiblearray:
+		return bind(bound.Compatible, true /* isArray */)
+	}
+	if typ.Family() == types.TupleFamily && ContainsPolymorphicType(typ) {
+		contents := make([]*types.T, len(typ.TupleContents()))
+		for i, t := range typ.TupleContents() {
+			contents[i] = ReplacePolymorphicType(t, bound)
+		}
+		return types.MakeLabeledTuple(contents, typ.TupleLabels())
+	}
+	return typ
+}
+
 func returnTypeToFixedType(s ReturnTyper, inputTyps []TypedExpr) *types.T {
 	if t := s(inputTyps); t != UnknownReturnType {
 		return t
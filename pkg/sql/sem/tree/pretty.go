@@ -694,6 +694,11 @@ func (node *Subquery) doc(p *PrettyCfg) pretty.Doc {
 			pretty.Keyword("EXISTS"),
 			d,
 		)
+	} else if node.Unique {
+		d = pretty.Concat(
+			pretty.Keyword("UNIQUE"),
+			d,
+		)
 	}
 	return d
 }
@@ -14,6 +14,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
@@ -141,6 +142,11 @@ type RoutineExpr struct {
 	// CursorDeclaration contains the information needed to open a SQL cursor with
 	// the result of the *first* body statement. It may be unset.
 	CursorDeclaration *RoutineOpenCursor
+
+	// SessionOverride, if set, describes changes to the session that are in
+	// effect while the routine is executing. It is set for SECURITY DEFINER
+	// routines and routines with SET clauses.
+	SessionOverride *RoutineSessionOverride
 }
 
 // NewTypedRoutineExpr returns a new RoutineExpr that is well-typed.
@@ -228,6 +234,19 @@ type RoutineOpenCursor struct {
 	CursorSQL string
 }
 
+// RoutineSessionOverride describes how the session of the invoking statement is
+// modified while a routine is executing. The modifications are reverted once the
+// routine returns.
+type RoutineSessionOverride struct {
+	// User, if set, is the user that the routine is executed as. It is the owner
+	// of a SECURITY DEFINER routine.
+	User username.SQLUsername
+
+	// Config contains the session variables that are set while the routine is
+	// executing, each in the form "name=value".
+	Config []string
+}
+
 // BlockState is shared state between all routines that make up a PLpgSQL block.
 // It allows for coordination between the routines for exception handling.
 type BlockState struct {
@@ -315,7 +334,11 @@ type TxnControlPlanGenerator func(
 // the session to end the current transaction, and provides a plan to resume
 // execution in a new transaction in the form of StoredProcContinuation.
 type TxnControlExpr struct {
-	Op    StoredProcTxnOp
+	Op StoredProcTxnOp
+	// Chain is true for COMMIT AND CHAIN and ROLLBACK AND CHAIN. In this case,
+	// the new transaction has the same characteristics as the current one,
+	// except where they are overridden by Modes.
+	Chain bool
 	Modes TransactionModes
 	Args  TypedExprs
 	Gen   TxnControlPlanGenerator
@@ -329,6 +352,7 @@ var _ Expr = &TxnControlExpr{}
 // NewTxnControlExpr returns a new TxnControlExpr that is well-typed.
 func NewTxnControlExpr(
 	opType StoredProcTxnOp,
+	chain bool,
 	txnModes TransactionModes,
 	args TypedExprs,
 	gen TxnControlPlanGenerator,
@@ -337,6 +361,7 @@ func NewTxnControlExpr(
 ) *TxnControlExpr {
 	return &TxnControlExpr{
 		Op:    opType,
+		Chain: chain,
 		Modes: txnModes,
 		Args:  args,
 		Gen:   gen,
@@ -364,7 +389,11 @@ func (node *TxnControlExpr) Format(ctx *FmtCtx) {
 			panic(errors.AssertionFailedf("called Format for no-op txn control expr"))
 		}
 	}
-	ctx.Printf("%s; CALL %s(", node.Op, node.Name)
+	ctx.Printf("%s", node.Op)
+	if node.Chain {
+		ctx.WriteString(" AND CHAIN")
+	}
+	ctx.Printf("; CALL %s(", node.Name)
 	ctx.FormatNode(&node.Args)
 	ctx.WriteByte(')')
 }
@@ -0,0 +1,178 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// TextSearchObjectKind is the kind of a text search object.
+type TextSearchObjectKind uint8
+
+const (
+	// TextSearchConfiguration is a text search configuration.
+	TextSearchConfiguration TextSearchObjectKind = iota
+	// TextSearchDictionary is a text search dictionary.
+	TextSearchDictionary
+)
+
+// String implements the fmt.Stringer interface.
+func (k TextSearchObjectKind) String() string {
+	if k == TextSearchDictionary {
+		return "DICTIONARY"
+	}
+	return "CONFIGURATION"
+}
+
+// CreateTextSearchDictionary represents a CREATE TEXT SEARCH DICTIONARY
+// statement.
+type CreateTextSearchDictionary struct {
+	Name *UnresolvedObjectName
+	// Options contains the TEMPLATE option followed by the options of the
+	// template.
+	Options StorageParams
+}
+
+var _ Statement = &CreateTextSearchDictionary{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTextSearchDictionary) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TEXT SEARCH DICTIONARY ")
+	ctx.FormatNode(node.Name)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteString(")")
+}
+
+// CreateTextSearchConfiguration represents a CREATE TEXT SEARCH CONFIGURATION
+// statement.
+type CreateTextSearchConfiguration struct {
+	Name *UnresolvedObjectName
+	// Copy is the configuration whose mappings are copied. If it is nil, the
+	// configuration is created with the default parser and no mappings.
+	Copy *UnresolvedObjectName
+}
+
+var _ Statement = &CreateTextSearchConfiguration{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTextSearchConfiguration) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TEXT SEARCH CONFIGURATION ")
+	ctx.FormatNode(node.Name)
+	if node.Copy != nil {
+		ctx.WriteString(" (COPY = ")
+		ctx.FormatNode(node.Copy)
+		ctx.WriteString(")")
+	} else {
+		ctx.WriteString(" (PARSER = default)")
+	}
+}
+
+// AlterTextSearchMappingAction is the action performed by an ALTER TEXT
+// SEARCH CONFIGURATION statement.
+type AlterTextSearchMappingAction uint8
+
+const (
+	// AlterTextSearchAddMapping adds mappings for token types which have none.
+	AlterTextSearchAddMapping AlterTextSearchMappingAction = iota
+	// AlterTextSearchAlterMapping replaces the dictionaries of existing
+	// mappings.
+	AlterTextSearchAlterMapping
+	// AlterTextSearchReplaceDictionary replaces one dictionary with another in
+	// existing mappings.
+	AlterTextSearchReplaceDictionary
+	// AlterTextSearchDropMapping removes mappings.
+	AlterTextSearchDropMapping
+)
+
+// AlterTextSearchConfiguration represents an ALTER TEXT SEARCH CONFIGURATION
+// statement which changes the mappings of the configuration.
+type AlterTextSearchConfiguration struct {
+	Name   *UnresolvedObjectName
+	Action AlterTextSearchMappingAction
+	// TokenTypes is empty when all the mappings are affected, which is only
+	// allowed for AlterTextSearchReplaceDictionary.
+	TokenTypes NameList
+	// Dictionaries is set for AlterTextSearchAddMapping and
+	// AlterTextSearchAlterMapping.
+	Dictionaries []*UnresolvedObjectName
+	// OldDictionary and NewDictionary are set for
+	// AlterTextSearchReplaceDictionary.
+	OldDictionary *UnresolvedObjectName
+	NewDictionary *UnresolvedObjectName
+	// IfExists is only set for AlterTextSearchDropMapping.
+	IfExists bool
+}
+
+var _ Statement = &AlterTextSearchConfiguration{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearchConfiguration) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER TEXT SEARCH CONFIGURATION ")
+	ctx.FormatNode(node.Name)
+	switch node.Action {
+	case AlterTextSearchAddMapping:
+		ctx.WriteString(" ADD MAPPING")
+	case AlterTextSearchAlterMapping, AlterTextSearchReplaceDictionary:
+		ctx.WriteString(" ALTER MAPPING")
+	case AlterTextSearchDropMapping:
+		ctx.WriteString(" DROP MAPPING")
+		if node.IfExists {
+			ctx.WriteString(" IF EXISTS")
+		}
+	}
+	if len(node.TokenTypes) > 0 {
+		ctx.WriteString(" FOR ")
+		ctx.FormatNode(&node.TokenTypes)
+	}
+	switch node.Action {
+	case AlterTextSearchAddMapping, AlterTextSearchAlterMapping:
+		ctx.WriteString(" WITH ")
+		for i, d := range node.Dictionaries {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(d)
+		}
+	case AlterTextSearchReplaceDictionary:
+		ctx.WriteString(" REPLACE ")
+		ctx.FormatNode(node.OldDictionary)
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(node.NewDictionary)
+	}
+}
+
+// DropTextSearchObject represents a DROP TEXT SEARCH CONFIGURATION or DROP
+// TEXT SEARCH DICTIONARY statement.
+type DropTextSearchObject struct {
+	Kind         TextSearchObjectKind
+	Names        []*UnresolvedObjectName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropTextSearchObject{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTextSearchObject) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TEXT SEARCH ")
+	ctx.WriteString(node.Kind.String())
+	ctx.WriteByte(' ')
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	for i, name := range node.Names {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(name)
+	}
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
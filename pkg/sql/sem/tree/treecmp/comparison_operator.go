@@ -70,6 +70,10 @@ const (
 	JSONAllExists
 	Overlaps
 	TSMatches
+	WordSimilar
+	WordSimilarCommutator
+	StrictWordSimilar
+	StrictWordSimilarCommutator
 
 	// The following operators will always be used with an associated SubOperator.
 	// If Go had algebraic data types they would be defined in a self-contained
@@ -109,22 +113,26 @@ var comparisonOpName = [...]string{
 	SimilarTo:    "SIMILAR TO",
 	NotSimilarTo: "NOT SIMILAR TO",
 	// TODO(otan): come up with a better name than RegMatch, as it also covers GeoContains.
-	RegMatch:          "~",
-	NotRegMatch:       "!~",
-	RegIMatch:         "~*",
-	NotRegIMatch:      "!~*",
-	IsDistinctFrom:    "IS DISTINCT FROM",
-	IsNotDistinctFrom: "IS NOT DISTINCT FROM",
-	Contains:          "@>",
-	ContainedBy:       "<@",
-	JSONExists:        "?",
-	JSONSomeExists:    "?|",
-	JSONAllExists:     "?&",
-	Overlaps:          "&&",
-	TSMatches:         "@@",
-	Any:               "ANY",
-	Some:              "SOME",
-	All:               "ALL",
+	RegMatch:                    "~",
+	NotRegMatch:                 "!~",
+	RegIMatch:                   "~*",
+	NotRegIMatch:                "!~*",
+	IsDistinctFrom:              "IS DISTINCT FROM",
+	IsNotDistinctFrom:           "IS NOT DISTINCT FROM",
+	Contains:                    "@>",
+	ContainedBy:                 "<@",
+	JSONExists:                  "?",
+	JSONSomeExists:              "?|",
+	JSONAllExists:               "?&",
+	Overlaps:                    "&&",
+	TSMatches:                   "@@",
+	WordSimilar:                 "<%",
+	WordSimilarCommutator:       "%>",
+	StrictWordSimilar:           "<<%",
+	StrictWordSimilarCommutator: "%>>",
+	Any:                         "ANY",
+	Some:                        "SOME",
+	All:                         "ALL",
 }
 
 func (i ComparisonOperatorSymbol) String() string {
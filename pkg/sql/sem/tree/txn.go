@@ -276,19 +276,35 @@ func (node *BeginTransaction) Format(ctx *FmtCtx) {
 }
 
 // CommitTransaction represents a COMMIT statement.
-type CommitTransaction struct{}
+type CommitTransaction struct {
+	// Chain is true if the statement was COMMIT AND CHAIN, in which case a new
+	// transaction with the same characteristics is started immediately after
+	// the current one commits.
+	Chain bool
+}
 
 // Format implements the NodeFormatter interface.
 func (node *CommitTransaction) Format(ctx *FmtCtx) {
 	ctx.WriteString("COMMIT TRANSACTION")
+	if node.Chain {
+		ctx.WriteString(" AND CHAIN")
+	}
 }
 
 // RollbackTransaction represents a ROLLBACK statement.
-type RollbackTransaction struct{}
+type RollbackTransaction struct {
+	// Chain is true if the statement was ROLLBACK AND CHAIN, in which case a
+	// new transaction with the same characteristics is started immediately
+	// after the current one is rolled back.
+	Chain bool
+}
 
 // Format implements the NodeFormatter interface.
 func (node *RollbackTransaction) Format(ctx *FmtCtx) {
 	ctx.WriteString("ROLLBACK TRANSACTION")
+	if node.Chain {
+		ctx.WriteString(" AND CHAIN")
+	}
 }
 
 // Savepoint represents a SAVEPOINT <name> statement.
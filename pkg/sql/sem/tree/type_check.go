@@ -69,6 +69,9 @@ type SemaContext struct {
 	// name of a table given its ID.
 	NameResolver QualifiedNameResolver
 
+	// CastResolver is used to look up user-defined casts. It may be unset.
+	CastResolver CastResolver
+
 	Properties SemaProperties
 
 	// DateStyle refers to the DateStyle to parse as.
@@ -270,10 +273,12 @@ func MakeSemaContext(resolver interface{}) SemaContext {
 	typeResolver, _ := resolver.(TypeReferenceResolver)
 	functionResolver, _ := resolver.(FunctionReferenceResolver)
 	nameResolver, _ := resolver.(QualifiedNameResolver)
+	castResolver, _ := resolver.(CastResolver)
 	return SemaContext{
 		TypeResolver:     typeResolver,
 		FunctionResolver: functionResolver,
 		NameResolver:     nameResolver,
+		CastResolver:     castResolver,
 	}
 }
 
@@ -687,6 +692,16 @@ func (expr *CastExpr) TypeCheck(
 		allowStable = false
 		context = semaCtx.Properties.required.context
 	}
+
+	// User-defined casts take precedence over builtin casts.
+	udc, err := ResolveUserDefinedCast(ctx, semaCtx, castFrom, exprType, cast.ContextExplicit)
+	if err != nil {
+		return nil, err
+	}
+	if udc != nil {
+		return typeCheckUserDefinedCast(ctx, semaCtx, typedSubExpr, exprType, udc, context, allowStable)
+	}
+
 	err = resolveCast(context, castFrom, exprType, allowStable)
 	if err != nil {
 		return nil, err
@@ -697,6 +712,51 @@ func (expr *CastExpr) TypeCheck(
 	return expr, nil
 }
 
+// typeCheckUserDefinedCast returns an expression which applies the given
+// user-defined cast to expr. A cast backed by a function becomes a call to the
+// function, and an INOUT cast becomes a cast to STRING followed by a cast to
+// the target type.
+func typeCheckUserDefinedCast(
+	ctx context.Context,
+	semaCtx *SemaContext,
+	expr TypedExpr,
+	to *types.T,
+	udc *UserDefinedCast,
+	requiredContext string,
+	allowStable bool,
+) (TypedExpr, error) {
+	switch udc.Method {
+	case CastMethodFunction:
+		fn := &FuncExpr{
+			Func:  ResolvableFunctionReference{FunctionReference: &FunctionOID{OID: udc.FuncOID}},
+			Exprs: Exprs{expr},
+		}
+		typedFn, err := fn.TypeCheck(ctx, semaCtx, to)
+		if err != nil {
+			return nil, err
+		}
+		if typedFn.ResolvedType().Identical(to) {
+			return typedFn, nil
+		}
+		// The function returns the target type without its modifiers, e.g.
+		// STRING instead of VARCHAR(3). Apply them with a builtin cast.
+		if err := resolveCast(requiredContext, typedFn.ResolvedType(), to, allowStable); err != nil {
+			return nil, err
+		}
+		return NewTypedCastExpr(typedFn, to), nil
+	case CastMethodInOut:
+		if err := resolveCast(requiredContext, expr.ResolvedType(), types.String, allowStable); err != nil {
+			return nil, err
+		}
+		if err := resolveCast(requiredContext, types.String, to, allowStable); err != nil {
+			return nil, err
+		}
+		return NewTypedCastExpr(NewTypedCastExpr(expr, types.String), to), nil
+	default:
+		return nil, errors.AssertionFailedf("unexpected user-defined cast method %d", udc.Method)
+	}
+}
+
 // TypeCheck implements the Expr interface.
 func (expr *IndirectionExpr) TypeCheck(
 	ctx context.Context, semaCtx *SemaContext, desired *types.T,
@@ -2717,6 +2777,17 @@ func typeCheckComparisonOp(
 	if len(s.overloadIdxs) != 1 || typeMismatch {
 		sig := fmt.Sprintf(compSignatureFmt, leftReturn, op, rightReturn)
 		if len(s.overloadIdxs) == 0 || typeMismatch {
+			if !nullComparison {
+				// Retry with an implicit user-defined cast of one side to the type
+				// of the other, if there is one.
+				newLeft, newRight, ok, err := applyImplicitUserDefinedCast(ctx, semaCtx, leftExpr, rightExpr)
+				if err != nil {
+					return nil, nil, nil, false, err
+				}
+				if ok {
+					return typeCheckComparisonOp(ctx, semaCtx, op, newLeft, newRight, params...)
+				}
+			}
 			// For some typeMismatch errors, we want to emit a more specific error
 			// message than "unknown comparison". In particular, comparison between
 			// two different enum types is invalid, rather than just unsupported.
@@ -2735,6 +2806,30 @@ func typeCheckComparisonOp(
 	return leftExpr, rightExpr, ops.overloads[s.overloadIdxs[0]], false, nil
 }
 
+// applyImplicitUserDefinedCast wraps left or right in a cast to the type of
+// the other expression if there is a user-defined cast between their types
+// which can be applied implicitly. It returns false if there is no such cast.
+func applyImplicitUserDefinedCast(
+	ctx context.Context, semaCtx *SemaContext, left, right TypedExpr,
+) (_, _ Expr, ok bool, _ error) {
+	leftType, rightType := left.ResolvedType(), right.ResolvedType()
+	udc, err := ResolveUserDefinedCast(ctx, semaCtx, leftType, rightType, cast.ContextImplicit)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if udc != nil {
+		return &CastExpr{Expr: left, Type: rightType, SyntaxMode: CastShort}, right, true, nil
+	}
+	udc, err = ResolveUserDefinedCast(ctx, semaCtx, rightType, leftType, cast.ContextImplicit)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if udc != nil {
+		return left, &CastExpr{Expr: right, Type: leftType, SyntaxMode: CastShort}, true, nil
+	}
+	return nil, nil, false, nil
+}
+
 type typeCheckExprsState struct {
 	ctx     context.Context
 	semaCtx *SemaContext
@@ -13,12 +13,14 @@ package sql
 import (
 	"context"
 	encjson "encoding/json"
+	"fmt"
 	"sort"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -101,7 +103,7 @@ func (p *planner) ShowTableStats(ctx context.Context, n *tree.ShowTableStats) (p
 			//    "handle" which can be used with SHOW HISTOGRAM.
 			// TODO(yuzefovich): refactor the code to use the iterator API
 			// (currently it is not possible due to a panic-catcher below).
-			stmt := `SELECT
+			stmt := fmt.Sprintf(`SELECT
 							"tableID",
 							"statisticID",
 							name,
@@ -113,10 +115,13 @@ func (p *planner) ShowTableStats(ctx context.Context, n *tree.ShowTableStats) (p
 							"avgSize",
 							"partialPredicate",
 							histogram,
-							"fullStatisticID"
+							"fullStatisticID",
+							%s
 						FROM system.table_statistics
 						WHERE "tableID" = $1
-						ORDER BY "createdAt", "columnIDs", "statisticID"`
+						ORDER BY "createdAt", "columnIDs", "statisticID"`,
+				stats.ExpressionColumnOrNull(ctx, p.ExecCfg().Settings),
+			)
 
 			// There is a privilege check above to make sure the user has any
 			// privilege on the table being inspected. We use the node user to execute
@@ -147,6 +152,7 @@ func (p *planner) ShowTableStats(ctx context.Context, n *tree.ShowTableStats) (p
 				partialPredicateIdx
 				histogramIdx
 				fullStatisticIDIdx
+				expressionIdx
 				numCols
 			)
 
@@ -270,6 +276,9 @@ func (p *planner) ShowTableStats(ctx context.Context, n *tree.ShowTableStats) (p
 						statsRow.PartialPredicate = string(*r[partialPredicateIdx].(*tree.DString))
 						statsRow.FullStatisticID = (uint64)(*r[fullStatisticIDIdx].(*tree.DInt))
 					}
+					if r[expressionIdx] != tree.DNull {
+						statsRow.Expression = string(*r[expressionIdx].(*tree.DString))
+					}
 					if err := statsRow.DecodeAndSetHistogram(ctx, &p.semaCtx, r[histIdx]); err != nil {
 						v.Close(ctx)
 						return nil, err
@@ -316,6 +325,21 @@ func (p *planner) ShowTableStats(ctx context.Context, n *tree.ShowTableStats) (p
 				if ignoreStatsRowWithDroppedColumn {
 					continue
 				}
+				if r[expressionIdx] != tree.DNull {
+					// Statistics on an expression are shown with the expression in
+					// place of the columns it references, formatted like the element
+					// of an expression index.
+					exprStr := string(*r[expressionIdx].(*tree.DString))
+					expr, err := schemaexpr.FormatExprForExpressionIndexDisplay(
+						ctx, desc, exprStr, &p.semaCtx, p.SessionData(), tree.FmtParsable,
+					)
+					if err != nil {
+						// The expression may no longer resolve if a column it references
+						// was renamed.
+						expr = "(" + exprStr + ")"
+					}
+					colNames.Array = tree.Datums{tree.NewDString(expr)}
+				}
 
 				createdAt := r[createdAtIdx].(*tree.DTimestamp)
 				createdAtTZ, err := createdAt.AddTimeZone(time.UTC, time.Microsecond)
@@ -403,5 +427,10 @@ func tableStatisticProtoToRow(stat *stats.TableStatisticProto) (tree.Datums, err
 		row = append(row, tree.NewDBytes(tree.DBytes(histogram)))
 	}
 	row = append(row, FullStatisticID)
+	if stat.Expression == "" {
+		row = append(row, tree.DNull)
+	} else {
+		row = append(row, tree.NewDString(stat.Expression))
+	}
 	return row, nil
 }
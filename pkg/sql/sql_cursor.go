@@ -81,6 +81,7 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 			pt.init(&stmt, &p.instrumentation)
 			opc := &p.optPlanningCtx
 			opc.p.stmt = stmt
+			opc.parseHints(ctx)
 			opc.reset(ctx)
 
 			memo, err := opc.buildExecMemo(ctx)
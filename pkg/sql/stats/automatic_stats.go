@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
@@ -73,6 +75,17 @@ var MultiColumnStatisticsClusterMode = settings.RegisterBoolSetting(
 	true,
 	settings.WithPublic)
 
+// AutomaticTargetedStatisticsClusterMode controls the cluster setting for
+// enabling automatic collection of multi-column statistics on the column sets
+// whose selectivity was misestimated during query execution.
+var AutomaticTargetedStatisticsClusterMode = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.stats.automatic_targeted_collection.enabled",
+	"when true, multi-column statistics are automatically collected on column sets "+
+		"whose selectivity was misestimated during query execution",
+	false,
+)
+
 // AutomaticStatisticsMaxIdleTime controls the maximum fraction of time that
 // the sampler processors will be idle when scanning large tables for automatic
 // statistics (in high load scenarios). This value can be tuned to trade off
@@ -162,6 +175,12 @@ const (
 	// in the buffer and makes space for new ones. SQL mutations will never block
 	// waiting on the refresher.
 	refreshChanBufferLen = 256
+
+	// maxMisestimatedColumnSets is the maximum number of misestimated column
+	// sets of a table that are collected by the refresher between two runs.
+	// Each of them can result in a CREATE STATISTICS job, so any additional
+	// column sets are ignored until the next run.
+	maxMisestimatedColumnSets = 8
 )
 
 // Refresher is responsible for automatically refreshing the table statistics
@@ -208,6 +227,16 @@ const (
 // AS OF SYSTEM TIME ‘-30s’ to minimize performance impact on running
 // transactions.
 //
+// Additionally, if sql.stats.automatic_targeted_collection.enabled is true,
+// the Refresher collects multi-column statistics on the column sets whose
+// selectivity turned out to be misestimated during query execution (which is
+// signaled by calling NotifyMisestimate). By default, multi-column statistics
+// are only collected on index prefixes, so the estimates for filters on other
+// correlated columns rely on an independence assumption. Such targeted
+// statistics are not refreshed automatically; they are deleted once they are
+// older than sql.stats.non_default_columns.min_retention_period, and collected
+// again if the misestimates persist.
+//
 // To avoid adding latency to SQL mutation operations, the Refresher is run
 // in one separate background thread per Server. SQL mutation operations signal
 // to the Refresher thread by calling NotifyMutation, which sends mutation
@@ -230,6 +259,10 @@ type Refresher struct {
 	// autostats setting override information to the background Refresher thread.
 	settings chan settingOverride
 
+	// misestimates is the buffered channel used to pass messages containing
+	// misestimated column sets to the background Refresher thread.
+	misestimates chan misestimate
+
 	// asOfTime is a duration which is used to define the AS OF time for
 	// runs of CREATE STATISTICS by the Refresher.
 	asOfTime time.Duration
@@ -248,6 +281,10 @@ type Refresher struct {
 	// table.
 	settingOverrides map[descpb.ID]catpb.AutoStatsSettings
 
+	// misestimatedColumns contains the misestimated column sets for each table
+	// that have yet to be processed by the refresher.
+	misestimatedColumns map[descpb.ID][][]descpb.ColumnID
+
 	// numTablesEnsured is an internal counter for testing ensureAllTables.
 	numTablesEnsured int
 
@@ -279,6 +316,15 @@ type settingOverride struct {
 	settings catpb.AutoStatsSettings
 }
 
+// misestimate contains a set of columns of a table (sorted by ID) that were
+// constrained by a filter whose selectivity was misestimated. It is the
+// message passed to the background refresher thread to (possibly) trigger the
+// collection of a multi-column statistic.
+type misestimate struct {
+	tableID   descpb.ID
+	columnIDs []descpb.ColumnID
+}
+
 // TableStatsTestingKnobs contains testing knobs for table statistics.
 type TableStatsTestingKnobs struct {
 	// DisableInitialTableCollection, if set, indicates that the "initial table
@@ -302,19 +348,21 @@ func MakeRefresher(
 	randSource := rand.NewSource(rand.Int63())
 
 	return &Refresher{
-		AmbientContext:   ambientCtx,
-		st:               st,
-		internalDB:       internalDB,
-		cache:            cache,
-		randGen:          makeAutoStatsRand(randSource),
-		knobs:            knobs,
-		mutations:        make(chan mutation, refreshChanBufferLen),
-		settings:         make(chan settingOverride, refreshChanBufferLen),
-		asOfTime:         asOfTime,
-		extraTime:        time.Duration(rand.Int63n(int64(time.Hour))),
-		mutationCounts:   make(map[descpb.ID]int64, 16),
-		settingOverrides: make(map[descpb.ID]catpb.AutoStatsSettings),
-		drainAutoStats:   make(chan struct{}),
+		AmbientContext:      ambientCtx,
+		st:                  st,
+		internalDB:          internalDB,
+		cache:               cache,
+		randGen:             makeAutoStatsRand(randSource),
+		knobs:               knobs,
+		mutations:           make(chan mutation, refreshChanBufferLen),
+		settings:            make(chan settingOverride, refreshChanBufferLen),
+		misestimates:        make(chan misestimate, refreshChanBufferLen),
+		asOfTime:            asOfTime,
+		extraTime:           time.Duration(rand.Int63n(int64(time.Hour))),
+		mutationCounts:      make(map[descpb.ID]int64, 16),
+		settingOverrides:    make(map[descpb.ID]catpb.AutoStatsSettings),
+		misestimatedColumns: make(map[descpb.ID][][]descpb.ColumnID),
+		drainAutoStats:      make(chan struct{}),
 	}
 }
 
@@ -453,6 +501,7 @@ func (r *Refresher) Start(
 
 			case <-timer.C:
 				mutationCounts := r.mutationCounts
+				misestimatedColumns := r.misestimatedColumns
 				refreshingAllTables := ensuringAllTables
 				ensuringAllTables = false
 
@@ -541,6 +590,17 @@ func (r *Refresher) Start(
 							default:
 							}
 						}
+						for tableID, columnSets := range misestimatedColumns {
+							r.maybeCreateTargetedStats(ctx, tableID, columnSets, r.asOfTime)
+
+							select {
+							case <-ctx.Done():
+								return
+							case <-r.drainAutoStats:
+								return
+							default:
+							}
+						}
 						timer.Reset(refreshInterval)
 					}); err != nil {
 					r.startedTasksWG.Done()
@@ -552,6 +612,7 @@ func (r *Refresher) Start(
 				// This is by design. We don't want to constantly refresh tables that
 				// are read-only.
 				r.mutationCounts = make(map[descpb.ID]int64, len(r.mutationCounts))
+				r.misestimatedColumns = make(map[descpb.ID][][]descpb.ColumnID)
 
 			case mut := <-r.mutations:
 				r.mutationCounts[mut.tableID] += int64(mut.rowsAffected)
@@ -565,6 +626,9 @@ func (r *Refresher) Start(
 			case clusterSettingOverride := <-r.settings:
 				r.settingOverrides[clusterSettingOverride.tableID] = clusterSettingOverride.settings
 
+			case m := <-r.misestimates:
+				r.addMisestimate(m)
+
 			case <-r.drainAutoStats:
 				log.Infof(ctx, "draining auto stats refresher")
 				return
@@ -748,6 +812,98 @@ func (r *Refresher) NotifyMutation(table catalog.TableDescriptor, rowsAffected i
 	}
 }
 
+// NotifyMisestimate is called after the execution of a statement to signal to
+// the Refresher that the selectivity of a filter constraining the given
+// columns of a table was misestimated. The Refresher then (possibly) collects
+// a multi-column statistic on these columns.
+func (r *Refresher) NotifyMisestimate(tableID descpb.ID, columnIDs []descpb.ColumnID) {
+	if !AutomaticTargetedStatisticsClusterMode.Get(&r.st.SV) ||
+		!AutomaticStatisticsClusterMode.Get(&r.st.SV) {
+		return
+	}
+	sorted := make([]descpb.ColumnID, len(columnIDs))
+	copy(sorted, columnIDs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	// Send the misestimate to the refresher thread to avoid adding latency to
+	// the calling statement.
+	select {
+	case r.misestimates <- misestimate{tableID: tableID, columnIDs: sorted}:
+	default:
+		// Don't block if there is no room in the buffered channel.
+		if bufferedChanFullLogLimiter.ShouldLog() {
+			log.Warningf(context.TODO(),
+				"buffered channel is full. Unable to record misestimated columns %v of table %d",
+				sorted, tableID)
+		}
+	}
+}
+
+// addMisestimate records the misestimated column set of a table, unless it is
+// already recorded or the table already has maxMisestimatedColumnSets column
+// sets. It is called by the background Refresher thread.
+func (r *Refresher) addMisestimate(m misestimate) {
+	columnSets := r.misestimatedColumns[m.tableID]
+	if len(columnSets) < maxMisestimatedColumnSets && !containsColumnSet(columnSets, m.columnIDs) {
+		r.misestimatedColumns[m.tableID] = append(columnSets, m.columnIDs)
+	}
+}
+
+// maybeCreateTargetedStats collects multi-column statistics on the given
+// misestimated column sets of a table. It is called by the background
+// Refresher thread.
+//
+// A column set is skipped if it already has a statistic that is at least as
+// recent as the latest automatic refresh of the table, since collecting it
+// again would not improve the estimates. All column sets are skipped if the
+// table has not been refreshed automatically yet.
+func (r *Refresher) maybeCreateTargetedStats(
+	ctx context.Context, tableID descpb.ID, columnSets [][]descpb.ColumnID, asOf time.Duration,
+) {
+	if !AutomaticTargetedStatisticsClusterMode.Get(&r.st.SV) {
+		return
+	}
+	desc := r.getTableDescriptor(ctx, tableID)
+	if desc == nil || !r.autoStatsEnabled(desc) || !autostatsCollectionAllowed(desc, r.st) {
+		return
+	}
+	tableStats, err := r.cache.getTableStatsFromCache(ctx, tableID, nil /* forecast */)
+	if err != nil {
+		log.Errorf(ctx, "failed to get table statistics: %v", err)
+		return
+	}
+	autoStat := mostRecentAutomaticStat(tableStats)
+	if autoStat == nil {
+		return
+	}
+	for _, columnIDs := range columnSets {
+		if hasStatOnColumnSetSince(tableStats, columnIDs, autoStat.CreatedAt) {
+			continue
+		}
+		columnNames := make(tree.NameList, 0, len(columnIDs))
+		for _, id := range columnIDs {
+			col := catalog.FindColumnByID(desc, id)
+			if col == nil || !col.Public() {
+				// The column was dropped after the misestimate.
+				break
+			}
+			columnNames = append(columnNames, tree.Name(col.GetName()))
+		}
+		if len(columnNames) != len(columnIDs) {
+			continue
+		}
+		if err := r.createTargetedStats(ctx, tableID, columnNames, asOf); err != nil {
+			// The column set is not rescheduled; it will be reported again if
+			// it is still misestimated.
+			log.Warningf(ctx, "failed to create statistics on columns %v of table %d: %v",
+				columnIDs, tableID, err)
+			if errors.Is(err, ConcurrentCreateStatsError) {
+				return
+			}
+		}
+	}
+}
+
 // maybeRefreshStats implements the core logic described in the comment for
 // Refresher. It is called by the background Refresher thread.
 // explicitSettings, if non-nil, holds any autostats cluster setting overrides
@@ -872,6 +1028,28 @@ func (r *Refresher) refreshStats(ctx context.Context, tableID descpb.ID, asOf ti
 	return err
 }
 
+func (r *Refresher) createTargetedStats(
+	ctx context.Context, tableID descpb.ID, columnNames tree.NameList, asOf time.Duration,
+) error {
+	// Create a statistic on the given column set only.
+	stmt := fmt.Sprintf(
+		"CREATE STATISTICS %s ON %s FROM [%d] WITH OPTIONS THROTTLING %g AS OF SYSTEM TIME '-%s'",
+		jobspb.AutoTargetedStatsName,
+		tree.AsString(&columnNames),
+		tableID,
+		AutomaticStatisticsMaxIdleTime.Get(&r.st.SV),
+		asOf.String(),
+	)
+	log.Infof(ctx, "automatically executing %q", stmt)
+	_ /* rows */, err := r.internalDB.Executor().Exec(
+		ctx,
+		"create-targeted-stats",
+		nil, /* txn */
+		stmt,
+	)
+	return err
+}
+
 // mostRecentAutomaticStat finds the most recent automatic statistic
 // (identified by the name AutoStatsName).
 func mostRecentAutomaticStat(tableStats []*TableStatistic) *TableStatistic {
@@ -897,7 +1075,7 @@ func avgFullRefreshTime(tableStats []*TableStatistic) time.Duration {
 	var sum time.Duration
 	var count int
 	for _, stat := range tableStats {
-		if !stat.IsAuto() || stat.IsPartial() {
+		if !stat.IsAuto() || stat.IsPartial() || stat.IsExpression() {
 			continue
 		}
 		if reference == nil {
@@ -921,6 +1099,38 @@ func avgFullRefreshTime(tableStats []*TableStatistic) time.Duration {
 	return sum / time.Duration(count)
 }
 
+// hasStatOnColumnSetSince returns whether there is a full statistic on
+// exactly the given (sorted) set of columns created no earlier than the given
+// time.
+func hasStatOnColumnSetSince(
+	tableStats []*TableStatistic, columnIDs []descpb.ColumnID, since time.Time,
+) bool {
+	for _, stat := range tableStats {
+		if stat.IsPartial() || stat.IsExpression() || stat.CreatedAt.Before(since) ||
+			len(stat.ColumnIDs) != len(columnIDs) {
+			continue
+		}
+		sorted := make([]descpb.ColumnID, len(stat.ColumnIDs))
+		copy(sorted, stat.ColumnIDs)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		if areEqual(sorted, columnIDs) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsColumnSet returns whether the given column set is one of
+// columnSets.
+func containsColumnSet(columnSets [][]descpb.ColumnID, columnIDs []descpb.ColumnID) bool {
+	for _, s := range columnSets {
+		if areEqual(s, columnIDs) {
+			return true
+		}
+	}
+	return false
+}
+
 func areEqual(a, b []descpb.ColumnID) bool {
 	if len(a) != len(b) {
 		return false
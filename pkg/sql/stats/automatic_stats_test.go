@@ -781,6 +781,102 @@ func TestMutationsAndSettingOverrideChannels(t *testing.T) {
 	}
 }
 
+func TestTargetedStats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	srv, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+	codec, st := s.Codec(), s.ClusterSettings()
+
+	AutomaticStatisticsClusterMode.Override(ctx, &st.SV, false)
+	evalCtx := eval.NewTestingEvalContext(st)
+	defer evalCtx.Stop(ctx)
+
+	sqlRun := sqlutils.MakeSQLRunner(sqlDB)
+	sqlRun.Exec(t,
+		`CREATE DATABASE t;
+		CREATE TABLE t.a (k INT PRIMARY KEY, x INT, y INT);
+		INSERT INTO t.a SELECT i, i % 10, i % 10 FROM generate_series(1, 100) AS g(i);`)
+
+	internalDB := s.InternalDB().(descs.DB)
+	table := desctestutils.TestingGetPublicTableDescriptor(s.DB(), codec, "t", "a")
+	cache := NewTableStatisticsCache(
+		10, /* cacheSize */
+		s.ClusterSettings(),
+		s.InternalDB().(descs.DB),
+	)
+	require.NoError(t, cache.Start(ctx, codec, s.RangeFeedFactory().(*rangefeed.Factory)))
+	r := MakeRefresher(s.AmbientCtx(), st, internalDB, cache, time.Microsecond /* asOfTime */, nil /* knobs */)
+
+	AutomaticStatisticsClusterMode.Override(ctx, &st.SV, true)
+	columnSets := [][]descpb.ColumnID{{2, 3}}
+
+	// Nothing should be collected while the setting is disabled.
+	r.NotifyMisestimate(table.GetID(), []descpb.ColumnID{3, 2})
+	require.Equal(t, 0, len(r.misestimates))
+	AutomaticTargetedStatisticsClusterMode.Override(ctx, &st.SV, true)
+	r.NotifyMisestimate(table.GetID(), []descpb.ColumnID{3, 2})
+	require.Equal(t, 1, len(r.misestimates))
+	require.Equal(t, columnSets[0], (<-r.misestimates).columnIDs)
+
+	// Nothing should be collected before the table has been refreshed
+	// automatically.
+	r.maybeCreateTargetedStats(ctx, table.GetID(), columnSets, time.Microsecond /* asOf */)
+	sqlRun.CheckQueryResults(t,
+		`SELECT count(*) FROM [SHOW STATISTICS FOR TABLE t.a]`,
+		[][]string{{"0"}})
+
+	sqlRun.Exec(t, `CREATE STATISTICS __auto__ FROM t.a`)
+	checkTargetedStats := func() {
+		testutils.SucceedsSoon(t, func() error {
+			r.maybeCreateTargetedStats(ctx, table.GetID(), columnSets, time.Microsecond /* asOf */)
+			var count int
+			sqlRun.QueryRow(t,
+				`SELECT count(*) FROM [SHOW STATISTICS FOR TABLE t.a]
+				WHERE statistics_name = '__auto_targeted__' AND column_names = '{x,y}'`,
+			).Scan(&count)
+			if count != 1 {
+				return fmt.Errorf("expected 1 targeted statistic but found %d", count)
+			}
+			return nil
+		})
+	}
+	checkTargetedStats()
+
+	// The targeted statistic is more recent than the automatic refresh, so it
+	// should not be collected again.
+	r.maybeCreateTargetedStats(ctx, table.GetID(), columnSets, time.Microsecond /* asOf */)
+	checkTargetedStats()
+}
+
+func TestAddMisestimate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	st := cluster.MakeTestingClusterSettings()
+	r := MakeRefresher(
+		log.MakeTestingAmbientCtxWithNewTracer(), st, nil /* internalDB */, nil, /* cache */
+		time.Microsecond /* asOfTime */, nil, /* knobs */
+	)
+
+	// Duplicate column sets are recorded once.
+	r.addMisestimate(misestimate{tableID: 1, columnIDs: []descpb.ColumnID{1, 2}})
+	r.addMisestimate(misestimate{tableID: 1, columnIDs: []descpb.ColumnID{1, 2}})
+	r.addMisestimate(misestimate{tableID: 2, columnIDs: []descpb.ColumnID{1, 2}})
+	require.Len(t, r.misestimatedColumns[1], 1)
+	require.Len(t, r.misestimatedColumns[2], 1)
+
+	// At most maxMisestimatedColumnSets column sets are recorded per table.
+	for i := 0; i < 2*maxMisestimatedColumnSets; i++ {
+		r.addMisestimate(misestimate{tableID: 1, columnIDs: []descpb.ColumnID{1, descpb.ColumnID(i + 3)}})
+	}
+	require.Len(t, r.misestimatedColumns[1], maxMisestimatedColumnSets)
+	require.Equal(t, []descpb.ColumnID{1, 2}, r.misestimatedColumns[1][0])
+}
+
 func TestDefaultColumns(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
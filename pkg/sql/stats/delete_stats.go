@@ -16,12 +16,15 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
 )
 
 const (
@@ -47,11 +50,17 @@ var TableStatisticsRetentionPeriod = settings.RegisterDurationSetting(
 	settings.WithPublic)
 
 // DeleteOldStatsForColumns deletes old statistics from the
-// system.table_statistics table. For the given tableID and columnIDs,
+// system.table_statistics table. For the given tableID, columnIDs and
+// expression (which is empty for statistics on columns),
 // DeleteOldStatsForColumns keeps the most recent keepCount automatic
 // statistics and deletes all the others.
 func DeleteOldStatsForColumns(
-	ctx context.Context, txn isql.Txn, tableID descpb.ID, columnIDs []descpb.ColumnID,
+	ctx context.Context,
+	st *cluster.Settings,
+	txn isql.Txn,
+	tableID descpb.ID,
+	columnIDs []descpb.ColumnID,
+	expression string,
 ) error {
 	columnIDsVal := tree.NewDArray(types.Int)
 	for _, c := range columnIDs {
@@ -60,19 +69,55 @@ func DeleteOldStatsForColumns(
 		}
 	}
 
-	// This will delete all old statistics for the given table and columns,
-	// including stats created manually (except for a few automatic statistics,
-	// which are identified by the name AutoStatsName).
+	if !st.Version.IsActive(ctx, clusterversion.V24_2_TableStatisticsExpression) {
+		if expression != "" {
+			return errors.New("statistics on expressions are not supported until upgrade to 24.2 is finalized")
+		}
+		// This will delete all old statistics for the given table and columns,
+		// including stats created manually (except for a few automatic statistics,
+		// which are identified by the name AutoStatsName).
+		_, err := txn.Exec(
+			ctx, "delete-statistics", txn.KV(),
+			`DELETE FROM system.table_statistics
+               WHERE "tableID" = $1
+               AND "columnIDs" = $3
+               AND "statisticID" NOT IN (
+                   SELECT "statisticID" FROM system.table_statistics
+                   WHERE "tableID" = $1
+                   AND "name" = $2
+                   AND "columnIDs" = $3
+                   ORDER BY "createdAt" DESC
+                   LIMIT $4
+               )`,
+			tableID,
+			jobspb.AutoStatsName,
+			columnIDsVal,
+			keepCount,
+		)
+		return err
+	}
+
+	// We must pass a nil interface{} if we want to match a NULL expression.
+	var expressionVal interface{}
+	if expression != "" {
+		expressionVal = expression
+	}
+
+	// Statistics on an expression are only superseded by statistics on the
+	// same expression, and statistics on columns only by statistics on the same
+	// columns.
 	_, err := txn.Exec(
 		ctx, "delete-statistics", txn.KV(),
 		`DELETE FROM system.table_statistics
                WHERE "tableID" = $1
                AND "columnIDs" = $3
+               AND expression IS NOT DISTINCT FROM $5
                AND "statisticID" NOT IN (
                    SELECT "statisticID" FROM system.table_statistics
                    WHERE "tableID" = $1
                    AND "name" = $2
                    AND "columnIDs" = $3
+                   AND expression IS NOT DISTINCT FROM $5
                    ORDER BY "createdAt" DESC
                    LIMIT $4
                )`,
@@ -80,15 +125,19 @@ func DeleteOldStatsForColumns(
 		jobspb.AutoStatsName,
 		columnIDsVal,
 		keepCount,
+		expressionVal,
 	)
 	return err
 }
 
 // DeleteOldStatsForOtherColumns deletes statistics from the
 // system.table_statistics table for columns *not* in the given set of column
-// IDs that are older than keepTime.
+// IDs that are older than keepTime. Statistics on expressions are never
+// collected by default, so they are deleted once they are older than keepTime
+// as well.
 func DeleteOldStatsForOtherColumns(
 	ctx context.Context,
+	st *cluster.Settings,
 	txn isql.Txn,
 	tableID descpb.ID,
 	columnIDs [][]descpb.ColumnID,
@@ -112,14 +161,19 @@ func DeleteOldStatsForOtherColumns(
 		placeholderVals = append(placeholderVals, columnIDsVal)
 	}
 
+	otherColumnsPredicate := fmt.Sprintf(`"columnIDs"::string NOT IN (%s)`, columnIDsPlaceholders.String())
+	if st.Version.IsActive(ctx, clusterversion.V24_2_TableStatisticsExpression) {
+		otherColumnsPredicate = fmt.Sprintf(`(%s OR expression IS NOT NULL)`, otherColumnsPredicate)
+	}
+
 	// This will delete all statistics for the given table that are not
 	// on the given columns and are older than keepTime.
 	_, err := txn.Exec(
 		ctx, "delete-statistics", txn.KV(),
 		fmt.Sprintf(`DELETE FROM system.table_statistics
                WHERE "tableID" = $1
-               AND "columnIDs"::string NOT IN (%s)
-               AND "createdAt" < now() - $2`, columnIDsPlaceholders.String()),
+               AND %s
+               AND "createdAt" < now() - $2`, otherColumnsPredicate),
 		placeholderVals...,
 	)
 	return err
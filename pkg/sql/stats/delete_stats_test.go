@@ -241,6 +241,30 @@ func TestDeleteOldStatsForColumns(t *testing.T) {
 			NullCount:     0,
 			AvgSize:       0,
 		},
+		{
+			TableID:       descpb.ID(100),
+			StatisticID:   18,
+			Name:          "stat_100_expr",
+			ColumnIDs:     []descpb.ColumnID{1},
+			CreatedAt:     timeutil.Now().Add(-18 * time.Hour),
+			RowCount:      1000,
+			DistinctCount: 10,
+			NullCount:     0,
+			AvgSize:       4,
+			Expression:    "a + 1",
+		},
+		{
+			TableID:       descpb.ID(100),
+			StatisticID:   19,
+			Name:          "stat_100_expr",
+			ColumnIDs:     []descpb.ColumnID{1},
+			CreatedAt:     timeutil.Now().Add(-19 * time.Hour),
+			RowCount:      1000,
+			DistinctCount: 10,
+			NullCount:     0,
+			AvgSize:       4,
+			Expression:    "a + 1",
+		},
 	}
 
 	for i := range testData {
@@ -250,14 +274,17 @@ func TestDeleteOldStatsForColumns(t *testing.T) {
 		}
 	}
 
-	// checkDelete deletes old statistics for the given table and column IDs and
-	// checks that only the statisticIDs contained in expectDeleted have been
-	// deleted.
+	// checkDelete deletes old statistics for the given table, column IDs and
+	// expression and checks that only the statisticIDs contained in
+	// expectDeleted have been deleted.
 	checkDelete := func(
-		tableID descpb.ID, columnIDs []descpb.ColumnID, expectDeleted map[uint64]struct{},
+		tableID descpb.ID,
+		columnIDs []descpb.ColumnID,
+		expression string,
+		expectDeleted map[uint64]struct{},
 	) error {
 		if err := s.InternalDB().(isql.DB).Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
-			return DeleteOldStatsForColumns(ctx, txn, tableID, columnIDs)
+			return DeleteOldStatsForColumns(ctx, s.ClusterSettings(), txn, tableID, columnIDs, expression)
 		}); err != nil {
 			return err
 		}
@@ -296,14 +323,14 @@ func TestDeleteOldStatsForColumns(t *testing.T) {
 	}
 
 	expectDeleted := make(map[uint64]struct{}, len(testData))
-	getExpectDeleted := func(tableID descpb.ID, columnIDs []descpb.ColumnID) {
+	getExpectDeleted := func(tableID descpb.ID, columnIDs []descpb.ColumnID, expression string) {
 		keptStats := 0
 		for i := range testData {
 			stat := &testData[i]
 			if stat.TableID != tableID {
 				continue
 			}
-			if !reflect.DeepEqual(stat.ColumnIDs, columnIDs) {
+			if !reflect.DeepEqual(stat.ColumnIDs, columnIDs) || stat.Expression != expression {
 				continue
 			}
 			if stat.Name == jobspb.AutoStatsName && keptStats < keepCount {
@@ -314,19 +341,29 @@ func TestDeleteOldStatsForColumns(t *testing.T) {
 		}
 	}
 
-	// Delete stats for column 1 in table 100.
+	// Delete stats for column 1 in table 100. The stats on an expression
+	// referencing column 1 are kept.
 	tableID := descpb.ID(100)
 	columnIDs := []descpb.ColumnID{1}
-	getExpectDeleted(tableID, columnIDs)
-	if err := checkDelete(tableID, columnIDs, expectDeleted); err != nil {
+	getExpectDeleted(tableID, columnIDs, "" /* expression */)
+	if err := checkDelete(tableID, columnIDs, "" /* expression */, expectDeleted); err != nil {
 		t.Fatal(err)
 	}
 
 	// Delete stats for columns {2, 3} in table 100.
 	tableID = descpb.ID(100)
 	columnIDs = []descpb.ColumnID{2, 3}
-	getExpectDeleted(tableID, columnIDs)
-	if err := checkDelete(tableID, columnIDs, expectDeleted); err != nil {
+	getExpectDeleted(tableID, columnIDs, "" /* expression */)
+	if err := checkDelete(tableID, columnIDs, "" /* expression */, expectDeleted); err != nil {
+		t.Fatal(err)
+	}
+
+	// Delete stats for the expression a + 1 in table 100. Neither of them is
+	// automatic, so both are deleted.
+	tableID = descpb.ID(100)
+	columnIDs = []descpb.ColumnID{1}
+	getExpectDeleted(tableID, columnIDs, "a + 1")
+	if err := checkDelete(tableID, columnIDs, "a + 1", expectDeleted); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -550,7 +587,9 @@ func TestDeleteOldStatsForOtherColumns(t *testing.T) {
 		tableID descpb.ID, columnIDs [][]descpb.ColumnID, expectDeleted map[uint64]struct{},
 	) error {
 		if err := db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
-			return DeleteOldStatsForOtherColumns(ctx, txn, tableID, columnIDs, defaultKeepTime)
+			return DeleteOldStatsForOtherColumns(
+				ctx, s.ClusterSettings(), txn, tableID, columnIDs, defaultKeepTime,
+			)
 		}); err != nil {
 			return err
 		}
@@ -109,12 +109,12 @@ var maxDecrease = settings.RegisterFloatSetting(
 func ForecastTableStatistics(
 	ctx context.Context, st *cluster.Settings, observed []*TableStatistic,
 ) []*TableStatistic {
-	// Group observed statistics by column set, skipping over partial statistics
-	// and statistics with inverted histograms.
+	// Group observed statistics by column set, skipping over partial statistics,
+	// statistics on expressions, and statistics with inverted histograms.
 	var forecastCols []string
 	observedByCols := make(map[string][]*TableStatistic)
 	for _, stat := range observed {
-		if stat.IsPartial() {
+		if stat.IsPartial() || stat.IsExpression() {
 			continue
 		}
 		// We don't have a good way to detect inverted statistics right now, so skip
@@ -43,6 +43,9 @@ type JSONStatistic struct {
 	HistogramVersion    HistogramVersion  `json:"histo_version,omitempty"`
 	PartialPredicate    string            `json:"partial_predicate,omitempty"`
 	FullStatisticID     uint64            `json:"full_statistic_id,omitempty"`
+	// Expression is set for statistics on an expression, in which case Columns
+	// are the columns referenced by the expression.
+	Expression string `json:"expression,omitempty"`
 }
 
 // JSONHistoBucket is a struct used for JSON marshaling and unmarshaling of
@@ -168,6 +171,11 @@ func (js *JSONStatistic) IsPartial() bool {
 	return js.PartialPredicate != ""
 }
 
+// IsExpression returns true if this statistic was collected on an expression.
+func (js *JSONStatistic) IsExpression() bool {
+	return js.Expression != ""
+}
+
 // IsMerged returns true if this statistic was created by merging a partial and
 // a full statistic.
 func (js *JSONStatistic) IsMerged() bool {
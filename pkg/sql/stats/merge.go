@@ -38,7 +38,7 @@ func MergedStatistics(
 	// is the latest.
 	fullStatsMap := make(map[descpb.ColumnID]*TableStatistic)
 	for _, stat := range stats {
-		if stat.IsPartial() || len(stat.ColumnIDs) != 1 {
+		if stat.IsPartial() || stat.IsExpression() || len(stat.ColumnIDs) != 1 {
 			continue
 		}
 		col := stat.ColumnIDs[0]
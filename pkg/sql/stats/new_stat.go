@@ -13,12 +13,14 @@ package stats
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
 )
 
 // InsertNewStats inserts a slice of statistics at the current time into the
@@ -42,6 +44,7 @@ func InsertNewStats(
 			statistic.HistogramData,
 			statistic.PartialPredicate,
 			statistic.FullStatisticID,
+			statistic.Expression,
 		)
 		if err != nil {
 			return err
@@ -50,7 +53,9 @@ func InsertNewStats(
 	return nil
 }
 
-// InsertNewStat inserts a new statistic in the system table.
+// InsertNewStat inserts a new statistic in the system table. If expression is
+// not empty, the statistic is on that expression and columnIDs are the columns
+// it references.
 //
 // The stats cache will automatically update asynchronously (as well as the
 // stats caches on all other nodes).
@@ -65,6 +70,7 @@ func InsertNewStat(
 	h *HistogramData,
 	partialPredicate string,
 	fullStatisticID uint64,
+	expression string,
 ) error {
 	// We must pass a nil interface{} if we want to insert a NULL.
 	var nameVal, histogramVal interface{}
@@ -93,6 +99,38 @@ func InsertNewStat(
 		predicateValue = partialPredicate
 	}
 
+	if expression == "" {
+		_, err := txn.Exec(
+			ctx, "insert-statistic", txn.KV(),
+			`INSERT INTO system.table_statistics (
+					"tableID",
+					"name",
+					"columnIDs",
+					"rowCount",
+					"distinctCount",
+					"nullCount",
+					"avgSize",
+					histogram,
+					"partialPredicate",
+					"fullStatisticID"
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			tableID,
+			nameVal,
+			columnIDsVal,
+			rowCount,
+			distinctCount,
+			nullCount,
+			avgSize,
+			histogramVal,
+			predicateValue,
+			fullStatisticID,
+		)
+		return err
+	}
+
+	if !settings.Version.IsActive(ctx, clusterversion.V24_2_TableStatisticsExpression) {
+		return errors.New("statistics on expressions are not supported until upgrade to 24.2 is finalized")
+	}
 	_, err := txn.Exec(
 		ctx, "insert-statistic", txn.KV(),
 		`INSERT INTO system.table_statistics (
@@ -105,8 +143,9 @@ func InsertNewStat(
 					"avgSize",
 					histogram,
 					"partialPredicate",
-					"fullStatisticID"
-				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+					"fullStatisticID",
+					expression
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
 		tableID,
 		nameVal,
 		columnIDsVal,
@@ -117,6 +156,7 @@ func InsertNewStat(
 		histogramVal,
 		predicateValue,
 		fullStatisticID,
+		expression,
 	)
 	return err
 }
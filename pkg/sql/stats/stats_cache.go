@@ -17,6 +17,7 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
@@ -517,6 +518,7 @@ const (
 	partialPredicateIndex
 	histogramIndex
 	fullStatisticsIdIndex
+	expressionIndex
 	statsLen
 )
 
@@ -554,6 +556,7 @@ func NewTableStatisticProto(datums tree.Datums) (*TableStatisticProto, error) {
 		{"partialPredicate", partialPredicateIndex, types.String, true},
 		{"histogram", hgIndex, types.Bytes, true},
 		{"fullStatisticID", fullStatisticsIdIndex, types.Int, true},
+		{"expression", expressionIndex, types.String, true},
 	}
 
 	for _, v := range expectedTypes {
@@ -588,6 +591,9 @@ func NewTableStatisticProto(datums tree.Datums) (*TableStatisticProto, error) {
 	if datums[fullStatisticsIdIndex] != tree.DNull {
 		res.FullStatisticID = uint64(*datums[fullStatisticsIdIndex].(*tree.DInt))
 	}
+	if datums[expressionIndex] != tree.DNull {
+		res.Expression = string(*datums[expressionIndex].(*tree.DString))
+	}
 	if datums[hgIndex] != tree.DNull {
 		res.HistogramData = &HistogramData{}
 		if err := protoutil.Unmarshal(
@@ -730,6 +736,12 @@ func (tsp *TableStatisticProto) IsPartial() bool {
 	return tsp.PartialPredicate != ""
 }
 
+// IsExpression returns true if this statistic was collected on an expression
+// rather than on a set of columns.
+func (tsp *TableStatisticProto) IsExpression() bool {
+	return tsp.Expression != ""
+}
+
 // IsMerged returns true if this statistic was created by merging a partial and
 // a full statistic.
 func (tsp *TableStatisticProto) IsMerged() bool {
@@ -746,6 +758,16 @@ func (tsp *TableStatisticProto) IsAuto() bool {
 	return tsp.Name == jobspb.AutoStatsName
 }
 
+// ExpressionColumnOrNull returns the expression column of
+// system.table_statistics for use in a SELECT list, or a NULL placeholder if
+// the column has not been added to the table yet.
+func ExpressionColumnOrNull(ctx context.Context, st *cluster.Settings) string {
+	if st.Version.IsActive(ctx, clusterversion.V24_2_TableStatisticsExpression) {
+		return "expression"
+	}
+	return "NULL AS expression"
+}
+
 // getTableStatsFromDB retrieves the statistics in system.table_statistics
 // for the given table ID.
 //
@@ -754,7 +776,7 @@ func (tsp *TableStatisticProto) IsAuto() bool {
 func (sc *TableStatisticsCache) getTableStatsFromDB(
 	ctx context.Context, tableID descpb.ID, forecast bool, st *cluster.Settings,
 ) ([]*TableStatistic, error) {
-	getTableStatisticsStmt := `
+	getTableStatisticsStmt := fmt.Sprintf(`
 SELECT
 	"tableID",
 	"statisticID",
@@ -767,11 +789,12 @@ SELECT
 	"avgSize",
 	"partialPredicate",
 	histogram,
-	"fullStatisticID"
+	"fullStatisticID",
+	%s
 FROM system.table_statistics
 WHERE "tableID" = $1
 ORDER BY "createdAt" DESC, "columnIDs" DESC, "statisticID" DESC
-`
+`, ExpressionColumnOrNull(ctx, st))
 	// TODO(michae2): Add an index on system.table_statistics (tableID, createdAt,
 	// columnIDs, statisticID).
 
@@ -42,8 +42,8 @@ import (
 func insertTableStat(ctx context.Context, ex isql.Executor, stat *TableStatisticProto) error {
 	insertStatStmt := `
 INSERT INTO system.table_statistics ("tableID", "statisticID", name, "columnIDs", "createdAt",
-	"rowCount", "distinctCount", "nullCount", "avgSize", histogram)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	"rowCount", "distinctCount", "nullCount", "avgSize", histogram, expression)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 `
 	columnIDs := tree.NewDArray(types.Int)
 	for _, id := range stat.ColumnIDs {
@@ -63,6 +63,7 @@ VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		stat.NullCount,
 		stat.AvgSize,
 		nil, // histogram
+		nil, // expression
 	}
 	if len(stat.Name) != 0 {
 		args[2] = stat.Name
@@ -74,6 +75,9 @@ VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		}
 		args[9] = histogramBytes
 	}
+	if stat.Expression != "" {
+		args[10] = stat.Expression
+	}
 
 	var rows int
 	rows, err := ex.Exec(ctx, "insert-stat", nil /* txn */, insertStatStmt, args...)
@@ -219,6 +219,11 @@ func CalcArrayOid(elemTyp *T) oid.Oid {
 			return o
 		}
 
+	case AnyFamily:
+		if o == oidext.T_anycompatible {
+			return oidext.T_anycompatiblearray
+		}
+
 	case UnknownFamily:
 		// Postgres doesn't have an OID for an array of unknown values, since
 		// it's not possible to create that in Postgres. But CRDB does allow that,
@@ -582,6 +582,22 @@ var (
 	AnyArray = &T{InternalType: InternalType{
 		Family: ArrayFamily, ArrayContents: Any, Oid: oid.T_anyarray, Locale: &emptyLocale}}
 
+	// AnyCompatible is a special type used only during static analysis as a
+	// wildcard type for routine parameters. Unlike Any, arguments passed to
+	// parameters of this type do not need to have identical types; they are
+	// instead cast to a common type. Execution-time values should never have
+	// this type.
+	AnyCompatible = &T{InternalType: InternalType{
+		Family: AnyFamily, Oid: oidext.T_anycompatible, Locale: &emptyLocale}}
+
+	// AnyCompatibleArray is a special type used only during static analysis as
+	// a wildcard type that matches an array whose elements are cast to the
+	// common type of all AnyCompatible arguments. Execution-time values should
+	// never have this type.
+	AnyCompatibleArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: AnyCompatible, Oid: oidext.T_anycompatiblearray,
+		Locale: &emptyLocale}}
+
 	// AnyEnum is a special type only used during static analysis as a wildcard
 	// type that matches an possible enum value. Execution-time values should
 	// never have this type.
@@ -1543,6 +1559,9 @@ func (f Family) Name() redact.SafeString {
 func (t *T) Name() string {
 	switch fam := t.Family(); fam {
 	case AnyFamily:
+		if t.Oid() == oidext.T_anycompatible {
+			return "anycompatible"
+		}
 		return "anyelement"
 
 	case ArrayFamily:
@@ -1681,6 +1700,9 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
+		if t.Oid() == oidext.T_anycompatible {
+			return "anycompatible"
+		}
 		return "anyelement"
 	case ArrayFamily:
 		switch t.Oid() {
@@ -2160,7 +2182,8 @@ func (t *T) Equal(other *T) bool {
 // static analysis, and cannot be used during execution.
 func (t *T) IsWildcardType() bool {
 	for _, wildcard := range []*T{
-		Any, AnyArray, AnyCollatedString, AnyEnum, AnyEnumArray, AnyTuple, AnyTupleArray,
+		Any, AnyArray, AnyCompatible, AnyCompatibleArray, AnyCollatedString, AnyEnum,
+		AnyEnumArray, AnyTuple, AnyTupleArray,
 	} {
 		// Note that pointer comparison is insufficient since we might have
 		// deserialized t from disk.
@@ -2947,19 +2970,21 @@ func IsSerialType(typ *T) bool {
 // unreservedTypeTokens contain type alias that we resolve during parsing.
 // Instead of adding a new token to the parser, add the type here.
 var unreservedTypeTokens = map[string]*T{
-	"blob":       Bytes,
-	"bool":       Bool,
-	"bytea":      Bytes,
-	"bytes":      Bytes,
-	"date":       Date,
-	"float4":     Float,
-	"float8":     Float,
-	"inet":       INet,
-	"int2":       Int2,
-	"int4":       Int4,
-	"int8":       Int,
-	"int64":      Int,
-	"int2vector": Int2Vector,
+	"anycompatible":      AnyCompatible,
+	"anycompatiblearray": AnyCompatibleArray,
+	"blob":               Bytes,
+	"bool":               Bool,
+	"bytea":              Bytes,
+	"bytes":              Bytes,
+	"date":               Date,
+	"float4":             Float,
+	"float8":             Float,
+	"inet":               INet,
+	"int2":               Int2,
+	"int4":               Int4,
+	"int8":               Int,
+	"int64":              Int,
+	"int2vector":         Int2Vector,
 	// NOTE(sql-exp): Change the line below to Json if we support the JSON type.
 	"json":      Jsonb,
 	"jsonb":     Jsonb,
@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -1344,6 +1345,50 @@ var varGen = map[string]sessionVar{
 		},
 	},
 
+	`pg_trgm.word_similarity_threshold`: {
+		GetStringVal: makeFloatGetStringValFn(`pg_trgm.word_similarity_threshold`),
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatFloatAsPostgresSetting(evalCtx.SessionData().TrigramWordSimilarityThreshold), nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return "0.6"
+		},
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return err
+			}
+			if f < 0 || f > 1 {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"%.2f is out of range for word_similarity_threshold", f)
+			}
+			m.SetTrigramWordSimilarityThreshold(f)
+			return nil
+		},
+	},
+
+	`pg_trgm.strict_word_similarity_threshold`: {
+		GetStringVal: makeFloatGetStringValFn(`pg_trgm.strict_word_similarity_threshold`),
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatFloatAsPostgresSetting(evalCtx.SessionData().TrigramStrictWordSimilarityThreshold), nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return "0.5"
+		},
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return err
+			}
+			if f < 0 || f > 1 {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"%.2f is out of range for strict_word_similarity_threshold", f)
+			}
+			m.SetTrigramStrictWordSimilarityThreshold(f)
+			return nil
+		},
+	},
+
 	// CockroachDB extension.
 	`troubleshooting_mode`: {
 		GetStringVal: makePostgresBoolGetStringValFn(`troubleshooting_mode`),
@@ -2205,6 +2250,31 @@ var varGen = map[string]sessionVar{
 		},
 	},
 
+	// CockroachDB extension. Configures the number of input rows after which
+	// an eligible lookup join switches to a hash join against a full scan of
+	// the lookup index. Zero disables adaptive lookup joins.
+	`adaptive_join_row_threshold`: {
+		GetStringVal: makeIntGetStringValFn(`adaptive_join_row_threshold`),
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			b, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return err
+			}
+			if b < 0 {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"cannot set adaptive_join_row_threshold to a negative value: %d", b)
+			}
+			m.SetAdaptiveJoinRowThreshold(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return strconv.FormatInt(evalCtx.SessionData().AdaptiveJoinRowThreshold, 10), nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return "0"
+		},
+	},
+
 	// CockroachDB extension.
 	`parallelize_multi_key_lookup_joins_enabled`: {
 		GetStringVal: makePostgresBoolGetStringValFn(`parallelize_multi_key_lookup_joins_enabled`),
@@ -3418,6 +3488,26 @@ func init() {
 				return p.setRole(ctx, local, u)
 			},
 		},
+		{
+			// Set only accepts the builtin configurations, since user-defined
+			// configurations can only be looked up once the session has started.
+			name: `default_text_search_config`,
+			fn: func(ctx context.Context, p *planner, local bool, s string) error {
+				if err := tsearch.ValidConfig(s); err != nil {
+					un, parseErr := parser.ParseTableName(s)
+					if parseErr != nil {
+						return err
+					}
+					if _, err := p.lookupTextSearchObject(ctx, tree.TextSearchConfiguration, un); err != nil {
+						return err
+					}
+				}
+				return p.applyOnSessionDataMutators(ctx, local, func(m sessionDataMutator) error {
+					m.SetDefaultTextSearchConfig(s)
+					return nil
+				})
+			},
+		},
 	} {
 		v := varGen[p.name]
 		v.SetWithPlanner = p.fn
@@ -1394,7 +1394,7 @@ CREATE TABLE pg_catalog.pg_statio_all_indexes (
 	idx_blks_hit INT
 )`
 
-// PgCatalogTsConfig is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsConfig describes the schema of the pg_catalog.pg_ts_config table.
 const PgCatalogTsConfig = `
 CREATE TABLE pg_catalog.pg_ts_config (
 	oid OID,
@@ -1728,7 +1728,8 @@ CREATE TABLE pg_catalog.pg_stat_all_indexes (
 	idx_tup_fetch INT
 )`
 
-// PgCatalogTsConfigMap is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsConfigMap describes the schema of the pg_catalog.pg_ts_config_map
+// table.
 const PgCatalogTsConfigMap = `
 CREATE TABLE pg_catalog.pg_ts_config_map (
 	mapcfg OID,
@@ -1921,7 +1922,7 @@ CREATE TABLE pg_catalog.pg_subscription (
 	subpublications STRING[]
 )`
 
-// PgCatalogTsDict is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsDict describes the schema of the pg_catalog.pg_ts_dict table.
 const PgCatalogTsDict = `
 CREATE TABLE pg_catalog.pg_ts_dict (
 	oid OID,
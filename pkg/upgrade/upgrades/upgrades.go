@@ -132,6 +132,22 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
 	),
 
+	upgrade.NewTenantUpgrade(
+		"add the system.plan_baselines table",
+		clusterversion.V24_2_PlanBaselinesTable.Version(),
+		upgrade.NoPrecondition,
+		addPlanBaselinesTable,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
+	),
+
+	upgrade.NewTenantUpgrade(
+		"add the expression column to system.table_statistics table",
+		clusterversion.V24_2_TableStatisticsExpression.Version(),
+		upgrade.NoPrecondition,
+		tableStatisticsExpressionMigration,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
+	),
+
 	// Note: when starting a new release version, the first upgrade (for
 	// Vxy_zStart) must be a newFirstUpgrade. Keep this comment at the bottom.
 }
@@ -84,7 +84,18 @@ func (w *Writer) FinishRecord() (err error) {
 }
 
 // WriteField writes an individual field.
-func (w *Writer) WriteField(field *bytes.Buffer) (e error) {
+func (w *Writer) WriteField(field *bytes.Buffer) error {
+	return w.writeField(field, false /* forceQuote */)
+}
+
+// WriteQuotedField writes an individual field, enclosing it in quotes even if
+// its contents would not otherwise require them. This is used for the
+// FORCE_QUOTE option of COPY TO.
+func (w *Writer) WriteQuotedField(field *bytes.Buffer) error {
+	return w.writeField(field, true /* forceQuote */)
+}
+
+func (w *Writer) writeField(field *bytes.Buffer, forceQuote bool) (e error) {
 	if w.midRow {
 		if _, err := w.w.WriteRune(w.Comma); err != nil {
 			return err
@@ -146,7 +157,7 @@ func (w *Writer) WriteField(field *bytes.Buffer) (e error) {
 	}
 
 	w.maybeTerminatorString = w.maybeTerminatorString && w.i == 2
-	w.currentRecordNeedsQuotes = w.currentRecordNeedsQuotes || w.maybeTerminatorString
+	w.currentRecordNeedsQuotes = w.currentRecordNeedsQuotes || w.maybeTerminatorString || forceQuote
 
 	// By now we know whether or not the entire field needs to be quoted.
 	// Fields with a Comma, fields with a quote or newline, and
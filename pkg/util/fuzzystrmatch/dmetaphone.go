@@ -0,0 +1,684 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package fuzzystrmatch
+
+import "strings"
+
+// Philips, Lawrence. "The Double Metaphone Search Algorithm." C/C++ Users
+// Journal, June 2000.
+//
+// This is a port of dmetaphone.c from the Postgres fuzzystrmatch extension.
+
+// dmetaphoneMaxLength is the maximum length of the generated codes.
+const dmetaphoneMaxLength = 4
+
+// dmetaphonePadding is appended to the source string so that lookahead past
+// the end of the string compares against spaces, as in Postgres.
+const dmetaphonePadding = "     "
+
+type dmetaphone struct {
+	// src is the upper-cased source string followed by dmetaphonePadding.
+	src []rune
+	// length is the length of the source string, excluding the padding.
+	length             int
+	primary, alternate strings.Builder
+}
+
+// at returns the character at the given position, or 0 if it is out of
+// bounds.
+func (d *dmetaphone) at(pos int) rune {
+	if pos < 0 || pos >= len(d.src) {
+		return 0
+	}
+	return d.src[pos]
+}
+
+// isVowel returns whether the character at the given position is a vowel.
+func (d *dmetaphone) isVowel(pos int) bool {
+	if pos < 0 || pos >= d.length {
+		return false
+	}
+	switch d.src[pos] {
+	case 'A', 'E', 'I', 'O', 'U', 'Y':
+		return true
+	}
+	return false
+}
+
+// isSlavoGermanic returns whether the source string looks Slavic or Germanic.
+func (d *dmetaphone) isSlavoGermanic() bool {
+	s := string(d.src[:d.length])
+	return strings.ContainsAny(s, "WK") || strings.Contains(s, "CZ")
+}
+
+// stringAt returns whether any of the given options appear at the given
+// position. All options must have the given length.
+func (d *dmetaphone) stringAt(start, length int, options ...string) bool {
+	if start < 0 || start >= len(d.src) || start+length > len(d.src) {
+		return false
+	}
+	s := string(d.src[start : start+length])
+	for _, option := range options {
+		if s == option {
+			return true
+		}
+	}
+	return false
+}
+
+// add appends the given codes to the primary and alternate encodings.
+func (d *dmetaphone) add(primary, alternate string) {
+	d.primary.WriteString(primary)
+	d.alternate.WriteString(alternate)
+}
+
+// addBoth appends the given code to both the primary and alternate encodings.
+func (d *dmetaphone) addBoth(code string) {
+	d.add(code, code)
+}
+
+// DoubleMetaphone returns the primary and alternate Double Metaphone codes of
+// the given string. The codes are at most 4 characters long.
+func DoubleMetaphone(source string) (primary string, alternate string) {
+	src := []rune(strings.ToUpper(source))
+	d := dmetaphone{
+		src:    append(src, []rune(dmetaphonePadding)...),
+		length: len(src),
+	}
+	current := 0
+	last := d.length - 1
+
+	// Skip these when at the start of a word.
+	if d.stringAt(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		current++
+	}
+
+	// Initial 'X' is pronounced 'Z', e.g. 'Xavier'.
+	if d.at(0) == 'X' {
+		d.addBoth("S")
+		current++
+	}
+
+	for d.primary.Len() < dmetaphoneMaxLength || d.alternate.Len() < dmetaphoneMaxLength {
+		if current >= d.length {
+			break
+		}
+		current = d.step(current, last)
+	}
+
+	primary, alternate = d.primary.String(), d.alternate.String()
+	if len(primary) > dmetaphoneMaxLength {
+		primary = primary[:dmetaphoneMaxLength]
+	}
+	if len(alternate) > dmetaphoneMaxLength {
+		alternate = alternate[:dmetaphoneMaxLength]
+	}
+	return primary, alternate
+}
+
+// step encodes the character at the current position and returns the
+// position of the next character to encode.
+func (d *dmetaphone) step(current, last int) int {
+	switch d.at(current) {
+	case 'A', 'E', 'I', 'O', 'U', 'Y':
+		// All initial vowels map to 'A'.
+		if current == 0 {
+			d.addBoth("A")
+		}
+		return current + 1
+
+	case 'B':
+		// "-mb", e.g. "dumb", is already skipped over.
+		d.addBoth("P")
+		if d.at(current+1) == 'B' {
+			return current + 2
+		}
+		return current + 1
+
+	case 'Ç':
+		d.addBoth("S")
+		return current + 1
+
+	case 'C':
+		return d.stepC(current)
+
+	case 'D':
+		if d.stringAt(current, 2, "DG") {
+			if d.stringAt(current+2, 1, "I", "E", "Y") {
+				// E.g. 'edge'.
+				d.addBoth("J")
+				return current + 3
+			}
+			// E.g. 'edgar'.
+			d.addBoth("TK")
+			return current + 2
+		}
+		d.addBoth("T")
+		if d.stringAt(current, 2, "DT", "DD") {
+			return current + 2
+		}
+		return current + 1
+
+	case 'F':
+		d.addBoth("F")
+		if d.at(current+1) == 'F' {
+			return current + 2
+		}
+		return current + 1
+
+	case 'G':
+		return d.stepG(current)
+
+	case 'H':
+		// Only keep if first and before a vowel, or between two vowels.
+		if (current == 0 || d.isVowel(current-1)) && d.isVowel(current+1) {
+			d.addBoth("H")
+			return current + 2
+		}
+		// This also takes care of 'HH'.
+		return current + 1
+
+	case 'J':
+		return d.stepJ(current, last)
+
+	case 'K':
+		d.addBoth("K")
+		if d.at(current+1) == 'K' {
+			return current + 2
+		}
+		return current + 1
+
+	case 'L':
+		if d.at(current+1) == 'L' {
+			// Spanish, e.g. 'cabrillo', 'gallegos'.
+			if (current == d.length-3 && d.stringAt(current-1, 4, "ILLO", "ILLA", "ALLE")) ||
+				((d.stringAt(last-1, 2, "AS", "OS") || d.stringAt(last, 1, "A", "O")) &&
+					d.stringAt(current-1, 4, "ALLE")) {
+				d.add("L", "")
+				return current + 2
+			}
+			d.addBoth("L")
+			return current + 2
+		}
+		d.addBoth("L")
+		return current + 1
+
+	case 'M':
+		d.addBoth("M")
+		// E.g. 'dumb', 'thumb'.
+		if (d.stringAt(current-1, 3, "UMB") &&
+			(current+1 == last || d.stringAt(current+2, 2, "ER"))) ||
+			d.at(current+1) == 'M' {
+			return current + 2
+		}
+		return current + 1
+
+	case 'N':
+		d.addBoth("N")
+		if d.at(current+1) == 'N' {
+			return current + 2
+		}
+		return current + 1
+
+	case 'Ñ':
+		d.addBoth("N")
+		return current + 1
+
+	case 'P':
+		if d.at(current+1) == 'H' {
+			d.addBoth("F")
+			return current + 2
+		}
+		d.addBoth("P")
+		// Also account for "campbell" and "raspberry".
+		if d.stringAt(current+1, 1, "P", "B") {
+			return current + 2
+		}
+		return current + 1
+
+	case 'Q':
+		d.addBoth("K")
+		if d.at(current+1) == 'Q' {
+			return current + 2
+		}
+		return current + 1
+
+	case 'R':
+		// French, e.g. 'rogier', but exclude 'hochmeier'.
+		if current == last && !d.isSlavoGermanic() &&
+			d.stringAt(current-2, 2, "IE") && !d.stringAt(current-4, 2, "ME", "MA") {
+			d.add("", "R")
+		} else {
+			d.addBoth("R")
+		}
+		if d.at(current+1) == 'R' {
+			return current + 2
+		}
+		return current + 1
+
+	case 'S':
+		return d.stepS(current, last)
+
+	case 'T':
+		if d.stringAt(current, 4, "TION") || d.stringAt(current, 3, "TIA", "TCH") {
+			d.addBoth("X")
+			return current + 3
+		}
+		if d.stringAt(current, 2, "TH") || d.stringAt(current, 3, "TTH") {
+			// Special case 'thomas', 'thames', or Germanic.
+			if d.stringAt(current+2, 2, "OM", "AM") ||
+				d.stringAt(0, 4, "VAN ", "VON ") || d.stringAt(0, 3, "SCH") {
+				d.addBoth("T")
+			} else {
+				d.add("0", "T")
+			}
+			return current + 2
+		}
+		d.addBoth("T")
+		if d.stringAt(current+1, 1, "T", "D") {
+			return current + 2
+		}
+		return current + 1
+
+	case 'V':
+		d.addBoth("F")
+		if d.at(current+1) == 'V' {
+			return current + 2
+		}
+		return current + 1
+
+	case 'W':
+		// This can also be in the middle of a word.
+		if d.stringAt(current, 2, "WR") {
+			d.addBoth("R")
+			return current + 2
+		}
+		if current == 0 && (d.isVowel(current+1) || d.stringAt(current, 2, "WH")) {
+			if d.isVowel(current + 1) {
+				// Wasserman should match Vasserman.
+				d.add("A", "F")
+			} else {
+				// Uomo should match Womo.
+				d.addBoth("A")
+			}
+		}
+		// Arnow should match Arnoff.
+		if (current == last && d.isVowel(current-1)) ||
+			d.stringAt(current-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") ||
+			d.stringAt(0, 3, "SCH") {
+			d.add("", "F")
+			return current + 1
+		}
+		// Polish, e.g. 'filipowicz'.
+		if d.stringAt(current, 4, "WICZ", "WITZ") {
+			d.add("TS", "FX")
+			return current + 4
+		}
+		return current + 1
+
+	case 'X':
+		// French, e.g. 'breaux'.
+		if !(current == last &&
+			(d.stringAt(current-3, 3, "IAU", "EAU") || d.stringAt(current-2, 2, "AU", "OU"))) {
+			d.addBoth("KS")
+		}
+		if d.stringAt(current+1, 1, "C", "X") {
+			return current + 2
+		}
+		return current + 1
+
+	case 'Z':
+		// Chinese pinyin, e.g. 'zhao'.
+		if d.at(current+1) == 'H' {
+			d.addBoth("J")
+			return current + 2
+		}
+		if d.stringAt(current+1, 2, "ZO", "ZI", "ZA") ||
+			(d.isSlavoGermanic() && current > 0 && d.at(current-1) != 'T') {
+			d.add("S", "TS")
+		} else {
+			d.addBoth("S")
+		}
+		if d.at(current+1) == 'Z' {
+			return current + 2
+		}
+		return current + 1
+
+	default:
+		return current + 1
+	}
+}
+
+func (d *dmetaphone) stepC(current int) int {
+	// Various Germanic.
+	if current > 1 && !d.isVowel(current-2) && d.stringAt(current-1, 3, "ACH") &&
+		d.at(current+2) != 'I' &&
+		(d.at(current+2) != 'E' || d.stringAt(current-2, 6, "BACHER", "MACHER")) {
+		d.addBoth("K")
+		return current + 2
+	}
+
+	// Special case 'caesar'.
+	if current == 0 && d.stringAt(current, 6, "CAESAR") {
+		d.addBoth("S")
+		return current + 2
+	}
+
+	// Italian 'chianti'.
+	if d.stringAt(current, 4, "CHIA") {
+		d.addBoth("K")
+		return current + 2
+	}
+
+	if d.stringAt(current, 2, "CH") {
+		// Find 'michael'.
+		if current > 0 && d.stringAt(current, 4, "CHAE") {
+			d.add("K", "X")
+			return current + 2
+		}
+
+		// Greek roots, e.g. 'chemistry', 'chorus'.
+		if current == 0 &&
+			(d.stringAt(current+1, 5, "HARAC", "HARIS") ||
+				d.stringAt(current+1, 3, "HOR", "HYM", "HIA", "HEM")) &&
+			!d.stringAt(0, 5, "CHORE") {
+			d.addBoth("K")
+			return current + 2
+		}
+
+		// Germanic, Greek, or otherwise 'ch' for the 'kh' sound.
+		if d.stringAt(0, 4, "VAN ", "VON ") || d.stringAt(0, 3, "SCH") ||
+			// 'architect' but not 'arch', 'orchestra', 'orchid'.
+			d.stringAt(current-2, 6, "ORCHES", "ARCHIT", "ORCHID") ||
+			d.stringAt(current+2, 1, "T", "S") ||
+			((d.stringAt(current-1, 1, "A", "O", "U", "E") || current == 0) &&
+				// E.g. 'wachtler', 'wechsler', but not 'tichner'.
+				d.stringAt(current+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ")) {
+			d.addBoth("K")
+		} else if current > 0 {
+			if d.stringAt(0, 2, "MC") {
+				// E.g. 'McHugh'.
+				d.addBoth("K")
+			} else {
+				d.add("X", "K")
+			}
+		} else {
+			d.addBoth("X")
+		}
+		return current + 2
+	}
+
+	// E.g. 'czerny'.
+	if d.stringAt(current, 2, "CZ") && !d.stringAt(current-2, 4, "WICZ") {
+		d.add("S", "X")
+		return current + 2
+	}
+
+	// E.g. 'focaccia'.
+	if d.stringAt(current+1, 3, "CIA") {
+		d.addBoth("X")
+		return current + 3
+	}
+
+	// Double 'C', but not if e.g. 'McClellan'.
+	if d.stringAt(current, 2, "CC") && !(current == 1 && d.at(0) == 'M') {
+		// 'bellocchio' but not 'bacchus'.
+		if d.stringAt(current+2, 1, "I", "E", "H") && !d.stringAt(current+2, 2, "HU") {
+			if (current == 1 && d.at(current-1) == 'A') ||
+				d.stringAt(current-1, 5, "UCCEE", "UCCES") {
+				// 'accident', 'accede', 'succeed'.
+				d.addBoth("KS")
+			} else {
+				// 'bacci', 'bertucci', other Italian.
+				d.addBoth("X")
+			}
+			return current + 3
+		}
+		// Pierce's rule.
+		d.addBoth("K")
+		return current + 2
+	}
+
+	if d.stringAt(current, 2, "CK", "CG", "CQ") {
+		d.addBoth("K")
+		return current + 2
+	}
+
+	if d.stringAt(current, 2, "CI", "CE", "CY") {
+		// Italian vs. English.
+		if d.stringAt(current, 3, "CIO", "CIE", "CIA") {
+			d.add("S", "X")
+		} else {
+			d.addBoth("S")
+		}
+		return current + 2
+	}
+
+	d.addBoth("K")
+	// Names such as 'mac caffrey', 'mac gregor'.
+	if d.stringAt(current+1, 2, " C", " Q", " G") {
+		return current + 3
+	}
+	if d.stringAt(current+1, 1, "C", "K", "Q") && !d.stringAt(current+1, 2, "CE", "CI") {
+		return current + 2
+	}
+	return current + 1
+}
+
+func (d *dmetaphone) stepG(current int) int {
+	if d.at(current+1) == 'H' {
+		if current > 0 && !d.isVowel(current-1) {
+			d.addBoth("K")
+			return current + 2
+		}
+
+		// 'ghislane', 'ghiradelli'.
+		if current == 0 {
+			if d.at(current+2) == 'I' {
+				d.addBoth("J")
+			} else {
+				d.addBoth("K")
+			}
+			return current + 2
+		}
+
+		// Parker's rule (with some further refinements), e.g. 'hugh'.
+		if (current > 1 && d.stringAt(current-2, 1, "B", "H", "D")) ||
+			// E.g. 'bough'.
+			(current > 2 && d.stringAt(current-3, 1, "B", "H", "D")) ||
+			// E.g. 'broughton'.
+			(current > 3 && d.stringAt(current-4, 1, "B", "H")) {
+			return current + 2
+		}
+
+		// E.g. 'laugh', 'McLaughlin', 'cough', 'gough', 'rough', 'tough'.
+		if current > 2 && d.at(current-1) == 'U' &&
+			d.stringAt(current-3, 1, "C", "G", "L", "R", "T") {
+			d.addBoth("F")
+		} else if current > 0 && d.at(current-1) != 'I' {
+			d.addBoth("K")
+		}
+		return current + 2
+	}
+
+	if d.at(current+1) == 'N' {
+		if current == 1 && d.isVowel(0) && !d.isSlavoGermanic() {
+			d.add("KN", "N")
+		} else if !d.stringAt(current+2, 2, "EY") && d.at(current+1) != 'Y' &&
+			!d.isSlavoGermanic() {
+			// Not e.g. 'cagney'.
+			d.add("N", "KN")
+		} else {
+			d.addBoth("KN")
+		}
+		return current + 2
+	}
+
+	// 'tagliaro'.
+	if d.stringAt(current+1, 2, "LI") && !d.isSlavoGermanic() {
+		d.add("KL", "L")
+		return current + 2
+	}
+
+	// -ges-, -gep-, -gel-, -gie- at the beginning.
+	if current == 0 &&
+		(d.at(current+1) == 'Y' ||
+			d.stringAt(current+1, 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")) {
+		d.add("K", "J")
+		return current + 2
+	}
+
+	// -ger-, -gy-.
+	if (d.stringAt(current+1, 2, "ER") || d.at(current+1) == 'Y') &&
+		!d.stringAt(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!d.stringAt(current-1, 1, "E", "I") &&
+		!d.stringAt(current-1, 3, "RGY", "OGY") {
+		d.add("K", "J")
+		return current + 2
+	}
+
+	// Italian, e.g. 'biaggi'.
+	if d.stringAt(current+1, 1, "E", "I", "Y") || d.stringAt(current-1, 4, "AGGI", "OGGI") {
+		if d.stringAt(0, 4, "VAN ", "VON ") || d.stringAt(0, 3, "SCH") ||
+			d.stringAt(current+1, 2, "ET") {
+			// Obviously Germanic.
+			d.addBoth("K")
+		} else if d.stringAt(current+1, 4, "IER ") {
+			// Always soft if French ending.
+			d.addBoth("J")
+		} else {
+			d.add("J", "K")
+		}
+		return current + 2
+	}
+
+	d.addBoth("K")
+	if d.at(current+1) == 'G' {
+		return current + 2
+	}
+	return current + 1
+}
+
+func (d *dmetaphone) stepJ(current, last int) int {
+	// Obviously Spanish, 'jose', 'san jacinto'.
+	if d.stringAt(current, 4, "JOSE") || d.stringAt(0, 4, "SAN ") {
+		if (current == 0 && d.at(current+4) == ' ') || d.stringAt(0, 4, "SAN ") {
+			d.addBoth("H")
+		} else {
+			d.add("J", "H")
+		}
+		return current + 1
+	}
+
+	if current == 0 && !d.stringAt(current, 4, "JOSE") {
+		// Yankelovich/Jankelowicz.
+		d.add("J", "A")
+	} else if d.isVowel(current-1) && !d.isSlavoGermanic() &&
+		(d.at(current+1) == 'A' || d.at(current+1) == 'O') {
+		// Spanish pronunciation of e.g. 'bajador'.
+		d.add("J", "H")
+	} else if current == last {
+		d.add("J", "")
+	} else if !d.stringAt(current+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") &&
+		!d.stringAt(current-1, 1, "S", "K", "L") {
+		d.addBoth("J")
+	}
+
+	// It could happen!
+	if d.at(current+1) == 'J' {
+		return current + 2
+	}
+	return current + 1
+}
+
+func (d *dmetaphone) stepS(current, last int) int {
+	// Special cases 'island', 'isle', 'carlisle', 'carlysle'.
+	if d.stringAt(current-1, 3, "ISL", "YSL") {
+		return current + 1
+	}
+
+	// Special case 'sugar-'.
+	if current == 0 && d.stringAt(current, 5, "SUGAR") {
+		d.add("X", "S")
+		return current + 1
+	}
+
+	if d.stringAt(current, 2, "SH") {
+		if d.stringAt(current+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			// Germanic.
+			d.addBoth("S")
+		} else {
+			d.addBoth("X")
+		}
+		return current + 2
+	}
+
+	// Italian and Armenian.
+	if d.stringAt(current, 3, "SIO", "SIA") || d.stringAt(current, 4, "SIAN") {
+		if !d.isSlavoGermanic() {
+			d.add("S", "X")
+		} else {
+			d.addBoth("S")
+		}
+		return current + 3
+	}
+
+	// German and anglicisations, e.g. 'smith' matches 'schmidt' and 'snider'
+	// matches 'schneider'. Also, -sz- in Slavic languages, although it is
+	// pronounced 's' in Hungarian.
+	if (current == 0 && d.stringAt(current+1, 1, "M", "N", "L", "W")) ||
+		d.stringAt(current+1, 1, "Z") {
+		d.add("S", "X")
+		if d.stringAt(current+1, 1, "Z") {
+			return current + 2
+		}
+		return current + 1
+	}
+
+	if d.stringAt(current, 2, "SC") {
+		// Schlesinger's rule.
+		if d.at(current+2) == 'H' {
+			// Dutch origin, e.g. 'school', 'schooner'.
+			if d.stringAt(current+3, 2, "OO", "ER", "EN", "UY", "ED", "EM") {
+				if d.stringAt(current+3, 2, "ER", "EN") {
+					// 'schermerhorn', 'schenker'.
+					d.add("X", "SK")
+				} else {
+					d.addBoth("SK")
+				}
+				return current + 3
+			}
+			if current == 0 && !d.isVowel(3) && d.at(3) != 'W' {
+				d.add("X", "S")
+			} else {
+				d.addBoth("X")
+			}
+			return current + 3
+		}
+		if d.stringAt(current+2, 1, "I", "E", "Y") {
+			d.addBoth("S")
+			return current + 3
+		}
+		d.addBoth("SK")
+		return current + 3
+	}
+
+	// French, e.g. 'resnais', 'artois'.
+	if current == last && d.stringAt(current-2, 2, "AI", "OI") {
+		d.add("", "S")
+	} else {
+		d.addBoth("S")
+	}
+	if d.stringAt(current+1, 1, "S", "Z") {
+		return current + 2
+	}
+	return current + 1
+}
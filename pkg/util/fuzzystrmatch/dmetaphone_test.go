@@ -0,0 +1,76 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package fuzzystrmatch
+
+import (
+	crypto_rand "crypto/rand"
+	"math/rand"
+	"testing"
+)
+
+func TestDoubleMetaphone(t *testing.T) {
+	tt := []struct {
+		Source            string
+		ExpectedPrimary   string
+		ExpectedAlternate string
+	}{
+		{Source: "", ExpectedPrimary: "", ExpectedAlternate: ""},
+		{Source: "gumbo", ExpectedPrimary: "KMP", ExpectedAlternate: "KMP"},
+		{Source: "Smith", ExpectedPrimary: "SM0", ExpectedAlternate: "XMT"},
+		{Source: "Schmidt", ExpectedPrimary: "XMT", ExpectedAlternate: "SMT"},
+		{Source: "Jose", ExpectedPrimary: "HS", ExpectedAlternate: "HS"},
+		{Source: "Xavier", ExpectedPrimary: "SF", ExpectedAlternate: "SFR"},
+		{Source: "Michael", ExpectedPrimary: "MKL", ExpectedAlternate: "MXL"},
+		{Source: "Caesar", ExpectedPrimary: "SSR", ExpectedAlternate: "SSR"},
+		{Source: "Chianti", ExpectedPrimary: "KNT", ExpectedAlternate: "KNT"},
+		{Source: "edge", ExpectedPrimary: "AJ", ExpectedAlternate: "AJ"},
+		{Source: "laugh", ExpectedPrimary: "LF", ExpectedAlternate: "LF"},
+		{Source: "Arnow", ExpectedPrimary: "ARN", ExpectedAlternate: "ARNF"},
+		{Source: "filipowicz", ExpectedPrimary: "FLPT", ExpectedAlternate: "FLPF"},
+		{Source: "breaux", ExpectedPrimary: "PR", ExpectedAlternate: "PR"},
+		{Source: "zhao", ExpectedPrimary: "J", ExpectedAlternate: "J"},
+		{Source: "school", ExpectedPrimary: "SKL", ExpectedAlternate: "SKL"},
+		{Source: "sugar", ExpectedPrimary: "XKR", ExpectedAlternate: "SKR"},
+		{Source: "tagliaro", ExpectedPrimary: "TKLR", ExpectedAlternate: "TLR"},
+		{Source: "biaggi", ExpectedPrimary: "PJ", ExpectedAlternate: "PK"},
+		{Source: "Wasserman", ExpectedPrimary: "ASRM", ExpectedAlternate: "FSRM"},
+		{Source: "McHugh", ExpectedPrimary: "MK", ExpectedAlternate: "MK"},
+		{Source: "bacci", ExpectedPrimary: "PX", ExpectedAlternate: "PX"},
+		{Source: "accident", ExpectedPrimary: "AKST", ExpectedAlternate: "AKST"},
+		{Source: "czerny", ExpectedPrimary: "SRN", ExpectedAlternate: "XRN"},
+		{Source: "Gallegos", ExpectedPrimary: "KLKS", ExpectedAlternate: "KKS"},
+		{Source: "rogier", ExpectedPrimary: "RJ", ExpectedAlternate: "RJR"},
+		{Source: "dumb", ExpectedPrimary: "TM", ExpectedAlternate: "TM"},
+		{Source: "campbell", ExpectedPrimary: "KMPL", ExpectedAlternate: "KMPL"},
+		{Source: "Ñandu", ExpectedPrimary: "NNT", ExpectedAlternate: "NNT"},
+		{Source: "😄 🐃 🐯", ExpectedPrimary: "", ExpectedAlternate: ""},
+	}
+
+	// Run some random test cases to make sure we don't panic.
+	for i := 0; i < 1000; i++ {
+		l := rand.Int31n(10)
+		b := make([]byte, l)
+		_, _ = crypto_rand.Read(b)
+
+		_, _ = DoubleMetaphone(string(b))
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Source, func(t *testing.T) {
+			primary, alternate := DoubleMetaphone(tc.Source)
+			if tc.ExpectedPrimary != primary || tc.ExpectedAlternate != alternate {
+				t.Fatalf("error converting string to its Double Metaphone codes with source=%q"+
+					" expected (%s, %s) got (%s, %s)",
+					tc.Source, tc.ExpectedPrimary, tc.ExpectedAlternate, primary, alternate)
+			}
+		})
+	}
+}
@@ -169,3 +169,171 @@ func Similarity(l string, r string) float64 {
 	shared := float64(nShared)
 	return shared / (float64(len(lTrigrams)+len(rTrigrams)) - shared)
 }
+
+// wordBound marks the trigrams at the boundaries of a word.
+type wordBound uint8
+
+const (
+	// wordBoundLeft marks the first trigram of a word.
+	wordBoundLeft wordBound = 1 << iota
+	// wordBoundRight marks the last trigram of a word.
+	wordBoundRight
+)
+
+// makeOrderedTrigrams returns the downcased and padded trigrams of an input
+// string in the order in which they appear, without de-duplication, along
+// with the word boundaries of each trigram. Words are split in the same way
+// as MakeTrigrams.
+func makeOrderedTrigrams(s string) (trigrams []string, bounds []wordBound) {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, word := range words {
+		oneByteCharsOnly := true
+		for i := 0; i < len(word); i++ {
+			if word[i] >= utf8.RuneSelf {
+				oneByteCharsOnly = false
+				break
+			}
+		}
+		start := len(trigrams)
+		trigrams = generateTrigrams(trigrams, word, true /* pad */, oneByteCharsOnly)
+		for len(bounds) < len(trigrams) {
+			bounds = append(bounds, 0)
+		}
+		bounds[start] |= wordBoundLeft
+		bounds[len(bounds)-1] |= wordBoundRight
+	}
+	return trigrams, bounds
+}
+
+// WordSimilarity returns the greatest trigram similarity between l and any
+// continuous extent of the ordered trigrams of r. 1.0 means that the trigrams
+// of l all appear in a continuous extent of r, 0.0 means no trigrams were
+// shared. See word_similarity in Postgres contrib/pg_trgm/trgm_op.c.
+func WordSimilarity(l string, r string) float64 {
+	return wordSimilarity(l, r, false /* strict */)
+}
+
+// StrictWordSimilarity is like WordSimilarity, but only considers extents of r
+// which match whole words.
+func StrictWordSimilarity(l string, r string) float64 {
+	return wordSimilarity(l, r, true /* strict */)
+}
+
+// wordSimilarity is a port of calc_word_similarity and iterate_word_similarity
+// in Postgres contrib/pg_trgm/trgm_op.c.
+func wordSimilarity(l string, r string, strict bool) float64 {
+	lTrigrams, _ := makeOrderedTrigrams(l)
+	rTrigrams, bounds := makeOrderedTrigrams(r)
+	if len(lTrigrams) == 0 || len(rTrigrams) == 0 {
+		return 0
+	}
+
+	// Enumerate the distinct trigrams of l and r. found records whether each
+	// distinct trigram is present in l, and rIndexes maps each trigram of r to
+	// its distinct index.
+	indexes := make(map[string]int, len(lTrigrams)+len(rTrigrams))
+	var found []bool
+	lenL := 0
+	for _, t := range lTrigrams {
+		if _, ok := indexes[t]; !ok {
+			indexes[t] = len(found)
+			found = append(found, true)
+			lenL++
+		}
+	}
+	rIndexes := make([]int, len(rTrigrams))
+	for i, t := range rTrigrams {
+		idx, ok := indexes[t]
+		if !ok {
+			idx = len(found)
+			indexes[t] = idx
+			found = append(found, false)
+		}
+		rIndexes[i] = idx
+	}
+
+	calcSimilarity := func(count, lenL, lenR int) float64 {
+		return float64(count) / float64(lenL+lenR-count)
+	}
+
+	// lower is the lower bound of the current extent of r. For strict word
+	// similarity it starts at the first trigram, otherwise it is initialized
+	// with the first trigram present in l.
+	lower := -1
+	if strict {
+		lower = 0
+	}
+	// lastPos records the last position in the current extent of each distinct
+	// trigram.
+	lastPos := make([]int, len(found))
+	for i := range lastPos {
+		lastPos[i] = -1
+	}
+	upper := -1
+	lenR, count := 0, 0
+	maxSimilarity := 0.0
+	for i := range rTrigrams {
+		idx := rIndexes[i]
+		if lower >= 0 || found[idx] {
+			if lastPos[idx] < 0 {
+				lenR++
+				if found[idx] {
+					count++
+				}
+			}
+			lastPos[idx] = i
+		}
+
+		// Adjust the upper bound if the trigram is the upper bound of a word for
+		// strict word similarity, or if the trigram is present in l for plain
+		// word similarity.
+		isUpper := found[idx]
+		if strict {
+			isUpper = bounds[i]&wordBoundRight != 0
+		}
+		if !isUpper {
+			continue
+		}
+		upper = i
+		if lower == -1 {
+			lower = i
+			lenR = 1
+		}
+		cur := calcSimilarity(count, lenL, lenR)
+
+		// Also try to adjust the lower bound for greater similarity.
+		tmpCount, tmpLenR, prevLower := count, lenR, lower
+		for tmpLower := lower; tmpLower <= upper; tmpLower++ {
+			// Adjust the lower bound only if the trigram is the lower bound of a
+			// word for strict word similarity, or consider every trigram as a
+			// lower bound for plain word similarity.
+			if !strict || bounds[tmpLower]&wordBoundLeft != 0 {
+				if tmp := calcSimilarity(tmpCount, lenL, tmpLenR); tmp > cur {
+					cur = tmp
+					lenR = tmpLenR
+					lower = tmpLower
+					count = tmpCount
+				}
+			}
+			tmpIdx := rIndexes[tmpLower]
+			if lastPos[tmpIdx] == tmpLower {
+				tmpLenR--
+				if found[tmpIdx] {
+					tmpCount--
+				}
+			}
+		}
+		if cur > maxSimilarity {
+			maxSimilarity = cur
+		}
+		for tmpLower := prevLower; tmpLower < lower; tmpLower++ {
+			tmpIdx := rIndexes[tmpLower]
+			if lastPos[tmpIdx] == tmpLower {
+				lastPos[tmpIdx] = -1
+			}
+		}
+	}
+	return maxSimilarity
+}
@@ -86,6 +86,33 @@ func TestSimilarity(t *testing.T) {
 	}
 }
 
+func TestWordSimilarity(t *testing.T) {
+	for _, tc := range []struct {
+		l          string
+		r          string
+		want       float64
+		wantStrict float64
+	}{
+		// Empty cases.
+		{"", "", 0, 0},
+		{"a", "", 0, 0},
+		{"", "a", 0, 0},
+		{"_-%#@($", "_-%#@($", 0, 0},
+
+		{"word", "word", 1, 1},
+		{"word", "two words", 0.8, 0.5714},
+		{"word", "two word", 1, 1},
+		{"word", "words", 0.8, 0.5714},
+		{"cat", "concatenation", 0.25, 0.125},
+		{"trigram", "trigrams are great", 0.875, 0.7},
+		{"abc", "xyz", 0, 0},
+		{"Москва", "Москва город", 1, 1},
+	} {
+		assert.InDelta(t, tc.want, WordSimilarity(tc.l, tc.r), 0.0001, "for %s <%% %s", tc.l, tc.r)
+		assert.InDelta(t, tc.wantStrict, StrictWordSimilarity(tc.l, tc.r), 0.0001, "for %s <<%% %s", tc.l, tc.r)
+	}
+}
+
 func BenchmarkSimilarity(b *testing.B) {
 	for _, t := range []struct {
 		x string
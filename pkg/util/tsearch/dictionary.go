@@ -0,0 +1,415 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blevesearch/snowballstem"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// This file implements text search dictionaries and the mapping of token
+// types to dictionaries that makes up a text search configuration. See
+// https://www.postgresql.org/docs/current/textsearch-dictionaries.html.
+
+// TokenType is a type of token produced by the text search parser. Its value
+// is the ID of the token type in the Postgres default parser.
+type TokenType int
+
+const (
+	// AsciiWord is a word made of ASCII letters.
+	AsciiWord TokenType = 1
+	// Word is a word made of letters, some of which aren't ASCII.
+	Word TokenType = 2
+	// NumWord is a word made of letters and digits.
+	NumWord TokenType = 3
+	// UnsignedInt is a token made of digits.
+	UnsignedInt TokenType = 22
+)
+
+// TokenTypes lists the token types that the parser can produce.
+var TokenTypes = []TokenType{AsciiWord, Word, NumWord, UnsignedInt}
+
+// String returns the name of the token type.
+func (t TokenType) String() string {
+	switch t {
+	case AsciiWord:
+		return "asciiword"
+	case Word:
+		return "word"
+	case NumWord:
+		return "numword"
+	case UnsignedInt:
+		return "uint"
+	}
+	return "unknown"
+}
+
+// Description returns a description of the token type, as shown by
+// ts_token_type in Postgres.
+func (t TokenType) Description() string {
+	switch t {
+	case AsciiWord:
+		return "Word, all ASCII"
+	case Word:
+		return "Word, all letters"
+	case NumWord:
+		return "Word, letters and digits"
+	case UnsignedInt:
+		return "Unsigned integer"
+	}
+	return "unknown"
+}
+
+// TokenTypeFromString returns the token type with the given name.
+func TokenTypeFromString(name string) (TokenType, error) {
+	for _, t := range TokenTypes {
+		if strings.EqualFold(name, t.String()) {
+			return t, nil
+		}
+	}
+	return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+		"token type %q does not exist", name)
+}
+
+// tokenTypeOf classifies a token produced by TSParse.
+func tokenTypeOf(token string) TokenType {
+	hasLetter, hasDigit, ascii := false, false, true
+	for _, r := range token {
+		if r >= utf8.RuneSelf {
+			ascii = false
+		}
+		if unicode.IsDigit(r) {
+			hasDigit = true
+		} else {
+			hasLetter = true
+		}
+	}
+	switch {
+	case !hasLetter:
+		return UnsignedInt
+	case hasDigit:
+		return NumWord
+	case ascii:
+		return AsciiWord
+	default:
+		return Word
+	}
+}
+
+// The templates that dictionaries can be created from.
+const (
+	// SimpleTemplate lowercases tokens and checks them against a list of stop
+	// words.
+	SimpleTemplate = "simple"
+	// SynonymTemplate replaces tokens with their synonyms.
+	SynonymTemplate = "synonym"
+	// SnowballTemplate stems tokens with a Snowball stemmer after checking them
+	// against a list of stop words.
+	SnowballTemplate = "snowball"
+)
+
+// Templates lists the templates that dictionaries can be created from.
+var Templates = []string{SimpleTemplate, SnowballTemplate, SynonymTemplate}
+
+// DictionaryOption is an option of a text search dictionary, as specified in
+// CREATE TEXT SEARCH DICTIONARY.
+type DictionaryOption struct {
+	Name  string
+	Value string
+}
+
+// Dictionary is a text search dictionary. It normalizes a token into a lexeme,
+// recognizes it as a stop word, or doesn't recognize it, in which case the
+// token is passed on to the next dictionary of the configuration.
+type Dictionary struct {
+	template  string
+	stopwords map[string]struct{}
+	// accept is false if a simple dictionary passes on the tokens which aren't
+	// stop words instead of recognizing them.
+	accept bool
+	// stemmer is the stemmer of a snowball dictionary.
+	stemmer func(env *snowballstem.Env) bool
+	// synonyms maps each word of a synonym dictionary to its synonym.
+	synonyms      map[string]string
+	caseSensitive bool
+}
+
+// NewDictionary returns a dictionary created from the given template with the
+// given options. The options accepted by each template follow Postgres:
+//
+//   - simple: STOPWORDS and ACCEPT.
+//   - synonym: SYNONYMS (required) and CASESENSITIVE.
+//   - snowball: LANGUAGE (required) and STOPWORDS.
+//
+// Since there are no dictionary files, STOPWORDS is either the name of a
+// builtin stop word list, such as english, or a list of stop words separated
+// by whitespace or commas. SYNONYMS is a list of entries separated by newlines
+// or commas, where each entry is a word followed by its synonym.
+func NewDictionary(template string, options []DictionaryOption) (*Dictionary, error) {
+	switch template {
+	case SimpleTemplate, SynonymTemplate, SnowballTemplate:
+	default:
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search template %q does not exist", template)
+	}
+	d := &Dictionary{template: template, accept: true}
+	var hasSynonyms, hasLanguage bool
+	for _, opt := range options {
+		var err error
+		switch name := strings.ToLower(opt.Name); {
+		case name == "stopwords" && template != SynonymTemplate:
+			d.stopwords = parseStopwords(opt.Value)
+		case name == "accept" && template == SimpleTemplate:
+			d.accept, err = parseDictionaryBool(opt)
+		case name == "synonyms" && template == SynonymTemplate:
+			hasSynonyms = true
+			d.synonyms, err = parseSynonyms(opt.Value)
+		case name == "casesensitive" && template == SynonymTemplate:
+			d.caseSensitive, err = parseDictionaryBool(opt)
+		case name == "language" && template == SnowballTemplate:
+			hasLanguage = true
+			d.stemmer, err = getSnowballStemmer(opt.Value)
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized %s dictionary parameter: %q", template, opt.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch template {
+	case SynonymTemplate:
+		if !hasSynonyms {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "missing Synonyms parameter")
+		}
+		if !d.caseSensitive {
+			lower := make(map[string]string, len(d.synonyms))
+			for word, synonym := range d.synonyms {
+				lower[strings.ToLower(word)] = strings.ToLower(synonym)
+			}
+			d.synonyms = lower
+		}
+	case SnowballTemplate:
+		if !hasLanguage {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "missing Language parameter")
+		}
+	}
+	return d, nil
+}
+
+// getSnowballStemmer returns the stemmer for the LANGUAGE option of a snowball
+// dictionary.
+func getSnowballStemmer(language string) (func(env *snowballstem.Env) bool, error) {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language != "simple" {
+		if stemmer, err := getStemmer(language); err == nil {
+			return stemmer, nil
+		}
+	}
+	return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+		"no Snowball stemmer available for language %q", language)
+}
+
+// parseDictionaryBool parses the value of a boolean dictionary option.
+func parseDictionaryBool(opt DictionaryOption) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(opt.Value)) {
+	case "1", "on", "true", "t", "y", "yes":
+		return true, nil
+	case "0", "off", "false", "f", "n", "no":
+		return false, nil
+	}
+	return false, pgerror.Newf(pgcode.InvalidParameterValue,
+		"%s requires a Boolean value", opt.Name)
+}
+
+// parseStopwords returns the stop word list named by the input, or the stop
+// words listed in it.
+func parseStopwords(input string) map[string]struct{} {
+	name := strings.ToLower(strings.TrimSpace(input))
+	if stopwords, ok := stopwordsMap[name]; ok && name != "simple" {
+		return stopwords
+	}
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	stopwords := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		stopwords[word] = struct{}{}
+	}
+	return stopwords
+}
+
+// parseSynonyms parses a list of synonym entries.
+func parseSynonyms(input string) (map[string]string, error) {
+	synonyms := make(map[string]string)
+	for _, entry := range strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == '\n'
+	}) {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid synonym entry %q: expected a word and its synonym", strings.TrimSpace(entry))
+		}
+		synonyms[fields[0]] = fields[1]
+	}
+	return synonyms, nil
+}
+
+// lexize normalizes a token with the dictionary. ok is false if the dictionary
+// doesn't recognize the token.
+func (d *Dictionary) lexize(token string) (lexeme string, stopWord bool, ok bool) {
+	lower := strings.ToLower(token)
+	switch d.template {
+	case SynonymTemplate:
+		word := lower
+		if d.caseSensitive {
+			word = token
+		}
+		synonym, found := d.synonyms[word]
+		if !found {
+			return "", false, false
+		}
+		return synonym, false, true
+	case SnowballTemplate:
+		if _, found := d.stopwords[lower]; found {
+			return "", true, true
+		}
+		env := snowballstem.NewEnv(lower)
+		d.stemmer(env)
+		return env.Current(), false, true
+	default:
+		if _, found := d.stopwords[lower]; found {
+			return "", true, true
+		}
+		return lower, false, d.accept
+	}
+}
+
+// Lexize implements the ts_lexize builtin. It returns the lexemes that the
+// dictionary produces for the token, an empty list if the token is a stop
+// word, and ok=false if the dictionary doesn't recognize the token.
+func (d *Dictionary) Lexize(token string) (lexemes []string, ok bool) {
+	lexeme, stopWord, ok := d.lexize(token)
+	if !ok || stopWord {
+		return nil, ok
+	}
+	return []string{lexeme}, true
+}
+
+// Config is a text search configuration. It maps each token type to the
+// dictionaries which are consulted in order to normalize tokens of that type.
+// The first dictionary which recognizes a token determines its lexeme. Tokens
+// which aren't recognized by any dictionary are ignored like stop words.
+type Config struct {
+	mappings map[TokenType][]*Dictionary
+}
+
+// NewConfig returns a configuration without mappings, which ignores all
+// tokens.
+func NewConfig() *Config {
+	return &Config{mappings: make(map[TokenType][]*Dictionary)}
+}
+
+// AddMapping appends dictionaries to the mapping of the token type.
+func (c *Config) AddMapping(t TokenType, dicts ...*Dictionary) {
+	c.mappings[t] = append(c.mappings[t], dicts...)
+}
+
+// BuiltinDictionaries lists the names of the builtin dictionaries, in order.
+func BuiltinDictionaries() []string {
+	names := []string{"simple"}
+	for name := range stopwordsMap {
+		if name != "simple" {
+			names = append(names, name+"_stem")
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuiltinDictionary returns the builtin dictionary with the given name: simple,
+// which recognizes every token, or a snowball dictionary such as english_stem.
+func BuiltinDictionary(name string) (*Dictionary, error) {
+	if name == "simple" {
+		return &Dictionary{template: SimpleTemplate, accept: true}, nil
+	}
+	if language, ok := strings.CutSuffix(name, "_stem"); ok && language != "simple" {
+		if stopwords, ok := stopwordsMap[language]; ok {
+			stemmer, err := getStemmer(language)
+			if err != nil {
+				return nil, err
+			}
+			return &Dictionary{template: SnowballTemplate, stopwords: stopwords, stemmer: stemmer}, nil
+		}
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject,
+		"text search dictionary %q does not exist", name)
+}
+
+// BuiltinConfigs lists the names of the builtin configurations, in order.
+func BuiltinConfigs() []string {
+	names := make([]string, 0, len(stopwordsMap))
+	for name := range stopwordsMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var builtinConfigs struct {
+	once    sync.Once
+	configs map[string]*Config
+}
+
+// BuiltinConfig returns the builtin configuration with the given name, which
+// maps every token type to the dictionary of the same language.
+func BuiltinConfig(name string) (*Config, error) {
+	builtinConfigs.once.Do(func() {
+		builtinConfigs.configs = make(map[string]*Config, len(stopwordsMap))
+		for configName := range stopwordsMap {
+			d, err := BuiltinDictionary(BuiltinConfigDictionary(configName))
+			if err != nil {
+				panic(errors.NewAssertionErrorWithWrappedErrf(err, "loading builtin configuration %q", configName))
+			}
+			c := NewConfig()
+			for _, t := range TokenTypes {
+				c.AddMapping(t, d)
+			}
+			builtinConfigs.configs[configName] = c
+		}
+	})
+	c, ok := builtinConfigs.configs[name]
+	if !ok {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search configuration %q does not exist", name)
+	}
+	return c, nil
+}
+
+// BuiltinConfigDictionary returns the name of the dictionary that the builtin
+// configuration with the given name uses.
+func BuiltinConfigDictionary(name string) string {
+	if name == "simple" {
+		return name
+	}
+	return name + "_stem"
+}
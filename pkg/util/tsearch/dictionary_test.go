@@ -0,0 +1,87 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDictionaryLexize(t *testing.T) {
+	for _, tc := range []struct {
+		template string
+		options  []DictionaryOption
+		token    string
+		expected []string
+		ok       bool
+	}{
+		{SimpleTemplate, nil, `Cats`, []string{`cats`}, true},
+		{SimpleTemplate, []DictionaryOption{{`StopWords`, `english`}}, `The`, nil, true},
+		{SimpleTemplate, []DictionaryOption{{`stopwords`, `foo, bar`}}, `BAR`, nil, true},
+		{SimpleTemplate, []DictionaryOption{{`accept`, `false`}}, `cats`, nil, false},
+		{SnowballTemplate, []DictionaryOption{{`language`, `english`}}, `Cats`, []string{`cat`}, true},
+		{SnowballTemplate, []DictionaryOption{{`language`, `english`}, {`stopwords`, `english`}}, `the`, nil, true},
+		{SynonymTemplate, []DictionaryOption{{`synonyms`, "postgres pgsql\npostgresql pgsql"}}, `Postgres`, []string{`pgsql`}, true},
+		{SynonymTemplate, []DictionaryOption{{`synonyms`, `postgres pgsql`}}, `mysql`, nil, false},
+		{SynonymTemplate, []DictionaryOption{{`synonyms`, `Postgres PgSQL`}, {`casesensitive`, `true`}}, `Postgres`, []string{`PgSQL`}, true},
+		{SynonymTemplate, []DictionaryOption{{`synonyms`, `Postgres PgSQL`}, {`casesensitive`, `true`}}, `postgres`, nil, false},
+	} {
+		t.Log(tc.template, tc.options, tc.token)
+		d, err := NewDictionary(tc.template, tc.options)
+		require.NoError(t, err)
+		lexemes, ok := d.Lexize(tc.token)
+		assert.Equal(t, tc.ok, ok)
+		assert.Equal(t, tc.expected, lexemes)
+	}
+}
+
+func TestNewDictionaryError(t *testing.T) {
+	for _, tc := range []struct {
+		template string
+		options  []DictionaryOption
+		expected string
+	}{
+		{`ispell`, nil, `text search template "ispell" does not exist`},
+		{SimpleTemplate, []DictionaryOption{{`language`, `english`}}, `unrecognized simple dictionary parameter: "language"`},
+		{SimpleTemplate, []DictionaryOption{{`accept`, `maybe`}}, `accept requires a Boolean value`},
+		{SynonymTemplate, nil, `missing Synonyms parameter`},
+		{SynonymTemplate, []DictionaryOption{{`synonyms`, `a b c`}}, `invalid synonym entry "a b c": expected a word and its synonym`},
+		{SnowballTemplate, nil, `missing Language parameter`},
+		{SnowballTemplate, []DictionaryOption{{`language`, `klingon`}}, `no Snowball stemmer available for language "klingon"`},
+	} {
+		t.Log(tc.template, tc.options)
+		_, err := NewDictionary(tc.template, tc.options)
+		require.EqualError(t, err, tc.expected)
+	}
+}
+
+func TestConfigMappings(t *testing.T) {
+	synonyms, err := NewDictionary(SynonymTemplate, []DictionaryOption{{`synonyms`, `crdb cockroachdb`}})
+	require.NoError(t, err)
+	english, err := BuiltinDictionary(`english_stem`)
+	require.NoError(t, err)
+
+	config := NewConfig()
+	config.AddMapping(AsciiWord, synonyms, english)
+	config.AddMapping(NumWord, english)
+
+	// Words are normalized by the first dictionary that recognizes them, and
+	// tokens of unmapped types, like numbers, are ignored.
+	vector, err := DocumentToTSVector(config, `The crdb databases v23 of 2024`)
+	require.NoError(t, err)
+	assert.Equal(t, `'cockroachdb':2 'databas':3 'v23':4`, vector.String())
+
+	query, err := PlainToTSQuery(config, `CRDB 2024 databases`)
+	require.NoError(t, err)
+	assert.Equal(t, `'cockroachdb' & 'databas'`, query.String())
+}
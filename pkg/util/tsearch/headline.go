@@ -0,0 +1,613 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// This file implements ts_headline, which returns an excerpt of a document
+// with the terms that match a query highlighted. The implementation follows
+// the one in Postgres's wparser_def.c (prsd_headline and friends).
+
+// HeadlineOptions are the options that control the output of ts_headline.
+// See https://www.postgresql.org/docs/current/textsearch-controls.html#TEXTSEARCH-HEADLINE
+// for the meaning of each of them.
+type HeadlineOptions struct {
+	StartSel          string
+	StopSel           string
+	MaxWords          int
+	MinWords          int
+	ShortWord         int
+	HighlightAll      bool
+	MaxFragments      int
+	FragmentDelimiter string
+}
+
+// DefaultHeadlineOptions returns the options used by ts_headline when no
+// options are specified.
+func DefaultHeadlineOptions() HeadlineOptions {
+	return HeadlineOptions{
+		StartSel:          "<b>",
+		StopSel:           "</b>",
+		MaxWords:          35,
+		MinWords:          15,
+		ShortWord:         3,
+		FragmentDelimiter: " ... ",
+	}
+}
+
+// ParseHeadlineOptions parses the options string of ts_headline, which is a
+// comma-separated list of option=value pairs. Values containing spaces or
+// commas may be double-quoted. Options that aren't specified are set to their
+// defaults.
+func ParseHeadlineOptions(input string) (HeadlineOptions, error) {
+	opts := DefaultHeadlineOptions()
+	for _, item := range splitHeadlineOptions(input) {
+		name, val, ok := strings.Cut(item, "=")
+		if !ok {
+			return opts, pgerror.Newf(pgcode.Syntax, "invalid parameter list format: %q", input)
+		}
+		name = strings.TrimSpace(name)
+		val = strings.TrimSpace(val)
+		if len(val) >= 2 && val[0] == '"' && val[len(val)-1] == '"' {
+			val = strings.ReplaceAll(val[1:len(val)-1], `""`, `"`)
+		}
+		var err error
+		switch strings.ToLower(name) {
+		case "startsel":
+			opts.StartSel = val
+		case "stopsel":
+			opts.StopSel = val
+		case "maxwords":
+			opts.MaxWords, err = parseHeadlineInt(name, val)
+		case "minwords":
+			opts.MinWords, err = parseHeadlineInt(name, val)
+		case "shortword":
+			opts.ShortWord, err = parseHeadlineInt(name, val)
+		case "highlightall":
+			switch strings.ToLower(val) {
+			case "1", "on", "true", "t", "y", "yes":
+				opts.HighlightAll = true
+			default:
+				opts.HighlightAll = false
+			}
+		case "maxfragments":
+			opts.MaxFragments, err = parseHeadlineInt(name, val)
+		case "fragmentdelimiter":
+			opts.FragmentDelimiter = val
+		default:
+			return opts, pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized headline parameter: %q", name)
+		}
+		if err != nil {
+			return opts, err
+		}
+	}
+	if !opts.HighlightAll {
+		if opts.MinWords >= opts.MaxWords {
+			return opts, pgerror.New(pgcode.InvalidParameterValue, "MinWords should be less than MaxWords")
+		}
+		if opts.MinWords <= 0 {
+			return opts, pgerror.New(pgcode.InvalidParameterValue, "MinWords should be positive")
+		}
+		if opts.ShortWord < 0 {
+			return opts, pgerror.New(pgcode.InvalidParameterValue, "ShortWord should be >= 0")
+		}
+		if opts.MaxFragments < 0 {
+			return opts, pgerror.New(pgcode.InvalidParameterValue, "MaxFragments should be >= 0")
+		}
+	}
+	return opts, nil
+}
+
+// splitHeadlineOptions splits the input on commas that aren't inside of
+// double quotes. Empty items are omitted.
+func splitHeadlineOptions(input string) []string {
+	var ret []string
+	inQuotes := false
+	start := 0
+	for i := 0; i <= len(input); i++ {
+		if i < len(input) {
+			if input[i] == '"' {
+				inQuotes = !inQuotes
+			}
+			if input[i] != ',' || inQuotes {
+				continue
+			}
+		}
+		if item := strings.TrimSpace(input[start:i]); item != "" {
+			ret = append(ret, item)
+		}
+		start = i + 1
+	}
+	return ret
+}
+
+func parseHeadlineInt(name, val string) (int, error) {
+	i, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue, "invalid value for headline parameter %s: %q", name, val)
+	}
+	return i, nil
+}
+
+// hlToken is a token of a document being processed by ts_headline. Unlike
+// TSParse, the headline parser retains the non-word tokens between words so
+// that the excerpt can be reproduced verbatim.
+type hlToken struct {
+	text   string
+	isWord bool
+	// position is the position of a word within the document, as it would be
+	// reported by to_tsvector.
+	position int
+	// matches contains the lexemes of the query terms that this word matches.
+	matches []string
+
+	// in is true if the token is part of the output.
+	in bool
+	// selected is true if the token should be highlighted.
+	selected bool
+}
+
+type headliner struct {
+	tokens []hlToken
+	q      TSQuery
+	opts   HeadlineOptions
+}
+
+// Headline implements the ts_headline builtin. It returns an excerpt of the
+// given document in which the words matching the query are highlighted.
+func Headline(config *Config, document string, q TSQuery, opts HeadlineOptions) (string, error) {
+	h := headliner{q: q, opts: opts}
+	h.parse(config, document)
+	maxCover := opts.MaxWords * 10
+	if maxCover < 100 {
+		maxCover = 100
+	}
+	var err error
+	switch {
+	case opts.HighlightAll:
+		h.markFragment(0, len(h.tokens)-1)
+	case opts.MaxFragments == 0:
+		err = h.markWords(maxCover)
+	default:
+		err = h.markFragments(maxCover)
+	}
+	if err != nil {
+		return "", err
+	}
+	return h.generate(), nil
+}
+
+// parse splits the document into tokens and determines which of the words
+// match terms of the query.
+func (h *headliner) parse(config *Config, document string) {
+	leaves := sortAndDistinctQueryTerms(h.q)
+	position := 0
+	for len(document) > 0 {
+		r, _ := utf8.DecodeRuneInString(document)
+		isWord := unicode.IsOneOf(validCharTables, r)
+		end := strings.IndexFunc(document, func(r rune) bool {
+			return unicode.IsOneOf(validCharTables, r) != isWord
+		})
+		if end < 0 {
+			end = len(document)
+		}
+		tok := hlToken{text: document[:end], isWord: isWord}
+		document = document[end:]
+		if isWord {
+			if position < maxTSVectorPosition {
+				position++
+			}
+			tok.position = position
+			lexeme, stopWord := TSLexize(config, tok.text)
+			if !stopWord {
+				for _, leaf := range leaves {
+					if lexeme == leaf.term.lexeme ||
+						(leaf.term.isPrefixMatch() && strings.HasPrefix(lexeme, leaf.term.lexeme)) {
+						tok.matches = append(tok.matches, leaf.term.lexeme)
+					}
+				}
+			}
+		}
+		h.tokens = append(h.tokens, tok)
+	}
+}
+
+// interesting returns true if the i-th token matches a term of the query.
+func (h *headliner) interesting(i int) bool {
+	return len(h.tokens[i].matches) > 0
+}
+
+// badEndpoint returns true if the excerpt should not start or end at the i-th
+// token, because it isn't a word or it is too short.
+func (h *headliner) badEndpoint(i int) bool {
+	tok := &h.tokens[i]
+	return !tok.isWord || utf8.RuneCountInString(tok.text) <= h.opts.ShortWord
+}
+
+// matches returns true if the query is satisfied by the tokens between from
+// and to, inclusive.
+func (h *headliner) matches(from, to int) (bool, error) {
+	var v TSVector
+	for i := from; i <= to; i++ {
+		for _, lexeme := range h.tokens[i].matches {
+			v = append(v, tsTerm{
+				lexeme:    lexeme,
+				positions: []tsPosition{{position: uint16(h.tokens[i].position)}},
+			})
+		}
+	}
+	v, err := normalizeTSVector(v)
+	if err != nil {
+		return false, err
+	}
+	return EvalTSQuery(h.q, v)
+}
+
+// cover looks for the earliest and shortest sequence of tokens that starts at
+// or after the given position and satisfies the query. Both endpoints of the
+// sequence are words matching terms of the query. It returns false if no such
+// sequence exists.
+func (h *headliner) cover(start int, maxCover int) (found bool, p int, q int, err error) {
+	pMin := start
+	for pMin >= 0 && pMin < len(h.tokens) {
+		nextPMin := -1
+		pMax := pMin
+		for pMax >= 0 && pMax-pMin < maxCover {
+			ok, err := h.matches(pMin, pMax)
+			if err != nil {
+				return false, 0, 0, err
+			}
+			if ok {
+				return true, pMin, pMax, nil
+			}
+			// Advance pMax to the next token that matches a query term.
+			nextPMax := -1
+			for i := pMax + 1; i < len(h.tokens); i++ {
+				if h.interesting(i) {
+					if nextPMin < 0 {
+						nextPMin = i
+					}
+					nextPMax = i
+					break
+				}
+			}
+			pMax = nextPMax
+		}
+		pMin = nextPMin
+	}
+	return false, 0, 0, nil
+}
+
+// markFragment marks the tokens between start and end, inclusive, as part of
+// the output.
+func (h *headliner) markFragment(start, end int) {
+	for i := start; i <= end; i++ {
+		h.tokens[i].selected = h.interesting(i)
+		h.tokens[i].in = true
+	}
+}
+
+// markWords selects a single excerpt of the document that contains the best
+// cover of the query, with between MinWords and MaxWords words. It's used when
+// MaxFragments is 0.
+func (h *headliner) markWords(maxCover int) error {
+	minWords, maxWords := h.opts.MinWords, h.opts.MaxWords
+	bestB, bestE, bestLen := -1, -1, -1
+	p := 0
+	for {
+		found, coverP, q, err := h.cover(p, maxCover)
+		if err != nil {
+			return err
+		}
+		if !found {
+			break
+		}
+		p = coverP
+		// Find the length of the cover in words.
+		curLen, posLen := 0, 0
+		posE := p
+		i := p
+		for ; i <= q && curLen < maxWords; i++ {
+			if h.tokens[i].isWord {
+				curLen++
+			}
+			if h.interesting(i) {
+				posLen++
+			}
+			posE = i
+		}
+		if posLen < bestLen && !h.badEndpoint(bestE) {
+			// We already found a better cover, so try the next one.
+			p++
+			continue
+		}
+		posB := p
+		if curLen < maxWords {
+			// Find a good end.
+			for i = i - 1; i < len(h.tokens) && curLen < maxWords; i++ {
+				if i != q {
+					if h.tokens[i].isWord {
+						curLen++
+					}
+					if h.interesting(i) {
+						posLen++
+					}
+				}
+				posE = i
+				if h.badEndpoint(i) {
+					continue
+				}
+				if curLen >= minWords {
+					break
+				}
+			}
+			if curLen < minWords && i >= len(h.tokens) {
+				// We reached the end of the text and the cover is shorter than
+				// MinWords, so extend it backwards.
+				for i = p - 1; i >= 0; i-- {
+					if h.tokens[i].isWord {
+						curLen++
+					}
+					if h.interesting(i) {
+						posLen++
+					}
+					if curLen >= maxWords {
+						break
+					}
+					if h.badEndpoint(i) {
+						continue
+					}
+					if curLen >= minWords {
+						break
+					}
+				}
+				if i < 0 {
+					i = 0
+				}
+				posB = i
+			}
+		} else {
+			// The cover is longer than MaxWords, so shorten it.
+			if i > q {
+				i = q
+			}
+			for ; curLen > minWords; i-- {
+				if h.tokens[i].isWord {
+					curLen--
+				}
+				if h.interesting(i) {
+					posLen--
+				}
+				posE = i
+				if h.badEndpoint(i) {
+					continue
+				}
+				break
+			}
+		}
+		if bestLen < 0 ||
+			(posLen > bestLen && !h.badEndpoint(posE)) ||
+			(!h.badEndpoint(posE) && h.badEndpoint(bestE)) {
+			bestB, bestE, bestLen = posB, posE, posLen
+		}
+		p++
+	}
+
+	if bestLen < 0 {
+		// No cover was found, so show the first MinWords words.
+		bestB, bestE = 0, -1
+		curLen := 0
+		for i := 0; i < len(h.tokens) && curLen < minWords; i++ {
+			if h.tokens[i].isWord {
+				curLen++
+			}
+			bestE = i
+		}
+	}
+	h.markFragment(bestB, bestE)
+	return nil
+}
+
+// hlCover is a candidate fragment of the output of ts_headline.
+type hlCover struct {
+	startPos, endPos int
+	curLen, posLen   int
+	chosen, excluded bool
+}
+
+// nextFragment shrinks the fragment between startPos and endPos such that it
+// has at most MaxWords words, and both of its ends match query terms.
+func (h *headliner) nextFragment(startPos, endPos int) (c hlCover) {
+	// First, move startPos to a token that matches the query.
+	for i := startPos; i <= endPos; i++ {
+		startPos = i
+		if h.interesting(i) {
+			break
+		}
+	}
+	// Then, cut endPos to have only MaxWords words.
+	i := startPos
+	for ; i <= endPos && c.curLen < h.opts.MaxWords; i++ {
+		if h.tokens[i].isWord {
+			c.curLen++
+		}
+		if h.interesting(i) {
+			c.posLen++
+		}
+	}
+	// If the cover was cut, move endPos back to a token that matches the query.
+	if endPos > i {
+		endPos = i
+		for i = endPos; i >= startPos; i-- {
+			endPos = i
+			if h.interesting(i) {
+				break
+			}
+			if h.tokens[i].isWord {
+				c.curLen--
+			}
+		}
+	}
+	c.startPos, c.endPos = startPos, endPos
+	return c
+}
+
+// markFragments selects up to MaxFragments excerpts of the document that
+// contain covers of the query.
+func (h *headliner) markFragments(maxCover int) error {
+	minWords, maxWords := h.opts.MinWords, h.opts.MaxWords
+	var covers []hlCover
+	p := 0
+	for {
+		found, coverP, q, err := h.cover(p, maxCover)
+		if err != nil {
+			return err
+		}
+		if !found {
+			break
+		}
+		p = coverP
+		// Break the cover into smaller fragments such that each fragment has at
+		// most MaxWords words, and both ends of each fragment are query terms.
+		// This allows us to stretch the fragment in either direction.
+		for startPos := p; startPos <= q; {
+			c := h.nextFragment(startPos, q)
+			covers = append(covers, c)
+			startPos = c.endPos + 1
+		}
+		p++
+	}
+
+	numFragments := 0
+	for f := 0; f < h.opts.MaxFragments; f++ {
+		// Choose the cover that contains the most query terms. In case of a tie,
+		// choose the one with the fewest words.
+		maxItems, minLen, best := 0, math.MaxInt, -1
+		for i := range covers {
+			c := &covers[i]
+			if !c.chosen && !c.excluded &&
+				(maxItems < c.posLen || (maxItems == c.posLen && minLen > c.curLen)) {
+				maxItems, minLen, best = c.posLen, c.curLen, i
+			}
+		}
+		if best < 0 {
+			// No selectable covers remain.
+			break
+		}
+		c := &covers[best]
+		c.chosen = true
+		startPos, endPos, curLen := c.startPos, c.endPos, c.curLen
+		if curLen < maxWords {
+			// Stretch the cover, dividing the stretch between both sides. First,
+			// stretch the start until we hit the beginning of the document, we
+			// exceed the maximum stretch, or we hit an already marked fragment.
+			maxStretch := (maxWords - curLen) / 2
+			stretch := 0
+			posMarker := startPos
+			for i := startPos - 1; i >= 0 && stretch < maxStretch && !h.tokens[i].in; i-- {
+				if h.tokens[i].isWord {
+					curLen++
+					stretch++
+				}
+				posMarker = i
+			}
+			// Cut back the start until we find a good endpoint.
+			i := posMarker
+			for ; i < startPos && h.badEndpoint(i); i++ {
+				if h.tokens[i].isWord {
+					curLen--
+				}
+			}
+			startPos = i
+			// Now stretch the end as much as possible.
+			posMarker = endPos
+			for i = endPos + 1; i < len(h.tokens) && curLen < maxWords && !h.tokens[i].in; i++ {
+				if h.tokens[i].isWord {
+					curLen++
+				}
+				posMarker = i
+			}
+			// Cut back the end until we find a good endpoint.
+			for i = posMarker; i > endPos && h.badEndpoint(i); i-- {
+				if h.tokens[i].isWord {
+					curLen--
+				}
+			}
+			endPos = i
+		}
+		c.startPos, c.endPos, c.curLen = startPos, endPos, curLen
+		h.markFragment(startPos, endPos)
+		numFragments++
+		// Exclude covers overlapping this one from future consideration.
+		for i := range covers {
+			o := &covers[i]
+			if i != best &&
+				((o.startPos >= startPos && o.startPos <= endPos) ||
+					(o.endPos >= startPos && o.endPos <= endPos) ||
+					(o.startPos < startPos && o.endPos > endPos)) {
+				o.excluded = true
+			}
+		}
+	}
+
+	if numFragments == 0 {
+		// Show the first MinWords words if we haven't marked anything.
+		endPos, curLen := -1, 0
+		for i := 0; i < len(h.tokens) && curLen < minWords; i++ {
+			if h.tokens[i].isWord {
+				curLen++
+			}
+			endPos = i
+		}
+		h.markFragment(0, endPos)
+	}
+	return nil
+}
+
+// generate returns the headline consisting of the tokens that were marked as
+// part of the output.
+func (h *headliner) generate() string {
+	var buf strings.Builder
+	inFragment := false
+	numFragments := 0
+	for i := range h.tokens {
+		tok := &h.tokens[i]
+		if !tok.in {
+			inFragment = false
+			continue
+		}
+		if !inFragment {
+			// This is the start of a new fragment.
+			inFragment = true
+			numFragments++
+			if numFragments > 1 {
+				buf.WriteString(h.opts.FragmentDelimiter)
+			}
+		}
+		if tok.selected {
+			buf.WriteString(h.opts.StartSel)
+			buf.WriteString(tok.text)
+			buf.WriteString(h.opts.StopSel)
+		} else {
+			buf.WriteString(tok.text)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadline(t *testing.T) {
+	const doc = `The fat cat sat on the mat`
+	tcs := []struct {
+		config   string
+		document string
+		query    string
+		options  string
+		expected string
+	}{
+		{`english`, doc, `cat`, ``, `The fat <b>cat</b> sat on the mat`},
+		{`english`, doc, `cat`, `StartSel=<<, StopSel=>>`, `The fat <<cat>> sat on the mat`},
+		{`english`, doc, `cat & mat`, `StartSel = "[", StopSel = "]"`, `The fat [cat] sat on the [mat]`},
+		{`english`, doc, `dog`, `MaxWords=3, MinWords=2`, `The fat`},
+		{`simple`, `one two three four five six seven eight nine ten`, `seven`,
+			`MaxWords=4, MinWords=2, ShortWord=0`, `five six <b>seven</b>`},
+		{`simple`, `one two three four five six seven eight nine ten`, `two`,
+			`HighlightAll=true`, `one <b>two</b> three four five six seven eight nine ten`},
+		{`simple`, `a b c d e f g h i j k l m n o p q r s t`, `b | s`,
+			`MaxFragments=2, MaxWords=3, MinWords=1, ShortWord=0, FragmentDelimiter=" / "`,
+			`a <b>b</b> c / r <b>s</b> t`},
+	}
+	for _, tc := range tcs {
+		t.Log(tc.query, tc.options)
+		q, err := ParseTSQuery(tc.query)
+		require.NoError(t, err)
+		opts, err := ParseHeadlineOptions(tc.options)
+		require.NoError(t, err)
+		config, err := BuiltinConfig(tc.config)
+		require.NoError(t, err)
+		actual, err := Headline(config, tc.document, q, opts)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, actual)
+	}
+}
+
+func TestParseHeadlineOptionsError(t *testing.T) {
+	for _, tc := range []string{
+		`MaxWords`,
+		`Foo=1`,
+		`MaxWords=abc`,
+		`MaxWords=5, MinWords=5`,
+		`MinWords=0`,
+		`ShortWord=-1`,
+		`MaxFragments=-1`,
+	} {
+		t.Log(tc)
+		_, err := ParseHeadlineOptions(tc)
+		assert.Error(t, err)
+	}
+}
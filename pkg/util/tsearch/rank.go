@@ -14,6 +14,9 @@ import (
 	"math"
 	"sort"
 	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 )
 
 // defaultWeights is the default list of weights corresponding to the tsvector
@@ -25,12 +28,8 @@ var defaultWeights = [4]float32{0.1, 0.2, 0.4, 1.0}
 // 0, the default, ignores the document length.
 // 1 devides the rank by 1 + the logarithm of the document length.
 // 2 divides the rank by the document length.
-// 4 divides the rank by the mean harmonic distance between extents.
-//
-//	NOTE: This is only implemented by ts_rank_cd, which is currently not
-//	implemented by CockroachDB. This constant is left for consistency with
-//	the original PostgreSQL source code.
-//
+// 4 divides the rank by the mean harmonic distance between extents. This is
+// only implemented by ts_rank_cd.
 // 8 divides the rank by the number of unique words in document.
 // 16 divides the rank by 1 + the logarithm of the number of unique words in document.
 // 32 divides the rank by itself + 1.
@@ -44,8 +43,7 @@ const (
 	// rankNormLength divides the rank by the document length.
 	rankNormLength = 0x02
 	// rankNormExtdist divides the rank by the mean harmonic distance between extents.
-	// Note, this is only implemented by ts_rank_cd, which is not currently implemented
-	// by CockroachDB. The constant is kept for consistency with Postgres.
+	// Note, this is only implemented by ts_rank_cd.
 	rankNormExtdist = 0x04
 	// rankNormUniq divides the rank by the number of unique words in document.
 	rankNormUniq = 0x08
@@ -57,7 +55,6 @@ const (
 
 // Defeat the unused linter.
 var _ = rankNoNorm
-var _ = rankNormExtdist
 
 // cntLen returns the count of represented lexemes in a tsvector, including
 // the number of repeated lexemes in the vector.
@@ -284,3 +281,232 @@ func wordDistance(dist int) float32 {
 	}
 	return float32(1.0 / (1.005 + 0.05*math.Exp(float64(float32(dist)/1.5-2))))
 }
+
+// docRepEntry is a single position in a document at which one or more terms of
+// a query match. It corresponds to the DocRepresentation struct in Postgres's
+// tsrank.c.
+type docRepEntry struct {
+	position uint16
+	weight   tsWeight
+	// lexemes are the lexemes of the query terms that match at this position.
+	lexemes []string
+}
+
+// getDocRep returns the positions of the input vector that match any term of
+// the input query, sorted by position.
+func getDocRep(v TSVector, q TSQuery) []docRepEntry {
+	type docRepKey struct {
+		position uint16
+		entry    int
+	}
+	entries := make(map[docRepKey]*docRepEntry)
+	var doc []*docRepEntry
+	for _, leaf := range sortAndDistinctQueryTerms(q) {
+		target := leaf.term.lexeme
+		queryWeight := leaf.term.queryWeight() &^ weightStar
+		i := sort.Search(len(v), func(i int) bool {
+			return v[i].lexeme >= target
+		})
+		for ; i < len(v); i++ {
+			if leaf.term.isPrefixMatch() {
+				if !strings.HasPrefix(v[i].lexeme, target) {
+					break
+				}
+			} else if v[i].lexeme != target {
+				break
+			}
+			// Note that stripped lexemes, which have no positions, are ignored.
+			for _, pos := range v[i].positions {
+				if queryWeight != 0 && !pos.weight.matches(queryWeight) {
+					continue
+				}
+				key := docRepKey{position: pos.position, entry: i}
+				e, ok := entries[key]
+				if !ok {
+					e = &docRepEntry{position: pos.position, weight: pos.weight}
+					entries[key] = e
+					doc = append(doc, e)
+				}
+				e.lexemes = append(e.lexemes, target)
+			}
+		}
+	}
+	sort.Slice(doc, func(i, j int) bool {
+		return doc[i].position < doc[j].position
+	})
+	ret := make([]docRepEntry, len(doc))
+	for i := range doc {
+		ret[i] = *doc[i]
+	}
+	return ret
+}
+
+// docRepMatches returns true if the query is satisfied by the document
+// positions doc[from:to+1].
+func docRepMatches(doc []docRepEntry, q TSQuery, from, to int) (bool, error) {
+	var v TSVector
+	for i := from; i <= to; i++ {
+		for _, lexeme := range doc[i].lexemes {
+			v = append(v, tsTerm{
+				lexeme:    lexeme,
+				positions: []tsPosition{{position: doc[i].position, weight: doc[i].weight}},
+			})
+		}
+	}
+	v, err := normalizeTSVector(v)
+	if err != nil {
+		return false, err
+	}
+	return EvalTSQuery(q, v)
+}
+
+// coverExt is an extent of a document that satisfies a query. It corresponds
+// to the CoverExt struct in Postgres's tsrank.c.
+type coverExt struct {
+	// pos is the index in the document representation at which to start
+	// searching for the next extent.
+	pos int
+	// p and q are the first and last document positions of the extent.
+	p, q int
+	// begin and end are the indexes in the document representation of the
+	// first and last entries of the extent.
+	begin, end int
+}
+
+// nextCover finds the next shortest extent of the document that satisfies the
+// query, starting the search at ext.pos. It returns false if there are no more
+// such extents.
+func nextCover(doc []docRepEntry, q TSQuery, ext *coverExt) (bool, error) {
+	for ext.pos < len(doc) {
+		ext.p, ext.q = math.MaxInt, 0
+		// Find the upper bound of the cover, moving forwards from the current
+		// position.
+		found := false
+		lastPos := ext.pos
+		for i := ext.pos; i < len(doc); i++ {
+			ok, err := docRepMatches(doc, q, ext.pos, i)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				ext.q = int(doc[i].position)
+				ext.end = i
+				lastPos = i
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+		// Find the lower bound of the cover, moving backwards from the upper
+		// bound.
+		i := lastPos
+		for ; i >= ext.pos; i-- {
+			ok, err := docRepMatches(doc, q, i, lastPos)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				ext.p = int(doc[i].position)
+				ext.begin = i
+				break
+			}
+		}
+		if ext.p <= ext.q {
+			// Start the search for the next cover after the beginning of the one
+			// we found.
+			ext.pos = i + 1
+			return true, nil
+		}
+		ext.pos++
+	}
+	return false, nil
+}
+
+// RankCD implements the ts_rank_cd functionality, which ranks a tsvector
+// against a tsquery using the "cover density" method described in Clarke,
+// Cormack, and Tudhope's "Relevance Ranking for One to Three Term Queries".
+// The parameters are the same as the ones for Rank. Unlike Rank, this function
+// requires positional information, so stripped lexemes are ignored.
+//
+// This function is translated from the calc_rank_cd function in tsrank.c.
+func RankCD(weights []float32, v TSVector, q TSQuery, method int) (float32, error) {
+	w := defaultWeights
+	if weights != nil {
+		copy(w[:4], weights[:4])
+	}
+	var invWeights [4]float64
+	for i := range w {
+		if w[i] > 1 {
+			return 0, pgerror.New(pgcode.InvalidParameterValue, "weight out of range")
+		}
+		if w[i] < 0 {
+			w[i] = defaultWeights[i]
+		}
+		invWeights[i] = 1 / float64(w[i])
+	}
+	if len(v) == 0 || q.root == nil {
+		return 0, nil
+	}
+	doc := getDocRep(v, q)
+	if len(doc) == 0 {
+		return 0, nil
+	}
+
+	var wDoc, sumDist, prevExtPos float64
+	var nExtent int
+	var ext coverExt
+	for {
+		ok, err := nextCover(doc, q, &ext)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			break
+		}
+		var invSum float64
+		for i := ext.begin; i <= ext.end; i++ {
+			invSum += invWeights[doc[i].weight.val()]
+		}
+		cPos := float64(ext.end-ext.begin+1) / invSum
+		// If the document is big enough, q may be equal to p due to the limit of
+		// positional information. In this case, we approximate the number of
+		// noise words as half the cover's length.
+		nNoise := (ext.q - ext.p) - (ext.end - ext.begin)
+		if nNoise < 0 {
+			nNoise = (ext.end - ext.begin) / 2
+		}
+		wDoc += cPos / float64(1+nNoise)
+
+		curExtPos := float64(ext.q+ext.p) / 2
+		if nExtent > 0 && curExtPos > prevExtPos {
+			sumDist += 1 / (curExtPos - prevExtPos)
+		}
+		prevExtPos = curExtPos
+		nExtent++
+	}
+
+	if method&rankNormLoglength > 0 {
+		wDoc /= math.Log(float64(cntLen(v) + 1))
+	}
+	if method&rankNormLength > 0 {
+		l := cntLen(v)
+		if l > 0 {
+			wDoc /= float64(l)
+		}
+	}
+	if method&rankNormExtdist > 0 && nExtent > 0 && sumDist > 0 {
+		wDoc /= float64(nExtent) / sumDist
+	}
+	if method&rankNormUniq > 0 {
+		wDoc /= float64(len(v))
+	}
+	if method&rankNormLoguniq > 0 {
+		wDoc /= math.Log(float64(len(v)+1)) / math.Log(2.0)
+	}
+	if method&rankNormRdivrplus1 > 0 {
+		wDoc /= wDoc + 1
+	}
+	return float32(wDoc), nil
+}
@@ -44,3 +44,33 @@ func TestRank(t *testing.T) {
 		assert.Equalf(t, tt.expected, actual, "Rank(%v, %v, %v, %v)", tt.weights, tt.v, tt.q, tt.method)
 	}
 }
+
+func TestRankCD(t *testing.T) {
+	tests := []struct {
+		weights  []float32
+		v        string
+		q        string
+		method   int
+		expected float32
+	}{
+		{v: "a:1 b:2", q: "a & b", expected: 0.1},
+		{v: "a:1 b:3", q: "a & b", expected: 0.05},
+		{v: "a:1A b:2A", q: "a & b", expected: 1},
+		{v: "a:1 b:2", q: "a | b", expected: 0.2},
+		{v: "a:1 b:2", q: "a <-> b", expected: 0.1},
+		{v: "a:2 b:1", q: "a <-> b", expected: 0},
+		{v: "a:1 b:2", q: "a & c", expected: 0},
+		{v: "a b", q: "a & b", expected: 0},
+		{weights: []float32{1, 1, 1, 1}, v: "a:1 b:2", q: "a & b", expected: 1},
+		{weights: []float32{1, 1, 1, 1}, v: "a:1 b:2", q: "a & b", method: 32, expected: 0.5},
+	}
+	for _, tt := range tests {
+		v, err := ParseTSVector(tt.v)
+		assert.NoError(t, err)
+		q, err := ParseTSQuery(tt.q)
+		assert.NoError(t, err)
+		actual, err := RankCD(tt.weights, v, q, tt.method)
+		assert.NoError(t, err)
+		assert.Equalf(t, tt.expected, actual, "RankCD(%v, %v, %v, %v)", tt.weights, tt.v, tt.q, tt.method)
+	}
+}
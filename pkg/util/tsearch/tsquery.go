@@ -13,6 +13,8 @@ package tsearch
 import (
 	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/cockroachdb/cockroach/pkg/keysbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
@@ -415,21 +417,21 @@ func (p *tsQueryParser) syntaxError() (*tsNode, error) {
 
 // ToTSQuery implements the to_tsquery builtin, which lexes an input, performs
 // stopwording and normalization on the tokens, and returns a parsed query.
-func ToTSQuery(config string, input string) (TSQuery, error) {
+func ToTSQuery(config *Config, input string) (TSQuery, error) {
 	return toTSQuery(config, invalid, input)
 }
 
 // PlainToTSQuery implements the plainto_tsquery builtin, which lexes an input,
 // performs stopwording and normalization on the tokens, and returns a parsed
 // query, interposing the & operator between each token.
-func PlainToTSQuery(config string, input string) (TSQuery, error) {
+func PlainToTSQuery(config *Config, input string) (TSQuery, error) {
 	return toTSQuery(config, and, input)
 }
 
 // PhraseToTSQuery implements the phraseto_tsquery builtin, which lexes an input,
 // performs stopwording and normalization on the tokens, and returns a parsed
 // query, interposing the <-> operator between each token.
-func PhraseToTSQuery(config string, input string) (TSQuery, error) {
+func PhraseToTSQuery(config *Config, input string) (TSQuery, error) {
 	return toTSQuery(config, followedby, input)
 }
 
@@ -437,7 +439,7 @@ func PhraseToTSQuery(config string, input string) (TSQuery, error) {
 // performs stopwording and normalization on the tokens, and returns a parsed
 // query. If the interpose operator is not invalid, it's interposed between each
 // token in the input.
-func toTSQuery(config string, interpose tsOperator, input string) (TSQuery, error) {
+func toTSQuery(config *Config, interpose tsOperator, input string) (TSQuery, error) {
 	vector, err := lexTSQuery(input)
 	if err != nil {
 		return TSQuery{}, err
@@ -499,10 +501,7 @@ func toTSQuery(config string, interpose tsOperator, input string) (TSQuery, erro
 				}
 				tokens = append(tokens, term)
 			}
-			lexeme, stopWord, err := TSLexize(config, lexemeTokens[j])
-			if err != nil {
-				return TSQuery{}, err
-			}
+			lexeme, stopWord := TSLexize(config, lexemeTokens[j])
 			if stopWord {
 				foundStopwords = true
 			}
@@ -652,3 +651,222 @@ func cleanupStopword(node *tsNode) (ret *tsNode, lAdd int, rAdd int) {
 	// Otherwise we found a non-phrase operator; keep it as-is.
 	return node, 0, 0
 }
+
+// webSearchOperand is a single operand of a websearch_to_tsquery input: either
+// an unquoted word or a quoted phrase, possibly negated with a leading -.
+type webSearchOperand struct {
+	words []string
+	// negated is true if the operand was prefixed with -.
+	negated bool
+	// or is true if the operand was separated from the previous one with the
+	// or keyword.
+	or bool
+}
+
+// WebSearchToTSQuery implements the websearch_to_tsquery builtin, which
+// converts an input in the style of a web search engine to a tsquery. Unquoted
+// text is converted to terms separated by &, quoted text is converted to terms
+// separated by <->, the word "or" is converted to |, and a leading - is
+// converted to !. Unlike to_tsquery, syntax errors in the input are never
+// raised; malformed operators are ignored.
+func WebSearchToTSQuery(config *Config, input string) (TSQuery, error) {
+	operands := lexWebSearch(input)
+	var groups [][]*tsNode
+	var group []*tsNode
+	carryOr := false
+	for i := range operands {
+		op := &operands[i]
+		node, err := webSearchOperandToNode(config, op)
+		if err != nil {
+			return TSQuery{}, err
+		}
+		if node == nil {
+			// The operand consisted only of stop words. If it was preceded by an
+			// or, carry the or over to the next operand.
+			carryOr = carryOr || op.or
+			continue
+		}
+		if (op.or || carryOr) && len(group) > 0 {
+			groups = append(groups, group)
+			group = nil
+		}
+		carryOr = false
+		group = append(group, node)
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+	if len(groups) == 0 {
+		return TSQuery{}, pgerror.Newf(pgcode.Syntax, "text-search query doesn't contain lexemes: %s", input)
+	}
+	var root *tsNode
+	for _, g := range groups {
+		andNode := g[0]
+		for _, n := range g[1:] {
+			andNode = &tsNode{op: and, l: andNode, r: n}
+		}
+		if root == nil {
+			root = andNode
+		} else {
+			root = &tsNode{op: or, l: root, r: andNode}
+		}
+	}
+	return TSQuery{root: root}, nil
+}
+
+// lexWebSearch splits a websearch_to_tsquery input into its operands.
+func lexWebSearch(input string) []webSearchOperand {
+	var operands []webSearchOperand
+	negated, or := false, false
+	addOperand := func(text string) {
+		words := TSParse(text)
+		if len(words) == 0 {
+			// The text contained only punctuation, which we ignore.
+			negated = false
+			return
+		}
+		operands = append(operands, webSearchOperand{words: words, negated: negated, or: or})
+		negated, or = false, false
+	}
+	for len(input) > 0 {
+		r, size := utf8.DecodeRuneInString(input)
+		switch {
+		case unicode.IsSpace(r):
+			input = input[size:]
+		case r == '"':
+			// A quoted phrase extends until the next quote or the end of the input.
+			input = input[size:]
+			end := strings.IndexByte(input, '"')
+			if end < 0 {
+				end = len(input)
+			}
+			addOperand(input[:end])
+			input = input[end:]
+			if len(input) > 0 {
+				input = input[1:]
+			}
+		default:
+			end := strings.IndexFunc(input, func(r rune) bool {
+				return unicode.IsSpace(r) || r == '"'
+			})
+			if end < 0 {
+				end = len(input)
+			}
+			word := input[:end]
+			input = input[end:]
+			if strings.EqualFold(word, "or") {
+				// An or is only meaningful between two operands.
+				if len(operands) > 0 {
+					or = true
+				}
+				continue
+			}
+			if strings.HasPrefix(word, "-") {
+				negated = true
+				word = word[1:]
+				if len(word) == 0 {
+					if len(input) == 0 || input[0] != '"' {
+						// A lone - that doesn't precede a phrase is ignored.
+						negated = false
+					}
+					continue
+				}
+			}
+			addOperand(word)
+		}
+	}
+	return operands
+}
+
+// webSearchOperandToNode converts a websearch_to_tsquery operand to a query
+// node, connecting multiple words with the <-> operator. Stop words are removed,
+// with the distance of the surrounding <-> operators adjusted accordingly. It
+// returns nil if the operand consisted only of stop words.
+func webSearchOperandToNode(config *Config, op *webSearchOperand) (*tsNode, error) {
+	var node *tsNode
+	distance := 0
+	for _, word := range op.words {
+		distance++
+		lexeme, stopWord := TSLexize(config, word)
+		if stopWord {
+			continue
+		}
+		term, err := newLexemeTerm(lexeme)
+		if err != nil {
+			return nil, err
+		}
+		leaf := &tsNode{term: term}
+		if node == nil {
+			node = leaf
+		} else {
+			node = &tsNode{op: followedby, followedN: uint16(distance), l: node, r: leaf}
+		}
+		distance = 0
+	}
+	if node != nil && op.negated {
+		node = &tsNode{op: not, l: node}
+	}
+	return node, nil
+}
+
+// Rewrite implements the ts_rewrite builtin. It returns a copy of the input
+// query in which every occurrence of the target query is replaced with the
+// substitute query.
+func Rewrite(q, target, substitute TSQuery) TSQuery {
+	if q.root == nil || target.root == nil || substitute.root == nil {
+		return q
+	}
+	return TSQuery{root: rewriteNode(q.root, target.root, substitute.root)}
+}
+
+func rewriteNode(node, target, substitute *tsNode) *tsNode {
+	if node.equals(target) {
+		return substitute.copy()
+	}
+	ret := *node
+	if node.l != nil {
+		ret.l = rewriteNode(node.l, target, substitute)
+	}
+	if node.r != nil {
+		ret.r = rewriteNode(node.r, target, substitute)
+	}
+	return &ret
+}
+
+// equals returns true if the receiver and the other node represent the same
+// query. The operands of the commutative & and | operators may appear in
+// either order.
+func (n *tsNode) equals(other *tsNode) bool {
+	if n.op != other.op {
+		return false
+	}
+	switch n.op {
+	case invalid:
+		return n.term.lexeme == other.term.lexeme && n.term.queryWeight() == other.term.queryWeight()
+	case not:
+		return n.l.equals(other.l)
+	case followedby:
+		return n.followedN == other.followedN && n.l.equals(other.l) && n.r.equals(other.r)
+	}
+	return (n.l.equals(other.l) && n.r.equals(other.r)) ||
+		(n.l.equals(other.r) && n.r.equals(other.l))
+}
+
+// copy returns a deep copy of the receiver.
+func (n *tsNode) copy() *tsNode {
+	if n == nil {
+		return nil
+	}
+	ret := *n
+	ret.l = n.l.copy()
+	ret.r = n.r.copy()
+	return &ret
+}
+
+// queryWeight returns the weight and prefix-match flags of a query term.
+func (t tsTerm) queryWeight() tsWeight {
+	if len(t.positions) == 0 {
+		return 0
+	}
+	return t.positions[0].weight
+}
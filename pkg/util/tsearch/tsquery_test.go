@@ -295,3 +295,72 @@ func TestParseTSQueryError(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestWebSearchToTSQuery(t *testing.T) {
+	for _, tc := range []struct {
+		config   string
+		input    string
+		expected string
+	}{
+		{`simple`, `fat rat`, `'fat' & 'rat'`},
+		{`english`, `The fat rats`, `'fat' & 'rat'`},
+		{`simple`, `"fat rat"`, `'fat' <-> 'rat'`},
+		{`english`, `"fat the rat"`, `'fat' <2> 'rat'`},
+		{`simple`, `fat or rat`, `'fat' | 'rat'`},
+		{`simple`, `fat OR rat cat`, `'fat' | 'rat' & 'cat'`},
+		{`simple`, `fat -rat`, `'fat' & !'rat'`},
+		{`simple`, `signal -"segmentation fault"`, `'signal' & !( 'segmentation' <-> 'fault' )`},
+		{`simple`, `"sad cat" or "fat rat"`, `'sad' <-> 'cat' | 'fat' <-> 'rat'`},
+		{`simple`, `or cat or`, `'cat'`},
+		{`simple`, `cat - dog`, `'cat' & 'dog'`},
+		{`simple`, `)( dummy \\ query <->`, `'dummy' & 'query'`},
+		{`simple`, `well-known "unterminated phrase`, `'well' <-> 'known' & 'unterminated' <-> 'phrase'`},
+		{`english`, `cat or the rat`, `'cat' | 'rat'`},
+	} {
+		t.Log(tc.input)
+		config, err := BuiltinConfig(tc.config)
+		require.NoError(t, err)
+		query, err := WebSearchToTSQuery(config, tc.input)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, query.String())
+	}
+
+	simple, err := BuiltinConfig("simple")
+	require.NoError(t, err)
+	for _, input := range []string{``, `   `, `or`, `" "`, `-`} {
+		t.Log(input)
+		_, err := WebSearchToTSQuery(simple, input)
+		assert.Error(t, err)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	for _, tc := range []struct {
+		query      string
+		target     string
+		substitute string
+		expected   string
+	}{
+		{`a & b`, `a`, `c`, `'c' & 'b'`},
+		{`a & b`, `a`, `c | d`, `( 'c' | 'd' ) & 'b'`},
+		{`(a | b) & c`, `b | a`, `d`, `'d' & 'c'`},
+		{`a <-> b & a <-> b`, `a <-> b`, `c`, `'c' & 'c'`},
+		{`a <-> b`, `b <-> a`, `c`, `'a' <-> 'b'`},
+		{`a:A & a`, `a`, `c`, `'a':A & 'c'`},
+		{`!a`, `a`, `b`, `!'b'`},
+	} {
+		t.Log(tc.query)
+		q, err := ParseTSQuery(tc.query)
+		require.NoError(t, err)
+		target, err := ParseTSQuery(tc.target)
+		require.NoError(t, err)
+		substitute, err := ParseTSQuery(tc.substitute)
+		require.NoError(t, err)
+		actual := Rewrite(q, target, substitute)
+		assert.Equal(t, tc.expected, actual.String())
+		// The input query must not be modified.
+		reparsed, err := ParseTSQuery(tc.query)
+		require.NoError(t, err)
+		assert.Equal(t, reparsed.String(), q.String())
+	}
+}
@@ -403,50 +403,107 @@ func TSParse(input string) []string {
 
 // TSLexize implements the "dictionary" construct that's exposed via ts_lexize.
 // It gets invoked once per input token to produce an output lexeme during
-// routines like to_tsvector and to_tsquery.
-// It can return true in the second parameter to indicate a stopword was found.
-func TSLexize(config string, token string) (lexeme string, stopWord bool, err error) {
-	stopwords, ok := stopwordsMap[config]
-	if !ok {
-		return "", false, pgerror.Newf(pgcode.UndefinedObject, "text search configuration %q does not exist", config)
-	}
-
-	lower := strings.ToLower(token)
-	if _, ok := stopwords[lower]; ok {
-		return "", true, nil
-	}
-	stemmer, err := getStemmer(config)
-	if err != nil {
-		return "", false, err
+// routines like to_tsvector and to_tsquery. The token is normalized by the
+// first dictionary of the configuration's mapping for its token type which
+// recognizes it.
+// It returns true in the second parameter to indicate a stopword was found, or
+// that no dictionary recognized the token.
+func TSLexize(config *Config, token string) (lexeme string, stopWord bool) {
+	for _, d := range config.mappings[tokenTypeOf(token)] {
+		if lexeme, stopWord, ok := d.lexize(token); ok {
+			return lexeme, stopWord
+		}
 	}
-	env := snowballstem.NewEnv(lower)
-	stemmer(env)
-	return env.Current(), false, nil
+	return "", true
 }
 
 // DocumentToTSVector parses an input document into lexemes, removes stop words,
 // stems and normalizes the lexemes, and returns a TSVector annotated with
-// lexeme positions according to a text search configuration passed by name.
-func DocumentToTSVector(config string, input string) (TSVector, error) {
-	tokens := TSParse(input)
-	vector := make(TSVector, 0, len(tokens))
-	for i := range tokens {
-		lexeme, stopWord, err := TSLexize(config, tokens[i])
-		if err != nil {
-			return nil, err
+// lexeme positions according to a text search configuration.
+func DocumentToTSVector(config *Config, input string) (TSVector, error) {
+	return DocumentsToTSVector(config, []string{input})
+}
+
+// DocumentsToTSVector is like DocumentToTSVector, but converts a series of
+// documents into a single TSVector. Lexeme positions continue from one document
+// to the next, skipping one position after each document that produced at
+// least one lexeme, so that phrase searches don't match across documents. This
+// mirrors how Postgres builds a tsvector out of the values of a JSON document.
+func DocumentsToTSVector(config *Config, inputs []string) (TSVector, error) {
+	vector := make(TSVector, 0, len(inputs))
+	offset := 0
+	for _, input := range inputs {
+		tokens := TSParse(input)
+		foundLexeme := false
+		for i := range tokens {
+			lexeme, stopWord := TSLexize(config, tokens[i])
+			if stopWord {
+				continue
+			}
+			foundLexeme = true
+
+			term := tsTerm{lexeme: lexeme}
+			pos := offset + i + 1
+			if pos > maxTSVectorPosition {
+				// Postgres silently truncates positions larger than 16383 to 16383.
+				pos = maxTSVectorPosition
+			}
+			term.positions = []tsPosition{{position: uint16(pos)}}
+			vector = append(vector, term)
 		}
-		if stopWord {
-			continue
+		offset += len(tokens)
+		if foundLexeme {
+			offset++
 		}
+	}
+	return normalizeTSVector(vector)
+}
+
+// tsWeightFromChar returns the tsWeight corresponding to the given weight
+// letter, which may be one of A, B, C or D (case-insensitive).
+func tsWeightFromChar(c string) (tsWeight, error) {
+	switch c {
+	case "A", "a":
+		return weightA, nil
+	case "B", "b":
+		return weightB, nil
+	case "C", "c":
+		return weightC, nil
+	case "D", "d":
+		// We don't explicitly store weightD, since it's the default.
+		return 0, nil
+	}
+	return 0, pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized weight: %q", c)
+}
 
-		term := tsTerm{lexeme: lexeme}
-		pos := i + 1
-		if i > maxTSVectorPosition {
-			// Postgres silently truncates positions larger than 16383 to 16383.
-			pos = maxTSVectorPosition
+// SetWeight implements the setweight builtin. It returns a copy of the input
+// vector in which every position has been assigned the given weight. If
+// lexemes is non-nil, only the positions of the given lexemes are modified.
+// Lexemes without positions are left unchanged.
+func SetWeight(v TSVector, weight string, lexemes []string) (TSVector, error) {
+	w, err := tsWeightFromChar(weight)
+	if err != nil {
+		return nil, err
+	}
+	var filter map[string]struct{}
+	if lexemes != nil {
+		filter = make(map[string]struct{}, len(lexemes))
+		for _, l := range lexemes {
+			filter[l] = struct{}{}
 		}
-		term.positions = []tsPosition{{position: uint16(pos)}}
-		vector = append(vector, term)
 	}
-	return normalizeTSVector(vector)
+	ret := make(TSVector, len(v))
+	for i := range v {
+		ret[i] = v[i]
+		if filter != nil {
+			if _, ok := filter[v[i].lexeme]; !ok {
+				continue
+			}
+		}
+		ret[i].positions = make([]tsPosition, len(v[i].positions))
+		for j, pos := range v[i].positions {
+			ret[i].positions[j] = tsPosition{position: pos.position, weight: w}
+		}
+	}
+	return ret, nil
 }
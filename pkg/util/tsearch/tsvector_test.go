@@ -218,3 +218,52 @@ func BenchmarkTSVector(b *testing.B) {
 		}
 	})
 }
+
+func TestSetWeight(t *testing.T) {
+	tcs := []struct {
+		input    string
+		weight   string
+		lexemes  []string
+		expected string
+	}{
+		{`a:1 b:2 c`, `A`, nil, `'a':1A 'b':2A 'c'`},
+		{`a:1 b:2C`, `d`, nil, `'a':1 'b':2`},
+		{`a:1,3 b:2 c:4`, `b`, []string{`a`, `c`, `z`}, `'a':1B,3B 'b':2 'c':4B`},
+		{`a:1 b:2`, `C`, []string{}, `'a':1 'b':2`},
+	}
+	for _, tc := range tcs {
+		t.Log(tc.input)
+		vec, err := ParseTSVector(tc.input)
+		require.NoError(t, err)
+		actual, err := SetWeight(vec, tc.weight, tc.lexemes)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, actual.String())
+	}
+
+	vec, err := ParseTSVector(`a:1`)
+	require.NoError(t, err)
+	_, err = SetWeight(vec, `E`, nil)
+	assert.Error(t, err)
+}
+
+func TestDocumentsToTSVector(t *testing.T) {
+	tcs := []struct {
+		config   string
+		inputs   []string
+		expected string
+	}{
+		{`english`, []string{`The Fat Rats`, `dog`}, `'dog':5 'fat':2 'rat':3`},
+		{`simple`, []string{``, `a cat`}, `'a':1 'cat':2`},
+		{`english`, []string{`the`, `cat`}, `'cat':2`},
+		{`simple`, []string{`a b`, `b a`}, `'a':1,5 'b':2,4`},
+		{`simple`, nil, ``},
+	}
+	for _, tc := range tcs {
+		t.Log(tc.inputs)
+		config, err := BuiltinConfig(tc.config)
+		require.NoError(t, err)
+		actual, err := DocumentsToTSVector(config, tc.inputs)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, actual.String())
+	}
+}